@@ -0,0 +1,397 @@
+// Package broker defines the transport between the interactions server and
+// agent listeners: the server publishes interaction envelopes, agents
+// subscribe to their own stream, and a registry tracks live agents. Backends
+// that implement EventBus also carry gateway events on per-type channels.
+//
+// Redis pub/sub is the default backend. Other backends register a Factory
+// with Register and are selected by name through Open:
+//
+//	func init() {
+//		broker.Register("nats", func(ctx context.Context, cfg broker.Config) (broker.Broker, error) {
+//			return newNATSBroker(ctx, cfg)
+//		})
+//	}
+package broker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBackend is used when Config.Backend is empty.
+	DefaultBackend = "redis"
+	// DefaultPrefix namespaces channels and registry keys.
+	DefaultPrefix = "arc:discord"
+	// DefaultRegistryTTL is how long a registry entry lives without a heartbeat.
+	DefaultRegistryTTL = 2 * time.Minute
+	// DefaultHeartbeatInterval is how often agents refresh their entry.
+	DefaultHeartbeatInterval = 30 * time.Second
+	// EventKind is the Envelope.Kind of gateway events published with
+	// PublishEvent.
+	EventKind = "event"
+	// EnvelopeVersion is the envelope schema publishers write.
+	EnvelopeVersion = 1
+)
+
+// Envelope wraps an interaction routed to an agent. It travels as JSON.
+//
+// Version 1 has agent, kind, key, interaction, received_at,
+// timeout_seconds, and source, plus the optional ephemeral, captures,
+// request_id, event, id, attempt, and reply_queue. Optional fields are added without a version bump,
+// so decoders must ignore fields they don't know; the version changes only
+// when an existing field changes meaning. Envelopes written before
+// versioning carry no version and are version 1.
+type Envelope struct {
+	// Version is the schema version; see EnvelopeVersion.
+	Version        int             `json:"version,omitempty"`
+	Agent          string          `json:"agent"`
+	Kind           string          `json:"kind"`
+	Key            string          `json:"key"`
+	Interaction    json.RawMessage `json:"interaction"`
+	ReceivedAt     time.Time       `json:"received_at"`
+	TimeoutSeconds int             `json:"timeout_seconds"`
+	Source         string          `json:"source"`
+	// Ephemeral is set when the server deferred the interaction ephemerally;
+	// followups should carry the ephemeral flag too.
+	Ephemeral bool `json:"ephemeral,omitempty"`
+	// Captures holds the parts of the custom ID matched by a wildcard or
+	// regex handler key, e.g. ["PR-1234"] for approve:* and approve:PR-1234.
+	Captures []string `json:"captures,omitempty"`
+	// RequestID is the server's access log ID for the HTTP request that
+	// carried the interaction.
+	RequestID string `json:"request_id,omitempty"`
+	// Event holds the data of a gateway event. Such envelopes have Kind
+	// EventKind, the event type (MESSAGE_CREATE) as Key, and no Agent.
+	Event json.RawMessage `json:"event,omitempty"`
+	// ID identifies the envelope when the publisher waits for an Ack;
+	// Attempt counts its deliveries from 1, so agents can spot redeliveries.
+	ID      string `json:"id,omitempty"`
+	Attempt int    `json:"attempt,omitempty"`
+	// ReplyQueue is set when the server delivers responses itself: agents
+	// publish a Response with PublishResponse instead of calling Discord.
+	ReplyQueue bool `json:"reply_queue,omitempty"`
+}
+
+// Message is a delivered envelope. Payload holds the encoded envelope exactly
+// as published; ID identifies the delivery for Ack on backends that track it.
+type Message struct {
+	ID      string
+	Agent   string
+	Payload []byte
+}
+
+// Envelope decodes the message payload with DecodeEnvelope.
+func (m *Message) Envelope() (*Envelope, error) {
+	return DecodeEnvelope(m.Payload)
+}
+
+// gzipMagic starts gzip-compressed payloads.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// EnvelopeJSON returns the JSON of an encoded envelope, inflating it first
+// when it is gzip-compressed, so agents keep working if publishers start
+// compressing envelopes.
+func EnvelopeJSON(payload []byte) ([]byte, error) {
+	if !bytes.HasPrefix(payload, gzipMagic) {
+		return payload, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("decompress envelope: %w", err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress envelope: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeEnvelope decodes an envelope of any version. Unknown fields are
+// ignored, compressed payloads are inflated, and unversioned envelopes are
+// reported as version 1. Callers compare Version with EnvelopeVersion to
+// notice envelopes from a newer publisher.
+func DecodeEnvelope(payload []byte) (*Envelope, error) {
+	data, err := EnvelopeJSON(payload)
+	if err != nil {
+		return nil, err
+	}
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("decode envelope: %w", err)
+	}
+	if env.Version == 0 {
+		env.Version = 1
+	}
+	return &env, nil
+}
+
+// Handler processes a delivered message. Returning an error stops Subscribe.
+type Handler func(ctx context.Context, msg *Message) error
+
+// AgentInfo is a registry entry describing a running agent.
+type AgentInfo struct {
+	Agent        string    `json:"agent"`
+	Capabilities []string  `json:"capabilities,omitempty"`
+	Channels     []string  `json:"channels,omitempty"`
+	Hostname     string    `json:"hostname,omitempty"`
+	ProcessID    int       `json:"process_id,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	Commit       string    `json:"commit,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Publisher publishes envelopes to the agent named in Envelope.Agent.
+type Publisher interface {
+	Publish(ctx context.Context, env *Envelope) error
+	Close() error
+}
+
+// EventBus is implemented by backends that carry gateway events: envelopes
+// published by type to EventChannel, which any number of agents subscribe to.
+type EventBus interface {
+	PublishEvent(ctx context.Context, env *Envelope) error
+	// SubscribeEvents delivers events of the given types to handler until
+	// ctx is done.
+	SubscribeEvents(ctx context.Context, eventTypes []string, handler Handler) error
+}
+
+// Ack confirms that Agent processed the envelope with ID.
+type Ack struct {
+	ID    string    `json:"id"`
+	Agent string    `json:"agent"`
+	At    time.Time `json:"at"`
+}
+
+// AckHandler processes an acknowledgement.
+type AckHandler func(ctx context.Context, ack Ack) error
+
+// AckBus is implemented by backends that report acknowledgements back to
+// the publisher: Broker.Ack on a message whose envelope has an ID publishes
+// an Ack to AckChannel.
+type AckBus interface {
+	// SubscribeAcks delivers acknowledgements to handler until ctx is done.
+	SubscribeAcks(ctx context.Context, handler AckHandler) error
+}
+
+// Response is an agent's reply to an interaction, published for the server
+// to deliver. Message is the JSON message body (content, embeds,
+// components, allowed_mentions, flags); Files are attached to it.
+type Response struct {
+	InteractionID string `json:"interaction_id"`
+	Agent         string `json:"agent"`
+	// Followup posts a new followup message instead of editing the
+	// original response.
+	Followup bool            `json:"followup,omitempty"`
+	Message  json.RawMessage `json:"message"`
+	Files    []ResponseFile  `json:"files,omitempty"`
+}
+
+// ResponseFile is a file attached to a Response. Data is base64 in JSON.
+type ResponseFile struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type,omitempty"`
+	Data        []byte `json:"data"`
+}
+
+// ResponseHandler processes a published response.
+type ResponseHandler func(ctx context.Context, resp *Response) error
+
+// ResponseBus is implemented by backends that carry agent responses back to
+// the server on ResponseChannel.
+type ResponseBus interface {
+	PublishResponse(ctx context.Context, resp *Response) error
+	// SubscribeResponses delivers the responses for every interaction to
+	// handler until ctx is done.
+	SubscribeResponses(ctx context.Context, handler ResponseHandler) error
+}
+
+// DefaultStateTTL matches the 15-minute lifetime of an interaction token.
+const DefaultStateTTL = 15 * time.Minute
+
+// StateStore is implemented by backends that keep JSON state per
+// interaction, so the steps of a flow (command, button, modal) can share
+// context. Keys are chosen by the caller, typically the first interaction's
+// ID or token.
+type StateStore interface {
+	// SetState stores value under field and renews the key's TTL; ttl <= 0
+	// uses DefaultStateTTL.
+	SetState(ctx context.Context, key, field string, value json.RawMessage, ttl time.Duration) error
+	// GetState returns every field stored for key, or an empty map when the
+	// key is unknown or has expired.
+	GetState(ctx context.Context, key string) (map[string]json.RawMessage, error)
+}
+
+func checkState(key, field string, value json.RawMessage) error {
+	if strings.TrimSpace(key) == "" {
+		return errors.New("state key is required")
+	}
+	if strings.TrimSpace(field) == "" {
+		return errors.New("state field is required")
+	}
+	if !json.Valid(value) {
+		return fmt.Errorf("state field %s is not valid JSON", field)
+	}
+	return nil
+}
+
+func encodeResponse(resp *Response) ([]byte, error) {
+	if resp == nil {
+		return nil, errors.New("missing response")
+	}
+	if strings.TrimSpace(resp.InteractionID) == "" {
+		return nil, errors.New("response missing interaction_id")
+	}
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("encode response: %w", err)
+	}
+	return payload, nil
+}
+
+// ackFor returns the Ack for a processed message, or nil when its envelope
+// has no ID and nobody waits for it.
+func ackFor(msg *Message) *Ack {
+	if msg == nil {
+		return nil
+	}
+	env, err := DecodeEnvelope(msg.Payload)
+	if err != nil || env.ID == "" {
+		return nil
+	}
+	return &Ack{ID: env.ID, Agent: env.Agent, At: time.Now().UTC()}
+}
+
+// Pinger is implemented by backends that can check their connection, such
+// as Redis. Health checks treat backends without it as always reachable.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Registry tracks live agents. Entries expire unless refreshed by Heartbeat.
+type Registry interface {
+	Register(ctx context.Context, info AgentInfo) error
+	Heartbeat(ctx context.Context, info AgentInfo, interval time.Duration) error
+	Unregister(ctx context.Context, agent string) error
+	List(ctx context.Context) ([]AgentInfo, error)
+}
+
+// Broker is the full transport used by the server and agents.
+type Broker interface {
+	Publisher
+	// Subscribe delivers messages for agent to handler until ctx is done.
+	Subscribe(ctx context.Context, agent string, handler Handler) error
+	// Ack confirms a message was processed. Backends implementing AckBus
+	// pass it on to the publisher when the envelope has an ID; otherwise it
+	// is a no-op.
+	Ack(ctx context.Context, msg *Message) error
+	Registry() Registry
+}
+
+// Config selects and configures a backend. Addr, Username, Password, DB, and
+// TLS are interpreted by the backend; Options carries backend-specific
+// settings. A nil TLS connects in plaintext.
+type Config struct {
+	Backend     string
+	Addr        string
+	Username    string
+	Password    string
+	DB          int
+	TLS         *tls.Config
+	Prefix      string
+	RegistryTTL time.Duration
+	Options     map[string]string
+
+	// PublishBatch pipelines publishes on the Redis backend; zero disables.
+	PublishBatch BatchConfig
+}
+
+func (c Config) prefix() string {
+	if strings.TrimSpace(c.Prefix) == "" {
+		return DefaultPrefix
+	}
+	return c.Prefix
+}
+
+// AgentChannel returns the channel name an agent subscribes to.
+func (c Config) AgentChannel(agent string) string {
+	return fmt.Sprintf("%s:agent:%s", c.prefix(), strings.ToLower(agent))
+}
+
+// EventChannel returns the channel gateway events of eventType are published
+// to, e.g. arc:discord:event:message_create.
+func (c Config) EventChannel(eventType string) string {
+	return fmt.Sprintf("%s:event:%s", c.prefix(), strings.ToLower(eventType))
+}
+
+// AckChannel returns the channel acknowledgements are published to.
+func (c Config) AckChannel() string {
+	return c.prefix() + ":acks"
+}
+
+// ResponseChannel returns the channel agents publish the response to an
+// interaction on, e.g. arc:discord:response:1234.
+func (c Config) ResponseChannel(interactionID string) string {
+	return fmt.Sprintf("%s:response:%s", c.prefix(), interactionID)
+}
+
+// StateKey returns the key interaction state for key is stored under, e.g.
+// arc:discord:state:1234.
+func (c Config) StateKey(key string) string {
+	return fmt.Sprintf("%s:state:%s", c.prefix(), key)
+}
+
+// Factory constructs a broker for a backend.
+type Factory func(ctx context.Context, cfg Config) (Broker, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Factory{}
+)
+
+// Register makes a backend available to Open. Registering a name twice
+// replaces the earlier factory.
+func Register(name string, factory Factory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[strings.ToLower(name)] = factory
+}
+
+// Backends returns the registered backend names in sorted order.
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open connects to the backend named by cfg.Backend (default redis).
+func Open(ctx context.Context, cfg Config) (Broker, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.Backend))
+	if name == "" {
+		name = DefaultBackend
+	}
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown broker backend %q (available: %s)", name, strings.Join(Backends(), ", "))
+	}
+	return factory(ctx, cfg)
+}
@@ -0,0 +1,76 @@
+package broker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOpenUnknownBackend(t *testing.T) {
+	_, err := Open(context.Background(), Config{Backend: "carrier-pigeon"})
+	if err == nil || !strings.Contains(err.Error(), "carrier-pigeon") {
+		t.Fatalf("expected unknown backend error, got %v", err)
+	}
+}
+
+func TestRegisterBackend(t *testing.T) {
+	Register("test-memory", func(ctx context.Context, cfg Config) (Broker, error) { return nil, nil })
+	found := false
+	for _, name := range Backends() {
+		if name == "test-memory" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected test-memory in %v", Backends())
+	}
+	if _, err := Open(context.Background(), Config{Backend: "TEST-MEMORY"}); err != nil {
+		t.Fatalf("open registered backend: %v", err)
+	}
+}
+
+func TestAgentChannel(t *testing.T) {
+	if got := (Config{}).AgentChannel("Claude"); got != "arc:discord:agent:claude" {
+		t.Fatalf("unexpected default channel %s", got)
+	}
+	if got := (Config{Prefix: "team"}).AgentChannel("zed"); got != "team:agent:zed" {
+		t.Fatalf("unexpected channel %s", got)
+	}
+}
+
+func TestEventChannel(t *testing.T) {
+	if got := (Config{}).EventChannel("MESSAGE_CREATE"); got != "arc:discord:event:message_create" {
+		t.Fatalf("unexpected default channel %s", got)
+	}
+	if got := (Config{Prefix: "team"}).EventChannel("GUILD_MEMBER_ADD"); got != "team:event:guild_member_add" {
+		t.Fatalf("unexpected channel %s", got)
+	}
+}
+
+func TestDecodeEnvelopeIsTolerant(t *testing.T) {
+	env, err := DecodeEnvelope([]byte(`{"agent":"ops","kind":"command","key":"deploy","priority":"high"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Version != 1 || env.Agent != "ops" || env.Key != "deploy" {
+		t.Fatalf("unexpected envelope %+v", env)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(`{"version":2,"agent":"ops","kind":"command","key":"deploy"}`))
+	zw.Close()
+	msg := &Message{Payload: buf.Bytes()}
+	env, err = msg.Envelope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Version != 2 || env.Agent != "ops" {
+		t.Fatalf("unexpected compressed envelope %+v", env)
+	}
+	if _, err := DecodeEnvelope([]byte{0x1f, 0x8b, 0x00}); err == nil {
+		t.Fatal("expected error for a truncated gzip payload")
+	}
+}
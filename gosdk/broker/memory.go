@@ -0,0 +1,321 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryBackend is the registered name of the in-process broker.
+const MemoryBackend = "memory"
+
+const memoryQueueSize = 64
+
+func init() {
+	Register(MemoryBackend, func(ctx context.Context, cfg Config) (Broker, error) {
+		return NewMemory(), nil
+	})
+}
+
+// Memory is an in-process Broker for tests and single-binary setups. Like
+// Redis pub/sub, messages published while no subscriber is attached are
+// dropped.
+type Memory struct {
+	mu       sync.Mutex
+	subs     map[string][]chan *Message
+	registry *MemoryRegistry
+	closed   chan struct{}
+	once     sync.Once
+	state    map[string]*memoryState
+	// now is the clock for state expiry.
+	now func() time.Time
+}
+
+type memoryState struct {
+	fields  map[string]json.RawMessage
+	expires time.Time
+}
+
+// NewMemory returns an empty in-process broker.
+func NewMemory() *Memory {
+	return &Memory{
+		subs:     map[string][]chan *Message{},
+		registry: &MemoryRegistry{agents: map[string]AgentInfo{}},
+		closed:   make(chan struct{}),
+		state:    map[string]*memoryState{},
+		now:      time.Now,
+	}
+}
+
+func (m *Memory) Publish(ctx context.Context, env *Envelope) error {
+	if env == nil {
+		return errors.New("missing envelope")
+	}
+	agent := strings.ToLower(strings.TrimSpace(env.Agent))
+	if agent == "" {
+		return errors.New("envelope missing agent")
+	}
+	return m.deliver(agent, agent, env)
+}
+
+// PublishEvent delivers a gateway event envelope to the subscribers of its
+// type (env.Key).
+func (m *Memory) PublishEvent(ctx context.Context, env *Envelope) error {
+	if env == nil {
+		return errors.New("missing envelope")
+	}
+	if strings.TrimSpace(env.Key) == "" {
+		return errors.New("event envelope missing type")
+	}
+	return m.deliver(memoryEventKey(env.Key), "", env)
+}
+
+// memoryEventKey keys event subscriptions apart from agent names.
+func memoryEventKey(eventType string) string {
+	return "\x00event:" + strings.ToLower(strings.TrimSpace(eventType))
+}
+
+// memoryAckKey keys acknowledgement subscriptions.
+const memoryAckKey = "\x00acks"
+
+func (m *Memory) deliver(key, agent string, env *Envelope) error {
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("encode envelope: %w", err)
+	}
+	return m.deliverPayload(key, agent, payload)
+}
+
+func (m *Memory) deliverPayload(key, agent string, payload []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, ch := range m.subs[key] {
+		select {
+		case ch <- &Message{Agent: agent, Payload: payload}:
+		default:
+			return fmt.Errorf("subscriber queue for %s is full", key)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Subscribe(ctx context.Context, agent string, handler Handler) error {
+	return m.receive(ctx, []string{strings.ToLower(agent)}, handler)
+}
+
+// SubscribeEvents delivers gateway events of the given types.
+func (m *Memory) SubscribeEvents(ctx context.Context, eventTypes []string, handler Handler) error {
+	if len(eventTypes) == 0 {
+		return errors.New("no event types to subscribe to")
+	}
+	keys := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		keys = append(keys, memoryEventKey(eventType))
+	}
+	return m.receive(ctx, keys, handler)
+}
+
+func (m *Memory) receive(ctx context.Context, keys []string, handler Handler) error {
+	ch := make(chan *Message, memoryQueueSize)
+	m.mu.Lock()
+	for _, key := range keys {
+		m.subs[key] = append(m.subs[key], ch)
+	}
+	m.mu.Unlock()
+	for _, key := range keys {
+		defer m.unsubscribe(key, ch)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-m.closed:
+			return nil
+		case msg := <-ch:
+			if handler == nil {
+				continue
+			}
+			if err := handler(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (m *Memory) unsubscribe(agent string, ch chan *Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subs := m.subs[agent]
+	for i, existing := range subs {
+		if existing == ch {
+			m.subs[agent] = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(m.subs[agent]) == 0 {
+		delete(m.subs, agent)
+	}
+}
+
+// Subscribers reports how many subscriptions are attached for agent.
+func (m *Memory) Subscribers(agent string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.subs[strings.ToLower(agent)])
+}
+
+// Ack delivers an acknowledgement to SubscribeAcks when the envelope has an
+// ID.
+func (m *Memory) Ack(ctx context.Context, msg *Message) error {
+	ack := ackFor(msg)
+	if ack == nil {
+		return nil
+	}
+	payload, err := json.Marshal(ack)
+	if err != nil {
+		return fmt.Errorf("encode ack: %w", err)
+	}
+	return m.deliverPayload(memoryAckKey, "", payload)
+}
+
+// SubscribeAcks delivers acknowledgements until ctx is done.
+func (m *Memory) SubscribeAcks(ctx context.Context, handler AckHandler) error {
+	return m.receive(ctx, []string{memoryAckKey}, func(ctx context.Context, msg *Message) error {
+		var ack Ack
+		if err := json.Unmarshal(msg.Payload, &ack); err != nil {
+			return nil
+		}
+		return handler(ctx, ack)
+	})
+}
+
+// memoryResponseKey keys response subscriptions.
+const memoryResponseKey = "\x00responses"
+
+// PublishResponse delivers an agent's response to SubscribeResponses.
+func (m *Memory) PublishResponse(ctx context.Context, resp *Response) error {
+	payload, err := encodeResponse(resp)
+	if err != nil {
+		return err
+	}
+	return m.deliverPayload(memoryResponseKey, resp.Agent, payload)
+}
+
+// SubscribeResponses delivers responses until ctx is done.
+func (m *Memory) SubscribeResponses(ctx context.Context, handler ResponseHandler) error {
+	return m.receive(ctx, []string{memoryResponseKey}, func(ctx context.Context, msg *Message) error {
+		var resp Response
+		if err := json.Unmarshal(msg.Payload, &resp); err != nil {
+			return nil
+		}
+		return handler(ctx, &resp)
+	})
+}
+
+// SetState stores interaction state in process, expiring it after ttl.
+func (m *Memory) SetState(ctx context.Context, key, field string, value json.RawMessage, ttl time.Duration) error {
+	if err := checkState(key, field, value); err != nil {
+		return err
+	}
+	if ttl <= 0 {
+		ttl = DefaultStateTTL
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	entry, ok := m.state[key]
+	if !ok || !now.Before(entry.expires) {
+		entry = &memoryState{fields: map[string]json.RawMessage{}}
+		m.state[key] = entry
+	}
+	entry.fields[field] = append(json.RawMessage(nil), value...)
+	entry.expires = now.Add(ttl)
+	return nil
+}
+
+// GetState returns the fields stored for key in a new map, empty once the key
+// has expired.
+func (m *Memory) GetState(ctx context.Context, key string) (map[string]json.RawMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := map[string]json.RawMessage{}
+	entry, ok := m.state[key]
+	if !ok {
+		return state, nil
+	}
+	if !m.now().Before(entry.expires) {
+		delete(m.state, key)
+		return state, nil
+	}
+	for field, value := range entry.fields {
+		state[field] = value
+	}
+	return state, nil
+}
+
+func (m *Memory) Registry() Registry {
+	return m.registry
+}
+
+func (m *Memory) Close() error {
+	m.once.Do(func() { close(m.closed) })
+	return nil
+}
+
+// MemoryRegistry keeps agent entries in process. Entries do not expire.
+type MemoryRegistry struct {
+	mu     sync.Mutex
+	agents map[string]AgentInfo
+}
+
+func (r *MemoryRegistry) Register(ctx context.Context, info AgentInfo) error {
+	if strings.TrimSpace(info.Agent) == "" {
+		return fmt.Errorf("agent is required for registry entry")
+	}
+	info.UpdatedAt = time.Now().UTC()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agents[strings.ToLower(info.Agent)] = info
+	return nil
+}
+
+func (r *MemoryRegistry) Heartbeat(ctx context.Context, info AgentInfo, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultHeartbeatInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_ = r.Register(ctx, info)
+		}
+	}
+}
+
+func (r *MemoryRegistry) Unregister(ctx context.Context, agent string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.agents, strings.ToLower(agent))
+	return nil
+}
+
+// List returns the registered agents sorted by name.
+func (r *MemoryRegistry) List(ctx context.Context) ([]AgentInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	agents := make([]AgentInfo, 0, len(r.agents))
+	for _, info := range r.agents {
+		agents = append(agents, info)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Agent < agents[j].Agent })
+	return agents, nil
+}
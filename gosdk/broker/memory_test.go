@@ -0,0 +1,247 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryPublishSubscribe(t *testing.T) {
+	b, err := Open(context.Background(), Config{Backend: MemoryBackend})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	mem := b.(*Memory)
+	defer mem.Close()
+
+	if err := mem.Publish(context.Background(), &Envelope{Agent: "claude", Key: "dropped"}); err != nil {
+		t.Fatalf("publish without subscriber: %v", err)
+	}
+
+	got := make(chan *Envelope, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- mem.Subscribe(ctx, "Claude", func(ctx context.Context, msg *Message) error {
+			env, err := msg.Envelope()
+			if err != nil {
+				return err
+			}
+			got <- env
+			return nil
+		})
+	}()
+	waitForSubscriber(t, mem, "claude")
+
+	if err := mem.Publish(context.Background(), &Envelope{Agent: "CLAUDE", Key: "help"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case env := <-got:
+		if env.Key != "help" {
+			t.Fatalf("unexpected envelope %+v", env)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if mem.Subscribers("claude") != 0 {
+		t.Fatal("expected subscription to be removed")
+	}
+}
+
+func TestMemorySubscribePropagatesHandlerError(t *testing.T) {
+	mem := NewMemory()
+	want := errors.New("boom")
+	done := make(chan error, 1)
+	go func() {
+		done <- mem.Subscribe(context.Background(), "zed", func(context.Context, *Message) error { return want })
+	}()
+	waitForSubscriber(t, mem, "zed")
+	if err := mem.Publish(context.Background(), &Envelope{Agent: "zed"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := <-done; !errors.Is(err, want) {
+		t.Fatalf("expected %v, got %v", want, err)
+	}
+}
+
+func TestMemoryEventsReachEverySubscriber(t *testing.T) {
+	mem := NewMemory()
+	defer mem.Close()
+
+	got := make(chan string, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		go mem.SubscribeEvents(ctx, []string{"MESSAGE_CREATE"}, func(ctx context.Context, msg *Message) error {
+			env, err := msg.Envelope()
+			if err != nil {
+				return err
+			}
+			got <- string(env.Event)
+			return nil
+		})
+	}
+	deadline := time.Now().Add(time.Second)
+	for mem.Subscribers(memoryEventKey("MESSAGE_CREATE")) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("event subscribers never attached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := mem.PublishEvent(context.Background(), &Envelope{Kind: EventKind, Key: "GUILD_MEMBER_ADD", Event: []byte(`{}`)}); err != nil {
+		t.Fatalf("publish other type: %v", err)
+	}
+	if err := mem.PublishEvent(context.Background(), &Envelope{Kind: EventKind, Key: "message_create", Event: []byte(`{"id":"1"}`)}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-got:
+			if event != `{"id":"1"}` {
+				t.Fatalf("unexpected event %s", event)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("event not delivered to every subscriber")
+		}
+	}
+	if mem.Subscribers("message_create") != 0 {
+		t.Fatal("event subscriptions must not be visible as an agent")
+	}
+}
+
+func TestMemoryRegistry(t *testing.T) {
+	reg := NewMemory().Registry()
+	ctx := context.Background()
+	if err := reg.Register(ctx, AgentInfo{}); err == nil {
+		t.Fatal("expected error when agent missing")
+	}
+	_ = reg.Register(ctx, AgentInfo{Agent: "zed"})
+	_ = reg.Register(ctx, AgentInfo{Agent: "claude"})
+	agents, _ := reg.List(ctx)
+	if len(agents) != 2 || agents[0].Agent != "claude" || agents[0].UpdatedAt.IsZero() {
+		t.Fatalf("unexpected agents %#v", agents)
+	}
+	_ = reg.Unregister(ctx, "ZED")
+	if agents, _ := reg.List(ctx); len(agents) != 1 {
+		t.Fatalf("expected one agent after unregister, got %#v", agents)
+	}
+}
+
+func waitForSubscriber(t *testing.T, mem *Memory, agent string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for mem.Subscribers(agent) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("subscriber for %s never attached", agent)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMemoryAckReachesAckSubscribers(t *testing.T) {
+	mem := NewMemory()
+	defer mem.Close()
+
+	got := make(chan Ack, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go mem.SubscribeAcks(ctx, func(ctx context.Context, ack Ack) error {
+		got <- ack
+		return nil
+	})
+	deadline := time.Now().Add(time.Second)
+	for mem.Subscribers(memoryAckKey) < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("ack subscriber never attached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := mem.Ack(ctx, &Message{Payload: []byte(`{"agent":"ops"}`)}); err != nil {
+		t.Fatalf("ack without id: %v", err)
+	}
+	if err := mem.Ack(ctx, &Message{Payload: []byte(`{"agent":"ops","id":"d1"}`)}); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	select {
+	case ack := <-got:
+		if ack.ID != "d1" || ack.Agent != "ops" || ack.At.IsZero() {
+			t.Fatalf("unexpected ack %+v", ack)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ack was not delivered")
+	}
+}
+
+func TestMemoryResponsesReachResponseSubscribers(t *testing.T) {
+	mem := NewMemory()
+	defer mem.Close()
+
+	got := make(chan *Response, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go mem.SubscribeResponses(ctx, func(ctx context.Context, resp *Response) error {
+		got <- resp
+		return nil
+	})
+	deadline := time.Now().Add(time.Second)
+	for mem.Subscribers(memoryResponseKey) < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("response subscriber never attached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := mem.PublishResponse(ctx, &Response{Agent: "ops"}); err == nil {
+		t.Fatal("expected an error for a response without interaction_id")
+	}
+	resp := &Response{InteractionID: "42", Agent: "ops", Message: json.RawMessage(`{"content":"done"}`), Files: []ResponseFile{{Name: "a.txt", Data: []byte("hi")}}}
+	if err := mem.PublishResponse(ctx, resp); err != nil {
+		t.Fatalf("publish response: %v", err)
+	}
+	select {
+	case resp := <-got:
+		if resp.InteractionID != "42" || string(resp.Message) != `{"content":"done"}` || len(resp.Files) != 1 || string(resp.Files[0].Data) != "hi" {
+			t.Fatalf("unexpected response %+v", resp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("response was not delivered")
+	}
+}
+
+func TestMemoryStateExpires(t *testing.T) {
+	mem := NewMemory()
+	defer mem.Close()
+	now := time.Unix(1700000000, 0)
+	mem.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := mem.SetState(ctx, "42", "step", json.RawMessage(`not json`), 0); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+	if err := mem.SetState(ctx, "42", "env", json.RawMessage(`"staging"`), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.SetState(ctx, "42", "step", json.RawMessage(`{"n":2}`), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	state, err := mem.GetState(ctx, "42")
+	if err != nil || len(state) != 2 || string(state["env"]) != `"staging"` || string(state["step"]) != `{"n":2}` {
+		t.Fatalf("unexpected state %v, %v", state, err)
+	}
+
+	// The last set's TTL applies to the whole key.
+	now = now.Add(time.Minute)
+	if state, _ := mem.GetState(ctx, "42"); len(state) != 0 {
+		t.Fatalf("expected expired state, got %v", state)
+	}
+}
@@ -0,0 +1,191 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrPublisherClosed is returned by Publish after Close.
+var ErrPublisherClosed = errors.New("publisher closed")
+
+// BatchConfig enables pipelined publishing on the Redis backend. Publish
+// still returns each envelope's own result; envelopes queued while a
+// pipeline is in flight, or within Interval of the first one, share the next
+// round trip. Size <= 1 disables batching.
+type BatchConfig struct {
+	// Size caps the envelopes sent in one pipeline.
+	Size int
+	// Interval is how long to wait for more envelopes once one is queued.
+	// Zero flushes whatever is queued immediately, which still coalesces
+	// bursts that arrive during the previous round trip.
+	Interval time.Duration
+}
+
+func (c BatchConfig) enabled() bool {
+	return c.Size > 1
+}
+
+type publishRequest struct {
+	channel string
+	payload []byte
+	done    chan error
+}
+
+// publishBatcher collects publish requests and hands them to exec in
+// batches from a single goroutine.
+type publishBatcher struct {
+	cfg   BatchConfig
+	exec  func(ctx context.Context, batch []publishRequest) []error
+	queue chan publishRequest
+
+	mu     sync.RWMutex
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func newPublishBatcher(cfg BatchConfig, exec func(ctx context.Context, batch []publishRequest) []error) *publishBatcher {
+	b := &publishBatcher{
+		cfg:   cfg,
+		exec:  exec,
+		queue: make(chan publishRequest, cfg.Size),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// publish queues one envelope and waits for the pipeline that carries it.
+func (b *publishBatcher) publish(ctx context.Context, channel string, payload []byte) error {
+	req := publishRequest{channel: channel, payload: payload, done: make(chan error, 1)}
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrPublisherClosed
+	}
+	select {
+	case b.queue <- req:
+		b.mu.RUnlock()
+	case <-ctx.Done():
+		b.mu.RUnlock()
+		return ctx.Err()
+	}
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *publishBatcher) run() {
+	defer close(b.done)
+	for {
+		var first publishRequest
+		select {
+		case first = <-b.queue:
+		case <-b.stop:
+			b.drain()
+			return
+		}
+		b.flush(b.collect(first))
+	}
+}
+
+// collect gathers up to Size requests, lingering for Interval when set.
+func (b *publishBatcher) collect(first publishRequest) []publishRequest {
+	batch := append(make([]publishRequest, 0, b.cfg.Size), first)
+	var linger <-chan time.Time
+	if b.cfg.Interval > 0 {
+		timer := time.NewTimer(b.cfg.Interval)
+		defer timer.Stop()
+		linger = timer.C
+	}
+	for len(batch) < b.cfg.Size {
+		if linger == nil {
+			select {
+			case req := <-b.queue:
+				batch = append(batch, req)
+				continue
+			default:
+				return batch
+			}
+		}
+		select {
+		case req := <-b.queue:
+			batch = append(batch, req)
+		case <-linger:
+			return batch
+		case <-b.stop:
+			return batch
+		}
+	}
+	return batch
+}
+
+// drain flushes requests queued before close; no new ones can arrive.
+func (b *publishBatcher) drain() {
+	for {
+		var batch []publishRequest
+	fill:
+		for len(batch) < b.cfg.Size {
+			select {
+			case req := <-b.queue:
+				batch = append(batch, req)
+			default:
+				break fill
+			}
+		}
+		if len(batch) == 0 {
+			return
+		}
+		b.flush(batch)
+	}
+}
+
+func (b *publishBatcher) flush(batch []publishRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	errs := b.exec(ctx, batch)
+	for i, req := range batch {
+		req.done <- errs[i]
+	}
+}
+
+// close stops accepting requests and waits for queued ones to be flushed.
+func (b *publishBatcher) close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	b.mu.Unlock()
+	close(b.stop)
+	<-b.done
+}
+
+// pipelinePublish sends a batch of PUBLISH commands in one round trip.
+func pipelinePublish(client *redis.Client) func(ctx context.Context, batch []publishRequest) []error {
+	return func(ctx context.Context, batch []publishRequest) []error {
+		pipe := client.Pipeline()
+		cmds := make([]*redis.IntCmd, len(batch))
+		for i, req := range batch {
+			cmds[i] = pipe.Publish(ctx, req.channel, req.payload)
+		}
+		_, _ = pipe.Exec(ctx)
+		errs := make([]error, len(batch))
+		for i, cmd := range cmds {
+			if err := cmd.Err(); err != nil {
+				errs[i] = fmt.Errorf("publish redis channel %s: %w", batch[i].channel, err)
+			}
+		}
+		return errs
+	}
+}
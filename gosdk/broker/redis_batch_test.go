@@ -0,0 +1,128 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingExec struct {
+	mu      sync.Mutex
+	batches [][]string
+	failOn  string
+	gate    chan struct{}
+}
+
+func (r *recordingExec) exec(ctx context.Context, batch []publishRequest) []error {
+	if r.gate != nil {
+		<-r.gate
+	}
+	channels := make([]string, len(batch))
+	errs := make([]error, len(batch))
+	for i, req := range batch {
+		channels[i] = req.channel
+		if req.channel == r.failOn {
+			errs[i] = errors.New("boom")
+		}
+	}
+	r.mu.Lock()
+	r.batches = append(r.batches, channels)
+	r.mu.Unlock()
+	return errs
+}
+
+func (r *recordingExec) snapshot() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]string(nil), r.batches...)
+}
+
+func TestPublishBatcherCoalescesConcurrentPublishes(t *testing.T) {
+	rec := &recordingExec{failOn: "bad"}
+	b := newPublishBatcher(BatchConfig{Size: 10, Interval: 50 * time.Millisecond}, rec.exec)
+	defer b.close()
+
+	channels := []string{"a", "b", "bad", "c"}
+	errs := make([]error, len(channels))
+	var wg sync.WaitGroup
+	for i, channel := range channels {
+		wg.Add(1)
+		go func(i int, channel string) {
+			defer wg.Done()
+			errs[i] = b.publish(context.Background(), channel, []byte(channel))
+		}(i, channel)
+	}
+	wg.Wait()
+
+	for i, channel := range channels {
+		if (channel == "bad") != (errs[i] != nil) {
+			t.Fatalf("publish %s: unexpected error %v", channel, errs[i])
+		}
+	}
+	if batches := rec.snapshot(); len(batches) != 1 || len(batches[0]) != len(channels) {
+		t.Fatalf("expected one pipeline of %d, got %v", len(channels), batches)
+	}
+}
+
+func TestPublishBatcherCapsBatchSize(t *testing.T) {
+	gate := make(chan struct{})
+	rec := &recordingExec{gate: gate}
+	b := newPublishBatcher(BatchConfig{Size: 2}, rec.exec)
+	defer b.close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.publish(context.Background(), "ch", nil); err != nil {
+				t.Errorf("publish: %v", err)
+			}
+		}()
+	}
+	// Let the queue fill while the first pipeline is held open.
+	time.Sleep(20 * time.Millisecond)
+	close(gate)
+	wg.Wait()
+
+	total := 0
+	for _, batch := range rec.snapshot() {
+		if len(batch) > 2 {
+			t.Fatalf("batch exceeds size: %v", batch)
+		}
+		total += len(batch)
+	}
+	if total != 5 {
+		t.Fatalf("expected 5 publishes, got %d", total)
+	}
+}
+
+func TestPublishBatcherCloseDrainsQueue(t *testing.T) {
+	gate := make(chan struct{})
+	rec := &recordingExec{gate: gate}
+	b := newPublishBatcher(BatchConfig{Size: 4, Interval: time.Hour}, rec.exec)
+
+	results := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() { results <- b.publish(context.Background(), "ch", nil) }()
+	}
+	time.Sleep(20 * time.Millisecond)
+	closed := make(chan struct{})
+	go func() {
+		b.close()
+		close(closed)
+	}()
+	close(gate)
+	for i := 0; i < 3; i++ {
+		if err := <-results; err != nil {
+			t.Fatalf("queued publish failed: %v", err)
+		}
+	}
+	<-closed
+
+	if err := b.publish(context.Background(), "ch", nil); !errors.Is(err, ErrPublisherClosed) {
+		t.Fatalf("expected ErrPublisherClosed, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -13,6 +14,7 @@ type Config struct {
 	Discord      DiscordConfig             `yaml:"discord"`
 	Client       ClientConfig              `yaml:"client"`
 	Logging      LoggingConfig             `yaml:"logging"`
+	Output       OutputConfig              `yaml:"output"`
 	Profiles     map[string]ProfileConfig  `yaml:"profiles"`
 	Environments map[string]EnvironmentSet `yaml:"environments"`
 }
@@ -31,20 +33,41 @@ type EnvironmentSet struct {
 // DiscordConfig contains Discord-specific configuration
 type DiscordConfig struct {
 	BotToken        string            `yaml:"bot_token"`
+	BotTokens       []string          `yaml:"bot_tokens,omitempty"` // fallbacks tried in order on 401
 	ApplicationID   string            `yaml:"application_id"`
 	DefaultGuildID  string            `yaml:"default_guild_id"`
 	DefaultChannelID string            `yaml:"default_channel_id"`
 	Webhooks        map[string]string `yaml:"webhooks"`
 }
 
+// Tokens returns the bot tokens in failover order: bot_token first, then
+// bot_tokens, without blanks or duplicates.
+func (d DiscordConfig) Tokens() []string {
+	var tokens []string
+	seen := map[string]bool{}
+	for _, token := range append([]string{d.BotToken}, d.BotTokens...) {
+		if strings.TrimSpace(token) == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
 // ClientConfig contains HTTP client configuration
 type ClientConfig struct {
 	Timeout           time.Duration   `yaml:"timeout"`
 	Retries           int             `yaml:"retries"`
 	RateLimit         RateLimitConfig `yaml:"rate_limit"`
 	RateLimitStrategy string          `yaml:"rate_limit_strategy,omitempty"` // legacy support
+	APIVersion        int             `yaml:"api_version"`
+	Features          map[string]bool `yaml:"features,omitempty"` // feature gate overrides by name
 }
 
+// DefaultAPIVersion is the Discord REST API version used when api_version is unset.
+const DefaultAPIVersion = 10
+
 // RateLimitConfig configures client-side rate limiting
 type RateLimitConfig struct {
 	Strategy    string        `yaml:"strategy"`
@@ -59,6 +82,12 @@ type LoggingConfig struct {
 	Output string `yaml:"output"`
 }
 
+// OutputConfig controls how the CLI renders human-readable values.
+type OutputConfig struct {
+	Locale   string `yaml:"locale"`   // Discord locale for dates and numbers, e.g. en-GB, de
+	Timezone string `yaml:"timezone"` // IANA zone for table timestamps (default: local)
+}
+
 // Load loads configuration from a YAML file
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
@@ -67,7 +96,7 @@ func Load(path string) (*Config, error) {
 	}
 
 	// Expand environment variables
-	expanded := os.ExpandEnv(string(data))
+	expanded := expandEnv(string(data))
 
 	var cfg Config
 	if err := yaml.Unmarshal([]byte(expanded), &cfg); err != nil {
@@ -81,6 +110,9 @@ func Load(path string) (*Config, error) {
 	if cfg.Client.Retries == 0 {
 		cfg.Client.Retries = 3
 	}
+	if cfg.Client.APIVersion == 0 {
+		cfg.Client.APIVersion = DefaultAPIVersion
+	}
 	applyRateLimitDefaults(&cfg.Client)
 	if cfg.Logging.Level == "" {
 		cfg.Logging.Level = "info"
@@ -92,6 +124,18 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// expandEnv is os.ExpandEnv, except that references with a scheme, such as
+// ${file:/run/secrets/token}, are not environment variables and are left for
+// the caller to resolve.
+func expandEnv(s string) string {
+	return os.Expand(s, func(name string) string {
+		if strings.Contains(name, ":") {
+			return "${" + name + "}"
+		}
+		return os.Getenv(name)
+	})
+}
+
 // Default returns a default configuration
 func Default() *Config {
 	return &Config{
@@ -105,8 +149,9 @@ func Default() *Config {
 			},
 		},
 		Client: ClientConfig{
-			Timeout: 30 * time.Second,
-			Retries: 3,
+			Timeout:    30 * time.Second,
+			Retries:    3,
+			APIVersion: DefaultAPIVersion,
 			RateLimit: RateLimitConfig{
 				Strategy:    getEnvOrDefault("DISCORD_RATE_LIMIT_STRATEGY", "adaptive"),
 				BackoffBase: time.Second,
@@ -71,3 +71,80 @@ client:
 		t.Fatalf("expected backoff max 10s, got %v", cfg.Client.RateLimit.BackoffMax)
 	}
 }
+
+func TestLoadAPIVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(`
+client:
+  timeout: 5s
+`), 0o600); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Client.APIVersion != DefaultAPIVersion {
+		t.Fatalf("expected default api version %d, got %d", DefaultAPIVersion, cfg.Client.APIVersion)
+	}
+
+	if err := os.WriteFile(path, []byte(`
+client:
+  api_version: 9
+  features:
+    forum_channels: false
+`), 0o600); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Client.APIVersion != 9 {
+		t.Fatalf("expected pinned api version 9, got %d", cfg.Client.APIVersion)
+	}
+	if enabled, ok := cfg.Client.Features["forum_channels"]; !ok || enabled {
+		t.Fatalf("expected forum_channels override false, got %v", cfg.Client.Features)
+	}
+}
+
+func TestLoadKeepsSchemeReferences(t *testing.T) {
+	t.Setenv("ARC_TEST_APP_ID", "42")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(`
+discord:
+  bot_token: ${file:/run/secrets/token}
+  application_id: ${ARC_TEST_APP_ID}
+`), 0o600); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Discord.BotToken != "${file:/run/secrets/token}" {
+		t.Fatalf("expected reference to survive, got %q", cfg.Discord.BotToken)
+	}
+	if cfg.Discord.ApplicationID != "42" {
+		t.Fatalf("expected env expansion, got %q", cfg.Discord.ApplicationID)
+	}
+}
+
+func TestDiscordTokensOrder(t *testing.T) {
+	d := DiscordConfig{BotToken: "primary", BotTokens: []string{"", "secondary", "primary", "third"}}
+	got := d.Tokens()
+	want := []string{"primary", "secondary", "third"}
+	if len(got) != len(want) {
+		t.Fatalf("Tokens() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Tokens() = %v, want %v", got, want)
+		}
+	}
+	if tokens := (DiscordConfig{BotTokens: []string{"only"}}).Tokens(); len(tokens) != 1 || tokens[0] != "only" {
+		t.Fatalf("bot_tokens alone = %v", tokens)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// Applications exposes helpers for the application that owns the bot token.
+type Applications struct {
+	client *Client
+}
+
+// Applications returns an application service bound to the client instance.
+func (c *Client) Applications() *Applications {
+	return &Applications{client: c}
+}
+
+// GetCurrentApplication returns the application the bot token belongs to.
+func (a *Applications) GetCurrentApplication(ctx context.Context) (*types.Application, error) {
+	var app types.Application
+	if err := a.client.Get(ctx, "/applications/@me", &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// EditCurrentApplication updates the application the bot token belongs to
+// and returns it as saved.
+func (a *Applications) EditCurrentApplication(ctx context.Context, params *types.ModifyApplicationParams) (*types.Application, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var app types.Application
+	if err := a.client.Patch(ctx, "/applications/@me", params, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// ListEntitlements returns one page of the application's entitlements,
+// filtered by params.
+func (a *Applications) ListEntitlements(ctx context.Context, applicationID string, params *types.ListEntitlementsParams) ([]*types.Entitlement, error) {
+	if err := validateID("applicationID", applicationID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if params != nil {
+		if params.UserID != "" {
+			query.Set("user_id", params.UserID)
+		}
+		if params.GuildID != "" {
+			query.Set("guild_id", params.GuildID)
+		}
+		if len(params.SKUIDs) > 0 {
+			query.Set("sku_ids", strings.Join(params.SKUIDs, ","))
+		}
+		if params.Before != "" {
+			query.Set("before", params.Before)
+		}
+		if params.After != "" {
+			query.Set("after", params.After)
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.ExcludeEnded {
+			query.Set("exclude_ended", "true")
+		}
+	}
+	path := fmt.Sprintf("/applications/%s/entitlements", applicationID)
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	var entitlements []*types.Entitlement
+	if err := a.client.Get(ctx, path, &entitlements); err != nil {
+		return nil, err
+	}
+	return entitlements, nil
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestApplicationsGetCurrentApplication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/@me" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "app-1", "name": "arc", "flags": 1 << 15})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	app, err := client.Applications().GetCurrentApplication(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentApplication error: %v", err)
+	}
+	if app.ID != "app-1" || app.Name != "arc" {
+		t.Fatalf("unexpected application %+v", app)
+	}
+	if app.Flags != types.ApplicationFlagGatewayGuildMembersLimited || !app.Flags.HasGuildMembersIntent() {
+		t.Fatal("expected limited members flag to count as enabled")
+	}
+	if app.Flags.HasMessageContentIntent() || app.Flags.HasPresenceIntent() {
+		t.Fatalf("unexpected intents in flags %d", app.Flags)
+	}
+}
+
+func TestApplicationsListEntitlements(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/app-1/entitlements" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("sku_ids") != "sku-1,sku-2" || q.Get("limit") != "100" || q.Get("exclude_ended") != "true" || q.Get("user_id") != "" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[{"id":"ent-1","sku_id":"sku-1","application_id":"app-1","user_id":"user-1","type":8,"ends_at":"2030-01-01T00:00:00Z"}]`))
+	}))
+	defer server.Close()
+
+	params := &types.ListEntitlementsParams{SKUIDs: []string{"sku-1", "sku-2"}, Limit: 100, ExcludeEnded: true}
+	entitlements, err := newTestClient(t, server.URL).Applications().ListEntitlements(context.Background(), "app-1", params)
+	if err != nil {
+		t.Fatalf("ListEntitlements error: %v", err)
+	}
+	if len(entitlements) != 1 || entitlements[0].Type != types.EntitlementTypeApplicationSubscription || entitlements[0].EndsAt == nil {
+		t.Fatalf("unexpected entitlements %+v", entitlements)
+	}
+}
+
+func TestApplicationsEditCurrentApplication(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/applications/@me" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]any{"id": "app-1", "interactions_endpoint_url": body["interactions_endpoint_url"]})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	endpoint := "https://bot.example.com/interactions"
+	description := ""
+	app, err := client.Applications().EditCurrentApplication(context.Background(), &types.ModifyApplicationParams{
+		InteractionsEndpointURL: &endpoint,
+		Description:             &description,
+	})
+	if err != nil {
+		t.Fatalf("EditCurrentApplication error: %v", err)
+	}
+	if app.InteractionsEndpointURL != endpoint {
+		t.Fatalf("unexpected application %+v", app)
+	}
+	if d, ok := body["description"]; !ok || d != "" {
+		t.Fatalf("expected an empty description to be sent to clear it, got %v", body)
+	}
+	if _, ok := body["tags"]; ok {
+		t.Fatalf("unset fields must be omitted, got %v", body)
+	}
+
+	if _, err := client.Applications().EditCurrentApplication(context.Background(), &types.ModifyApplicationParams{}); err == nil {
+		t.Fatal("expected an error for an empty edit")
+	}
+}
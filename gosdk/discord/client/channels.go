@@ -45,6 +45,16 @@ func (c *Channels) ModifyChannel(ctx context.Context, channelID string, params *
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	if params.DefaultAutoArchiveDuration != 0 || params.DefaultThreadRateLimitPerUser != 0 {
+		if err := c.client.requireFeature(FeatureThreads); err != nil {
+			return nil, err
+		}
+	}
+	if len(params.AvailableTags) > 0 || params.DefaultReaction != nil || params.DefaultSortOrder != "" {
+		if err := c.client.requireFeature(FeatureForumChannels); err != nil {
+			return nil, err
+		}
+	}
 
 	headers := http.Header{}
 	if params.AuditLogReason != "" {
@@ -66,6 +76,52 @@ func (c *Channels) DeleteChannel(ctx context.Context, channelID string) error {
 	return c.client.Delete(ctx, fmt.Sprintf("/channels/%s", channelID))
 }
 
+// StartThreadInForum creates a post in a forum or media channel (requires
+// SEND_MESSAGES in the channel).
+func (c *Channels) StartThreadInForum(ctx context.Context, channelID string, params *types.ForumThreadParams) (*types.ForumThread, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.client.requireFeature(FeatureForumChannels); err != nil {
+		return nil, err
+	}
+	var thread types.ForumThread
+	if err := c.client.do(ctx, http.MethodPost, fmt.Sprintf("/channels/%s/threads", channelID), params, &thread, auditLogHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// FollowAnnouncementChannel subscribes targetChannelID to an announcement
+// channel; Discord posts crossposted messages through a webhook it creates
+// in the target (requires MANAGE_WEBHOOKS there).
+func (c *Channels) FollowAnnouncementChannel(ctx context.Context, channelID, targetChannelID, reason string) (*types.FollowedChannel, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if err := validateID("targetChannelID", targetChannelID); err != nil {
+		return nil, err
+	}
+	body := map[string]string{"webhook_channel_id": targetChannelID}
+	var followed types.FollowedChannel
+	if err := c.client.do(ctx, http.MethodPost, fmt.Sprintf("/channels/%s/followers", channelID), body, &followed, auditLogHeaders(reason)); err != nil {
+		return nil, err
+	}
+	return &followed, nil
+}
+
+// TriggerTypingIndicator shows the bot as typing in a channel. Discord clears
+// the indicator after about 10 seconds or when the bot sends a message.
+func (c *Channels) TriggerTypingIndicator(ctx context.Context, channelID string) error {
+	if err := validateID("channelID", channelID); err != nil {
+		return err
+	}
+	return c.client.Post(ctx, fmt.Sprintf("/channels/%s/typing", channelID), nil, nil)
+}
+
 // GetChannelMessagesParams controls pagination for channel history.
 type GetChannelMessagesParams struct {
 	Limit  int
@@ -142,3 +142,71 @@ func newTestClient(t *testing.T, baseURL string) *Client {
 	}
 	return client
 }
+
+func TestStartThreadInForum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/channels/forum1/threads" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var params types.ForumThreadParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if params.Name != "Release 1.4" || params.Message.Content != "Notes" || len(params.AppliedTags) != 1 || params.AutoArchiveDuration != 1440 {
+			t.Fatalf("unexpected body %+v", params)
+		}
+		w.Write([]byte(`{"id":"t1","type":11,"parent_id":"forum1","applied_tags":["tag1"],"message":{"id":"m1","content":"Notes"}}`))
+	}))
+	defer server.Close()
+
+	thread, err := newTestClient(t, server.URL).Channels().StartThreadInForum(context.Background(), "forum1", &types.ForumThreadParams{
+		Name:                "Release 1.4",
+		AutoArchiveDuration: 1440,
+		AppliedTags:         []string{"tag1"},
+		Message:             types.MessageCreateParams{Content: "Notes"},
+	})
+	if err != nil || thread.ID != "t1" || thread.Message == nil || thread.Message.ID != "m1" || thread.AppliedTags[0] != "tag1" {
+		t.Fatalf("start thread: %+v, %v", thread, err)
+	}
+	if _, err := newTestClient(t, server.URL).Channels().StartThreadInForum(context.Background(), "forum1", &types.ForumThreadParams{Name: "x"}); err == nil {
+		t.Fatal("expected missing message error")
+	}
+}
+
+func TestFollowAnnouncementChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/channels/news1/followers" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["webhook_channel_id"] != "mine" {
+			t.Fatalf("unexpected body %v: %v", body, err)
+		}
+		w.Write([]byte(`{"channel_id":"news1","webhook_id":"wh1"}`))
+	}))
+	defer server.Close()
+
+	followed, err := newTestClient(t, server.URL).Channels().FollowAnnouncementChannel(context.Background(), "news1", "mine", "")
+	if err != nil || followed.WebhookID != "wh1" {
+		t.Fatalf("follow: %+v, %v", followed, err)
+	}
+}
+
+func TestTriggerTypingIndicator(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/channels/chan1/typing" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		hits++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := newTestClient(t, server.URL).Channels().TriggerTypingIndicator(context.Background(), "chan1"); err != nil {
+		t.Fatalf("typing: %v", err)
+	}
+	if hits != 1 {
+		t.Fatalf("expected 1 request, got %d", hits)
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"net/http/httptrace"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -62,16 +65,21 @@ func defaultPoolConfig() PoolConfig {
 // It mirrors the webhook client's patterns: typed errors, structured logging,
 // shared rate-limit tracking, and context-aware requests.
 type Client struct {
-	token       string
+	tokenMu     sync.Mutex
+	tokens      []string
+	activeToken int
 	baseURL     string
 	httpClient  *http.Client
 	logger      *logger.Logger
 	rateLimiter ratelimit.Tracker
 	strategy    ratelimit.Strategy
+	observer    ratelimit.Observer
 	maxRetries  int
 	timeout     time.Duration
 	poolConfig  PoolConfig
 	poolStats   *poolStats
+	apiVersion  int
+	features    map[Feature]bool
 
 	middlewares []Middleware
 }
@@ -124,6 +132,13 @@ func WithStrategy(strategy ratelimit.Strategy) Option {
 	}
 }
 
+// WithRateLimitObserver reports bucket updates and 429s to obs.
+func WithRateLimitObserver(obs ratelimit.Observer) Option {
+	return func(c *Client) {
+		c.observer = obs
+	}
+}
+
 // WithStrategyName selects a rate limiting strategy by name.
 func WithStrategyName(name string) Option {
 	return func(c *Client) {
@@ -156,6 +171,18 @@ func WithPoolConfig(cfg PoolConfig) Option {
 	}
 }
 
+// WithFallbackTokens adds tokens to fail over to, in order, when Discord
+// rejects the active one with 401 Unauthorized.
+func WithFallbackTokens(tokens ...string) Option {
+	return func(c *Client) {
+		for _, token := range tokens {
+			if strings.TrimSpace(token) != "" {
+				c.tokens = append(c.tokens, token)
+			}
+		}
+	}
+}
+
 // New creates a new Discord bot HTTP client.
 func New(token string, opts ...Option) (*Client, error) {
 	if strings.TrimSpace(token) == "" {
@@ -166,7 +193,7 @@ func New(token string, opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		token:       token,
+		tokens:      []string{token},
 		baseURL:     defaultBaseURL,
 		httpClient:  &http.Client{},
 		logger:      logger.Default(),
@@ -176,12 +203,26 @@ func New(token string, opts ...Option) (*Client, error) {
 		timeout:     30 * time.Second,
 		poolConfig:  defaultPoolConfig(),
 		poolStats:   &poolStats{},
+		apiVersion:  DefaultAPIVersion,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if obs, ok := c.observer.(ratelimit.StrategyObserver); ok {
+		if stats, ok := c.strategy.(ratelimit.StatsReporter); ok {
+			obs.ObserveStrategy(stats)
+		}
+	}
+
+	if err := ValidateAPIVersion(c.apiVersion); err != nil {
+		return nil, err
+	}
+	if c.baseURL == defaultBaseURL {
+		c.baseURL = fmt.Sprintf("%s/v%d", defaultBaseURL, c.apiVersion)
+	}
+
 	c.configureHTTPClient()
 
 	return c, nil
@@ -240,6 +281,35 @@ func applyPoolConfig(t *http.Transport, cfg PoolConfig) {
 	}
 }
 
+func (c *Client) token() string {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.tokens[c.activeToken]
+}
+
+// failover moves past rejected and reports whether another token is left
+// to try. Concurrent requests rejected with the same token fail over once.
+func (c *Client) failover(rejected string) bool {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.tokens[c.activeToken] != rejected {
+		return true
+	}
+	if c.activeToken+1 >= len(c.tokens) {
+		return false
+	}
+	c.activeToken++
+	return true
+}
+
+// TokenIndex reports which token requests use: 0 for the primary, 1 for the
+// first fallback, and so on.
+func (c *Client) TokenIndex() int {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.activeToken
+}
+
 // Get performs a GET request relative to the Discord API base path.
 func (c *Client) Get(ctx context.Context, path string, out interface{}) error {
 	return c.do(ctx, http.MethodGet, path, nil, out, nil)
@@ -271,7 +341,13 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{},
 
 	var payload []byte
 	var err error
-	if body != nil {
+	contentType := "application/json"
+	if mp, ok := body.(*MultipartBody); ok {
+		payload, contentType, err = mp.encode()
+		if err != nil {
+			return err
+		}
+	} else if body != nil {
 		payload, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
@@ -280,9 +356,10 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{},
 
 	backoff := time.Second
 	var lastErr error
+	failedOver := false
 
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
+		if attempt > 0 && !failedOver {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -315,9 +392,11 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{},
 		}
 
 		if payload != nil {
-			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Type", contentType)
 		}
-		req.Header.Set("Authorization", "Bot "+c.token)
+		failedOver = false
+		token := c.token()
+		req.Header.Set("Authorization", "Bot "+token)
 		req.Header.Set("User-Agent", defaultUserAgent)
 		for key, values := range headers {
 			for _, v := range values {
@@ -340,6 +419,7 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{},
 
 		if c.rateLimiter != nil {
 			c.rateLimiter.Update(route, resp.Header)
+			c.observeBucket(route)
 		}
 
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -374,6 +454,9 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{},
 				"attempt", attempt+1,
 			)
 			c.recordStrategyOutcome(route, true)
+			if c.observer != nil {
+				c.observer.RateLimited(route, resp.Header.Get("X-RateLimit-Global") == "true", time.Duration(apiErr.RetryAfter)*time.Second)
+			}
 
 			if apiErr.RetryAfter > 0 {
 				backoff = time.Duration(apiErr.RetryAfter) * time.Second
@@ -382,6 +465,19 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{},
 			continue
 		}
 
+		if resp.StatusCode == http.StatusUnauthorized && c.failover(token) {
+			c.logger.Warn("bot token rejected, failing over to the next token",
+				"route", route,
+				"token_index", c.TokenIndex(),
+			)
+			// Failovers are bounded by the token list, so they do not use
+			// up retries.
+			failedOver = true
+			attempt--
+			lastErr = apiErr
+			continue
+		}
+
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 			return apiErr
 		}
@@ -439,7 +535,7 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 		strategyName = "none"
 	}
 
-	if err := c.rateLimiter.Wait(ctx, route); err != nil {
+	if err := ratelimit.Acquire(ctx, c.rateLimiter, route); err != nil {
 		return err
 	}
 
@@ -457,6 +553,16 @@ func (c *Client) recordStrategyOutcome(route string, hitLimit bool) {
 	}
 }
 
+// observeBucket reports the tracker's bucket for route to the observer, if any.
+func (c *Client) observeBucket(route string) {
+	if c.observer == nil {
+		return
+	}
+	if bucket := c.rateLimiter.GetBucket(route); bucket != nil {
+		c.observer.BucketUpdated(route, *bucket)
+	}
+}
+
 // PoolStats returns connection pooling metrics for the HTTP client.
 func (c *Client) PoolStats() PoolStats {
 	if c.poolStats == nil {
@@ -496,6 +602,7 @@ func (c *Client) parseErrorResponse(resp *http.Response) *types.APIError {
 	apiErr := &types.APIError{
 		StatusCode: resp.StatusCode,
 		Message:    string(data),
+		APIVersion: c.apiVersion,
 	}
 
 	var payload struct {
@@ -515,6 +622,12 @@ func (c *Client) parseErrorResponse(resp *http.Response) *types.APIError {
 			apiErr.RetryAfter = int(payload.RetryAfter)
 		}
 	}
+	// Proxies and some 5xx responses only send the header.
+	if apiErr.RetryAfter == 0 {
+		if seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil && seconds > 0 {
+			apiErr.RetryAfter = int(math.Ceil(seconds))
+		}
+	}
 
 	return apiErr
 }
@@ -3,8 +3,10 @@ package client
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -123,6 +125,79 @@ func TestClientReturnsAPIError(t *testing.T) {
 	}
 }
 
+func TestClientFailsOverOnUnauthorized(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if auth != "Bot secondary" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"401: Unauthorized","code":0}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	client, err := New("primary",
+		WithFallbackTokens("secondary"),
+		WithMaxRetries(0),
+		WithBaseURL(server.URL),
+		WithRateLimiter(&noopTracker{}),
+		WithStrategy(ratelimit.NewReactiveStrategy()),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := client.Get(context.Background(), "/users/@me", nil); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	want := []string{"Bot primary", "Bot secondary", "Bot secondary"}
+	if len(seen) != len(want) {
+		t.Fatalf("requests = %v, want %v", seen, want)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("requests = %v, want %v", seen, want)
+		}
+	}
+	if client.TokenIndex() != 1 {
+		t.Fatalf("TokenIndex() = %d, want 1", client.TokenIndex())
+	}
+}
+
+func TestClientReturnsUnauthorizedWhenTokensRunOut(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"401: Unauthorized","code":0}`))
+	}))
+	defer server.Close()
+
+	client, err := New("primary",
+		WithFallbackTokens("secondary"),
+		WithBaseURL(server.URL),
+		WithRateLimiter(&noopTracker{}),
+		WithStrategy(ratelimit.NewReactiveStrategy()),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	err = client.Get(context.Background(), "/users/@me", nil)
+	if !errors.Is(err, types.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected one request per token, got %d", got)
+	}
+}
+
 func TestClientRespectsContextCancellation(t *testing.T) {
 	block := make(chan struct{})
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -185,6 +260,60 @@ func TestClientWaitsOnRateLimiter(t *testing.T) {
 	}
 }
 
+func TestClientReportsRateLimitsToObserver(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "abc")
+		w.Header().Set("X-RateLimit-Limit", "5")
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("X-RateLimit-Reset-After", "0.01")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"slow down","retry_after":0}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Reset-After", "60")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	rec := ratelimit.NewRecorder(time.Minute)
+	client, err := New("token",
+		WithBaseURL(server.URL),
+		WithStrategy(ratelimit.NewReactiveStrategy()),
+		WithRateLimitObserver(rec),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := client.Get(context.Background(), "/test", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	snap := rec.Snapshot()
+	if len(snap.Events) != 1 || !snap.Events[0].Global {
+		t.Fatalf("expected one global 429, got %+v", snap.Events)
+	}
+	if len(snap.Buckets) != 1 || snap.Buckets[0].Key != "abc" || snap.Buckets[0].Remaining != 4 {
+		t.Fatalf("unexpected buckets %+v", snap.Buckets)
+	}
+}
+
+func TestParseErrorResponseReadsRetryAfterHeader(t *testing.T) {
+	c := &Client{}
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": {"1.5"}}, Body: io.NopCloser(strings.NewReader("upstream unavailable"))}
+	if apiErr := c.parseErrorResponse(resp); apiErr.RetryAfter != 2 || apiErr.Message != "upstream unavailable" {
+		t.Fatalf("unexpected error %+v", apiErr)
+	}
+	resp = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"9"}}, Body: io.NopCloser(strings.NewReader(`{"message":"slow down","retry_after":3}`))}
+	if apiErr := c.parseErrorResponse(resp); apiErr.RetryAfter != 3 {
+		t.Fatalf("expected the body's retry_after to win, got %d", apiErr.RetryAfter)
+	}
+}
+
 // --- helpers ---
 
 type noopTracker struct{}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// GetGuildTemplate returns a template by code.
+func (g *Guilds) GetGuildTemplate(ctx context.Context, code string) (*types.GuildTemplate, error) {
+	if err := validateID("code", code); err != nil {
+		return nil, err
+	}
+	var template types.GuildTemplate
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/templates/%s", url.PathEscape(code)), &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// CreateGuildFromTemplate creates a guild owned by the bot from a template.
+// Discord only allows this for bots in fewer than 10 guilds.
+func (g *Guilds) CreateGuildFromTemplate(ctx context.Context, code string, params *types.GuildFromTemplateParams) (*types.Guild, error) {
+	if err := validateID("code", code); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var guild types.Guild
+	if err := g.client.Post(ctx, fmt.Sprintf("/guilds/templates/%s", url.PathEscape(code)), params, &guild); err != nil {
+		return nil, err
+	}
+	return &guild, nil
+}
+
+// GetGuildTemplates lists the templates of a guild (requires MANAGE_GUILD).
+func (g *Guilds) GetGuildTemplates(ctx context.Context, guildID string) ([]*types.GuildTemplate, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var templates []*types.GuildTemplate
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/templates", guildID), &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// CreateGuildTemplate snapshots the guild into a new template.
+func (g *Guilds) CreateGuildTemplate(ctx context.Context, guildID string, params *types.GuildTemplateParams) (*types.GuildTemplate, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(true); err != nil {
+		return nil, err
+	}
+	var template types.GuildTemplate
+	if err := g.client.Post(ctx, fmt.Sprintf("/guilds/%s/templates", guildID), params, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// SyncGuildTemplate updates the template to the guild's current layout.
+func (g *Guilds) SyncGuildTemplate(ctx context.Context, guildID, code string) (*types.GuildTemplate, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := validateID("code", code); err != nil {
+		return nil, err
+	}
+	var template types.GuildTemplate
+	if err := g.client.Put(ctx, fmt.Sprintf("/guilds/%s/templates/%s", guildID, url.PathEscape(code)), nil, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ModifyGuildTemplate changes a template's name or description.
+func (g *Guilds) ModifyGuildTemplate(ctx context.Context, guildID, code string, params *types.GuildTemplateParams) (*types.GuildTemplate, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := validateID("code", code); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(false); err != nil {
+		return nil, err
+	}
+	var template types.GuildTemplate
+	if err := g.client.Patch(ctx, fmt.Sprintf("/guilds/%s/templates/%s", guildID, url.PathEscape(code)), params, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// DeleteGuildTemplate deletes a template.
+func (g *Guilds) DeleteGuildTemplate(ctx context.Context, guildID, code string) error {
+	if err := validateID("guildID", guildID); err != nil {
+		return err
+	}
+	if err := validateID("code", code); err != nil {
+		return err
+	}
+	return g.client.Delete(ctx, fmt.Sprintf("/guilds/%s/templates/%s", guildID, url.PathEscape(code)))
+}
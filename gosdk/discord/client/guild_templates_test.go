@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestGuildTemplatesCreateAndSync(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/guilds/g1/templates":
+			var params types.GuildTemplateParams
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil || params.Name != "Project" {
+				t.Fatalf("unexpected body %+v: %v", params, err)
+			}
+			json.NewEncoder(w).Encode(types.GuildTemplate{Code: "abc123", Name: params.Name, SourceGuildID: "g1"})
+		case r.Method == http.MethodPut && r.URL.Path == "/guilds/g1/templates/abc123":
+			json.NewEncoder(w).Encode(types.GuildTemplate{Code: "abc123", SourceGuildID: "g1"})
+		case r.Method == http.MethodPost && r.URL.Path == "/guilds/templates/abc123":
+			json.NewEncoder(w).Encode(types.Guild{ID: "g2", Name: "Project X"})
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	guilds := newTestClient(t, server.URL).Guilds()
+	ctx := context.Background()
+	template, err := guilds.CreateGuildTemplate(ctx, "g1", &types.GuildTemplateParams{Name: "Project"})
+	if err != nil || template.URL() != "https://discord.new/abc123" {
+		t.Fatalf("create template: %+v, %v", template, err)
+	}
+	if _, err := guilds.SyncGuildTemplate(ctx, "g1", "abc123"); err != nil {
+		t.Fatalf("sync template: %v", err)
+	}
+	guild, err := guilds.CreateGuildFromTemplate(ctx, "abc123", &types.GuildFromTemplateParams{Name: "Project X"})
+	if err != nil || guild.ID != "g2" {
+		t.Fatalf("create from template: %+v, %v", guild, err)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestGuildTemplatesValidate(t *testing.T) {
+	guilds := newTestClient(t, "http://127.0.0.1").Guilds()
+	if _, err := guilds.CreateGuildTemplate(context.Background(), "g1", &types.GuildTemplateParams{}); err == nil {
+		t.Fatal("expected name required error")
+	}
+	if _, err := guilds.CreateGuildFromTemplate(context.Background(), "abc", &types.GuildFromTemplateParams{Name: "x"}); err == nil {
+		t.Fatal("expected short name error")
+	}
+}
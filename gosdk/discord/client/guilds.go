@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 )
@@ -244,3 +245,166 @@ func (g *Guilds) RemoveGuildMemberRole(ctx context.Context, guildID, userID, rol
 	}
 	return g.client.Delete(ctx, fmt.Sprintf("/guilds/%s/members/%s/roles/%s", guildID, userID, roleID))
 }
+
+// GetGuildOnboarding returns the guild's onboarding prompts and defaults.
+func (g *Guilds) GetGuildOnboarding(ctx context.Context, guildID string) (*types.GuildOnboarding, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var onboarding types.GuildOnboarding
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/onboarding", guildID), &onboarding); err != nil {
+		return nil, err
+	}
+	return &onboarding, nil
+}
+
+// ModifyGuildOnboarding replaces the guild's onboarding configuration
+// (requires MANAGE_GUILD and MANAGE_ROLES).
+func (g *Guilds) ModifyGuildOnboarding(ctx context.Context, guildID string, params *types.GuildOnboardingParams) (*types.GuildOnboarding, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	params.Normalize()
+
+	var onboarding types.GuildOnboarding
+	if err := g.client.do(ctx, http.MethodPut, fmt.Sprintf("/guilds/%s/onboarding", guildID), params, &onboarding, auditLogHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &onboarding, nil
+}
+
+// GetGuildWelcomeScreen returns the guild's welcome screen.
+func (g *Guilds) GetGuildWelcomeScreen(ctx context.Context, guildID string) (*types.WelcomeScreen, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	var screen types.WelcomeScreen
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/welcome-screen", guildID), &screen); err != nil {
+		return nil, err
+	}
+	return &screen, nil
+}
+
+// ModifyGuildWelcomeScreen updates the guild's welcome screen (requires
+// MANAGE_GUILD).
+func (g *Guilds) ModifyGuildWelcomeScreen(ctx context.Context, guildID string, params *types.WelcomeScreenModifyParams) (*types.WelcomeScreen, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var screen types.WelcomeScreen
+	if err := g.client.do(ctx, http.MethodPatch, fmt.Sprintf("/guilds/%s/welcome-screen", guildID), params, &screen, auditLogHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &screen, nil
+}
+
+// GetGuildBans lists one page of the guild's bans (requires BAN_MEMBERS).
+func (g *Guilds) GetGuildBans(ctx context.Context, guildID string, params *types.ListBansParams) ([]*types.Ban, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if params != nil {
+		if params.Limit > 0 {
+			query.Set("limit", fmt.Sprintf("%d", params.Limit))
+		}
+		if params.Before != "" {
+			query.Set("before", params.Before)
+		}
+		if params.After != "" {
+			query.Set("after", params.After)
+		}
+	}
+	path := fmt.Sprintf("/guilds/%s/bans", guildID)
+	if q := query.Encode(); q != "" {
+		path += "?" + q
+	}
+	var bans []*types.Ban
+	if err := g.client.Get(ctx, path, &bans); err != nil {
+		return nil, err
+	}
+	return bans, nil
+}
+
+// CreateGuildBan bans a user (requires BAN_MEMBERS).
+func (g *Guilds) CreateGuildBan(ctx context.Context, guildID, userID string, params *types.CreateBanParams) error {
+	if err := validateID("guildID", guildID); err != nil {
+		return err
+	}
+	if err := validateID("userID", userID); err != nil {
+		return err
+	}
+	if params == nil {
+		params = &types.CreateBanParams{}
+	}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	return g.client.do(ctx, http.MethodPut, fmt.Sprintf("/guilds/%s/bans/%s", guildID, userID), params, nil, auditLogHeaders(params.AuditLogReason))
+}
+
+// BulkGuildBan bans up to 200 users at once (requires BAN_MEMBERS and
+// MANAGE_GUILD).
+func (g *Guilds) BulkGuildBan(ctx context.Context, guildID string, params *types.BulkBanParams) (*types.BulkBanResult, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var result types.BulkBanResult
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf("/guilds/%s/bulk-ban", guildID), params, &result, auditLogHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetGuildPruneCount returns how many members a prune with params would
+// remove (requires MANAGE_GUILD and KICK_MEMBERS).
+func (g *Guilds) GetGuildPruneCount(ctx context.Context, guildID string, params *types.PruneParams) (int, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return 0, err
+	}
+	if err := params.Validate(); err != nil {
+		return 0, err
+	}
+	query := url.Values{}
+	query.Set("days", fmt.Sprintf("%d", params.Days))
+	if len(params.IncludeRoles) > 0 {
+		query.Set("include_roles", strings.Join(params.IncludeRoles, ","))
+	}
+	var result types.PruneResult
+	if err := g.client.Get(ctx, fmt.Sprintf("/guilds/%s/prune?%s", guildID, query.Encode()), &result); err != nil {
+		return 0, err
+	}
+	if result.Pruned == nil {
+		return 0, nil
+	}
+	return *result.Pruned, nil
+}
+
+// BeginGuildPrune kicks inactive members (requires MANAGE_GUILD and
+// KICK_MEMBERS).
+func (g *Guilds) BeginGuildPrune(ctx context.Context, guildID string, params *types.PruneParams) (*types.PruneResult, error) {
+	if err := validateID("guildID", guildID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var result types.PruneResult
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf("/guilds/%s/prune", guildID), params, &result, auditLogHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -139,3 +139,114 @@ func TestGuildMemberOperations(t *testing.T) {
 		t.Fatalf("expected requests")
 	}
 }
+
+func TestGuildsModifyOnboardingFlattensEmoji(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/guilds/g1/onboarding" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		option := body["prompts"].([]interface{})[0].(map[string]interface{})["options"].([]interface{})[0].(map[string]interface{})
+		if option["emoji_name"] != "🚀" || option["emoji"] != nil {
+			t.Fatalf("emoji not flattened: %v", option)
+		}
+		json.NewEncoder(w).Encode(types.GuildOnboarding{GuildID: "g1", Enabled: true})
+	}))
+	defer server.Close()
+
+	params := &types.GuildOnboardingParams{
+		Enabled: true,
+		Prompts: []types.OnboardingPrompt{{
+			Title:   "What brings you here?",
+			Options: []types.OnboardingPromptOption{{Title: "Shipping", Emoji: &types.OnboardingEmoji{Name: "🚀"}}},
+		}},
+	}
+	onboarding, err := newTestClient(t, server.URL).Guilds().ModifyGuildOnboarding(context.Background(), "g1", params)
+	if err != nil || !onboarding.Enabled {
+		t.Fatalf("modify onboarding: %+v, %v", onboarding, err)
+	}
+}
+
+func TestGuildsModifyWelcomeScreenValidates(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1")
+	if _, err := client.Guilds().ModifyGuildWelcomeScreen(context.Background(), "g1", &types.WelcomeScreenModifyParams{}); err == nil {
+		t.Fatal("expected nothing to modify error")
+	}
+	channels := make([]types.WelcomeScreenChannel, 6)
+	if _, err := client.Guilds().ModifyGuildWelcomeScreen(context.Background(), "g1", &types.WelcomeScreenModifyParams{WelcomeChannels: &channels}); err == nil {
+		t.Fatal("expected too many channels error")
+	}
+}
+
+func TestGuildsBans(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/guilds/g1/bans":
+			if r.URL.Query().Get("after") != "10" || r.URL.Query().Get("limit") != "1000" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode([]types.Ban{{Reason: "spam", User: &types.User{ID: "11"}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/guilds/g1/bulk-ban":
+			if r.Header.Get("X-Audit-Log-Reason") == "" {
+				t.Fatalf("missing audit log reason")
+			}
+			var params types.BulkBanParams
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil || len(params.UserIDs) != 2 {
+				t.Fatalf("unexpected body %+v: %v", params, err)
+			}
+			json.NewEncoder(w).Encode(types.BulkBanResult{BannedUsers: []string{"11"}, FailedUsers: []string{"12"}})
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	guilds := newTestClient(t, server.URL).Guilds()
+	bans, err := guilds.GetGuildBans(context.Background(), "g1", &types.ListBansParams{Limit: 1000, After: "10"})
+	if err != nil || len(bans) != 1 || bans[0].User.ID != "11" {
+		t.Fatalf("get bans: %+v, %v", bans, err)
+	}
+	result, err := guilds.BulkGuildBan(context.Background(), "g1", &types.BulkBanParams{UserIDs: []string{"11", "12"}, AuditLogReason: "migrate"})
+	if err != nil || len(result.BannedUsers) != 1 || len(result.FailedUsers) != 1 {
+		t.Fatalf("bulk ban: %+v, %v", result, err)
+	}
+	if _, err := guilds.BulkGuildBan(context.Background(), "g1", &types.BulkBanParams{UserIDs: make([]string, 201)}); err == nil {
+		t.Fatal("expected too many users error")
+	}
+}
+
+func TestGuildsPrune(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("days") != "30" || r.URL.Query().Get("include_roles") != "r1,r2" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"pruned": 12}`))
+		case http.MethodPost:
+			var params types.PruneParams
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil || params.Days != 30 || params.ComputePruneCount {
+				t.Fatalf("unexpected body %+v: %v", params, err)
+			}
+			w.Write([]byte(`{"pruned": null}`))
+		}
+	}))
+	defer server.Close()
+
+	guilds := newTestClient(t, server.URL).Guilds()
+	params := &types.PruneParams{Days: 30, IncludeRoles: []string{"r1", "r2"}}
+	count, err := guilds.GetGuildPruneCount(context.Background(), "g1", params)
+	if err != nil || count != 12 {
+		t.Fatalf("prune count: %d, %v", count, err)
+	}
+	result, err := guilds.BeginGuildPrune(context.Background(), "g1", params)
+	if err != nil || result.Pruned != nil {
+		t.Fatalf("begin prune: %+v, %v", result, err)
+	}
+	if _, err := guilds.GetGuildPruneCount(context.Background(), "g1", &types.PruneParams{Days: 31}); err == nil {
+		t.Fatal("expected days validation error")
+	}
+}
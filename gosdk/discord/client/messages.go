@@ -67,6 +67,9 @@ func (m *MessageService) EditMessage(ctx context.Context, channelID, messageID s
 	if params == nil {
 		return nil, &types.ValidationError{Field: "params", Message: "message edit params required"}
 	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 
 	var msg types.Message
 	if err := m.client.Patch(ctx, fmt.Sprintf("/channels/%s/messages/%s", channelID, messageID), params, &msg); err != nil {
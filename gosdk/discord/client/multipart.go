@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// File is an upload sent with a MultipartBody.
+type File struct {
+	Name string
+	// ContentType defaults to application/octet-stream.
+	ContentType string
+	Data        []byte
+}
+
+// MultipartBody is a request body sent as multipart/form-data: Payload as
+// payload_json and each file as files[n], the form Discord expects for
+// uploads. Pass it as the body of Post or Patch.
+type MultipartBody struct {
+	Payload interface{}
+	Files   []File
+}
+
+func (b *MultipartBody) encode() ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	payload, err := json.Marshal(b.Payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	if err := w.WriteField("payload_json", string(payload)); err != nil {
+		return nil, "", err
+	}
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	for i, file := range b.Files {
+		if file.Name == "" {
+			return nil, "", fmt.Errorf("file %d has no name", i)
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, quote.Replace(file.Name)))
+		header.Set("Content-Type", contentType)
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(file.Data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
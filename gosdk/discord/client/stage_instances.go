@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// StageInstances exposes the stage instance REST helpers.
+type StageInstances struct {
+	client *Client
+}
+
+// StageInstances returns a stage instance service bound to the Client.
+func (c *Client) StageInstances() *StageInstances {
+	return &StageInstances{client: c}
+}
+
+// CreateStageInstance starts a stage in the params' channel.
+func (s *StageInstances) CreateStageInstance(ctx context.Context, params *types.StageInstanceCreateParams) (*types.StageInstance, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var stage types.StageInstance
+	if err := s.client.do(ctx, http.MethodPost, "/stage-instances", params, &stage, auditLogHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &stage, nil
+}
+
+// GetStageInstance returns the live stage in channelID.
+func (s *StageInstances) GetStageInstance(ctx context.Context, channelID string) (*types.StageInstance, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	var stage types.StageInstance
+	if err := s.client.Get(ctx, fmt.Sprintf("/stage-instances/%s", channelID), &stage); err != nil {
+		return nil, err
+	}
+	return &stage, nil
+}
+
+// ModifyStageInstance updates the topic or privacy of the stage in channelID.
+func (s *StageInstances) ModifyStageInstance(ctx context.Context, channelID string, params *types.StageInstanceModifyParams) (*types.StageInstance, error) {
+	if err := validateID("channelID", channelID); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	var stage types.StageInstance
+	if err := s.client.do(ctx, http.MethodPatch, fmt.Sprintf("/stage-instances/%s", channelID), params, &stage, auditLogHeaders(params.AuditLogReason)); err != nil {
+		return nil, err
+	}
+	return &stage, nil
+}
+
+// DeleteStageInstance ends the stage in channelID.
+func (s *StageInstances) DeleteStageInstance(ctx context.Context, channelID, reason string) error {
+	if err := validateID("channelID", channelID); err != nil {
+		return err
+	}
+	return s.client.do(ctx, http.MethodDelete, fmt.Sprintf("/stage-instances/%s", channelID), nil, nil, auditLogHeaders(reason))
+}
+
+func auditLogHeaders(reason string) http.Header {
+	headers := http.Header{}
+	if reason != "" {
+		headers.Set("X-Audit-Log-Reason", url.QueryEscape(reason))
+	}
+	return headers
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestStageInstancesLifecycle(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			var params types.StageInstanceCreateParams
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if params.ChannelID != "stage-1" || params.Topic != "Town hall" || !params.SendStartNotification {
+				t.Fatalf("unexpected create params %+v", params)
+			}
+			if r.Header.Get("X-Audit-Log-Reason") != "weekly+event" {
+				t.Fatalf("missing audit log reason: %q", r.Header.Get("X-Audit-Log-Reason"))
+			}
+			json.NewEncoder(w).Encode(types.StageInstance{ID: "si-1", ChannelID: params.ChannelID, Topic: params.Topic})
+		case http.MethodPatch:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["topic"] != "Q&A" || len(body) != 1 {
+				t.Fatalf("unexpected modify body %v", body)
+			}
+			json.NewEncoder(w).Encode(types.StageInstance{ID: "si-1", Topic: "Q&A"})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	stages := newTestClient(t, server.URL).StageInstances()
+	ctx := context.Background()
+	stage, err := stages.CreateStageInstance(ctx, &types.StageInstanceCreateParams{
+		ChannelID: "stage-1", Topic: "Town hall", SendStartNotification: true, AuditLogReason: "weekly event",
+	})
+	if err != nil || stage.ID != "si-1" {
+		t.Fatalf("create: %+v, %v", stage, err)
+	}
+	if stage, err = stages.ModifyStageInstance(ctx, "stage-1", &types.StageInstanceModifyParams{Topic: "Q&A"}); err != nil || stage.Topic != "Q&A" {
+		t.Fatalf("modify: %+v, %v", stage, err)
+	}
+	if err := stages.DeleteStageInstance(ctx, "stage-1", ""); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "POST /stage-instances,PATCH /stage-instances/stage-1,DELETE /stage-instances/stage-1" {
+		t.Fatalf("unexpected calls %s", got)
+	}
+}
+
+func TestStageInstanceParamsValidate(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1")
+	if _, err := client.StageInstances().CreateStageInstance(context.Background(), &types.StageInstanceCreateParams{ChannelID: "c"}); err == nil {
+		t.Fatal("expected missing topic error")
+	}
+	long := &types.StageInstanceCreateParams{ChannelID: "c", Topic: strings.Repeat("x", 121)}
+	if _, err := client.StageInstances().CreateStageInstance(context.Background(), long); err == nil {
+		t.Fatal("expected topic length error")
+	}
+	if _, err := client.StageInstances().ModifyStageInstance(context.Background(), "c", &types.StageInstanceModifyParams{}); err == nil {
+		t.Fatal("expected nothing to modify error")
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// Users exposes user-scoped REST helpers (current user, DM channels).
+type Users struct {
+	client *Client
+}
+
+// Users returns a user service bound to the client instance.
+func (c *Client) Users() *Users {
+	return &Users{client: c}
+}
+
+// GetCurrentUser returns the user object for the authenticated bot.
+func (u *Users) GetCurrentUser(ctx context.Context) (*types.User, error) {
+	var user types.User
+	if err := u.client.Get(ctx, "/users/@me", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// currentUserGuildsPageSize is the maximum page Discord returns for
+// /users/@me/guilds.
+const currentUserGuildsPageSize = 200
+
+// GetCurrentUserGuilds returns every guild the bot is a member of as partial
+// guild objects (id, name, icon, owner, permissions, features), following
+// pagination until Discord returns a short page.
+func (u *Users) GetCurrentUserGuilds(ctx context.Context) ([]*types.Guild, error) {
+	var all []*types.Guild
+	after := ""
+	for {
+		path := fmt.Sprintf("/users/@me/guilds?limit=%d", currentUserGuildsPageSize)
+		if after != "" {
+			path += "&after=" + after
+		}
+		var page []*types.Guild
+		if err := u.client.Get(ctx, path, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < currentUserGuildsPageSize {
+			return all, nil
+		}
+		after = page[len(page)-1].ID
+	}
+}
+
+// CreateDM opens (or returns the existing) DM channel with the given user.
+func (u *Users) CreateDM(ctx context.Context, recipientID string) (*types.Channel, error) {
+	if err := validateID("recipientID", recipientID); err != nil {
+		return nil, err
+	}
+
+	payload := struct {
+		RecipientID string `json:"recipient_id"`
+	}{
+		RecipientID: recipientID,
+	}
+
+	var channel types.Channel
+	if err := u.client.Post(ctx, "/users/@me/channels", payload, &channel); err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
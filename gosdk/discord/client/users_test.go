@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestUsersCreateDM(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/users/@me/channels" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload["recipient_id"] != "42" {
+			t.Fatalf("expected recipient 42, got %q", payload["recipient_id"])
+		}
+		json.NewEncoder(w).Encode(types.Channel{ID: "dm-1", Type: types.ChannelTypeDM})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	channel, err := client.Users().CreateDM(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("CreateDM error: %v", err)
+	}
+	if channel.ID != "dm-1" || channel.Type != types.ChannelTypeDM {
+		t.Fatalf("unexpected channel %+v", channel)
+	}
+}
+
+func TestUsersCreateDMRequiresRecipient(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1")
+	if _, err := client.Users().CreateDM(context.Background(), ""); err == nil {
+		t.Fatal("expected validation error for empty recipient")
+	}
+}
+
+func TestUsersGetCurrentUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/@me" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(types.User{ID: "bot", Username: "arc"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	user, err := client.Users().GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser error: %v", err)
+	}
+	if user.ID != "bot" {
+		t.Fatalf("expected bot user, got %+v", user)
+	}
+}
+
+func TestUsersGetCurrentUserGuildsPaginates(t *testing.T) {
+	var afters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/@me/guilds" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		if after == "" {
+			page := make([]types.Guild, currentUserGuildsPageSize)
+			for i := range page {
+				page[i] = types.Guild{ID: fmt.Sprintf("%d", i+1), Name: "g"}
+			}
+			json.NewEncoder(w).Encode(page)
+			return
+		}
+		json.NewEncoder(w).Encode([]types.Guild{{ID: "999", Name: "last"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	guilds, err := client.Users().GetCurrentUserGuilds(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUserGuilds error: %v", err)
+	}
+	if len(guilds) != currentUserGuildsPageSize+1 || guilds[len(guilds)-1].Name != "last" {
+		t.Fatalf("unexpected guilds: %d", len(guilds))
+	}
+	if len(afters) != 2 || afters[1] != fmt.Sprintf("%d", currentUserGuildsPageSize) {
+		t.Fatalf("unexpected pagination cursors %v", afters)
+	}
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// DefaultAPIVersion is the Discord REST API version used when none is pinned.
+const DefaultAPIVersion = 10
+
+// supportedAPIVersions lists the versions Discord still serves.
+var supportedAPIVersions = map[int]bool{9: true, 10: true}
+
+// Feature names an SDK capability that depends on the pinned API version.
+// Gates let new endpoints land behind a version check and be enabled (or
+// disabled) per deployment before the default version moves.
+type Feature string
+
+const (
+	// FeatureThreads covers thread channels and auto-archive settings.
+	FeatureThreads Feature = "threads"
+	// FeatureForumChannels covers forum tags, default reactions, and sort order.
+	FeatureForumChannels Feature = "forum_channels"
+	// FeatureMessageContentIntent marks the v10 requirement that message
+	// content is only delivered with the MESSAGE_CONTENT privileged intent.
+	FeatureMessageContentIntent Feature = "message_content_intent"
+)
+
+// featureMinVersions maps each gated feature to the first API version that
+// supports it.
+var featureMinVersions = map[Feature]int{
+	FeatureThreads:              9,
+	FeatureForumChannels:        9,
+	FeatureMessageContentIntent: 10,
+}
+
+// ValidateAPIVersion reports whether the SDK can talk to the given version.
+func ValidateAPIVersion(version int) error {
+	if supportedAPIVersions[version] {
+		return nil
+	}
+	versions := make([]string, 0, len(supportedAPIVersions))
+	for v := range supportedAPIVersions {
+		versions = append(versions, fmt.Sprintf("v%d", v))
+	}
+	sort.Strings(versions)
+	return &types.ValidationError{
+		Field:   "api_version",
+		Message: fmt.Sprintf("unsupported Discord API version v%d (supported: %s)", version, strings.Join(versions, ", ")),
+	}
+}
+
+// FeatureNames returns the known feature gate names in sorted order.
+func FeatureNames() []string {
+	names := make([]string, 0, len(featureMinVersions))
+	for f := range featureMinVersions {
+		names = append(names, string(f))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithAPIVersion pins the Discord REST API version (default v10).
+func WithAPIVersion(version int) Option {
+	return func(c *Client) {
+		if version > 0 {
+			c.apiVersion = version
+		}
+	}
+}
+
+// WithFeatures overrides feature gates by name. true enables a feature on a
+// version that would otherwise reject it; false disables it entirely.
+func WithFeatures(features map[string]bool) Option {
+	return func(c *Client) {
+		for name, enabled := range features {
+			if c.features == nil {
+				c.features = make(map[Feature]bool)
+			}
+			c.features[Feature(strings.ToLower(strings.TrimSpace(name)))] = enabled
+		}
+	}
+}
+
+// APIVersion returns the pinned Discord REST API version.
+func (c *Client) APIVersion() int {
+	return c.apiVersion
+}
+
+// FeatureEnabled reports whether a gated feature is available on this client.
+func (c *Client) FeatureEnabled(f Feature) bool {
+	if enabled, ok := c.features[f]; ok {
+		return enabled
+	}
+	min, ok := featureMinVersions[f]
+	return ok && c.apiVersion >= min
+}
+
+// requireFeature returns a types.APIVersionError when f is unavailable.
+func (c *Client) requireFeature(f Feature) error {
+	if c.FeatureEnabled(f) {
+		return nil
+	}
+	return &types.APIVersionError{
+		Feature:         string(f),
+		PinnedVersion:   c.apiVersion,
+		RequiredVersion: featureMinVersions[f],
+		Disabled:        c.apiVersion >= featureMinVersions[f],
+	}
+}
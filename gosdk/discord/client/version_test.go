@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestNewPinsDefaultAPIVersion(t *testing.T) {
+	c, err := New("token")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if c.APIVersion() != DefaultAPIVersion {
+		t.Fatalf("expected v%d, got v%d", DefaultAPIVersion, c.APIVersion())
+	}
+	if c.baseURL != "https://discord.com/api/v10" {
+		t.Fatalf("unexpected base URL %s", c.baseURL)
+	}
+
+	c, err = New("token", WithAPIVersion(9))
+	if err != nil {
+		t.Fatalf("New(v9) error = %v", err)
+	}
+	if c.baseURL != "https://discord.com/api/v9" {
+		t.Fatalf("unexpected v9 base URL %s", c.baseURL)
+	}
+
+	if _, err := New("token", WithAPIVersion(6)); err == nil {
+		t.Fatal("expected unsupported version error")
+	}
+}
+
+func TestFeatureGates(t *testing.T) {
+	c, err := New("token", WithAPIVersion(9), WithFeatures(map[string]bool{"forum_channels": false}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !c.FeatureEnabled(FeatureThreads) {
+		t.Fatal("threads should be enabled on v9")
+	}
+	if c.FeatureEnabled(FeatureMessageContentIntent) {
+		t.Fatal("message content intent gate should require v10")
+	}
+	var versionErr *types.APIVersionError
+	if err := c.requireFeature(FeatureMessageContentIntent); !errors.As(err, &versionErr) || versionErr.RequiredVersion != 10 {
+		t.Fatalf("expected APIVersionError requiring v10, got %v", err)
+	}
+
+	_, err = c.Channels().ModifyChannel(context.Background(), "1", &types.ModifyChannelParams{DefaultSortOrder: "latest_activity"})
+	if !errors.As(err, &versionErr) || !versionErr.Disabled {
+		t.Fatalf("expected disabled forum_channels gate, got %v", err)
+	}
+}
+
+func TestUnknownRouteErrorMentionsAPIVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "404: Not Found", "code": 0}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL)
+	err := c.Get(context.Background(), "/does-not-exist", nil)
+	if err == nil || !strings.Contains(err.Error(), "not available on API v10") {
+		t.Fatalf("expected version hint in error, got %v", err)
+	}
+}
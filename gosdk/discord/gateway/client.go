@@ -64,6 +64,30 @@ func WithConnectionOptions(opts ...ConnectionOption) ClientOption {
 	}
 }
 
+// WithShard identifies the connection as shard id of total.
+func WithShard(id, total int) ClientOption {
+	return func(c *Client) {
+		if total > 0 && id >= 0 && id < total {
+			c.shard = []int{id, total}
+		}
+	}
+}
+
+// WithIdentifyLimiter gates every IDENTIFY through the shared limiter.
+func WithIdentifyLimiter(l *IdentifyLimiter) ClientOption {
+	return func(c *Client) {
+		c.identifyLimiter = l
+	}
+}
+
+type shardContextKey struct{}
+
+// ShardFromContext returns the shard ID an event handler was invoked for.
+func ShardFromContext(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(shardContextKey{}).(int)
+	return id, ok
+}
+
 // Client manages a gateway connection and event routing.
 type Client struct {
 	token          string
@@ -75,6 +99,9 @@ type Client struct {
 	activity       *Activity
 	connectionOpts []ConnectionOption
 
+	shard           []int
+	identifyLimiter *IdentifyLimiter
+
 	eventCancel context.CancelFunc
 	wg          sync.WaitGroup
 	mu          sync.RWMutex
@@ -150,11 +177,13 @@ func (c *Client) Disconnect() error {
 		c.eventCancel()
 		c.eventCancel = nil
 	}
-	c.wg.Wait()
-	if c.conn == nil {
-		return nil
+	// Closing first unblocks the read loop, which does not watch ctx.
+	var err error
+	if c.conn != nil {
+		err = c.conn.Close()
 	}
-	return c.conn.Close()
+	c.wg.Wait()
+	return err
 }
 
 // On registers a generic event handler.
@@ -231,6 +260,31 @@ func (c *Client) RequestGuildMembers(ctx context.Context, guildID string, query
 	return c.conn.Send(ctx, payload)
 }
 
+// VoiceStateUpdate is the payload that joins, moves, or leaves a voice channel.
+type VoiceStateUpdate struct {
+	GuildID   string  `json:"guild_id"`
+	ChannelID *string `json:"channel_id"`
+	SelfMute  bool    `json:"self_mute"`
+	SelfDeaf  bool    `json:"self_deaf"`
+}
+
+// UpdateVoiceState joins channelID in guildID, or leaves voice when channelID
+// is empty. Discord answers with VOICE_STATE_UPDATE and VOICE_SERVER_UPDATE.
+func (c *Client) UpdateVoiceState(ctx context.Context, guildID, channelID string, mute, deaf bool) error {
+	if guildID == "" {
+		return errors.New("guild_id is required")
+	}
+	update := VoiceStateUpdate{GuildID: guildID, SelfMute: mute, SelfDeaf: deaf}
+	if channelID != "" {
+		update.ChannelID = &channelID
+	}
+	raw, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("marshal voice state update: %w", err)
+	}
+	return c.Send(ctx, &Payload{Op: OpCodeVoiceStateUpdate, D: raw})
+}
+
 // Send proxies a raw payload over the websocket connection.
 func (c *Client) Send(ctx context.Context, payload *Payload) error {
 	if c.conn == nil {
@@ -240,6 +294,13 @@ func (c *Client) Send(ctx context.Context, payload *Payload) error {
 }
 
 func (c *Client) identify(ctx context.Context) error {
+	shardID := 0
+	if len(c.shard) == 2 {
+		shardID = c.shard[0]
+	}
+	if err := c.identifyLimiter.Wait(ctx, shardID); err != nil {
+		return fmt.Errorf("wait for identify slot: %w", err)
+	}
 	payload := &Payload{Op: OpCodeIdentify}
 	props := IdentifyPayload{
 		Token: c.token,
@@ -249,6 +310,7 @@ func (c *Client) identify(ctx context.Context) error {
 			Device:  "agent-discord",
 		},
 		Intents: c.intents,
+		Shard:   c.shard,
 	}
 	raw, err := json.Marshal(props)
 	if err != nil {
@@ -299,6 +361,9 @@ func (c *Client) handleDispatch(ctx context.Context, payload *Payload) {
 	if ready, ok := event.(*ReadyEvent); ok && ready.SessionID != "" {
 		c.conn.SetSession(ready.SessionID)
 	}
+	if len(c.shard) == 2 {
+		ctx = context.WithValue(ctx, shardContextKey{}, c.shard[0])
+	}
 
 	if err := c.dispatcher.Dispatch(ctx, event); err != nil {
 		c.logger.Warn("dispatch error", "error", err)
@@ -389,6 +454,36 @@ func decodeEvent(payload *Payload) (Event, error) {
 			return nil, err
 		}
 		return &InteractionCreateEvent{Interaction: &interaction}, nil
+	case EventVoiceStateUpdate:
+		var evt VoiceStateUpdateEvent
+		if err := json.Unmarshal(payload.D, &evt); err != nil {
+			return nil, err
+		}
+		return &evt, nil
+	case EventVoiceServerUpdate:
+		var evt VoiceServerUpdateEvent
+		if err := json.Unmarshal(payload.D, &evt); err != nil {
+			return nil, err
+		}
+		return &evt, nil
+	case EventGuildMemberAdd:
+		var evt GuildMemberAddEvent
+		if err := json.Unmarshal(payload.D, &evt); err != nil {
+			return nil, err
+		}
+		return &evt, nil
+	case EventGuildMemberUpdate:
+		var evt GuildMemberUpdateEvent
+		if err := json.Unmarshal(payload.D, &evt); err != nil {
+			return nil, err
+		}
+		return &evt, nil
+	case EventGuildMemberRemove:
+		var evt GuildMemberRemoveEvent
+		if err := json.Unmarshal(payload.D, &evt); err != nil {
+			return nil, err
+		}
+		return &evt, nil
 	default:
 		return nil, nil
 	}
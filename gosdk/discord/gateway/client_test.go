@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
@@ -48,3 +49,46 @@ func TestDecodeMessageCreate(t *testing.T) {
 		t.Fatalf("unexpected event %T", event)
 	}
 }
+
+func TestDecodeVoiceServerUpdate(t *testing.T) {
+	data := []byte(`{"token":"tok","guild_id":"g1","endpoint":"us-east1.discord.media:443"}`)
+	event, err := decodeEvent(&Payload{Op: OpCodeDispatch, T: EventVoiceServerUpdate, D: data})
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	server, ok := event.(*VoiceServerUpdateEvent)
+	if !ok || server.Endpoint != "us-east1.discord.media:443" || server.Token != "tok" {
+		t.Fatalf("unexpected event %#v", event)
+	}
+}
+
+func TestDecodeGuildMemberEvents(t *testing.T) {
+	data := []byte(`{"guild_id":"g1","user":{"id":"u1","username":"ada"},"roles":["r1"],"joined_at":"2024-01-02T03:04:05Z"}`)
+	event, err := decodeEvent(&Payload{Op: OpCodeDispatch, T: EventGuildMemberAdd, D: data})
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	added, ok := event.(*GuildMemberAddEvent)
+	if !ok || added.GuildID != "g1" || added.User.ID != "u1" || len(added.Roles) != 1 {
+		t.Fatalf("unexpected event %#v", event)
+	}
+	raw, _ := json.Marshal(added)
+	if !strings.Contains(string(raw), `"guild_id":"g1"`) || !strings.Contains(string(raw), `"username":"ada"`) {
+		t.Fatalf("member event does not round-trip: %s", raw)
+	}
+
+	event, err = decodeEvent(&Payload{Op: OpCodeDispatch, T: EventGuildMemberRemove, D: []byte(`{"guild_id":"g1","user":{"id":"u1"}}`)})
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if removed, ok := event.(*GuildMemberRemoveEvent); !ok || removed.User.ID != "u1" {
+		t.Fatalf("unexpected event %#v", event)
+	}
+}
+
+func TestVoiceStateUpdateLeaveSendsNullChannel(t *testing.T) {
+	raw, _ := json.Marshal(VoiceStateUpdate{GuildID: "g1"})
+	if string(raw) != `{"guild_id":"g1","channel_id":null,"self_mute":false,"self_deaf":false}` {
+		t.Fatalf("unexpected payload %s", raw)
+	}
+}
@@ -186,7 +186,8 @@ func (c *Connection) startHeartbeat(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 	c.heartbeatCtx = ctx
 	c.heartbeatCancel = cancel
-	c.heartbeatTicker = time.NewTicker(c.heartbeatInterval)
+	ticker := time.NewTicker(c.heartbeatInterval)
+	c.heartbeatTicker = ticker
 	c.mu.Unlock()
 
 	go func() {
@@ -194,7 +195,7 @@ func (c *Connection) startHeartbeat(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				return
-			case <-c.heartbeatTicker.C:
+			case <-ticker.C:
 				if err := c.sendHeartbeat(ctx); err != nil {
 					c.logger.Warn("heartbeat failed", "error", err)
 				}
@@ -18,6 +18,11 @@ const (
 	EventGuildUpdate       = "GUILD_UPDATE"
 	EventGuildDelete       = "GUILD_DELETE"
 	EventInteractionCreate = "INTERACTION_CREATE"
+	EventVoiceStateUpdate  = "VOICE_STATE_UPDATE"
+	EventVoiceServerUpdate = "VOICE_SERVER_UPDATE"
+	EventGuildMemberAdd    = "GUILD_MEMBER_ADD"
+	EventGuildMemberUpdate = "GUILD_MEMBER_UPDATE"
+	EventGuildMemberRemove = "GUILD_MEMBER_REMOVE"
 )
 
 // ReadyEvent signals the gateway is ready for the client.
@@ -82,3 +87,50 @@ type GuildDeleteEvent struct {
 }
 
 func (e *GuildDeleteEvent) Type() string { return EventGuildDelete }
+
+// VoiceStateUpdateEvent fires when a user joins, leaves, or moves between
+// voice channels.
+type VoiceStateUpdateEvent struct {
+	GuildID   string `json:"guild_id,omitempty"`
+	ChannelID string `json:"channel_id,omitempty"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (e *VoiceStateUpdateEvent) Type() string { return EventVoiceStateUpdate }
+
+// VoiceServerUpdateEvent carries the voice server the bot should connect to.
+// Endpoint is empty until Discord has allocated one.
+type VoiceServerUpdateEvent struct {
+	Token    string `json:"token"`
+	GuildID  string `json:"guild_id"`
+	Endpoint string `json:"endpoint"`
+}
+
+func (e *VoiceServerUpdateEvent) Type() string { return EventVoiceServerUpdate }
+
+// GuildMemberAddEvent fires when a user joins a guild. It needs the
+// GUILD_MEMBERS privileged intent, as do the other member events.
+type GuildMemberAddEvent struct {
+	*types.Member
+	GuildID string `json:"guild_id"`
+}
+
+func (e *GuildMemberAddEvent) Type() string { return EventGuildMemberAdd }
+
+// GuildMemberUpdateEvent fires when a member's roles, nickname, or timeout
+// change.
+type GuildMemberUpdateEvent struct {
+	*types.Member
+	GuildID string `json:"guild_id"`
+}
+
+func (e *GuildMemberUpdateEvent) Type() string { return EventGuildMemberUpdate }
+
+// GuildMemberRemoveEvent fires when a user leaves or is removed from a guild.
+type GuildMemberRemoveEvent struct {
+	GuildID string      `json:"guild_id"`
+	User    *types.User `json:"user"`
+}
+
+func (e *GuildMemberRemoveEvent) Type() string { return EventGuildMemberRemove }
@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// identifyInterval is how often each max_concurrency bucket may IDENTIFY.
+const identifyInterval = 5 * time.Second
+
+// IdentifyLimiter spaces IDENTIFY payloads according to Discord's session
+// start rules: shard N belongs to bucket N % max_concurrency, and each bucket
+// may identify once every five seconds.
+type IdentifyLimiter struct {
+	maxConcurrency int
+	interval       time.Duration
+
+	mu   sync.Mutex
+	next map[int]time.Time
+}
+
+// NewIdentifyLimiter builds a limiter for the given max_concurrency (minimum 1).
+func NewIdentifyLimiter(maxConcurrency int) *IdentifyLimiter {
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+	return &IdentifyLimiter{
+		maxConcurrency: maxConcurrency,
+		interval:       identifyInterval,
+		next:           make(map[int]time.Time),
+	}
+}
+
+// MaxConcurrency returns the number of buckets that may identify in parallel.
+func (l *IdentifyLimiter) MaxConcurrency() int {
+	if l == nil {
+		return 1
+	}
+	return l.maxConcurrency
+}
+
+// Wait blocks until shardID may identify, reserving its bucket's next slot.
+func (l *IdentifyLimiter) Wait(ctx context.Context, shardID int) error {
+	if l == nil {
+		return nil
+	}
+	bucket := shardID % l.maxConcurrency
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[bucket]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[bucket] = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,30 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIdentifyLimiterSpacesBucket(t *testing.T) {
+	l := NewIdentifyLimiter(2)
+	l.interval = 50 * time.Millisecond
+	ctx := context.Background()
+
+	start := time.Now()
+	for _, shard := range []int{0, 1, 2} {
+		if err := l.Wait(ctx, shard); err != nil {
+			t.Fatalf("wait shard %d: %v", shard, err)
+		}
+	}
+	// Shards 0 and 1 are in different buckets; shard 2 waits behind shard 0.
+	if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+		t.Fatalf("shard 2 identified after %s, want >= interval", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Wait(cancelled, 0); err == nil {
+		t.Fatalf("expected context error while waiting")
+	}
+}
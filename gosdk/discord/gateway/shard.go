@@ -6,12 +6,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/yourorg/arc-discord/gosdk/logger"
 )
 
-const defaultGatewayBotURL = "https://discord.com/api/v10/gateway/bot"
+const (
+	defaultGatewayBotURL = "https://discord.com/api/v10/gateway/bot"
+	gatewayQuery         = "/?v=10&encoding=json"
+
+	// ShardCountAuto asks the manager to use Discord's recommended shard count.
+	ShardCountAuto = 0
+)
 
 // Shard represents a gateway shard (ID + total + client).
 type Shard struct {
@@ -76,12 +86,18 @@ type ShardManager struct {
 	connectionOpts   []ConnectionOption
 	gatewayBotURL    string
 	gatewayBotClient *http.Client
+	gatewayURL       string
+	maxConcurrency   int
+	startsRemaining  int
+	startsResetAfter time.Duration
+	identifyInterval time.Duration
 
 	shards []*Shard
 	mu     sync.Mutex
 }
 
-// NewShardManager constructs a shard manager.
+// NewShardManager constructs a shard manager. A shardCount of ShardCountAuto
+// discovers the count from /gateway/bot when Connect is called.
 func NewShardManager(token string, shardCount int, intents int, opts ...ShardManagerOption) *ShardManager {
 	sm := &ShardManager{
 		token:            token,
@@ -91,6 +107,10 @@ func NewShardManager(token string, shardCount int, intents int, opts ...ShardMan
 		dispatcher:       NewDispatcher(),
 		gatewayBotURL:    defaultGatewayBotURL,
 		gatewayBotClient: http.DefaultClient,
+		gatewayURL:       defaultGatewayURL,
+		maxConcurrency:   1,
+		startsRemaining:  -1,
+		identifyInterval: identifyInterval,
 	}
 	for _, opt := range opts {
 		opt(sm)
@@ -98,39 +118,113 @@ func NewShardManager(token string, shardCount int, intents int, opts ...ShardMan
 	return sm
 }
 
-// Connect initializes and starts all shard clients.
+// Connect initializes and starts all shard clients. Shards in different
+// max_concurrency buckets start in parallel; within a bucket they identify
+// one at a time, five seconds apart. If any shard fails, the ones already
+// started are disconnected.
 func (sm *ShardManager) Connect(ctx context.Context) error {
 	sm.mu.Lock()
 	if len(sm.shards) > 0 {
 		sm.mu.Unlock()
 		return errors.New("shard manager already connected")
 	}
+	auto := sm.shardCount <= 0
 	sm.mu.Unlock()
 
-	for id := 0; id < sm.shardCount; id++ {
-		connOpts := append([]ConnectionOption{}, sm.connectionOpts...)
-		shardURL := fmt.Sprintf("%s&shard=%d,%d", defaultGatewayURL, id, sm.shardCount)
-		connOpts = append(connOpts, WithGatewayURL(shardURL))
-
-		client, err := NewClient(sm.token, sm.intents,
-			WithDispatcher(sm.dispatcher),
-			WithGatewayLogger(sm.logger),
-			WithConnectionOptions(connOpts...),
-		)
-		if err != nil {
-			return fmt.Errorf("init shard %d: %w", id, err)
-		}
-		if err := client.Connect(ctx); err != nil {
-			return fmt.Errorf("connect shard %d: %w", id, err)
+	if auto {
+		if err := sm.AutoScale(ctx, 0, &RecommendedSharding{}); err != nil {
+			return err
 		}
+	}
 
-		sm.mu.Lock()
-		sm.shards = append(sm.shards, &Shard{id: id, totalShards: sm.shardCount, client: client})
-		sm.mu.Unlock()
+	sm.mu.Lock()
+	count, concurrency := sm.shardCount, sm.maxConcurrency
+	remaining, resetAfter := sm.startsRemaining, sm.startsResetAfter
+	limiter := NewIdentifyLimiter(concurrency)
+	limiter.interval = sm.identifyInterval
+	sm.mu.Unlock()
+	if remaining >= 0 && remaining < count {
+		return fmt.Errorf("session start limit reached: %d of %d shard start(s) remaining, resets in %s", remaining, count, resetAfter)
 	}
+	if concurrency > count {
+		concurrency = count
+	}
+
+	startCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, concurrency)
+	)
+	for bucket := 0; bucket < concurrency; bucket++ {
+		wg.Add(1)
+		go func(bucket int) {
+			defer wg.Done()
+			for id := bucket; id < count && startCtx.Err() == nil; id += concurrency {
+				shard, err := sm.startShard(ctx, id, count, limiter)
+				if err != nil {
+					errs[bucket] = err
+					cancel()
+					return
+				}
+				sm.mu.Lock()
+				sm.shards = append(sm.shards, shard)
+				sm.mu.Unlock()
+			}
+		}(bucket)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		_ = sm.Disconnect()
+		return err
+	}
+	sm.mu.Lock()
+	sort.Slice(sm.shards, func(i, j int) bool { return sm.shards[i].id < sm.shards[j].id })
+	sm.mu.Unlock()
+	sm.logger.Info("gateway shards connected", "shards", count, "max_concurrency", concurrency)
 	return nil
 }
 
+// startShard connects shard id; its IDENTIFY waits for a slot in limiter.
+func (sm *ShardManager) startShard(ctx context.Context, id, count int, limiter *IdentifyLimiter) (*Shard, error) {
+	connOpts := append([]ConnectionOption{WithGatewayURL(sm.gatewayURL)}, sm.connectionOpts...)
+	client, err := NewClient(sm.token, sm.intents,
+		WithDispatcher(sm.dispatcher),
+		WithGatewayLogger(sm.logger),
+		WithConnectionOptions(connOpts...),
+		WithShard(id, count),
+		WithIdentifyLimiter(limiter),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("init shard %d: %w", id, err)
+	}
+	if err := client.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("connect shard %d: %w", id, err)
+	}
+	return &Shard{id: id, totalShards: count, client: client}, nil
+}
+
+// ShardCount returns the configured or discovered number of shards.
+func (sm *ShardManager) ShardCount() int {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.shardCount
+}
+
+// ShardForGuild returns the shard that receives events for guildID:
+// (guild_id >> 22) % shard_count.
+func ShardForGuild(guildID string, shardCount int) (int, error) {
+	if shardCount <= 0 {
+		return 0, errors.New("shard count must be positive")
+	}
+	id, err := strconv.ParseUint(guildID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid guild id %q", guildID)
+	}
+	return int((id >> 22) % uint64(shardCount)), nil
+}
+
 // Disconnect closes all shard clients.
 func (sm *ShardManager) Disconnect() error {
 	sm.mu.Lock()
@@ -198,6 +292,19 @@ func (sm *ShardManager) AutoScale(ctx context.Context, guildCount int, strategy
 	if err != nil {
 		return fmt.Errorf("fetch gateway bot info: %w", err)
 	}
+	sm.mu.Lock()
+	if info.URL != "" {
+		sm.gatewayURL = strings.TrimSuffix(info.URL, "/") + gatewayQuery
+	}
+	if info.SessionStartLimit.MaxConcurrency > 0 {
+		sm.maxConcurrency = info.SessionStartLimit.MaxConcurrency
+	}
+	if info.SessionStartLimit.Total > 0 {
+		sm.startsRemaining = info.SessionStartLimit.Remaining
+		sm.startsResetAfter = time.Duration(info.SessionStartLimit.ResetAfter) * time.Millisecond
+	}
+	sm.mu.Unlock()
+
 	if setter, ok := strategy.(interface{ SetRecommended(int) }); ok {
 		setter.SetRecommended(info.Shards)
 	}
@@ -260,9 +367,10 @@ type GatewayBotInfo struct {
 	URL               string `json:"url"`
 	Shards            int    `json:"shards"`
 	SessionStartLimit struct {
-		Total      int `json:"total"`
-		Remaining  int `json:"remaining"`
-		ResetAfter int `json:"reset_after"`
+		Total          int `json:"total"`
+		Remaining      int `json:"remaining"`
+		ResetAfter     int `json:"reset_after"`
+		MaxConcurrency int `json:"max_concurrency"`
 	} `json:"session_start_limit"`
 }
 
@@ -5,7 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
 
 	"github.com/yourorg/arc-discord/gosdk/logger"
 )
@@ -52,3 +57,100 @@ func TestFetchGatewayBotInfoError(t *testing.T) {
 		t.Fatalf("expected error for non-200 response")
 	}
 }
+
+func TestShardForGuild(t *testing.T) {
+	// 175928847299117063 >> 22 = 41944705796.
+	got, err := ShardForGuild("175928847299117063", 16)
+	if err != nil || got != int(41944705796%16) {
+		t.Fatalf("ShardForGuild = %d, %v", got, err)
+	}
+	if _, err := ShardForGuild("abc", 2); err == nil {
+		t.Fatalf("expected error for invalid guild id")
+	}
+}
+
+func TestConnectAutoShardsIdentifiesPerBucket(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		identifies = map[int]time.Time{}
+	)
+	upgrader := websocket.Upgrader{}
+	ws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var payload Payload
+		if err := conn.ReadJSON(&payload); err != nil || payload.Op != OpCodeIdentify {
+			return
+		}
+		var identify IdentifyPayload
+		_ = json.Unmarshal(payload.D, &identify)
+		if len(identify.Shard) == 2 && identify.Shard[1] == 3 {
+			mu.Lock()
+			identifies[identify.Shard[0]] = time.Now()
+			mu.Unlock()
+		}
+	}))
+	defer ws.Close()
+
+	info := GatewayBotInfo{URL: wsURL(ws), Shards: 3}
+	info.SessionStartLimit.Total = 1000
+	info.SessionStartLimit.Remaining = 1000
+	info.SessionStartLimit.MaxConcurrency = 2
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(info)
+	}))
+	defer api.Close()
+
+	sm := NewShardManager("token", ShardCountAuto, 0, WithShardGatewayBotURL(api.URL))
+	sm.identifyInterval = 100 * time.Millisecond
+	if err := sm.Connect(context.Background()); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer sm.Disconnect()
+	if sm.ShardCount() != 3 || sm.maxConcurrency != 2 {
+		t.Fatalf("unexpected discovery: shards=%d concurrency=%d", sm.ShardCount(), sm.maxConcurrency)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(identifies)
+		mu.Unlock()
+		if n == 3 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(identifies) != 3 {
+		t.Fatalf("expected 3 identifies, got %v", identifies)
+	}
+	// Shards 0 and 2 share bucket 0 and must be spaced by the interval.
+	if gap := identifies[2].Sub(identifies[0]); gap < 90*time.Millisecond {
+		t.Fatalf("bucket 0 identified %s apart", gap)
+	}
+	if gap := identifies[1].Sub(identifies[0]); gap > 90*time.Millisecond || gap < -90*time.Millisecond {
+		t.Fatalf("buckets 0 and 1 should identify in parallel, gap %s", gap)
+	}
+}
+
+func TestConnectRespectsSessionStartLimit(t *testing.T) {
+	info := GatewayBotInfo{URL: "wss://example", Shards: 4}
+	info.SessionStartLimit.Total = 1000
+	info.SessionStartLimit.Remaining = 2
+	info.SessionStartLimit.ResetAfter = 60000
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(info)
+	}))
+	defer api.Close()
+
+	sm := NewShardManager("token", ShardCountAuto, 0, WithShardGatewayBotURL(api.URL))
+	err := sm.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "session start limit") {
+		t.Fatalf("expected session start limit error, got %v", err)
+	}
+}
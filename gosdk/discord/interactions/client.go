@@ -99,6 +99,41 @@ func (ic *InteractionClient) CreateFollowupMessage(ctx context.Context, applicat
 	return &msg, nil
 }
 
+// EditOriginalInteractionResponseWithFiles updates the original response and
+// attaches files to it.
+func (ic *InteractionClient) EditOriginalInteractionResponseWithFiles(ctx context.Context, applicationID, token string, params *types.MessageEditParams, files []client.File) (*types.Message, error) {
+	if err := ensureAppAndToken(applicationID, token); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		return nil, &types.ValidationError{Field: "params", Message: "message edit params are required"}
+	}
+
+	path := fmt.Sprintf("%s/messages/@original", ic.webhookPath(applicationID, token))
+	var msg types.Message
+	if err := ic.base.Patch(ctx, path, &client.MultipartBody{Payload: params, Files: files}, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// CreateFollowupMessageWithFiles sends a follow-up message with files.
+func (ic *InteractionClient) CreateFollowupMessageWithFiles(ctx context.Context, applicationID, token string, params *types.MessageCreateParams, files []client.File) (*types.Message, error) {
+	if err := ensureAppAndToken(applicationID, token); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		return nil, &types.ValidationError{Field: "params", Message: "message create params are required"}
+	}
+
+	path := ic.webhookPath(applicationID, token) + buildWaitQuery()
+	var msg types.Message
+	if err := ic.base.Post(ctx, path, &client.MultipartBody{Payload: params, Files: files}, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
 // EditFollowupMessage updates an existing follow-up message.
 func (ic *InteractionClient) EditFollowupMessage(ctx context.Context, applicationID, token, messageID string, params *types.MessageEditParams) (*types.Message, error) {
 	if err := ensureAppAndToken(applicationID, token); err != nil {
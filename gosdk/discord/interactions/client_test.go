@@ -171,3 +171,33 @@ func (t *testTracker) Wait(ctx context.Context, route string) error { return nil
 func (t *testTracker) Update(route string, headers http.Header)     {}
 func (t *testTracker) GetBucket(route string) *ratelimit.Bucket     { return nil }
 func (t *testTracker) Clear()                                       {}
+
+func TestInteractionClientEditOriginalWithFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/webhooks/app/token/messages/@original" {
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart: %v", err)
+		}
+		var payload types.MessageEditParams
+		if err := json.Unmarshal([]byte(r.FormValue("payload_json")), &payload); err != nil || payload.Content != "report" {
+			t.Fatalf("unexpected payload_json %q", r.FormValue("payload_json"))
+		}
+		files := r.MultipartForm.File["files[0]"]
+		if len(files) != 1 || files[0].Filename != "report.txt" || files[0].Size != 2 {
+			t.Fatalf("unexpected files %+v", r.MultipartForm.File)
+		}
+		_ = json.NewEncoder(w).Encode(types.Message{ID: "123", Content: payload.Content})
+	}))
+	defer server.Close()
+
+	ic := NewInteractionClient(newInteractionTestClient(t, server.URL))
+	msg, err := ic.EditOriginalInteractionResponseWithFiles(context.Background(), "app", "token", &types.MessageEditParams{Content: "report"}, []client.File{{Name: "report.txt", Data: []byte("ok")}})
+	if err != nil {
+		t.Fatalf("EditOriginalInteractionResponseWithFiles error: %v", err)
+	}
+	if msg.ID != "123" {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+}
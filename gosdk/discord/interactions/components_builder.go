@@ -34,6 +34,16 @@ func NewLinkButton(label, url string) *ButtonBuilder {
 	}
 }
 
+// NewPremiumButton creates a button that opens the purchase flow for a SKU.
+func NewPremiumButton(skuID string) *ButtonBuilder {
+	return &ButtonBuilder{
+		button: &types.Button{
+			Style: types.ButtonStylePremium,
+			SKUID: skuID,
+		},
+	}
+}
+
 // SetEmoji sets the button emoji.
 func (b *ButtonBuilder) SetEmoji(emoji *types.Emoji) *ButtonBuilder {
 	if b.button != nil {
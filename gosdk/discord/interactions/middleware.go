@@ -0,0 +1,403 @@
+package interactions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/permissions"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+// MiddlewareOptions are the settings a named middleware is configured with,
+// as decoded from YAML or JSON.
+type MiddlewareOptions map[string]any
+
+// MiddlewareFactory constructs a middleware from its options.
+type MiddlewareFactory func(opts MiddlewareOptions) (Middleware, error)
+
+var (
+	middlewareMu        sync.RWMutex
+	middlewareFactories = map[string]MiddlewareFactory{}
+)
+
+func init() {
+	RegisterMiddleware("log", func(opts MiddlewareOptions) (Middleware, error) {
+		return Logging(logger.Default()), nil
+	})
+	RegisterMiddleware("rate_limit", func(opts MiddlewareOptions) (Middleware, error) {
+		limit, err := opts.Int("limit", 1)
+		if err != nil {
+			return nil, err
+		}
+		per, err := opts.Duration("per", time.Minute)
+		if err != nil {
+			return nil, err
+		}
+		if limit < 1 || per <= 0 {
+			return nil, fmt.Errorf("rate_limit needs a positive limit and per")
+		}
+		return RateLimitPerUser(limit, per, opts.String("message")), nil
+	})
+	RegisterMiddleware("roles", func(opts MiddlewareOptions) (Middleware, error) {
+		roles, err := opts.Strings("any")
+		if err != nil {
+			return nil, err
+		}
+		if len(roles) == 0 {
+			return nil, fmt.Errorf("roles needs at least one role ID in any")
+		}
+		return RequireRoles(roles, opts.String("message")), nil
+	})
+	RegisterMiddleware("permissions", func(opts MiddlewareOptions) (Middleware, error) {
+		names, err := opts.Strings("require")
+		if err != nil {
+			return nil, err
+		}
+		mask, err := permissionMask(names)
+		if err != nil {
+			return nil, err
+		}
+		if mask == 0 {
+			return nil, fmt.Errorf("permissions needs at least one permission in require")
+		}
+		return RequirePermissions(mask, opts.String("message")), nil
+	})
+	RegisterMiddleware("guilds", func(opts MiddlewareOptions) (Middleware, error) {
+		allow, err := opts.Strings("allow")
+		if err != nil {
+			return nil, err
+		}
+		if len(allow) == 0 {
+			return nil, fmt.Errorf("guilds needs at least one guild ID in allow")
+		}
+		return GuildAllowlist(allow, opts.String("message")), nil
+	})
+}
+
+// RegisterMiddleware makes a middleware available to NewMiddleware, and so
+// to handler configuration. Registering a name twice replaces the earlier
+// factory.
+func RegisterMiddleware(name string, factory MiddlewareFactory) {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+	middlewareFactories[strings.ToLower(name)] = factory
+}
+
+// MiddlewareNames returns the registered middleware names in sorted order.
+func MiddlewareNames() []string {
+	middlewareMu.RLock()
+	defer middlewareMu.RUnlock()
+	names := make([]string, 0, len(middlewareFactories))
+	for name := range middlewareFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewMiddleware constructs the middleware registered under name.
+func NewMiddleware(name string, opts MiddlewareOptions) (Middleware, error) {
+	middlewareMu.RLock()
+	factory, ok := middlewareFactories[strings.ToLower(strings.TrimSpace(name))]
+	middlewareMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown middleware %q (available: %s)", name, strings.Join(MiddlewareNames(), ", "))
+	}
+	m, err := factory(opts)
+	if err != nil {
+		return nil, fmt.Errorf("middleware %s: %w", name, err)
+	}
+	return m, nil
+}
+
+// Chain wraps handler so the middleware run in order, first outermost.
+func Chain(handler Handler, middleware ...Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			handler = middleware[i](handler)
+		}
+	}
+	return handler
+}
+
+// Logging logs each interaction with its outcome and latency.
+func Logging(l *logger.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			start := time.Now()
+			resp, err := next(ctx, i)
+			fields := []interface{}{"type", int(i.Type), "route", routeName(i), "user", InteractionUserID(i), "guild", i.GuildID, "duration", time.Since(start).Round(time.Millisecond).String()}
+			if err != nil {
+				l.Warn("interaction failed", append(fields, "error", err)...)
+			} else {
+				l.Info("interaction handled", fields...)
+			}
+			return resp, err
+		}
+	}
+}
+
+const (
+	defaultRateLimitMessage    = "You're doing that too often. Try again in %s."
+	defaultGuildMessage        = "This isn't available in this server."
+	defaultUnauthorizedMessage = "You're not authorized to use this."
+)
+
+// RateLimitPerUser allows each user limit interactions per window and
+// replies ephemerally to the rest. message may contain a %s for the wait.
+func RateLimitPerUser(limit int, per time.Duration, message string) Middleware {
+	if message == "" {
+		message = defaultRateLimitMessage
+	}
+	limiter := &userRateLimiter{limit: limit, per: per, hits: map[string][]time.Time{}}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			user := InteractionUserID(i)
+			if user == "" {
+				return next(ctx, i)
+			}
+			if wait := limiter.allow(user, time.Now()); wait > 0 {
+				return rejectInteraction(i, formatWait(message, wait))
+			}
+			return next(ctx, i)
+		}
+	}
+}
+
+// userRateLimiter keeps each user's interaction times within the window.
+// Users idle for a whole window are swept, so a long-running server only
+// holds entries for recently active users.
+type userRateLimiter struct {
+	limit int
+	per   time.Duration
+
+	mu    sync.Mutex
+	hits  map[string][]time.Time
+	swept time.Time
+}
+
+// allow records an interaction by user at now, or returns how long the user
+// must wait when they are over the limit.
+func (l *userRateLimiter) allow(user string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.swept) >= l.per {
+		for id, times := range l.hits {
+			if len(times) == 0 || now.Sub(times[len(times)-1]) >= l.per {
+				delete(l.hits, id)
+			}
+		}
+		l.swept = now
+	}
+	recent := l.hits[user][:0]
+	for _, t := range l.hits[user] {
+		if now.Sub(t) < l.per {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= l.limit {
+		l.hits[user] = recent
+		return l.per - now.Sub(recent[0])
+	}
+	l.hits[user] = append(recent, now)
+	return 0
+}
+
+// GuildAllowlist only passes interactions from the listed guilds; DMs and
+// other guilds get message ephemerally.
+func GuildAllowlist(guildIDs []string, message string) Middleware {
+	if message == "" {
+		message = defaultGuildMessage
+	}
+	allowed := make(map[string]bool, len(guildIDs))
+	for _, id := range guildIDs {
+		allowed[id] = true
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			if !allowed[i.GuildID] {
+				return rejectInteraction(i, message)
+			}
+			return next(ctx, i)
+		}
+	}
+}
+
+// RequireRoles only passes members holding at least one of roleIDs; others,
+// including DMs, get message ephemerally.
+func RequireRoles(roleIDs []string, message string) Middleware {
+	if message == "" {
+		message = defaultUnauthorizedMessage
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			if i.Member != nil {
+				for _, have := range i.Member.Roles {
+					for _, want := range roleIDs {
+						if have == want {
+							return next(ctx, i)
+						}
+					}
+				}
+			}
+			return rejectInteraction(i, message)
+		}
+	}
+}
+
+// RequirePermissions only passes members whose channel permissions include
+// every bit in mask; others, including DMs, get message ephemerally.
+func RequirePermissions(mask permissions.Permission, message string) Middleware {
+	if message == "" {
+		message = defaultUnauthorizedMessage
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+			if i.Member == nil || !permissions.PermissionFromString(i.Member.Permissions).Has(mask) {
+				return rejectInteraction(i, message)
+			}
+			return next(ctx, i)
+		}
+	}
+}
+
+func permissionMask(names []string) (permissions.Permission, error) {
+	var mask permissions.Permission
+	for _, name := range names {
+		perm, err := permissions.ParsePermission(name)
+		if err != nil {
+			return 0, err
+		}
+		mask = mask.Add(perm)
+	}
+	return mask, nil
+}
+
+// InteractionUserID returns the invoking user's ID from the member (guilds)
+// or user (DMs) field.
+func InteractionUserID(i *types.Interaction) string {
+	switch {
+	case i.Member != nil && i.Member.User != nil:
+		return i.Member.User.ID
+	case i.User != nil:
+		return i.User.ID
+	default:
+		return ""
+	}
+}
+
+// rejectInteraction answers without running the handler. Autocomplete
+// cannot carry a message, so it gets a single placeholder choice.
+func rejectInteraction(i *types.Interaction, message string) (*types.InteractionResponse, error) {
+	if i.Type == types.InteractionTypeApplicationCommandAutocomplete {
+		name := message
+		if r := []rune(name); len(r) > 100 {
+			name = string(r[:100])
+		}
+		return &types.InteractionResponse{
+			Type: types.InteractionResponseAutocompleteResult,
+			Data: &types.InteractionApplicationCommandCallbackData{Choices: []types.AutocompleteChoice{{Name: name, Value: "-"}}},
+		}, nil
+	}
+	return NewMessageResponse(message).SetEphemeral(true).Build()
+}
+
+func routeName(i *types.Interaction) string {
+	if i.Data == nil {
+		return ""
+	}
+	if i.Data.CustomID != "" {
+		return i.Data.CustomID
+	}
+	return i.Data.Name
+}
+
+func formatWait(message string, wait time.Duration) string {
+	if !strings.Contains(message, "%s") {
+		return message
+	}
+	return fmt.Sprintf(message, wait.Round(time.Second).String())
+}
+
+// String returns the string option key, or "".
+func (o MiddlewareOptions) String(key string) string {
+	s, _ := o[key].(string)
+	return s
+}
+
+// Int returns the integer option key, or def when unset.
+func (o MiddlewareOptions) Int(key string, def int) (int, error) {
+	switch v := o[key].(type) {
+	case nil:
+		return def, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("%s must be a whole number", key)
+		}
+		return int(v), nil
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a number", key)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("%s must be a number", key)
+	}
+}
+
+// Duration returns the duration option key ("30s", "1m"), or def when unset.
+func (o MiddlewareOptions) Duration(key string, def time.Duration) (time.Duration, error) {
+	switch v := o[key].(type) {
+	case nil:
+		return def, nil
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", key, err)
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("%s must be a duration such as 30s", key)
+	}
+}
+
+// Strings returns the string list option key. A single string is a one-item
+// list; integers are formatted, since YAML reads unquoted IDs as numbers.
+func (o MiddlewareOptions) Strings(key string) ([]string, error) {
+	switch v := o[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			switch s := item.(type) {
+			case string:
+				out = append(out, s)
+			case int, int64:
+				out = append(out, fmt.Sprint(s))
+			default:
+				return nil, fmt.Errorf("%s must be a list of strings", key)
+			}
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%s must be a list of strings", key)
+	}
+}
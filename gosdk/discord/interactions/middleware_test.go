@@ -0,0 +1,159 @@
+package interactions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func okHandler(calls *int) Handler {
+	return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		*calls++
+		return NewMessageResponse("ok").Build()
+	}
+}
+
+func userInteraction(user, guild string) *types.Interaction {
+	return &types.Interaction{
+		Type:    types.InteractionTypeApplicationCommand,
+		GuildID: guild,
+		Member:  &types.Member{User: &types.User{ID: user}},
+		Data:    &types.InteractionData{Name: "deploy"},
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var order string
+	mark := func(s string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+				order += s
+				return next(ctx, i)
+			}
+		}
+	}
+	var calls int
+	_, _ = Chain(okHandler(&calls), mark("A"), nil, mark("B"))(context.Background(), userInteraction("u", "g"))
+	if order != "AB" || calls != 1 {
+		t.Fatalf("unexpected chain order %q (%d calls)", order, calls)
+	}
+}
+
+func TestRateLimitPerUser(t *testing.T) {
+	var calls int
+	handler := RateLimitPerUser(2, time.Minute, "")(okHandler(&calls))
+	for n := 0; n < 3; n++ {
+		resp, err := handler(context.Background(), userInteraction("u1", "g"))
+		if err != nil {
+			t.Fatalf("handler error: %v", err)
+		}
+		if n == 2 && (resp.Data.Flags&interactionResponseFlagEphemeral == 0 || !strings.Contains(resp.Data.Content, "Try again in")) {
+			t.Fatalf("expected ephemeral rate limit reply, got %+v", resp.Data)
+		}
+	}
+	if _, err := handler(context.Background(), userInteraction("u2", "g")); err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls through the limiter, got %d", calls)
+	}
+}
+
+func TestRateLimitPerUserForgetsIdleUsers(t *testing.T) {
+	limiter := &userRateLimiter{limit: 2, per: time.Minute, hits: map[string][]time.Time{}}
+	start := time.Unix(1700000000, 0)
+	for n := 0; n < 100; n++ {
+		limiter.allow(fmt.Sprintf("u%d", n), start)
+	}
+	limiter.allow("active", start.Add(30*time.Second))
+	if len(limiter.hits) != 101 {
+		t.Fatalf("expected 101 tracked users within the window, got %d", len(limiter.hits))
+	}
+	limiter.allow("active", start.Add(2*time.Minute))
+	if len(limiter.hits) != 1 {
+		t.Fatalf("expected idle users to be swept, %d remain", len(limiter.hits))
+	}
+}
+
+func TestGuildAllowlist(t *testing.T) {
+	var calls int
+	handler := GuildAllowlist([]string{"g1"}, "nope")(okHandler(&calls))
+	if resp, _ := handler(context.Background(), userInteraction("u", "g2")); resp.Data.Content != "nope" {
+		t.Fatalf("expected rejection, got %+v", resp.Data)
+	}
+	autocomplete := userInteraction("u", "")
+	autocomplete.Type = types.InteractionTypeApplicationCommandAutocomplete
+	if resp, _ := handler(context.Background(), autocomplete); resp.Type != types.InteractionResponseAutocompleteResult || resp.Data.Choices[0].Name != "nope" {
+		t.Fatalf("expected autocomplete placeholder, got %+v", resp)
+	}
+	if _, _ = handler(context.Background(), userInteraction("u", "g1")); calls != 1 {
+		t.Fatalf("expected allowed guild to pass, got %d calls", calls)
+	}
+}
+
+func TestNewMiddleware(t *testing.T) {
+	if _, err := NewMiddleware("rate_limit", MiddlewareOptions{"limit": 3, "per": "30s"}); err != nil {
+		t.Fatalf("rate_limit: %v", err)
+	}
+	if _, err := NewMiddleware("rate_limit", MiddlewareOptions{"per": "soon"}); err == nil {
+		t.Fatal("expected error for invalid duration")
+	}
+	if _, err := NewMiddleware("guilds", MiddlewareOptions{"allow": []any{"1", 2}}); err != nil {
+		t.Fatalf("guilds: %v", err)
+	}
+	if _, err := NewMiddleware("audit", nil); err == nil || !strings.Contains(err.Error(), "available: guilds, log, permissions, rate_limit, roles") {
+		t.Fatalf("expected unknown middleware error, got %v", err)
+	}
+
+	RegisterMiddleware("audit", func(opts MiddlewareOptions) (Middleware, error) {
+		return func(next Handler) Handler { return next }, nil
+	})
+	defer func() {
+		middlewareMu.Lock()
+		delete(middlewareFactories, "audit")
+		middlewareMu.Unlock()
+	}()
+	if _, err := NewMiddleware("Audit", nil); err != nil {
+		t.Fatalf("registered middleware: %v", err)
+	}
+}
+
+func TestRequireRolesAndPermissions(t *testing.T) {
+	var calls int
+	roles := RequireRoles([]string{"r-admin", "r-ops"}, "")(okHandler(&calls))
+	member := userInteraction("u", "g")
+	member.Member.Roles = []string{"r-everyone"}
+	if resp, _ := roles(context.Background(), member); resp.Data.Content != defaultUnauthorizedMessage || resp.Data.Flags&interactionResponseFlagEphemeral == 0 {
+		t.Fatalf("expected ephemeral not authorized reply, got %+v", resp.Data)
+	}
+	member.Member.Roles = append(member.Member.Roles, "r-ops")
+	if _, _ = roles(context.Background(), member); calls != 1 {
+		t.Fatalf("expected member with r-ops to pass, got %d calls", calls)
+	}
+
+	perms, err := NewMiddleware("permissions", MiddlewareOptions{"require": []string{"manage_guild", "KickMembers"}, "message": "admins only"})
+	if err != nil {
+		t.Fatalf("permissions: %v", err)
+	}
+	handler := perms(okHandler(&calls))
+	member.Member.Permissions = "32" // ManageGuild only
+	if resp, _ := handler(context.Background(), member); resp.Data.Content != "admins only" {
+		t.Fatalf("expected rejection without KickMembers, got %+v", resp.Data)
+	}
+	member.Member.Permissions = "34"
+	if _, _ = handler(context.Background(), member); calls != 2 {
+		t.Fatalf("expected member with both permissions to pass, got %d calls", calls)
+	}
+	dm := &types.Interaction{Type: types.InteractionTypeApplicationCommand, User: &types.User{ID: "u"}}
+	if resp, _ := handler(context.Background(), dm); resp.Data.Content != "admins only" {
+		t.Fatalf("expected DM rejection, got %+v", resp.Data)
+	}
+
+	if _, err := NewMiddleware("permissions", MiddlewareOptions{"require": "launch_rockets"}); err == nil {
+		t.Fatal("expected error for unknown permission")
+	}
+}
@@ -17,6 +17,7 @@ type Router struct {
 	modals            map[string]Handler
 	autocomplete      map[string]Handler
 	componentPatterns []patternHandler
+	modalPatterns     []patternHandler
 	middleware        []Middleware
 }
 
@@ -74,6 +75,21 @@ func (r *Router) ComponentPattern(pattern string, handler Handler) {
 	})
 }
 
+// ModalPattern registers a handler with a regex pattern that matches modal custom IDs.
+func (r *Router) ModalPattern(pattern string, handler Handler) {
+	if r == nil || pattern == "" || handler == nil {
+		return
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return
+	}
+	r.modalPatterns = append(r.modalPatterns, patternHandler{
+		pattern: re,
+		handler: handler,
+	})
+}
+
 // Modal registers a handler for a modal custom ID.
 func (r *Router) Modal(customID string, handler Handler) {
 	if r == nil || customID == "" || handler == nil {
@@ -109,18 +125,16 @@ func (r *Router) Resolve(interaction *types.Interaction) Handler {
 		}
 		handler = r.components[interaction.Data.CustomID]
 		if handler == nil {
-			for _, pattern := range r.componentPatterns {
-				if pattern.pattern.MatchString(interaction.Data.CustomID) {
-					handler = pattern.handler
-					break
-				}
-			}
+			handler = matchPattern(r.componentPatterns, interaction.Data.CustomID)
 		}
 	case types.InteractionTypeModalSubmit:
 		if interaction.Data.CustomID == "" {
 			return nil
 		}
 		handler = r.modals[interaction.Data.CustomID]
+		if handler == nil {
+			handler = matchPattern(r.modalPatterns, interaction.Data.CustomID)
+		}
 	case types.InteractionTypeApplicationCommandAutocomplete:
 		if interaction.Data.Name == "" {
 			return nil
@@ -137,6 +151,17 @@ func (r *Router) Resolve(interaction *types.Interaction) Handler {
 	return r.applyMiddleware(handler)
 }
 
+// matchPattern returns the first registered handler whose pattern matches
+// customID; exact registrations are checked before patterns.
+func matchPattern(patterns []patternHandler, customID string) Handler {
+	for _, pattern := range patterns {
+		if pattern.pattern.MatchString(customID) {
+			return pattern.handler
+		}
+	}
+	return nil
+}
+
 func (r *Router) applyMiddleware(handler Handler) Handler {
 	wrapped := handler
 	for i := len(r.middleware) - 1; i >= 0; i-- {
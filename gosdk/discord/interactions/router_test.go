@@ -37,6 +37,30 @@ func TestRouterComponentPattern(t *testing.T) {
 	}
 }
 
+func TestRouterModalPatternAfterExact(t *testing.T) {
+	router := NewRouter()
+	var hit string
+	router.Modal("feedback:general", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		hit = "exact"
+		return nil, nil
+	})
+	router.ModalPattern(`^feedback:`, func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		hit = "pattern"
+		return nil, nil
+	})
+
+	for customID, want := range map[string]string{"feedback:general": "exact", "feedback:PR-12": "pattern"} {
+		handler := router.Resolve(&types.Interaction{Type: types.InteractionTypeModalSubmit, Data: &types.InteractionData{CustomID: customID}})
+		if handler == nil {
+			t.Fatalf("expected handler for %s", customID)
+		}
+		_, _ = handler(context.Background(), nil)
+		if hit != want {
+			t.Fatalf("%s: got %s handler, want %s", customID, hit, want)
+		}
+	}
+}
+
 func TestRouterMiddleware(t *testing.T) {
 	router := NewRouter()
 
@@ -3,12 +3,13 @@ package interactions
 import (
 	"context"
 	"crypto/ed25519"
-	"encoding/hex"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"net/http"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 	"github.com/yourorg/arc-discord/gosdk/logger"
@@ -29,6 +30,11 @@ type Server struct {
 	dryRun    bool
 	router    *Router
 
+	timestampWindow time.Duration
+	clockSkew       time.Duration
+	onReject        func(reason string)
+	maxBodyBytes    int64
+
 	commandHandlers      map[string]Handler
 	componentHandlers    map[string]Handler
 	modalHandlers        map[string]Handler
@@ -54,6 +60,38 @@ func WithDryRun(enabled bool) ServerOption {
 	}
 }
 
+// Rejection reasons passed to a WithRejectionObserver callback.
+const (
+	RejectSignature      = "signature"
+	RejectStaleTimestamp = "stale_timestamp"
+)
+
+// WithTimestampWindow rejects signed requests whose timestamp is more than
+// window old, tolerating skew of clock drift either way. Zero disables the
+// check.
+func WithTimestampWindow(window, skew time.Duration) ServerOption {
+	return func(s *Server) {
+		s.timestampWindow = window
+		s.clockSkew = skew
+	}
+}
+
+// WithRejectionObserver is called with a Reject* reason for every request
+// refused before routing, e.g. to count them.
+func WithRejectionObserver(fn func(reason string)) ServerOption {
+	return func(s *Server) {
+		s.onReject = fn
+	}
+}
+
+// WithMaxBodyBytes answers 413 to requests with bodies over n bytes instead
+// of reading them in full. Zero leaves bodies unbounded.
+func WithMaxBodyBytes(n int64) ServerOption {
+	return func(s *Server) {
+		s.maxBodyBytes = n
+	}
+}
+
 // WithRouter injects a custom router implementation.
 func WithRouter(r *Router) ServerOption {
 	return func(s *Server) {
@@ -65,16 +103,13 @@ func WithRouter(r *Router) ServerOption {
 
 // NewServer constructs a new interaction server.
 func NewServer(publicKey string, opts ...ServerOption) (*Server, error) {
-	pubBytes, err := hex.DecodeString(strings.TrimSpace(publicKey))
+	pubKey, err := ParsePublicKey(publicKey)
 	if err != nil {
-		return nil, fmt.Errorf("invalid public key: %w", err)
-	}
-	if len(pubBytes) != ed25519.PublicKeySize {
-		return nil, fmt.Errorf("invalid public key length: expected %d bytes", ed25519.PublicKeySize)
+		return nil, err
 	}
 
 	s := &Server{
-		publicKey:            ed25519.PublicKey(pubBytes),
+		publicKey:            pubKey,
 		logger:               logger.Default(),
 		commandHandlers:      make(map[string]Handler),
 		componentHandlers:    make(map[string]Handler),
@@ -89,6 +124,14 @@ func NewServer(publicKey string, opts ...ServerOption) (*Server, error) {
 	return s, nil
 }
 
+// Use appends middleware that wraps every handler the server's router
+// resolves.
+func (s *Server) Use(m Middleware) {
+	if s.router != nil {
+		s.router.Use(m)
+	}
+}
+
 // RegisterCommand registers a handler for an application command (slash/user/message).
 func (s *Server) RegisterCommand(name string, handler Handler) {
 	if name == "" || handler == nil {
@@ -111,6 +154,33 @@ func (s *Server) RegisterComponent(customID string, handler Handler) {
 	}
 }
 
+// RegisterComponentPattern registers a handler for component custom IDs
+// matching the regular expression. Exact custom IDs take precedence, then
+// patterns in registration order.
+func (s *Server) RegisterComponentPattern(pattern string, handler Handler) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return err
+	}
+	if pattern == "" || handler == nil || s.router == nil {
+		return nil
+	}
+	s.router.ComponentPattern(pattern, handler)
+	return nil
+}
+
+// RegisterModalPattern registers a handler for modal custom IDs matching the
+// regular expression, after exact custom IDs.
+func (s *Server) RegisterModalPattern(pattern string, handler Handler) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return err
+	}
+	if pattern == "" || handler == nil || s.router == nil {
+		return nil
+	}
+	s.router.ModalPattern(pattern, handler)
+	return nil
+}
+
 // RegisterModal registers a handler for a modal custom ID.
 func (s *Server) RegisterModal(customID string, handler Handler) {
 	if customID == "" || handler == nil {
@@ -140,8 +210,16 @@ func (s *Server) HandleInteraction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.maxBodyBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.maxBodyBytes)
+	}
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
 		s.logger.Error("failed to read request body", "error", err)
 		http.Error(w, "invalid request body", http.StatusBadRequest)
 		return
@@ -191,19 +269,27 @@ func (s *Server) HandleInteraction(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) verifyRequest(r *http.Request, body []byte) bool {
-	signatureHex := r.Header.Get(signatureHeader)
 	timestamp := r.Header.Get(timestampHeader)
-	if signatureHex == "" || timestamp == "" {
-		return false
-	}
-
-	signature, err := hex.DecodeString(signatureHex)
+	err := VerifySignature(s.publicKey, r.Header.Get(signatureHeader), timestamp, body)
 	if err != nil {
+		s.logger.Debug("interaction signature rejected", "error", err)
+		s.reject(RejectSignature)
 		return false
 	}
+	if s.timestampWindow > 0 {
+		if err := VerifyTimestamp(timestamp, time.Now(), s.timestampWindow, s.clockSkew); err != nil {
+			s.logger.Warn("interaction timestamp rejected", "error", err)
+			s.reject(RejectStaleTimestamp)
+			return false
+		}
+	}
+	return true
+}
 
-	message := append([]byte(timestamp), body...)
-	return ed25519.Verify(s.publicKey, message, signature)
+func (s *Server) reject(reason string) {
+	if s.onReject != nil {
+		s.onReject(reason)
+	}
 }
 
 func (s *Server) resolveHandler(i *types.Interaction) Handler {
@@ -9,7 +9,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 )
@@ -157,6 +160,33 @@ func TestServerComponentHandler(t *testing.T) {
 	}
 }
 
+func TestServerComponentPatternHandler(t *testing.T) {
+	server, priv := newTestServer(t)
+	if err := server.RegisterComponentPattern(`^approve:(.+)$`, func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+		return NewMessageResponse("approved " + i.Data.CustomID).Build()
+	}); err != nil {
+		t.Fatalf("register pattern: %v", err)
+	}
+	if err := server.RegisterComponentPattern(`approve:(`, nil); err == nil {
+		t.Fatal("expected error for invalid pattern")
+	}
+
+	body, _ := json.Marshal(&types.Interaction{
+		Type: types.InteractionTypeMessageComponent,
+		Data: &types.InteractionData{CustomID: "approve:PR-1234"},
+	})
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body))
+
+	var resp types.InteractionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data == nil || resp.Data.Content != "approved approve:PR-1234" {
+		t.Fatalf("unexpected response payload %+v", resp.Data)
+	}
+}
+
 func TestServerModalHandler(t *testing.T) {
 	server, priv := newTestServer(t)
 	server.RegisterModal("modal_submit", func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
@@ -298,3 +328,51 @@ func newSignedRequest(t *testing.T, priv ed25519.PrivateKey, body []byte) *http.
 	req.Header.Set(signatureHeader, hex.EncodeToString(signature))
 	return req
 }
+
+func TestServerTimestampWindow(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	var reasons []string
+	server, err := NewServer(hex.EncodeToString(pub),
+		WithTimestampWindow(time.Minute, 5*time.Second),
+		WithRejectionObserver(func(reason string) { reasons = append(reasons, reason) }))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	body, _ := json.Marshal(&types.Interaction{Type: types.InteractionTypePing})
+
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, newSignedRequest(t, priv, body)) // signed in 2009
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected stale request to be rejected, got %d", rr.Code)
+	}
+
+	req := newSignedRequest(t, priv, body)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(signatureHeader, hex.EncodeToString(ed25519.Sign(priv, append([]byte(timestamp), body...))))
+	rr = httptest.NewRecorder()
+	server.HandleInteraction(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected fresh request to pass, got %d", rr.Code)
+	}
+
+	req = newSignedRequest(t, priv, body)
+	req.Header.Set(signatureHeader, strings.Repeat("00", ed25519.SignatureSize))
+	server.HandleInteraction(httptest.NewRecorder(), req)
+	if len(reasons) != 2 || reasons[0] != RejectStaleTimestamp || reasons[1] != RejectSignature {
+		t.Fatalf("unexpected rejection reasons %v", reasons)
+	}
+}
+
+func TestServerMaxBodyBytes(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	server, err := NewServer(hex.EncodeToString(pub), WithMaxBodyBytes(16))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	rr := httptest.NewRecorder()
+	server.HandleInteraction(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", 17))))
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", rr.Code)
+	}
+}
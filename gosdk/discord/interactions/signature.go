@@ -0,0 +1,65 @@
+package interactions
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signature verification failures reported by VerifySignature.
+var (
+	ErrMissingSignature   = errors.New("missing X-Signature-Ed25519 or X-Signature-Timestamp header")
+	ErrMalformedSignature = errors.New("signature is not a 64-byte hex string")
+	ErrSignatureMismatch  = errors.New("signature does not match timestamp+body for this public key")
+	ErrStaleTimestamp     = errors.New("X-Signature-Timestamp is outside the accepted window")
+)
+
+// ParsePublicKey decodes a hex-encoded Discord application public key.
+func ParsePublicKey(publicKey string) (ed25519.PublicKey, error) {
+	pubBytes, err := hex.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(pubBytes) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key length: expected %d bytes", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(pubBytes), nil
+}
+
+// VerifySignature checks a Discord interaction signature exactly as the
+// server does: Ed25519 over the timestamp header followed by the raw body.
+func VerifySignature(publicKey ed25519.PublicKey, signatureHex, timestamp string, body []byte) error {
+	if signatureHex == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return ErrMalformedSignature
+	}
+	message := make([]byte, 0, len(timestamp)+len(body))
+	message = append(message, timestamp...)
+	message = append(message, body...)
+	if !ed25519.Verify(publicKey, message, signature) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// VerifyTimestamp checks that a signature timestamp (Unix seconds, as
+// Discord sends it) is no more than window old, allowing skew in either
+// direction for clock drift. Signed requests replayed later fail it.
+func VerifyTimestamp(timestamp string, now time.Time, window, skew time.Duration) error {
+	secs, err := strconv.ParseInt(strings.TrimSpace(timestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not a Unix timestamp", ErrStaleTimestamp, timestamp)
+	}
+	age := now.Sub(time.Unix(secs, 0))
+	if age > window+skew || age < -skew {
+		return fmt.Errorf("%w: %s old", ErrStaleTimestamp, age.Round(time.Second))
+	}
+	return nil
+}
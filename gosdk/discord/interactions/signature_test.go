@@ -0,0 +1,81 @@
+package interactions
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerifySignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey error: %v", err)
+	}
+	body := []byte(`{"type":1}`)
+	timestamp := "1700000000"
+	signature := hex.EncodeToString(ed25519.Sign(priv, append([]byte(timestamp), body...)))
+
+	parsed, err := ParsePublicKey(hex.EncodeToString(pub))
+	if err != nil {
+		t.Fatalf("ParsePublicKey error: %v", err)
+	}
+	if err := VerifySignature(parsed, signature, timestamp, body); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+
+	cases := []struct {
+		name      string
+		signature string
+		timestamp string
+		body      []byte
+		want      error
+	}{
+		{name: "missing", signature: "", timestamp: timestamp, body: body, want: ErrMissingSignature},
+		{name: "malformed", signature: "zz", timestamp: timestamp, body: body, want: ErrMalformedSignature},
+		{name: "tampered body", signature: signature, timestamp: timestamp, body: []byte(`{"type":2}`), want: ErrSignatureMismatch},
+		{name: "wrong timestamp", signature: signature, timestamp: "1700000001", body: body, want: ErrSignatureMismatch},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := VerifySignature(parsed, tc.signature, tc.timestamp, tc.body); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestParsePublicKeyRejectsBadInput(t *testing.T) {
+	if _, err := ParsePublicKey("not-hex"); err == nil {
+		t.Fatal("expected error for non-hex key")
+	}
+	if _, err := ParsePublicKey("abcd"); err == nil {
+		t.Fatal("expected error for short key")
+	}
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cases := []struct {
+		timestamp string
+		ok        bool
+	}{
+		{"1700000000", true},
+		{"1699999700", true},  // 5m old, at the window
+		{"1699999695", true},  // within skew past the window
+		{"1699999690", false}, // replayed
+		{"1700000004", true},  // slightly ahead
+		{"1700000030", false}, // too far ahead
+		{"yesterday", false},
+	}
+	for _, tc := range cases {
+		err := VerifyTimestamp(tc.timestamp, now, 5*time.Minute, 5*time.Second)
+		if (err == nil) != tc.ok {
+			t.Fatalf("%s: got %v, want ok=%v", tc.timestamp, err, tc.ok)
+		}
+		if err != nil && !errors.Is(err, ErrStaleTimestamp) {
+			t.Fatalf("%s: expected ErrStaleTimestamp, got %v", tc.timestamp, err)
+		}
+	}
+}
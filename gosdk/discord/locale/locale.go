@@ -0,0 +1,285 @@
+// Package locale formats timestamps, relative times, and numbers for a
+// Discord locale, and exposes the same helpers as text/template functions.
+package locale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/utils"
+)
+
+// DefaultLocale is used when no locale is configured.
+const DefaultLocale = "en-US"
+
+// conventions describes how a locale writes dates, times, and numbers.
+type conventions struct {
+	date    string // Go layout for dates
+	clock   string // Go layout for times of day
+	group   string // thousands separator
+	decimal string // decimal separator
+}
+
+// known lists the Discord client locales and their formatting conventions.
+var known = map[string]conventions{
+	"en-US":  {date: "Jan 2, 2006", clock: "3:04 PM", group: ",", decimal: "."},
+	"en-GB":  {date: "2 Jan 2006", clock: "15:04", group: ",", decimal: "."},
+	"bg":     {date: "02.01.2006", clock: "15:04", group: " ", decimal: ","},
+	"cs":     {date: "2. 1. 2006", clock: "15:04", group: " ", decimal: ","},
+	"da":     {date: "02.01.2006", clock: "15.04", group: ".", decimal: ","},
+	"de":     {date: "02.01.2006", clock: "15:04", group: ".", decimal: ","},
+	"el":     {date: "2/1/2006", clock: "3:04 PM", group: ".", decimal: ","},
+	"es-ES":  {date: "2/1/2006", clock: "15:04", group: ".", decimal: ","},
+	"es-419": {date: "2/1/2006", clock: "15:04", group: ",", decimal: "."},
+	"fi":     {date: "2.1.2006", clock: "15.04", group: " ", decimal: ","},
+	"fr":     {date: "02/01/2006", clock: "15:04", group: " ", decimal: ","},
+	"hi":     {date: "2/1/2006", clock: "3:04 PM", group: ",", decimal: "."},
+	"hr":     {date: "02. 01. 2006.", clock: "15:04", group: ".", decimal: ","},
+	"hu":     {date: "2006. 01. 02.", clock: "15:04", group: " ", decimal: ","},
+	"id":     {date: "02/01/2006", clock: "15.04", group: ".", decimal: ","},
+	"it":     {date: "02/01/2006", clock: "15:04", group: ".", decimal: ","},
+	"ja":     {date: "2006/01/02", clock: "15:04", group: ",", decimal: "."},
+	"ko":     {date: "2006. 1. 2.", clock: "PM 3:04", group: ",", decimal: "."},
+	"lt":     {date: "2006-01-02", clock: "15:04", group: " ", decimal: ","},
+	"nl":     {date: "02-01-2006", clock: "15:04", group: ".", decimal: ","},
+	"no":     {date: "02.01.2006", clock: "15:04", group: " ", decimal: ","},
+	"pl":     {date: "02.01.2006", clock: "15:04", group: " ", decimal: ","},
+	"pt-BR":  {date: "02/01/2006", clock: "15:04", group: ".", decimal: ","},
+	"ro":     {date: "02.01.2006", clock: "15:04", group: ".", decimal: ","},
+	"ru":     {date: "02.01.2006", clock: "15:04", group: " ", decimal: ","},
+	"sv-SE":  {date: "2006-01-02", clock: "15:04", group: " ", decimal: ","},
+	"th":     {date: "2/1/2006", clock: "15:04", group: ",", decimal: "."},
+	"tr":     {date: "02.01.2006", clock: "15:04", group: ".", decimal: ","},
+	"uk":     {date: "02.01.2006", clock: "15:04", group: " ", decimal: ","},
+	"vi":     {date: "02/01/2006", clock: "15:04", group: ".", decimal: ","},
+	"zh-CN":  {date: "2006/1/2", clock: "15:04", group: ",", decimal: "."},
+	"zh-TW":  {date: "2006/1/2", clock: "PM 3:04", group: ",", decimal: "."},
+}
+
+// languageDefaults picks a regional convention for bare language codes.
+var languageDefaults = map[string]string{
+	"en": "en-US",
+	"es": "es-ES",
+	"pt": "pt-BR",
+	"sv": "sv-SE",
+	"zh": "zh-CN",
+}
+
+// Formatter renders values for a single locale and time zone.
+type Formatter struct {
+	locale   string
+	location *time.Location
+	conv     conventions
+	now      func() time.Time
+}
+
+// New returns a Formatter for a Discord locale (e.g. "en-US", "de", "pt-BR")
+// and an IANA time zone name. Empty values fall back to en-US and the local
+// zone. Region variants without their own entry ("de-AT") use the language.
+func New(locale, timezone string) (*Formatter, error) {
+	name, conv, err := resolve(locale)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.Local
+	if tz := strings.TrimSpace(timezone); tz != "" {
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q: %w", tz, err)
+		}
+	}
+	return &Formatter{locale: name, location: loc, conv: conv, now: time.Now}, nil
+}
+
+// Default returns an en-US formatter in the local time zone.
+func Default() *Formatter {
+	f, _ := New(DefaultLocale, "")
+	return f
+}
+
+// Supported returns true when locale resolves to a known convention.
+func Supported(locale string) bool {
+	_, _, err := resolve(locale)
+	return err == nil
+}
+
+func resolve(locale string) (string, conventions, error) {
+	locale = strings.TrimSpace(strings.ReplaceAll(locale, "_", "-"))
+	if locale == "" {
+		return DefaultLocale, known[DefaultLocale], nil
+	}
+	for name, conv := range known {
+		if strings.EqualFold(name, locale) {
+			return name, conv, nil
+		}
+	}
+	lang := strings.ToLower(strings.SplitN(locale, "-", 2)[0])
+	if conv, ok := known[lang]; ok {
+		return lang, conv, nil
+	}
+	if name, ok := languageDefaults[lang]; ok {
+		return name, known[name], nil
+	}
+	return "", conventions{}, fmt.Errorf("unsupported locale %q", locale)
+}
+
+// Locale returns the resolved locale name.
+func (f *Formatter) Locale() string { return f.locale }
+
+// Location returns the time zone used for rendering.
+func (f *Formatter) Location() *time.Location { return f.location }
+
+// Date renders the calendar date of t.
+func (f *Formatter) Date(t time.Time) string {
+	return t.In(f.location).Format(f.conv.date)
+}
+
+// Time renders the time of day of t.
+func (f *Formatter) Time(t time.Time) string {
+	return t.In(f.location).Format(f.conv.clock)
+}
+
+// DateTime renders the date and time of day of t with the zone abbreviation.
+func (f *Formatter) DateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	local := t.In(f.location)
+	return local.Format(f.conv.date) + " " + local.Format(f.conv.clock) + " " + local.Format("MST")
+}
+
+// Relative renders t relative to now ("3 hours ago", "in 5 minutes") in
+// English. Discord clients localize <t:...:R> markup themselves, so prefer
+// Markup with utils.TimestampRelative in message content.
+func (f *Formatter) Relative(t time.Time) string {
+	d := f.now().Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var phrase string
+	switch {
+	case d < 45*time.Second:
+		return "just now"
+	case d < 90*time.Second:
+		phrase = "1 minute"
+	case d < 45*time.Minute:
+		phrase = plural(int(math.Round(d.Minutes())), "minute")
+	case d < 90*time.Minute:
+		phrase = "1 hour"
+	case d < 22*time.Hour:
+		phrase = plural(int(math.Round(d.Hours())), "hour")
+	case d < 36*time.Hour:
+		phrase = "1 day"
+	case d < 26*24*time.Hour:
+		phrase = plural(int(math.Round(d.Hours()/24)), "day")
+	case d < 320*24*time.Hour:
+		phrase = plural(int(math.Max(1, math.Round(d.Hours()/24/30))), "month")
+	default:
+		phrase = plural(int(math.Max(1, math.Round(d.Hours()/24/365))), "year")
+	}
+	if future {
+		return "in " + phrase
+	}
+	return phrase + " ago"
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
+
+// Number renders v with the locale's grouping and decimal separators using
+// the given number of fractional digits.
+func (f *Formatter) Number(v float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	raw := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	whole, frac, _ := strings.Cut(raw, ".")
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(raw, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(f.conv.group)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString(f.conv.decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// Markup returns Discord timestamp markup (<t:unix:style>) which each viewer's
+// client renders in their own locale and time zone.
+func Markup(t time.Time, style utils.TimestampStyle) string {
+	return utils.FormatTimestamp(t, style)
+}
+
+// FuncMap exposes the formatter to text/template:
+//
+//	{{ date .At }} {{ time .At }} {{ datetime .At }} {{ relative .At }}
+//	{{ number .Count }} {{ decimal .Ratio 2 }}
+//	{{ discordTime .At "R" }} {{ discordRelative .At }}
+func (f *Formatter) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"date":     f.Date,
+		"time":     f.Time,
+		"datetime": f.DateTime,
+		"relative": f.Relative,
+		"number": func(v any) (string, error) {
+			n, err := toFloat(v)
+			if err != nil {
+				return "", err
+			}
+			return f.Number(n, 0), nil
+		},
+		"decimal": func(v any, decimals int) (string, error) {
+			n, err := toFloat(v)
+			if err != nil {
+				return "", err
+			}
+			return f.Number(n, decimals), nil
+		},
+		"discordTime": func(t time.Time, style string) string {
+			return Markup(t, utils.TimestampStyle(style))
+		},
+		"discordRelative": func(t time.Time) string {
+			return Markup(t, utils.TimestampRelative)
+		},
+	}
+}
+
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float32:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case string:
+		return strconv.ParseFloat(n, 64)
+	default:
+		return 0, fmt.Errorf("cannot format %T as a number", v)
+	}
+}
@@ -0,0 +1,108 @@
+package locale
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestFormatterConventions(t *testing.T) {
+	at := time.Date(2024, 3, 7, 16, 5, 0, 0, time.UTC)
+	tests := []struct {
+		locale   string
+		date     string
+		clock    string
+		number   string
+		resolved string
+	}{
+		{locale: "", date: "Mar 7, 2024", clock: "4:05 PM", number: "1,234,567.89", resolved: "en-US"},
+		{locale: "de", date: "07.03.2024", clock: "16:05", number: "1.234.567,89", resolved: "de"},
+		{locale: "de-AT", date: "07.03.2024", clock: "16:05", number: "1.234.567,89", resolved: "de"},
+		{locale: "fr", date: "07/03/2024", clock: "16:05", number: "1 234 567,89", resolved: "fr"},
+		{locale: "pt", date: "07/03/2024", clock: "16:05", number: "1.234.567,89", resolved: "pt-BR"},
+		{locale: "ja_JP", date: "2024/03/07", clock: "16:05", number: "1,234,567.89", resolved: "ja"},
+	}
+	for _, tt := range tests {
+		f, err := New(tt.locale, "UTC")
+		if err != nil {
+			t.Fatalf("New(%q) error = %v", tt.locale, err)
+		}
+		if f.Locale() != tt.resolved {
+			t.Errorf("New(%q).Locale() = %s, want %s", tt.locale, f.Locale(), tt.resolved)
+		}
+		if got := f.Date(at); got != tt.date {
+			t.Errorf("%s Date = %q, want %q", tt.locale, got, tt.date)
+		}
+		if got := f.Time(at); got != tt.clock {
+			t.Errorf("%s Time = %q, want %q", tt.locale, got, tt.clock)
+		}
+		if got := f.Number(1234567.891, 2); got != tt.number {
+			t.Errorf("%s Number = %q, want %q", tt.locale, got, tt.number)
+		}
+	}
+
+	if _, err := New("xx-YY", ""); err == nil {
+		t.Fatal("expected error for unknown locale")
+	}
+	if _, err := New("en-US", "Mars/Olympus"); err == nil {
+		t.Fatal("expected error for unknown time zone")
+	}
+}
+
+func TestFormatterNumberEdgeCases(t *testing.T) {
+	f := Default()
+	tests := []struct {
+		value    float64
+		decimals int
+		want     string
+	}{
+		{0, 0, "0"},
+		{999, 0, "999"},
+		{1000, 0, "1,000"},
+		{-12345, 1, "-12,345.0"},
+		{-0.01, 0, "0"},
+	}
+	for _, tt := range tests {
+		if got := f.Number(tt.value, tt.decimals); got != tt.want {
+			t.Errorf("Number(%v, %d) = %q, want %q", tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestFormatterRelative(t *testing.T) {
+	f := Default()
+	now := time.Date(2024, 3, 7, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+	cases := map[time.Duration]string{
+		-10 * time.Second:    "just now",
+		-5 * time.Minute:     "5 minutes ago",
+		-3 * time.Hour:       "3 hours ago",
+		-24 * time.Hour:      "1 day ago",
+		2 * time.Hour:        "in 2 hours",
+		-60 * 24 * time.Hour: "2 months ago",
+	}
+	for offset, want := range cases {
+		if got := f.Relative(now.Add(offset)); got != want {
+			t.Errorf("Relative(%v) = %q, want %q", offset, got, want)
+		}
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	f, err := New("de", "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	tmpl := template.Must(template.New("msg").Funcs(f.FuncMap()).Parse(
+		`Deploy {{ number .Count }} pods at {{ datetime .At }} ({{ discordRelative .At }}, {{ discordTime .At "F" }})`))
+	var buf bytes.Buffer
+	at := time.Unix(1700000000, 0).UTC()
+	if err := tmpl.Execute(&buf, map[string]any{"Count": 12500, "At": at}); err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	want := "Deploy 12.500 pods at 14.11.2023 23:13 CET (<t:1700000000:R>, <t:1700000000:F>)"
+	if buf.String() != want {
+		t.Fatalf("got %q\nwant %q", buf.String(), want)
+	}
+}
@@ -218,6 +218,18 @@ func PermissionFromString(value string) Permission {
 	return Permission(n)
 }
 
+// ParsePermission looks up a permission by name, ignoring case and
+// underscores, so ManageGuild, manage_guild, and MANAGE_GUILD all match.
+func ParsePermission(name string) (Permission, error) {
+	want := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", ""))
+	for _, perm := range allPermissions {
+		if strings.ToLower(permissionNames[perm]) == want {
+			return perm, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown permission %q", name)
+}
+
 // Has reports whether all bits in mask are present.
 func (p Permission) Has(mask Permission) bool {
 	if mask == 0 {
@@ -250,6 +262,17 @@ func (p Permission) String() string {
 	return fmt.Sprintf("[%s]", strings.Join(names, ", "))
 }
 
+// Names lists the known permissions in p by name, in bit order.
+func (p Permission) Names() []string {
+	var names []string
+	for _, perm := range allPermissions {
+		if p.Has(perm) {
+			names = append(names, permissionNames[perm])
+		}
+	}
+	return names
+}
+
 // PermissionCalculator evaluates permissions for a member in a channel.
 type PermissionCalculator struct {
 	guild   *types.Guild
@@ -62,3 +62,15 @@ func TestPermissionCalculatorChannelOverrides(t *testing.T) {
 		t.Fatalf("deny should block manage channels")
 	}
 }
+
+func TestParsePermission(t *testing.T) {
+	for _, name := range []string{"ManageGuild", "manage_guild", "MANAGE_GUILD"} {
+		perm, err := ParsePermission(name)
+		if err != nil || perm != PermissionManageGuild {
+			t.Fatalf("%s: got %v, %v", name, perm, err)
+		}
+	}
+	if _, err := ParsePermission("LaunchRockets"); err == nil {
+		t.Fatal("expected error for unknown permission")
+	}
+}
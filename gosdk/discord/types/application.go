@@ -0,0 +1,154 @@
+package types
+
+import "unicode/utf8"
+
+// Application is the application object returned by /applications/@me.
+type Application struct {
+	ID                             string                    `json:"id"`
+	Name                           string                    `json:"name"`
+	Description                    string                    `json:"description,omitempty"`
+	BotPublic                      bool                      `json:"bot_public"`
+	BotRequireCodeGrant            bool                      `json:"bot_require_code_grant,omitempty"`
+	Owner                          *User                     `json:"owner,omitempty"`
+	Team                           *Team                     `json:"team,omitempty"`
+	Flags                          ApplicationFlags          `json:"flags,omitempty"`
+	VerifyKey                      string                    `json:"verify_key,omitempty"`
+	InteractionsEndpointURL        string                    `json:"interactions_endpoint_url,omitempty"`
+	RoleConnectionsVerificationURL string                    `json:"role_connections_verification_url,omitempty"`
+	CustomInstallURL               string                    `json:"custom_install_url,omitempty"`
+	InstallParams                  *ApplicationInstallParams `json:"install_params,omitempty"`
+	Tags                           []string                  `json:"tags,omitempty"`
+	ApproximateGuildCount          int                       `json:"approximate_guild_count,omitempty"`
+}
+
+// ApplicationInstallParams are the scopes and permissions the in-app
+// "Add App" button requests.
+type ApplicationInstallParams struct {
+	Scopes      []string `json:"scopes"`
+	Permissions string   `json:"permissions"`
+}
+
+// Team owns an application on behalf of several developers.
+type Team struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	OwnerUserID string        `json:"owner_user_id"`
+	Members     []*TeamMember `json:"members,omitempty"`
+}
+
+// TeamMember is a developer on a team. Role is admin, developer, or
+// read_only; the owner is reported by Team.OwnerUserID.
+type TeamMember struct {
+	MembershipState int    `json:"membership_state"`
+	TeamID          string `json:"team_id"`
+	User            *User  `json:"user"`
+	Role            string `json:"role"`
+}
+
+// Discord's limits for application fields editable through the API.
+const (
+	maxApplicationDescriptionLength = 400
+	maxApplicationTags              = 5
+	maxApplicationTagLength         = 20
+)
+
+// ModifyApplicationParams edits the current application. Nil fields are
+// left unchanged; pointers to empty values clear them.
+type ModifyApplicationParams struct {
+	Description                    *string                   `json:"description,omitempty"`
+	InteractionsEndpointURL        *string                   `json:"interactions_endpoint_url,omitempty"`
+	RoleConnectionsVerificationURL *string                   `json:"role_connections_verification_url,omitempty"`
+	CustomInstallURL               *string                   `json:"custom_install_url,omitempty"`
+	InstallParams                  *ApplicationInstallParams `json:"install_params,omitempty"`
+	Tags                           *[]string                 `json:"tags,omitempty"`
+}
+
+// Validate ensures at least one field is set and each fits Discord's limits.
+func (p *ModifyApplicationParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "application params required"}
+	}
+	if p.Description == nil && p.InteractionsEndpointURL == nil && p.RoleConnectionsVerificationURL == nil &&
+		p.CustomInstallURL == nil && p.InstallParams == nil && p.Tags == nil {
+		return &ValidationError{Field: "params", Message: "nothing to modify"}
+	}
+	if p.Description != nil && utf8.RuneCountInString(*p.Description) > maxApplicationDescriptionLength {
+		return &ValidationError{Field: "description", Message: "description exceeds 400 characters"}
+	}
+	if p.Tags != nil {
+		if len(*p.Tags) > maxApplicationTags {
+			return &ValidationError{Field: "tags", Message: "at most 5 tags are allowed"}
+		}
+		for _, tag := range *p.Tags {
+			if utf8.RuneCountInString(tag) > maxApplicationTagLength {
+				return &ValidationError{Field: "tags", Message: "tags are limited to 20 characters"}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplicationFlags is the application flags bitmask.
+type ApplicationFlags uint64
+
+// Privileged gateway intent flags. The "Limited" variants are set for
+// unverified bots in fewer than 100 guilds, where the intent is toggled in
+// the developer portal rather than granted through verification.
+const (
+	ApplicationFlagGatewayPresence              ApplicationFlags = 1 << 12
+	ApplicationFlagGatewayPresenceLimited       ApplicationFlags = 1 << 13
+	ApplicationFlagGatewayGuildMembers          ApplicationFlags = 1 << 14
+	ApplicationFlagGatewayGuildMembersLimited   ApplicationFlags = 1 << 15
+	ApplicationFlagGatewayMessageContent        ApplicationFlags = 1 << 18
+	ApplicationFlagGatewayMessageContentLimited ApplicationFlags = 1 << 19
+)
+
+// Other application flags Discord reports.
+const (
+	ApplicationFlagAutoModerationRuleCreateBadge ApplicationFlags = 1 << 6
+	ApplicationFlagVerificationPendingGuildLimit ApplicationFlags = 1 << 16
+	ApplicationFlagEmbedded                      ApplicationFlags = 1 << 17
+	ApplicationFlagApplicationCommandBadge       ApplicationFlags = 1 << 23
+)
+
+var applicationFlagNames = []struct {
+	flag ApplicationFlags
+	name string
+}{
+	{ApplicationFlagAutoModerationRuleCreateBadge, "AutoModerationRuleCreateBadge"},
+	{ApplicationFlagGatewayPresence, "GatewayPresence"},
+	{ApplicationFlagGatewayPresenceLimited, "GatewayPresenceLimited"},
+	{ApplicationFlagGatewayGuildMembers, "GatewayGuildMembers"},
+	{ApplicationFlagGatewayGuildMembersLimited, "GatewayGuildMembersLimited"},
+	{ApplicationFlagVerificationPendingGuildLimit, "VerificationPendingGuildLimit"},
+	{ApplicationFlagEmbedded, "Embedded"},
+	{ApplicationFlagGatewayMessageContent, "GatewayMessageContent"},
+	{ApplicationFlagGatewayMessageContentLimited, "GatewayMessageContentLimited"},
+	{ApplicationFlagApplicationCommandBadge, "ApplicationCommandBadge"},
+}
+
+// Names lists the known flags that are set, lowest bit first.
+func (f ApplicationFlags) Names() []string {
+	var names []string
+	for _, entry := range applicationFlagNames {
+		if f&entry.flag != 0 {
+			names = append(names, entry.name)
+		}
+	}
+	return names
+}
+
+// HasPresenceIntent reports whether the Presence intent is enabled.
+func (f ApplicationFlags) HasPresenceIntent() bool {
+	return f&(ApplicationFlagGatewayPresence|ApplicationFlagGatewayPresenceLimited) != 0
+}
+
+// HasGuildMembersIntent reports whether the Server Members intent is enabled.
+func (f ApplicationFlags) HasGuildMembersIntent() bool {
+	return f&(ApplicationFlagGatewayGuildMembers|ApplicationFlagGatewayGuildMembersLimited) != 0
+}
+
+// HasMessageContentIntent reports whether the Message Content intent is enabled.
+func (f ApplicationFlags) HasMessageContentIntent() bool {
+	return f&(ApplicationFlagGatewayMessageContent|ApplicationFlagGatewayMessageContentLimited) != 0
+}
@@ -0,0 +1,79 @@
+package types
+
+// maxBulkBanUsers is Discord's per-request limit for bulk bans.
+const maxBulkBanUsers = 200
+
+// maxBanDeleteMessageSeconds is the longest message history (7 days) a ban
+// can delete.
+const maxBanDeleteMessageSeconds = 604800
+
+// Ban is a banned user and the reason recorded for the ban.
+type Ban struct {
+	Reason string `json:"reason"`
+	User   *User  `json:"user"`
+}
+
+// ListBansParams controls pagination when listing guild bans. Bans are
+// ordered by user ID.
+type ListBansParams struct {
+	Limit  int
+	Before string
+	After  string
+}
+
+// Validate ensures the ban list parameters are within Discord's limits.
+func (p *ListBansParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Limit < 0 || p.Limit > 1000 {
+		return &ValidationError{Field: "limit", Message: "limit must be between 0 and 1000"}
+	}
+	return nil
+}
+
+// CreateBanParams bans a single user.
+type CreateBanParams struct {
+	DeleteMessageSeconds int    `json:"delete_message_seconds,omitempty"`
+	AuditLogReason       string `json:"-"`
+}
+
+// Validate checks the message deletion window.
+func (p *CreateBanParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	return validateBanDeleteSeconds(p.DeleteMessageSeconds)
+}
+
+// BulkBanParams bans up to 200 users in one request.
+type BulkBanParams struct {
+	UserIDs              []string `json:"user_ids"`
+	DeleteMessageSeconds int      `json:"delete_message_seconds,omitempty"`
+	AuditLogReason       string   `json:"-"`
+}
+
+// Validate enforces the bulk ban user count and deletion window.
+func (p *BulkBanParams) Validate() error {
+	if p == nil || len(p.UserIDs) == 0 {
+		return &ValidationError{Field: "user_ids", Message: "at least one user ID is required"}
+	}
+	if len(p.UserIDs) > maxBulkBanUsers {
+		return &ValidationError{Field: "user_ids", Message: "at most 200 users can be banned per request"}
+	}
+	return validateBanDeleteSeconds(p.DeleteMessageSeconds)
+}
+
+// BulkBanResult reports which users a bulk ban applied to. Users who were
+// already banned, or outrank the bot, are reported as failed.
+type BulkBanResult struct {
+	BannedUsers []string `json:"banned_users"`
+	FailedUsers []string `json:"failed_users"`
+}
+
+func validateBanDeleteSeconds(seconds int) error {
+	if seconds < 0 || seconds > maxBanDeleteMessageSeconds {
+		return &ValidationError{Field: "delete_message_seconds", Message: "delete_message_seconds must be between 0 and 604800 (7 days)"}
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"time"
+	"unicode/utf8"
 )
 
 // ChannelType defines the Discord channel type.
@@ -24,6 +27,10 @@ const (
 	ChannelTypeGuildForum
 )
 
+// ChannelTypeGuildMedia is a media channel: a forum whose posts lead with
+// images and video.
+const ChannelTypeGuildMedia ChannelType = 16
+
 // PermissionOverwriteType distinguishes role vs member overwrites.
 type PermissionOverwriteType string
 
@@ -32,6 +39,41 @@ const (
 	PermissionOverwriteMember PermissionOverwriteType = "member"
 )
 
+// MarshalJSON sends role and member as Discord's 0 and 1.
+func (t PermissionOverwriteType) MarshalJSON() ([]byte, error) {
+	switch t {
+	case PermissionOverwriteRole:
+		return []byte("0"), nil
+	case PermissionOverwriteMember:
+		return []byte("1"), nil
+	default:
+		return json.Marshal(string(t))
+	}
+}
+
+// UnmarshalJSON accepts Discord's integer form (0 role, 1 member) as well
+// as the names.
+func (t *PermissionOverwriteType) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		switch n {
+		case 0:
+			*t = PermissionOverwriteRole
+		case 1:
+			*t = PermissionOverwriteMember
+		default:
+			return fmt.Errorf("unknown permission overwrite type %d", n)
+		}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*t = PermissionOverwriteType(s)
+	return nil
+}
+
 // ChannelFlags represents channel-level feature flags (bitmask).
 type ChannelFlags uint64
 
@@ -88,11 +130,13 @@ type Channel struct {
 	DefaultReaction      *DefaultReaction      `json:"default_reaction_emoji,omitempty"`
 	DefaultSortOrder     string                `json:"default_sort_order,omitempty"`
 	DefaultForumLayout   string                `json:"default_forum_layout,omitempty"`
+	AppliedTags          []string              `json:"applied_tags,omitempty"`
 }
 
-// ForumTag represents tags available for forum channels.
+// ForumTag represents tags available for forum channels. Leave ID empty
+// when adding a tag; Discord assigns it.
 type ForumTag struct {
-	ID        string `json:"id"`
+	ID        string `json:"id,omitempty"`
 	Name      string `json:"name"`
 	Moderated bool   `json:"moderated"`
 	EmojiID   string `json:"emoji_id,omitempty"`
@@ -127,23 +171,65 @@ type ChannelCreateParams struct {
 }
 
 // ModifyChannelParams mirrors update payloads (name optional).
+//
+// Zero values are omitted from the payload. To reset a field (remove a topic,
+// move a channel out of its category, disable slowmode, drop all forum tags)
+// list its JSON name in ClearFields; the field is then sent as null, or as the
+// type's empty value when Discord does not accept null for it. MergePatch, when
+// set, is an RFC 7386 document merged over the final payload.
 type ModifyChannelParams struct {
-	Name                 string                `json:"name,omitempty"`
-	Type                 ChannelType           `json:"type,omitempty"`
-	Topic                string                `json:"topic,omitempty"`
-	Bitrate              int                   `json:"bitrate,omitempty"`
-	UserLimit            int                   `json:"user_limit,omitempty"`
-	RateLimitPerUser     int                   `json:"rate_limit_per_user,omitempty"`
-	Position             int                   `json:"position,omitempty"`
-	PermissionOverwrites []PermissionOverwrite `json:"permission_overwrites,omitempty"`
-	ParentID             string                `json:"parent_id,omitempty"`
-	NSFW                 bool                  `json:"nsfw,omitempty"`
-	RTCRegion            string                `json:"rtc_region,omitempty"`
-	VideoQualityMode     int                   `json:"video_quality_mode,omitempty"`
-	AvailableTags        []ForumTag            `json:"available_tags,omitempty"`
-	DefaultReaction      *DefaultReaction      `json:"default_reaction_emoji,omitempty"`
-	DefaultSortOrder     string                `json:"default_sort_order,omitempty"`
-	AuditLogReason       string                `json:"-"`
+	Name                          string                `json:"name,omitempty"`
+	Type                          ChannelType           `json:"type,omitempty"`
+	Topic                         string                `json:"topic,omitempty"`
+	Bitrate                       int                   `json:"bitrate,omitempty"`
+	UserLimit                     *int                  `json:"user_limit,omitempty"`
+	RateLimitPerUser              int                   `json:"rate_limit_per_user,omitempty"`
+	Position                      *int                  `json:"position,omitempty"`
+	PermissionOverwrites          []PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	ParentID                      string                `json:"parent_id,omitempty"`
+	NSFW                          bool                  `json:"nsfw,omitempty"`
+	RTCRegion                     string                `json:"rtc_region,omitempty"`
+	VideoQualityMode              int                   `json:"video_quality_mode,omitempty"`
+	DefaultAutoArchiveDuration    int                   `json:"default_auto_archive_duration,omitempty"`
+	DefaultThreadRateLimitPerUser int                   `json:"default_thread_rate_limit_per_user,omitempty"`
+	Flags                         ChannelFlags          `json:"flags,omitempty"`
+	AvailableTags                 []ForumTag            `json:"available_tags,omitempty"`
+	DefaultReaction               *DefaultReaction      `json:"default_reaction_emoji,omitempty"`
+	DefaultSortOrder              string                `json:"default_sort_order,omitempty"`
+	ClearFields                   []string              `json:"-"`
+	MergePatch                    json.RawMessage       `json:"-"`
+	AuditLogReason                string                `json:"-"`
+}
+
+// modifyChannelClearValues lists the payload sent for each clearable field.
+var modifyChannelClearValues = map[string]json.RawMessage{
+	"topic":                              json.RawMessage("null"),
+	"bitrate":                            json.RawMessage("null"),
+	"user_limit":                         json.RawMessage("null"),
+	"rate_limit_per_user":                json.RawMessage("null"),
+	"position":                           json.RawMessage("null"),
+	"permission_overwrites":              json.RawMessage("[]"),
+	"parent_id":                          json.RawMessage("null"),
+	"nsfw":                               json.RawMessage("false"),
+	"rtc_region":                         json.RawMessage("null"),
+	"video_quality_mode":                 json.RawMessage("null"),
+	"default_auto_archive_duration":      json.RawMessage("null"),
+	"default_thread_rate_limit_per_user": json.RawMessage("0"),
+	"flags":                              json.RawMessage("0"),
+	"available_tags":                     json.RawMessage("[]"),
+	"default_reaction_emoji":             json.RawMessage("null"),
+	"default_sort_order":                 json.RawMessage("null"),
+}
+
+// ClearableModifyChannelFields returns the JSON field names accepted by ClearFields.
+func ClearableModifyChannelFields() []string {
+	return clearableFields(modifyChannelClearValues)
+}
+
+// MarshalJSON emits the populated fields, explicit resets for ClearFields, and MergePatch.
+func (p ModifyChannelParams) MarshalJSON() ([]byte, error) {
+	type alias ModifyChannelParams
+	return marshalPatch(alias(p), p.ClearFields, modifyChannelClearValues, p.MergePatch)
 }
 
 // Validate ensures Channel fields meet Discord constraints.
@@ -204,13 +290,32 @@ func (p *ModifyChannelParams) Validate() error {
 	if p.Bitrate < 0 {
 		return &ValidationError{Field: "bitrate", Message: "bitrate cannot be negative"}
 	}
-	if p.UserLimit < 0 {
+	if p.UserLimit != nil && *p.UserLimit < 0 {
 		return &ValidationError{Field: "user_limit", Message: "user limit cannot be negative"}
 	}
 	if p.RateLimitPerUser < 0 || p.RateLimitPerUser > 21600 {
 		return &ValidationError{Field: "rate_limit_per_user", Message: "rate limit must be between 0 and 21600 seconds"}
 	}
-	return nil
+	if p.DefaultThreadRateLimitPerUser < 0 || p.DefaultThreadRateLimitPerUser > 21600 {
+		return &ValidationError{Field: "default_thread_rate_limit_per_user", Message: "rate limit must be between 0 and 21600 seconds"}
+	}
+	switch p.DefaultAutoArchiveDuration {
+	case 0, 60, 1440, 4320, 10080:
+	default:
+		return &ValidationError{Field: "default_auto_archive_duration", Message: "auto archive duration must be 60, 1440, 4320, or 10080 minutes"}
+	}
+	if len(p.AvailableTags) > 20 {
+		return &ValidationError{Field: "available_tags", Message: "forum channels support at most 20 tags"}
+	}
+	for _, tag := range p.AvailableTags {
+		if n := utf8.RuneCountInString(tag.Name); n == 0 || n > 20 {
+			return &ValidationError{Field: "available_tags", Message: "tag names must be 1-20 characters"}
+		}
+	}
+	if err := ValidateMergePatch(p.MergePatch); err != nil {
+		return err
+	}
+	return validateClearFields(p.ClearFields, modifyChannelClearValues)
 }
 
 // ChannelParamsBuilder offers a fluent builder for ChannelCreateParams.
@@ -269,3 +374,52 @@ func validateChannelName(name string) error {
 	}
 	return nil
 }
+
+// ForumThreadParams starts a post (thread plus opening message) in a forum or
+// media channel.
+type ForumThreadParams struct {
+	Name                string              `json:"name"`
+	AutoArchiveDuration int                 `json:"auto_archive_duration,omitempty"`
+	RateLimitPerUser    int                 `json:"rate_limit_per_user,omitempty"`
+	AppliedTags         []string            `json:"applied_tags,omitempty"`
+	Message             MessageCreateParams `json:"message"`
+	AuditLogReason      string              `json:"-"`
+}
+
+// Validate checks the post against Discord's forum limits.
+func (p *ForumThreadParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "forum post params required"}
+	}
+	if n := utf8.RuneCountInString(p.Name); n == 0 || n > 100 {
+		return &ValidationError{Field: "name", Message: "post name must be 1-100 characters"}
+	}
+	switch p.AutoArchiveDuration {
+	case 0, 60, 1440, 4320, 10080:
+	default:
+		return &ValidationError{Field: "auto_archive_duration", Message: "auto archive duration must be 60, 1440, 4320, or 10080 minutes"}
+	}
+	if p.RateLimitPerUser < 0 || p.RateLimitPerUser > 21600 {
+		return &ValidationError{Field: "rate_limit_per_user", Message: "rate limit must be between 0 and 21600 seconds"}
+	}
+	if len(p.AppliedTags) > 5 {
+		return &ValidationError{Field: "applied_tags", Message: "a post can have at most 5 tags"}
+	}
+	if p.Message.Content == "" && len(p.Message.Embeds) == 0 {
+		return &ValidationError{Field: "message", Message: "the opening message needs content or an embed"}
+	}
+	return nil
+}
+
+// ForumThread is a forum post: the thread channel and its opening message.
+type ForumThread struct {
+	Channel
+	Message *Message `json:"message,omitempty"`
+}
+
+// FollowedChannel links an announcement channel to the webhook Discord
+// created in the following channel.
+type FollowedChannel struct {
+	ChannelID string `json:"channel_id"`
+	WebhookID string `json:"webhook_id"`
+}
@@ -69,6 +69,57 @@ func TestModifyChannelParamsValidate(t *testing.T) {
 	}
 }
 
+func TestModifyChannelParamsClearFields(t *testing.T) {
+	position := 3
+	params := &ModifyChannelParams{
+		Position:    &position,
+		ClearFields: []string{"topic", "parent_id", "available_tags", "nsfw"},
+	}
+	if err := params.Validate(); err != nil {
+		t.Fatalf("expected valid params, got %v", err)
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	expect := map[string]string{
+		"position":       "3",
+		"topic":          "null",
+		"parent_id":      "null",
+		"available_tags": "[]",
+		"nsfw":           "false",
+	}
+	for field, want := range expect {
+		if got := string(decoded[field]); got != want {
+			t.Fatalf("%s: expected %s, got %q (payload %s)", field, want, got, data)
+		}
+	}
+	if len(decoded) != len(expect) {
+		t.Fatalf("unexpected extra fields in payload %s", data)
+	}
+
+	params.ClearFields = []string{"name"}
+	if err := params.Validate(); err == nil {
+		t.Fatal("expected error when clearing a non-clearable field")
+	}
+}
+
+func TestModifyChannelParamsValidateForumFields(t *testing.T) {
+	params := &ModifyChannelParams{DefaultAutoArchiveDuration: 1440, DefaultThreadRateLimitPerUser: 30}
+	if err := params.Validate(); err != nil {
+		t.Fatalf("expected valid params, got %v", err)
+	}
+	params.DefaultAutoArchiveDuration = 15
+	if err := params.Validate(); err == nil {
+		t.Fatal("expected error for unsupported auto archive duration")
+	}
+}
+
 func TestChannelJSONMarshalling(t *testing.T) {
 	now := time.Now().UTC()
 	ch := &Channel{
@@ -90,3 +141,36 @@ func TestChannelJSONMarshalling(t *testing.T) {
 		t.Fatalf("expected JSON to contain channel name, got %s", data)
 	}
 }
+
+func TestModifyChannelParamsValidateTagNames(t *testing.T) {
+	params := &ModifyChannelParams{AvailableTags: []ForumTag{{Name: "release"}}}
+	if err := params.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params.AvailableTags = append(params.AvailableTags, ForumTag{Name: "this tag name is far too long"})
+	if err := params.Validate(); err == nil {
+		t.Fatal("expected tag name length error")
+	}
+	raw, err := json.Marshal(ForumTag{Name: "new"})
+	if err != nil || strings.Contains(string(raw), `"id"`) {
+		t.Fatalf("new tags must omit id, got %s (%v)", raw, err)
+	}
+}
+
+func TestPermissionOverwriteTypeUsesIntegers(t *testing.T) {
+	var overwrites []PermissionOverwrite
+	raw := `[{"id":"1","type":0,"allow":"1024","deny":"0"},{"id":"2","type":1,"allow":"0","deny":"0"},{"id":"3","type":"role"}]`
+	if err := json.Unmarshal([]byte(raw), &overwrites); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if overwrites[0].Type != PermissionOverwriteRole || overwrites[1].Type != PermissionOverwriteMember || overwrites[2].Type != PermissionOverwriteRole {
+		t.Fatalf("unexpected types %+v", overwrites)
+	}
+	if err := json.Unmarshal([]byte(`{"type":7}`), &PermissionOverwrite{}); err == nil {
+		t.Fatal("expected error for unknown type")
+	}
+	data, err := json.Marshal(overwrites[1])
+	if err != nil || !strings.Contains(string(data), `"type":1`) {
+		t.Fatalf("expected member overwrite sent as 1, got %s (%v)", data, err)
+	}
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// marshalWithClears marshals v and then overrides each cleared field with its
+// reset value so PATCH payloads can express "remove this" alongside omitempty fields.
+func marshalWithClears(v any, clear []string, resets map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(clear) == 0 {
+		return data, err
+	}
+	if err := validateClearFields(clear, resets); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range clear {
+		fields[field] = resets[field]
+	}
+	return json.Marshal(fields)
+}
+
+func validateClearFields(clear []string, resets map[string]json.RawMessage) error {
+	for _, field := range clear {
+		if _, ok := resets[field]; !ok {
+			return &ValidationError{Field: field, Message: "field cannot be cleared"}
+		}
+	}
+	return nil
+}
+
+func clearableFields(resets map[string]json.RawMessage) []string {
+	fields := make([]string, 0, len(resets))
+	for field := range resets {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
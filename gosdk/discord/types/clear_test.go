@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMessageEditParamsClearFields(t *testing.T) {
+	params := &MessageEditParams{
+		Embeds:      []Embed{{Title: "status"}},
+		ClearFields: []string{"content"},
+	}
+	if err := params.Validate(); err != nil {
+		t.Fatalf("expected valid params, got %v", err)
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if string(decoded["content"]) != `""` {
+		t.Fatalf("expected cleared content, got %s", data)
+	}
+	if _, ok := decoded["embeds"]; !ok {
+		t.Fatalf("expected embeds to be preserved, got %s", data)
+	}
+}
+
+func TestMessageEditParamsWithoutClearsOmitsEmptyFields(t *testing.T) {
+	data, err := json.Marshal(MessageEditParams{Content: "updated"})
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(data) != `{"content":"updated"}` {
+		t.Fatalf("unexpected payload %s", data)
+	}
+}
+
+func TestClearFieldsRejectUnknownFields(t *testing.T) {
+	params := &MessageEditParams{ClearFields: []string{"author"}}
+	if err := params.Validate(); err == nil {
+		t.Fatal("expected validation error for unknown clear field")
+	}
+	if _, err := json.Marshal(params); err == nil {
+		t.Fatal("expected marshal error for unknown clear field")
+	}
+}
@@ -88,6 +88,9 @@ const (
 	ButtonStyleSuccess
 	ButtonStyleDanger
 	ButtonStyleLink
+	// ButtonStylePremium opens the purchase flow for SKUID. Premium buttons
+	// have no label, emoji, custom_id, or URL.
+	ButtonStylePremium
 )
 
 // Button represents an interactive button component.
@@ -97,6 +100,7 @@ type Button struct {
 	Emoji    *Emoji      `json:"emoji,omitempty"`
 	CustomID string      `json:"custom_id,omitempty"`
 	URL      string      `json:"url,omitempty"`
+	SKUID    string      `json:"sku_id,omitempty"`
 	Disabled bool        `json:"disabled,omitempty"`
 }
 
@@ -110,9 +114,21 @@ func (b *Button) Validate() error {
 	if b == nil {
 		return &ValidationError{Field: "button", Message: "button is required"}
 	}
-	if b.Style < ButtonStylePrimary || b.Style > ButtonStyleLink {
+	if b.Style < ButtonStylePrimary || b.Style > ButtonStylePremium {
 		return &ValidationError{Field: "button.style", Message: "invalid button style"}
 	}
+	if b.Style == ButtonStylePremium {
+		if strings.TrimSpace(b.SKUID) == "" {
+			return &ValidationError{Field: "button.sku_id", Message: "premium buttons require a sku_id"}
+		}
+		if b.Label != "" || b.Emoji != nil || b.CustomID != "" || b.URL != "" {
+			return &ValidationError{Field: "button", Message: "premium buttons cannot have a label, emoji, custom_id, or URL"}
+		}
+		return nil
+	}
+	if b.SKUID != "" {
+		return &ValidationError{Field: "button.sku_id", Message: "only premium buttons can have a sku_id"}
+	}
 	if utf8.RuneCountInString(b.Label) > maxButtonLabelLength {
 		return &ValidationError{Field: "button.label", Message: fmt.Sprintf("label must be <= %d characters", maxButtonLabelLength)}
 	}
@@ -154,6 +170,7 @@ func (b *Button) ToMessageComponent() (MessageComponent, error) {
 		Emoji:    b.Emoji,
 		CustomID: b.CustomID,
 		URL:      b.URL,
+		SKUID:    b.SKUID,
 		Disabled: b.Disabled,
 	}, nil
 }
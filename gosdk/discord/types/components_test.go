@@ -23,6 +23,15 @@ func TestButtonValidate(t *testing.T) {
 	if err := btn.Validate(); err == nil {
 		t.Fatal("expected error for missing URL on link button")
 	}
+
+	premium := &Button{Style: ButtonStylePremium, SKUID: "sku-1"}
+	if err := premium.Validate(); err != nil {
+		t.Fatalf("expected valid premium button, got %v", err)
+	}
+	premium.Label = "Buy"
+	if err := premium.Validate(); err == nil {
+		t.Fatal("expected error for premium button with a label")
+	}
 }
 
 func TestSelectMenuValidate(t *testing.T) {
@@ -0,0 +1,66 @@
+package types
+
+import "time"
+
+// EntitlementType describes how a user or guild came to own a SKU.
+type EntitlementType int
+
+const (
+	EntitlementTypePurchase EntitlementType = iota + 1
+	EntitlementTypePremiumSubscription
+	EntitlementTypeDeveloperGift
+	EntitlementTypeTestModePurchase
+	EntitlementTypeFreePurchase
+	EntitlementTypeUserGift
+	EntitlementTypePremiumPurchase
+	EntitlementTypeApplicationSubscription
+)
+
+// Entitlement grants a user or guild access to a premium SKU of the
+// application.
+type Entitlement struct {
+	ID            string          `json:"id"`
+	SKUID         string          `json:"sku_id"`
+	ApplicationID string          `json:"application_id"`
+	UserID        string          `json:"user_id,omitempty"`
+	GuildID       string          `json:"guild_id,omitempty"`
+	Type          EntitlementType `json:"type"`
+	Deleted       bool            `json:"deleted"`
+	StartsAt      *time.Time      `json:"starts_at,omitempty"`
+	EndsAt        *time.Time      `json:"ends_at,omitempty"`
+	Consumed      bool            `json:"consumed,omitempty"`
+}
+
+// Active reports whether the entitlement grants access at now. Entitlements
+// without dates (test purchases, gifts) never expire.
+func (e *Entitlement) Active(now time.Time) bool {
+	if e == nil || e.Deleted {
+		return false
+	}
+	if e.StartsAt != nil && now.Before(*e.StartsAt) {
+		return false
+	}
+	return e.EndsAt == nil || now.Before(*e.EndsAt)
+}
+
+// ListEntitlementsParams filters the application's entitlements.
+type ListEntitlementsParams struct {
+	UserID       string
+	GuildID      string
+	SKUIDs       []string
+	Before       string
+	After        string
+	Limit        int
+	ExcludeEnded bool
+}
+
+// Validate ensures the page size is within Discord's 1-100 range.
+func (p *ListEntitlementsParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Limit < 0 || p.Limit > 100 {
+		return &ValidationError{Field: "limit", Message: "limit must be between 1 and 100"}
+	}
+	return nil
+}
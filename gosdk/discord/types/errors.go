@@ -32,15 +32,25 @@ type APIError struct {
 	Code       int
 	Errors     map[string]interface{}
 	RetryAfter int // seconds to wait before retry (for rate limits)
+	APIVersion int // pinned API version the request was sent to (0 if unknown)
 }
 
 func (e *APIError) Error() string {
 	if e.RetryAfter > 0 {
 		return fmt.Sprintf("Discord API error %d: %s (retry after %ds)", e.StatusCode, e.Message, e.RetryAfter)
 	}
+	if e.UnknownRoute() && e.APIVersion > 0 {
+		return fmt.Sprintf("Discord API error %d: %s (endpoint not available on API v%d)", e.StatusCode, e.Message, e.APIVersion)
+	}
 	return fmt.Sprintf("Discord API error %d: %s", e.StatusCode, e.Message)
 }
 
+// UnknownRoute reports a 404 without a Discord error code, which Discord
+// returns for routes that do not exist (as opposed to unknown resources).
+func (e *APIError) UnknownRoute() bool {
+	return e.StatusCode == 404 && e.Code == 0
+}
+
 // Is implements error matching for common error types
 func (e *APIError) Is(target error) bool {
 	switch target {
@@ -59,6 +69,22 @@ func (e *APIError) Is(target error) bool {
 	}
 }
 
+// APIVersionError reports an SDK feature that is unavailable on the pinned
+// Discord API version or was disabled through feature gate overrides.
+type APIVersionError struct {
+	Feature         string
+	PinnedVersion   int
+	RequiredVersion int
+	Disabled        bool
+}
+
+func (e *APIVersionError) Error() string {
+	if e.Disabled {
+		return fmt.Sprintf("feature %q is disabled by client feature overrides (API v%d)", e.Feature, e.PinnedVersion)
+	}
+	return fmt.Sprintf("feature %q requires Discord API v%d or newer (pinned to v%d)", e.Feature, e.RequiredVersion, e.PinnedVersion)
+}
+
 // ValidationError represents input validation errors
 type ValidationError struct {
 	Field   string
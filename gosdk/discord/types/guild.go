@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Guild represents a Discord guild (server).
 type Guild struct {
@@ -22,6 +25,7 @@ type Guild struct {
 	DefaultMessageNotifications int            `json:"default_message_notifications,omitempty"`
 	ExplicitContentFilter       int            `json:"explicit_content_filter,omitempty"`
 	Roles                       []Role         `json:"roles,omitempty"`
+	Emojis                      []Emoji        `json:"emojis,omitempty"`
 	Members                     []Member       `json:"members,omitempty"`
 	Channels                    []Channel      `json:"channels,omitempty"`
 	Description                 string         `json:"description,omitempty"`
@@ -30,6 +34,52 @@ type Guild struct {
 	ApproximateMemberCount      int            `json:"approximate_member_count,omitempty"`
 	ApproximatePresenceCount    int            `json:"approximate_presence_count,omitempty"`
 	WelcomeScreen               *WelcomeScreen `json:"welcome_screen,omitempty"`
+	SystemChannelID             string         `json:"system_channel_id,omitempty"`
+	RulesChannelID              string         `json:"rules_channel_id,omitempty"`
+	PublicUpdatesChannelID      string         `json:"public_updates_channel_id,omitempty"`
+	PreferredLocale             string         `json:"preferred_locale,omitempty"`
+	PremiumTier                 int            `json:"premium_tier"`
+	PremiumSubscriptionCount    int            `json:"premium_subscription_count,omitempty"`
+	NSFWLevel                   int            `json:"nsfw_level,omitempty"`
+	MFALevel                    int            `json:"mfa_level,omitempty"`
+}
+
+// Guild feature flags that change what a bot can do in a guild.
+const (
+	GuildFeatureCommunity          = "COMMUNITY"
+	GuildFeatureNews               = "NEWS"
+	GuildFeatureWelcomeScreen      = "WELCOME_SCREEN_ENABLED"
+	GuildFeatureMemberVerification = "MEMBER_VERIFICATION_GATE_ENABLED"
+	GuildFeatureAutoModeration     = "AUTO_MODERATION"
+	GuildFeatureRoleSubscriptions  = "ROLE_SUBSCRIPTIONS_ENABLED"
+	GuildFeatureInvitesDisabled    = "INVITES_DISABLED"
+	GuildFeatureVanityURL          = "VANITY_URL"
+	GuildFeatureAnimatedIcon       = "ANIMATED_ICON"
+	GuildFeatureBanner             = "BANNER"
+)
+
+// BotCapabilityFeatures maps guild features to the bot capabilities they unlock or restrict.
+var BotCapabilityFeatures = map[string]string{
+	GuildFeatureCommunity:          "stage channels, announcement channels, forum guidelines, welcome screen",
+	GuildFeatureNews:               "announcement channels and channel following",
+	GuildFeatureWelcomeScreen:      "welcome screen configuration",
+	GuildFeatureMemberVerification: "membership screening (new members stay pending)",
+	GuildFeatureAutoModeration:     "auto moderation rules",
+	GuildFeatureRoleSubscriptions:  "role subscription listings",
+	GuildFeatureInvitesDisabled:    "invite creation is paused",
+}
+
+// HasFeature reports whether the guild advertises the given feature flag.
+func (g *Guild) HasFeature(feature string) bool {
+	if g == nil {
+		return false
+	}
+	for _, f := range g.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
 }
 
 // GuildModifyParams represents the payload for modifying a guild.
@@ -45,7 +95,18 @@ type GuildModifyParams struct {
 	Banner                      string `json:"banner,omitempty"`
 	Description                 string `json:"description,omitempty"`
 	PreferredLocale             string `json:"preferred_locale,omitempty"`
-	AuditLogReason              string `json:"-"`
+	SystemChannelID             string `json:"system_channel_id,omitempty"`
+	RulesChannelID              string `json:"rules_channel_id,omitempty"`
+	PublicUpdatesChannelID      string `json:"public_updates_channel_id,omitempty"`
+	// MergePatch is an RFC 7386 document merged over the final payload.
+	MergePatch     json.RawMessage `json:"-"`
+	AuditLogReason string          `json:"-"`
+}
+
+// MarshalJSON emits the populated fields merged with MergePatch.
+func (p GuildModifyParams) MarshalJSON() ([]byte, error) {
+	type alias GuildModifyParams
+	return marshalPatch(alias(p), nil, nil, p.MergePatch)
 }
 
 // Role represents a guild role.
@@ -77,7 +138,15 @@ type RoleModifyParams struct {
 	Color       int    `json:"color,omitempty"`
 	Hoist       bool   `json:"hoist,omitempty"`
 	Mentionable bool   `json:"mentionable,omitempty"`
-	AuditLogReason string `json:"-"`
+	// MergePatch is an RFC 7386 document merged over the final payload.
+	MergePatch     json.RawMessage `json:"-"`
+	AuditLogReason string          `json:"-"`
+}
+
+// MarshalJSON emits the populated fields merged with MergePatch.
+func (p RoleModifyParams) MarshalJSON() ([]byte, error) {
+	type alias RoleModifyParams
+	return marshalPatch(alias(p), nil, nil, p.MergePatch)
 }
 
 // Member represents a guild member.
@@ -90,6 +159,9 @@ type Member struct {
 	Deaf         bool       `json:"deaf"`
 	Mute         bool       `json:"mute"`
 	Pending      bool       `json:"pending,omitempty"`
+	// Permissions is the member's total permissions in the channel,
+	// included only in interaction payloads.
+	Permissions string `json:"permissions,omitempty"`
 }
 
 // ListMembersParams controls pagination when listing guild members.
@@ -117,6 +189,7 @@ type Emoji struct {
 	ID        string   `json:"id"`
 	Name      string   `json:"name"`
 	Roles     []string `json:"roles,omitempty"`
+	Animated  bool     `json:"animated,omitempty"`
 	Available bool     `json:"available"`
 }
 
@@ -174,7 +247,7 @@ func (p *RoleModifyParams) Validate() error {
 	if p == nil {
 		return &ValidationError{Field: "params", Message: "role modify params required"}
 	}
-	return nil
+	return ValidateMergePatch(p.MergePatch)
 }
 
 // Validate ensures member list params are within Discord bounds.
@@ -196,5 +269,5 @@ func (p *GuildModifyParams) Validate() error {
 	if p.Name != "" && len(p.Name) > 100 {
 		return &ValidationError{Field: "name", Message: "guild name exceeds 100 characters"}
 	}
-	return nil
+	return ValidateMergePatch(p.MergePatch)
 }
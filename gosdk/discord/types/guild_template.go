@@ -0,0 +1,123 @@
+package types
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxTemplateNameLength        = 100
+	maxTemplateDescriptionLength = 120
+)
+
+// GuildTemplate is a snapshot of a guild's channels, roles, and settings
+// that new guilds can be created from.
+type GuildTemplate struct {
+	Code                  string               `json:"code"`
+	Name                  string               `json:"name"`
+	Description           string               `json:"description,omitempty"`
+	UsageCount            int                  `json:"usage_count"`
+	CreatorID             string               `json:"creator_id"`
+	Creator               *User                `json:"creator,omitempty"`
+	CreatedAt             string               `json:"created_at"`
+	UpdatedAt             string               `json:"updated_at"`
+	SourceGuildID         string               `json:"source_guild_id"`
+	SerializedSourceGuild *TemplateSourceGuild `json:"serialized_source_guild,omitempty"`
+	// IsDirty reports unsynced changes in the source guild.
+	IsDirty bool `json:"is_dirty,omitempty"`
+}
+
+// URL returns the discord.new link that creates a guild from the template.
+func (t *GuildTemplate) URL() string {
+	return "https://discord.new/" + t.Code
+}
+
+// TemplateSourceGuild is the guild layout captured by a template. Roles and
+// channels use small integer placeholder IDs rather than snowflakes.
+type TemplateSourceGuild struct {
+	Name                        string            `json:"name"`
+	Description                 string            `json:"description,omitempty"`
+	VerificationLevel           int               `json:"verification_level"`
+	DefaultMessageNotifications int               `json:"default_message_notifications"`
+	ExplicitContentFilter       int               `json:"explicit_content_filter"`
+	PreferredLocale             string            `json:"preferred_locale,omitempty"`
+	Roles                       []TemplateRole    `json:"roles"`
+	Channels                    []TemplateChannel `json:"channels"`
+}
+
+// TemplateRole is a role in a template.
+type TemplateRole struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Color       int    `json:"color"`
+	Hoist       bool   `json:"hoist"`
+	Mentionable bool   `json:"mentionable"`
+	Permissions string `json:"permissions"`
+}
+
+// TemplateChannel is a channel in a template.
+type TemplateChannel struct {
+	ID       int         `json:"id"`
+	Name     string      `json:"name"`
+	Type     ChannelType `json:"type"`
+	ParentID *int        `json:"parent_id,omitempty"`
+	Position int         `json:"position"`
+	Topic    string      `json:"topic,omitempty"`
+}
+
+// GuildTemplateParams creates or modifies a template.
+type GuildTemplateParams struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Validate enforces Discord's name and description limits. Name is required
+// when creating.
+func (p *GuildTemplateParams) Validate(create bool) error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "template params required"}
+	}
+	if create && strings.TrimSpace(p.Name) == "" {
+		return &ValidationError{Field: "name", Message: "template name is required"}
+	}
+	if utf8.RuneCountInString(p.Name) > maxTemplateNameLength {
+		return &ValidationError{Field: "name", Message: "template name exceeds 100 characters"}
+	}
+	if utf8.RuneCountInString(p.Description) > maxTemplateDescriptionLength {
+		return &ValidationError{Field: "description", Message: "template description exceeds 120 characters"}
+	}
+	return nil
+}
+
+// GuildFromTemplateParams creates a new guild from a template.
+type GuildFromTemplateParams struct {
+	Name string `json:"name"`
+	// Icon is a data URI (data:image/png;base64,...).
+	Icon string `json:"icon,omitempty"`
+}
+
+// Validate ensures the guild name is 2-100 characters.
+func (p *GuildFromTemplateParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "guild params required"}
+	}
+	n := utf8.RuneCountInString(strings.TrimSpace(p.Name))
+	if n < 2 || n > 100 {
+		return &ValidationError{Field: "name", Message: "guild name must be 2-100 characters"}
+	}
+	return nil
+}
+
+// TemplateCode extracts a template code from a bare code or a
+// discord.new / discord.com/template link.
+func TemplateCode(ref string) string {
+	ref = strings.TrimSpace(ref)
+	ref = strings.TrimSuffix(ref, "/")
+	if i := strings.LastIndex(ref, "/"); i >= 0 && strings.Contains(ref, "discord") {
+		ref = ref[i+1:]
+	}
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		ref = ref[:i]
+	}
+	return ref
+}
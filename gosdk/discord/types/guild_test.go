@@ -85,3 +85,17 @@ func TestGuildJSONMarshaling(t *testing.T) {
 		t.Fatalf("unexpected JSON: %s", data)
 	}
 }
+
+func TestTemplateCode(t *testing.T) {
+	cases := map[string]string{
+		"hgM48av5Q69A":                               "hgM48av5Q69A",
+		"https://discord.new/hgM48av5Q69A":           "hgM48av5Q69A",
+		"https://discord.com/template/hgM48av5Q69A/": "hgM48av5Q69A",
+		" discord.new/hgM48av5Q69A?utm_source=x ":    "hgM48av5Q69A",
+	}
+	for in, want := range cases {
+		if got := TemplateCode(in); got != want {
+			t.Errorf("TemplateCode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
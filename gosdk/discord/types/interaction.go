@@ -45,6 +45,9 @@ type Interaction struct {
 	Message       *Message         `json:"message,omitempty"`
 	Locale        string           `json:"locale,omitempty"`
 	GuildLocale   string           `json:"guild_locale,omitempty"`
+	// Entitlements are the invoking user's and guild's active entitlements
+	// for the application's SKUs.
+	Entitlements []Entitlement `json:"entitlements,omitempty"`
 }
 
 // InteractionData contains payload-specific data (commands/components).
@@ -112,6 +115,10 @@ type ApplicationCommandOption struct {
 	MinLength                *int                         `json:"min_length,omitempty"`
 	MaxLength                *int                         `json:"max_length,omitempty"`
 	Autocomplete             bool                         `json:"autocomplete,omitempty"`
+	// Value and Focused are only set on options received in interaction
+	// payloads; Focused marks the option being autocompleted.
+	Value   interface{} `json:"value,omitempty"`
+	Focused bool        `json:"focused,omitempty"`
 }
 
 // ApplicationCommandOptionType enumerates option types.
@@ -188,6 +195,27 @@ func (c *ApplicationCommand) Validate() error {
 	return nil
 }
 
+// FocusedOption returns the option the user is typing in during
+// autocomplete, searching subcommand options, or nil if none is focused.
+func (d *InteractionData) FocusedOption() *ApplicationCommandOption {
+	if d == nil {
+		return nil
+	}
+	return focusedOption(d.Options)
+}
+
+func focusedOption(options []ApplicationCommandOption) *ApplicationCommandOption {
+	for i := range options {
+		if options[i].Focused {
+			return &options[i]
+		}
+		if opt := focusedOption(options[i].Options); opt != nil {
+			return opt
+		}
+	}
+	return nil
+}
+
 // Validate ensures option details respect Discord limits.
 func (o *ApplicationCommandOption) Validate() error {
 	if len(o.Name) < 1 || len(o.Name) > 32 {
@@ -246,6 +274,7 @@ type MessageComponent struct {
 	Label        string             `json:"label,omitempty"`
 	Emoji        *Emoji             `json:"emoji,omitempty"`
 	URL          string             `json:"url,omitempty"`
+	SKUID        string             `json:"sku_id,omitempty"`
 	Options      []SelectOption     `json:"options,omitempty"`
 	Placeholder  string             `json:"placeholder,omitempty"`
 	MinValues    int                `json:"min_values,omitempty"`
@@ -44,6 +44,26 @@ func TestApplicationCommandOptionValidate(t *testing.T) {
 	}
 }
 
+func TestInteractionDataFocusedOption(t *testing.T) {
+	data := &InteractionData{Options: []ApplicationCommandOption{{
+		Name: "session",
+		Type: CommandOptionSubCommand,
+		Options: []ApplicationCommandOption{
+			{Name: "agent", Type: CommandOptionString, Value: "claude"},
+			{Name: "id", Type: CommandOptionString, Value: "ab", Focused: true},
+		},
+	}}}
+	opt := data.FocusedOption()
+	if opt == nil || opt.Name != "id" || opt.Value != "ab" {
+		t.Fatalf("unexpected focused option: %+v", opt)
+	}
+
+	data.Options[0].Options[1].Focused = false
+	if opt := data.FocusedOption(); opt != nil {
+		t.Fatalf("expected no focused option, got %+v", opt)
+	}
+}
+
 func TestInteractionResponseValidate_Message(t *testing.T) {
 	resp := &InteractionResponse{
 		Type: InteractionResponseChannelMessageWithSource,
@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Message represents a Discord message
 type Message struct {
@@ -17,6 +20,27 @@ type Message struct {
 	Flags           int             `json:"flags,omitempty"`
 	Thread          *Channel        `json:"thread,omitempty"`
 	ThreadMetadata  *ThreadMetadata `json:"thread_metadata,omitempty"`
+	Reactions       []Reaction      `json:"reactions,omitempty"`
+}
+
+// MessageFlagEphemeral shows an interaction response or followup only to the
+// invoking user.
+const MessageFlagEphemeral = 1 << 6
+
+// Reaction is one emoji's reaction tally on a message.
+type Reaction struct {
+	Count int   `json:"count"`
+	Me    bool  `json:"me"`
+	Emoji Emoji `json:"emoji"`
+}
+
+// EmojiRef returns the emoji in the form reaction endpoints take: the
+// unicode character, or name:id for custom emoji.
+func (r Reaction) EmojiRef() string {
+	if r.Emoji.ID != "" {
+		return r.Emoji.Name + ":" + r.Emoji.ID
+	}
+	return r.Emoji.Name
 }
 
 // User represents a Discord user
@@ -82,13 +106,46 @@ type Attachment struct {
 
 // MessageCreateParams represents parameters for creating a message
 type MessageCreateParams struct {
-	Content string  `json:"content,omitempty"`
-	Embeds  []Embed `json:"embeds,omitempty"`
-	// Add more fields as needed (components, attachments, etc.)
+	Content    string             `json:"content,omitempty"`
+	Embeds     []Embed            `json:"embeds,omitempty"`
+	Flags      int                `json:"flags,omitempty"`
+	Components []MessageComponent `json:"components,omitempty"`
 }
 
 // MessageEditParams represents editable message fields.
+// List "content" or "embeds" in ClearFields to remove them from the message;
+// MergePatch, when set, is an RFC 7386 document merged over the final payload.
 type MessageEditParams struct {
-	Content string  `json:"content,omitempty"`
-	Embeds  []Embed `json:"embeds,omitempty"`
+	Content     string             `json:"content,omitempty"`
+	Embeds      []Embed            `json:"embeds,omitempty"`
+	Components  []MessageComponent `json:"components,omitempty"`
+	ClearFields []string           `json:"-"`
+	MergePatch  json.RawMessage    `json:"-"`
+}
+
+var messageEditClearValues = map[string]json.RawMessage{
+	"content": json.RawMessage(`""`),
+	"embeds":  json.RawMessage("[]"),
+}
+
+// ClearableMessageEditFields returns the JSON field names accepted by ClearFields.
+func ClearableMessageEditFields() []string {
+	return clearableFields(messageEditClearValues)
+}
+
+// MarshalJSON emits the populated fields, explicit resets for ClearFields, and MergePatch.
+func (p MessageEditParams) MarshalJSON() ([]byte, error) {
+	type alias MessageEditParams
+	return marshalPatch(alias(p), p.ClearFields, messageEditClearValues, p.MergePatch)
+}
+
+// Validate ensures ClearFields and MergePatch are well formed.
+func (p *MessageEditParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "message edit params required"}
+	}
+	if err := ValidateMergePatch(p.MergePatch); err != nil {
+		return err
+	}
+	return validateClearFields(p.ClearFields, messageEditClearValues)
 }
@@ -0,0 +1,133 @@
+package types
+
+// OnboardingMode selects which channels count toward onboarding's
+// requirements.
+type OnboardingMode int
+
+const (
+	// OnboardingModeDefault counts only default channels.
+	OnboardingModeDefault OnboardingMode = 0
+	// OnboardingModeAdvanced counts default channels and prompt answers.
+	OnboardingModeAdvanced OnboardingMode = 1
+)
+
+// OnboardingPromptType is how a prompt's options are presented.
+type OnboardingPromptType int
+
+const (
+	OnboardingPromptMultipleChoice OnboardingPromptType = 0
+	OnboardingPromptDropdown       OnboardingPromptType = 1
+)
+
+// GuildOnboarding is a guild's onboarding flow (community guilds).
+type GuildOnboarding struct {
+	GuildID           string             `json:"guild_id"`
+	Prompts           []OnboardingPrompt `json:"prompts"`
+	DefaultChannelIDs []string           `json:"default_channel_ids"`
+	Enabled           bool               `json:"enabled"`
+	Mode              OnboardingMode     `json:"mode"`
+}
+
+// OnboardingPrompt is one question in the onboarding flow.
+type OnboardingPrompt struct {
+	ID           string                   `json:"id,omitempty"`
+	Type         OnboardingPromptType     `json:"type"`
+	Options      []OnboardingPromptOption `json:"options"`
+	Title        string                   `json:"title"`
+	SingleSelect bool                     `json:"single_select"`
+	Required     bool                     `json:"required"`
+	InOnboarding bool                     `json:"in_onboarding"`
+}
+
+// OnboardingPromptOption is an answer that grants channels and roles.
+// Discord returns the emoji as an object but accepts it only as the flat
+// emoji_* fields; GuildOnboardingParams.Normalize copies one to the other.
+type OnboardingPromptOption struct {
+	ID            string           `json:"id,omitempty"`
+	ChannelIDs    []string         `json:"channel_ids"`
+	RoleIDs       []string         `json:"role_ids"`
+	Emoji         *OnboardingEmoji `json:"emoji,omitempty"`
+	EmojiID       string           `json:"emoji_id,omitempty"`
+	EmojiName     string           `json:"emoji_name,omitempty"`
+	EmojiAnimated bool             `json:"emoji_animated,omitempty"`
+	Title         string           `json:"title"`
+	Description   string           `json:"description,omitempty"`
+}
+
+// OnboardingEmoji is the partial emoji Discord returns on prompt options.
+type OnboardingEmoji struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Animated bool   `json:"animated,omitempty"`
+}
+
+// GuildOnboardingParams replaces a guild's onboarding flow. Every field is
+// sent, so start from the current configuration (GET) when editing.
+type GuildOnboardingParams struct {
+	Prompts           []OnboardingPrompt `json:"prompts"`
+	DefaultChannelIDs []string           `json:"default_channel_ids"`
+	Enabled           bool               `json:"enabled"`
+	Mode              OnboardingMode     `json:"mode"`
+	AuditLogReason    string             `json:"-"`
+}
+
+// Normalize moves option emoji objects into the emoji_* fields Discord
+// accepts on writes, so a fetched configuration can be sent back unchanged.
+func (p *GuildOnboardingParams) Normalize() {
+	if p == nil {
+		return
+	}
+	for i := range p.Prompts {
+		for j := range p.Prompts[i].Options {
+			opt := &p.Prompts[i].Options[j]
+			if opt.Emoji != nil && opt.EmojiID == "" && opt.EmojiName == "" {
+				opt.EmojiID, opt.EmojiName, opt.EmojiAnimated = opt.Emoji.ID, opt.Emoji.Name, opt.Emoji.Animated
+			}
+			opt.Emoji = nil
+		}
+	}
+}
+
+// Validate checks the limits Discord enforces on onboarding prompts.
+func (p *GuildOnboardingParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "onboarding params required"}
+	}
+	for _, prompt := range p.Prompts {
+		if prompt.Title == "" {
+			return &ValidationError{Field: "prompts.title", Message: "every prompt needs a title"}
+		}
+		if len(prompt.Options) == 0 {
+			return &ValidationError{Field: "prompts.options", Message: "prompt " + prompt.Title + " has no options"}
+		}
+		for _, opt := range prompt.Options {
+			if opt.Title == "" {
+				return &ValidationError{Field: "prompts.options.title", Message: "every option in prompt " + prompt.Title + " needs a title"}
+			}
+		}
+	}
+	return nil
+}
+
+// WelcomeScreenModifyParams updates a guild's welcome screen. Nil fields are
+// left unchanged.
+type WelcomeScreenModifyParams struct {
+	Enabled         *bool                   `json:"enabled,omitempty"`
+	WelcomeChannels *[]WelcomeScreenChannel `json:"welcome_channels,omitempty"`
+	Description     *string                 `json:"description,omitempty"`
+	AuditLogReason  string                  `json:"-"`
+}
+
+// Validate enforces Discord's limit of five welcome channels.
+func (p *WelcomeScreenModifyParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "welcome screen params required"}
+	}
+	if p.Enabled == nil && p.WelcomeChannels == nil && p.Description == nil {
+		return &ValidationError{Field: "params", Message: "nothing to modify"}
+	}
+	if p.WelcomeChannels != nil && len(*p.WelcomeChannels) > 5 {
+		return &ValidationError{Field: "welcome_channels", Message: "at most 5 welcome channels are allowed"}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// marshalPatch marshals v with its clears applied (see marshalWithClears) and
+// then merges an RFC 7386 patch document on top. Nulls in the patch are kept
+// so Discord receives them as explicit "remove this" values.
+func marshalPatch(v any, clear []string, resets map[string]json.RawMessage, patch json.RawMessage) ([]byte, error) {
+	data, err := marshalWithClears(v, clear, resets)
+	if err != nil || len(patch) == 0 {
+		return data, err
+	}
+	patchFields, err := decodePatchObject(patch)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields, err = mergePatch(fields, patchFields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// ValidateMergePatch ensures the document is a JSON object, as required for
+// merge patches applied to Discord resources.
+func ValidateMergePatch(patch json.RawMessage) error {
+	if len(patch) == 0 {
+		return nil
+	}
+	_, err := decodePatchObject(patch)
+	return err
+}
+
+func decodePatchObject(patch json.RawMessage) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	trimmed := bytes.TrimSpace(patch)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, &ValidationError{Field: "patch", Message: "merge patch must be a JSON object"}
+	}
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return nil, &ValidationError{Field: "patch", Message: "merge patch is not valid JSON: " + err.Error()}
+	}
+	return fields, nil
+}
+
+// mergePatch applies patch onto target: nested objects merge recursively,
+// every other value (including null) replaces the target value.
+func mergePatch(target, patch map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	if target == nil {
+		target = make(map[string]json.RawMessage, len(patch))
+	}
+	for key, value := range patch {
+		existing, ok := target[key]
+		if ok && isJSONObject(existing) && isJSONObject(value) {
+			var left, right map[string]json.RawMessage
+			if err := json.Unmarshal(existing, &left); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(value, &right); err != nil {
+				return nil, err
+			}
+			merged, err := mergePatch(left, right)
+			if err != nil {
+				return nil, err
+			}
+			encoded, err := json.Marshal(merged)
+			if err != nil {
+				return nil, err
+			}
+			target[key] = encoded
+			continue
+		}
+		target[key] = value
+	}
+	return target, nil
+}
+
+func isJSONObject(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
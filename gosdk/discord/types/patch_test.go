@@ -0,0 +1,71 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestModifyChannelParamsMergePatch(t *testing.T) {
+	params := &ModifyChannelParams{
+		Name:        "alerts",
+		Topic:       "old",
+		ClearFields: []string{"parent_id"},
+		MergePatch:  json.RawMessage(`{"topic": null, "default_reaction_emoji": {"emoji_name": "✅"}, "parent_id": "9"}`),
+	}
+	if err := params.Validate(); err != nil {
+		t.Fatalf("expected valid params, got %v", err)
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if string(decoded["name"]) != `"alerts"` {
+		t.Fatalf("expected flag-set name to survive, got %s", data)
+	}
+	if string(decoded["topic"]) != "null" {
+		t.Fatalf("expected patch null to be forwarded, got %s", data)
+	}
+	if string(decoded["parent_id"]) != `"9"` {
+		t.Fatalf("expected patch to override clears, got %s", data)
+	}
+	if _, ok := decoded["default_reaction_emoji"]; !ok {
+		t.Fatalf("expected nested patch object, got %s", data)
+	}
+}
+
+func TestMergePatchMergesNestedObjects(t *testing.T) {
+	target := map[string]json.RawMessage{"a": json.RawMessage(`{"x":1,"y":2}`)}
+	patch := map[string]json.RawMessage{"a": json.RawMessage(`{"y":null,"z":3}`)}
+	merged, err := mergePatch(target, patch)
+	if err != nil {
+		t.Fatalf("mergePatch error: %v", err)
+	}
+	var inner map[string]json.RawMessage
+	if err := json.Unmarshal(merged["a"], &inner); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if string(inner["x"]) != "1" || string(inner["y"]) != "null" || string(inner["z"]) != "3" {
+		t.Fatalf("unexpected merge result %s", merged["a"])
+	}
+}
+
+func TestValidateMergePatchRequiresObject(t *testing.T) {
+	if err := ValidateMergePatch(json.RawMessage(`["topic"]`)); err == nil {
+		t.Fatal("expected error for non-object patch")
+	}
+	if err := ValidateMergePatch(json.RawMessage(`{"topic":`)); err == nil {
+		t.Fatal("expected error for malformed patch")
+	}
+	params := &RoleModifyParams{MergePatch: json.RawMessage(`{"color": 0}`)}
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(data) != `{"color":0}` {
+		t.Fatalf("expected zero color from patch, got %s", data)
+	}
+}
@@ -0,0 +1,30 @@
+package types
+
+// PruneParams selects inactive members for a prune. By default only members
+// without roles are pruned; IncludeRoles also prunes members whose roles are
+// all in the list.
+type PruneParams struct {
+	Days         int      `json:"days"`
+	IncludeRoles []string `json:"include_roles,omitempty"`
+	// ComputePruneCount asks Discord to return the pruned count. Discord
+	// recommends false for large guilds, where counting can time out.
+	ComputePruneCount bool   `json:"compute_prune_count"`
+	AuditLogReason    string `json:"-"`
+}
+
+// Validate ensures days is within Discord's 1-30 range.
+func (p *PruneParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "prune params required"}
+	}
+	if p.Days < 1 || p.Days > 30 {
+		return &ValidationError{Field: "days", Message: "days must be between 1 and 30"}
+	}
+	return nil
+}
+
+// PruneResult reports how many members a prune removed (or would remove).
+// Pruned is nil when the count was not computed.
+type PruneResult struct {
+	Pruned *int `json:"pruned"`
+}
@@ -0,0 +1,76 @@
+package types
+
+import "unicode/utf8"
+
+// StagePrivacyLevel controls who can see a stage instance.
+type StagePrivacyLevel int
+
+const (
+	// StagePrivacyPublic is deprecated by Discord and rejected for new stages.
+	StagePrivacyPublic StagePrivacyLevel = 1
+	// StagePrivacyGuildOnly limits the stage to guild members.
+	StagePrivacyGuildOnly StagePrivacyLevel = 2
+)
+
+// maxStageTopicLength is Discord's limit for stage instance topics.
+const maxStageTopicLength = 120
+
+// StageInstance is a live stage in a stage channel.
+type StageInstance struct {
+	ID                    string            `json:"id"`
+	GuildID               string            `json:"guild_id"`
+	ChannelID             string            `json:"channel_id"`
+	Topic                 string            `json:"topic"`
+	PrivacyLevel          StagePrivacyLevel `json:"privacy_level"`
+	DiscoverableDisabled  bool              `json:"discoverable_disabled,omitempty"`
+	GuildScheduledEventID string            `json:"guild_scheduled_event_id,omitempty"`
+}
+
+// StageInstanceCreateParams starts a stage in a stage channel.
+type StageInstanceCreateParams struct {
+	ChannelID             string            `json:"channel_id"`
+	Topic                 string            `json:"topic"`
+	PrivacyLevel          StagePrivacyLevel `json:"privacy_level,omitempty"`
+	SendStartNotification bool              `json:"send_start_notification,omitempty"`
+	GuildScheduledEventID string            `json:"guild_scheduled_event_id,omitempty"`
+	AuditLogReason        string            `json:"-"`
+}
+
+// Validate ensures the create payload is acceptable to Discord.
+func (p *StageInstanceCreateParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "stage instance params required"}
+	}
+	if p.ChannelID == "" {
+		return &ValidationError{Field: "channel_id", Message: "channel ID is required"}
+	}
+	return validateStageTopic(p.Topic, true)
+}
+
+// StageInstanceModifyParams updates a live stage.
+type StageInstanceModifyParams struct {
+	Topic          string            `json:"topic,omitempty"`
+	PrivacyLevel   StagePrivacyLevel `json:"privacy_level,omitempty"`
+	AuditLogReason string            `json:"-"`
+}
+
+// Validate ensures at least one field is set and the topic fits.
+func (p *StageInstanceModifyParams) Validate() error {
+	if p == nil {
+		return &ValidationError{Field: "params", Message: "stage instance params required"}
+	}
+	if p.Topic == "" && p.PrivacyLevel == 0 {
+		return &ValidationError{Field: "params", Message: "nothing to modify"}
+	}
+	return validateStageTopic(p.Topic, false)
+}
+
+func validateStageTopic(topic string, required bool) error {
+	if required && topic == "" {
+		return &ValidationError{Field: "topic", Message: "topic is required"}
+	}
+	if utf8.RuneCountInString(topic) > maxStageTopicLength {
+		return &ValidationError{Field: "topic", Message: "topic exceeds 120 characters"}
+	}
+	return nil
+}
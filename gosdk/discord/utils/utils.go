@@ -89,6 +89,44 @@ func TimeToSnowflake(t time.Time) string {
 	return strconv.FormatInt(val, 10)
 }
 
+// TimestampStyle selects how Discord clients render <t:...> markup.
+type TimestampStyle string
+
+const (
+	TimestampShortTime     TimestampStyle = "t" // 4:20 PM
+	TimestampLongTime      TimestampStyle = "T" // 4:20:30 PM
+	TimestampShortDate     TimestampStyle = "d" // 20/04/2021
+	TimestampLongDate      TimestampStyle = "D" // 20 April 2021
+	TimestampShortDateTime TimestampStyle = "f" // 20 April 2021 16:20
+	TimestampLongDateTime  TimestampStyle = "F" // Tuesday, 20 April 2021 16:20
+	TimestampRelative      TimestampStyle = "R" // 2 months ago
+)
+
+// FormatTimestamp builds Discord timestamp markup such as <t:1618953630:R>.
+// An empty style uses the client default (short date/time).
+func FormatTimestamp(t time.Time, style TimestampStyle) string {
+	if style == "" {
+		return fmt.Sprintf("<t:%d>", t.Unix())
+	}
+	return fmt.Sprintf("<t:%d:%s>", t.Unix(), style)
+}
+
+// ParseTimestamp extracts the time and style from Discord timestamp markup.
+func ParseTimestamp(markup string) (time.Time, TimestampStyle, bool) {
+	if !strings.HasPrefix(markup, "<t:") || !strings.HasSuffix(markup, ">") {
+		return time.Time{}, "", false
+	}
+	unix, style, _ := strings.Cut(markup[3:len(markup)-1], ":")
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	if len(style) > 1 || (style != "" && !strings.Contains("tTdDfFR", style)) {
+		return time.Time{}, "", false
+	}
+	return time.Unix(seconds, 0), TimestampStyle(style), true
+}
+
 // ChunkSlice splits a slice into chunks of the requested size.
 func ChunkSlice[T any](slice []T, size int) [][]T {
 	if size <= 0 {
@@ -60,3 +60,20 @@ func TestRateLimitDelay(t *testing.T) {
 		t.Fatalf("expected positive delay, got %v", delay)
 	}
 }
+
+func TestTimestampMarkup(t *testing.T) {
+	at := time.Unix(1618953630, 0)
+	if got := FormatTimestamp(at, TimestampRelative); got != "<t:1618953630:R>" {
+		t.Fatalf("FormatTimestamp = %s", got)
+	}
+	if got := FormatTimestamp(at, ""); got != "<t:1618953630>" {
+		t.Fatalf("FormatTimestamp default = %s", got)
+	}
+	parsed, style, ok := ParseTimestamp("<t:1618953630:F>")
+	if !ok || !parsed.Equal(at) || style != TimestampLongDateTime {
+		t.Fatalf("ParseTimestamp = %v %q %v", parsed, style, ok)
+	}
+	if _, _, ok := ParseTimestamp("<t:1618953630:Z>"); ok {
+		t.Fatal("expected invalid style to be rejected")
+	}
+}
@@ -0,0 +1,396 @@
+package voice
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+const (
+	voiceGatewayVersion = 8
+
+	opIdentify           = 0
+	opSelectProtocol     = 1
+	opReady              = 2
+	opHeartbeat          = 3
+	opSessionDescription = 4
+	opSpeaking           = 5
+	opHello              = 8
+
+	speakingMicrophone = 1
+
+	frameDuration   = 20 * time.Millisecond
+	silenceFrames   = 5
+	discoveryLength = 74
+)
+
+// Session holds what the main gateway hands out for a voice connection: the
+// bot's voice session (VOICE_STATE_UPDATE) and the allocated voice server
+// (VOICE_SERVER_UPDATE).
+type Session struct {
+	GuildID   string
+	UserID    string
+	SessionID string
+	Token     string
+	Endpoint  string
+}
+
+// Option configures a voice connection.
+type Option func(*Connection)
+
+// WithLogger overrides the logger.
+func WithLogger(l *logger.Logger) Option {
+	return func(c *Connection) {
+		if l != nil {
+			c.logger = l
+		}
+	}
+}
+
+// WithDialer overrides the websocket dialer.
+func WithDialer(d *websocket.Dialer) Option {
+	return func(c *Connection) {
+		if d != nil {
+			c.dialer = d
+		}
+	}
+}
+
+// Connection is an established voice connection: the voice websocket for
+// signalling and a UDP socket for encrypted RTP audio.
+type Connection struct {
+	logger *logger.Logger
+	dialer *websocket.Dialer
+
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+	udp     *net.UDPConn
+	ssrc    uint32
+	mode    string
+	packets *packetizer
+
+	seqMu sync.Mutex
+	seq   int
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type voicePayload struct {
+	Op  int             `json:"op"`
+	D   json.RawMessage `json:"d"`
+	Seq *int            `json:"seq,omitempty"`
+}
+
+type voiceReady struct {
+	SSRC  uint32   `json:"ssrc"`
+	IP    string   `json:"ip"`
+	Port  int      `json:"port"`
+	Modes []string `json:"modes"`
+}
+
+type voiceSessionDescription struct {
+	Mode      string `json:"mode"`
+	SecretKey []int  `json:"secret_key"`
+}
+
+// Open performs the voice handshake: identify, discover the external UDP
+// address, select an encryption mode, and wait for the session key. It
+// advertises no end-to-end encryption (DAVE) support, so channels that
+// require it will refuse the connection.
+func Open(ctx context.Context, session Session, opts ...Option) (*Connection, error) {
+	if session.Endpoint == "" || session.Token == "" || session.SessionID == "" {
+		return nil, errors.New("voice session requires endpoint, token, and session id")
+	}
+	c := &Connection{logger: logger.Default(), dialer: websocket.DefaultDialer}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	ws, _, err := c.dialer.DialContext(ctx, voiceURL(session.Endpoint), nil)
+	if err != nil {
+		return nil, fmt.Errorf("dial voice websocket: %w", err)
+	}
+	c.ws = ws
+	if err := c.handshake(ctx, session); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func voiceURL(endpoint string) string {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "wss://" + endpoint
+	}
+	return fmt.Sprintf("%s/?v=%d", strings.TrimSuffix(endpoint, "/"), voiceGatewayVersion)
+}
+
+func (c *Connection) handshake(ctx context.Context, session Session) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.ws.SetReadDeadline(deadline)
+		defer c.ws.SetReadDeadline(time.Time{})
+	}
+	if err := c.send(opIdentify, map[string]interface{}{
+		"server_id":                 session.GuildID,
+		"user_id":                   session.UserID,
+		"session_id":                session.SessionID,
+		"token":                     session.Token,
+		"max_dave_protocol_version": 0,
+	}); err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	for {
+		payload, err := c.receive()
+		if err != nil {
+			return fmt.Errorf("voice handshake: %w", err)
+		}
+		switch payload.Op {
+		case opHello:
+			var hello struct {
+				HeartbeatInterval float64 `json:"heartbeat_interval"`
+			}
+			if err := json.Unmarshal(payload.D, &hello); err != nil {
+				return fmt.Errorf("decode voice hello: %w", err)
+			}
+			c.startHeartbeat(runCtx, time.Duration(hello.HeartbeatInterval*float64(time.Millisecond)))
+		case opReady:
+			var ready voiceReady
+			if err := json.Unmarshal(payload.D, &ready); err != nil {
+				return fmt.Errorf("decode voice ready: %w", err)
+			}
+			if err := c.selectProtocol(ctx, ready); err != nil {
+				return err
+			}
+		case opSessionDescription:
+			var desc voiceSessionDescription
+			if err := json.Unmarshal(payload.D, &desc); err != nil {
+				return fmt.Errorf("decode session description: %w", err)
+			}
+			key := make([]byte, len(desc.SecretKey))
+			for i, b := range desc.SecretKey {
+				key[i] = byte(b)
+			}
+			packets, err := newPacketizer(c.ssrc, desc.Mode, key)
+			if err != nil {
+				return err
+			}
+			c.packets = packets
+			c.wg.Add(1)
+			go c.drain(runCtx)
+			return nil
+		}
+	}
+}
+
+// selectProtocol opens the UDP socket, runs IP discovery, and tells the
+// voice server where to send audio and which encryption mode to use.
+func (c *Connection) selectProtocol(ctx context.Context, ready voiceReady) error {
+	mode, err := selectMode(ready.Modes)
+	if err != nil {
+		return err
+	}
+	addr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(ready.IP, fmt.Sprint(ready.Port)))
+	if err != nil {
+		return fmt.Errorf("resolve voice server: %w", err)
+	}
+	udp, err := net.DialUDP("udp", nil, addr)
+	if err != nil {
+		return fmt.Errorf("dial voice udp: %w", err)
+	}
+	c.udp, c.ssrc, c.mode = udp, ready.SSRC, mode
+
+	ip, port, err := discoverIP(ctx, udp, ready.SSRC)
+	if err != nil {
+		return err
+	}
+	return c.send(opSelectProtocol, map[string]interface{}{
+		"protocol": "udp",
+		"data":     map[string]interface{}{"address": ip, "port": port, "mode": mode},
+	})
+}
+
+// discoverIP asks the voice server for our external address as seen from
+// its side of any NAT.
+func discoverIP(ctx context.Context, udp *net.UDPConn, ssrc uint32) (string, int, error) {
+	req := make([]byte, discoveryLength)
+	binary.BigEndian.PutUint16(req[0:2], 1)
+	binary.BigEndian.PutUint16(req[2:4], discoveryLength-4)
+	binary.BigEndian.PutUint32(req[4:8], ssrc)
+	if _, err := udp.Write(req); err != nil {
+		return "", 0, fmt.Errorf("send ip discovery: %w", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = udp.SetReadDeadline(deadline)
+	defer udp.SetReadDeadline(time.Time{})
+
+	resp := make([]byte, discoveryLength)
+	n, err := udp.Read(resp)
+	if err != nil {
+		return "", 0, fmt.Errorf("read ip discovery: %w", err)
+	}
+	if n < discoveryLength || binary.BigEndian.Uint16(resp[0:2]) != 2 {
+		return "", 0, errors.New("invalid ip discovery response")
+	}
+	ip := strings.TrimRight(string(resp[8:72]), "\x00")
+	return ip, int(binary.BigEndian.Uint16(resp[72:74])), nil
+}
+
+func (c *Connection) startHeartbeat(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			c.seqMu.Lock()
+			ack := c.seq
+			c.seqMu.Unlock()
+			payload := map[string]interface{}{"t": time.Now().UnixMilli(), "seq_ack": ack}
+			if err := c.send(opHeartbeat, payload); err != nil {
+				c.logger.Warn("voice heartbeat failed", "error", err)
+				return
+			}
+		}
+	}()
+}
+
+// drain keeps reading the voice websocket after the handshake so acks and
+// client notices are consumed and seq_ack stays current.
+func (c *Connection) drain(ctx context.Context) {
+	defer c.wg.Done()
+	for {
+		if _, err := c.receive(); err != nil {
+			if ctx.Err() == nil {
+				c.logger.Warn("voice websocket closed", "error", err)
+			}
+			return
+		}
+	}
+}
+
+func (c *Connection) send(op int, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal voice op %d: %w", op, err)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.ws.WriteJSON(voicePayload{Op: op, D: raw}); err != nil {
+		return fmt.Errorf("write voice op %d: %w", op, err)
+	}
+	return nil
+}
+
+func (c *Connection) receive() (*voicePayload, error) {
+	var payload voicePayload
+	if err := c.ws.ReadJSON(&payload); err != nil {
+		return nil, err
+	}
+	if payload.Seq != nil {
+		c.seqMu.Lock()
+		c.seq = *payload.Seq
+		c.seqMu.Unlock()
+	}
+	return &payload, nil
+}
+
+// Mode returns the negotiated encryption mode.
+func (c *Connection) Mode() string {
+	return c.mode
+}
+
+// Play streams src in real time, one frame every 20ms, and returns the number
+// of frames sent. It stops early when ctx is cancelled.
+func (c *Connection) Play(ctx context.Context, src OpusSource) (int, error) {
+	if c.packets == nil {
+		return 0, errors.New("voice connection is not ready")
+	}
+	if err := c.setSpeaking(true); err != nil {
+		return 0, err
+	}
+	defer c.setSpeaking(false)
+
+	ticker := time.NewTicker(frameDuration)
+	defer ticker.Stop()
+	frames := 0
+	for {
+		frame, err := src.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return frames, fmt.Errorf("read audio: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return frames, ctx.Err()
+		case <-ticker.C:
+		}
+		if _, err := c.udp.Write(c.packets.packet(frame)); err != nil {
+			return frames, fmt.Errorf("send audio: %w", err)
+		}
+		frames++
+	}
+	for i := 0; i < silenceFrames; i++ {
+		if _, err := c.udp.Write(c.packets.packet(silenceFrame)); err != nil {
+			return frames, fmt.Errorf("send audio: %w", err)
+		}
+	}
+	return frames, nil
+}
+
+func (c *Connection) setSpeaking(speaking bool) error {
+	flags := 0
+	if speaking {
+		flags = speakingMicrophone
+	}
+	return c.send(opSpeaking, map[string]interface{}{"speaking": flags, "delay": 0, "ssrc": c.ssrc})
+}
+
+// Close shuts down the voice websocket and UDP socket.
+func (c *Connection) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	var errs []error
+	if c.ws != nil {
+		c.writeMu.Lock()
+		_ = c.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		c.writeMu.Unlock()
+		if err := c.ws.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.udp != nil {
+		if err := c.udp.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,209 @@
+package voice
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yourorg/arc-discord/gosdk/discord/gateway"
+)
+
+type sliceSource [][]byte
+
+func (s *sliceSource) ReadPacket() ([]byte, error) {
+	if len(*s) == 0 {
+		return nil, io.EOF
+	}
+	p := (*s)[0]
+	*s = (*s)[1:]
+	return p, nil
+}
+
+// fakeVoiceServer speaks enough of the voice protocol for a handshake and
+// records the UDP packets and websocket ops it receives.
+type fakeVoiceServer struct {
+	t   *testing.T
+	ws  *httptest.Server
+	udp *net.UDPConn
+	mu  sync.Mutex
+	ops []int
+	rtp int
+}
+
+func newFakeVoiceServer(t *testing.T) *fakeVoiceServer {
+	udp, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeVoiceServer{t: t, udp: udp}
+	go f.serveUDP()
+	upgrader := websocket.Upgrader{}
+	f.ws = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		f.serveWS(conn)
+	}))
+	t.Cleanup(func() {
+		f.ws.Close()
+		udp.Close()
+	})
+	return f
+}
+
+func (f *fakeVoiceServer) endpoint() string {
+	return "ws" + strings.TrimPrefix(f.ws.URL, "http")
+}
+
+func (f *fakeVoiceServer) serveUDP() {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := f.udp.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		if n == discoveryLength && binary.BigEndian.Uint16(buf[0:2]) == 1 {
+			resp := make([]byte, discoveryLength)
+			binary.BigEndian.PutUint16(resp[0:2], 2)
+			copy(resp[8:], "203.0.113.5")
+			binary.BigEndian.PutUint16(resp[72:74], 50000)
+			_, _ = f.udp.WriteToUDP(resp, addr)
+			continue
+		}
+		f.mu.Lock()
+		f.rtp++
+		f.mu.Unlock()
+	}
+}
+
+func (f *fakeVoiceServer) serveWS(conn *websocket.Conn) {
+	seq := 0
+	write := func(op int, d interface{}) {
+		raw, _ := json.Marshal(d)
+		seq++
+		_ = conn.WriteJSON(voicePayload{Op: op, D: raw, Seq: &seq})
+	}
+	write(opHello, map[string]float64{"heartbeat_interval": 30000})
+	for {
+		var msg voicePayload
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.ops = append(f.ops, msg.Op)
+		f.mu.Unlock()
+		switch msg.Op {
+		case opIdentify:
+			var id map[string]interface{}
+			_ = json.Unmarshal(msg.D, &id)
+			if id["token"] != "voice-token" || id["session_id"] != "sess" {
+				f.t.Errorf("unexpected identify %v", id)
+			}
+			port := f.udp.LocalAddr().(*net.UDPAddr).Port
+			write(opReady, voiceReady{SSRC: 42, IP: "127.0.0.1", Port: port, Modes: []string{ModeXChaCha20Poly1305, ModeAES256GCM}})
+		case opSelectProtocol:
+			var sel struct {
+				Data struct {
+					Address string `json:"address"`
+					Port    int    `json:"port"`
+					Mode    string `json:"mode"`
+				} `json:"data"`
+			}
+			_ = json.Unmarshal(msg.D, &sel)
+			if sel.Data.Address != "203.0.113.5" || sel.Data.Port != 50000 {
+				f.t.Errorf("unexpected select protocol %+v", sel.Data)
+			}
+			key := make([]int, 32)
+			write(opSessionDescription, voiceSessionDescription{Mode: sel.Data.Mode, SecretKey: key})
+		}
+	}
+}
+
+func TestOpenAndPlay(t *testing.T) {
+	srv := newFakeVoiceServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := Open(ctx, Session{GuildID: "g1", UserID: "u1", SessionID: "sess", Token: "voice-token", Endpoint: srv.endpoint()})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer conn.Close()
+	if conn.Mode() != ModeAES256GCM {
+		t.Fatalf("mode = %s", conn.Mode())
+	}
+
+	src := sliceSource{{1}, {2}, {3}}
+	frames, err := conn.Play(ctx, &src)
+	if err != nil || frames != 3 {
+		t.Fatalf("play frames=%d err=%v", frames, err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		srv.mu.Lock()
+		rtp, ops := srv.rtp, append([]int(nil), srv.ops...)
+		srv.mu.Unlock()
+		if rtp == 3+silenceFrames && len(ops) >= 4 {
+			if ops[2] != opSpeaking || ops[3] != opSpeaking {
+				t.Fatalf("expected speaking on/off, got ops %v", ops)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("server saw %d rtp packets and ops %v", srv.rtp, srv.ops)
+}
+
+type fakeGateway struct {
+	handlers map[string]gateway.EventHandler
+	updates  []string
+	endpoint string
+}
+
+func (g *fakeGateway) On(eventType string, handler gateway.EventHandler) {
+	g.handlers[eventType] = handler
+}
+
+func (g *fakeGateway) UpdateVoiceState(ctx context.Context, guildID, channelID string, mute, deaf bool) error {
+	g.updates = append(g.updates, channelID)
+	if channelID == "" {
+		return nil
+	}
+	go func() {
+		_ = g.handlers[gateway.EventVoiceServerUpdate](ctx, &gateway.VoiceServerUpdateEvent{GuildID: guildID, Token: "voice-token", Endpoint: g.endpoint})
+		_ = g.handlers[gateway.EventVoiceStateUpdate](ctx, &gateway.VoiceStateUpdateEvent{GuildID: guildID, ChannelID: "other", UserID: "u1", SessionID: "stale"})
+		_ = g.handlers[gateway.EventVoiceStateUpdate](ctx, &gateway.VoiceStateUpdateEvent{GuildID: guildID, ChannelID: channelID, UserID: "u1", SessionID: "sess"})
+	}()
+	return nil
+}
+
+func TestJoinWaitsForStateAndServer(t *testing.T) {
+	srv := newFakeVoiceServer(t)
+	gw := &fakeGateway{handlers: map[string]gateway.EventHandler{}, endpoint: srv.endpoint()}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := Join(ctx, gw, "u1", "g1", "c1")
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	conn.Close()
+	if err := Leave(ctx, gw, "g1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(gw.updates) != 2 || gw.updates[0] != "c1" || gw.updates[1] != "" {
+		t.Fatalf("unexpected voice state updates %v", gw.updates)
+	}
+}
@@ -0,0 +1,78 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/gateway"
+)
+
+// Gateway is the part of the main gateway client needed to join voice.
+type Gateway interface {
+	On(eventType string, handler gateway.EventHandler)
+	UpdateVoiceState(ctx context.Context, guildID, channelID string, mute, deaf bool) error
+}
+
+// Join asks the gateway to move the bot (userID) into channelID, waits for
+// Discord to hand out the voice session and server, and opens the voice
+// connection. The bot joins self-deafened since it only plays audio. Bound
+// the wait with ctx; Discord does not answer if the bot lacks CONNECT.
+func Join(ctx context.Context, gw Gateway, userID, guildID, channelID string, opts ...Option) (*Connection, error) {
+	if userID == "" || guildID == "" || channelID == "" {
+		return nil, errors.New("user, guild, and channel ids are required")
+	}
+	states := make(chan *gateway.VoiceStateUpdateEvent, 1)
+	servers := make(chan *gateway.VoiceServerUpdateEvent, 1)
+	gw.On(gateway.EventVoiceStateUpdate, func(_ context.Context, event gateway.Event) error {
+		evt, ok := event.(*gateway.VoiceStateUpdateEvent)
+		if ok && evt.GuildID == guildID && evt.UserID == userID && evt.ChannelID == channelID {
+			select {
+			case states <- evt:
+			default:
+			}
+		}
+		return nil
+	})
+	gw.On(gateway.EventVoiceServerUpdate, func(_ context.Context, event gateway.Event) error {
+		evt, ok := event.(*gateway.VoiceServerUpdateEvent)
+		if ok && evt.GuildID == guildID {
+			select {
+			case servers <- evt:
+			default:
+			}
+		}
+		return nil
+	})
+
+	if err := gw.UpdateVoiceState(ctx, guildID, channelID, false, true); err != nil {
+		return nil, fmt.Errorf("request voice join: %w", err)
+	}
+	var (
+		state  *gateway.VoiceStateUpdateEvent
+		server *gateway.VoiceServerUpdateEvent
+	)
+	for state == nil || server == nil {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for voice server: %w", ctx.Err())
+		case state = <-states:
+		case server = <-servers:
+		}
+	}
+	if server.Endpoint == "" {
+		return nil, errors.New("discord has not allocated a voice server; try again")
+	}
+	return Open(ctx, Session{
+		GuildID:   guildID,
+		UserID:    userID,
+		SessionID: state.SessionID,
+		Token:     server.Token,
+		Endpoint:  server.Endpoint,
+	}, opts...)
+}
+
+// Leave disconnects the bot from voice in guildID.
+func Leave(ctx context.Context, gw Gateway, guildID string) error {
+	return gw.UpdateVoiceState(ctx, guildID, "", false, false)
+}
@@ -0,0 +1,130 @@
+package voice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	oggPageHeaderSize = 27
+	oggMaxSegment     = 255
+)
+
+// OpusSource yields Opus packets, one 20ms frame at a time, until io.EOF.
+type OpusSource interface {
+	ReadPacket() ([]byte, error)
+}
+
+// OggReader extracts Opus packets from an Ogg Opus stream (RFC 7845), such as
+// the output of `ffmpeg -c:a libopus -ar 48000 -ac 2 clip.ogg`. Only the first
+// logical stream is read; the OpusHead and OpusTags header packets are
+// skipped.
+type OggReader struct {
+	r        *bufio.Reader
+	serial   uint32
+	started  bool
+	segments []byte
+	data     []byte
+	partial  []byte
+	headers  int
+}
+
+// NewOggReader wraps r. The stream is validated lazily by ReadPacket.
+func NewOggReader(r io.Reader) *OggReader {
+	return &OggReader{r: bufio.NewReader(r)}
+}
+
+// ReadPacket returns the next Opus audio packet, or io.EOF at the end of the
+// stream.
+func (o *OggReader) ReadPacket() ([]byte, error) {
+	for {
+		packet, err := o.nextPacket()
+		if err != nil {
+			return nil, err
+		}
+		switch o.headers {
+		case 0:
+			if !bytes.HasPrefix(packet, []byte("OpusHead")) {
+				return nil, errors.New("ogg stream is not Opus (missing OpusHead)")
+			}
+			o.headers++
+			continue
+		case 1:
+			if !bytes.HasPrefix(packet, []byte("OpusTags")) {
+				return nil, errors.New("ogg opus stream is missing OpusTags")
+			}
+			o.headers++
+			continue
+		}
+		if len(packet) == 0 {
+			continue
+		}
+		return packet, nil
+	}
+}
+
+// nextPacket reassembles the next packet from the lacing values, which may
+// continue across pages.
+func (o *OggReader) nextPacket() ([]byte, error) {
+	for {
+		if len(o.segments) == 0 {
+			if err := o.readPage(); err != nil {
+				if errors.Is(err, io.EOF) && len(o.partial) > 0 {
+					return nil, io.ErrUnexpectedEOF
+				}
+				return nil, err
+			}
+			continue
+		}
+		size := int(o.segments[0])
+		o.segments = o.segments[1:]
+		o.partial = append(o.partial, o.data[:size]...)
+		o.data = o.data[size:]
+		if size < oggMaxSegment {
+			packet := o.partial
+			o.partial = nil
+			return packet, nil
+		}
+	}
+}
+
+func (o *OggReader) readPage() error {
+	for {
+		header := make([]byte, oggPageHeaderSize)
+		if _, err := io.ReadFull(o.r, header); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("truncated ogg page header: %w", err)
+			}
+			return err
+		}
+		if string(header[:4]) != "OggS" {
+			return errors.New("invalid ogg page: missing OggS capture pattern")
+		}
+		serial := binary.LittleEndian.Uint32(header[14:18])
+		segments := make([]byte, header[26])
+		if _, err := io.ReadFull(o.r, segments); err != nil {
+			return fmt.Errorf("truncated ogg segment table: %w", err)
+		}
+		total := 0
+		for _, s := range segments {
+			total += int(s)
+		}
+		data := make([]byte, total)
+		if _, err := io.ReadFull(o.r, data); err != nil {
+			return fmt.Errorf("truncated ogg page: %w", err)
+		}
+		if !o.started {
+			o.serial = serial
+			o.started = true
+		}
+		if serial != o.serial {
+			continue
+		}
+		o.segments, o.data = segments, data
+		return nil
+	}
+}
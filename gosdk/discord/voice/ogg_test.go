@@ -0,0 +1,83 @@
+package voice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+// oggPage builds a page carrying packets; a packet continued on the next
+// page is expressed by ending with a 255 lacing value (pass open=true).
+func oggPage(serial uint32, open bool, packets ...[]byte) []byte {
+	var lacing, body []byte
+	for i, p := range packets {
+		n := len(p)
+		for n >= 255 {
+			lacing = append(lacing, 255)
+			n -= 255
+		}
+		if !(open && i == len(packets)-1) {
+			lacing = append(lacing, byte(n))
+		}
+		body = append(body, p...)
+	}
+	header := make([]byte, oggPageHeaderSize)
+	copy(header, "OggS")
+	binary.LittleEndian.PutUint32(header[14:18], serial)
+	header[26] = byte(len(lacing))
+	return append(append(header, lacing...), body...)
+}
+
+func oggOpus(packets ...[]byte) []byte {
+	var buf bytes.Buffer
+	buf.Write(oggPage(1, false, []byte("OpusHead\x01\x02")))
+	buf.Write(oggPage(1, false, []byte("OpusTags")))
+	buf.Write(oggPage(1, false, packets...))
+	return buf.Bytes()
+}
+
+func TestOggReaderSkipsHeadersAndSplitsPackets(t *testing.T) {
+	long := bytes.Repeat([]byte{7}, 300)
+	r := NewOggReader(bytes.NewReader(oggOpus([]byte{1, 2, 3}, long, []byte{4})))
+
+	var got [][]byte
+	for {
+		p, err := r.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read packet: %v", err)
+		}
+		got = append(got, p)
+	}
+	if len(got) != 3 || len(got[1]) != 300 || got[2][0] != 4 {
+		t.Fatalf("unexpected packets: %d", len(got))
+	}
+}
+
+func TestOggReaderJoinsPacketsAcrossPages(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(oggPage(1, false, []byte("OpusHead")))
+	buf.Write(oggPage(1, false, []byte("OpusTags")))
+	buf.Write(oggPage(1, true, bytes.Repeat([]byte{1}, 255)))
+	buf.Write(oggPage(2, false, []byte("other stream")))
+	buf.Write(oggPage(1, false, []byte{2, 2}))
+
+	p, err := NewOggReader(&buf).ReadPacket()
+	if err != nil || len(p) != 257 || p[256] != 2 {
+		t.Fatalf("joined packet len=%d err=%v", len(p), err)
+	}
+}
+
+func TestOggReaderRejectsNonOpus(t *testing.T) {
+	data := oggPage(1, false, []byte("\x01vorbis"))
+	if _, err := NewOggReader(bytes.NewReader(data)).ReadPacket(); err == nil {
+		t.Fatalf("expected error for vorbis stream")
+	}
+	if _, err := NewOggReader(bytes.NewReader([]byte("RIFF....WAVEfmt something long enough"))).ReadPacket(); err == nil {
+		t.Fatalf("expected error for non-ogg input")
+	}
+}
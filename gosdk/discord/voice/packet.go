@@ -0,0 +1,98 @@
+package voice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// ModeAES256GCM and ModeXChaCha20Poly1305 are the encryption modes Discord
+	// accepts for new voice connections, in order of preference.
+	ModeAES256GCM         = "aead_aes256_gcm_rtpsize"
+	ModeXChaCha20Poly1305 = "aead_xchacha20_poly1305_rtpsize"
+
+	rtpHeaderSize   = 12
+	rtpVersion      = 0x80
+	rtpPayloadType  = 0x78
+	nonceSuffixSize = 4
+
+	// samplesPerFrame is 20ms of 48kHz audio, the frame size Discord expects.
+	samplesPerFrame = 960
+)
+
+// silenceFrame is sent a few times after playback so clients stop
+// interpolating the last frame.
+var silenceFrame = []byte{0xF8, 0xFF, 0xFE}
+
+// supportedModes lists the encryption modes this package implements, most
+// preferred first.
+var supportedModes = []string{ModeAES256GCM, ModeXChaCha20Poly1305}
+
+// selectMode picks the first supported mode the voice server offers.
+func selectMode(offered []string) (string, error) {
+	for _, mode := range supportedModes {
+		for _, o := range offered {
+			if o == mode {
+				return mode, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no supported encryption mode in %v", offered)
+}
+
+func newAEAD(mode string, key []byte) (cipher.AEAD, error) {
+	switch mode {
+	case ModeAES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case ModeXChaCha20Poly1305:
+		return chacha20poly1305.NewX(key)
+	default:
+		return nil, fmt.Errorf("unsupported encryption mode %q", mode)
+	}
+}
+
+// packetizer turns Opus frames into encrypted RTP packets. In the *_rtpsize
+// modes the RTP header is authenticated but not encrypted, and a 32-bit
+// counter, zero-padded to the AEAD's nonce size, is appended to each packet.
+type packetizer struct {
+	ssrc      uint32
+	sequence  uint16
+	timestamp uint32
+	nonce     uint32
+	aead      cipher.AEAD
+}
+
+func newPacketizer(ssrc uint32, mode string, key []byte) (*packetizer, error) {
+	aead, err := newAEAD(mode, key)
+	if err != nil {
+		return nil, err
+	}
+	return &packetizer{ssrc: ssrc, aead: aead}, nil
+}
+
+func (p *packetizer) packet(opus []byte) []byte {
+	out := make([]byte, rtpHeaderSize, rtpHeaderSize+len(opus)+p.aead.Overhead()+nonceSuffixSize)
+	out[0] = rtpVersion
+	out[1] = rtpPayloadType
+	binary.BigEndian.PutUint16(out[2:4], p.sequence)
+	binary.BigEndian.PutUint32(out[4:8], p.timestamp)
+	binary.BigEndian.PutUint32(out[8:12], p.ssrc)
+
+	nonce := make([]byte, p.aead.NonceSize())
+	binary.BigEndian.PutUint32(nonce, p.nonce)
+	out = p.aead.Seal(out, nonce, opus, out[:rtpHeaderSize])
+	out = append(out, nonce[:nonceSuffixSize]...)
+
+	p.sequence++
+	p.timestamp += samplesPerFrame
+	p.nonce++
+	return out
+}
@@ -0,0 +1,43 @@
+package voice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestPacketizerEncryptsWithRTPHeaderAsAAD(t *testing.T) {
+	key := bytes.Repeat([]byte{9}, 32)
+	for _, mode := range supportedModes {
+		p, err := newPacketizer(0xAABBCCDD, mode, key)
+		if err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		p.packet([]byte{1})
+		pkt := p.packet([]byte("opus"))
+
+		if binary.BigEndian.Uint16(pkt[2:4]) != 1 || binary.BigEndian.Uint32(pkt[4:8]) != samplesPerFrame || binary.BigEndian.Uint32(pkt[8:12]) != 0xAABBCCDD {
+			t.Fatalf("%s: unexpected rtp header % x", mode, pkt[:12])
+		}
+		aead, _ := newAEAD(mode, key)
+		nonce := make([]byte, aead.NonceSize())
+		copy(nonce, pkt[len(pkt)-nonceSuffixSize:])
+		if binary.BigEndian.Uint32(nonce) != 1 {
+			t.Fatalf("%s: nonce counter not appended", mode)
+		}
+		plain, err := aead.Open(nil, nonce, pkt[rtpHeaderSize:len(pkt)-nonceSuffixSize], pkt[:rtpHeaderSize])
+		if err != nil || string(plain) != "opus" {
+			t.Fatalf("%s: decrypt %q, %v", mode, plain, err)
+		}
+	}
+}
+
+func TestSelectModePrefersAES(t *testing.T) {
+	mode, err := selectMode([]string{"xsalsa20_poly1305", ModeXChaCha20Poly1305, ModeAES256GCM})
+	if err != nil || mode != ModeAES256GCM {
+		t.Fatalf("selectMode = %q, %v", mode, err)
+	}
+	if _, err := selectMode([]string{"xsalsa20_poly1305"}); err == nil {
+		t.Fatalf("expected error for unsupported modes")
+	}
+}
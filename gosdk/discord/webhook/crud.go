@@ -15,11 +15,14 @@ import (
 
 // MessageEditParams represents parameters for editing a webhook message
 type MessageEditParams struct {
-	Content         *string        `json:"content,omitempty"`
-	Embeds          []types.Embed  `json:"embeds,omitempty"`
+	Content         *string                  `json:"content,omitempty"`
+	Embeds          []types.Embed            `json:"embeds,omitempty"`
+	Components      []types.MessageComponent `json:"components,omitempty"`
 	AllowedMentions *struct {
 		Parse []string `json:"parse,omitempty"`
 	} `json:"allowed_mentions,omitempty"`
+	// ThreadID targets a message that lives in a thread of the webhook's channel.
+	ThreadID string `json:"-"`
 	// Note: File attachments cannot be edited, only replaced
 }
 
@@ -41,7 +44,7 @@ func (c *Client) Edit(ctx context.Context, messageID string, params *MessageEdit
 	}
 
 	// Build URL for editing message
-	url := c.buildMessageURL(messageID)
+	url := c.buildURLWithThreadID(c.buildMessageURL(messageID), params.ThreadID)
 
 	body, err := json.Marshal(params)
 	if err != nil {
@@ -53,6 +56,12 @@ func (c *Client) Edit(ctx context.Context, messageID string, params *MessageEdit
 
 // Delete deletes a previously sent webhook message
 func (c *Client) Delete(ctx context.Context, messageID string) error {
+	return c.DeleteInThread(ctx, messageID, "")
+}
+
+// DeleteInThread deletes a webhook message posted in the given thread.
+// An empty threadID targets the webhook's channel.
+func (c *Client) DeleteInThread(ctx context.Context, messageID, threadID string) error {
 	if messageID == "" {
 		return &types.ValidationError{
 			Field:   "messageID",
@@ -60,7 +69,7 @@ func (c *Client) Delete(ctx context.Context, messageID string) error {
 		}
 	}
 
-	url := c.buildMessageURL(messageID)
+	url := c.buildURLWithThreadID(c.buildMessageURL(messageID), threadID)
 	route := ratelimit.RouteFromEndpoint("DELETE", url)
 
 	var lastErr error
@@ -145,6 +154,12 @@ func (c *Client) Delete(ctx context.Context, messageID string) error {
 
 // Get retrieves a previously sent webhook message
 func (c *Client) Get(ctx context.Context, messageID string) (*types.Message, error) {
+	return c.GetInThread(ctx, messageID, "")
+}
+
+// GetInThread retrieves a webhook message posted in the given thread.
+// An empty threadID targets the webhook's channel.
+func (c *Client) GetInThread(ctx context.Context, messageID, threadID string) (*types.Message, error) {
 	if messageID == "" {
 		return nil, &types.ValidationError{
 			Field:   "messageID",
@@ -152,7 +167,7 @@ func (c *Client) Get(ctx context.Context, messageID string) (*types.Message, err
 		}
 	}
 
-	url := c.buildMessageURL(messageID)
+	url := c.buildURLWithThreadID(c.buildMessageURL(messageID), threadID)
 
 	return c.doMessageRequest(ctx, "GET", url, nil)
 }
@@ -417,3 +417,44 @@ func unwrapToAPIError(err error) *types.APIError {
 	}
 	return nil
 }
+
+func TestClient_MessageOpsInThread(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(types.Message{ID: "123", ChannelID: "thread-9"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	content := "deploy finished"
+	if _, err := client.Edit(ctx, "123", &MessageEditParams{Content: &content, ThreadID: "9"}); err != nil {
+		t.Fatalf("Edit() error = %v", err)
+	}
+	if _, err := client.GetInThread(ctx, "123", "9"); err != nil {
+		t.Fatalf("GetInThread() error = %v", err)
+	}
+	if err := client.DeleteInThread(ctx, "123", "9"); err != nil {
+		t.Fatalf("DeleteInThread() error = %v", err)
+	}
+
+	want := []string{"PATCH /messages/123?thread_id=9", "GET /messages/123?thread_id=9", "DELETE /messages/123?thread_id=9"}
+	if len(seen) != len(want) {
+		t.Fatalf("expected %d requests, got %v", len(want), seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("request %d = %s, want %s", i, seen[i], want[i])
+		}
+	}
+}
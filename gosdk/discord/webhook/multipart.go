@@ -112,19 +112,34 @@ func (f *FileAttachment) resolvedSize() (int64, bool, error) {
 
 // SendWithFiles sends a webhook message with file attachments
 func (c *Client) SendWithFiles(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment) error {
+	_, err := c.sendWithFiles(ctx, msg, files, false)
+	return err
+}
+
+// SendWithFilesAndWait sends a webhook message with file attachments using
+// ?wait=true and returns the message Discord created.
+func (c *Client) SendWithFilesAndWait(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment) (*types.Message, error) {
+	respBody, err := c.sendWithFiles(ctx, msg, files, true)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCreatedMessage(respBody)
+}
+
+func (c *Client) sendWithFiles(ctx context.Context, msg *types.WebhookMessage, files []FileAttachment, wait bool) ([]byte, error) {
 	if err := msg.Validate(); err != nil {
-		return fmt.Errorf("invalid webhook message: %w", err)
+		return nil, fmt.Errorf("invalid webhook message: %w", err)
 	}
 
 	if len(files) == 0 {
-		return &types.ValidationError{
+		return nil, &types.ValidationError{
 			Field:   "files",
 			Message: "at least one file is required (use Send for messages without files)",
 		}
 	}
 
 	if len(files) > MaxFiles {
-		return &types.ValidationError{
+		return nil, &types.ValidationError{
 			Field:   "files",
 			Message: fmt.Sprintf("too many files: %d (maximum %d)", len(files), MaxFiles),
 		}
@@ -134,17 +149,17 @@ func (c *Client) SendWithFiles(ctx context.Context, msg *types.WebhookMessage, f
 	var totalSize int64
 	for i := range files {
 		if err := (&files[i]).Validate(); err != nil {
-			return fmt.Errorf("file %d validation failed: %w", i, err)
+			return nil, fmt.Errorf("file %d validation failed: %w", i, err)
 		}
 
 		size, known, err := files[i].resolvedSize()
 		if err != nil {
-			return fmt.Errorf("file %d size detection failed: %w", i, err)
+			return nil, fmt.Errorf("file %d size detection failed: %w", i, err)
 		}
 
 		if known {
 			if size > MaxFileSize {
-				return &types.ValidationError{
+				return nil, &types.ValidationError{
 					Field:   "files",
 					Message: fmt.Sprintf("file %s exceeds maximum %d bytes", files[i].Name, MaxFileSize),
 				}
@@ -154,7 +169,7 @@ func (c *Client) SendWithFiles(ctx context.Context, msg *types.WebhookMessage, f
 	}
 
 	if MaxTotalSize > 0 && totalSize > MaxTotalSize {
-		return &types.ValidationError{
+		return nil, &types.ValidationError{
 			Field:   "files",
 			Message: fmt.Sprintf("total file size %d exceeds maximum %d bytes", totalSize, MaxTotalSize),
 		}
@@ -166,24 +181,27 @@ func (c *Client) SendWithFiles(ctx context.Context, msg *types.WebhookMessage, f
 
 	// Add JSON payload
 	if err := c.writeJSONPayload(writer, msg); err != nil {
-		return fmt.Errorf("failed to write JSON payload: %w", err)
+		return nil, fmt.Errorf("failed to write JSON payload: %w", err)
 	}
 
 	// Add files
 	counter := &uploadCounter{limit: MaxTotalSize}
 	for i, file := range files {
 		if err := c.writeFile(writer, i, file, counter); err != nil {
-			return fmt.Errorf("failed to write file %d: %w", i, err)
+			return nil, fmt.Errorf("failed to write file %d: %w", i, err)
 		}
 	}
 
 	// Close multipart writer
 	if err := writer.Close(); err != nil {
-		return fmt.Errorf("failed to close multipart writer: %w", err)
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
 	// Build URL with thread_id query parameter if specified
 	url := c.buildURLWithThreadID(c.webhookURL, msg.ThreadID)
+	if wait {
+		url = withWaitQuery(url)
+	}
 
 	// Send with retry
 	return c.sendMultipartWithRetry(ctx, body.Bytes(), writer.FormDataContentType(), url)
@@ -238,7 +256,7 @@ func (c *Client) writeFile(writer *multipart.Writer, index int, file FileAttachm
 }
 
 // sendMultipartWithRetry sends a multipart request with retry logic
-func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, contentType, url string) error {
+func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, contentType, url string) ([]byte, error) {
 	var lastErr error
 	backoff := c.timeout / 30 // Start with ~1 second
 	route := c.buildRoute("POST", url)
@@ -247,7 +265,7 @@ func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, conten
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return nil, ctx.Err()
 			case <-waitWithBackoff(backoff):
 				backoff *= 2
 			}
@@ -255,12 +273,12 @@ func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, conten
 
 		// Rate limiting
 		if err := c.waitForRateLimit(ctx, route); err != nil {
-			return err
+			return nil, err
 		}
 
 		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 		if err != nil {
-			return fmt.Errorf("failed to create request: %w", err)
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
 		req.Header.Set("Content-Type", contentType)
@@ -278,9 +296,10 @@ func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, conten
 		}
 
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			respBody, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
 			c.recordStrategyOutcome(route, false)
-			return nil
+			return respBody, nil
 		}
 
 		// Handle error response (reuse existing logic)
@@ -306,7 +325,7 @@ func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, conten
 
 		// Don't retry client errors (except rate limits)
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return apiErr
+			return nil, apiErr
 		}
 
 		// Retry server errors
@@ -314,10 +333,10 @@ func (c *Client) sendMultipartWithRetry(ctx context.Context, body []byte, conten
 	}
 
 	if lastErr != nil {
-		return fmt.Errorf("multipart request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+		return nil, fmt.Errorf("multipart request failed after %d attempts: %w", c.maxRetries+1, lastErr)
 	}
 
-	return fmt.Errorf("multipart request failed after %d attempts", c.maxRetries+1)
+	return nil, fmt.Errorf("multipart request failed after %d attempts", c.maxRetries+1)
 }
 
 type uploadCounter struct {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
@@ -22,6 +23,7 @@ type Client struct {
 	timeout     time.Duration
 	rateLimiter ratelimit.Tracker
 	strategy    ratelimit.Strategy
+	observer    ratelimit.Observer
 	logger      *logger.Logger
 }
 
@@ -63,6 +65,13 @@ func WithStrategy(strategy ratelimit.Strategy) Option {
 	}
 }
 
+// WithRateLimitObserver reports bucket updates and 429s to the observer
+func WithRateLimitObserver(obs ratelimit.Observer) Option {
+	return func(c *Client) {
+		c.observer = obs
+	}
+}
+
 // WithStrategyName sets the rate limiting strategy by name
 // Supported: "reactive", "proactive", "adaptive"
 func WithStrategyName(name string) Option {
@@ -101,6 +110,12 @@ func NewClient(webhookURL string, opts ...Option) (*Client, error) {
 		opt(c)
 	}
 
+	if obs, ok := c.observer.(ratelimit.StrategyObserver); ok {
+		if stats, ok := c.strategy.(ratelimit.StatsReporter); ok {
+			obs.ObserveStrategy(stats)
+		}
+	}
+
 	c.httpClient.Timeout = c.timeout
 
 	return c, nil
@@ -108,17 +123,35 @@ func NewClient(webhookURL string, opts ...Option) (*Client, error) {
 
 // Send sends a message via the webhook
 func (c *Client) Send(ctx context.Context, msg *types.WebhookMessage) error {
+	_, err := c.send(ctx, msg, false)
+	return err
+}
+
+// SendAndWait sends a message with ?wait=true so Discord responds with the
+// created message, whose ID can be used for follow-up edits or threads.
+func (c *Client) SendAndWait(ctx context.Context, msg *types.WebhookMessage) (*types.Message, error) {
+	respBody, err := c.send(ctx, msg, true)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCreatedMessage(respBody)
+}
+
+func (c *Client) send(ctx context.Context, msg *types.WebhookMessage, wait bool) ([]byte, error) {
 	if err := msg.Validate(); err != nil {
-		return fmt.Errorf("invalid webhook message: %w", err)
+		return nil, fmt.Errorf("invalid webhook message: %w", err)
 	}
 
 	body, err := json.Marshal(msg)
 	if err != nil {
-		return fmt.Errorf("failed to marshal webhook message: %w", err)
+		return nil, fmt.Errorf("failed to marshal webhook message: %w", err)
 	}
 
 	// Build URL with thread_id query parameter if specified
 	url := c.buildURLWithThreadID(c.webhookURL, msg.ThreadID)
+	if wait {
+		url = withWaitQuery(url)
+	}
 
 	return c.sendWithRetryToURL(ctx, body, url)
 }
@@ -161,7 +194,7 @@ func (c *Client) SendSimple(ctx context.Context, content string) error {
 	})
 }
 
-func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string) error {
+func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string) ([]byte, error) {
 	var lastErr error
 	backoff := time.Second
 	route := c.buildRoute("POST", url)
@@ -170,7 +203,7 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return nil, ctx.Err()
 			case <-time.After(backoff):
 				backoff *= 2
 			}
@@ -178,12 +211,12 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 
 		// Rate limiting: centralize proactive + reactive waits
 		if err := c.waitForRateLimit(ctx, route); err != nil {
-			return fmt.Errorf("rate limit wait failed: %w", err)
+			return nil, fmt.Errorf("rate limit wait failed: %w", err)
 		}
 
 		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 		if err != nil {
-			return fmt.Errorf("failed to create request: %w", err)
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
 		req.Header.Set("Content-Type", "application/json")
@@ -198,16 +231,18 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 		// Update rate limiter with response headers
 		if c.rateLimiter != nil {
 			c.rateLimiter.Update(route, resp.Header)
+			c.observeBucket(route)
 		}
 
 		// Success
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			respBody, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
 
 			// Record successful request for adaptive strategy
 			c.recordStrategyOutcome(route, false)
 
-			return nil
+			return respBody, nil
 		}
 
 		// Read error response
@@ -245,6 +280,9 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 
 			// Record rate limit hit for adaptive strategy
 			c.recordStrategyOutcome(route, true)
+			if c.observer != nil {
+				c.observer.RateLimited(route, resp.Header.Get("X-RateLimit-Global") == "true", time.Duration(apiErr.RetryAfter)*time.Second)
+			}
 
 			if apiErr.RetryAfter > 0 {
 				backoff = time.Duration(apiErr.RetryAfter) * time.Second
@@ -255,7 +293,7 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 
 		// Don't retry client errors (except rate limits)
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			return apiErr
+			return nil, apiErr
 		}
 
 		// Retry server errors
@@ -263,10 +301,10 @@ func (c *Client) sendWithRetryToURL(ctx context.Context, body []byte, url string
 	}
 
 	if lastErr != nil {
-		return fmt.Errorf("webhook request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+		return nil, fmt.Errorf("webhook request failed after %d attempts: %w", c.maxRetries+1, lastErr)
 	}
 
-	return fmt.Errorf("webhook request failed after %d attempts", c.maxRetries+1)
+	return nil, fmt.Errorf("webhook request failed after %d attempts", c.maxRetries+1)
 }
 
 // parseErrorResponse parses an HTTP error response into an APIError
@@ -372,7 +410,7 @@ func (c *Client) waitForRateLimit(ctx context.Context, route string) error {
 		)
 	}
 
-	if err := c.rateLimiter.Wait(ctx, route); err != nil {
+	if err := ratelimit.Acquire(ctx, c.rateLimiter, route); err != nil {
 		return err
 	}
 
@@ -399,6 +437,16 @@ func (c *Client) recordStrategyOutcome(route string, hitLimit bool) {
 	}
 }
 
+// observeBucket reports the tracker's bucket for route to the observer, if any
+func (c *Client) observeBucket(route string) {
+	if c.observer == nil {
+		return
+	}
+	if bucket := c.rateLimiter.GetBucket(route); bucket != nil {
+		c.observer.BucketUpdated(route, *bucket)
+	}
+}
+
 // buildURLWithThreadID builds a URL with the thread_id query parameter if specified
 func (c *Client) buildURLWithThreadID(baseURL, threadID string) string {
 	if threadID == "" {
@@ -406,3 +454,23 @@ func (c *Client) buildURLWithThreadID(baseURL, threadID string) string {
 	}
 	return baseURL + "?thread_id=" + threadID
 }
+
+// withWaitQuery adds wait=true so Discord returns the created message.
+func withWaitQuery(url string) string {
+	if strings.Contains(url, "?") {
+		return url + "&wait=true"
+	}
+	return url + "?wait=true"
+}
+
+// decodeCreatedMessage parses the message returned by a ?wait=true execution.
+func decodeCreatedMessage(body []byte) (*types.Message, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, fmt.Errorf("webhook response did not include the created message")
+	}
+	var msg types.Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook message: %w", err)
+	}
+	return &msg, nil
+}
@@ -70,6 +70,33 @@ func TestClient_SendSimple(t *testing.T) {
 	}
 }
 
+func TestClient_SendAndWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("wait"); got != "true" {
+			t.Errorf("Expected wait=true, got %q", got)
+		}
+		if got := r.URL.Query().Get("thread_id"); got != "555" {
+			t.Errorf("Expected thread_id=555, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"999","channel_id":"555","content":"deploying"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	msg, err := client.SendAndWait(context.Background(), &types.WebhookMessage{Content: "deploying", ThreadID: "555"})
+	if err != nil {
+		t.Fatalf("SendAndWait() error = %v", err)
+	}
+	if msg.ID != "999" || msg.ChannelID != "555" {
+		t.Errorf("SendAndWait() = %+v, want id 999 in channel 555", msg)
+	}
+}
+
 func TestClient_SendWithRetry(t *testing.T) {
 	attempts := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,174 @@
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Observer receives rate limit events from a client's request loop. Clients
+// call BucketUpdated after every response that carried rate limit headers and
+// RateLimited for every 429. Implementations must be safe for concurrent use
+// and must not block.
+type Observer interface {
+	// BucketUpdated reports the bucket state the tracker now holds for route
+	BucketUpdated(route string, bucket Bucket)
+
+	// RateLimited reports a 429 for route; global is true when Discord flagged
+	// the global limit rather than a per-route bucket
+	RateLimited(route string, global bool, retryAfter time.Duration)
+}
+
+// StrategyObserver is implemented by observers that also report strategy
+// metrics. Clients attach their strategy at construction when it implements
+// StatsReporter; the observer pulls Stats when it needs them.
+type StrategyObserver interface {
+	ObserveStrategy(strategy StatsReporter)
+}
+
+// BucketState is a bucket as last seen by a Recorder
+type BucketState struct {
+	Bucket
+
+	// Route is the most recent route that resolved to this bucket
+	Route string
+
+	// UpdatedAt is when the bucket was last reported
+	UpdatedAt time.Time
+}
+
+// LimitEvent is a single 429 response seen by a Recorder
+type LimitEvent struct {
+	Route      string
+	Bucket     string
+	Global     bool
+	RetryAfter time.Duration
+	At         time.Time
+}
+
+// RecorderSnapshot is a point-in-time copy of a Recorder's state
+type RecorderSnapshot struct {
+	// Buckets holds the latest state per bucket, sorted by key
+	Buckets []BucketState
+
+	// Events holds the 429s seen within the recorder's window, oldest first
+	Events []LimitEvent
+
+	// Strategies holds the stats of the attached strategies, sorted by name
+	Strategies []StrategyStats
+}
+
+// DefaultRecorderWindow is how long a Recorder keeps 429 events
+const DefaultRecorderWindow = time.Hour
+
+// Recorder is an Observer that keeps the latest state of every bucket and the
+// 429s seen within a sliding window, for status commands and metrics.
+type Recorder struct {
+	mu      sync.Mutex
+	window  time.Duration
+	buckets map[string]BucketState
+	routes  map[string]string
+	events  []LimitEvent
+	now     func() time.Time
+
+	// strategies keeps the most recently attached strategy per name, so a
+	// process that builds a client per job doesn't accumulate them
+	strategies map[string]StatsReporter
+}
+
+// NewRecorder creates a Recorder that keeps 429 events for window
+// (DefaultRecorderWindow when window <= 0)
+func NewRecorder(window time.Duration) *Recorder {
+	if window <= 0 {
+		window = DefaultRecorderWindow
+	}
+	return &Recorder{
+		window:  window,
+		buckets: make(map[string]BucketState),
+		routes:  make(map[string]string),
+		now:     time.Now,
+
+		strategies: make(map[string]StatsReporter),
+	}
+}
+
+// BucketUpdated implements Observer
+func (r *Recorder) BucketUpdated(route string, bucket Bucket) {
+	key := bucket.Key
+	if key == "" {
+		key = route
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buckets[key] = BucketState{Bucket: bucket, Route: route, UpdatedAt: r.now()}
+	r.routes[route] = key
+}
+
+// RateLimited implements Observer
+func (r *Recorder) RateLimited(route string, global bool, retryAfter time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.pruneLocked(now)
+	r.events = append(r.events, LimitEvent{
+		Route:      route,
+		Bucket:     r.routes[route],
+		Global:     global,
+		RetryAfter: retryAfter,
+		At:         now,
+	})
+}
+
+// ObserveStrategy implements StrategyObserver
+func (r *Recorder) ObserveStrategy(strategy StatsReporter) {
+	if strategy == nil {
+		return
+	}
+	name := strategy.Stats().Name
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strategies[name] = strategy
+}
+
+// Snapshot returns a copy of the recorder's current state
+func (r *Recorder) Snapshot() RecorderSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pruneLocked(r.now())
+
+	snap := RecorderSnapshot{
+		Buckets: make([]BucketState, 0, len(r.buckets)),
+		Events:  append([]LimitEvent(nil), r.events...),
+	}
+	for _, state := range r.buckets {
+		snap.Buckets = append(snap.Buckets, state)
+	}
+	sort.Slice(snap.Buckets, func(i, j int) bool { return snap.Buckets[i].Key < snap.Buckets[j].Key })
+	for _, strategy := range r.strategies {
+		snap.Strategies = append(snap.Strategies, strategy.Stats())
+	}
+	sort.Slice(snap.Strategies, func(i, j int) bool { return snap.Strategies[i].Name < snap.Strategies[j].Name })
+	return snap
+}
+
+// Reset discards all recorded state
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buckets = make(map[string]BucketState)
+	r.routes = make(map[string]string)
+	r.events = nil
+	r.strategies = make(map[string]StatsReporter)
+}
+
+// pruneLocked drops events older than the window (must be called with lock held)
+func (r *Recorder) pruneLocked(now time.Time) {
+	cutoff := now.Add(-r.window)
+	i := 0
+	for i < len(r.events) && r.events[i].At.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		r.events = append(r.events[:0], r.events[i:]...)
+	}
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecorder_TracksBucketsAndLimitEvents(t *testing.T) {
+	rec := NewRecorder(time.Minute)
+	now := time.Unix(1_700_000_000, 0)
+	rec.now = func() time.Time { return now }
+
+	route := "POST:/channels/1/messages"
+	rec.BucketUpdated(route, Bucket{Key: "abc", Limit: 5, Remaining: 4, Reset: now.Add(time.Second)})
+	rec.BucketUpdated(route, Bucket{Key: "abc", Limit: 5, Remaining: 0, Reset: now.Add(time.Second)})
+	rec.BucketUpdated("GET:/users/@me", Bucket{Limit: 2, Remaining: 1})
+	rec.RateLimited(route, false, 2*time.Second)
+	rec.RateLimited(route, true, time.Second)
+
+	snap := rec.Snapshot()
+	if len(snap.Buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %d", len(snap.Buckets))
+	}
+	if snap.Buckets[1].Key != "abc" || snap.Buckets[1].Remaining != 0 || snap.Buckets[1].Route != route {
+		t.Errorf("Unexpected bucket state: %+v", snap.Buckets[1])
+	}
+	if snap.Buckets[0].Route != "GET:/users/@me" {
+		t.Errorf("Headerless bucket should be keyed by route, got %+v", snap.Buckets[0])
+	}
+	if len(snap.Events) != 2 || snap.Events[0].Bucket != "abc" || !snap.Events[1].Global {
+		t.Fatalf("Unexpected events: %+v", snap.Events)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if events := rec.Snapshot().Events; len(events) != 0 {
+		t.Errorf("Expected events outside the window to be pruned, got %d", len(events))
+	}
+
+	rec.Reset()
+	if snap := rec.Snapshot(); len(snap.Buckets) != 0 {
+		t.Errorf("Expected Reset to clear buckets, got %d", len(snap.Buckets))
+	}
+}
@@ -1,6 +1,7 @@
 package ratelimit
 
 import (
+	"sort"
 	"sync"
 	"time"
 )
@@ -177,8 +178,31 @@ type AdaptiveStrategy struct {
 
 	// AdjustmentFactor determines how quickly we adapt (0.0-1.0)
 	AdjustmentFactor float64
+
+	// routes holds per-bucket learning so a hot route (message creation
+	// during a broadcast) turns conservative without throttling the rest.
+	// New buckets start from CurrentThreshold.
+	routes map[string]*routeState
+}
+
+// routeState is the adaptive state of a single bucket
+type routeState struct {
+	threshold float64
+	history   []bool
+	requests  int
+	hits      int
+	lastSeen  time.Time
 }
 
+const (
+	// maxAdaptiveRoutes bounds the per-bucket state; idle buckets are evicted
+	// first once it is reached
+	maxAdaptiveRoutes = 512
+
+	// routeIdleTTL is how long an untouched bucket keeps its learned threshold
+	routeIdleTTL = 10 * time.Minute
+)
+
 type requestOutcome struct {
 	timestamp   time.Time
 	hitLimit    bool
@@ -212,6 +236,7 @@ func NewAdaptiveStrategy(minThreshold, maxThreshold float64, learningWindow int)
 		LearningWindow:   learningWindow,
 		requestHistory:   make([]requestOutcome, 0, learningWindow),
 		AdjustmentFactor: 0.1, // 10% adjustment per learning cycle
+		routes:           make(map[string]*routeState),
 	}
 }
 
@@ -232,7 +257,7 @@ func (s *AdaptiveStrategy) ShouldWait(bucket *Bucket) bool {
 	}
 
 	s.mu.RLock()
-	threshold := s.CurrentThreshold
+	threshold, _ := s.thresholdFor(bucket.Key)
 	s.mu.RUnlock()
 
 	// Check if we're below the adaptive threshold
@@ -247,7 +272,7 @@ func (s *AdaptiveStrategy) CalculateWait(bucket *Bucket) time.Duration {
 	}
 
 	s.mu.RLock()
-	threshold := s.CurrentThreshold
+	threshold, hitRate := s.thresholdFor(bucket.Key)
 	s.mu.RUnlock()
 
 	remainingPercent := float64(bucket.Remaining) / float64(bucket.Limit)
@@ -263,11 +288,6 @@ func (s *AdaptiveStrategy) CalculateWait(bucket *Bucket) time.Duration {
 
 	fullWait := time.Until(bucket.Reset)
 
-	// Use adaptive factor to adjust wait time based on recent history
-	s.mu.RLock()
-	hitRate := s.calculateHitRate()
-	s.mu.RUnlock()
-
 	// If we're hitting limits frequently, increase wait time
 	adaptiveFactor := 1.0 + (hitRate * 0.5) // Up to 50% longer waits if hitting limits
 
@@ -309,12 +329,102 @@ func (s *AdaptiveStrategy) RecordRequest(bucket *Bucket, hitLimit bool) {
 	if len(s.requestHistory) >= s.LearningWindow {
 		s.adaptThreshold()
 	}
+
+	if bucket != nil && bucket.Key != "" {
+		s.recordRoute(bucket.Key, hitLimit, outcome.timestamp)
+	}
+}
+
+// recordRoute updates the learning state of one bucket. A 429 raises the
+// bucket's threshold immediately rather than waiting for a full window, so
+// the next burst on the same route backs off before Discord rejects it.
+func (s *AdaptiveStrategy) recordRoute(key string, hitLimit bool, now time.Time) {
+	if s.routes == nil {
+		s.routes = make(map[string]*routeState)
+	}
+	state, ok := s.routes[key]
+	if !ok {
+		if len(s.routes) >= maxAdaptiveRoutes {
+			s.evictRoutes(now)
+		}
+		state = &routeState{threshold: s.CurrentThreshold}
+		s.routes[key] = state
+	}
+	state.lastSeen = now
+	state.requests++
+	if hitLimit {
+		state.hits++
+	}
+
+	window := s.routeWindow()
+	state.history = append(state.history, hitLimit)
+	if len(state.history) > window {
+		state.history = state.history[1:]
+	}
+	if hitLimit || len(state.history) >= window {
+		state.threshold = s.adjustThreshold(state.threshold, routeHitRate(state.history))
+	}
+}
+
+// routeWindow is the per-bucket learning window; buckets see a fraction of
+// the client's traffic, so they learn from a shorter history
+func (s *AdaptiveStrategy) routeWindow() int {
+	if w := s.LearningWindow / 5; w > 10 {
+		return w
+	}
+	return 10
+}
+
+// evictRoutes drops idle buckets, or the least recently used one when none
+// are idle (must be called with lock held)
+func (s *AdaptiveStrategy) evictRoutes(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, state := range s.routes {
+		if now.Sub(state.lastSeen) > routeIdleTTL {
+			delete(s.routes, key)
+			continue
+		}
+		if oldestKey == "" || state.lastSeen.Before(oldest) {
+			oldestKey, oldest = key, state.lastSeen
+		}
+	}
+	if len(s.routes) >= maxAdaptiveRoutes && oldestKey != "" {
+		delete(s.routes, oldestKey)
+	}
+}
+
+// thresholdFor returns the threshold and hit rate for a bucket, falling back
+// to the client-wide values for buckets without history (must be called
+// with at least a read lock held)
+func (s *AdaptiveStrategy) thresholdFor(key string) (float64, float64) {
+	if state, ok := s.routes[key]; ok && key != "" {
+		return state.threshold, routeHitRate(state.history)
+	}
+	return s.CurrentThreshold, s.calculateHitRate()
+}
+
+func routeHitRate(history []bool) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	hits := 0
+	for _, hit := range history {
+		if hit {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(history))
 }
 
 // adaptThreshold adjusts the threshold based on recent request history
 func (s *AdaptiveStrategy) adaptThreshold() {
-	hitRate := s.calculateHitRate()
+	s.CurrentThreshold = s.adjustThreshold(s.CurrentThreshold, s.calculateHitRate())
+}
 
+// adjustThreshold returns threshold moved toward MaxThreshold when hitRate is
+// above target and toward MinThreshold when below
+func (s *AdaptiveStrategy) adjustThreshold(threshold, hitRate float64) float64 {
 	// If we're hitting rate limits too often, increase threshold (be more conservative)
 	// If we're not hitting limits, decrease threshold (be more aggressive)
 
@@ -323,20 +433,21 @@ func (s *AdaptiveStrategy) adaptThreshold() {
 	if hitRate > targetHitRate {
 		// Increase threshold (be more conservative)
 		adjustment := s.AdjustmentFactor * (hitRate / targetHitRate)
-		s.CurrentThreshold += adjustment
+		threshold += adjustment
 
-		if s.CurrentThreshold > s.MaxThreshold {
-			s.CurrentThreshold = s.MaxThreshold
+		if threshold > s.MaxThreshold {
+			threshold = s.MaxThreshold
 		}
-	} else if hitRate < targetHitRate && s.CurrentThreshold > s.MinThreshold {
+	} else if hitRate < targetHitRate && threshold > s.MinThreshold {
 		// Decrease threshold (be more aggressive)
 		adjustment := s.AdjustmentFactor * (1.0 - hitRate/targetHitRate)
-		s.CurrentThreshold -= adjustment
+		threshold -= adjustment
 
-		if s.CurrentThreshold < s.MinThreshold {
-			s.CurrentThreshold = s.MinThreshold
+		if threshold < s.MinThreshold {
+			threshold = s.MinThreshold
 		}
 	}
+	return threshold
 }
 
 // calculateHitRate returns the rate limit hit rate from recent history
@@ -382,6 +493,68 @@ type AdaptiveStats struct {
 	HitRate            float64
 }
 
+// RouteStats is the adaptive state of a single bucket
+type RouteStats struct {
+	Key           string
+	Threshold     float64
+	Requests      int
+	RateLimitHits int
+	HitRate       float64
+}
+
+// StrategyStats summarises a strategy for metrics and status output.
+// Threshold is the fraction of a bucket's limit at which the strategy starts
+// waiting (0 for reactive); Routes is only populated by AdaptiveStrategy.
+type StrategyStats struct {
+	Name          string
+	Threshold     float64
+	Requests      int
+	RateLimitHits int
+	HitRate       float64
+	Routes        []RouteStats
+}
+
+// StatsReporter is implemented by strategies that expose metrics
+type StatsReporter interface {
+	Stats() StrategyStats
+}
+
+// Stats implements StatsReporter
+func (s *ReactiveStrategy) Stats() StrategyStats {
+	return StrategyStats{Name: s.Name()}
+}
+
+// Stats implements StatsReporter
+func (s *ProactiveStrategy) Stats() StrategyStats {
+	return StrategyStats{Name: s.Name(), Threshold: s.Threshold}
+}
+
+// Stats implements StatsReporter, including per-bucket thresholds sorted by key
+func (s *AdaptiveStrategy) Stats() StrategyStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := StrategyStats{
+		Name:          s.Name(),
+		Threshold:     s.CurrentThreshold,
+		Requests:      s.rateLimitHits + s.successfulRequests,
+		RateLimitHits: s.rateLimitHits,
+		HitRate:       s.calculateHitRate(),
+		Routes:        make([]RouteStats, 0, len(s.routes)),
+	}
+	for key, state := range s.routes {
+		stats.Routes = append(stats.Routes, RouteStats{
+			Key:           key,
+			Threshold:     state.threshold,
+			Requests:      state.requests,
+			RateLimitHits: state.hits,
+			HitRate:       routeHitRate(state.history),
+		})
+	}
+	sort.Slice(stats.Routes, func(i, j int) bool { return stats.Routes[i].Key < stats.Routes[j].Key })
+	return stats
+}
+
 // Name returns the strategy name
 func (s *AdaptiveStrategy) Name() string {
 	return "adaptive"
@@ -579,3 +579,43 @@ func BenchmarkAdaptiveStrategyWithLearning(b *testing.B) {
 		strategy.CalculateWait(bucket)
 	}
 }
+
+func TestAdaptiveStrategyPerRouteThresholds(t *testing.T) {
+	strategy := NewDefaultAdaptiveStrategy()
+	initial := strategy.CurrentThreshold
+
+	hot := &Bucket{Key: "msg-create:channels/1", Limit: 5, Remaining: 0, Reset: time.Now().Add(time.Second)}
+	quiet := &Bucket{Key: "guild-get:guilds/9", Limit: 5, Remaining: 1, Reset: time.Now().Add(time.Second)}
+
+	strategy.RecordRequest(hot, true)
+	strategy.RecordRequest(quiet, false)
+
+	stats := strategy.Stats()
+	if stats.Name != "adaptive" || stats.Requests != 2 || stats.RateLimitHits != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if len(stats.Routes) != 2 {
+		t.Fatalf("expected 2 routes, got %+v", stats.Routes)
+	}
+	// Routes are sorted by key: guild-get before msg-create.
+	if stats.Routes[1].Threshold <= initial {
+		t.Errorf("expected a 429 to raise the hot route's threshold above %f, got %f", initial, stats.Routes[1].Threshold)
+	}
+	if stats.Routes[0].Threshold != initial {
+		t.Errorf("expected the quiet route to keep %f, got %f", initial, stats.Routes[0].Threshold)
+	}
+	if strategy.CurrentThreshold != initial {
+		t.Errorf("a single 429 should not move the client-wide threshold, got %f", strategy.CurrentThreshold)
+	}
+
+	// 30% remaining is above the default threshold but within the hot route's.
+	probe := func(key string) *Bucket {
+		return &Bucket{Key: key, Limit: 10, Remaining: 3, Reset: time.Now().Add(time.Second)}
+	}
+	if !strategy.ShouldWait(probe(hot.Key)) {
+		t.Error("expected the hot route to wait at 30% remaining")
+	}
+	if strategy.ShouldWait(probe(quiet.Key)) {
+		t.Error("expected the quiet route not to wait at 30% remaining")
+	}
+}
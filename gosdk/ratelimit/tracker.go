@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -42,6 +43,27 @@ type Tracker interface {
 	Clear()
 }
 
+// Reserver is implemented by trackers that can account for a request before
+// its response arrives. Clients call Reserve after Wait and Wait again when
+// it returns false.
+type Reserver interface {
+	Reserve(route string) bool
+}
+
+// Acquire waits on tracker for route and, when the tracker is a Reserver,
+// claims a request from the bucket, waiting again while it is exhausted
+func Acquire(ctx context.Context, tracker Tracker, route string) error {
+	for {
+		if err := tracker.Wait(ctx, route); err != nil {
+			return err
+		}
+		r, ok := tracker.(Reserver)
+		if !ok || r.Reserve(route) {
+			return nil
+		}
+	}
+}
+
 // MemoryTracker implements an in-memory rate limit tracker
 type MemoryTracker struct {
 	buckets       map[string]*Bucket
@@ -135,10 +157,15 @@ func (t *MemoryTracker) Update(route string, headers http.Header) {
 	if global {
 		t.global = bucket
 	} else {
+		// Discord shares a bucket hash across major parameters, so the same
+		// hash for two channels is two independent buckets.
 		key := bucketKey
 		if key == "" {
 			key = route
+		} else if major := MajorParameter(route); major != "" {
+			key = bucketKey + ":" + major
 		}
+		bucket.Key = key
 
 		t.buckets[key] = bucket
 		t.routeToBucket[route] = key
@@ -168,6 +195,26 @@ func (t *MemoryTracker) GetBucket(route string) *Bucket {
 	}
 }
 
+// Reserve claims one request from the route's bucket ahead of the response
+// that would report it, so concurrent callers on a hot route stop before
+// the bucket is exhausted instead of discovering it through 429s. It
+// returns false when the bucket has nothing left before its reset; the
+// caller should Wait again. Routes without a known bucket always succeed.
+func (t *MemoryTracker) Reserve(route string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket, exists := t.getBucketByRouteLocked(route)
+	if !exists || bucket.Limit == 0 || !time.Now().Before(bucket.Reset) {
+		return true
+	}
+	if bucket.Remaining <= 0 {
+		return false
+	}
+	bucket.Remaining--
+	return true
+}
+
 // Clear removes all stored rate limit information
 func (t *MemoryTracker) Clear() {
 	t.mu.Lock()
@@ -246,11 +293,74 @@ func parseFloatHeader(headers http.Header, key string) float64 {
 	return floatValue
 }
 
-// RouteFromEndpoint extracts a rate limit route identifier from an endpoint
-// Discord uses major parameters (guild_id, channel_id, etc.) for route bucketing
+// RouteFromEndpoint extracts a rate limit route identifier from an endpoint.
+// Discord buckets routes by their major parameters (channel, guild, webhook
+// and interaction IDs), so those are kept while other snowflakes collapse to
+// ":id": /channels/1/messages/2 and /channels/1/messages/3 share a route,
+// /channels/1/messages and /channels/2/messages do not.
 func RouteFromEndpoint(method, endpoint string) string {
-	// This is a simplified implementation
-	// In production, you'd parse the endpoint and replace IDs with placeholders
-	// For example: /channels/123456/messages/789 -> /channels/:id/messages/:id
-	return fmt.Sprintf("%s:%s", method, endpoint)
+	base, path := splitEndpoint(endpoint)
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if !isSnowflake(segments[i]) || majorResources[segments[i-1]] {
+			continue
+		}
+		segments[i] = ":id"
+	}
+	return fmt.Sprintf("%s:%s%s", method, base, strings.Join(segments, "/"))
+}
+
+// MajorParameter returns the major parameter of a route built by
+// RouteFromEndpoint (e.g. "channels/123", or "webhooks/123/token"), or ""
+// when it has none.
+func MajorParameter(route string) string {
+	_, path := splitEndpoint(route)
+	segments := strings.Split(path, "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if !majorResources[segments[i]] || !isSnowflake(segments[i+1]) {
+			continue
+		}
+		major := segments[i] + "/" + segments[i+1]
+		// Webhook and interaction tokens are part of the major parameter.
+		if (segments[i] == "webhooks" || segments[i] == "interactions") && i+2 < len(segments) && segments[i+2] != "" && !isSnowflake(segments[i+2]) {
+			major += "/" + segments[i+2]
+		}
+		return major
+	}
+	return ""
+}
+
+// majorResources are the path segments whose following ID is a major
+// parameter
+var majorResources = map[string]bool{
+	"channels":     true,
+	"guilds":       true,
+	"webhooks":     true,
+	"interactions": true,
+}
+
+// splitEndpoint separates "scheme://host" (possibly empty) from the path
+func splitEndpoint(endpoint string) (string, string) {
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		if j := strings.Index(endpoint[i+3:], "/"); j >= 0 {
+			return endpoint[:i+3+j], endpoint[i+3+j:]
+		}
+		return endpoint, ""
+	}
+	return "", endpoint
+}
+
+func isSnowflake(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
@@ -393,3 +393,80 @@ func TestMemoryTracker_Concurrent(t *testing.T) {
 		<-done
 	}
 }
+
+func TestRouteFromEndpoint_MajorParameters(t *testing.T) {
+	tests := []struct {
+		endpoint  string
+		wantRoute string
+		wantMajor string
+	}{
+		{"/channels/123/messages/456", "GET:/channels/123/messages/:id", "channels/123"},
+		{"https://discord.com/api/v10/guilds/9/members/42", "GET:https://discord.com/api/v10/guilds/9/members/:id", "guilds/9"},
+		{"https://discord.com/api/webhooks/7/tok/messages/8?wait=true", "GET:https://discord.com/api/webhooks/7/tok/messages/:id", "webhooks/7/tok"},
+		{"/users/@me/guilds", "GET:/users/@me/guilds", ""},
+	}
+	for _, tt := range tests {
+		route := RouteFromEndpoint("GET", tt.endpoint)
+		if route != tt.wantRoute {
+			t.Errorf("RouteFromEndpoint(%q) = %q, want %q", tt.endpoint, route, tt.wantRoute)
+		}
+		if major := MajorParameter(route); major != tt.wantMajor {
+			t.Errorf("MajorParameter(%q) = %q, want %q", route, major, tt.wantMajor)
+		}
+	}
+}
+
+func TestMemoryTracker_BucketsSplitByMajorParameter(t *testing.T) {
+	tracker := NewMemoryTracker()
+
+	headers := make(http.Header)
+	headers.Set("X-RateLimit-Limit", "5")
+	headers.Set("X-RateLimit-Reset-After", "60")
+	headers.Set("X-RateLimit-Bucket", "msg-create")
+
+	headers.Set("X-RateLimit-Remaining", "0")
+	tracker.Update("POST:/channels/1/messages", headers)
+	headers.Set("X-RateLimit-Remaining", "4")
+	tracker.Update("POST:/channels/2/messages", headers)
+
+	first := tracker.GetBucket("POST:/channels/1/messages")
+	second := tracker.GetBucket("POST:/channels/2/messages")
+	if first == nil || second == nil {
+		t.Fatal("Expected a bucket per channel")
+	}
+	if first.Remaining != 0 || second.Remaining != 4 || first.Key == second.Key {
+		t.Errorf("Channels sharing a bucket hash should not share state: %+v %+v", first, second)
+	}
+}
+
+func TestMemoryTracker_ReserveClaimsAheadOfResponses(t *testing.T) {
+	tracker := NewMemoryTracker()
+
+	headers := make(http.Header)
+	headers.Set("X-RateLimit-Limit", "5")
+	headers.Set("X-RateLimit-Remaining", "2")
+	headers.Set("X-RateLimit-Reset-After", "0.2")
+	headers.Set("X-RateLimit-Bucket", "msg-create")
+	route := "POST:/channels/1/messages"
+	tracker.Update(route, headers)
+
+	if !tracker.Reserve(route) || !tracker.Reserve(route) {
+		t.Fatal("Expected the two remaining requests to be reservable")
+	}
+	if tracker.Reserve(route) {
+		t.Fatal("Expected the exhausted bucket to refuse a reservation")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := Acquire(ctx, tracker, route); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Expected Acquire to wait for the reset, took %v", elapsed)
+	}
+	if !tracker.Reserve("POST:/channels/9/messages") {
+		t.Error("Routes without a known bucket should always be reservable")
+	}
+}
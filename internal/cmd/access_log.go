@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+const (
+	requestIDHeader    = "X-Request-ID"
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestIDFrom returns the ID the access log assigned to the request, or "".
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func newRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// validRequestID accepts an upstream proxy's X-Request-ID when it is short
+// and printable, so it can't inject into log lines.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// accessLogMiddleware logs one line per /interactions request and tags it
+// with a request ID, echoed in X-Request-ID and carried into the envelope
+// so agent logs can be matched to server logs.
+type accessLogMiddleware struct {
+	next   http.Handler
+	logger *logger.Logger
+	now    func() time.Time
+}
+
+func newAccessLogMiddleware(next http.Handler, log *logger.Logger) *accessLogMiddleware {
+	if log == nil {
+		log = logger.Default()
+	}
+	return &accessLogMiddleware{next: next, logger: log, now: time.Now}
+}
+
+func (m *accessLogMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := m.now()
+	id := r.Header.Get(requestIDHeader)
+	if !validRequestID(id) {
+		id = newRequestID()
+	}
+	w.Header().Set(requestIDHeader, id)
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	// Replay the body, including a read error such as an exceeded size
+	// limit, so the handler responds as it would have.
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	m.next.ServeHTTP(rec, r.WithContext(withRequestID(r.Context(), id)))
+
+	fields := []interface{}{
+		"request_id", id,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", rec.status,
+		"duration_ms", m.now().Sub(start).Milliseconds(),
+	}
+	var interaction struct {
+		Type types.InteractionType `json:"type"`
+		Data struct {
+			Name     string `json:"name"`
+			CustomID string `json:"custom_id"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(body, &interaction) == nil && interaction.Type != 0 {
+		name := interaction.Data.Name
+		if name == "" {
+			name = interaction.Data.CustomID
+		}
+		fields = append(fields, "interaction_type", interactionTypeLabel(interaction.Type), "interaction", name)
+	}
+	m.logger.Info("interaction request", fields...)
+}
+
+// errReader returns err once the replayed body is exhausted, or io.EOF.
+type errReader struct{ err error }
+
+func (e errReader) Read([]byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	return 0, io.EOF
+}
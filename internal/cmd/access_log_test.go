@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+func TestAccessLogPropagatesRequestID(t *testing.T) {
+	cfg := interactionsConfig{
+		Enabled: true,
+		Timeout: time.Second,
+		Handlers: handlerMappings{
+			Commands: map[string]handlerRoute{"deploy": {Agent: "codex"}},
+		},
+	}
+	srv, priv, publisher := newServerWithConfig(t, cfg)
+	var logs bytes.Buffer
+	handler := newAccessLogMiddleware(http.HandlerFunc(srv.HandleInteraction), logger.New(logger.InfoLevel, "json", &logs))
+
+	body, _ := json.Marshal(map[string]any{
+		"type":  types.InteractionTypeApplicationCommand,
+		"token": "command-token",
+		"id":    "123",
+		"data":  map[string]any{"name": "deploy"},
+	})
+	req := signedRequest(t, priv, body)
+	req.Header.Set(requestIDHeader, "edge-42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get(requestIDHeader) != "edge-42" {
+		t.Fatalf("expected request ID header, got %q", rec.Header().Get(requestIDHeader))
+	}
+	if len(publisher.envelopes) != 1 || publisher.envelopes[0].RequestID != "edge-42" {
+		t.Fatalf("expected envelope with request ID, got %+v", publisher.envelopes)
+	}
+	for _, want := range []string{`"request_id":"edge-42"`, `"status":200`, `"interaction_type":"command"`, `"interaction":"deploy"`, `"method":"POST"`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Fatalf("log %s missing %s", logs.String(), want)
+		}
+	}
+}
+
+func TestAccessLogGeneratesRequestID(t *testing.T) {
+	var seen string
+	handler := newAccessLogMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestIDFrom(r.Context())
+		w.WriteHeader(http.StatusUnauthorized)
+	}), logger.New(logger.InfoLevel, "json", &bytes.Buffer{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/interactions", strings.NewReader("{}"))
+	req.Header.Set(requestIDHeader, "bad id\nforged")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if seen == "" || seen == "bad id\nforged" || rec.Header().Get(requestIDHeader) != seen {
+		t.Fatalf("expected generated request ID, got %q (header %q)", seen, rec.Header().Get(requestIDHeader))
+	}
+}
+
+func TestRequestRef(t *testing.T) {
+	if got := requestRef(&broker.Envelope{RequestID: "abc"}); got != " (request abc)" {
+		t.Fatalf("unexpected ref %q", got)
+	}
+	if got := requestRef(&broker.Envelope{}); got != "" {
+		t.Fatalf("expected empty ref, got %q", got)
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+const (
+	defaultAgentReplyTimeout = 30 * time.Second
+	maxAgentReplyBytes       = 1 << 20
+)
+
+// agentReply is the JSON an external handler returns. Components are passed
+// through to Discord unchanged.
+type agentReply struct {
+	Content    string          `json:"content"`
+	Embeds     []types.Embed   `json:"embeds"`
+	Components json.RawMessage `json:"components"`
+}
+
+// parseAgentReply decodes handler output. A JSON object is read as an
+// agentReply; any other non-empty output becomes the message content.
+func parseAgentReply(out []byte) (*types.MessageEditParams, error) {
+	trimmed := bytes.TrimSpace(out)
+	if len(trimmed) == 0 {
+		return nil, errors.New("handler produced no output")
+	}
+	if trimmed[0] != '{' {
+		return &types.MessageEditParams{Content: string(trimmed)}, nil
+	}
+	var reply agentReply
+	if err := json.Unmarshal(trimmed, &reply); err != nil {
+		return nil, fmt.Errorf("decode handler reply: %w", err)
+	}
+	params := &types.MessageEditParams{Content: reply.Content, Embeds: reply.Embeds}
+	if len(reply.Components) > 0 && string(reply.Components) != "null" {
+		patch, err := json.Marshal(map[string]json.RawMessage{"components": reply.Components})
+		if err != nil {
+			return nil, err
+		}
+		params.MergePatch = patch
+	}
+	if params.Content == "" && len(params.Embeds) == 0 && params.MergePatch == nil {
+		return nil, errors.New("handler reply has no content, embeds, or components")
+	}
+	return params, nil
+}
+
+// replyTimeout bounds a single handler call: the explicit setting wins, then
+// the envelope's TimeoutSeconds, then defaultAgentReplyTimeout.
+func replyTimeout(configured time.Duration, env *broker.Envelope) time.Duration {
+	if configured > 0 {
+		return configured
+	}
+	if env != nil && env.TimeoutSeconds > 0 {
+		return time.Duration(env.TimeoutSeconds) * time.Second
+	}
+	return defaultAgentReplyTimeout
+}
+
+// execResponder runs a command per envelope with the envelope JSON on stdin
+// and reads the reply from stdout.
+type execResponder struct {
+	command string
+	timeout time.Duration
+	stderr  io.Writer
+}
+
+func (e *execResponder) Respond(ctx context.Context, env *broker.Envelope, payload []byte) (*types.MessageEditParams, error) {
+	runCtx, cancel := context.WithTimeout(ctx, replyTimeout(e.timeout, env))
+	defer cancel()
+
+	proc := shellCommand(runCtx, e.command)
+	proc.Stdin = bytes.NewReader(payload)
+	proc.Env = append(os.Environ(),
+		envDefaultAgentID+"="+env.Agent,
+		"VIBE_INTERACTION_KIND="+env.Kind,
+		"VIBE_INTERACTION_KEY="+env.Key,
+	)
+	var stdout bytes.Buffer
+	proc.Stdout = &limitedWriter{w: &stdout, n: maxAgentReplyBytes}
+	var progress *progressWriter
+	if p := agentProgressFrom(ctx); p != nil {
+		progress = &progressWriter{progress: p, out: e.stderr}
+		proc.Stderr = progress
+	} else if e.stderr != nil {
+		proc.Stderr = e.stderr
+	}
+	err := proc.Run()
+	if progress != nil {
+		progress.flush()
+	}
+	if err != nil {
+		if runCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s timed out", e.command)
+		}
+		return nil, fmt.Errorf("%s: %w", e.command, err)
+	}
+	return parseAgentReply(stdout.Bytes())
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+type limitedWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.n {
+		return 0, fmt.Errorf("handler output exceeds %d bytes", maxAgentReplyBytes)
+	}
+	l.n -= int64(len(p))
+	return l.w.Write(p)
+}
+
+func validateExecCommand(command string) error {
+	if strings.TrimSpace(command) == "" {
+		return errors.New("--exec requires a command")
+	}
+	return nil
+}
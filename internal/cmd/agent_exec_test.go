@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestParseAgentReply(t *testing.T) {
+	params, err := parseAgentReply([]byte(`{"content":"done","embeds":[{"title":"Result"}],"components":[{"type":1,"components":[]}]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if params.Content != "done" || len(params.Embeds) != 1 || params.Embeds[0].Title != "Result" {
+		t.Fatalf("unexpected params %+v", params)
+	}
+	if !strings.Contains(string(params.MergePatch), `"components":[{"type":1`) {
+		t.Fatalf("expected components merge patch, got %s", params.MergePatch)
+	}
+
+	params, err = parseAgentReply([]byte("  plain text reply\n"))
+	if err != nil || params.Content != "plain text reply" {
+		t.Fatalf("expected plain text content, got %+v (%v)", params, err)
+	}
+	if _, err := parseAgentReply([]byte("\n")); err == nil {
+		t.Fatal("expected error for empty output")
+	}
+	if _, err := parseAgentReply([]byte(`{"content":`)); err == nil {
+		t.Fatal("expected error for malformed JSON")
+	}
+	if _, err := parseAgentReply([]byte(`{}`)); err == nil {
+		t.Fatal("expected error for empty reply object")
+	}
+}
+
+func TestReplyTimeout(t *testing.T) {
+	if got := replyTimeout(5*time.Second, &broker.Envelope{TimeoutSeconds: 60}); got != 5*time.Second {
+		t.Fatalf("expected configured timeout, got %v", got)
+	}
+	if got := replyTimeout(0, &broker.Envelope{TimeoutSeconds: 12}); got != 12*time.Second {
+		t.Fatalf("expected envelope timeout, got %v", got)
+	}
+	if got := replyTimeout(0, &broker.Envelope{}); got != defaultAgentReplyTimeout {
+		t.Fatalf("expected default timeout, got %v", got)
+	}
+}
+
+func TestExecResponderRunsCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	env := &broker.Envelope{Agent: "py", Kind: handlerKindCommand, Key: "help"}
+	payload, _ := json.Marshal(env)
+	var stderr bytes.Buffer
+	responder := &execResponder{
+		command: `read -r line; echo "warning" >&2; printf '{"content":"%s %s"}' "$VIBE_INTERACTION_KIND" "$VIBE_INTERACTION_KEY"`,
+		timeout: 5 * time.Second,
+		stderr:  &stderr,
+	}
+	params, err := responder.Respond(context.Background(), env, payload)
+	if err != nil {
+		t.Fatalf("respond: %v", err)
+	}
+	if params.Content != "command help" {
+		t.Fatalf("unexpected content %q", params.Content)
+	}
+	if !strings.Contains(stderr.String(), "warning") {
+		t.Fatalf("expected stderr passthrough, got %q", stderr.String())
+	}
+
+	failing := &execResponder{command: "exit 3", timeout: 5 * time.Second}
+	if _, err := failing.Respond(context.Background(), env, payload); err == nil {
+		t.Fatal("expected error for non-zero exit")
+	}
+	slow := &execResponder{command: "sleep 5", timeout: 50 * time.Millisecond}
+	if _, err := slow.Respond(context.Background(), env, payload); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+type bufferPrinter struct{ bytes.Buffer }
+
+func (b *bufferPrinter) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(&b.Buffer, format, args...)
+}
+
+type stubAgentResponder struct {
+	params  *types.MessageEditParams
+	err     error
+	payload []byte
+}
+
+func (s *stubAgentResponder) Respond(ctx context.Context, env *broker.Envelope, payload []byte) (*types.MessageEditParams, error) {
+	s.payload = payload
+	return s.params, s.err
+}
+
+func TestAgentListenerUsesResponder(t *testing.T) {
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+	payload, _ := json.Marshal(&broker.Envelope{Agent: "py", Kind: handlerKindCommand, Key: "help", Interaction: raw})
+
+	client := &stubInteractionResponder{}
+	listener := newAgentListener("py", "app", client, &bufferPrinter{})
+	listener.responder = &stubAgentResponder{params: &types.MessageEditParams{Content: "from handler"}}
+	if err := listener.handlePayload(context.Background(), payload); err != nil {
+		t.Fatalf("handlePayload: %v", err)
+	}
+	if client.params == nil || client.params.Content != "from handler" {
+		t.Fatalf("unexpected edit params %+v", client.params)
+	}
+	if client.followupCalled {
+		t.Fatal("responder mode should not send the built-in follow-up")
+	}
+
+	client = &stubInteractionResponder{}
+	out := &bufferPrinter{}
+	listener = newAgentListener("py", "app", client, out)
+	listener.responder = &stubAgentResponder{err: errors.New("boom")}
+	if err := listener.handlePayload(context.Background(), payload); err != nil {
+		t.Fatalf("handler failure should not stop the listener: %v", err)
+	}
+	if client.params == nil || !strings.Contains(client.params.Content, "could not handle") {
+		t.Fatalf("expected failure notice, got %+v", client.params)
+	}
+	if !strings.Contains(out.String(), "boom") {
+		t.Fatalf("expected failure to be logged, got %q", out.String())
+	}
+}
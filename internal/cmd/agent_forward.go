@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+const defaultForwardAttempts = 3
+
+// forwardResponder POSTs each envelope to a local HTTP endpoint and reads the
+// reply from the response body. Network errors, 429s, and 5xx responses are
+// retried with backoff until the envelope's timeout budget runs out.
+type forwardResponder struct {
+	url      string
+	client   *http.Client
+	attempts int
+	timeout  time.Duration
+}
+
+func (f *forwardResponder) Respond(ctx context.Context, env *broker.Envelope, payload []byte) (*types.MessageEditParams, error) {
+	budget := replyTimeout(f.timeout, env)
+	runCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	attempts := f.attempts
+	if attempts <= 0 {
+		attempts = defaultForwardAttempts
+	}
+	policy := &types.RetryPolicy{
+		MaxAttempts: attempts,
+		BackoffBase: 250 * time.Millisecond,
+		BackoffMax:  2 * time.Second,
+		Jitter:      true,
+	}
+
+	var (
+		reply     []byte
+		permanent error
+	)
+	err := policy.Execute(runCtx, func() error {
+		body, retry, err := f.post(runCtx, env, payload)
+		if err != nil && !retry {
+			permanent = err
+			return nil
+		}
+		reply = body
+		return err
+	})
+	if permanent != nil {
+		return nil, permanent
+	}
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%s did not answer within %s", f.url, budget)
+		}
+		return nil, err
+	}
+	return parseAgentReply(reply)
+}
+
+// post performs one delivery and reports whether a failure is worth retrying.
+func (f *forwardResponder) post(ctx context.Context, env *broker.Envelope, payload []byte) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vibe-Agent", env.Agent)
+	req.Header.Set("X-Vibe-Interaction-Kind", env.Kind)
+	req.Header.Set("X-Vibe-Interaction-Key", env.Key)
+
+	client := f.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("forward to %s: %w", f.url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAgentReplyBytes+1))
+	if err != nil {
+		return nil, true, fmt.Errorf("read reply from %s: %w", f.url, err)
+	}
+	if len(body) > maxAgentReplyBytes {
+		return nil, false, fmt.Errorf("reply from %s exceeds %d bytes", f.url, maxAgentReplyBytes)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retry, fmt.Errorf("%s returned HTTP %d: %s", f.url, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, false, nil
+}
+
+func validateForwardURL(raw string) error {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid --forward-url %q: expected an http:// or https:// URL", raw)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+)
+
+func TestForwardResponderRetriesServerErrors(t *testing.T) {
+	var calls int32
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			http.Error(w, "warming up", http.StatusServiceUnavailable)
+			return
+		}
+		gotBody, _ = io.ReadAll(r.Body)
+		if r.Header.Get("X-Vibe-Interaction-Key") != "help" {
+			t.Errorf("missing interaction header: %v", r.Header)
+		}
+		_, _ = w.Write([]byte(`{"content":"forwarded"}`))
+	}))
+	defer srv.Close()
+
+	env := &broker.Envelope{Agent: "node", Kind: handlerKindCommand, Key: "help"}
+	payload, _ := json.Marshal(env)
+	responder := &forwardResponder{url: srv.URL, timeout: 5 * time.Second}
+	params, err := responder.Respond(context.Background(), env, payload)
+	if err != nil {
+		t.Fatalf("respond: %v", err)
+	}
+	if params.Content != "forwarded" {
+		t.Fatalf("unexpected content %q", params.Content)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected one retry, got %d calls", calls)
+	}
+	if string(gotBody) != string(payload) {
+		t.Fatalf("expected envelope body, got %s", gotBody)
+	}
+}
+
+func TestForwardResponderDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "bad envelope", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	env := &broker.Envelope{Agent: "node"}
+	responder := &forwardResponder{url: srv.URL, timeout: 5 * time.Second}
+	_, err := responder.Respond(context.Background(), env, []byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "HTTP 400") {
+		t.Fatalf("expected HTTP 400 error, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestForwardResponderHonorsTimeoutBudget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	env := &broker.Envelope{Agent: "node", TimeoutSeconds: 1}
+	responder := &forwardResponder{url: srv.URL, timeout: 100 * time.Millisecond}
+	start := time.Now()
+	_, err := responder.Respond(context.Background(), env, []byte(`{}`))
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("budget not enforced, took %s", time.Since(start))
+	}
+}
+
+func TestValidateForwardURL(t *testing.T) {
+	if err := validateForwardURL("http://localhost:9000/hook"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, raw := range []string{"", "localhost:9000", "ftp://host/x"} {
+		if err := validateForwardURL(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
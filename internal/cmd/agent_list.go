@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+
+	"github.com/yourorg/arc-sdk/output"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// agentsPath is where the interactions server serves the agent registry.
+const (
+	agentsPath    = "/agents"
+	agentsTimeout = 5 * time.Second
+)
+
+func agentListCmd(opts *globalOptions) *cobra.Command {
+	var (
+		redisAddr   string
+		redisPrefix string
+		capability  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List running agents from the Redis registry",
+		Long: `List agents whose listeners are currently registered, with the capabilities they advertise and
+how long ago each last heartbeated. Entries expire when an agent stops heartbeating. --capability filters to agents that can handle a
+capability; a bare name such as deploy also matches scoped declarations like deploy:staging.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			return runAgentList(cmd, opts, opts.output, redisAddr, redisPrefix, capability)
+		},
+		Example: `Example:
+  arc-discord agent list
+
+Example:
+  # Find agents that can deploy (any environment)
+  arc-discord agent list --capability deploy --output json`,
+	}
+	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis host:port or redis[s]://user:pass@host:port/db URL for the registry")
+	cmd.Flags().StringVar(&redisPrefix, "redis-prefix", "", "Redis channel prefix (default arc:discord)")
+	cmd.Flags().StringVar(&capability, "capability", "", "Only show agents advertising this capability")
+	return cmd
+}
+
+func runAgentList(cmd *cobra.Command, opts *globalOptions, out output.OutputOptions, redisAddr, redisPrefix, capability string) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	b, err := openAgentBroker(ctx, opts, redisAddr, redisPrefix)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	agents, err := b.Registry().List(ctx)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to list agents"}).WithCause(err)
+	}
+	agents = filterAgentsByCapability(agents, capability)
+	now := time.Now()
+	entries := agentListEntries(agents, now)
+	rows := make([][]string, 0, len(agents))
+	for _, a := range agents {
+		rows = append(rows, []string{
+			a.Agent,
+			valueOrDash(a.Version),
+			valueOrDash(shortCommit(a.Commit)),
+			strings.Join(a.Capabilities, ", "),
+			a.Hostname,
+			fmt.Sprintf("%d", a.ProcessID),
+			agentStarted(a.StartedAt),
+			formatHeartbeatAge(a.UpdatedAt, now),
+		})
+	}
+	table := &tableData{headers: []string{"Agent", "Version", "Commit", "Capabilities", "Host", "PID", "Started", "Last Heartbeat"}, rows: rows}
+	return renderOutput(cmd, out, entries, table)
+}
+
+// agentListEntry is a registry entry plus the age of its last heartbeat.
+type agentListEntry struct {
+	broker.AgentInfo    `yaml:",inline"`
+	HeartbeatAgeSeconds int64 `json:"heartbeat_age_seconds" yaml:"heartbeat_age_seconds"`
+}
+
+func agentListEntries(agents []broker.AgentInfo, now time.Time) []agentListEntry {
+	entries := make([]agentListEntry, 0, len(agents))
+	for _, a := range agents {
+		entries = append(entries, agentListEntry{AgentInfo: a, HeartbeatAgeSeconds: int64(heartbeatAge(a.UpdatedAt, now).Seconds())})
+	}
+	return entries
+}
+
+func filterAgentsByCapability(agents []broker.AgentInfo, capability string) []broker.AgentInfo {
+	if capability == "" {
+		return agents
+	}
+	filtered := agents[:0]
+	for _, a := range agents {
+		if hasCapability(a.Capabilities, capability) {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// newAgentsHandler serves the live registry entries as JSON, in the same
+// shape as "agent list --output json", so monitoring can read fleet state
+// without Redis access. ?capability= filters like --capability.
+func newAgentsHandler(registry broker.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), agentsTimeout)
+		defer cancel()
+		agents, err := registry.List(ctx)
+		if err != nil {
+			// The error names the broker address; keep it in the server log.
+			logger.Default().Error("list agents for /agents", "error", err)
+			http.Error(w, "agent registry unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		agents = filterAgentsByCapability(agents, strings.TrimSpace(r.URL.Query().Get("capability")))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(agentListEntries(agents, time.Now()))
+	})
+}
+
+func heartbeatAge(updated, now time.Time) time.Duration {
+	if updated.IsZero() || updated.After(now) {
+		return 0
+	}
+	return now.Sub(updated)
+}
+
+func formatHeartbeatAge(updated, now time.Time) string {
+	if updated.IsZero() {
+		return "-"
+	}
+	return heartbeatAge(updated, now).Round(time.Second).String() + " ago"
+}
+
+// openAgentBroker connects to the broker configured in discord.yaml, with the
+// registry commands' --redis-addr/--redis-prefix overrides applied.
+func openAgentBroker(ctx context.Context, opts *globalOptions, redisAddr, redisPrefix string) (broker.Broker, error) {
+	_, extra, _, err := opts.loadConfigWithInteractions()
+	if err != nil {
+		return nil, err
+	}
+	if redisAddr != "" {
+		extra.Redis.Addr = redisAddr
+	}
+	if redisPrefix != "" {
+		extra.Redis.ChannelPrefix = redisPrefix
+	}
+	extra.Redis.ChannelPrefix = normalizeChannelPrefix(extra.Redis.ChannelPrefix)
+	brokerCfg, err := extra.brokerConfig()
+	if err != nil {
+		return nil, &arcer.CLIError{Msg: err.Error(), Hint: "fix the redis section in discord.yaml"}
+	}
+	b, err := newBrokerFn(ctx, brokerCfg)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "failed to connect to broker"}).WithCause(err)
+	}
+	return b, nil
+}
+
+func agentStarted(started time.Time) string {
+	if started.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (up %s)", started.Format(time.RFC3339), time.Since(started).Round(time.Second))
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+// Log formats for agent listen --log-format.
+const (
+	agentLogText = "text"
+	agentLogJSON = "json"
+)
+
+// Envelope outcomes in --log-format json records.
+const (
+	envelopeProcessed     = "processed"
+	envelopeHandlerFailed = "handler_failed"
+	envelopeFailed        = "failed"
+	envelopeSkipped       = "skipped"
+	envelopeInvalid       = "invalid"
+	envelopeIgnored       = "ignored"
+)
+
+// envelopeRecord is what handling one envelope came to. env is nil until
+// the envelope is known to be for this agent.
+type envelopeRecord struct {
+	env     *broker.Envelope
+	outcome string
+	err     error
+}
+
+// textf prints per-envelope lines in text mode; JSON mode logs one record
+// per envelope instead.
+func (l *agentListener) textf(format string, args ...interface{}) {
+	if l.log == nil {
+		l.output.Printf(format, args...)
+	}
+}
+
+// logEnvelope writes the JSON record for an envelope. Envelopes for other
+// agents are not logged.
+func (l *agentListener) logEnvelope(rec *envelopeRecord, latency time.Duration) {
+	if l.log == nil || rec.outcome == envelopeIgnored {
+		return
+	}
+	fields := []interface{}{
+		"agent", l.agentID,
+		"outcome", rec.outcome,
+		"latency_ms", latency.Milliseconds(),
+	}
+	if env := rec.env; env != nil {
+		fields = append(fields, "kind", env.Kind, "key", env.Key)
+		if env.ID != "" {
+			fields = append(fields, "envelope_id", env.ID, "attempt", env.Attempt)
+		}
+		if env.RequestID != "" {
+			fields = append(fields, "request_id", env.RequestID)
+		}
+	}
+	if rec.err != nil {
+		fields = append(fields, "error", rec.err.Error())
+	}
+	switch rec.outcome {
+	case envelopeFailed, envelopeHandlerFailed, envelopeInvalid:
+		l.log.Error("envelope", fields...)
+	default:
+		l.log.Info("envelope", fields...)
+	}
+}
+
+// logPrinter writes the listener's other messages as log records, so
+// --log-format json output is JSON throughout.
+type logPrinter struct {
+	log *logger.Logger
+}
+
+func (p logPrinter) Printf(format string, args ...interface{}) {
+	msg := strings.TrimSpace(fmt.Sprintf(format, args...))
+	if warning, ok := strings.CutPrefix(msg, "Warning: "); ok {
+		p.log.Warn(warning)
+		return
+	}
+	p.log.Info(msg)
+}
+
+// agentListenOutput picks where agent listen writes. JSON records go to
+// stdout so they can be piped to a log shipper; text goes to stderr like the
+// other commands' progress messages.
+func agentListenOutput(cmd *cobra.Command, format string) (outputPrinter, *logger.Logger) {
+	if format != agentLogJSON {
+		return cmd, nil
+	}
+	log := logger.New(logger.InfoLevel, agentLogJSON, cmd.OutOrStdout())
+	return logPrinter{log: log}, log
+}
+
+func validateAgentLogFormat(format string) error {
+	if format != agentLogText && format != agentLogJSON {
+		return fmt.Errorf("invalid --log-format %q: expected %s or %s", format, agentLogText, agentLogJSON)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+func TestAgentListenerLogsEnvelopesAsJSON(t *testing.T) {
+	var logs bytes.Buffer
+	log := logger.New(logger.InfoLevel, agentLogJSON, &logs)
+	responder := &stubInteractionResponder{}
+	listener := newAgentListener("ops", "app123", responder, logPrinter{log: log})
+	listener.log = log
+	agent := &stubAgentResponder{params: &types.MessageEditParams{Content: "done"}}
+	listener.responder = agent
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+
+	envelopes := []*broker.Envelope{
+		{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw, ID: "d1", Attempt: 1, RequestID: "r1"},
+		{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw, ID: "d1", Attempt: 2},
+		{Agent: "other", Kind: handlerKindCommand, Key: "deploy", Interaction: raw},
+	}
+	for _, env := range envelopes {
+		if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	agent.err = errors.New("exit status 2")
+	if err := listener.handlePayload(context.Background(), mustEnvelope(t, &broker.Envelope{Agent: "ops", Kind: handlerKindComponent, Key: "approve", Interaction: raw})); err != nil {
+		t.Fatal(err)
+	}
+	if err := listener.handlePayload(context.Background(), []byte("not json")); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("non-JSON output %q", line)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected one record per envelope for this agent, got %d: %s", len(records), logs.String())
+	}
+	first := records[0]
+	if first["message"] != "envelope" || first["agent"] != "ops" || first["kind"] != handlerKindCommand || first["key"] != "deploy" ||
+		first["outcome"] != envelopeProcessed || first["request_id"] != "r1" || first["latency_ms"] == nil {
+		t.Fatalf("unexpected record %v", first)
+	}
+	if records[1]["outcome"] != envelopeSkipped {
+		t.Fatalf("expected the redelivery to be skipped, got %v", records[1])
+	}
+	if failed := records[2]; failed["outcome"] != envelopeHandlerFailed || failed["error"] != "exit status 2" || failed["level"] != "error" {
+		t.Fatalf("unexpected handler failure record %v", failed)
+	}
+	if records[3]["outcome"] != envelopeInvalid || records[3]["error"] == nil {
+		t.Fatalf("unexpected invalid payload record %v", records[3])
+	}
+}
+
+func TestAgentListenJSONLogsGoToStdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	out, log := agentListenOutput(cmd, agentLogJSON)
+	out.Printf("Listening for agent ops\n")
+	log.Info("envelope", "outcome", envelopeProcessed)
+
+	if stderr.Len() != 0 {
+		t.Fatalf("expected nothing on stderr, got %q", stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two records on stdout, got %q", stdout.String())
+	}
+	for _, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Fatalf("non-JSON output %q", line)
+		}
+	}
+}
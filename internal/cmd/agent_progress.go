@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// minProgressInterval keeps progress edits well inside Discord's rate limits
+// for a single interaction token.
+const minProgressInterval = 2 * time.Second
+
+// progressEditTimeout bounds one progress edit; a late one is skipped, not
+// retried, since the next frame replaces it.
+const progressEditTimeout = 10 * time.Second
+
+var progressSpinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// agentProgress is the latest progress a handler reported. Percent is -1
+// until the handler reports one.
+type agentProgress struct {
+	mu      sync.Mutex
+	percent int
+	note    string
+}
+
+func newAgentProgress() *agentProgress {
+	return &agentProgress{percent: -1}
+}
+
+// set records a report; a negative percent keeps the previous one.
+func (p *agentProgress) set(percent int, note string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if percent >= 0 {
+		p.percent = min(percent, 100)
+	}
+	p.note = note
+}
+
+func (p *agentProgress) snapshot() (int, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.percent, p.note
+}
+
+type agentProgressKey struct{}
+
+func withAgentProgress(ctx context.Context, p *agentProgress) context.Context {
+	return context.WithValue(ctx, agentProgressKey{}, p)
+}
+
+// agentProgressFrom returns where a responder reports progress, or nil when
+// the listener does not show it.
+func agentProgressFrom(ctx context.Context) *agentProgress {
+	p, _ := ctx.Value(agentProgressKey{}).(*agentProgress)
+	return p
+}
+
+// parseProgressLine reads a handler's "progress: 40 building image" report.
+// The percent (with or without %) is optional.
+func parseProgressLine(line string) (int, string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), "progress:")
+	if !ok {
+		return 0, "", false
+	}
+	rest = strings.TrimSpace(rest)
+	first, note, _ := strings.Cut(rest, " ")
+	percent, err := strconv.Atoi(strings.TrimSuffix(first, "%"))
+	if err != nil || percent < 0 {
+		return -1, rest, true
+	}
+	return percent, strings.TrimSpace(note), true
+}
+
+// progressWriter takes progress lines out of a handler's stderr and passes
+// the rest through to out.
+type progressWriter struct {
+	progress *agentProgress
+	out      io.Writer
+	partial  []byte
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		idx := bytes.IndexByte(w.partial, '\n')
+		if idx < 0 {
+			return len(p), nil
+		}
+		line := w.partial[:idx+1]
+		w.partial = w.partial[idx+1:]
+		w.line(line)
+	}
+}
+
+// flush handles a last line without a trailing newline.
+func (w *progressWriter) flush() {
+	if len(w.partial) > 0 {
+		w.line(w.partial)
+		w.partial = nil
+	}
+}
+
+func (w *progressWriter) line(line []byte) {
+	if percent, note, ok := parseProgressLine(string(line)); ok {
+		w.progress.set(percent, note)
+		return
+	}
+	if w.out != nil {
+		w.out.Write(line)
+	}
+}
+
+// progressText renders one progress edit, such as
+// "⠹ Agent ops is working on command `deploy` · 40% · 12s · building image".
+func progressText(agent string, env *broker.Envelope, frame, percent int, elapsed time.Duration, note string) string {
+	parts := []string{fmt.Sprintf("%s Agent %s is working on %s `%s`", progressSpinner[frame%len(progressSpinner)], agent, env.Kind, env.Key)}
+	if percent >= 0 {
+		parts = append(parts, fmt.Sprintf("%d%%", percent))
+	}
+	parts = append(parts, elapsed.Round(time.Second).String())
+	if note != "" {
+		parts = append(parts, note)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// progressUpdates edits the original response every interval while a
+// handler runs.
+type progressUpdates struct {
+	state   *agentProgress
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// startProgress begins progress edits for the interaction, or returns nil
+// when the listener does not show progress.
+func (l *agentListener) startProgress(ctx context.Context, target interactionResponder, env *broker.Envelope, interaction *types.Interaction) *progressUpdates {
+	if l.progressInterval <= 0 {
+		return nil
+	}
+	// Progress edits are best effort: the next tick stands in for a retry.
+	if retrying, ok := target.(*retryingResponder); ok {
+		target = retrying.next
+	}
+	u := &progressUpdates{state: newAgentProgress(), stop: make(chan struct{}), stopped: make(chan struct{})}
+	started := time.Now()
+	go func() {
+		defer close(u.stopped)
+		ticker := time.NewTicker(l.progressInterval)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			select {
+			case <-u.stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			percent, note := u.state.snapshot()
+			params := &types.MessageEditParams{Content: progressText(l.agentID, env, frame, percent, time.Since(started), note)}
+			opCtx, cancel := context.WithTimeout(ctx, progressEditTimeout)
+			_, err := target.EditOriginalInteractionResponse(opCtx, l.applicationID, interaction.Token, params)
+			cancel()
+			if err != nil {
+				l.output.Printf("Progress update failed for %s interaction %s%s: %v\n", env.Kind, env.Key, requestRef(env), err)
+			}
+		}
+	}()
+	return u
+}
+
+// context returns ctx carrying the progress state for the responder.
+func (u *progressUpdates) context(ctx context.Context) context.Context {
+	if u == nil {
+		return ctx
+	}
+	return withAgentProgress(ctx, u.state)
+}
+
+// finish stops the edits and waits for one in flight, so it cannot land
+// after the final result.
+func (u *progressUpdates) finish() {
+	if u == nil {
+		return
+	}
+	close(u.stop)
+	<-u.stopped
+}
+
+func validateProgressInterval(interval time.Duration) error {
+	if interval != 0 && interval < minProgressInterval {
+		return fmt.Errorf("--progress-interval must be at least %s", minProgressInterval)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// reportingResponder reports progress, waits for the listener to show it,
+// and then replies.
+type reportingResponder struct {
+	edited chan *types.MessageEditParams
+	shown  string
+}
+
+func (r *reportingResponder) Respond(ctx context.Context, env *broker.Envelope, payload []byte) (*types.MessageEditParams, error) {
+	agentProgressFrom(ctx).set(40, "building image")
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case params := <-r.edited:
+			if strings.Contains(params.Content, "40%") {
+				r.shown = params.Content
+				return &types.MessageEditParams{Content: "built"}, nil
+			}
+		case <-deadline:
+			return &types.MessageEditParams{Content: "no progress shown"}, nil
+		}
+	}
+}
+
+func TestAgentListenerShowsProgress(t *testing.T) {
+	responder := &notifyingResponder{edited: make(chan *types.MessageEditParams, 4)}
+	listener := newAgentListener("build", "app123", responder, testPrinter{t})
+	listener.progressInterval = 10 * time.Millisecond
+	agent := &reportingResponder{edited: responder.edited}
+	listener.responder = agent
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+	env := &broker.Envelope{Agent: "build", Kind: handlerKindCommand, Key: "deploy", Interaction: raw}
+	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(agent.shown, "Agent build is working on command `deploy` · 40%") || !strings.HasSuffix(agent.shown, " · building image") {
+		t.Fatalf("unexpected progress edit %q", agent.shown)
+	}
+	var last *types.MessageEditParams
+	for len(responder.edited) > 0 {
+		last = <-responder.edited
+	}
+	if last == nil || last.Content != "built" {
+		t.Fatalf("expected the reply as the last edit, got %+v", last)
+	}
+}
+
+func TestProgressText(t *testing.T) {
+	env := &broker.Envelope{Kind: handlerKindCommand, Key: "deploy"}
+	if got := progressText("ops", env, 11, -1, 1500*time.Millisecond, ""); got != "⠙ Agent ops is working on command `deploy` · 2s" {
+		t.Fatalf("unexpected text %q", got)
+	}
+	cases := map[string][3]any{
+		"progress: 40 building image": {40, "building image", true},
+		"progress: 75%":               {75, "", true},
+		"progress: pulling layers":    {-1, "pulling layers", true},
+		"warning: slow disk":          {0, "", false},
+	}
+	for line, want := range cases {
+		percent, note, ok := parseProgressLine(line)
+		if percent != want[0] || note != want[1] || ok != want[2] {
+			t.Errorf("%q: got %d %q %v", line, percent, note, ok)
+		}
+	}
+}
+
+func TestExecResponderReportsProgress(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	env := &broker.Envelope{Agent: "py", Kind: handlerKindCommand, Key: "build"}
+	var stderr bytes.Buffer
+	responder := &execResponder{
+		command: `echo "progress: 60 linking" >&2; echo "warning" >&2; printf 'progress: 90%%' >&2; echo done`,
+		timeout: 5 * time.Second,
+		stderr:  &stderr,
+	}
+	progress := newAgentProgress()
+	if _, err := responder.Respond(withAgentProgress(context.Background(), progress), env, []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if percent, note := progress.snapshot(); percent != 90 || note != "" {
+		t.Fatalf("unexpected progress %d %q", percent, note)
+	}
+	if stderr.String() != "warning\n" {
+		t.Fatalf("progress lines leaked to stderr: %q", stderr.String())
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	discordutils "github.com/yourorg/arc-discord/gosdk/discord/utils"
+)
+
+// Retry budget for the listener's Discord calls. Attempts stop early when
+// the interaction token would expire before the next one.
+const (
+	agentCallAttempts   = 5
+	agentCallBackoff    = time.Second
+	agentCallBackoffMax = 30 * time.Second
+)
+
+// retryingResponder retries edits and followups that fail with a 5xx, a 429,
+// or a network error. A 429's retry_after replaces the backoff; other 4xx
+// errors are returned at once. It owns the retries, so next should not retry
+// on its own (see createAgentInteractionClient), and the whole call is bound
+// by the token lifetime.
+type retryingResponder struct {
+	next interactionResponder
+	// expires is when Discord stops accepting the interaction token.
+	expires time.Time
+	now     func() time.Time
+	sleep   func(ctx context.Context, d time.Duration) bool
+	// retried reports each failed attempt that will be retried.
+	retried func(op string, attempt int, wait time.Duration, err error)
+}
+
+// newRetryingResponder wraps next for one interaction. The token lifetime
+// runs from the interaction's snowflake, or from now when it has none.
+func newRetryingResponder(next interactionResponder, interaction *types.Interaction) *retryingResponder {
+	created := time.Now()
+	if at, err := discordutils.SnowflakeToTime(interaction.ID); err == nil && at.Before(created) {
+		created = at
+	}
+	return &retryingResponder{next: next, expires: created.Add(interactionTokenTTL), now: time.Now, sleep: sleepContext}
+}
+
+func (r *retryingResponder) EditOriginalInteractionResponse(ctx context.Context, applicationID, token string, params *types.MessageEditParams) (*types.Message, error) {
+	var msg *types.Message
+	err := r.call(ctx, "edit original response", func(ctx context.Context) (err error) {
+		msg, err = r.next.EditOriginalInteractionResponse(ctx, applicationID, token, params)
+		return err
+	})
+	return msg, err
+}
+
+func (r *retryingResponder) CreateFollowupMessage(ctx context.Context, applicationID, token string, params *types.MessageCreateParams) (*types.Message, error) {
+	var msg *types.Message
+	err := r.call(ctx, "create followup", func(ctx context.Context) (err error) {
+		msg, err = r.next.CreateFollowupMessage(ctx, applicationID, token, params)
+		return err
+	})
+	return msg, err
+}
+
+func (r *retryingResponder) call(ctx context.Context, op string, fn func(context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithDeadline(ctx, r.expires)
+		err := fn(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || !retryableAgentCall(err) || attempt >= agentCallAttempts {
+			return err
+		}
+		wait := agentCallBackoff << (attempt - 1)
+		if wait > agentCallBackoffMax {
+			wait = agentCallBackoffMax
+		}
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = time.Duration(apiErr.RetryAfter) * time.Second
+		}
+		if r.now().Add(wait).After(r.expires) {
+			return fmt.Errorf("%w (the interaction token expires before a retry)", err)
+		}
+		if r.retried != nil {
+			r.retried(op, attempt, wait, err)
+		}
+		if !r.sleep(ctx, wait) {
+			return err
+		}
+	}
+}
+
+// retryableAgentCall reports failures a later attempt may not repeat.
+func retryableAgentCall(err error) bool {
+	var netErr *types.NetworkError
+	return errors.Is(err, types.ErrServerError) ||
+		errors.Is(err, types.ErrRateLimited) ||
+		errors.As(err, &netErr)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/client"
+	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// flakyResponder fails edits with the queued errors before succeeding.
+type flakyResponder struct {
+	stubInteractionResponder
+	errs  []error
+	calls int
+}
+
+func (f *flakyResponder) EditOriginalInteractionResponse(ctx context.Context, applicationID, token string, params *types.MessageEditParams) (*types.Message, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return f.stubInteractionResponder.EditOriginalInteractionResponse(ctx, applicationID, token, params)
+}
+
+func TestRetryingResponderRetriesTransientFailures(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var waits []time.Duration
+	newRetrying := func(next interactionResponder, expires time.Duration) *retryingResponder {
+		waits = nil
+		return &retryingResponder{
+			next:    next,
+			expires: now.Add(expires),
+			now:     func() time.Time { return now },
+			sleep: func(_ context.Context, d time.Duration) bool {
+				waits = append(waits, d)
+				return true
+			},
+		}
+	}
+	params := &types.MessageEditParams{Content: "done"}
+
+	flaky := &flakyResponder{errs: []error{
+		fmt.Errorf("request failed after 4 attempts: %w", &types.APIError{StatusCode: 503}),
+		&types.APIError{StatusCode: 429, RetryAfter: 7},
+		&types.NetworkError{Op: "request", Err: errors.New("connection reset")},
+	}}
+	if _, err := newRetrying(flaky, interactionTokenTTL).EditOriginalInteractionResponse(context.Background(), "app", "tok", params); err != nil {
+		t.Fatal(err)
+	}
+	if flaky.calls != 4 || flaky.params != params || fmt.Sprint(waits) != "[1s 7s 4s]" {
+		t.Fatalf("expected three retries, got %d calls with waits %v", flaky.calls, waits)
+	}
+
+	rejected := &flakyResponder{errs: []error{&types.APIError{StatusCode: 400, Message: "Invalid Form Body"}}}
+	if _, err := newRetrying(rejected, interactionTokenTTL).EditOriginalInteractionResponse(context.Background(), "app", "tok", params); err == nil || rejected.calls != 1 {
+		t.Fatalf("retried a 400: %d calls, %v", rejected.calls, err)
+	}
+
+	expiring := &flakyResponder{errs: []error{&types.APIError{StatusCode: 429, RetryAfter: 60}}}
+	_, err := newRetrying(expiring, 30*time.Second).EditOriginalInteractionResponse(context.Background(), "app", "tok", params)
+	if err == nil || !strings.Contains(err.Error(), "token expires") || len(waits) != 0 {
+		t.Fatalf("expected to give up before the token expires, got %v after waits %v", err, waits)
+	}
+
+	down := &flakyResponder{errs: []error{&types.APIError{StatusCode: 502}, &types.APIError{StatusCode: 502}, &types.APIError{StatusCode: 502}, &types.APIError{StatusCode: 502}, &types.APIError{StatusCode: 502}, &types.APIError{StatusCode: 502}}}
+	if _, err := newRetrying(down, interactionTokenTTL).EditOriginalInteractionResponse(context.Background(), "app", "tok", params); err == nil || down.calls != agentCallAttempts {
+		t.Fatalf("expected %d attempts, got %d (%v)", agentCallAttempts, down.calls, err)
+	}
+}
+
+func TestNewRetryingResponderUsesInteractionAge(t *testing.T) {
+	// Discord snowflake for 2023-11-14T22:13:20Z.
+	retrying := newRetryingResponder(&stubInteractionResponder{}, &types.Interaction{ID: "1174109840998400000"})
+	if want := time.Date(2023, 11, 14, 22, 28, 20, 0, time.UTC); !retrying.expires.Equal(want) {
+		t.Fatalf("expected the token to expire at %s, got %s", want, retrying.expires)
+	}
+}
+
+func TestRetryingResponderResendsRateLimitedEditOnceAfterRetryAfter(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record(r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		first := len(events) == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"message":"You are being rate limited.","retry_after":2,"global":false}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","content":"done"}`)
+	}))
+	defer srv.Close()
+
+	// The listener's client leaves retries to retryingResponder, even when
+	// the config asks the client for its own.
+	cfg := discordconfig.Default()
+	cfg.Client.Retries = 3
+	raw, err := createRawDiscordClient(cfg, "tok", client.WithMaxRetries(0), client.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	retrying := &retryingResponder{
+		next:    interactions.NewInteractionClient(raw),
+		expires: time.Now().Add(interactionTokenTTL),
+		now:     time.Now,
+		sleep: func(_ context.Context, d time.Duration) bool {
+			record("wait " + d.String())
+			return true
+		},
+	}
+	if _, err := retrying.EditOriginalInteractionResponse(context.Background(), "app", "tok", &types.MessageEditParams{Content: "done"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(events); got != "[PATCH wait 2s PATCH]" {
+		t.Fatalf("expected one resend after the retry_after wait, got %s", got)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// Ordering keys for agent listen --concurrency.
+const (
+	orderByToken   = "token"
+	orderByChannel = "channel"
+)
+
+// shardQueueSize bounds the envelopes waiting on one worker before the
+// subscription stops reading.
+const shardQueueSize = 16
+
+// shardedHandler runs envelopes on a fixed set of workers. Envelopes with the
+// same ordering key always land on the same worker, so an edit and the
+// followup after it are handled one at a time, in arrival order.
+type shardedHandler struct {
+	orderBy string
+	handle  broker.Handler
+	queues  []chan *broker.Message
+	wg      sync.WaitGroup
+	// failed is the first handler error; stop ends the subscription then.
+	mu     sync.Mutex
+	failed error
+	stop   context.CancelFunc
+}
+
+// newShardedHandler starts workers that call handle with ctx. stop is
+// called when a handler fails.
+func newShardedHandler(ctx context.Context, workers int, orderBy string, handle broker.Handler, stop context.CancelFunc) *shardedHandler {
+	s := &shardedHandler{orderBy: orderBy, handle: handle, stop: stop}
+	for range workers {
+		queue := make(chan *broker.Message, shardQueueSize)
+		s.queues = append(s.queues, queue)
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for msg := range queue {
+				if s.err() != nil {
+					continue
+				}
+				if err := handle(ctx, msg); err != nil {
+					s.fail(err)
+				}
+			}
+		}()
+	}
+	return s
+}
+
+// submit queues msg on its key's worker, waiting while that worker is busy.
+func (s *shardedHandler) submit(ctx context.Context, msg *broker.Message) error {
+	if err := s.err(); err != nil {
+		return err
+	}
+	select {
+	case s.queues[s.shard(orderKey(msg.Payload, s.orderBy))] <- msg:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// close lets the workers finish what is queued and returns the first
+// handler error.
+func (s *shardedHandler) close() error {
+	for _, queue := range s.queues {
+		close(queue)
+	}
+	s.wg.Wait()
+	return s.err()
+}
+
+func (s *shardedHandler) shard(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(s.queues)))
+}
+
+func (s *shardedHandler) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed == nil {
+		s.failed = err
+		s.stop()
+	}
+}
+
+func (s *shardedHandler) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failed
+}
+
+// orderKey is the interaction token, or the channel ID when ordering by
+// channel. Payloads that cannot be read share the empty key; the listener
+// reports them.
+func orderKey(payload []byte, orderBy string) string {
+	payload, err := broker.EnvelopeJSON(payload)
+	if err != nil {
+		return ""
+	}
+	env, err := broker.DecodeEnvelope(payload)
+	if err != nil {
+		return ""
+	}
+	var interaction types.Interaction
+	if err := json.Unmarshal(env.Interaction, &interaction); err != nil {
+		return ""
+	}
+	if orderBy == orderByChannel && interaction.ChannelID != "" {
+		return "channel:" + interaction.ChannelID
+	}
+	return interaction.Token
+}
+
+func validateConcurrency(workers int, orderBy string) error {
+	if workers < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", workers)
+	}
+	if orderBy != orderByToken && orderBy != orderByChannel {
+		return fmt.Errorf("invalid --order-by %q: expected %s or %s", orderBy, orderByToken, orderByChannel)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func shardMessage(t *testing.T, token, channel string, seq int) *broker.Message {
+	t.Helper()
+	raw, _ := json.Marshal(types.Interaction{Token: token, ChannelID: channel})
+	env := &broker.Envelope{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw, ID: token + string(rune('a'+seq))}
+	return &broker.Message{ID: env.ID, Payload: mustEnvelope(t, env)}
+}
+
+func TestShardedHandlerKeepsKeyOrder(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		order   = map[string][]string{}
+		running = map[string]bool{}
+		overlap bool
+	)
+	handle := func(_ context.Context, msg *broker.Message) error {
+		key := orderKey(msg.Payload, orderByToken)
+		mu.Lock()
+		if running[key] {
+			overlap = true
+		}
+		running[key] = true
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running[key] = false
+		order[key] = append(order[key], msg.ID)
+		mu.Unlock()
+		return nil
+	}
+	ctx := context.Background()
+	shards := newShardedHandler(ctx, 4, orderByToken, handle, func() {})
+	tokens := []string{"t1", "t2", "t3", "t4", "t5"}
+	for seq := range 5 {
+		for _, token := range tokens {
+			if err := shards.submit(ctx, shardMessage(t, token, "", seq)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := shards.close(); err != nil {
+		t.Fatal(err)
+	}
+	if overlap {
+		t.Fatal("two envelopes for one token ran at once")
+	}
+	for _, token := range tokens {
+		got := order[token]
+		if len(got) != 5 {
+			t.Fatalf("%s: handled %d envelopes", token, len(got))
+		}
+		for seq, id := range got {
+			if id != token+string(rune('a'+seq)) {
+				t.Fatalf("%s: out of order %v", token, got)
+			}
+		}
+	}
+
+	if orderKey(shardMessage(t, "t1", "55", 0).Payload, orderByChannel) != orderKey(shardMessage(t, "t2", "55", 0).Payload, orderByChannel) {
+		t.Fatal("envelopes in one channel got different keys")
+	}
+}
+
+func TestShardedHandlerStopsOnError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	boom := errors.New("boom")
+	shards := newShardedHandler(context.Background(), 2, orderByToken, func(context.Context, *broker.Message) error { return boom }, cancel)
+	if err := shards.submit(ctx, shardMessage(t, "t1", "", 0)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("a failed handler did not stop the subscription")
+	}
+	if err := shards.submit(ctx, shardMessage(t, "t2", "", 0)); !errors.Is(err, boom) {
+		t.Fatalf("expected the handler error, got %v", err)
+	}
+	if err := shards.close(); !errors.Is(err, boom) {
+		t.Fatalf("expected the handler error from close, got %v", err)
+	}
+	if err := validateConcurrency(4, "guild"); err == nil {
+		t.Fatal("expected an error for an unknown order key")
+	}
+}
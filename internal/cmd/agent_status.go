@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+
+	"github.com/yourorg/arc-sdk/output"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const (
+	// defaultStaleAfter allows three missed heartbeats before an entry counts as dead.
+	defaultStaleAfter    = 3 * defaultHeartbeatInterval
+	agentLivenessRefresh = 15 * time.Second
+	agentStatusLive      = "live"
+	agentStatusStale     = "stale"
+)
+
+func agentStatusCmd(opts *globalOptions) *cobra.Command {
+	var (
+		redisAddr   string
+		redisPrefix string
+		staleAfter  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status <agent>",
+		Short: "Show one agent's registry entry and whether it is still heartbeating",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			b, err := openAgentBroker(ctx, opts, redisAddr, redisPrefix)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			agents, err := b.Registry().List(ctx)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to read agent registry"}).WithCause(err)
+			}
+			info, ok := findAgent(agents, args[0])
+			if !ok {
+				return &arcer.CLIError{
+					Msg:  fmt.Sprintf("agent %s is not registered", args[0]),
+					Hint: "start it with 'arc-discord agent listen' or check 'arc-discord agent list'",
+				}
+			}
+			now := time.Now()
+			status := agentFreshness(info, staleAfter, now)
+			data := agentStatusEntry{
+				agentListEntry: agentListEntry{AgentInfo: info, HeartbeatAgeSeconds: int64(heartbeatAge(info.UpdatedAt, now).Seconds())},
+				Status:         status,
+			}
+			table := keyValueTable(map[string]string{
+				"Agent":          info.Agent,
+				"Status":         status,
+				"Last Heartbeat": formatHeartbeatAge(info.UpdatedAt, now),
+				"Version":        valueOrDash(info.Version),
+				"Commit":         valueOrDash(shortCommit(info.Commit)),
+				"Host":           valueOrDash(info.Hostname),
+				"PID":            fmt.Sprintf("%d", info.ProcessID),
+				"Started":        agentStarted(info.StartedAt),
+				"Capabilities":   valueOrDash(strings.Join(info.Capabilities, ", ")),
+				"Channels":       valueOrDash(strings.Join(info.Channels, ", ")),
+			})
+			return renderOutput(cmd, opts.output, data, table)
+		},
+		Example: `Example:
+  arc-discord agent status claude
+
+Example:
+  arc-discord agent status claude --stale-after 2m --output json`,
+	}
+	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis host:port or redis[s]://user:pass@host:port/db URL for the registry")
+	cmd.Flags().StringVar(&redisPrefix, "redis-prefix", "", "Redis channel prefix (default arc:discord)")
+	cmd.Flags().DurationVar(&staleAfter, "stale-after", defaultStaleAfter, "Report the agent as stale when its last heartbeat is older than this")
+	return cmd
+}
+
+func agentPruneCmd(opts *globalOptions) *cobra.Command {
+	var (
+		redisAddr   string
+		redisPrefix string
+		staleAfter  time.Duration
+		dryRun      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove registry entries for agents that stopped heartbeating",
+		Long: `Remove registry entries whose last heartbeat is older than --stale-after. Redis entries also
+expire on their own once the registry TTL passes; prune clears them sooner, for example after a host
+crash, so "agent list" and routing warnings reflect reality.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			if staleAfter <= 0 {
+				return &arcer.CLIError{Msg: "--stale-after must be positive"}
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			b, err := openAgentBroker(ctx, opts, redisAddr, redisPrefix)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			registry := b.Registry()
+			agents, err := registry.List(ctx)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to read agent registry"}).WithCause(err)
+			}
+			now := time.Now()
+			pruned := []agentListEntry{}
+			rows := [][]string{}
+			for _, info := range agents {
+				if agentFreshness(info, staleAfter, now) != agentStatusStale {
+					continue
+				}
+				if !dryRun {
+					if err := registry.Unregister(ctx, info.Agent); err != nil {
+						return (&arcer.CLIError{Msg: fmt.Sprintf("failed to remove %s", info.Agent)}).WithCause(err)
+					}
+				}
+				pruned = append(pruned, agentListEntry{AgentInfo: info, HeartbeatAgeSeconds: int64(heartbeatAge(info.UpdatedAt, now).Seconds())})
+				rows = append(rows, []string{info.Agent, valueOrDash(info.Hostname), formatHeartbeatAge(info.UpdatedAt, now)})
+			}
+			if opts.output.Is(output.OutputTable) {
+				verb := "Removed"
+				if dryRun {
+					verb = "Would remove"
+				}
+				cmd.Printf("%s %d stale agent(s) of %d registered\n", verb, len(pruned), len(agents))
+			}
+			table := &tableData{headers: []string{"Agent", "Host", "Last Heartbeat"}, rows: rows}
+			return renderOutput(cmd, opts.output, pruned, table)
+		},
+		Example: `Example:
+  arc-discord agent prune --stale-after 5m
+
+Example:
+  # Preview what would be removed
+  arc-discord agent prune --dry-run`,
+	}
+	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis host:port or redis[s]://user:pass@host:port/db URL for the registry")
+	cmd.Flags().StringVar(&redisPrefix, "redis-prefix", "", "Redis channel prefix (default arc:discord)")
+	cmd.Flags().DurationVar(&staleAfter, "stale-after", defaultStaleAfter, "Remove entries whose last heartbeat is older than this")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List stale entries without removing them")
+	return cmd
+}
+
+type agentStatusEntry struct {
+	agentListEntry `yaml:",inline"`
+	Status         string `json:"status" yaml:"status"`
+}
+
+func findAgent(agents []broker.AgentInfo, name string) (broker.AgentInfo, bool) {
+	for _, info := range agents {
+		if strings.EqualFold(info.Agent, name) {
+			return info, true
+		}
+	}
+	return broker.AgentInfo{}, false
+}
+
+func agentFreshness(info broker.AgentInfo, staleAfter time.Duration, now time.Time) string {
+	if staleAfter <= 0 {
+		staleAfter = defaultStaleAfter
+	}
+	if info.UpdatedAt.IsZero() || heartbeatAge(info.UpdatedAt, now) > staleAfter {
+		return agentStatusStale
+	}
+	return agentStatusLive
+}
+
+// livenessPublisher wraps a publisher and logs a warning when an envelope is
+// routed to an agent with no live registry entry. The registry is re-read at
+// most every agentLivenessRefresh, and each dead agent is reported once per
+// refresh, so a busy command does not flood the log.
+type livenessPublisher struct {
+	broker.Publisher
+	registry   broker.Registry
+	staleAfter time.Duration
+	logger     *logger.Logger
+	now        func() time.Time
+
+	mu        sync.Mutex
+	live      map[string]bool
+	warned    map[string]bool
+	refreshed time.Time
+}
+
+func newLivenessPublisher(publisher broker.Publisher, registry broker.Registry, log *logger.Logger) *livenessPublisher {
+	if log == nil {
+		log = logger.Default()
+	}
+	return &livenessPublisher{Publisher: publisher, registry: registry, staleAfter: defaultStaleAfter, logger: log, now: time.Now}
+}
+
+func (p *livenessPublisher) Publish(ctx context.Context, env *broker.Envelope) error {
+	if err := p.Publisher.Publish(ctx, env); err != nil {
+		return err
+	}
+	if env != nil && p.shouldWarn(ctx, env.Agent) {
+		p.logger.Warn("interaction routed to agent with no live registry entry", "agent", env.Agent, "kind", env.Kind, "key", env.Key)
+	}
+	return nil
+}
+
+// shouldWarn reports whether agent has no live entry and has not already been
+// reported since the last registry refresh.
+func (p *livenessPublisher) shouldWarn(ctx context.Context, agent string) bool {
+	agent = strings.ToLower(agent)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.live == nil || now.Sub(p.refreshed) >= agentLivenessRefresh {
+		agents, err := p.registry.List(ctx)
+		if err != nil {
+			// An unreadable registry says nothing about the agent.
+			return false
+		}
+		p.live = make(map[string]bool, len(agents))
+		p.warned = map[string]bool{}
+		for _, info := range agents {
+			if agentFreshness(info, p.staleAfter, now) == agentStatusLive {
+				p.live[strings.ToLower(info.Agent)] = true
+			}
+		}
+		p.refreshed = now
+	}
+	if p.live[agent] || p.warned[agent] {
+		return false
+	}
+	p.warned[agent] = true
+	return true
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func agentRegistryOptions(t *testing.T, reg *stubRegistry) (*globalOptions, *stubBroker) {
+	t.Helper()
+	stub := &stubBroker{reg: reg}
+	hookBroker(t, stub)
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte("discord:\n  bot_token: dummy\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return &globalOptions{configPath: path, output: output.OutputOptions{Format: string(output.OutputJSON)}}, stub
+}
+
+func TestAgentStatusReportsFreshness(t *testing.T) {
+	reg := &stubRegistry{agents: []broker.AgentInfo{
+		{Agent: "claude", UpdatedAt: time.Now().Add(-10 * time.Second)},
+		{Agent: "zed", UpdatedAt: time.Now().Add(-10 * time.Minute)},
+	}}
+	opts, _ := agentRegistryOptions(t, reg)
+
+	for name, want := range map[string]string{"CLAUDE": agentStatusLive, "zed": agentStatusStale} {
+		cmd := agentStatusCmd(opts)
+		cmd.SetArgs([]string{name})
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("status %s: %v (%s)", name, err, buf.String())
+		}
+		if !strings.Contains(buf.String(), `"status": "`+want+`"`) {
+			t.Fatalf("expected %s to be %s, got %s", name, want, buf.String())
+		}
+	}
+
+	cmd := agentStatusCmd(opts)
+	cmd.SetArgs([]string{"ghost"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("expected not registered error, got %v", err)
+	}
+}
+
+func TestAgentPruneRemovesStaleEntries(t *testing.T) {
+	reg := &stubRegistry{agents: []broker.AgentInfo{
+		{Agent: "claude", UpdatedAt: time.Now()},
+		{Agent: "zed", UpdatedAt: time.Now().Add(-10 * time.Minute)},
+	}}
+	opts, _ := agentRegistryOptions(t, reg)
+
+	cmd := agentPruneCmd(opts)
+	cmd.SetArgs([]string{"--stale-after", "5m", "--dry-run"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("prune dry-run: %v", err)
+	}
+	if reg.unregistered != 0 || !strings.Contains(buf.String(), `"agent": "zed"`) {
+		t.Fatalf("dry run should only report zed: %d removals, %s", reg.unregistered, buf.String())
+	}
+
+	cmd = agentPruneCmd(opts)
+	cmd.SetArgs([]string{"--stale-after", "5m"})
+	buf.Reset()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if reg.unregistered != 1 || strings.Contains(buf.String(), "claude") {
+		t.Fatalf("expected only zed to be removed: %d removals, %s", reg.unregistered, buf.String())
+	}
+}
+
+func TestLivenessPublisherWarnsOncePerRefresh(t *testing.T) {
+	now := time.Now()
+	reg := &stubRegistry{agents: []broker.AgentInfo{{Agent: "claude", UpdatedAt: now}}}
+	var logs bytes.Buffer
+	inner := &stubPublisher{}
+	pub := newLivenessPublisher(inner, reg, logger.New(logger.InfoLevel, "text", &logs))
+	pub.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for _, agent := range []string{"claude", "ghost", "ghost"} {
+		if err := pub.Publish(ctx, &broker.Envelope{Agent: agent, Kind: handlerKindCommand, Key: "help"}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	if len(inner.envelopes) != 3 {
+		t.Fatalf("expected every envelope to be published, got %d", len(inner.envelopes))
+	}
+	if got := strings.Count(logs.String(), "no live registry entry"); got != 1 {
+		t.Fatalf("expected a single warning, got %d: %s", got, logs.String())
+	}
+	if strings.Contains(logs.String(), "agent=claude") {
+		t.Fatalf("live agent should not be reported: %s", logs.String())
+	}
+
+	now = now.Add(agentLivenessRefresh)
+	_ = pub.Publish(ctx, &broker.Envelope{Agent: "ghost"})
+	if got := strings.Count(logs.String(), "no live registry entry"); got != 2 {
+		t.Fatalf("expected a new warning after refresh, got %d", got)
+	}
+}
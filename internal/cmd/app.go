@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/permissions"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const oauth2AuthorizeURL = "https://discord.com/oauth2/authorize"
+
+// oauth2Scopes lists the scopes Discord's authorize endpoint accepts.
+// botInstallScopes work without a redirect; the rest need --redirect-uri.
+var (
+	oauth2Scopes = map[string]bool{
+		"activities.read": true, "activities.write": true, "applications.builds.read": true,
+		"applications.builds.upload": true, "applications.commands": true, "applications.commands.permissions.update": true,
+		"applications.commands.update": true, "applications.entitlements": true, "applications.store.update": true,
+		"bot": true, "connections": true, "dm_channels.read": true, "email": true, "gdm.join": true,
+		"guilds": true, "guilds.join": true, "guilds.members.read": true, "identify": true,
+		"messages.read": true, "relationships.read": true, "role_connections.write": true, "rpc": true,
+		"rpc.activities.write": true, "rpc.notifications.read": true, "rpc.voice.read": true,
+		"rpc.voice.write": true, "voice": true, "webhook.incoming": true,
+	}
+	botInstallScopes = map[string]bool{"bot": true, "applications.commands": true}
+)
+
+type inviteURL struct {
+	URL             string   `json:"url" yaml:"url"`
+	ClientID        string   `json:"client_id" yaml:"client_id"`
+	Scopes          []string `json:"scopes" yaml:"scopes"`
+	Permissions     string   `json:"permissions" yaml:"permissions"`
+	PermissionNames []string `json:"permission_names" yaml:"permission_names"`
+	GuildID         string   `json:"guild_id,omitempty" yaml:"guild_id,omitempty"`
+}
+
+func appCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "app",
+		Short: "Work with the Discord application behind the bot",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(appGetCmd(opts))
+	cmd.AddCommand(appUpdateCmd(opts))
+	cmd.AddCommand(appInviteURLCmd(opts))
+	return cmd
+}
+
+func appGetCmd(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Show the application the bot token belongs to",
+		Long: `Show the current application from /applications/@me: name, description, flags (including the
+privileged intents), interactions endpoint URL, install params, and the owner or team.`,
+		Example: `Example:
+  arc-discord app get --output table
+
+Example:
+  # Check the endpoint Discord posts interactions to
+  arc-discord app get | jq -r .interactions_endpoint_url`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApp(cmd, opts, func(ctx context.Context, apps applicationService) (*types.Application, error) {
+				app, err := apps.GetCurrentApplication(ctx)
+				if err != nil {
+					return nil, (&arcer.CLIError{Msg: "failed to fetch the application"}).WithCause(err)
+				}
+				return app, nil
+			})
+		},
+	}
+}
+
+func appUpdateCmd(opts *globalOptions) *cobra.Command {
+	var (
+		description        string
+		endpointURL        string
+		customInstallURL   string
+		tags               []string
+		installScopes      []string
+		installPermissions []string
+	)
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Edit the application's description, endpoint, and install settings",
+		Long: `Update the current application, so CI can point the interactions endpoint at a fresh deploy
+without a trip to the developer portal. Only the flags given are changed; pass an empty value
+(--description "") to clear a field.
+
+Discord checks a new --interactions-endpoint-url by sending it a signed PING, so the server must
+already be running there ("arc-discord server start"). --install-permissions takes permission
+names like app invite-url and needs --install-scopes.`,
+		Example: `Example:
+  arc-discord app update --interactions-endpoint-url https://bot.example.com/interactions
+
+Example:
+  arc-discord app update --description "Deploy bot for Arc Labs" --install-scopes bot,applications.commands --install-permissions send_messages`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := &types.ModifyApplicationParams{}
+			flags := cmd.Flags()
+			if flags.Changed("description") {
+				params.Description = &description
+			}
+			if flags.Changed("interactions-endpoint-url") {
+				if endpointURL != "" && !strings.HasPrefix(endpointURL, "https://") {
+					return &arcer.CLIError{Msg: "--interactions-endpoint-url must be an https:// URL"}
+				}
+				params.InteractionsEndpointURL = &endpointURL
+			}
+			if flags.Changed("custom-install-url") {
+				params.CustomInstallURL = &customInstallURL
+			}
+			if flags.Changed("tags") {
+				params.Tags = &tags
+			}
+			if flags.Changed("install-permissions") && !flags.Changed("install-scopes") {
+				return &arcer.CLIError{Msg: "--install-permissions needs --install-scopes"}
+			}
+			if flags.Changed("install-scopes") {
+				mask, err := parsePermissionList(installPermissions)
+				if err != nil {
+					return &arcer.CLIError{Msg: err.Error(), Hint: "use permission names such as send_messages or manage_roles"}
+				}
+				for _, scope := range installScopes {
+					if !botInstallScopes[scope] {
+						return &arcer.CLIError{Msg: fmt.Sprintf("install scope %q is not allowed", scope), Hint: "install params take bot and applications.commands"}
+					}
+				}
+				params.InstallParams = &types.ApplicationInstallParams{Scopes: installScopes, Permissions: strconv.FormatInt(int64(mask), 10)}
+			}
+			if err := params.Validate(); err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "pass at least one of the flags in --help"}
+			}
+			return runApp(cmd, opts, func(ctx context.Context, apps applicationService) (*types.Application, error) {
+				app, err := apps.EditCurrentApplication(ctx, params)
+				if err != nil {
+					hint := ""
+					if params.InteractionsEndpointURL != nil {
+						hint = "Discord rejects an endpoint that does not answer its signed PING; check the server is up and uses this application's public key"
+					}
+					return nil, (&arcer.CLIError{Msg: "failed to update the application", Hint: hint}).WithCause(err)
+				}
+				return app, nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&description, "description", "", "Application description (up to 400 characters)")
+	cmd.Flags().StringVar(&endpointURL, "interactions-endpoint-url", "", "HTTPS URL Discord posts interactions to")
+	cmd.Flags().StringVar(&customInstallURL, "custom-install-url", "", "URL the Add App button opens instead of the install params")
+	cmd.Flags().StringSliceVar(&tags, "tags", nil, "Up to 5 discovery tags (comma-separated)")
+	cmd.Flags().StringSliceVar(&installScopes, "install-scopes", nil, "Scopes the Add App button requests")
+	cmd.Flags().StringSliceVar(&installPermissions, "install-permissions", nil, "Permissions the Add App button requests (names or bitfields)")
+	return cmd
+}
+
+// runApp builds the bot client, runs call, and renders the application it
+// returns.
+func runApp(cmd *cobra.Command, opts *globalOptions, call func(context.Context, applicationService) (*types.Application, error)) error {
+	if err := opts.output.Resolve(); err != nil {
+		return err
+	}
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	app, err := call(ctx, bot.Applications())
+	if err != nil {
+		return err
+	}
+	return renderOutput(cmd, opts.output, app, applicationTable(app))
+}
+
+func applicationTable(app *types.Application) *tableData {
+	owner := ""
+	switch {
+	case app.Team != nil:
+		owner = fmt.Sprintf("team %s (%s)", app.Team.Name, app.Team.ID)
+	case app.Owner != nil:
+		owner = fmt.Sprintf("%s (%s)", app.Owner.Username, app.Owner.ID)
+	}
+	install := ""
+	if app.InstallParams != nil {
+		mask := permissions.PermissionFromString(app.InstallParams.Permissions)
+		install = fmt.Sprintf("%s %s", strings.Join(app.InstallParams.Scopes, " "), mask)
+	}
+	guilds := ""
+	if app.ApproximateGuildCount > 0 {
+		guilds = strconv.Itoa(app.ApproximateGuildCount)
+	}
+	return keyValueTable(map[string]string{
+		"id":                        app.ID,
+		"name":                      app.Name,
+		"description":               valueOrDash(app.Description),
+		"owner":                     valueOrDash(owner),
+		"flags":                     valueOrDash(strings.Join(app.Flags.Names(), ", ")),
+		"bot_public":                strconv.FormatBool(app.BotPublic),
+		"interactions_endpoint_url": valueOrDash(app.InteractionsEndpointURL),
+		"install_params":            valueOrDash(install),
+		"custom_install_url":        valueOrDash(app.CustomInstallURL),
+		"tags":                      valueOrDash(strings.Join(app.Tags, ", ")),
+		"approximate_guilds":        valueOrDash(guilds),
+	})
+}
+
+func appInviteURLCmd(opts *globalOptions) *cobra.Command {
+	var (
+		perms       []string
+		scopes      []string
+		clientID    string
+		guildID     string
+		lockGuild   bool
+		redirectURI string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "invite-url",
+		Short: "Print the OAuth2 URL that adds the bot to a server",
+		Long: `Build the OAuth2 authorization URL for installing the bot, computing the permissions bitfield
+from names so no third-party calculator is needed.
+
+--permissions takes names in any case, with or without underscores (send_messages, ManageRoles),
+or decimal bitfields. The client ID is --client-id, else discord.application_id, else asked of
+Discord with the bot token. --guild preselects a server; --disable-guild-select locks it.
+Scopes other than bot and applications.commands start a user authorization flow and need
+--redirect-uri.`,
+		Example: `Example:
+  arc-discord app invite-url --permissions send_messages,manage_roles --scopes bot,applications.commands
+
+Example:
+  # Just the URL, for a README or a chat message
+  arc-discord app invite-url --permissions view_channel,send_messages | jq -r .url`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			mask, err := parsePermissionList(perms)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "use permission names such as send_messages or manage_roles"}
+			}
+			if clientID == "" {
+				if clientID, err = inviteClientID(cmd, opts); err != nil {
+					return err
+				}
+			}
+			invite, err := buildInviteURL(clientID, scopes, mask, guildID, lockGuild, redirectURI)
+			if err != nil {
+				return err
+			}
+			table := keyValueTable(map[string]string{
+				"url":         invite.URL,
+				"client_id":   invite.ClientID,
+				"scopes":      strings.Join(invite.Scopes, " "),
+				"permissions": fmt.Sprintf("%s %s", invite.Permissions, mask),
+				"guild_id":    valueOrDash(invite.GuildID),
+			})
+			return renderOutput(cmd, opts.output, invite, table)
+		},
+	}
+	cmd.Flags().StringSliceVar(&perms, "permissions", nil, "Permissions to request (comma-separated names or bitfields)")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{"bot", "applications.commands"}, "OAuth2 scopes (comma-separated)")
+	cmd.Flags().StringVar(&clientID, "client-id", "", "Application ID (default: discord.application_id)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID to preselect in the install dialog")
+	cmd.Flags().BoolVar(&lockGuild, "disable-guild-select", false, "Prevent picking a different guild than --guild")
+	cmd.Flags().StringVar(&redirectURI, "redirect-uri", "", "Redirect URI for scopes that need a user authorization code")
+	cmd.Annotations = map[string]string{annotationRawIDs: "true"}
+	return cmd
+}
+
+// inviteClientID reads discord.application_id, falling back to asking
+// Discord which application the bot token belongs to.
+func inviteClientID(cmd *cobra.Command, opts *globalOptions) (string, error) {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return "", err
+	}
+	if isSnowflake(cfg.Discord.ApplicationID) {
+		return cfg.Discord.ApplicationID, nil
+	}
+	hint := "pass --client-id or set discord.application_id"
+	if cfg.Discord.BotToken == "" {
+		return "", &arcer.CLIError{Msg: "no application ID configured", Hint: hint}
+	}
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return "", (&arcer.CLIError{Msg: "failed to create Discord client", Hint: hint}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	app, err := bot.Applications().GetCurrentApplication(ctx)
+	if err != nil {
+		return "", (&arcer.CLIError{Msg: "failed to look up the application ID", Hint: hint}).WithCause(err)
+	}
+	return app.ID, nil
+}
+
+func buildInviteURL(clientID string, scopes []string, mask permissions.Permission, guildID string, lockGuild bool, redirectURI string) (*inviteURL, error) {
+	if !isSnowflake(clientID) {
+		return nil, &arcer.CLIError{Msg: fmt.Sprintf("client ID %q is not a snowflake", clientID)}
+	}
+	if len(scopes) == 0 {
+		return nil, &arcer.CLIError{Msg: "no scopes given", Hint: "most bots need --scopes bot,applications.commands"}
+	}
+	hasBot, needsRedirect := false, false
+	for i, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !oauth2Scopes[scope] {
+			return nil, &arcer.CLIError{Msg: fmt.Sprintf("unknown OAuth2 scope %q", scope), Hint: "see https://discord.com/developers/docs/topics/oauth2#shared-resources-oauth2-scopes"}
+		}
+		scopes[i] = scope
+		hasBot = hasBot || scope == "bot"
+		needsRedirect = needsRedirect || !botInstallScopes[scope]
+	}
+	if mask != 0 && !hasBot {
+		return nil, &arcer.CLIError{Msg: "--permissions only apply with the bot scope", Hint: "add bot to --scopes"}
+	}
+	if needsRedirect && redirectURI == "" {
+		return nil, &arcer.CLIError{Msg: "these scopes need a redirect URI", Hint: "pass --redirect-uri (it must be listed under OAuth2 -> Redirects)"}
+	}
+	if lockGuild && guildID == "" {
+		return nil, &arcer.CLIError{Msg: "--disable-guild-select needs --guild"}
+	}
+	if guildID != "" && !isSnowflake(guildID) {
+		return nil, &arcer.CLIError{Msg: fmt.Sprintf("guild ID %q is not a snowflake", guildID)}
+	}
+
+	query := url.Values{}
+	query.Set("client_id", clientID)
+	query.Set("scope", strings.Join(scopes, " "))
+	if hasBot {
+		query.Set("permissions", strconv.FormatInt(int64(mask), 10))
+	}
+	if guildID != "" {
+		query.Set("guild_id", guildID)
+	}
+	if lockGuild {
+		query.Set("disable_guild_select", "true")
+	}
+	if redirectURI != "" {
+		query.Set("redirect_uri", redirectURI)
+		query.Set("response_type", "code")
+	}
+	return &inviteURL{
+		URL:             oauth2AuthorizeURL + "?" + strings.ReplaceAll(query.Encode(), "+", "%20"),
+		ClientID:        clientID,
+		Scopes:          scopes,
+		Permissions:     strconv.FormatInt(int64(mask), 10),
+		PermissionNames: mask.Names(),
+		GuildID:         guildID,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestAppInviteURLComputesPermissions(t *testing.T) {
+	cfg := testConfig()
+	cfg.Discord.ApplicationID = "123456789012345678"
+	hookBot(t, cfg, &fakeBotClient{})
+
+	cmd := NewRootCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"app", "invite-url", "--permissions", "send_messages,ManageRoles", "--output", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("invite-url: %v", err)
+	}
+	var invite inviteURL
+	if err := json.Unmarshal(out.Bytes(), &invite); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	want := "https://discord.com/oauth2/authorize?client_id=123456789012345678&permissions=268437504&scope=bot%20applications.commands"
+	if invite.URL != want {
+		t.Fatalf("url = %s\nwant  %s", invite.URL, want)
+	}
+	if strings.Join(invite.PermissionNames, ",") != "SendMessages,ManageRoles" {
+		t.Fatalf("permission names = %v", invite.PermissionNames)
+	}
+}
+
+func TestBuildInviteURLValidates(t *testing.T) {
+	mask, _ := parsePermissionList([]string{"send_messages"})
+	cases := []struct {
+		scopes []string
+		want   string
+	}{
+		{[]string{"bot", "aplications.commands"}, "unknown OAuth2 scope"},
+		{[]string{"applications.commands"}, "only apply with the bot scope"},
+		{[]string{"bot", "identify"}, "redirect URI"},
+	}
+	for _, tc := range cases {
+		_, err := buildInviteURL("123456789012345678", tc.scopes, mask, "", false, "")
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("scopes %v: got %v, want %q", tc.scopes, err, tc.want)
+		}
+	}
+	invite, err := buildInviteURL("123456789012345678", []string{"bot"}, 0, "987654321098765432", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(invite.URL, "guild_id=987654321098765432") || !strings.Contains(invite.URL, "disable_guild_select=true") {
+		t.Fatalf("guild not preselected: %s", invite.URL)
+	}
+}
+
+func TestAppGetShowsEndpointAndTeam(t *testing.T) {
+	apps := &fakeApplicationService{app: &types.Application{
+		ID:                      "app-1",
+		Name:                    "arc",
+		Flags:                   types.ApplicationFlagGatewayMessageContentLimited,
+		InteractionsEndpointURL: "https://bot.example.com/interactions",
+		InstallParams:           &types.ApplicationInstallParams{Scopes: []string{"bot"}, Permissions: "2048"},
+		Team:                    &types.Team{ID: "t-1", Name: "Arc Labs"},
+	}}
+	hookBot(t, testConfig(), &fakeBotClient{appSvc: apps})
+
+	cmd := NewRootCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"app", "get", "--output", "table"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("app get: %v", err)
+	}
+	for _, want := range []string{"https://bot.example.com/interactions", "team Arc Labs (t-1)", "GatewayMessageContentLimited", "bot [SendMessages]"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestAppUpdateSendsOnlyChangedFields(t *testing.T) {
+	apps := &fakeApplicationService{}
+	hookBot(t, testConfig(), &fakeBotClient{appSvc: apps})
+
+	cmd := NewRootCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"app", "update", "--interactions-endpoint-url", "https://bot.example.com/interactions", "--description", ""})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("app update: %v", err)
+	}
+	if len(apps.edits) != 1 {
+		t.Fatalf("expected one edit, got %d", len(apps.edits))
+	}
+	edit := apps.edits[0]
+	if edit.InteractionsEndpointURL == nil || *edit.InteractionsEndpointURL != "https://bot.example.com/interactions" {
+		t.Fatalf("endpoint not sent: %+v", edit)
+	}
+	if edit.Description == nil || *edit.Description != "" {
+		t.Fatalf("empty description should clear it: %+v", edit)
+	}
+	if edit.Tags != nil || edit.InstallParams != nil {
+		t.Fatalf("unchanged fields sent: %+v", edit)
+	}
+
+	cmd = NewRootCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"app", "update"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "nothing to modify") {
+		t.Fatalf("expected nothing-to-modify error, got %v", err)
+	}
+}
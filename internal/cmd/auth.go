@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"crypto/subtle"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// serverAuthConfig protects every server path except /interactions, which
+// Discord calls without credentials and is guarded by signature checks.
+type serverAuthConfig struct {
+	BearerTokens   []string `yaml:"bearer_tokens"`
+	BearerTokenEnv string   `yaml:"bearer_token_env"`
+	ClientCA       string   `yaml:"client_ca"`
+	PublicPaths    []string `yaml:"public_paths"`
+}
+
+func (c serverAuthConfig) enabled() bool {
+	return len(c.tokens()) > 0 || c.ClientCA != ""
+}
+
+func (c serverAuthConfig) tokens() []string {
+	var tokens []string
+	for _, t := range c.BearerTokens {
+		if t = strings.TrimSpace(t); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	if c.BearerTokenEnv != "" {
+		if t := strings.TrimSpace(os.Getenv(c.BearerTokenEnv)); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// loadClientCAs reads the PEM bundle used to verify mTLS client certificates.
+func (c serverAuthConfig) loadClientCAs() (*x509.CertPool, error) {
+	data, err := os.ReadFile(c.ClientCA)
+	if err != nil {
+		return nil, fmt.Errorf("read client ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("client ca %s contains no PEM certificates", c.ClientCA)
+	}
+	return pool, nil
+}
+
+func validateServerAuth(cfg serverConfig) error {
+	if cfg.Auth.ClientCA != "" && !cfg.tlsEnabled() {
+		return errors.New("server.auth.client_ca requires TLS (server.tls_cert/tls_key or server.acme_domain)")
+	}
+	if cfg.Auth.BearerTokenEnv != "" && len(cfg.Auth.tokens()) == 0 && cfg.Auth.ClientCA == "" {
+		return fmt.Errorf("server.auth.bearer_token_env %s is empty", cfg.Auth.BearerTokenEnv)
+	}
+	return nil
+}
+
+// authMiddleware admits requests with a configured bearer token or a verified
+// client certificate. With no credentials configured every request is denied,
+// so only /interactions stays reachable by default.
+type authMiddleware struct {
+	next        http.Handler
+	tokens      [][]byte
+	mtls        bool
+	publicPaths map[string]bool
+}
+
+func newAuthMiddleware(next http.Handler, cfg serverAuthConfig) *authMiddleware {
+	m := &authMiddleware{next: next, mtls: cfg.ClientCA != "", publicPaths: map[string]bool{}}
+	for _, t := range cfg.tokens() {
+		m.tokens = append(m.tokens, []byte(t))
+	}
+	for _, p := range cfg.PublicPaths {
+		if p = strings.TrimSpace(p); p != "" {
+			m.publicPaths[p] = true
+		}
+	}
+	return m
+}
+
+func (m *authMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.publicPaths[r.URL.Path] || m.authorized(r) {
+		m.next.ServeHTTP(w, r)
+		return
+	}
+	if len(m.tokens) == 0 && !m.mtls {
+		http.Error(w, "forbidden: configure server.auth to enable this endpoint", http.StatusForbidden)
+		return
+	}
+	if len(m.tokens) > 0 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="arc-discord"`)
+	}
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
+
+func (m *authMiddleware) authorized(r *http.Request) bool {
+	if m.mtls && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return true
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	presented := []byte(strings.TrimSpace(token))
+	for _, t := range m.tokens {
+		if subtle.ConstantTimeCompare(presented, t) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	request := func(h http.Handler, path, authz string, mutate func(*http.Request)) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authz != "" {
+			req.Header.Set("Authorization", authz)
+		}
+		if mutate != nil {
+			mutate(req)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	closed := newAuthMiddleware(ok, serverAuthConfig{})
+	if code := request(closed, "/metrics", "Bearer anything", nil); code != http.StatusForbidden {
+		t.Fatalf("expected 403 without auth config, got %d", code)
+	}
+
+	t.Setenv("ADMIN_TOKEN", "env-secret")
+	bearer := newAuthMiddleware(ok, serverAuthConfig{
+		BearerTokens:   []string{"static-secret"},
+		BearerTokenEnv: "ADMIN_TOKEN",
+		PublicPaths:    []string{"/healthz"},
+	})
+	cases := []struct {
+		path, authz string
+		want        int
+	}{
+		{"/metrics", "", http.StatusUnauthorized},
+		{"/metrics", "Bearer wrong", http.StatusUnauthorized},
+		{"/metrics", "Basic static-secret", http.StatusUnauthorized},
+		{"/metrics", "Bearer static-secret", http.StatusOK},
+		{"/metrics", "bearer env-secret", http.StatusOK},
+		{"/healthz", "", http.StatusOK},
+	}
+	for _, tc := range cases {
+		if code := request(bearer, tc.path, tc.authz, nil); code != tc.want {
+			t.Fatalf("%s with %q: got %d, want %d", tc.path, tc.authz, code, tc.want)
+		}
+	}
+
+	mtls := newAuthMiddleware(ok, serverAuthConfig{ClientCA: "ca.pem"})
+	if code := request(mtls, "/metrics", "", nil); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without client cert, got %d", code)
+	}
+	verified := func(r *http.Request) {
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+	}
+	if code := request(mtls, "/metrics", "", verified); code != http.StatusOK {
+		t.Fatalf("expected 200 with verified client cert, got %d", code)
+	}
+}
+
+func TestValidateServerAuth(t *testing.T) {
+	if err := validateServerAuth(serverConfig{Auth: serverAuthConfig{ClientCA: "ca.pem"}}); err == nil {
+		t.Fatal("expected error for client_ca without TLS")
+	}
+	if err := validateServerAuth(serverConfig{ACMEDomain: "bot.example.com", Auth: serverAuthConfig{ClientCA: "ca.pem"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := validateServerAuth(serverConfig{Auth: serverAuthConfig{BearerTokenEnv: "ARC_UNSET_TOKEN_FOR_TEST"}}); err == nil {
+		t.Fatal("expected error for empty bearer_token_env")
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// Match ranks for filterAutocompleteChoices; lower sorts first.
+const (
+	matchPrefix = iota
+	matchWordPrefix
+	matchSubstring
+	matchFuzzy
+	matchNone
+)
+
+// filterAutocompleteChoices keeps the choices whose name matches what the
+// user typed, best matches first, capped at Discord's 25 choices. An empty
+// value keeps every choice in order.
+func filterAutocompleteChoices(choices []types.AutocompleteChoice, value string) []types.AutocompleteChoice {
+	value = strings.ToLower(strings.TrimSpace(value))
+	type ranked struct {
+		choice types.AutocompleteChoice
+		rank   int
+	}
+	matches := make([]ranked, 0, len(choices))
+	for _, choice := range choices {
+		if rank := matchRank(strings.ToLower(choice.Name), value); rank != matchNone {
+			matches = append(matches, ranked{choice: choice, rank: rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].rank < matches[j].rank })
+	out := make([]types.AutocompleteChoice, 0, min(len(matches), maxAutocompleteChoices))
+	for _, m := range matches[:min(len(matches), maxAutocompleteChoices)] {
+		out = append(out, m.choice)
+	}
+	return out
+}
+
+// staticAutocompleteChoices filters configured choices by the typed value.
+// Discord rejects an empty result, so when nothing matches the first 25
+// choices are offered unfiltered.
+func staticAutocompleteChoices(choices []types.AutocompleteChoice, value string) []types.AutocompleteChoice {
+	if filtered := filterAutocompleteChoices(choices, value); len(filtered) > 0 {
+		return filtered
+	}
+	return choices[:min(len(choices), maxAutocompleteChoices)]
+}
+
+// matchRank scores name against the lowercased query: a prefix, the start of
+// a word, anywhere, or its characters in order ("fl" matches "fix-login").
+func matchRank(name, query string) int {
+	switch {
+	case strings.HasPrefix(name, query):
+		return matchPrefix
+	case strings.Contains(name, query):
+		for i := strings.Index(name, query); i >= 0; {
+			if strings.ContainsRune(" -_/.:", rune(name[i-1])) {
+				return matchWordPrefix
+			}
+			next := strings.Index(name[i+1:], query)
+			if next < 0 {
+				break
+			}
+			i += next + 1
+		}
+		return matchSubstring
+	case isSubsequence(name, query):
+		return matchFuzzy
+	default:
+		return matchNone
+	}
+}
+
+func isSubsequence(s, sub string) bool {
+	rest := []rune(sub)
+	for _, r := range s {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
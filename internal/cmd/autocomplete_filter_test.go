@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func choiceNames(choices []types.AutocompleteChoice) []string {
+	names := make([]string, 0, len(choices))
+	for _, c := range choices {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestFilterAutocompleteChoicesRanksMatches(t *testing.T) {
+	var choices []types.AutocompleteChoice
+	for _, name := range []string{"Fix login", "hotfix", "staging", "feature/fix-logs", "Final"} {
+		choices = append(choices, types.AutocompleteChoice{Name: name, Value: name})
+	}
+	got := choiceNames(filterAutocompleteChoices(choices, "fix"))
+	want := []string{"Fix login", "feature/fix-logs", "hotfix"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	if got := choiceNames(filterAutocompleteChoices(choices, "stg")); len(got) != 1 || got[0] != "staging" {
+		t.Fatalf("expected fuzzy match on staging, got %v", got)
+	}
+	if got := filterAutocompleteChoices(choices, ""); len(got) != len(choices) {
+		t.Fatalf("empty value should keep all choices, got %d", len(got))
+	}
+}
+
+func TestDispatchHandlerAutocompleteFiltersByFocusedValue(t *testing.T) {
+	var choices []types.AutocompleteChoice
+	for i := 0; i < 40; i++ {
+		name := "session-" + strconv.Itoa(i)
+		choices = append(choices, types.AutocompleteChoice{Name: name, Value: name})
+	}
+	binding := handlerBinding{Kind: handlerKindAutocomplete, Key: "session", AutocompleteChoices: choices}
+	handler := dispatchHandler(binding, 0, nil)
+
+	resp, err := handler(context.Background(), autocompleteInteraction("session-3"))
+	if err != nil {
+		t.Fatalf("autocomplete handler error: %v", err)
+	}
+	if got := choiceNames(resp.Data.Choices); len(got) != 13 || got[0] != "session-3" || got[12] != "session-23" {
+		t.Fatalf("unexpected filtered choices %v", got)
+	}
+
+	resp, err = handler(context.Background(), autocompleteInteraction("zzz"))
+	if err != nil {
+		t.Fatalf("autocomplete handler error: %v", err)
+	}
+	if len(resp.Data.Choices) != maxAutocompleteChoices {
+		t.Fatalf("expected %d unfiltered choices without a match, got %d", maxAutocompleteChoices, len(resp.Data.Choices))
+	}
+}
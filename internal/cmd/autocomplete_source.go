@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+const (
+	// Discord drops autocomplete responses after 3 seconds, so sources get
+	// less than that to answer.
+	defaultAutocompleteTimeout = 2 * time.Second
+	maxAutocompleteTimeout     = 2500 * time.Millisecond
+	maxAutocompleteChoices     = 25
+	maxAutocompleteChoiceLen   = 100
+	maxAutocompleteScan        = 1000
+)
+
+// autocompleteQuery is what a source receives: the exec source reads it as
+// JSON on stdin, the URL source as query parameters.
+type autocompleteQuery struct {
+	Command string `json:"command"`
+	Option  string `json:"option"`
+	Value   string `json:"value"`
+	UserID  string `json:"user_id,omitempty"`
+	GuildID string `json:"guild_id,omitempty"`
+}
+
+func newAutocompleteQuery(i *types.Interaction) autocompleteQuery {
+	q := autocompleteQuery{GuildID: i.GuildID}
+	if i.Data != nil {
+		q.Command = i.Data.Name
+		if opt := i.Data.FocusedOption(); opt != nil {
+			q.Option = opt.Name
+			if opt.Value != nil {
+				q.Value = fmt.Sprint(opt.Value)
+			}
+		}
+	}
+	switch {
+	case i.Member != nil && i.Member.User != nil:
+		q.UserID = i.Member.User.ID
+	case i.User != nil:
+		q.UserID = i.User.ID
+	}
+	return q
+}
+
+// suggestionStore is the subset of the go-redis client used by redis_key
+// sources.
+type suggestionStore interface {
+	Type(ctx context.Context, key string) *redis.StatusCmd
+	LRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd
+	SMembers(ctx context.Context, key string) *redis.StringSliceCmd
+	ZRevRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd
+}
+
+// newSuggestionStore opens a client on the configured Redis for redis_key
+// sources. go-redis connects lazily, so servers without such sources never
+// dial it.
+func newSuggestionStore(cfg redisConfig) (*redis.Client, error) {
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := broker.RedisOptions(broker.Config{Addr: cfg.Addr, DB: cfg.DB, Username: cfg.Username, Password: cfg.Password, TLS: tlsConfig})
+	if err != nil {
+		return nil, err
+	}
+	return redis.NewClient(opts), nil
+}
+
+func (s *autocompleteSource) validate() error {
+	set := 0
+	for _, v := range []string{s.Exec, s.URL, s.RedisKey} {
+		if strings.TrimSpace(v) != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("source must set exactly one of exec, url, or redis_key")
+	}
+	if s.URL != "" {
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("source url %q must be an absolute http(s) URL", s.URL)
+		}
+	}
+	if s.Timeout < 0 || s.Timeout > maxAutocompleteTimeout {
+		return fmt.Errorf("source timeout must be at most %s", maxAutocompleteTimeout)
+	}
+	return nil
+}
+
+func (s *autocompleteSource) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return defaultAutocompleteTimeout
+}
+
+// fetch queries the source for choices matching q.
+func (s *autocompleteSource) fetch(ctx context.Context, store suggestionStore, q autocompleteQuery) ([]types.AutocompleteChoice, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout())
+	defer cancel()
+
+	var (
+		out []byte
+		err error
+	)
+	switch {
+	case s.Exec != "":
+		out, err = s.fetchExec(ctx, q)
+	case s.URL != "":
+		out, err = s.fetchURL(ctx, q)
+	default:
+		if store == nil {
+			return nil, errors.New("redis_key source needs a redis connection")
+		}
+		return s.fetchRedis(ctx, store, q)
+	}
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("autocomplete source timed out after %s", s.timeout())
+		}
+		return nil, err
+	}
+	return parseAutocompleteChoices(out)
+}
+
+func (s *autocompleteSource) fetchExec(ctx context.Context, q autocompleteQuery) ([]byte, error) {
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return nil, err
+	}
+	proc := shellCommand(ctx, s.Exec)
+	proc.Stdin = bytes.NewReader(payload)
+	proc.Env = append(os.Environ(),
+		"VIBE_AUTOCOMPLETE_COMMAND="+q.Command,
+		"VIBE_AUTOCOMPLETE_OPTION="+q.Option,
+		"VIBE_AUTOCOMPLETE_VALUE="+q.Value,
+	)
+	var stdout bytes.Buffer
+	proc.Stdout = &limitedWriter{w: &stdout, n: maxAgentReplyBytes}
+	if err := proc.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.Exec, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func (s *autocompleteSource) fetchURL(ctx context.Context, q autocompleteQuery) ([]byte, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	params := u.Query()
+	params.Set("q", q.Value)
+	params.Set("command", q.Command)
+	params.Set("option", q.Option)
+	if q.UserID != "" {
+		params.Set("user_id", q.UserID)
+	}
+	if q.GuildID != "" {
+		params.Set("guild_id", q.GuildID)
+	}
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("autocomplete source returned %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxAgentReplyBytes))
+}
+
+// fetchRedis reads a list (in order), sorted set (highest score first), or
+// set (sorted) and keeps members matching the typed value.
+func (s *autocompleteSource) fetchRedis(ctx context.Context, store suggestionStore, q autocompleteQuery) ([]types.AutocompleteChoice, error) {
+	kind, err := store.Type(ctx, s.RedisKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis type %s: %w", s.RedisKey, err)
+	}
+	var members []string
+	switch kind {
+	case "none":
+		return nil, nil
+	case "list":
+		members, err = store.LRange(ctx, s.RedisKey, 0, maxAutocompleteScan-1).Result()
+	case "zset":
+		members, err = store.ZRevRange(ctx, s.RedisKey, 0, maxAutocompleteScan-1).Result()
+	case "set":
+		members, err = store.SMembers(ctx, s.RedisKey).Result()
+		sort.Strings(members)
+	default:
+		return nil, fmt.Errorf("redis key %s is a %s; want a list, set, or zset", s.RedisKey, kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis read %s: %w", s.RedisKey, err)
+	}
+	choices := make([]types.AutocompleteChoice, 0, len(members))
+	for _, member := range members {
+		if choice, ok := newAutocompleteChoice(member, member); ok {
+			choices = append(choices, choice)
+		}
+	}
+	return filterAutocompleteChoices(choices, q.Value), nil
+}
+
+// parseAutocompleteChoices reads a JSON array of strings or {name, value}
+// objects, or else one choice per non-empty line. Results are capped at
+// Discord's 25 choices.
+func parseAutocompleteChoices(out []byte) ([]types.AutocompleteChoice, error) {
+	trimmed := bytes.TrimSpace(out)
+	var choices []types.AutocompleteChoice
+	add := func(name string, value interface{}) {
+		if len(choices) < maxAutocompleteChoices {
+			if choice, ok := newAutocompleteChoice(name, value); ok {
+				choices = append(choices, choice)
+			}
+		}
+	}
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		for _, line := range strings.Split(string(trimmed), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				add(line, line)
+			}
+		}
+		return choices, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(trimmed, &raw); err != nil {
+		return nil, fmt.Errorf("decode autocomplete choices: %w", err)
+	}
+	for _, item := range raw {
+		var s string
+		if err := json.Unmarshal(item, &s); err == nil {
+			add(s, s)
+			continue
+		}
+		var entry struct {
+			Name  string      `json:"name"`
+			Value interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(item, &entry); err != nil {
+			return nil, fmt.Errorf("decode autocomplete choice %s: %w", item, err)
+		}
+		if entry.Value == nil {
+			entry.Value = entry.Name
+		}
+		add(entry.Name, entry.Value)
+	}
+	return choices, nil
+}
+
+// newAutocompleteChoice clamps name and string values to Discord's 100
+// character limit and drops entries that cannot be sent.
+func newAutocompleteChoice(name string, value interface{}) (types.AutocompleteChoice, bool) {
+	if s, ok := value.(string); ok {
+		value = truncateRunes(s, maxAutocompleteChoiceLen)
+	}
+	choice := types.AutocompleteChoice{Name: truncateRunes(name, maxAutocompleteChoiceLen), Value: value}
+	return choice, choice.Validate() == nil
+}
+
+func truncateRunes(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
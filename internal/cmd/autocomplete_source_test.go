@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func autocompleteInteraction(value string) *types.Interaction {
+	return &types.Interaction{
+		Type:    types.InteractionTypeApplicationCommandAutocomplete,
+		GuildID: "g1",
+		Member:  &types.Member{User: &types.User{ID: "u1"}},
+		Data: &types.InteractionData{Name: "session", Options: []types.ApplicationCommandOption{
+			{Name: "id", Type: types.CommandOptionString, Value: value, Focused: true},
+		}},
+	}
+}
+
+func TestAutocompleteExecSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	binding := handlerBinding{Kind: handlerKindAutocomplete, Key: "session", Route: handlerRoute{
+		Source: &autocompleteSource{Exec: `printf 'main\n%s-fix\n' "$VIBE_AUTOCOMPLETE_VALUE"`},
+	}}
+	resp, err := dispatchHandler(binding, 0, nil)(context.Background(), autocompleteInteraction("ab"))
+	if err != nil {
+		t.Fatalf("autocomplete handler error: %v", err)
+	}
+	choices := resp.Data.Choices
+	if len(choices) != 2 || choices[1].Name != "ab-fix" || choices[1].Value != "ab-fix" {
+		t.Fatalf("unexpected choices %+v", choices)
+	}
+}
+
+func TestAutocompleteURLSource(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`["TICKET-1", {"name": "TICKET-2: Fix login", "value": "TICKET-2"}, {"name": "Count", "value": 3}]`))
+	}))
+	defer srv.Close()
+
+	src := &autocompleteSource{URL: srv.URL + "/suggest?project=arc"}
+	choices, err := src.fetch(context.Background(), nil, newAutocompleteQuery(autocompleteInteraction("TIC")))
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	for _, want := range []string{"project=arc", "q=TIC", "option=id", "user_id=u1", "guild_id=g1"} {
+		if !strings.Contains(query, want) {
+			t.Fatalf("query %q missing %s", query, want)
+		}
+	}
+	if len(choices) != 3 || choices[1].Value != "TICKET-2" || choices[2].Value != float64(3) {
+		t.Fatalf("unexpected choices %+v", choices)
+	}
+}
+
+func TestAutocompleteURLSourceTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	src := &autocompleteSource{URL: srv.URL, Timeout: 20 * time.Millisecond}
+	if _, err := src.fetch(context.Background(), nil, autocompleteQuery{}); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+
+	// Static choices stand in for a failed source.
+	binding := handlerBinding{Kind: handlerKindAutocomplete, Key: "ticket", Route: handlerRoute{Source: src},
+		AutocompleteChoices: []types.AutocompleteChoice{{Name: "recent", Value: "recent"}}}
+	resp, err := dispatchHandler(binding, 0, nil)(context.Background(), autocompleteInteraction(""))
+	if err != nil || resp.Data.Choices[0].Name != "recent" {
+		t.Fatalf("expected fallback choices, got %+v, %v", resp, err)
+	}
+}
+
+type fakeSuggestionStore struct {
+	kind    string
+	members []string
+}
+
+func (f fakeSuggestionStore) Type(ctx context.Context, key string) *redis.StatusCmd {
+	return redis.NewStatusResult(f.kind, nil)
+}
+
+func (f fakeSuggestionStore) LRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd {
+	return redis.NewStringSliceResult(f.members, nil)
+}
+
+func (f fakeSuggestionStore) SMembers(ctx context.Context, key string) *redis.StringSliceCmd {
+	return redis.NewStringSliceResult(f.members, nil)
+}
+
+func (f fakeSuggestionStore) ZRevRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd {
+	return redis.NewStringSliceResult(f.members, nil)
+}
+
+func TestAutocompleteRedisSource(t *testing.T) {
+	src := &autocompleteSource{RedisKey: "sessions:recent"}
+	store := fakeSuggestionStore{kind: "zset", members: []string{"sess-ABC", "sess-xyz", "other-abc"}}
+	choices, err := src.fetch(context.Background(), store, autocompleteQuery{Value: "abc"})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(choices) != 2 || choices[0].Value != "sess-ABC" || choices[1].Value != "other-abc" {
+		t.Fatalf("unexpected choices %+v", choices)
+	}
+
+	if _, err := src.fetch(context.Background(), fakeSuggestionStore{kind: "hash"}, autocompleteQuery{}); err == nil {
+		t.Fatal("expected error for unsupported key type")
+	}
+}
+
+func TestParseAutocompleteChoicesCapsAndClamps(t *testing.T) {
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, strings.Repeat("x", 120))
+	}
+	choices, err := parseAutocompleteChoices([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(choices) != maxAutocompleteChoices || len(choices[0].Name) != maxAutocompleteChoiceLen {
+		t.Fatalf("expected %d clamped choices, got %d (name %d)", maxAutocompleteChoices, len(choices), len(choices[0].Name))
+	}
+	if _, err := parseAutocompleteChoices([]byte(`[{"name": 1}`)); err == nil {
+		t.Fatal("expected error for malformed JSON")
+	}
+}
+
+func TestAutocompleteSourceValidate(t *testing.T) {
+	cases := map[string]*autocompleteSource{
+		"none":     {},
+		"two":      {Exec: "./suggest.sh", RedisKey: "k"},
+		"relative": {URL: "/suggest"},
+		"slow":     {Exec: "./suggest.sh", Timeout: 5 * time.Second},
+	}
+	for name, src := range cases {
+		if err := src.validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+	if err := (&autocompleteSource{URL: "https://tickets.example.com/suggest"}).validate(); err != nil {
+		t.Fatalf("expected valid source: %v", err)
+	}
+}
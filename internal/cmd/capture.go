@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+const defaultCaptureWindow = 10 * time.Minute
+
+// requestCapture is the metadata written next to each captured body.
+type requestCapture struct {
+	ReceivedAt time.Time           `json:"received_at"`
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	Host       string              `json:"host"`
+	RemoteAddr string              `json:"remote_addr"`
+	Headers    map[string][]string `json:"headers"`
+	BodyFile   string              `json:"body_file"`
+	BodyBytes  int                 `json:"body_bytes"`
+	Verify     string              `json:"verify_command,omitempty"`
+}
+
+// captureMiddleware writes every inbound request (headers and raw body) to dir
+// until the capture window closes, then passes requests through untouched.
+type captureMiddleware struct {
+	next     http.Handler
+	dir      string
+	deadline time.Time
+	now      func() time.Time
+	logger   *logger.Logger
+
+	mu      sync.Mutex
+	seq     int
+	expired bool
+}
+
+func newCaptureMiddleware(next http.Handler, dir string, window time.Duration, log *logger.Logger) (*captureMiddleware, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create capture dir: %w", err)
+	}
+	if window <= 0 {
+		window = defaultCaptureWindow
+	}
+	if log == nil {
+		log = logger.Default()
+	}
+	return &captureMiddleware{
+		next:     next,
+		dir:      dir,
+		deadline: time.Now().Add(window),
+		now:      time.Now,
+		logger:   log,
+	}, nil
+}
+
+func (c *captureMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if seq, ok := c.nextSeq(); ok {
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			c.logger.Warn("capture read body failed", "error", err)
+		} else if err := c.write(seq, r, body); err != nil {
+			c.logger.Warn("capture write failed", "error", err)
+		}
+	}
+	c.next.ServeHTTP(w, r)
+}
+
+func (c *captureMiddleware) nextSeq() (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.expired {
+		return 0, false
+	}
+	if c.now().After(c.deadline) {
+		c.expired = true
+		c.logger.Info("request capture window closed", "dir", c.dir, "captured", c.seq)
+		return 0, false
+	}
+	c.seq++
+	return c.seq, true
+}
+
+func (c *captureMiddleware) write(seq int, r *http.Request, body []byte) error {
+	received := c.now().UTC()
+	base := fmt.Sprintf("%s-%04d", received.Format("20060102T150405.000Z"), seq)
+	bodyPath := filepath.Join(c.dir, base+".body")
+	if err := os.WriteFile(bodyPath, body, 0o600); err != nil {
+		return err
+	}
+
+	meta := requestCapture{
+		ReceivedAt: received,
+		Method:     r.Method,
+		URL:        r.URL.String(),
+		Host:       r.Host,
+		RemoteAddr: r.RemoteAddr,
+		Headers:    r.Header.Clone(),
+		BodyFile:   filepath.Base(bodyPath),
+		BodyBytes:  len(body),
+	}
+	if sig, ts := r.Header.Get("X-Signature-Ed25519"), r.Header.Get("X-Signature-Timestamp"); sig != "" && ts != "" {
+		meta.Verify = fmt.Sprintf("arc-discord util verify-signature --timestamp %s --signature %s --body %s", ts, sig, bodyPath)
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, base+".json"), data, 0o600)
+}
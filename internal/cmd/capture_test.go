@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+func TestCaptureMiddlewareWritesRequests(t *testing.T) {
+	dir := t.TempDir()
+	var seen []byte
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	capture, err := newCaptureMiddleware(next, dir, time.Minute, logger.New(logger.InfoLevel, "json", io.Discard))
+	if err != nil {
+		t.Fatalf("newCaptureMiddleware: %v", err)
+	}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	capture.now = func() time.Time { return now }
+	capture.deadline = now.Add(time.Minute)
+
+	body := `{"type":1}` + "\n"
+	req := httptest.NewRequest(http.MethodPost, "/interactions", strings.NewReader(body))
+	req.Header.Set("X-Signature-Ed25519", "abcd")
+	req.Header.Set("X-Signature-Timestamp", "1700000000")
+	capture.ServeHTTP(httptest.NewRecorder(), req)
+
+	if string(seen) != body {
+		t.Fatalf("downstream handler saw %q", seen)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "20240102T030405.000Z-0001.body"))
+	if err != nil {
+		t.Fatalf("read captured body: %v", err)
+	}
+	if !bytes.Equal(raw, []byte(body)) {
+		t.Fatalf("captured body mismatch: %q", raw)
+	}
+	metaRaw, err := os.ReadFile(filepath.Join(dir, "20240102T030405.000Z-0001.json"))
+	if err != nil {
+		t.Fatalf("read capture metadata: %v", err)
+	}
+	var meta requestCapture
+	if err := json.Unmarshal(metaRaw, &meta); err != nil {
+		t.Fatalf("decode metadata: %v", err)
+	}
+	if meta.Headers["X-Signature-Timestamp"][0] != "1700000000" || meta.BodyBytes != len(body) {
+		t.Fatalf("unexpected metadata %+v", meta)
+	}
+	if !strings.Contains(meta.Verify, "--timestamp 1700000000") {
+		t.Fatalf("expected verify command, got %q", meta.Verify)
+	}
+
+	now = now.Add(2 * time.Minute)
+	capture.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/interactions", strings.NewReader(body)))
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("expected capture to stop after window, found %d files", len(entries))
+	}
+}
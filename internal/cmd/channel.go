@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,6 +27,9 @@ func channelCmd(opts *globalOptions) *cobra.Command {
 	cmd.AddCommand(channelGetCmd(opts))
 	cmd.AddCommand(channelHistoryCmd(opts))
 	cmd.AddCommand(channelModifyCmd(opts))
+	cmd.AddCommand(channelForumCmd(opts))
+	cmd.AddCommand(channelFollowCmd(opts))
+	cmd.AddCommand(channelTypingCmd(opts))
 	return cmd
 }
 
@@ -60,7 +67,7 @@ Example:
   arc-discord channel get --channel 1427555325136867393 --config ~/.config/vibe/discord_staging.yaml`,
 	}
 
-	c.Flags().StringVar(&channelID, "channel", "", "Channel ID to inspect")
+	c.Flags().StringVar(&channelID, "channel", "", "Channel ID or #name to inspect")
 
 	return c
 }
@@ -78,6 +85,10 @@ func runChannelGet(cmd *cobra.Command, opts *globalOptions, channelID string, ou
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	ch, err := bot.Channels().GetChannel(ctx, channelID)
 	if err != nil {
@@ -99,33 +110,86 @@ func runChannelGet(cmd *cobra.Command, opts *globalOptions, channelID string, ou
 
 func channelModifyCmd(opts *globalOptions) *cobra.Command {
 	var (
-		channelID string
-		name      string
-		topic     string
-		nsfwFlag  bool
-		rateLimit int
+		channelID       string
+		name            string
+		topic           string
+		nsfwFlag        bool
+		rateLimit       int
+		parentID        string
+		position        int
+		bitrate         int
+		userLimit       int
+		autoArchive     int
+		threadRateLimit int
+		flags           string
+		tagsFile        string
+		clearFields     []string
+		clearTopic      bool
+		clearParent     bool
+		patchPath       string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "modify",
 		Short: "Update channel metadata (topic/name/flags)",
-		Long: `Modify Discord channel properties such as name, topic, NSFW status, and slowmode rate limits.
-At least one field must be specified for the update to succeed.`,
+		Long: `Modify Discord channel properties such as name, topic, NSFW status, slowmode, category placement,
+position, voice settings (bitrate/user limit), thread defaults, channel flags, and forum tags.
+At least one field must be specified for the update to succeed.
+
+Fields are only sent when their flag is passed. Use --clear FIELD (repeatable) to reset a field
+explicitly, e.g. --clear topic (or --clear-topic) removes the topic and --clear parent_id (or
+--clear-parent) moves the channel out of its category. Clearable fields: ` + strings.Join(types.ClearableModifyChannelFields(), ", ") + `.
+
+--flags accepts a comma-separated list of pinned, require_tag, hide_media_download_options or a raw
+integer bitmask. --tags-file expects a JSON array of forum tag objects and replaces available_tags.
+
+--patch FILE applies an RFC 7386 merge patch over the flag-built payload, so scripted changes can
+set any channel field Discord accepts, including explicit nulls.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if channelID == "" {
 				return &arcer.CLIError{Msg: "--channel is required"}
 			}
-			nsfwChanged := cmd.Flags().Changed("nsfw")
-			if name == "" && topic == "" && !nsfwChanged && rateLimit == 0 {
-				return &arcer.CLIError{Msg: "specify at least one field to update (--name/--topic/--nsfw/--rate-limit-per-user)"}
+			if clearTopic {
+				clearFields = append(clearFields, "topic")
+			}
+			if clearParent {
+				clearFields = append(clearFields, "parent_id")
+			}
+			changed := cmd.Flags().Changed
+			input := channelModifyInput{
+				name:        name,
+				topic:       topic,
+				parentID:    parentID,
+				flags:       flags,
+				tagsFile:    tagsFile,
+				clearFields: clearFields,
+				patchPath:   patchPath,
+			}
+			if changed("nsfw") {
+				input.nsfw = &nsfwFlag
+			}
+			if changed("rate-limit-per-user") {
+				input.rateLimitPerUser = &rateLimit
+			}
+			if changed("position") {
+				input.position = &position
+			}
+			if changed("bitrate") {
+				input.bitrate = &bitrate
+			}
+			if changed("user-limit") {
+				input.userLimit = &userLimit
+			}
+			if changed("default-auto-archive") {
+				input.defaultAutoArchive = &autoArchive
+			}
+			if changed("default-thread-rate-limit") {
+				input.defaultThreadRateLimit = &threadRateLimit
+			}
+			if input.empty() {
+				return &arcer.CLIError{Msg: "specify at least one field to update", Hint: "see --help for the supported flags and --clear"}
 			}
-			return runChannelModify(cmd, opts, channelID, channelModifyInput{
-				name:             name,
-				topic:            topic,
-				nsfwSet:          nsfwChanged,
-				nsfw:             nsfwFlag,
-				rateLimitPerUser: rateLimit,
-			})
+			return runChannelModify(cmd, opts, channelID, input)
 		},
 		Example: `Example:
   # Update a channel topic without touching other fields
@@ -141,24 +205,69 @@ Example:
 
 Example:
   # Rename + retitle a channel in one call
-  arc-discord channel modify --channel 1427555325136867393 --name alerts --topic "System alerts"`,
+  arc-discord channel modify --channel 1427555325136867393 --name alerts --topic "System alerts"
+
+Example:
+  # Move a channel into a category at the top
+  arc-discord channel modify --channel 1427555325136867393 --parent 1427555325136860000 --position 0
+
+Example:
+  # Remove the topic and take the channel out of its category
+  arc-discord channel modify --channel 1427555325136867393 --clear-topic --clear-parent
+
+Example:
+  # Configure a forum: require tags, replace the tag list, and archive idle posts after a day
+  arc-discord channel modify --channel $FORUM --flags require_tag --tags-file tags.json --default-auto-archive 1440
+
+Example:
+  # Apply a scripted merge patch (null removes the topic)
+  echo '{"topic": null, "default_sort_order": 0}' > patch.json
+  arc-discord channel modify --channel 1427555325136867393 --patch patch.json`,
 	}
 
-	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID to modify")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID or #name to modify")
 	cmd.Flags().StringVar(&name, "name", "", "New channel name")
 	cmd.Flags().StringVar(&topic, "topic", "", "New channel topic")
 	cmd.Flags().IntVar(&rateLimit, "rate-limit-per-user", 0, "Slowmode rate limit in seconds (0 clears it)")
 	cmd.Flags().BoolVar(&nsfwFlag, "nsfw", false, "Mark channel as NSFW (use --nsfw=false to clear)")
 	cmd.Flags().Lookup("nsfw").NoOptDefVal = "true"
+	cmd.Flags().StringVar(&parentID, "parent", "", "Category ID to move the channel into")
+	cmd.Flags().IntVar(&position, "position", 0, "Sorting position within the category")
+	cmd.Flags().IntVar(&bitrate, "bitrate", 0, "Voice bitrate in bits per second")
+	cmd.Flags().IntVar(&userLimit, "user-limit", 0, "Voice user limit (0 removes the limit)")
+	cmd.Flags().IntVar(&autoArchive, "default-auto-archive", 0, "Default thread auto-archive minutes (60|1440|4320|10080)")
+	cmd.Flags().IntVar(&threadRateLimit, "default-thread-rate-limit", 0, "Default slowmode in seconds for new threads")
+	cmd.Flags().StringVar(&flags, "flags", "", "Channel flags: names (pinned,require_tag,hide_media_download_options) or integer bitmask")
+	cmd.Flags().StringVar(&tagsFile, "tags-file", "", "JSON file with forum tags to set as available_tags")
+	cmd.Flags().StringArrayVar(&clearFields, "clear", nil, "Field to reset explicitly (repeatable, e.g. topic, parent_id)")
+	cmd.Flags().BoolVar(&clearTopic, "clear-topic", false, "Remove the channel topic (same as --clear topic)")
+	cmd.Flags().BoolVar(&clearParent, "clear-parent", false, "Move the channel out of its category (same as --clear parent_id)")
+	cmd.Flags().StringVar(&patchPath, "patch", "", patchFlagUsage)
 	return cmd
 }
 
 type channelModifyInput struct {
-	name             string
-	topic            string
-	nsfwSet          bool
-	nsfw             bool
-	rateLimitPerUser int
+	name                   string
+	topic                  string
+	nsfw                   *bool
+	rateLimitPerUser       *int
+	parentID               string
+	position               *int
+	bitrate                *int
+	userLimit              *int
+	defaultAutoArchive     *int
+	defaultThreadRateLimit *int
+	flags                  string
+	tagsFile               string
+	clearFields            []string
+	patchPath              string
+}
+
+func (in channelModifyInput) empty() bool {
+	return in.name == "" && in.topic == "" && in.nsfw == nil && in.rateLimitPerUser == nil &&
+		in.parentID == "" && in.position == nil && in.bitrate == nil && in.userLimit == nil &&
+		in.defaultAutoArchive == nil && in.defaultThreadRateLimit == nil && in.flags == "" &&
+		in.tagsFile == "" && len(in.clearFields) == 0 && in.patchPath == ""
 }
 
 func runChannelModify(cmd *cobra.Command, opts *globalOptions, channelID string, input channelModifyInput) error {
@@ -166,27 +275,23 @@ func runChannelModify(cmd *cobra.Command, opts *globalOptions, channelID string,
 	if err != nil {
 		return err
 	}
-	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+
+	params, err := buildModifyChannelParams(input)
 	if err != nil {
-		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+		return err
 	}
 
-	params := &types.ModifyChannelParams{}
-	if input.name != "" {
-		params.Name = input.name
-	}
-	if input.topic != "" {
-		params.Topic = input.topic
-	}
-	if input.nsfwSet {
-		params.NSFW = input.nsfw
-	}
-	if input.rateLimitPerUser > 0 {
-		params.RateLimitPerUser = input.rateLimitPerUser
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
 	}
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	if _, err := bot.Channels().ModifyChannel(ctx, channelID, params); err != nil {
 		return (&arcer.CLIError{Msg: "failed to modify channel"}).WithCause(err)
@@ -195,6 +300,114 @@ func runChannelModify(cmd *cobra.Command, opts *globalOptions, channelID string,
 	return nil
 }
 
+func buildModifyChannelParams(input channelModifyInput) (*types.ModifyChannelParams, error) {
+	params := &types.ModifyChannelParams{
+		Name:     input.name,
+		Topic:    input.topic,
+		ParentID: input.parentID,
+	}
+	clear := func(field string) {
+		params.ClearFields = append(params.ClearFields, field)
+	}
+
+	if input.nsfw != nil {
+		if *input.nsfw {
+			params.NSFW = true
+		} else {
+			clear("nsfw")
+		}
+	}
+	setInt := func(value *int, target *int, field string) {
+		if value == nil {
+			return
+		}
+		if *value == 0 {
+			clear(field)
+			return
+		}
+		*target = *value
+	}
+	// Discord rejects a zero bitrate (the minimum is 8000); resetting it is --clear's job.
+	if input.bitrate != nil && *input.bitrate <= 0 {
+		return nil, &arcer.CLIError{Msg: "--bitrate must be positive", Hint: "use --clear bitrate to reset it"}
+	}
+	setInt(input.rateLimitPerUser, &params.RateLimitPerUser, "rate_limit_per_user")
+	// Position 0 is the top of the category and user limit 0 means no limit;
+	// both are sent as literal zeros, not resets.
+	params.Position = input.position
+	params.UserLimit = input.userLimit
+	setInt(input.bitrate, &params.Bitrate, "bitrate")
+	setInt(input.defaultAutoArchive, &params.DefaultAutoArchiveDuration, "default_auto_archive_duration")
+	setInt(input.defaultThreadRateLimit, &params.DefaultThreadRateLimitPerUser, "default_thread_rate_limit_per_user")
+
+	if input.flags != "" {
+		flags, err := parseChannelFlags(input.flags)
+		if err != nil {
+			return nil, err
+		}
+		if flags == 0 {
+			clear("flags")
+		}
+		params.Flags = flags
+	}
+
+	if input.tagsFile != "" {
+		data, err := os.ReadFile(input.tagsFile)
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read tags file %s", input.tagsFile)}).WithCause(err)
+		}
+		var tags []types.ForumTag
+		if err := json.Unmarshal(data, &tags); err != nil {
+			return nil, (&arcer.CLIError{Msg: "tags file must be a JSON array of forum tags"}).WithCause(err)
+		}
+		if len(tags) == 0 {
+			clear("available_tags")
+		}
+		params.AvailableTags = tags
+	}
+
+	for _, field := range input.clearFields {
+		clear(strings.TrimSpace(field))
+	}
+
+	patch, err := loadMergePatch(input.patchPath)
+	if err != nil {
+		return nil, err
+	}
+	params.MergePatch = patch
+
+	if err := params.Validate(); err != nil {
+		return nil, (&arcer.CLIError{Msg: "invalid channel update", Hint: "clearable fields: " + strings.Join(types.ClearableModifyChannelFields(), ", ")}).WithCause(err)
+	}
+	return params, nil
+}
+
+var channelFlagNames = map[string]types.ChannelFlags{
+	"pinned":                      types.ChannelFlagPinned,
+	"require_tag":                 types.ChannelFlagRequireTag,
+	"hide_media_download_options": types.ChannelFlagHideMediaDownloadOptions,
+}
+
+func parseChannelFlags(raw string) (types.ChannelFlags, error) {
+	raw = strings.TrimSpace(raw)
+	if n, err := strconv.ParseUint(raw, 10, 64); err == nil {
+		return types.ChannelFlags(n), nil
+	}
+	var flags types.ChannelFlags
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" || part == "none" {
+			continue
+		}
+		flag, ok := channelFlagNames[part]
+		if !ok {
+			return 0, &arcer.CLIError{Msg: fmt.Sprintf("unknown channel flag %q", part), Hint: "valid flags: pinned, require_tag, hide_media_download_options"}
+		}
+		flags |= flag
+	}
+	return flags, nil
+}
+
 func channelTypeName(t types.ChannelType) string {
 	switch t {
 	case types.ChannelTypeGuildText:
@@ -223,6 +436,8 @@ func channelTypeName(t types.ChannelType) string {
 		return "guild_directory"
 	case types.ChannelTypeGuildForum:
 		return "guild_forum"
+	case types.ChannelTypeGuildMedia:
+		return "guild_media"
 	default:
 		return fmt.Sprintf("unknown(%d)", t)
 	}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func channelFollowCmd(opts *globalOptions) *cobra.Command {
+	var channelRef, targetRef, guildRef, reason string
+	cmd := &cobra.Command{
+		Use:   "follow",
+		Short: "Subscribe a channel to an announcement channel",
+		Long: `Follow an announcement (news) channel so messages published there are crossposted into
+--target. Discord creates a webhook in the target channel to deliver them, so the bot needs
+MANAGE_WEBHOOKS in --target. --guild resolves #names for both channels; pass the announcement
+channel by ID when it lives in another server.`,
+		Example: `Example:
+  arc-discord channel follow --channel 1427555325136867393 --target "#upstream-news"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || targetRef == "" {
+				return &arcer.CLIError{Msg: "--channel and --target are required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, ctx, cancel, channelID, err := channelTarget(cmd, opts, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			targetID, err := newNameResolver(bot, cfg).ChannelID(ctx, targetRef, guildRef)
+			if err != nil {
+				return err
+			}
+			followed, err := bot.Channels().FollowAnnouncementChannel(ctx, channelID, targetID, reason)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to follow channel %s into %s", channelID, targetID), Hint: "--channel must be an announcement channel and the bot needs MANAGE_WEBHOOKS in --target"}).WithCause(err)
+			}
+			table := keyValueTable(map[string]string{
+				"channel_id": followed.ChannelID,
+				"target_id":  targetID,
+				"webhook_id": followed.WebhookID,
+			})
+			return renderOutput(cmd, opts.output, followed, table)
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Announcement channel ID or #name to follow")
+	cmd.Flags().StringVar(&targetRef, "target", "", "Channel ID or #name that receives the announcements")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve channel names")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason")
+	return cmd
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestChannelFollow(t *testing.T) {
+	channels := &fakeChannelService{}
+	hookBot(t, testConfig(), &fakeBotClient{channelSvc: channels})
+
+	var out bytes.Buffer
+	cmd := channelFollowCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--channel", "1427555325136867393", "--target", "1427555325136867400"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if channels.followed != [2]string{"1427555325136867393", "1427555325136867400"} {
+		t.Fatalf("unexpected follow call %v", channels.followed)
+	}
+	if !strings.Contains(out.String(), "wh-1") {
+		t.Fatalf("expected webhook id in output, got %q", out.String())
+	}
+}
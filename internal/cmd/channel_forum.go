@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func channelForumCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "forum",
+		Short: "Create posts and manage tags in forum and media channels",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(channelForumPostCmd(opts))
+	cmd.AddCommand(channelForumTagsCmd(opts))
+	return cmd
+}
+
+func channelForumPostCmd(opts *globalOptions) *cobra.Command {
+	var (
+		channelRef string
+		guildRef   string
+		tagRefs    []string
+		params     types.ForumThreadParams
+	)
+	cmd := &cobra.Command{
+		Use:   "post",
+		Short: "Create a forum post with the bot token",
+		Long: `Create a post (a thread with an opening message) in a forum or media channel. Unlike
+"webhook send --thread-name", the bot token path can apply tags and set the post's auto-archive
+and slowmode. Tags are given by name or ID; forums that require a tag reject untagged posts.`,
+		Example: `Example:
+  arc-discord channel forum post --channel "#releases" --name "v1.4.0" --content "Release notes..." --tag release
+
+Example:
+  arc-discord channel forum post --channel $FORUM --name "Incident 42" --content "Tracking" --tag incident --tag sev2 --auto-archive 10080`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || params.Name == "" || params.Message.Content == "" {
+				return &arcer.CLIError{Msg: "--channel, --name, and --content are required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, ctx, cancel, forum, err := forumTarget(cmd, opts, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			channelID := forum.ID
+			tags, err := resolveForumTags(forum, tagRefs)
+			if err != nil {
+				return err
+			}
+			params.AppliedTags = tags
+
+			thread, err := bot.Channels().StartThreadInForum(ctx, channelID, &params)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to create post in %s", channelID), Hint: "the bot needs Send Messages in the forum"}).WithCause(err)
+			}
+			guildID := thread.GuildID
+			if guildID == "" {
+				guildID = forum.GuildID
+			}
+			table := keyValueTable(map[string]string{
+				"id":        thread.ID,
+				"name":      thread.Name,
+				"parent_id": channelID,
+				"tags":      strings.Join(thread.AppliedTags, ","),
+				"url":       fmt.Sprintf("https://discord.com/channels/%s/%s", guildID, thread.ID),
+			})
+			return renderOutput(cmd, opts.output, thread, table)
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Forum channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&params.Name, "name", "", "Post title (1-100 characters)")
+	cmd.Flags().StringVar(&params.Message.Content, "content", "", "Opening message")
+	cmd.Flags().StringArrayVar(&tagRefs, "tag", nil, "Tag name or ID to apply (repeatable, up to 5)")
+	cmd.Flags().IntVar(&params.AutoArchiveDuration, "auto-archive", 0, "Minutes of inactivity before the post archives (60, 1440, 4320, 10080)")
+	cmd.Flags().IntVar(&params.RateLimitPerUser, "slowmode", 0, "Seconds between messages per user in the post")
+	cmd.Flags().StringVar(&params.AuditLogReason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+// resolveForumTags maps tag names (case-insensitive) or IDs to the forum's
+// tag IDs, and enforces the forum's require-tag setting up front.
+func resolveForumTags(forum *types.Channel, refs []string) ([]string, error) {
+	names := make([]string, 0, len(forum.AvailableTags))
+	for _, tag := range forum.AvailableTags {
+		names = append(names, tag.Name)
+	}
+	if len(refs) == 0 {
+		if forum.Flags&types.ChannelFlagRequireTag != 0 {
+			return nil, &arcer.CLIError{Msg: fmt.Sprintf("forum %s requires a tag", forum.ID), Hint: "available tags: " + strings.Join(names, ", ")}
+		}
+		return nil, nil
+	}
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		var match string
+		for _, tag := range forum.AvailableTags {
+			if tag.ID == ref || strings.EqualFold(tag.Name, ref) {
+				match = tag.ID
+				break
+			}
+		}
+		if match == "" {
+			return nil, &arcer.CLIError{Msg: fmt.Sprintf("tag %q not found in forum %s", ref, forum.ID), Hint: "available tags: " + strings.Join(names, ", ")}
+		}
+		ids = append(ids, match)
+	}
+	return ids, nil
+}
+
+// forumTarget resolves channelRef like channelTarget and fetches the channel,
+// failing unless it is a forum or media channel.
+func forumTarget(cmd *cobra.Command, opts *globalOptions, channelRef, guildRef string) (botClient, context.Context, context.CancelFunc, *types.Channel, error) {
+	bot, ctx, cancel, channelID, err := channelTarget(cmd, opts, channelRef, guildRef)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	forum, err := bot.Channels().GetChannel(ctx, channelID)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch channel %s", channelID)}).WithCause(err)
+	}
+	if forum.Type != types.ChannelTypeGuildForum && forum.Type != types.ChannelTypeGuildMedia {
+		cancel()
+		return nil, nil, nil, nil, &arcer.CLIError{Msg: fmt.Sprintf("channel %s is a %s channel, not a forum", channelID, channelTypeName(forum.Type)), Hint: "use 'message send' for text channels"}
+	}
+	if forum.ID == "" {
+		forum.ID = channelID
+	}
+	return bot, ctx, cancel, forum, nil
+}
+
+func channelForumTagsCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "List, create, and delete forum tags",
+		Long: `Manage the tags available on a forum or media channel (at most 20, names up to 20
+characters). Creating and deleting tags requires MANAGE_CHANNELS. Deleting a tag removes it from
+every post that has it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(channelForumTagsListCmd(opts))
+	cmd.AddCommand(channelForumTagsCreateCmd(opts))
+	cmd.AddCommand(channelForumTagsDeleteCmd(opts))
+	return cmd
+}
+
+func channelForumTagsListCmd(opts *globalOptions) *cobra.Command {
+	var channelRef, guildRef string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List a forum's available tags",
+		Example: `Example:
+  arc-discord channel forum tags list --channel "#releases"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" {
+				return &arcer.CLIError{Msg: "--channel is required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			_, _, cancel, forum, err := forumTarget(cmd, opts, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			cancel()
+			return renderForumTags(cmd, opts, forum.AvailableTags)
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Forum channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	return cmd
+}
+
+func channelForumTagsCreateCmd(opts *globalOptions) *cobra.Command {
+	var (
+		channelRef string
+		guildRef   string
+		emoji      string
+		reason     string
+		tag        types.ForumTag
+	)
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Add a tag to a forum",
+		Example: `Example:
+  arc-discord channel forum tags create --channel "#releases" --name hotfix --emoji 🔥
+
+Example:
+  arc-discord channel forum tags create --channel $FORUM --name triaged --moderated`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || tag.Name == "" {
+				return &arcer.CLIError{Msg: "--channel and --name are required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			// Custom emoji are referenced by ID alone.
+			if _, id, ok := strings.Cut(emoji, ":"); ok {
+				tag.EmojiID = id
+			} else {
+				tag.EmojiName = emoji
+			}
+			bot, ctx, cancel, forum, err := forumTarget(cmd, opts, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			for _, existing := range forum.AvailableTags {
+				if strings.EqualFold(existing.Name, tag.Name) {
+					return &arcer.CLIError{Msg: fmt.Sprintf("forum %s already has a tag named %q", forum.ID, existing.Name)}
+				}
+			}
+			tags := append(append([]types.ForumTag{}, forum.AvailableTags...), tag)
+			updated, err := bot.Channels().ModifyChannel(ctx, forum.ID, &types.ModifyChannelParams{AvailableTags: tags, AuditLogReason: reason})
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to add tag %q to forum %s", tag.Name, forum.ID), Hint: "forums allow at most 20 tags; the bot needs MANAGE_CHANNELS"}).WithCause(err)
+			}
+			return renderForumTags(cmd, opts, updated.AvailableTags)
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Forum channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&tag.Name, "name", "", "Tag name (1-20 characters)")
+	cmd.Flags().StringVar(&emoji, "emoji", "", "Tag emoji (unicode or name:id for a custom emoji)")
+	cmd.Flags().BoolVar(&tag.Moderated, "moderated", false, "Only members with MANAGE_THREADS can apply the tag")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+func channelForumTagsDeleteCmd(opts *globalOptions) *cobra.Command {
+	var channelRef, guildRef, tagRef, reason string
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Remove a tag from a forum",
+		Example: `Example:
+  arc-discord channel forum tags delete --channel "#releases" --tag hotfix`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || tagRef == "" {
+				return &arcer.CLIError{Msg: "--channel and --tag are required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, ctx, cancel, forum, err := forumTarget(cmd, opts, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			ids, err := resolveForumTags(forum, []string{tagRef})
+			if err != nil {
+				return err
+			}
+			params := &types.ModifyChannelParams{AuditLogReason: reason}
+			for _, tag := range forum.AvailableTags {
+				if tag.ID != ids[0] {
+					params.AvailableTags = append(params.AvailableTags, tag)
+				}
+			}
+			if len(params.AvailableTags) == 0 {
+				params.ClearFields = []string{"available_tags"}
+			}
+			updated, err := bot.Channels().ModifyChannel(ctx, forum.ID, params)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to delete tag %q from forum %s", tagRef, forum.ID)}).WithCause(err)
+			}
+			return renderForumTags(cmd, opts, updated.AvailableTags)
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Forum channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&tagRef, "tag", "", "Tag name or ID to delete")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+func renderForumTags(cmd *cobra.Command, opts *globalOptions, tags []types.ForumTag) error {
+	if tags == nil {
+		tags = []types.ForumTag{}
+	}
+	table := &tableData{headers: []string{"ID", "NAME", "EMOJI", "MODERATED"}}
+	for _, tag := range tags {
+		emoji := tag.EmojiName
+		if tag.EmojiID != "" {
+			emoji = tag.EmojiID
+		}
+		table.rows = append(table.rows, []string{tag.ID, tag.Name, emoji, strconv.FormatBool(tag.Moderated)})
+	}
+	return renderOutput(cmd, opts.output, tags, table)
+}
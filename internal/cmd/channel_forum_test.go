@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func forumChannel() *types.Channel {
+	return &types.Channel{
+		ID:      "1427555325136867393",
+		GuildID: "123456789012345678",
+		Type:    types.ChannelTypeGuildForum,
+		Flags:   types.ChannelFlagRequireTag,
+		AvailableTags: []types.ForumTag{
+			{ID: "t-release", Name: "Release"},
+			{ID: "t-incident", Name: "Incident"},
+		},
+	}
+}
+
+func runForumPost(t *testing.T, channels *fakeChannelService, args ...string) (string, error) {
+	t.Helper()
+	hookBot(t, testConfig(), &fakeBotClient{channelSvc: channels})
+	var out bytes.Buffer
+	cmd := channelForumPostCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs(append([]string{"--channel", "1427555325136867393", "--name", "v1.4.0", "--content", "Release notes"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestChannelForumPostResolvesTags(t *testing.T) {
+	channels := &fakeChannelService{channel: forumChannel()}
+	out, err := runForumPost(t, channels, "--tag", "release", "--tag", "t-incident", "--auto-archive", "1440")
+	if err != nil {
+		t.Fatalf("forum post: %v", err)
+	}
+	params := channels.forumParams
+	if params == nil || strings.Join(params.AppliedTags, ",") != "t-release,t-incident" || params.AutoArchiveDuration != 1440 || params.Message.Content != "Release notes" {
+		t.Fatalf("unexpected params %+v", params)
+	}
+	if !strings.Contains(out, "https://discord.com/channels/123456789012345678/thread-1") {
+		t.Fatalf("expected post URL in output, got %q", out)
+	}
+}
+
+func TestChannelForumPostRejectsMissingTags(t *testing.T) {
+	channels := &fakeChannelService{channel: forumChannel()}
+	if _, err := runForumPost(t, channels); err == nil || !strings.Contains(err.Error(), "requires a tag") {
+		t.Fatalf("expected require-tag error, got %v", err)
+	}
+	if _, err := runForumPost(t, channels, "--tag", "bogus"); err == nil || channels.forumParams != nil {
+		t.Fatalf("expected unknown tag error, got %v", err)
+	}
+	channels.channel.Type = types.ChannelTypeGuildText
+	if _, err := runForumPost(t, channels, "--tag", "release"); err == nil {
+		t.Fatal("expected non-forum channel error")
+	}
+}
+
+func runForumTags(t *testing.T, channels *fakeChannelService, sub func(*globalOptions) *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	hookBot(t, testConfig(), &fakeBotClient{channelSvc: channels})
+	var out bytes.Buffer
+	cmd := sub(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs(append([]string{"--channel", "1427555325136867393"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestChannelForumTagsCreateKeepsExistingTags(t *testing.T) {
+	channels := &fakeChannelService{channel: forumChannel()}
+	out, err := runForumTags(t, channels, channelForumTagsCreateCmd, "--name", "Hotfix", "--emoji", "fire:777", "--moderated")
+	if err != nil {
+		t.Fatalf("tags create: %v", err)
+	}
+	tags := channels.modifyParams.AvailableTags
+	if len(tags) != 3 || tags[0].ID != "t-release" || tags[2].ID != "" || tags[2].EmojiID != "777" || !tags[2].Moderated {
+		t.Fatalf("unexpected tags %+v", tags)
+	}
+	if !strings.Contains(out, "Hotfix") {
+		t.Fatalf("expected new tag in output, got %q", out)
+	}
+	if _, err := runForumTags(t, channels, channelForumTagsCreateCmd, "--name", "release"); err == nil {
+		t.Fatal("expected duplicate tag error")
+	}
+}
+
+func TestChannelForumTagsDelete(t *testing.T) {
+	channels := &fakeChannelService{channel: forumChannel()}
+	if _, err := runForumTags(t, channels, channelForumTagsDeleteCmd, "--tag", "incident"); err != nil {
+		t.Fatalf("tags delete: %v", err)
+	}
+	if tags := channels.modifyParams.AvailableTags; len(tags) != 1 || tags[0].ID != "t-release" {
+		t.Fatalf("unexpected tags %+v", tags)
+	}
+
+	channels.channel.AvailableTags = channels.channel.AvailableTags[:1]
+	if _, err := runForumTags(t, channels, channelForumTagsDeleteCmd, "--tag", "t-release"); err != nil {
+		t.Fatalf("tags delete: %v", err)
+	}
+	if params := channels.modifyParams; len(params.AvailableTags) != 0 || len(params.ClearFields) != 1 {
+		t.Fatalf("deleting the last tag must clear available_tags, got %+v", params)
+	}
+}
@@ -51,7 +51,7 @@ Example:
   arc-discord channel history --channel $CHANNEL --after 12039812398123 --output json | jq '.[].content'`,
 	}
 
-	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID or #name")
 	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum messages (1-100)")
 	cmd.Flags().StringVar(&before, "before", "", "Message ID to page before")
 	cmd.Flags().StringVar(&after, "after", "", "Message ID to page after")
@@ -64,6 +64,10 @@ func runChannelHistory(cmd *cobra.Command, opts *globalOptions, channelID string
 	if err != nil {
 		return err
 	}
+	fmtr, err := opts.formatter(cfg)
+	if err != nil {
+		return err
+	}
 	bot, err := newBotClientFn(cfg, opts.tokenOverride)
 	if err != nil {
 		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
@@ -82,6 +86,10 @@ func runChannelHistory(cmd *cobra.Command, opts *globalOptions, channelID string
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	messages, err := bot.Channels().GetChannelMessages(ctx, channelID, params)
 	if err != nil {
@@ -98,7 +106,7 @@ func runChannelHistory(cmd *cobra.Command, opts *globalOptions, channelID string
 			"content":   truncate(m.Content, 80),
 		}
 		payload = append(payload, entry)
-		rows = append(rows, []string{m.ID, entry["author"], entry["timestamp"], entry["content"]})
+		rows = append(rows, []string{m.ID, entry["author"], fmtr.DateTime(m.Timestamp), entry["content"]})
 	}
 
 	table := &tableData{headers: []string{"ID", "Author", "Timestamp", "Content"}, rows: rows}
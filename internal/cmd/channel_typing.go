@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// typingRefreshInterval re-triggers the indicator before Discord's ~10s
+// expiry.
+var typingRefreshInterval = 8 * time.Second
+
+func channelTypingCmd(opts *globalOptions) *cobra.Command {
+	var (
+		channelRef string
+		guildRef   string
+		duration   time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "typing",
+		Short: "Show the bot as typing in a channel",
+		Long: `Trigger the typing indicator in a channel. Discord shows it for about 10 seconds or until the
+bot posts a message. With --duration the indicator is re-triggered until the duration elapses or
+the command is interrupted, so a script can show activity while a long job runs and stop it by
+killing the process once the real response is posted.`,
+		Example: `Example:
+  arc-discord channel typing --channel 1427555325136867393
+
+Example:
+  arc-discord channel typing --channel "#support" --guild "My Server" --duration 2m &`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" {
+				return &arcer.CLIError{Msg: "--channel is required"}
+			}
+			if duration < 0 {
+				return &arcer.CLIError{Msg: "--duration must not be negative"}
+			}
+			bot, ctx, cancel, channelID, err := channelTarget(cmd, opts, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			cancel()
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			err = keepTyping(ctx, bot.Channels(), channelID, duration, typingRefreshInterval)
+			if err != nil && ctx.Err() == nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to trigger typing in channel %s", channelID), Hint: "the bot needs SEND_MESSAGES in the channel"}).WithCause(err)
+			}
+			cmd.Printf("Typing indicator shown in channel %s\n", channelID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve channel names")
+	cmd.Flags().DurationVar(&duration, "duration", 0, "Keep the indicator up for this long (default: trigger once)")
+	return cmd
+}
+
+// keepTyping triggers the typing indicator once, then every interval until
+// duration has elapsed or ctx is done.
+func keepTyping(ctx context.Context, svc channelService, channelID string, duration, interval time.Duration) error {
+	trigger := func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		return svc.TriggerTypingIndicator(reqCtx, channelID)
+	}
+	if err := trigger(); err != nil {
+		return err
+	}
+	if duration <= interval {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := trigger(); err != nil {
+				return err
+			}
+		}
+	}
+}
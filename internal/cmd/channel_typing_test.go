@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestKeepTypingRefreshesUntilDuration(t *testing.T) {
+	channels := &fakeChannelService{}
+	if err := keepTyping(context.Background(), channels, "chan-1", 35*time.Millisecond, 10*time.Millisecond); err != nil {
+		t.Fatalf("keepTyping: %v", err)
+	}
+	if channels.typing < 3 || channels.typing > 5 {
+		t.Fatalf("expected about 4 triggers, got %d", channels.typing)
+	}
+}
+
+func TestChannelTypingOnce(t *testing.T) {
+	channels := &fakeChannelService{}
+	hookBot(t, testConfig(), &fakeBotClient{channelSvc: channels})
+
+	cmd := channelTypingCmd(&globalOptions{})
+	cmd.SetArgs([]string{"--channel", "1427555325136867393"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("typing: %v", err)
+	}
+	if channels.typing != 1 {
+		t.Fatalf("expected one trigger, got %d", channels.typing)
+	}
+}
@@ -3,14 +3,19 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/yourorg/arc-sdk/output"
 	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
 	"github.com/yourorg/arc-discord/gosdk/discord/client"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 	"github.com/yourorg/arc-discord/gosdk/discord/webhook"
+	"github.com/yourorg/arc-sdk/output"
 )
 
 func TestWebhookSendUsesDispatcher(t *testing.T) {
@@ -63,6 +68,56 @@ func TestMessageSendInvokesBot(t *testing.T) {
 	}
 }
 
+func TestDMSendOpensChannelAndSends(t *testing.T) {
+	cfg := testConfig()
+	messageSvc := &fakeMessageService{}
+	userSvc := &fakeUserService{}
+	bot := &fakeBotClient{messageSvc: messageSvc, channelSvc: &fakeChannelService{}, guildSvc: &fakeGuildService{}, userSvc: userSvc}
+	hookBot(t, cfg, bot)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := dmSendCmd(opts)
+	cmd.SetArgs([]string{"--user", "77", "--content", "psst"})
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	if userSvc.recipient != "77" {
+		t.Fatalf("expected DM channel for user 77, got %q", userSvc.recipient)
+	}
+	if messageSvc.channelID != "dm-77" {
+		t.Fatalf("expected message sent to dm-77, got %s", messageSvc.channelID)
+	}
+	if messageSvc.params == nil || messageSvc.params.Content != "psst" {
+		t.Fatalf("params not captured: %#v", messageSvc.params)
+	}
+}
+
+func TestMessageEditClearContent(t *testing.T) {
+	cfg := testConfig()
+	messageSvc := &fakeMessageService{}
+	bot := &fakeBotClient{messageSvc: messageSvc, channelSvc: &fakeChannelService{}, guildSvc: &fakeGuildService{}}
+	hookBot(t, cfg, bot)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := messageEditCmd(opts)
+	cmd.SetArgs([]string{"--channel", "1", "--message", "2", "--clear-content"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	if messageSvc.editParams == nil || len(messageSvc.editParams.ClearFields) != 1 || messageSvc.editParams.ClearFields[0] != "content" {
+		t.Fatalf("expected content clear, got %#v", messageSvc.editParams)
+	}
+}
+
 func TestChannelGet(t *testing.T) {
 	cfg := testConfig()
 	channelSvc := &fakeChannelService{channel: &types.Channel{ID: "42", Name: "alerts"}}
@@ -84,6 +139,98 @@ func TestChannelGet(t *testing.T) {
 	}
 }
 
+func TestChannelModifyFieldsAndClears(t *testing.T) {
+	cfg := testConfig()
+	channelSvc := &fakeChannelService{}
+	bot := &fakeBotClient{messageSvc: &fakeMessageService{}, channelSvc: channelSvc, guildSvc: &fakeGuildService{}}
+	hookBot(t, cfg, bot)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := channelModifyCmd(opts)
+	cmd.SetArgs([]string{
+		"--channel", "42",
+		"--parent", "7",
+		"--position", "0",
+		"--user-limit", "10",
+		"--rate-limit-per-user", "0",
+		"--flags", "require_tag",
+		"--clear", "topic",
+	})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	params := channelSvc.modifyParams
+	if params == nil {
+		t.Fatal("modify params not captured")
+	}
+	if params.ParentID != "7" || params.UserLimit == nil || *params.UserLimit != 10 || params.Flags != types.ChannelFlagRequireTag || params.Position == nil || *params.Position != 0 {
+		t.Fatalf("unexpected params: %+v", params)
+	}
+	want := map[string]bool{"rate_limit_per_user": true, "topic": true}
+	if len(params.ClearFields) != len(want) {
+		t.Fatalf("unexpected clear fields: %v", params.ClearFields)
+	}
+	for _, field := range params.ClearFields {
+		if !want[field] {
+			t.Fatalf("unexpected clear field %q", field)
+		}
+	}
+}
+
+func TestChannelModifySendsLiteralZeros(t *testing.T) {
+	zero := 0
+	params, err := buildModifyChannelParams(channelModifyInput{position: &zero})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"position":0}` {
+		t.Fatalf("expected a literal zero position, got %s", body)
+	}
+
+	cleared, err := buildModifyChannelParams(channelModifyInput{clearFields: []string{"position"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := json.Marshal(cleared); string(body) != `{"position":null}` {
+		t.Fatalf("expected --clear position to send null, got %s", body)
+	}
+
+	unlimited, err := buildModifyChannelParams(channelModifyInput{userLimit: &zero})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := json.Marshal(unlimited); string(body) != `{"user_limit":0}` {
+		t.Fatalf("expected --user-limit 0 to send a literal zero, got %s", body)
+	}
+
+	if _, err := buildModifyChannelParams(channelModifyInput{bitrate: &zero}); err == nil || !strings.Contains(err.Error(), "must be positive") {
+		t.Fatalf("expected a zero bitrate to be rejected, got %v", err)
+	}
+}
+
+func TestChannelModifyRejectsUnknownClearField(t *testing.T) {
+	cfg := testConfig()
+	hookBot(t, cfg, &fakeBotClient{messageSvc: &fakeMessageService{}, channelSvc: &fakeChannelService{}, guildSvc: &fakeGuildService{}})
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := channelModifyCmd(opts)
+	cmd.SetArgs([]string{"--channel", "42", "--clear", "name"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error when clearing the channel name")
+	}
+}
+
 func TestGuildGet(t *testing.T) {
 	cfg := testConfig()
 	guildSvc := &fakeGuildService{guild: &types.Guild{ID: "99", Name: "labs"}}
@@ -105,6 +252,88 @@ func TestGuildGet(t *testing.T) {
 	}
 }
 
+func TestGuildGetReportsCapabilityFeatures(t *testing.T) {
+	cfg := testConfig()
+	guildSvc := &fakeGuildService{guild: &types.Guild{ID: "99", Name: "labs", PremiumTier: 2, Features: []string{types.GuildFeatureCommunity}}}
+	bot := &fakeBotClient{messageSvc: &fakeMessageService{}, channelSvc: &fakeChannelService{}, guildSvc: guildSvc}
+	hookBot(t, cfg, bot)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := guildGetCmd(opts)
+	cmd.SetArgs([]string{"--guild", "99"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	var decoded struct {
+		ID                 string `json:"id"`
+		PremiumTier        int    `json:"premium_tier"`
+		CapabilityFeatures []struct {
+			Feature string `json:"feature"`
+			Enabled bool   `json:"enabled"`
+		} `json:"capability_features"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, buf.String())
+	}
+	if decoded.ID != "99" || decoded.PremiumTier != 2 {
+		t.Fatalf("guild fields not flattened: %+v", decoded)
+	}
+	found := false
+	for _, f := range decoded.CapabilityFeatures {
+		if f.Feature == types.GuildFeatureCommunity {
+			found = f.Enabled
+		}
+	}
+	if !found {
+		t.Fatalf("expected COMMUNITY marked enabled: %+v", decoded.CapabilityFeatures)
+	}
+}
+
+func TestWebhookMessageEditAndDelete(t *testing.T) {
+	cfg := testConfig()
+	fake := &fakeWebhookClient{}
+	hookStubs(t, cfg, fake, nil)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	edit := webhookMessageEditCmd(opts)
+	edit.SetArgs([]string{"--message-id", "42", "--thread-id", "7", "--content", "deploy done"})
+	var buf bytes.Buffer
+	edit.SetOut(&buf)
+	edit.SetErr(&buf)
+	if err := edit.Execute(); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if len(fake.edits) != 1 || fake.edits[0].Content == nil || *fake.edits[0].Content != "deploy done" {
+		t.Fatalf("unexpected edits %#v", fake.edits)
+	}
+	if fake.lastThread != "7" {
+		t.Fatalf("expected thread 7, got %q", fake.lastThread)
+	}
+
+	del := webhookMessageDeleteCmd(opts)
+	del.SetArgs([]string{"--message-id", "42"})
+	del.SetOut(&buf)
+	del.SetErr(&buf)
+	if err := del.Execute(); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "42" {
+		t.Fatalf("unexpected deletes %v", fake.deleted)
+	}
+
+	empty := webhookMessageEditCmd(opts)
+	empty.SetArgs([]string{"--message-id", "42"})
+	empty.SetOut(&buf)
+	empty.SetErr(&buf)
+	if err := empty.Execute(); err == nil {
+		t.Fatal("expected error when nothing to edit")
+	}
+}
+
 func TestConfigShow(t *testing.T) {
 	cfg := testConfig()
 	hookStubs(t, cfg, &fakeWebhookClient{}, &fakeBotClient{})
@@ -122,8 +351,29 @@ func TestConfigShow(t *testing.T) {
 	}
 }
 
+func TestConfigShowReportsAPIVersion(t *testing.T) {
+	cfg := testConfig()
+	cfg.Client.APIVersion = 9
+	hookStubs(t, cfg, &fakeWebhookClient{}, &fakeBotClient{})
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}}
+	cmd := configShowCmd(opts)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(buf.String(), "v9") {
+		t.Fatalf("expected pinned api version in output, got %s", buf.String())
+	}
+}
+
 type fakeWebhookClient struct {
-	messages []*types.WebhookMessage
+	messages   []*types.WebhookMessage
+	edits      []*webhook.MessageEditParams
+	deleted    []string
+	lastThread string
 }
 
 func (f *fakeWebhookClient) Send(_ context.Context, msg *types.WebhookMessage) error {
@@ -136,16 +386,53 @@ func (f *fakeWebhookClient) SendWithFiles(_ context.Context, msg *types.WebhookM
 	return nil
 }
 
+func (f *fakeWebhookClient) SendAndWait(_ context.Context, msg *types.WebhookMessage) (*types.Message, error) {
+	f.messages = append(f.messages, msg)
+	channelID := "chan-1"
+	if msg.ThreadID != "" {
+		channelID = msg.ThreadID
+	}
+	return &types.Message{ID: "msg-1", ChannelID: channelID, Content: msg.Content}, nil
+}
+
+func (f *fakeWebhookClient) SendWithFilesAndWait(ctx context.Context, msg *types.WebhookMessage, _ []webhook.FileAttachment) (*types.Message, error) {
+	return f.SendAndWait(ctx, msg)
+}
+
 func (f *fakeWebhookClient) CreateThread(_ context.Context, name string, msg *types.WebhookMessage) error {
 	f.messages = append(f.messages, msg)
 	return nil
 }
 
+func (f *fakeWebhookClient) GetInThread(_ context.Context, messageID, threadID string) (*types.Message, error) {
+	f.lastThread = threadID
+	return &types.Message{ID: messageID, ChannelID: "chan-1", Content: "hello"}, nil
+}
+
+func (f *fakeWebhookClient) Edit(_ context.Context, messageID string, params *webhook.MessageEditParams) (*types.Message, error) {
+	f.edits = append(f.edits, params)
+	f.lastThread = params.ThreadID
+	msg := &types.Message{ID: messageID, ChannelID: "chan-1"}
+	if params.Content != nil {
+		msg.Content = *params.Content
+	}
+	return msg, nil
+}
+
+func (f *fakeWebhookClient) DeleteInThread(_ context.Context, messageID, threadID string) error {
+	f.deleted = append(f.deleted, messageID)
+	f.lastThread = threadID
+	return nil
+}
+
 type fakeBotClient struct {
 	messageSvc *fakeMessageService
 	channelSvc *fakeChannelService
 	guildSvc   *fakeGuildService
+	userSvc    *fakeUserService
 	commandSvc *fakeApplicationCommands
+	appSvc     *fakeApplicationService
+	stageSvc   *fakeStageInstances
 }
 
 func (f *fakeBotClient) Messages() messageService {
@@ -160,6 +447,13 @@ func (f *fakeBotClient) Guilds() guildService {
 	return f.guildSvc
 }
 
+func (f *fakeBotClient) Users() userService {
+	if f.userSvc != nil {
+		return f.userSvc
+	}
+	return &fakeUserService{}
+}
+
 func (f *fakeBotClient) ApplicationCommands(applicationID string) applicationCommandService {
 	if f.commandSvc != nil {
 		return f.commandSvc
@@ -167,9 +461,28 @@ func (f *fakeBotClient) ApplicationCommands(applicationID string) applicationCom
 	return &fakeApplicationCommands{}
 }
 
+func (f *fakeBotClient) Applications() applicationService {
+	if f.appSvc != nil {
+		return f.appSvc
+	}
+	return &fakeApplicationService{}
+}
+
+func (f *fakeBotClient) StageInstances() stageInstanceService {
+	if f.stageSvc != nil {
+		return f.stageSvc
+	}
+	return &fakeStageInstances{}
+}
+
 type fakeMessageService struct {
-	channelID string
-	params    *types.MessageCreateParams
+	channelID  string
+	params     *types.MessageCreateParams
+	editParams *types.MessageEditParams
+
+	message   *types.Message
+	reactions map[string][]*types.User
+	pages     []string
 }
 
 func (f *fakeMessageService) CreateMessage(_ context.Context, channelID string, params *types.MessageCreateParams) (*types.Message, error) {
@@ -179,6 +492,7 @@ func (f *fakeMessageService) CreateMessage(_ context.Context, channelID string,
 }
 
 func (f *fakeMessageService) EditMessage(_ context.Context, channelID, messageID string, params *types.MessageEditParams) (*types.Message, error) {
+	f.editParams = params
 	return &types.Message{ID: messageID, ChannelID: channelID, Timestamp: time.Now()}, nil
 }
 
@@ -194,9 +508,53 @@ func (f *fakeMessageService) DeleteOwnReaction(_ context.Context, channelID, mes
 	return nil
 }
 
+func (f *fakeMessageService) GetMessage(_ context.Context, channelID, messageID string) (*types.Message, error) {
+	if f.message != nil {
+		return f.message, nil
+	}
+	return &types.Message{ID: messageID, ChannelID: channelID}, nil
+}
+
+func (f *fakeMessageService) GetReactions(_ context.Context, channelID, messageID, emoji string, params *client.GetReactionsParams) ([]*types.User, error) {
+	f.pages = append(f.pages, emoji+">"+params.After)
+	var page []*types.User
+	for _, user := range f.reactions[emoji] {
+		if user.ID > params.After && len(page) < params.Limit {
+			page = append(page, user)
+		}
+	}
+	return page, nil
+}
+
 type fakeChannelService struct {
-	channel   *types.Channel
-	requested string
+	channel      *types.Channel
+	requested    string
+	modifyParams *types.ModifyChannelParams
+	forumParams  *types.ForumThreadParams
+	followed     [2]string
+	typing       int
+	deleted      []string
+}
+
+func (f *fakeChannelService) DeleteChannel(_ context.Context, channelID string) error {
+	f.deleted = append(f.deleted, channelID)
+	return nil
+}
+
+func (f *fakeChannelService) TriggerTypingIndicator(context.Context, string) error {
+	f.typing++
+	return nil
+}
+
+func (f *fakeChannelService) FollowAnnouncementChannel(_ context.Context, channelID, targetChannelID, _ string) (*types.FollowedChannel, error) {
+	f.followed = [2]string{channelID, targetChannelID}
+	return &types.FollowedChannel{ChannelID: channelID, WebhookID: "wh-1"}, nil
+}
+
+func (f *fakeChannelService) StartThreadInForum(_ context.Context, channelID string, params *types.ForumThreadParams) (*types.ForumThread, error) {
+	f.requested = channelID
+	f.forumParams = params
+	return &types.ForumThread{Channel: types.Channel{ID: "thread-1", Name: params.Name, ParentID: channelID, AppliedTags: params.AppliedTags}}, nil
 }
 
 func (f *fakeChannelService) GetChannel(_ context.Context, id string) (*types.Channel, error) {
@@ -212,12 +570,43 @@ func (f *fakeChannelService) GetChannelMessages(_ context.Context, channelID str
 }
 
 func (f *fakeChannelService) ModifyChannel(_ context.Context, channelID string, params *types.ModifyChannelParams) (*types.Channel, error) {
-	return &types.Channel{ID: channelID}, nil
+	f.modifyParams = params
+	return &types.Channel{ID: channelID, AvailableTags: params.AvailableTags}, nil
 }
 
 type fakeGuildService struct {
-	guild     *types.Guild
-	requested string
+	guild        *types.Guild
+	channels     map[string][]*types.Channel
+	channelCalls int
+	requested    string
+	modifyParams *types.GuildModifyParams
+	roleParams   *types.RoleModifyParams
+
+	onboarding       *types.GuildOnboarding
+	onboardingParams *types.GuildOnboardingParams
+	welcome          *types.WelcomeScreen
+	welcomeParams    *types.WelcomeScreenModifyParams
+
+	templates      []*types.GuildTemplate
+	templateParams *types.GuildTemplateParams
+	synced         []string
+	fromTemplate   *types.GuildFromTemplateParams
+	templateCode   string
+
+	bans     []*types.Ban
+	banPages []string
+	bulkBans [][]string
+	bulkErr  error
+	banned   []string
+
+	roles       []*types.Role
+	roleEdits   map[string]*types.RoleModifyParams
+	newRoles    []*types.RoleCreateParams
+	newChannels []*types.ChannelCreateParams
+	deleted     []string
+	pruneCount  int
+	pruneParams *types.PruneParams
+	pruned      bool
 }
 
 func (f *fakeGuildService) GetGuild(_ context.Context, id string, _ bool) (*types.Guild, error) {
@@ -233,16 +622,227 @@ func (f *fakeGuildService) ListGuildMembers(_ context.Context, guildID string, p
 }
 
 func (f *fakeGuildService) GetGuildRoles(_ context.Context, guildID string) ([]*types.Role, error) {
-	return []*types.Role{}, nil
+	return append([]*types.Role{}, f.roles...), nil
 }
 
 func (f *fakeGuildService) GetGuildChannels(_ context.Context, guildID string) ([]*types.Channel, error) {
+	f.channelCalls++
+	if channels, ok := f.channels[guildID]; ok {
+		return channels, nil
+	}
 	return []*types.Channel{}, nil
 }
 
-type fakeApplicationCommands struct{}
+func (f *fakeGuildService) ModifyGuild(_ context.Context, guildID string, params *types.GuildModifyParams) (*types.Guild, error) {
+	f.requested = guildID
+	f.modifyParams = params
+	return &types.Guild{ID: guildID}, nil
+}
+
+func (f *fakeGuildService) ModifyGuildRole(_ context.Context, guildID, roleID string, params *types.RoleModifyParams) (*types.Role, error) {
+	f.requested = guildID
+	f.roleParams = params
+	if f.roleEdits == nil {
+		f.roleEdits = map[string]*types.RoleModifyParams{}
+	}
+	f.roleEdits[roleID] = params
+	return &types.Role{ID: roleID}, nil
+}
+
+func (f *fakeGuildService) CreateGuildRole(_ context.Context, guildID string, params *types.RoleCreateParams) (*types.Role, error) {
+	f.newRoles = append(f.newRoles, params)
+	return &types.Role{ID: "new-role-" + strconv.Itoa(len(f.newRoles)), Name: params.Name}, nil
+}
+
+func (f *fakeGuildService) DeleteGuildRole(_ context.Context, guildID, roleID string) error {
+	f.deleted = append(f.deleted, roleID)
+	return nil
+}
+
+func (f *fakeGuildService) CreateGuildChannel(_ context.Context, guildID string, params *types.ChannelCreateParams) (*types.Channel, error) {
+	f.newChannels = append(f.newChannels, params)
+	return &types.Channel{ID: "new-channel-" + strconv.Itoa(len(f.newChannels)), Name: params.Name, Type: params.Type}, nil
+}
+
+func (f *fakeGuildService) GetGuildOnboarding(_ context.Context, guildID string) (*types.GuildOnboarding, error) {
+	f.requested = guildID
+	if f.onboarding != nil {
+		return f.onboarding, nil
+	}
+	return &types.GuildOnboarding{GuildID: guildID}, nil
+}
+
+func (f *fakeGuildService) ModifyGuildOnboarding(_ context.Context, guildID string, params *types.GuildOnboardingParams) (*types.GuildOnboarding, error) {
+	f.requested = guildID
+	f.onboardingParams = params
+	return &types.GuildOnboarding{GuildID: guildID, Prompts: params.Prompts, Enabled: params.Enabled}, nil
+}
+
+func (f *fakeGuildService) GetGuildWelcomeScreen(_ context.Context, guildID string) (*types.WelcomeScreen, error) {
+	f.requested = guildID
+	if f.welcome != nil {
+		return f.welcome, nil
+	}
+	return &types.WelcomeScreen{}, nil
+}
+
+func (f *fakeGuildService) ModifyGuildWelcomeScreen(_ context.Context, guildID string, params *types.WelcomeScreenModifyParams) (*types.WelcomeScreen, error) {
+	f.requested = guildID
+	f.welcomeParams = params
+	screen := &types.WelcomeScreen{}
+	if params.WelcomeChannels != nil {
+		screen.WelcomeChannels = *params.WelcomeChannels
+	}
+	return screen, nil
+}
+
+func (f *fakeGuildService) GetGuildTemplates(_ context.Context, guildID string) ([]*types.GuildTemplate, error) {
+	f.requested = guildID
+	return f.templates, nil
+}
+
+func (f *fakeGuildService) CreateGuildTemplate(_ context.Context, guildID string, params *types.GuildTemplateParams) (*types.GuildTemplate, error) {
+	f.requested = guildID
+	f.templateParams = params
+	return &types.GuildTemplate{Code: "tmpl", Name: params.Name, SourceGuildID: guildID}, nil
+}
+
+func (f *fakeGuildService) SyncGuildTemplate(_ context.Context, guildID, code string) (*types.GuildTemplate, error) {
+	f.requested = guildID
+	f.synced = append(f.synced, code)
+	return &types.GuildTemplate{Code: code, SourceGuildID: guildID}, nil
+}
+
+func (f *fakeGuildService) CreateGuildFromTemplate(_ context.Context, code string, params *types.GuildFromTemplateParams) (*types.Guild, error) {
+	f.templateCode = code
+	f.fromTemplate = params
+	return &types.Guild{ID: "new-guild", Name: params.Name}, nil
+}
+
+func (f *fakeGuildService) GetGuildBans(_ context.Context, guildID string, params *types.ListBansParams) ([]*types.Ban, error) {
+	f.requested = guildID
+	f.banPages = append(f.banPages, params.After)
+	var page []*types.Ban
+	for _, ban := range f.bans {
+		if ban.User.ID > params.After && len(page) < params.Limit {
+			page = append(page, ban)
+		}
+	}
+	return page, nil
+}
+
+func (f *fakeGuildService) CreateGuildBan(_ context.Context, guildID, userID string, _ *types.CreateBanParams) error {
+	f.requested = guildID
+	f.banned = append(f.banned, userID)
+	return nil
+}
+
+func (f *fakeGuildService) BulkGuildBan(_ context.Context, guildID string, params *types.BulkBanParams) (*types.BulkBanResult, error) {
+	f.requested = guildID
+	if f.bulkErr != nil {
+		return nil, f.bulkErr
+	}
+	f.bulkBans = append(f.bulkBans, params.UserIDs)
+	return &types.BulkBanResult{BannedUsers: params.UserIDs}, nil
+}
+
+func (f *fakeGuildService) GetGuildPruneCount(_ context.Context, guildID string, params *types.PruneParams) (int, error) {
+	f.requested = guildID
+	f.pruneParams = params
+	return f.pruneCount, nil
+}
+
+func (f *fakeGuildService) BeginGuildPrune(_ context.Context, guildID string, params *types.PruneParams) (*types.PruneResult, error) {
+	f.requested = guildID
+	f.pruneParams = params
+	f.pruned = true
+	return &types.PruneResult{}, nil
+}
+
+type fakeUserService struct {
+	recipient string
+	user      *types.User
+	guilds    []*types.Guild
+	err       error
+}
+
+func (f *fakeUserService) GetCurrentUser(context.Context) (*types.User, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.user != nil {
+		return f.user, nil
+	}
+	return &types.User{ID: "bot-1", Username: "arc", Bot: true}, nil
+}
+
+func (f *fakeUserService) GetCurrentUserGuilds(context.Context) ([]*types.Guild, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.guilds, nil
+}
+
+func (f *fakeUserService) CreateDM(_ context.Context, recipientID string) (*types.Channel, error) {
+	f.recipient = recipientID
+	return &types.Channel{ID: "dm-" + recipientID, Type: types.ChannelTypeDM}, nil
+}
+
+type fakeApplicationService struct {
+	app          *types.Application
+	err          error
+	entitlements []*types.Entitlement
+	entParams    []types.ListEntitlementsParams
+	edits        []*types.ModifyApplicationParams
+}
+
+// ListEntitlements serves f.entitlements in ID order, honoring after and limit.
+func (f *fakeApplicationService) ListEntitlements(_ context.Context, _ string, params *types.ListEntitlementsParams) ([]*types.Entitlement, error) {
+	f.entParams = append(f.entParams, *params)
+	var page []*types.Entitlement
+	for _, e := range f.entitlements {
+		if e.ID > params.After && len(page) < params.Limit {
+			page = append(page, e)
+		}
+	}
+	return page, nil
+}
+
+func (f *fakeApplicationService) GetCurrentApplication(context.Context) (*types.Application, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.app != nil {
+		return f.app, nil
+	}
+	return &types.Application{ID: "app-1", Name: "arc"}, nil
+}
+
+func (f *fakeApplicationService) EditCurrentApplication(_ context.Context, params *types.ModifyApplicationParams) (*types.Application, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.edits = append(f.edits, params)
+	app := &types.Application{ID: "app-1", Name: "arc"}
+	if params.InteractionsEndpointURL != nil {
+		app.InteractionsEndpointURL = *params.InteractionsEndpointURL
+	}
+	if params.Description != nil {
+		app.Description = *params.Description
+	}
+	return app, nil
+}
+
+type fakeApplicationCommands struct {
+	global []*types.ApplicationCommand
+	edited []string
+	bulk   map[string][]*types.ApplicationCommand
+}
 
 func (f *fakeApplicationCommands) GetGlobalApplicationCommands(ctx context.Context) ([]*types.ApplicationCommand, error) {
+	if f.global != nil {
+		return f.global, nil
+	}
 	return []*types.ApplicationCommand{}, nil
 }
 
@@ -258,6 +858,38 @@ func (f *fakeApplicationCommands) CreateGuildApplicationCommand(ctx context.Cont
 	return cmd, nil
 }
 
+func (f *fakeApplicationCommands) EditGlobalApplicationCommand(ctx context.Context, commandID string, cmd *types.ApplicationCommand) (*types.ApplicationCommand, error) {
+	f.edited = append(f.edited, commandID)
+	updated := *cmd
+	updated.ID = commandID
+	return &updated, nil
+}
+
+func (f *fakeApplicationCommands) EditGuildApplicationCommand(ctx context.Context, guildID, commandID string, cmd *types.ApplicationCommand) (*types.ApplicationCommand, error) {
+	f.edited = append(f.edited, guildID+"/"+commandID)
+	updated := *cmd
+	updated.ID = commandID
+	return &updated, nil
+}
+
+func (f *fakeApplicationCommands) BulkOverwriteGlobalApplicationCommands(ctx context.Context, cmds []*types.ApplicationCommand) ([]*types.ApplicationCommand, error) {
+	return f.BulkOverwriteGuildApplicationCommands(ctx, "", cmds)
+}
+
+func (f *fakeApplicationCommands) BulkOverwriteGuildApplicationCommands(ctx context.Context, guildID string, cmds []*types.ApplicationCommand) ([]*types.ApplicationCommand, error) {
+	if f.bulk == nil {
+		f.bulk = make(map[string][]*types.ApplicationCommand)
+	}
+	f.bulk[guildID] = cmds
+	out := make([]*types.ApplicationCommand, 0, len(cmds))
+	for i, c := range cmds {
+		registered := *c
+		registered.ID = "cmd-" + strconv.Itoa(i+1)
+		out = append(out, &registered)
+	}
+	return out, nil
+}
+
 func (f *fakeApplicationCommands) DeleteGlobalApplicationCommand(ctx context.Context, commandID string) error {
 	return nil
 }
@@ -300,3 +932,72 @@ func hookStubs(t *testing.T, cfg *discordconfig.Config, webhookClient webhookDis
 func hookBot(t *testing.T, cfg *discordconfig.Config, bot botClient) {
 	hookStubs(t, cfg, &fakeWebhookClient{}, bot)
 }
+
+func TestGuildRoleEditAppliesPatch(t *testing.T) {
+	cfg := testConfig()
+	guildSvc := &fakeGuildService{}
+	hookBot(t, cfg, &fakeBotClient{messageSvc: &fakeMessageService{}, channelSvc: &fakeChannelService{}, guildSvc: guildSvc})
+
+	patchPath := filepath.Join(t.TempDir(), "role.json")
+	if err := os.WriteFile(patchPath, []byte(`{"hoist": false, "color": 0}`), 0o600); err != nil {
+		t.Fatalf("write patch: %v", err)
+	}
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := guildRoleEditCmd(opts)
+	cmd.SetArgs([]string{"--guild", "99", "--role", "5", "--name", "ops", "--patch", patchPath})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	data, err := json.Marshal(guildSvc.roleParams)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(data) != `{"color":0,"hoist":false,"name":"ops"}` {
+		t.Fatalf("unexpected role payload %s", data)
+	}
+}
+
+func TestMessageEditRejectsNonObjectPatch(t *testing.T) {
+	cfg := testConfig()
+	hookBot(t, cfg, &fakeBotClient{messageSvc: &fakeMessageService{}, channelSvc: &fakeChannelService{}, guildSvc: &fakeGuildService{}})
+
+	patchPath := filepath.Join(t.TempDir(), "edit.json")
+	if err := os.WriteFile(patchPath, []byte(`["content"]`), 0o600); err != nil {
+		t.Fatalf("write patch: %v", err)
+	}
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := messageEditCmd(opts)
+	cmd.SetArgs([]string{"--channel", "1", "--message", "2", "--patch", patchPath})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error for array patch")
+	}
+}
+
+func TestWebhookSendWaitReportsMessage(t *testing.T) {
+	cfg := testConfig()
+	fake := &fakeWebhookClient{}
+	hookStubs(t, cfg, fake, nil)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := webhookSendCmd(opts)
+	cmd.SetArgs([]string{"--wait", "--thread-id", "thread-9", "deploying"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"message_id": "msg-1"`) || !strings.Contains(out, `"channel_id": "thread-9"`) {
+		t.Fatalf("expected created message in output, got %s", out)
+	}
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds the API calls made while the shell waits on a
+// completion; a slow Discord or Redis yields no suggestions, not a hang.
+const completionTimeout = 3 * time.Second
+
+var (
+	// completionCacheTTL is how long fetched names are reused. Every <TAB>
+	// runs a fresh process, so the cache lives on disk.
+	completionCacheTTL    = time.Minute
+	completionCachePathFn = defaultCompletionCachePath
+)
+
+// annotationRawIDs marks commands whose --guild/--channel values are used
+// verbatim rather than resolved by name, so they get no name completion.
+const annotationRawIDs = "arc-discord/raw-ids"
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// registerFlagCompletions attaches dynamic completion to every --channel,
+// --guild, --webhook, and --agent flag in the command tree, so new commands
+// pick it up by using the conventional flag names.
+func registerFlagCompletions(root *cobra.Command, opts *globalOptions) {
+	completers := map[string]completionFunc{
+		"channel": completeChannels(opts),
+		"guild":   completeGuilds(opts),
+		"webhook": completeWebhooks(opts),
+		"agent":   completeAgents(opts),
+	}
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		for name, fn := range completers {
+			if (name == "guild" || name == "channel") && c.Annotations[annotationRawIDs] != "" {
+				continue
+			}
+			if c.Flags().Lookup(name) != nil {
+				_ = c.RegisterFlagCompletionFunc(name, fn)
+			}
+		}
+		for _, child := range c.Commands() {
+			walk(child)
+		}
+	}
+	walk(root)
+}
+
+// completeGuilds suggests the names of the guilds the bot belongs to.
+func completeGuilds(opts *globalOptions) completionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		items, err := cachedCompletions(opts, "guilds", func(ctx context.Context) ([]string, error) {
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return nil, err
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return nil, err
+			}
+			guilds, err := newNameResolver(bot, cfg).listGuilds(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]string, 0, len(guilds))
+			for _, g := range guilds {
+				items = append(items, g.Name+"\t"+g.ID)
+			}
+			return items, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError | cobra.ShellCompDirectiveNoFileComp
+		}
+		return filterCompletions(items, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeChannels suggests channel names from --guild when the command has
+// one, else default_guild_id, else every guild the bot belongs to. Names are
+// offered bare because an unquoted leading # starts a shell comment.
+func completeChannels(opts *globalOptions) completionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		guildRef := ""
+		if f := cmd.Flags().Lookup("guild"); f != nil {
+			guildRef = f.Value.String()
+		}
+		items, err := cachedCompletions(opts, "channels:"+guildRef, func(ctx context.Context) ([]string, error) {
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return nil, err
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return nil, err
+			}
+			resolver := newNameResolver(bot, cfg)
+			guildID, err := resolver.GuildID(ctx, guildRef)
+			if err != nil {
+				return nil, err
+			}
+			if guildID == "" {
+				guildID = resolver.defaultGuildID
+			}
+			guilds := map[string]string{}
+			if guildID != "" {
+				guilds[guildID] = ""
+			} else {
+				all, err := resolver.listGuilds(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, g := range all {
+					guilds[g.ID] = g.Name
+				}
+			}
+			var items []string
+			for id, guildName := range guilds {
+				channels, err := resolver.Channels(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				for _, ch := range channels {
+					desc := ch.ID
+					if guildName != "" {
+						desc = fmt.Sprintf("%s in %s", ch.ID, guildName)
+					}
+					items = append(items, ch.Name+"\t"+desc)
+				}
+			}
+			sort.Strings(items)
+			return items, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError | cobra.ShellCompDirectiveNoFileComp
+		}
+		return filterCompletions(items, strings.TrimPrefix(toComplete, "#")), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeWebhooks suggests webhook names from discord.yaml; no API call.
+func completeWebhooks(opts *globalOptions) completionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cfg, _, err := opts.loadConfig()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError | cobra.ShellCompDirectiveNoFileComp
+		}
+		items := make([]string, 0, len(cfg.Discord.Webhooks))
+		for name := range cfg.Discord.Webhooks {
+			items = append(items, name)
+		}
+		sort.Strings(items)
+		return filterCompletions(items, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeAgents suggests agents named by interaction handlers in
+// discord.yaml plus those currently registered with the broker.
+func completeAgents(opts *globalOptions) completionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		items, err := cachedCompletions(opts, "agents", func(ctx context.Context) ([]string, error) {
+			_, extra, _, err := opts.loadConfigWithInteractions()
+			if err != nil {
+				return nil, err
+			}
+			seen := map[string]string{}
+			for _, b := range collectHandlerBindings(extra.Interactions) {
+				seen[b.Route.Agent] = "configured handler"
+			}
+			if b, err := openAgentBroker(ctx, opts, "", ""); err == nil {
+				if agents, err := b.Registry().List(ctx); err == nil {
+					for _, a := range agents {
+						seen[a.Agent] = "running on " + a.Hostname
+					}
+				}
+				_ = b.Close()
+			}
+			items := make([]string, 0, len(seen))
+			for name, desc := range seen {
+				items = append(items, name+"\t"+desc)
+			}
+			sort.Strings(items)
+			return items, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError | cobra.ShellCompDirectiveNoFileComp
+		}
+		return filterCompletions(items, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// filterCompletions keeps items whose value (before the tab-separated
+// description) starts with prefix, case-insensitively.
+func filterCompletions(items []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		value, _, _ := strings.Cut(item, "\t")
+		if strings.HasPrefix(strings.ToLower(value), prefix) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+type completionCacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Items     []string  `json:"items"`
+}
+
+func defaultCompletionCachePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "vibe", "discord-completion.json")
+}
+
+// cachedCompletions returns the cached items for key when younger than
+// completionCacheTTL, else fetches and stores them. Keys are scoped by config
+// path and profile so switching bots doesn't serve stale names. Cache write
+// failures are ignored; completion still works, just uncached.
+func cachedCompletions(opts *globalOptions, key string, fetch func(context.Context) ([]string, error)) ([]string, error) {
+	key = opts.configPath + "|" + opts.profile + "|" + key
+	path := completionCachePathFn()
+	entries := map[string]completionCacheEntry{}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &entries)
+	}
+	if entry, ok := entries[key]; ok && time.Since(entry.FetchedAt) < completionCacheTTL {
+		return entry.Items, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	items, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for k, entry := range entries {
+		if now.Sub(entry.FetchedAt) >= completionCacheTTL {
+			delete(entries, k)
+		}
+	}
+	entries[key] = completionCacheEntry{FetchedAt: now, Items: items}
+	if data, err := json.Marshal(entries); err == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			_ = os.WriteFile(path, data, 0o600)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func hookCompletion(t *testing.T, userSvc *fakeUserService) {
+	t.Helper()
+	guildSvc := &fakeGuildService{channels: map[string][]*types.Channel{
+		"111111111111111111": {{ID: "500000000000000001", Name: "deploys"}, {ID: "500000000000000002", Name: "general"}},
+	}}
+	cfg := testConfig()
+	cfg.Discord.DefaultGuildID = "111111111111111111"
+	cfg.Discord.Webhooks["alerts"] = "https://example.com/alerts"
+	hookBot(t, cfg, &fakeBotClient{messageSvc: &fakeMessageService{}, guildSvc: guildSvc, userSvc: userSvc})
+
+	cachePath := filepath.Join(t.TempDir(), "completion.json")
+	completionCachePathFn = func() string { return cachePath }
+	t.Cleanup(func() { completionCachePathFn = defaultCompletionCachePath })
+}
+
+// complete runs cobra's hidden __complete command and returns the suggested
+// lines without the trailing directive.
+func complete(t *testing.T, args ...string) []string {
+	t.Helper()
+	root := NewRootCmd()
+	root.SetArgs(append([]string{"__complete"}, args...))
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetErr(&bytes.Buffer{})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("complete %v: %v", args, err)
+	}
+	var out []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.HasPrefix(line, ":") && line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func TestCompleteGuildNames(t *testing.T) {
+	userSvc := &fakeUserService{guilds: []*types.Guild{
+		{ID: "111111111111111111", Name: "Arc Labs"},
+		{ID: "222222222222222222", Name: "Sandbox"},
+	}}
+	hookCompletion(t, userSvc)
+
+	got := complete(t, "guild", "get", "--guild", "ar")
+	if len(got) != 1 || got[0] != "Arc Labs\t111111111111111111" {
+		t.Fatalf("unexpected guild completions: %q", got)
+	}
+
+	// A second <TAB> within the TTL is served from the disk cache.
+	userSvc.guilds = nil
+	if got := complete(t, "guild", "get", "--guild", ""); len(got) != 2 {
+		t.Fatalf("expected cached guilds, got %q", got)
+	}
+}
+
+func TestCompleteChannelNamesInDefaultGuild(t *testing.T) {
+	hookCompletion(t, &fakeUserService{})
+	got := complete(t, "message", "list", "--channel", "#dep")
+	if len(got) != 1 || !strings.HasPrefix(got[0], "deploys\t500000000000000001") {
+		t.Fatalf("unexpected channel completions: %q", got)
+	}
+}
+
+func TestCompleteWebhookNames(t *testing.T) {
+	hookCompletion(t, &fakeUserService{})
+	got := complete(t, "webhook", "send", "--webhook", "")
+	if strings.Join(got, ",") != "alerts,default" {
+		t.Fatalf("unexpected webhook completions: %q", got)
+	}
+}
+
+func TestCompleteSkipsRawIDCommands(t *testing.T) {
+	hookCompletion(t, &fakeUserService{guilds: []*types.Guild{{ID: "1", Name: "Arc Labs"}}})
+	if got := complete(t, "interaction", "simulate", "--guild", ""); len(got) != 0 {
+		t.Fatalf("simulate should not complete guild names, got %q", got)
+	}
+}
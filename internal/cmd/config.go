@@ -23,8 +23,11 @@ type globalOptions struct {
 	profile         string
 	environment     string
 	rateStrategy    string
+	locale          string
+	timezone        string
 	appliedProfile  string
 	appliedEnv      string
+	envOnly         bool
 }
 
 var (
@@ -34,8 +37,15 @@ var (
 )
 
 func (o *globalOptions) loadConfig() (*discordconfig.Config, string, error) {
-	cfg, path, err := loadDiscordConfigFn(o.configPath)
-	if err != nil {
+	var (
+		cfg  *discordconfig.Config
+		path string
+		err  error
+	)
+	if o.envOnlyMode() {
+		// Default() reads DISCORD_BOT_TOKEN and friends from the environment.
+		cfg = discordconfig.Default()
+	} else if cfg, path, err = loadDiscordConfigFn(o.configPath); err != nil {
 		return nil, path, err
 	}
 	if err := o.applyProfile(cfg); err != nil {
@@ -49,6 +59,10 @@ func (o *globalOptions) loadConfig() (*discordconfig.Config, string, error) {
 	}
 	if o.tokenOverride != "" {
 		cfg.Discord.BotToken = o.tokenOverride
+		cfg.Discord.BotTokens = nil
+	}
+	if tokens := cfg.Discord.Tokens(); cfg.Discord.BotToken == "" && len(tokens) > 0 {
+		cfg.Discord.BotToken = tokens[0]
 	}
 	if o.webhookOverride != "" && cfg.Discord.Webhooks == nil {
 		cfg.Discord.Webhooks = map[string]string{"override": o.webhookOverride}
@@ -73,6 +87,9 @@ func (o *globalOptions) applyProfile(cfg *discordconfig.Config) error {
 	if profile.Client != nil {
 		cfg.Client = *profile.Client
 		ensureRateLimitDefaults(&cfg.Client)
+		if cfg.Client.APIVersion == 0 {
+			cfg.Client.APIVersion = discordconfig.DefaultAPIVersion
+		}
 	}
 	o.appliedProfile = o.profile
 	return nil
@@ -125,9 +142,16 @@ func loadDiscordConfig(path string) (*discordconfig.Config, string, error) {
 		if err != nil {
 			return nil, expanded, fmt.Errorf("failed to load Discord config %s: %w", expanded, err)
 		}
+		if err := resolveConfigSecrets(cfg); err != nil {
+			return nil, expanded, fmt.Errorf("failed to resolve secrets in %s: %w", expanded, err)
+		}
 		return cfg, expanded, nil
 	}
-	return discordconfig.Default(), "", nil
+	cfg := discordconfig.Default()
+	if err := resolveConfigSecrets(cfg); err != nil {
+		return nil, "", fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+	return cfg, "", nil
 }
 
 func orderedConfigPaths(explicit string) []string {
@@ -208,13 +232,18 @@ func (o *globalOptions) loadConfigWithInteractions() (*discordconfig.Config, *in
 
 type interactionConfigFile struct {
 	Discord struct {
-		PublicKey string `yaml:"public_key"`
-		PublicURL string `yaml:"public_url"`
+		PublicKey string   `yaml:"public_key"`
+		PublicURL string   `yaml:"public_url"`
+		Intents   []string `yaml:"intents"`
 	} `yaml:"discord"`
-	Server       serverConfig       `yaml:"server"`
-	Redis        redisConfig        `yaml:"redis"`
-	Tunnel       tunnelConfig       `yaml:"tunnel"`
-	Interactions interactionsConfig `yaml:"interactions"`
+	Server       serverConfig         `yaml:"server"`
+	Redis        redisConfig          `yaml:"redis"`
+	Broker       brokerSettings       `yaml:"broker"`
+	Kafka        kafkaConfig          `yaml:"kafka"`
+	Tunnel       tunnelConfig         `yaml:"tunnel"`
+	Interactions interactionsConfig   `yaml:"interactions"`
+	Jobs         map[string]jobConfig `yaml:"jobs"`
+	Events       eventsConfig         `yaml:"events"`
 }
 
 func loadInteractionSettings(path string) (*interactionSettings, error) {
@@ -234,21 +263,107 @@ func loadInteractionSettings(path string) (*interactionSettings, error) {
 		if extras.Discord.PublicURL != "" {
 			settings.PublicURL = strings.TrimSpace(extras.Discord.PublicURL)
 		}
+		if len(extras.Discord.Intents) > 0 {
+			settings.Intents = extras.Discord.Intents
+		}
 		if extras.Server.ListenAddr != "" {
 			settings.Server.ListenAddr = extras.Server.ListenAddr
 		}
+		if extras.Server.TLSCert != "" {
+			settings.Server.TLSCert = utils.ExpandPath(extras.Server.TLSCert)
+		}
+		if extras.Server.TLSKey != "" {
+			settings.Server.TLSKey = utils.ExpandPath(extras.Server.TLSKey)
+		}
+		if extras.Server.ACMEDomain != "" {
+			settings.Server.ACMEDomain = strings.TrimSpace(extras.Server.ACMEDomain)
+		}
+		if extras.Server.ACMEEmail != "" {
+			settings.Server.ACMEEmail = extras.Server.ACMEEmail
+		}
+		if extras.Server.ACMECacheDir != "" {
+			settings.Server.ACMECacheDir = utils.ExpandPath(extras.Server.ACMECacheDir)
+		}
+		if extras.Server.DebugAddr != "" {
+			settings.Server.DebugAddr = strings.TrimSpace(extras.Server.DebugAddr)
+		}
+		if extras.Server.DrainTimeout > 0 {
+			settings.Server.DrainTimeout = extras.Server.DrainTimeout
+		}
+		if extras.Server.ReadTimeout != 0 {
+			settings.Server.ReadTimeout = extras.Server.ReadTimeout
+		}
+		if extras.Server.WriteTimeout != 0 {
+			settings.Server.WriteTimeout = extras.Server.WriteTimeout
+		}
+		if extras.Server.IdleTimeout != 0 {
+			settings.Server.IdleTimeout = extras.Server.IdleTimeout
+		}
+		if extras.Server.MaxBodyBytes != 0 {
+			settings.Server.MaxBodyBytes = extras.Server.MaxBodyBytes
+		}
+		if len(extras.Server.AllowedCIDRs) > 0 {
+			settings.Server.AllowedCIDRs = extras.Server.AllowedCIDRs
+		}
+		if extras.Server.TimestampWindow > 0 {
+			settings.Server.TimestampWindow = extras.Server.TimestampWindow
+		}
+		if extras.Server.ClockSkew > 0 {
+			settings.Server.ClockSkew = extras.Server.ClockSkew
+		}
+		if auth := extras.Server.Auth; auth.enabled() || auth.BearerTokenEnv != "" || len(auth.PublicPaths) > 0 {
+			if auth.ClientCA != "" {
+				auth.ClientCA = utils.ExpandPath(auth.ClientCA)
+			}
+			settings.Server.Auth = auth
+		}
 		if extras.Redis.Addr != "" {
 			settings.Redis.Addr = extras.Redis.Addr
 		}
 		if extras.Redis.DB != 0 {
 			settings.Redis.DB = extras.Redis.DB
 		}
+		if extras.Redis.Username != "" {
+			settings.Redis.Username = extras.Redis.Username
+		}
 		if extras.Redis.Password != "" {
 			settings.Redis.Password = extras.Redis.Password
 		}
+		if tlsCfg := extras.Redis.TLS; tlsCfg.enabled() || tlsCfg.InsecureSkipVerify || tlsCfg.ServerName != "" {
+			for _, path := range []*string{&tlsCfg.CAFile, &tlsCfg.CertFile, &tlsCfg.KeyFile} {
+				if *path != "" {
+					*path = utils.ExpandPath(*path)
+				}
+			}
+			settings.Redis.TLS = tlsCfg
+		}
 		if extras.Redis.ChannelPrefix != "" {
 			settings.Redis.ChannelPrefix = extras.Redis.ChannelPrefix
 		}
+		if batch := extras.Redis.PublishBatch; batch.Size != 0 || batch.Interval != 0 {
+			settings.Redis.PublishBatch = batch
+		}
+		if extras.Broker.Backend != "" {
+			settings.Broker.Backend = strings.TrimSpace(extras.Broker.Backend)
+		}
+		if len(extras.Broker.Options) > 0 {
+			settings.Broker.Options = extras.Broker.Options
+		}
+		if len(extras.Kafka.Brokers) > 0 {
+			settings.Kafka.Brokers = extras.Kafka.Brokers
+		}
+		if extras.Kafka.Topic != "" {
+			settings.Kafka.Topic = extras.Kafka.Topic
+		}
+		if extras.Kafka.ClientID != "" {
+			settings.Kafka.ClientID = extras.Kafka.ClientID
+		}
+		if extras.Kafka.BatchSize > 0 {
+			settings.Kafka.BatchSize = extras.Kafka.BatchSize
+		}
+		if extras.Kafka.BatchTimeout > 0 {
+			settings.Kafka.BatchTimeout = extras.Kafka.BatchTimeout
+		}
 		if extras.Tunnel.Provider != "" {
 			settings.Tunnel.Provider = extras.Tunnel.Provider
 		}
@@ -261,7 +376,22 @@ func loadInteractionSettings(path string) (*interactionSettings, error) {
 		if !extras.Interactions.Enabled {
 			settings.Interactions.Enabled = false
 		}
+		if len(extras.Interactions.Middleware) > 0 {
+			settings.Interactions.Middleware = extras.Interactions.Middleware
+		}
+		if len(extras.Interactions.Rules) > 0 {
+			settings.Interactions.Rules = extras.Interactions.Rules
+		}
+		settings.Interactions.Delivery = extras.Interactions.Delivery
+		settings.Interactions.ReplyQueue = extras.Interactions.ReplyQueue
 		mergeHandlerMappings(&settings.Interactions, extras.Interactions.Handlers)
+		if len(extras.Jobs) > 0 {
+			settings.Jobs = extras.Jobs
+		}
+		settings.Events = extras.Events
+		if err := resolveSettingsSecrets(settings); err != nil {
+			return nil, fmt.Errorf("resolve secrets in discord config: %w", err)
+		}
 	}
 
 	if val := strings.TrimSpace(os.Getenv(envDiscordPublicKey)); val != "" {
@@ -270,12 +400,24 @@ func loadInteractionSettings(path string) (*interactionSettings, error) {
 	if val := strings.TrimSpace(os.Getenv(envDiscordPublicURL)); val != "" {
 		settings.PublicURL = val
 	}
+	if val := strings.TrimSpace(os.Getenv(envKafkaBrokers)); val != "" {
+		settings.Kafka.Brokers = splitKafkaBrokers(val)
+	}
+	if val := strings.TrimSpace(os.Getenv(envKafkaTopic)); val != "" {
+		settings.Kafka.Topic = val
+	}
 	if val := strings.TrimSpace(os.Getenv(envTunnelProvider)); val != "" {
 		settings.Tunnel.Provider = val
 	}
 	if val := strings.TrimSpace(os.Getenv(envNgrokAuthToken)); val != "" {
 		settings.Tunnel.NgrokAuthToken = val
 	}
+	if val := strings.TrimSpace(os.Getenv(envListenAddr)); val != "" {
+		settings.Server.ListenAddr = val
+	}
+	if err := applyEnvHandlers(&settings.Interactions); err != nil {
+		return nil, err
+	}
 	if settings.Server.ListenAddr == "" {
 		settings.Server.ListenAddr = defaultListenAddr
 	}
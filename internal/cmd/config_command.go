@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
@@ -26,6 +28,7 @@ Configuration file search order (use first found):
 
 Configuration Fields:
   • bot_token - Discord bot authentication token
+  • bot_tokens - Fallback tokens, tried in order when Discord rejects bot_token with 401
   • application_id - Discord application ID
   • default_guild_id - Default guild to use for guild commands (optional)
   • default_channel_id - Default channel for message send (optional)
@@ -37,13 +40,31 @@ Environment variables (override config values):
   • DISCORD_DEFAULT_GUILD_ID - Default guild ID
   • DISCORD_DEFAULT_CHANNEL_ID - Default channel ID
   • DISCORD_WEBHOOK - Default webhook URL
-  • DISCORD_RATE_LIMIT_STRATEGY - Rate limit strategy`,
+  • DISCORD_RATE_LIMIT_STRATEGY - Rate limit strategy
+
+Secret references (resolved when the config loads, so tokens stay out of the file):
+  • ${env:NAME} - Environment variable
+  • ${file:/run/secrets/token} - File contents (trailing newline dropped)
+  • ${vault:secret/discord#token} - Field from vault kv get
+  • ${op://vault/item/field} - 1Password reference via op read
+They work for the discord section (tokens, IDs, webhooks), profiles, environments, public_key,
+redis credentials, tunnel.ngrok_auth_token, and server.auth.bearer_tokens. Values encrypted with
+"config encrypt" (enc:age:... or enc:keychain:...) are decrypted the same way.
+
+Use "config rotate-token" to swap in a new bot token once it has been verified.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
 	cmd.AddCommand(configShowCmd(opts))
+	cmd.AddCommand(configValidateCmd(opts))
+	cmd.AddCommand(configGetCmd(opts))
+	cmd.AddCommand(configSetCmd(opts))
+	cmd.AddCommand(configUnsetCmd(opts))
+	cmd.AddCommand(configEncryptCmd(opts))
+	cmd.AddCommand(configDecryptCmd(opts))
+	cmd.AddCommand(configRotateTokenCmd(opts))
 	return cmd
 }
 
@@ -123,11 +144,36 @@ func runConfigShow(cmd *cobra.Command, opts *globalOptions, output output.Output
 		"profile":             valueOrDash(opts.appliedProfile),
 		"environment":         valueOrDash(opts.appliedEnv),
 		"rate_limit_strategy": cfg.Client.RateLimit.Strategy,
+		"api_version":         apiVersionLabel(cfg.Client.APIVersion),
+		"feature_overrides":   formatFeatureOverrides(cfg.Client.Features),
 	}
 
 	return renderOutput(cmd, output, payload, keyValueTable(summary))
 }
 
+func apiVersionLabel(version int) string {
+	if version == 0 {
+		version = discordconfig.DefaultAPIVersion
+	}
+	return fmt.Sprintf("v%d", version)
+}
+
+func formatFeatureOverrides(features map[string]bool) string {
+	if len(features) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%t", name, features[name]))
+	}
+	return strings.Join(parts, ",")
+}
+
 func maskToken(token string) string {
 	if token == "" {
 		return ""
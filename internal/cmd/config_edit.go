@@ -0,0 +1,421 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-sdk/utils"
+	"gopkg.in/yaml.v3"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// secretConfigKeys are masked by config get unless --show-secrets is set.
+// Webhook URLs carry their token, so every entry under a webhooks map is
+// masked as well.
+var secretConfigKeys = map[string]bool{
+	"bot_token":        true,
+	"bot_tokens":       true,
+	"password":         true,
+	"ngrok_auth_token": true,
+	"bearer_tokens":    true,
+}
+
+func isSecretConfigKey(keys []string) bool {
+	for i, key := range keys {
+		if secretConfigKeys[key] || (key == "webhooks" && i < len(keys)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func configGetCmd(opts *globalOptions) *cobra.Command {
+	var file string
+	var showSecrets bool
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print one value from discord.yaml",
+		Long: `Print the value at a dotted key path such as redis.addr or discord.webhooks.alerts. Scalars
+print bare, for use in scripts; sections print as YAML. List items are addressed by index
+(server.allowed_cidrs.0).
+
+Tokens, passwords, and webhook URLs are masked; pass --show-secrets to print them.`,
+		Args: cobra.ExactArgs(1),
+		Example: `Example:
+  arc-discord config get redis.addr
+
+Example:
+  TOKEN=$(arc-discord config get discord.bot_token --show-secrets)`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, root, err := loadConfigDocument(opts, file, false)
+			if err != nil {
+				return err
+			}
+			keys := splitConfigKey(args[0])
+			node, err := configNodeAt(root, keys)
+			if err != nil {
+				return &arcer.CLIError{Msg: fmt.Sprintf("%s: %v", path, err)}
+			}
+			if !showSecrets {
+				node = maskSecrets(node, keys)
+			}
+			if node.Kind == yaml.ScalarNode {
+				cmd.Println(node.Value)
+				return nil
+			}
+			data, err := encodeConfigNode(node)
+			if err != nil {
+				return err
+			}
+			cmd.Print(string(data))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "Config file (default: the file other commands load)")
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Print tokens, passwords, and webhook URLs unmasked")
+	return cmd
+}
+
+func configSetCmd(opts *globalOptions) *cobra.Command {
+	var file string
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set one value in discord.yaml, keeping comments",
+		Long: `Set the value at a dotted key path, creating missing sections, and rewrite the file in place.
+Comments and the order of keys are kept, so setup scripts can edit the file without yq.
+
+The value is read as YAML: 123 and "123" both work for IDs, and [a, b] sets a list. A change that
+"config validate" would flag (a misspelled key, a bad duration) is refused unless --force is set.
+When no config file exists yet, one is created at --file, --config, or ~/.config/arc/discord.yaml.`,
+		Args: cobra.ExactArgs(2),
+		Example: `Example:
+  arc-discord config set discord.default_channel_id 123456789012345678
+
+Example:
+  arc-discord config set server.allowed_cidrs "[10.0.0.0/8]"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, root, err := loadConfigDocument(opts, file, true)
+			if err != nil {
+				return err
+			}
+			value, err := parseConfigValue(args[1])
+			if err != nil {
+				return &arcer.CLIError{Msg: fmt.Sprintf("invalid value %q", args[1]), Hint: "values are YAML; quote strings that contain ': ' or start with [ or {"}
+			}
+			if err := setConfigNode(root, splitConfigKey(args[0]), value); err != nil {
+				return &arcer.CLIError{Msg: fmt.Sprintf("%s: %v", path, err)}
+			}
+			if err := writeConfigDocument(path, root, force); err != nil {
+				return err
+			}
+			cmd.Printf("Set %s in %s\n", args[0], path)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "Config file (default: the file other commands load)")
+	cmd.Flags().BoolVar(&force, "force", false, "Write the change even if config validate would flag it")
+	return cmd
+}
+
+func configUnsetCmd(opts *globalOptions) *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove one key from discord.yaml, keeping comments",
+		Args:  cobra.ExactArgs(1),
+		Example: `Example:
+  arc-discord config unset discord.webhooks.staging`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, root, err := loadConfigDocument(opts, file, false)
+			if err != nil {
+				return err
+			}
+			if err := unsetConfigNode(root, splitConfigKey(args[0])); err != nil {
+				return &arcer.CLIError{Msg: fmt.Sprintf("%s: %v", path, err)}
+			}
+			if err := writeConfigDocument(path, root, true); err != nil {
+				return err
+			}
+			cmd.Printf("Removed %s from %s\n", args[0], path)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "Config file (default: the file other commands load)")
+	return cmd
+}
+
+// loadConfigDocument reads the config file as a YAML node tree, which keeps
+// comments and key order. With create set, a missing file yields an empty
+// document at the path it would be written to.
+func loadConfigDocument(opts *globalOptions, file string, create bool) (string, *yaml.Node, error) {
+	path := file
+	if path == "" {
+		path = findConfigFile(opts.configPath)
+	}
+	if path == "" && create {
+		path = opts.configPath
+		if path == "" {
+			path = orderedConfigPaths("")[0]
+		}
+	}
+	if path == "" {
+		return "", nil, &arcer.CLIError{Msg: "no discord.yaml found", Hint: "pass --file or --config; config show lists the search order"}
+	}
+	path = utils.ExpandPath(path)
+	data, err := os.ReadFile(path)
+	if err != nil && !(create && os.IsNotExist(err)) {
+		return "", nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", nil, (&arcer.CLIError{Msg: fmt.Sprintf("invalid YAML in %s", path)}).WithCause(err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	return path, &doc, nil
+}
+
+// writeConfigDocument re-encodes the document and replaces the file,
+// keeping its permissions (new files are private: they hold tokens). Unless
+// force is set, changes that introduce validation problems are refused.
+func writeConfigDocument(path string, doc *yaml.Node, force bool) error {
+	data, err := encodeConfigNode(doc)
+	if err != nil {
+		return err
+	}
+	if !force {
+		before, _ := os.ReadFile(path)
+		if added := newConfigProblems(before, data); len(added) > 0 {
+			msgs := make([]string, 0, len(added))
+			for _, p := range added {
+				msgs = append(msgs, p.Problem)
+			}
+			return &arcer.CLIError{Msg: "refusing to write: " + strings.Join(msgs, "; "), Hint: "check the key for typos, or pass --force"}
+		}
+	}
+	mode := os.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to write %s", path)}).WithCause(err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, mode); err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to write %s", path)}).WithCause(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to write %s", path)}).WithCause(err)
+	}
+	return nil
+}
+
+// newConfigProblems lists problems in after that before did not have, so an
+// edit is not blamed for mistakes already in the file.
+func newConfigProblems(before, after []byte) []configProblem {
+	seen := map[string]int{}
+	for _, p := range validateConfigData(before) {
+		seen[p.Field+"\x00"+p.Problem]++
+	}
+	var added []configProblem
+	for _, p := range validateConfigData(after) {
+		key := p.Field + "\x00" + p.Problem
+		if seen[key] > 0 {
+			seen[key]--
+			continue
+		}
+		added = append(added, p)
+	}
+	return added
+}
+
+func encodeConfigNode(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func splitConfigKey(key string) []string {
+	return strings.Split(strings.Trim(key, "."), ".")
+}
+
+// parseConfigValue reads a command-line value as YAML. Long digit strings
+// (snowflakes) are quoted so no tool reading the file mistakes them for
+// numbers that do not fit a float.
+func parseConfigValue(raw string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ""}, nil
+	}
+	value := doc.Content[0]
+	if value.Kind == yaml.ScalarNode && value.Tag == "!!int" && len(value.Value) > 15 && isSnowflake(value.Value) {
+		value.Tag, value.Style = "!!str", yaml.DoubleQuotedStyle
+	}
+	return value, nil
+}
+
+// configNodeAt follows keys from the document root: mapping keys, or list
+// indexes.
+func configNodeAt(doc *yaml.Node, keys []string) (*yaml.Node, error) {
+	node := doc.Content[0]
+	for i, key := range keys {
+		child, _, err := configChild(node, key)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			return nil, fmt.Errorf("%s is not set", strings.Join(keys[:i+1], "."))
+		}
+		node = child
+	}
+	return node, nil
+}
+
+// configChild returns the value under key in a mapping or list and its
+// position in node.Content (-1 when absent).
+func configChild(node *yaml.Node, key string) (*yaml.Node, int, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], i, nil
+			}
+		}
+		return nil, -1, nil
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 {
+			return nil, -1, fmt.Errorf("%q is not a list index", key)
+		}
+		if idx >= len(node.Content) {
+			return nil, -1, nil
+		}
+		return node.Content[idx], idx, nil
+	default:
+		return nil, -1, fmt.Errorf("cannot look up %q inside a scalar value", key)
+	}
+}
+
+func setConfigNode(doc *yaml.Node, keys []string, value *yaml.Node) error {
+	node := doc.Content[0]
+	for i, key := range keys {
+		child, pos, err := configChild(node, key)
+		if err != nil {
+			return err
+		}
+		last := i == len(keys)-1
+		switch {
+		case child != nil && last:
+			// Keep the comments attached to the old value.
+			value.HeadComment, value.LineComment, value.FootComment = child.HeadComment, child.LineComment, child.FootComment
+			if child.Kind == yaml.ScalarNode && value.Kind == yaml.ScalarNode && value.Style == 0 && child.Style != 0 && child.Tag == "!!str" {
+				value.Style, value.Tag = child.Style, "!!str"
+			}
+			if node.Kind == yaml.MappingNode {
+				node.Content[pos+1] = value
+			} else {
+				node.Content[pos] = value
+			}
+			return nil
+		case child != nil:
+			if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+				// An empty section ("redis:") becomes a mapping.
+				child.Kind, child.Tag, child.Value, child.Style = yaml.MappingNode, "!!map", "", 0
+			}
+			node = child
+		case node.Kind == yaml.SequenceNode:
+			return fmt.Errorf("list index %s is out of range", strings.Join(keys[:i+1], "."))
+		default:
+			next := value
+			if !last {
+				next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, next)
+			node = next
+		}
+	}
+	return nil
+}
+
+func unsetConfigNode(doc *yaml.Node, keys []string) error {
+	parent, err := configNodeAt(doc, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	key := keys[len(keys)-1]
+	child, pos, err := configChild(parent, key)
+	if err != nil {
+		return err
+	}
+	if child == nil {
+		return fmt.Errorf("%s is not set", strings.Join(keys, "."))
+	}
+	if parent.Kind == yaml.MappingNode {
+		parent.Content = append(parent.Content[:pos], parent.Content[pos+2:]...)
+	} else {
+		parent.Content = append(parent.Content[:pos], parent.Content[pos+1:]...)
+	}
+	return nil
+}
+
+// maskSecrets returns node, found at keys, with the secrets inside it
+// masked.
+func maskSecrets(node *yaml.Node, keys []string) *yaml.Node {
+	if isSecretConfigKey(keys) {
+		return maskConfigNode(node)
+	}
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode {
+		return node
+	}
+	masked := *node
+	masked.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		switch {
+		case node.Kind == yaml.SequenceNode:
+			masked.Content[i] = maskSecrets(child, keys)
+		case i%2 == 0:
+			masked.Content[i] = child
+		default:
+			childKeys := append(append([]string{}, keys...), node.Content[i-1].Value)
+			masked.Content[i] = maskSecrets(child, childKeys)
+		}
+	}
+	return &masked
+}
+
+// maskConfigNode returns a copy of node with every scalar masked.
+func maskConfigNode(node *yaml.Node) *yaml.Node {
+	masked := *node
+	if node.Kind == yaml.ScalarNode {
+		masked.Value = maskToken(node.Value)
+		return &masked
+	}
+	masked.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			masked.Content[i] = child
+			continue
+		}
+		masked.Content[i] = maskConfigNode(child)
+	}
+	return &masked
+}
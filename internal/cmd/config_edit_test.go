@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const editableConfig = `# Arc Discord config
+discord:
+  bot_token: "abcdefghijklmnop" # from the developer portal
+  application_id: "YOUR_APPLICATION_ID"
+  webhooks:
+    default: "https://discord.com/api/webhooks/1/token"
+redis:
+  addr: 127.0.0.1:6379
+`
+
+func runConfigEdit(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := configCmd(&globalOptions{})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigSetKeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte(editableConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runConfigEdit(t, "set", "discord.application_id", "123456789012345678", "--file", path); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	if _, err := runConfigEdit(t, "set", "server.allowed_cidrs", "[10.0.0.0/8]", "--file", path); err != nil {
+		t.Fatalf("config set list: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{"# Arc Discord config", "# from the developer portal", `application_id: "123456789012345678"`, "allowed_cidrs: [10.0.0.0/8]"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("missing %q in:\n%s", want, got)
+		}
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Fatalf("permissions changed to %v", info.Mode().Perm())
+	}
+
+	if _, err := runConfigEdit(t, "set", "discord.default_chanel_id", "1", "--file", path); err == nil || !strings.Contains(err.Error(), "unknown field default_chanel_id") {
+		t.Fatalf("expected typo to be refused, got %v", err)
+	}
+
+	if _, err := runConfigEdit(t, "unset", "redis.addr", "--file", path); err != nil {
+		t.Fatalf("config unset: %v", err)
+	}
+	if _, err := runConfigEdit(t, "get", "redis.addr", "--file", path); err == nil {
+		t.Fatal("expected unset key to be missing")
+	}
+}
+
+func TestConfigGetMasksSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte(editableConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runConfigEdit(t, "get", "discord", "--file", path)
+	if err != nil {
+		t.Fatalf("config get: %v", err)
+	}
+	if strings.Contains(out, "abcdefghijklmnop") || strings.Contains(out, "webhooks/1/token") || !strings.Contains(out, "YOUR_APPLICATION_ID") {
+		t.Fatalf("secrets not masked:\n%s", out)
+	}
+	out, err = runConfigEdit(t, "get", "discord.bot_token", "--show-secrets", "--file", path)
+	if err != nil || strings.TrimSpace(out) != "abcdefghijklmnop" {
+		t.Fatalf("expected raw token, got %q (%v)", out, err)
+	}
+}
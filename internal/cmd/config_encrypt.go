@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/chacha20poly1305"
+	"gopkg.in/yaml.v3"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// Encrypted config values look like enc:<backend>:<base64>. The age backend
+// stores the binary output of the age CLI; the keychain backend stores
+// nonce||ciphertext sealed with a key kept in the OS keychain.
+const (
+	encryptedValuePrefix = "enc:"
+	encBackendAge        = "age"
+	encBackendKeychain   = "keychain"
+	envAgeIdentity       = "ARC_DISCORD_AGE_IDENTITY"
+	keychainService      = "arc-discord"
+	keychainAccount      = "config-key"
+)
+
+// encryptionAAD binds ciphertexts to this use so they cannot be replayed
+// into another tool sharing the keychain key.
+var encryptionAAD = []byte("arc-discord config")
+
+var keychainKeyCache struct {
+	sync.Mutex
+	key []byte
+}
+
+func configEncryptCmd(opts *globalOptions) *cobra.Command {
+	var file, backend, recipient string
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt tokens and webhook URLs in discord.yaml",
+		Long: `Encrypt every secret value in discord.yaml in place: bot tokens, webhook URLs, the redis password,
+the ngrok token, and server.auth.bearer_tokens. Comments and other values are kept. Values that
+are already encrypted or are secret references (${env:...}) are skipped.
+
+Encrypted values are decrypted transparently whenever the config loads, as long as the key is
+available:
+  • age: the identity file in $ARC_DISCORD_AGE_IDENTITY (default ~/.config/arc/age-identity.txt),
+    read by the age CLI. --recipient defaults to that identity's public key.
+  • keychain: a key kept in the macOS keychain (security) or the Secret Service (secret-tool),
+    created on first use.
+
+Use "config decrypt" to turn the values back into plaintext.`,
+		Example: `Example:
+  age-keygen -o ~/.config/arc/age-identity.txt
+  arc-discord config encrypt
+
+Example:
+  arc-discord config encrypt --backend keychain --file config/discord.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if backend != encBackendAge && backend != encBackendKeychain {
+				return &arcer.CLIError{Msg: fmt.Sprintf("unknown backend %q", backend), Hint: "use age or keychain"}
+			}
+			path, root, err := loadConfigDocument(opts, file, false)
+			if err != nil {
+				return err
+			}
+			if backend == encBackendAge && recipient == "" {
+				if recipient, err = ageRecipient(); err != nil {
+					return &arcer.CLIError{Msg: err.Error(), Hint: "create an identity with age-keygen -o " + ageIdentityPath() + ", or pass --recipient"}
+				}
+			}
+			count := 0
+			err = walkSecretScalars(root.Content[0], nil, func(field string, node *yaml.Node) error {
+				if node.Value == "" || isLoadTimeValue(node.Value) {
+					return nil
+				}
+				encrypted, err := encryptConfigValue(backend, recipient, node.Value)
+				if err != nil {
+					return fmt.Errorf("%s: %w", field, err)
+				}
+				node.Value, node.Tag, node.Style = encrypted, "!!str", 0
+				count++
+				return nil
+			})
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to encrypt config"}).WithCause(err)
+			}
+			if count == 0 {
+				cmd.Printf("No plaintext secrets in %s\n", path)
+				return nil
+			}
+			if err := writeConfigDocument(path, root, true); err != nil {
+				return err
+			}
+			cmd.Printf("Encrypted %d value(s) in %s with %s\n", count, path, backend)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "Config file (default: the file other commands load)")
+	cmd.Flags().StringVar(&backend, "backend", encBackendAge, "Encryption backend: age or keychain")
+	cmd.Flags().StringVar(&recipient, "recipient", "", "age recipient (age1...) to encrypt to (default: the identity's public key)")
+	return cmd
+}
+
+func configDecryptCmd(opts *globalOptions) *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "decrypt",
+		Short: "Decrypt encrypted values in discord.yaml back to plaintext",
+		Example: `Example:
+  arc-discord config decrypt --file config/discord.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, root, err := loadConfigDocument(opts, file, false)
+			if err != nil {
+				return err
+			}
+			count := 0
+			err = walkEncryptedScalars(root.Content[0], nil, func(field string, node *yaml.Node) error {
+				plain, err := decryptConfigValue(node.Value)
+				if err != nil {
+					return fmt.Errorf("%s: %w", field, err)
+				}
+				node.Value = plain
+				count++
+				return nil
+			})
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to decrypt config"}).WithCause(err)
+			}
+			if count == 0 {
+				cmd.Printf("No encrypted values in %s\n", path)
+				return nil
+			}
+			if err := writeConfigDocument(path, root, true); err != nil {
+				return err
+			}
+			cmd.Printf("Decrypted %d value(s) in %s\n", count, path)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "Config file (default: the file other commands load)")
+	return cmd
+}
+
+// walkSecretScalars calls fn for every scalar under a secret key (see
+// isSecretConfigKey).
+func walkSecretScalars(node *yaml.Node, keys []string, fn func(field string, node *yaml.Node) error) error {
+	return walkConfigScalars(node, keys, func(keys []string, n *yaml.Node) error {
+		if !isSecretConfigKey(keys) {
+			return nil
+		}
+		return fn(strings.Join(keys, "."), n)
+	})
+}
+
+// walkEncryptedScalars calls fn for every encrypted value, wherever it is.
+func walkEncryptedScalars(node *yaml.Node, keys []string, fn func(field string, node *yaml.Node) error) error {
+	return walkConfigScalars(node, keys, func(keys []string, n *yaml.Node) error {
+		if !strings.HasPrefix(n.Value, encryptedValuePrefix) {
+			return nil
+		}
+		return fn(strings.Join(keys, "."), n)
+	})
+}
+
+func walkConfigScalars(node *yaml.Node, keys []string, fn func(keys []string, node *yaml.Node) error) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return fn(keys, node)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childKeys := append(append([]string{}, keys...), node.Content[i].Value)
+			if err := walkConfigScalars(node.Content[i+1], childKeys, fn); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			childKeys := append(append([]string{}, keys...), fmt.Sprint(i))
+			if err := walkConfigScalars(child, childKeys, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func encryptConfigValue(backend, recipient, plaintext string) (string, error) {
+	var sealed []byte
+	switch backend {
+	case encBackendAge:
+		out, err := runSecretCommandInput([]byte(plaintext), "age", "--encrypt", "--recipient", recipient)
+		if err != nil {
+			return "", err
+		}
+		sealed = out
+	case encBackendKeychain:
+		key, err := keychainKey(true)
+		if err != nil {
+			return "", err
+		}
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return "", err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", err
+		}
+		sealed = aead.Seal(nonce, nonce, []byte(plaintext), encryptionAAD)
+	default:
+		return "", fmt.Errorf("unknown encryption backend %q", backend)
+	}
+	return encryptedValuePrefix + backend + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptConfigValue reverses encryptConfigValue.
+func decryptConfigValue(value string) (string, error) {
+	backend, payload, ok := strings.Cut(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	if !ok {
+		return "", errors.New("encrypted value must look like enc:<backend>:<data>")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("encrypted value is not valid base64: %w", err)
+	}
+	switch backend {
+	case encBackendAge:
+		identity := ageIdentityPath()
+		if _, err := os.Stat(identity); err != nil {
+			return "", fmt.Errorf("age identity %s is not available (set %s)", identity, envAgeIdentity)
+		}
+		out, err := runSecretCommandInput(sealed, "age", "--decrypt", "--identity", identity)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	case encBackendKeychain:
+		key, err := keychainKey(false)
+		if err != nil {
+			return "", err
+		}
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return "", err
+		}
+		if len(sealed) < aead.NonceSize() {
+			return "", errors.New("encrypted value is truncated")
+		}
+		plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], encryptionAAD)
+		if err != nil {
+			return "", errors.New("cannot decrypt value with the keychain key (was it encrypted on another machine?)")
+		}
+		return string(plain), nil
+	}
+	return "", fmt.Errorf("unknown encryption backend %q", backend)
+}
+
+func ageIdentityPath() string {
+	if path := strings.TrimSpace(os.Getenv(envAgeIdentity)); path != "" {
+		return path
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "arc", "age-identity.txt")
+}
+
+// ageRecipient derives the public key of the local age identity.
+func ageRecipient() (string, error) {
+	identity := ageIdentityPath()
+	if _, err := os.Stat(identity); err != nil {
+		return "", fmt.Errorf("age identity %s not found", identity)
+	}
+	return runSecretCommand("age-keygen", "-y", identity)
+}
+
+// keychainKey fetches the config key from the OS keychain, creating and
+// storing a random one when create is set and none exists yet.
+func keychainKey(create bool) ([]byte, error) {
+	keychainKeyCache.Lock()
+	defer keychainKeyCache.Unlock()
+	if keychainKeyCache.key != nil {
+		return keychainKeyCache.key, nil
+	}
+
+	var stored string
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		stored, err = runSecretCommand("security", "find-generic-password", "-s", keychainService, "-a", keychainAccount, "-w")
+	case "linux", "freebsd", "openbsd":
+		stored, err = runSecretCommand("secret-tool", "lookup", "service", keychainService, "account", keychainAccount)
+	default:
+		return nil, fmt.Errorf("the keychain backend is not supported on %s; use age", runtime.GOOS)
+	}
+	if err == nil && stored != "" {
+		key, err := hex.DecodeString(strings.TrimSpace(stored))
+		if err != nil || len(key) != chacha20poly1305.KeySize {
+			return nil, fmt.Errorf("keychain entry %s/%s is not a config key", keychainService, keychainAccount)
+		}
+		keychainKeyCache.key = key
+		return key, nil
+	}
+	if !create {
+		return nil, fmt.Errorf("no config key in the keychain (%s/%s)", keychainService, keychainAccount)
+	}
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	encoded := hex.EncodeToString(key)
+	if runtime.GOOS == "darwin" {
+		_, err = runSecretCommand("security", "add-generic-password", "-U", "-s", keychainService, "-a", keychainAccount, "-w", encoded)
+	} else {
+		_, err = runSecretCommandInput([]byte(encoded), "secret-tool", "store", "--label", "arc-discord config key", "service", keychainService, "account", keychainAccount)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store config key in keychain: %w", err)
+	}
+	keychainKeyCache.key = key
+	return key, nil
+}
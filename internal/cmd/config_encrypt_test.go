@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSecretTools stands in for the age CLI and the OS keychain tools.
+func fakeSecretTools(t *testing.T) {
+	t.Helper()
+	var stored string
+	orig := secretCommandFn
+	secretCommandFn = func(_ context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+		switch {
+		case name == "age-keygen":
+			return []byte("age1fakerecipient\n"), nil
+		case name == "age" && args[0] == "--encrypt":
+			return append([]byte("sealed:"), stdin...), nil
+		case name == "age" && args[0] == "--decrypt":
+			return bytes.TrimPrefix(stdin, []byte("sealed:")), nil
+		case name == "secret-tool" && args[0] == "store":
+			stored = string(stdin)
+		case name == "security" && args[0] == "add-generic-password":
+			stored = args[len(args)-1]
+		case name == "secret-tool" || name == "security":
+			return []byte(stored), nil
+		}
+		return nil, nil
+	}
+	keychainKeyCache.key = nil
+	t.Cleanup(func() {
+		secretCommandFn = orig
+		keychainKeyCache.key = nil
+	})
+}
+
+func TestConfigEncryptRoundTrip(t *testing.T) {
+	fakeSecretTools(t)
+	identity := filepath.Join(t.TempDir(), "age-identity.txt")
+	if err := os.WriteFile(identity, []byte("AGE-SECRET-KEY-FAKE\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envAgeIdentity, identity)
+
+	for _, backend := range []string{encBackendAge, encBackendKeychain} {
+		t.Run(backend, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "discord.yaml")
+			data := `discord:
+  bot_token: "plain-bot-token" # keep me
+  application_id: "123"
+  webhooks:
+    default: https://discord.com/api/webhooks/1/secret
+redis:
+  password: ${env:REDIS_PASSWORD}
+`
+			if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := runConfigEdit(t, "encrypt", "--backend", backend, "--file", path); err != nil {
+				t.Fatalf("config encrypt: %v", err)
+			}
+			encrypted, _ := os.ReadFile(path)
+			text := string(encrypted)
+			if strings.Contains(text, "plain-bot-token") || strings.Contains(text, "webhooks/1/secret") {
+				t.Fatalf("secrets left in plaintext:\n%s", text)
+			}
+			if !strings.Contains(text, "enc:"+backend+":") || !strings.Contains(text, "# keep me") ||
+				!strings.Contains(text, `application_id: "123"`) || !strings.Contains(text, "${env:REDIS_PASSWORD}") {
+				t.Fatalf("unexpected encrypted file:\n%s", text)
+			}
+
+			cfg, _, err := loadDiscordConfig(path)
+			if err != nil {
+				t.Fatalf("loadDiscordConfig: %v", err)
+			}
+			if cfg.Discord.BotToken != "plain-bot-token" || cfg.Discord.Webhooks["default"] != "https://discord.com/api/webhooks/1/secret" {
+				t.Fatalf("values not decrypted on load: %+v", cfg.Discord)
+			}
+
+			if _, err := runConfigEdit(t, "decrypt", "--file", path); err != nil {
+				t.Fatalf("config decrypt: %v", err)
+			}
+			decrypted, _ := os.ReadFile(path)
+			if !strings.Contains(string(decrypted), "bot_token: plain-bot-token # keep me") {
+				t.Fatalf("unexpected decrypted file:\n%s", decrypted)
+			}
+		})
+	}
+}
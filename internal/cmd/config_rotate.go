@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func configRotateTokenCmd(opts *globalOptions) *cobra.Command {
+	var file string
+	var keepOld, force bool
+	cmd := &cobra.Command{
+		Use:   "rotate-token [new-token|-]",
+		Short: "Swap a new bot token into discord.yaml after verifying it",
+		Long: `Replace discord.bot_token with a new token. The new token is checked against Discord first, and
+when the old one still works both must belong to the same bot, so a token pasted from the wrong
+application is refused (override with --force).
+
+The old token is retired: it is removed from the file, along with any discord.bot_tokens fallbacks.
+With --keep-old it stays in discord.bot_tokens instead, so processes fail over to it on 401 while
+you roll the new one out; drop it later with "config unset discord.bot_tokens". Discord keeps an
+old token valid until you reset it in the developer portal.
+
+The token is read from stdin when the argument is omitted or "-", which keeps it out of shell
+history. An encrypted bot_token is replaced with a new value encrypted the same way; tokens that
+come from ${...} references must be rotated where they are stored. With --profile, the profile's
+discord section is rotated instead.`,
+		Args: cobra.MaximumNArgs(1),
+		Example: `Example:
+  pbpaste | arc-discord config rotate-token
+
+Example:
+  arc-discord config rotate-token --keep-old --file config/discord.yaml < new-token.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newToken, err := readNewToken(cmd, args)
+			if err != nil {
+				return err
+			}
+			path, root, err := loadConfigDocument(opts, file, false)
+			if err != nil {
+				return err
+			}
+			section := []string{"discord"}
+			if opts.profile != "" {
+				section = []string{"profiles", opts.profile, "discord"}
+			}
+			sectionName := strings.Join(section, ".")
+			discord, err := configNodeAt(root, section)
+			if err != nil || discord.Kind != yaml.MappingNode {
+				return &arcer.CLIError{Msg: fmt.Sprintf("%s has no %s section", path, sectionName)}
+			}
+
+			previous := configuredTokens(discord)
+			for _, raw := range previous {
+				if strings.Contains(raw, "$") {
+					return &arcer.CLIError{
+						Msg:  fmt.Sprintf("%s tokens come from a reference (%s)", sectionName, raw),
+						Hint: "store the new token where the reference points instead",
+					}
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			bot, err := newBotClientFn(discordconfig.Default(), newToken)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to create Discord client"}).WithCause(err)
+			}
+			user, err := bot.Users().GetCurrentUser(ctx)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "Discord rejected the new token", Hint: "copy it again from Bot -> Reset Token in the developer portal"}).WithCause(err)
+			}
+
+			oldValid := false
+			if len(previous) > 0 {
+				oldToken, err := resolveSecretRef(previous[0])
+				if err != nil {
+					return (&arcer.CLIError{Msg: fmt.Sprintf("failed to read the current %s.bot_token", sectionName)}).WithCause(err)
+				}
+				if oldToken == newToken {
+					return &arcer.CLIError{Msg: "the new token is the one already configured"}
+				}
+				if oldBot, err := newBotClientFn(discordconfig.Default(), oldToken); err == nil {
+					if oldUser, err := oldBot.Users().GetCurrentUser(ctx); err == nil {
+						oldValid = true
+						if oldUser.ID != user.ID && !force {
+							return &arcer.CLIError{
+								Msg:  fmt.Sprintf("the new token belongs to %s (%s), not %s (%s)", user.Username, user.ID, oldUser.Username, oldUser.ID),
+								Hint: "pass --force to switch bots",
+							}
+						}
+					}
+				}
+			}
+
+			value := newToken
+			if len(previous) > 0 && strings.HasPrefix(previous[0], encryptedValuePrefix) {
+				if value, err = reencryptLike(previous[0], newToken); err != nil {
+					return (&arcer.CLIError{Msg: "failed to encrypt the new token"}).WithCause(err)
+				}
+			}
+			if err := setConfigNode(root, append(section, "bot_token"), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}); err != nil {
+				return &arcer.CLIError{Msg: fmt.Sprintf("%s: %v", path, err)}
+			}
+			fallbacks := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			if keepOld {
+				for _, raw := range previous {
+					fallbacks.Content = append(fallbacks.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: raw})
+				}
+			}
+			if len(fallbacks.Content) > 0 {
+				err = setConfigNode(root, append(section, "bot_tokens"), fallbacks)
+			} else if child, _, _ := configChild(discord, "bot_tokens"); child != nil {
+				err = unsetConfigNode(root, append(section, "bot_tokens"))
+			}
+			if err != nil {
+				return &arcer.CLIError{Msg: fmt.Sprintf("%s: %v", path, err)}
+			}
+			if err := writeConfigDocument(path, root, false); err != nil {
+				return err
+			}
+
+			cmd.Printf("Rotated %s.bot_token to %s for %s (%s) in %s\n", sectionName, maskToken(newToken), user.Username, user.ID, path)
+			switch {
+			case len(previous) == 0:
+			case keepOld:
+				cmd.Printf("Kept %d old token(s) in %s.bot_tokens as fallbacks; remove them with: arc-discord config unset %s.bot_tokens\n", len(previous), sectionName, sectionName)
+			case oldValid:
+				cmd.Println("The old token still works; reset it in the developer portal to revoke it.")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "Config file (default: the file other commands load)")
+	cmd.Flags().BoolVar(&keepOld, "keep-old", false, "Keep the old token in bot_tokens as a fallback instead of removing it")
+	cmd.Flags().BoolVar(&force, "force", false, "Accept a token for a different bot")
+	return cmd
+}
+
+func readNewToken(cmd *cobra.Command, args []string) (string, error) {
+	if len(args) == 1 && args[0] != "-" {
+		return strings.TrimSpace(args[0]), nil
+	}
+	data, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return "", (&arcer.CLIError{Msg: "failed to read the new token from stdin"}).WithCause(err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", &arcer.CLIError{Msg: "no token given", Hint: "pass the new token as an argument or pipe it on stdin"}
+	}
+	return token, nil
+}
+
+// configuredTokens returns the raw bot_token and bot_tokens values of a
+// discord section, primary first.
+func configuredTokens(discord *yaml.Node) []string {
+	var tokens []string
+	seen := map[string]bool{}
+	add := func(node *yaml.Node) {
+		if node != nil && node.Kind == yaml.ScalarNode && node.Value != "" && !seen[node.Value] {
+			seen[node.Value] = true
+			tokens = append(tokens, node.Value)
+		}
+	}
+	primary, _, _ := configChild(discord, "bot_token")
+	add(primary)
+	if list, _, _ := configChild(discord, "bot_tokens"); list != nil && list.Kind == yaml.SequenceNode {
+		for _, node := range list.Content {
+			add(node)
+		}
+	}
+	return tokens
+}
+
+// reencryptLike encrypts plaintext with the backend old was encrypted with.
+func reencryptLike(old, plaintext string) (string, error) {
+	backend, _, _ := strings.Cut(strings.TrimPrefix(old, encryptedValuePrefix), ":")
+	recipient := ""
+	if backend == encBackendAge {
+		var err error
+		if recipient, err = ageRecipient(); err != nil {
+			return "", err
+		}
+	}
+	return encryptConfigValue(backend, recipient, plaintext)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// hookTokenBots answers GetCurrentUser per token; tokens not in users are
+// rejected as Discord would.
+func hookTokenBots(t *testing.T, users map[string]*types.User) {
+	t.Helper()
+	newBotClientFn = func(_ *discordconfig.Config, token string) (botClient, error) {
+		svc := &fakeUserService{user: users[token]}
+		if svc.user == nil {
+			svc.err = types.ErrUnauthorized
+		}
+		return &fakeBotClient{userSvc: svc}, nil
+	}
+	t.Cleanup(func() { newBotClientFn = createBotClient })
+}
+
+func TestConfigRotateTokenRetiresOld(t *testing.T) {
+	hookTokenBots(t, map[string]*types.User{
+		"old-token-value": {ID: "42", Username: "arc"},
+		"new-token-value": {ID: "42", Username: "arc"},
+	})
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	config := `discord:
+  bot_token: "old-token-value" # from the developer portal
+  bot_tokens: [spare-token-value]
+  application_id: "42"
+`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runConfigEdit(t, "rotate-token", "new-token-value", "--file", path)
+	if err != nil {
+		t.Fatalf("rotate-token: %v", err)
+	}
+	if !strings.Contains(out, "reset it in the developer portal") {
+		t.Fatalf("expected a reminder to reset the old token, got %q", out)
+	}
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	if !strings.Contains(got, `bot_token: "new-token-value" # from the developer portal`) {
+		t.Fatalf("bot_token not replaced in place:\n%s", got)
+	}
+	if strings.Contains(got, "old-token-value") || strings.Contains(got, "bot_tokens") {
+		t.Fatalf("old tokens not retired:\n%s", got)
+	}
+}
+
+func TestConfigRotateTokenKeepOld(t *testing.T) {
+	hookTokenBots(t, map[string]*types.User{"new-token-value": {ID: "42", Username: "arc"}})
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte("discord:\n  bot_token: old-token-value\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := configCmd(&globalOptions{})
+	cmd.SetIn(strings.NewReader("new-token-value\n"))
+	cmd.SetOut(&strings.Builder{})
+	cmd.SetArgs([]string{"rotate-token", "--keep-old", "--file", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rotate-token: %v", err)
+	}
+	cfg, err := discordconfig.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := cfg.Discord.Tokens()
+	if len(tokens) != 2 || tokens[0] != "new-token-value" || tokens[1] != "old-token-value" {
+		t.Fatalf("tokens = %v, want new then old", tokens)
+	}
+}
+
+func TestConfigRotateTokenRefusesOtherBot(t *testing.T) {
+	hookTokenBots(t, map[string]*types.User{
+		"old-token-value": {ID: "42", Username: "arc"},
+		"new-token-value": {ID: "99", Username: "other"},
+	})
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	config := "discord:\n  bot_token: old-token-value\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runConfigEdit(t, "rotate-token", "new-token-value", "--file", path); err == nil || !strings.Contains(err.Error(), "belongs to other") {
+		t.Fatalf("expected a different-bot error, got %v", err)
+	}
+	if _, err := runConfigEdit(t, "rotate-token", "bogus", "--file", path); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Fatalf("expected a rejected-token error, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != config {
+		t.Fatalf("config changed after refused rotation:\n%s", data)
+	}
+}
@@ -0,0 +1,479 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/locale"
+	"github.com/yourorg/arc-sdk/utils"
+	"gopkg.in/yaml.v3"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// configFileSchema is every section discord.yaml may contain: the SDK's
+// config plus the sections loadInteractionSettings reads. config validate
+// decodes into it with unknown fields rejected; the loaders themselves stay
+// lenient so older files keep working.
+type configFileSchema struct {
+	Discord      discordSectionSchema                    `yaml:"discord"`
+	Client       discordconfig.ClientConfig              `yaml:"client"`
+	Logging      discordconfig.LoggingConfig             `yaml:"logging"`
+	Output       discordconfig.OutputConfig              `yaml:"output"`
+	Profiles     map[string]discordconfig.ProfileConfig  `yaml:"profiles"`
+	Environments map[string]discordconfig.EnvironmentSet `yaml:"environments"`
+	Server       serverConfig                            `yaml:"server"`
+	Redis        redisConfig                             `yaml:"redis"`
+	Broker       brokerSettings                          `yaml:"broker"`
+	Kafka        kafkaConfig                             `yaml:"kafka"`
+	Tunnel       tunnelConfig                            `yaml:"tunnel"`
+	Interactions interactionsConfig                      `yaml:"interactions"`
+	Jobs         map[string]jobConfig                    `yaml:"jobs"`
+	Events       eventsConfig                            `yaml:"events"`
+}
+
+type discordSectionSchema struct {
+	discordconfig.DiscordConfig `yaml:",inline"`
+	PublicKey                   string   `yaml:"public_key"`
+	PublicURL                   string   `yaml:"public_url"`
+	Intents                     []string `yaml:"intents"`
+}
+
+// configProblem is one finding from config validate. Line is 0 when the
+// problem is not tied to one place in the file.
+type configProblem struct {
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Field   string `json:"field,omitempty" yaml:"field,omitempty"`
+	Problem string `json:"problem" yaml:"problem"`
+}
+
+var (
+	yamlErrorLine    = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	yamlUnknownField = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+	webhookPath      = regexp.MustCompile(`^/api(?:/v\d+)?/webhooks/(\d+)/[\w-]+/?$`)
+)
+
+func configValidateCmd(opts *globalOptions) *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check discord.yaml for mistakes",
+		Long: `Strictly parse discord.yaml and report every problem with its line number. The commands
+that read the file skip keys they do not know, so a misspelled key silently does nothing; this
+command reports it instead.
+
+Besides unknown keys and malformed values (durations such as 30s or 5m), it checks:
+  • IDs (application_id, default_guild_id, premium_sku, require_roles) are numeric snowflakes
+  • webhook URLs look like https://discord.com/api/webhooks/<id>/<token>
+  • handlers route to an agent, an initial_response, a script, wasm, or respond, with valid patterns and middleware
+  • jobs have valid schedules and refer to configured webhooks
+  • server settings that must go together (TLS files, ACME, auth, tunnels, kafka)
+
+Values written as environment references (${VAR}) are not checked. Exits non-zero when a
+problem is found, so it can gate CI or a deploy.`,
+		Example: `Example:
+  arc-discord config validate
+
+Example:
+  arc-discord config validate --file deploy/discord.yaml --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			path := file
+			if path == "" {
+				path = findConfigFile(opts.configPath)
+			}
+			if path == "" {
+				return &arcer.CLIError{Msg: "no discord.yaml found", Hint: "pass --file or --config; config show lists the search order"}
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+			}
+			problems := validateConfigData(data)
+			if len(problems) == 0 {
+				cmd.Printf("%s is valid\n", path)
+				return nil
+			}
+			table := &tableData{headers: []string{"LINE", "FIELD", "PROBLEM"}}
+			for _, p := range problems {
+				line := "-"
+				if p.Line > 0 {
+					line = strconv.Itoa(p.Line)
+				}
+				table.rows = append(table.rows, []string{line, valueOrDash(p.Field), p.Problem})
+			}
+			if err := renderOutput(cmd, opts.output, problems, table); err != nil {
+				return err
+			}
+			return &arcer.CLIError{Msg: fmt.Sprintf("%d problem(s) in %s", len(problems), path)}
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "Config file to check (default: the file other commands load)")
+	return cmd
+}
+
+// findConfigFile returns the first existing file in the config search order.
+func findConfigFile(explicit string) string {
+	for _, candidate := range orderedConfigPaths(explicit) {
+		expanded := utils.ExpandPath(candidate)
+		if info, err := os.Stat(expanded); err == nil && !info.IsDir() {
+			return expanded
+		}
+	}
+	return ""
+}
+
+// validateConfigData strictly decodes a discord.yaml document and checks
+// the values the loaders would otherwise accept or reject only at use.
+func validateConfigData(data []byte) []configProblem {
+	v := &configValidator{}
+	if err := yaml.Unmarshal(data, &v.root); err != nil {
+		return []configProblem{yamlProblem(err.Error())}
+	}
+
+	var cfg configFileSchema
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return []configProblem{yamlProblem(err.Error())}
+		}
+		for _, msg := range typeErr.Errors {
+			v.problems = append(v.problems, yamlProblem(msg))
+		}
+	}
+
+	v.checkDiscord(cfg.Discord.DiscordConfig, "discord")
+	v.checkKey(cfg.Discord.PublicKey, "discord", "public_key")
+	v.checkURL(cfg.Discord.PublicURL, "discord", "public_url")
+	v.checkClient(cfg.Client, "client")
+	for name, profile := range cfg.Profiles {
+		if profile.Discord != nil {
+			v.checkDiscord(*profile.Discord, "profiles", name, "discord")
+		}
+		if profile.Client != nil {
+			v.checkClient(*profile.Client, "profiles", name, "client")
+		}
+	}
+	for name, env := range cfg.Environments {
+		if len(env.Webhooks) == 0 {
+			v.add([]string{"environments", name}, "defines no webhooks")
+		}
+		v.checkWebhooks(env.Webhooks, "environments", name, "webhooks")
+	}
+	if _, err := locale.New(cfg.Output.Locale, cfg.Output.Timezone); err != nil {
+		v.add([]string{"output"}, "%v", err)
+	}
+	v.checkServer(cfg.Server, cfg.Tunnel)
+	v.checkBackends(cfg)
+	v.checkInteractions(cfg.Interactions)
+	v.checkJobs(cfg.Jobs, cfg.Discord.Webhooks, cfg.Environments)
+	v.checkEvents(cfg.Events)
+
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		a, b := v.problems[i].Line, v.problems[j].Line
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		return a < b
+	})
+	return v.problems
+}
+
+// yamlProblem turns a yaml.v3 message ("line 4: field foo not found in type
+// cmd.serverConfig") into a problem.
+func yamlProblem(msg string) configProblem {
+	m := yamlErrorLine.FindStringSubmatch(msg)
+	if m == nil {
+		return configProblem{Problem: strings.TrimPrefix(msg, "yaml: ")}
+	}
+	line, _ := strconv.Atoi(m[1])
+	if f := yamlUnknownField.FindStringSubmatch(m[2]); f != nil {
+		return configProblem{Line: line, Field: f[1], Problem: "unknown field " + f[1]}
+	}
+	return configProblem{Line: line, Problem: m[2]}
+}
+
+type configValidator struct {
+	root     yaml.Node
+	problems []configProblem
+}
+
+// add records a problem at path, a chain of mapping keys such as
+// server, listen_addr. The line is that of the deepest key present.
+func (v *configValidator) add(path []string, format string, args ...any) {
+	v.problems = append(v.problems, configProblem{
+		Line:    v.line(path),
+		Field:   strings.Join(path, "."),
+		Problem: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *configValidator) line(path []string) int {
+	node := &v.root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := 0
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line = node.Content[i].Line
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
+
+func fieldPath(parts ...string) []string { return parts }
+
+// isLoadTimeValue reports whether value is only known once the config
+// loads: an environment or secret reference, or an encrypted value.
+func isLoadTimeValue(value string) bool {
+	return strings.Contains(value, "$") || strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+func (v *configValidator) checkSnowflake(value string, at ...string) {
+	if value == "" || isLoadTimeValue(value) || isSnowflake(value) {
+		return
+	}
+	v.add(at, "%q is not a Discord ID (expected digits only)", value)
+}
+
+func (v *configValidator) checkDiscord(cfg discordconfig.DiscordConfig, at ...string) {
+	v.checkSnowflake(cfg.ApplicationID, append(at, "application_id")...)
+	v.checkSnowflake(cfg.DefaultGuildID, append(at, "default_guild_id")...)
+	v.checkSnowflake(cfg.DefaultChannelID, append(at, "default_channel_id")...)
+	v.checkWebhooks(cfg.Webhooks, append(at, "webhooks")...)
+}
+
+func (v *configValidator) checkWebhooks(webhooks map[string]string, at ...string) {
+	for name, raw := range webhooks {
+		if raw == "" || isLoadTimeValue(raw) {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme != "https" || u.Host == "" || !webhookPath.MatchString(u.Path) {
+			v.add(append(at, name), "webhook URL must look like https://discord.com/api/webhooks/<id>/<token>")
+		}
+	}
+}
+
+func (v *configValidator) checkKey(value string, at ...string) {
+	if value == "" || isLoadTimeValue(value) {
+		return
+	}
+	if key, err := hex.DecodeString(strings.TrimSpace(value)); err != nil || len(key) != 32 {
+		v.add(at, "public key must be 64 hex characters (General Information in the developer portal)")
+	}
+}
+
+func (v *configValidator) checkURL(value string, at ...string) {
+	if value == "" || isLoadTimeValue(value) {
+		return
+	}
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(at, "%q must be an absolute http(s) URL", value)
+	}
+}
+
+func (v *configValidator) checkClient(cfg discordconfig.ClientConfig, at ...string) {
+	for field, strategy := range map[string]string{"rate_limit.strategy": cfg.RateLimit.Strategy, "rate_limit_strategy": cfg.RateLimitStrategy} {
+		switch strategy {
+		case "", "adaptive", "reactive", "proactive":
+		default:
+			v.add(append(at, strings.Split(field, ".")...), "rate limit strategy %q must be adaptive, reactive, or proactive", strategy)
+		}
+	}
+	if cfg.Timeout < 0 {
+		v.add(append(at, "timeout"), "must not be negative")
+	}
+	if cfg.Retries < 0 {
+		v.add(append(at, "retries"), "must not be negative")
+	}
+	if base, max := cfg.RateLimit.BackoffBase, cfg.RateLimit.BackoffMax; base > 0 && max > 0 && base > max {
+		v.add(append(at, "rate_limit", "backoff_base"), "backoff_base %s exceeds backoff_max %s", base, max)
+	}
+}
+
+func (v *configValidator) checkServer(cfg serverConfig, tunnel tunnelConfig) {
+	if cfg.ListenAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.ListenAddr); err != nil {
+			v.add(fieldPath("server", "listen_addr"), "%v", err)
+		}
+	}
+	for _, p := range []*string{&cfg.TLSCert, &cfg.TLSKey, &cfg.Auth.ClientCA} {
+		if *p != "" {
+			*p = utils.ExpandPath(*p)
+		}
+	}
+	if err := validateServerTLS(cfg); err != nil {
+		v.add(fieldPath("server", "tls_cert"), "%v", err)
+	}
+	if err := validateServerAuth(cfg); err != nil {
+		v.add(fieldPath("server", "auth"), "%v", err)
+	}
+	if err := validateServerLimits(cfg); err != nil {
+		v.add(fieldPath("server"), "%v", err)
+	}
+	if _, err := parseAllowedCIDRs(cfg.AllowedCIDRs); err != nil {
+		v.add(fieldPath("server", "allowed_cidrs"), "%v", err)
+	}
+	if err := validateDebugAddr(cfg.DebugAddr); err != nil {
+		v.add(fieldPath("server", "debug_addr"), "%v", err)
+	}
+	for field, d := range map[string]time.Duration{"drain_timeout": cfg.DrainTimeout, "timestamp_window": cfg.TimestampWindow, "clock_skew": cfg.ClockSkew} {
+		if d < 0 {
+			v.add(fieldPath("server", field), "must not be negative")
+		}
+	}
+	if cfg.tlsEnabled() && tunnel.Provider != "" {
+		v.add(fieldPath("tunnel", "provider"), "TLS cannot be combined with a tunnel; tunnels terminate HTTPS themselves")
+	}
+	switch strings.ToLower(strings.TrimSpace(tunnel.Provider)) {
+	case "", "none", "ngrok", "localtunnel", "auto":
+	default:
+		v.add(fieldPath("tunnel", "provider"), "unsupported tunnel provider %q (expected ngrok, localtunnel, auto)", tunnel.Provider)
+	}
+}
+
+func (v *configValidator) checkBackends(cfg configFileSchema) {
+	if backend := strings.ToLower(strings.TrimSpace(cfg.Broker.Backend)); backend != "" {
+		known := broker.Backends()
+		if i := sort.SearchStrings(known, backend); i == len(known) || known[i] != backend {
+			v.add(fieldPath("broker", "backend"), "unknown broker backend %q (available: %s)", cfg.Broker.Backend, strings.Join(known, ", "))
+		}
+	}
+	if cfg.Redis.PublishBatch.Size < 0 || cfg.Redis.PublishBatch.Interval < 0 {
+		v.add(fieldPath("redis", "publish_batch"), "size and interval must not be negative")
+	}
+	if len(cfg.Kafka.Brokers) > 0 && strings.TrimSpace(cfg.Kafka.Topic) == "" {
+		v.add(fieldPath("kafka", "brokers"), "kafka.topic is required when brokers are set")
+	}
+	if strings.TrimSpace(cfg.Kafka.Topic) != "" && len(cfg.Kafka.Brokers) == 0 {
+		v.add(fieldPath("kafka", "topic"), "kafka.brokers is required when a topic is set")
+	}
+}
+
+func (v *configValidator) checkInteractions(cfg interactionsConfig) {
+	if cfg.Timeout < 0 {
+		v.add(fieldPath("interactions", "timeout"), "must not be negative")
+	}
+	kinds := []struct {
+		kind, section string
+		routes        map[string]handlerRoute
+	}{
+		{handlerKindCommand, "commands", cfg.Handlers.Commands},
+		{handlerKindComponent, "components", cfg.Handlers.Components},
+		{handlerKindModal, "modals", cfg.Handlers.Modals},
+		{handlerKindAutocomplete, "autocomplete", cfg.Handlers.Autocomplete},
+	}
+	for _, k := range kinds {
+		for key, route := range k.routes {
+			at := fieldPath("interactions", "handlers", k.section, key)
+			if k.kind == handlerKindAutocomplete {
+				if len(route.Choices) == 0 && route.Source == nil {
+					v.add(at, "autocomplete handler needs choices or a source")
+					continue
+				}
+			} else if !route.answered() {
+				v.add(at, "handler needs an agent, an initial_response, a script, wasm, or respond")
+				continue
+			}
+			binding := handlerBinding{Kind: k.kind, Key: key, Route: route, Middleware: routeMiddleware(cfg, route)}
+			if _, _, err := prepareBinding(binding); err != nil {
+				v.add(at, "%v", err)
+			}
+			v.checkSnowflake(route.PremiumSKU, append(at, "premium_sku")...)
+			for _, role := range route.RequireRoles {
+				v.checkSnowflake(role, append(at, "require_roles")...)
+			}
+		}
+	}
+	v.checkRules(cfg.Rules)
+	if err := cfg.Delivery.validate(); err != nil {
+		v.add(fieldPath("interactions", "delivery"), "%v", err)
+	}
+}
+
+// checkRules reports invalid actions and non-ID matchers in
+// interactions.rules.
+func (v *configValidator) checkRules(rules []routingRule) {
+	for i, rule := range rules {
+		at := fieldPath("interactions", "rules", fmt.Sprint(i))
+		if err := rule.validate(); err != nil {
+			v.add(at, "%v", err)
+			continue
+		}
+		for key, ids := range map[string][]string{"guilds": rule.Match.Guilds, "channels": rule.Match.Channels, "roles": rule.Match.Roles} {
+			for j, id := range ids {
+				v.checkSnowflake(id, append(at, "match", key, fmt.Sprint(j))...)
+			}
+		}
+	}
+}
+
+// checkJobs requires webhook jobs to name a webhook that discord.webhooks or
+// one of the environments defines.
+func (v *configValidator) checkJobs(jobs map[string]jobConfig, webhooks map[string]string, envs map[string]discordconfig.EnvironmentSet) {
+	for name, job := range jobs {
+		at := fieldPath("jobs", name)
+		if err := job.validate(); err != nil {
+			v.add(at, "%v", err)
+			continue
+		}
+		if job.Channel == "" {
+			_, ok := webhooks[job.webhookName()]
+			for _, env := range envs {
+				_, inEnv := env.Webhooks[job.webhookName()]
+				ok = ok || inEnv
+			}
+			if !ok {
+				v.add(append(at, "webhook"), "webhook %q is not defined under discord.webhooks", job.webhookName())
+			}
+		}
+	}
+}
+
+// checkEvents requires event types the gateway client decodes and Discord IDs
+// in the guild and channel allowlists.
+func (v *configValidator) checkEvents(events eventsConfig) {
+	for i, name := range events.Types {
+		if _, err := selectGatewayEvents([]string{name}); err != nil {
+			v.add(fieldPath("events", "types", fmt.Sprint(i)), "%v", err)
+		}
+	}
+	for i, id := range events.Guilds {
+		v.checkSnowflake(id, "events", "guilds", fmt.Sprint(i))
+	}
+	for i, id := range events.Channels {
+		v.checkSnowflake(id, "events", "channels", fmt.Sprint(i))
+	}
+}
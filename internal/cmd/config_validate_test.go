@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExampleConfigHasOnlyPlaceholders(t *testing.T) {
+	// Every key in the generated example is known; only the YOUR_* and
+	// "..." placeholders in the discord section should be reported.
+	for _, p := range validateConfigData([]byte(GenerateExampleConfig())) {
+		if !strings.HasPrefix(p.Field, "discord.") {
+			t.Fatalf("unexpected problem in example config: %+v", p)
+		}
+	}
+}
+
+func TestConfigValidateReportsProblemsByLine(t *testing.T) {
+	data := `discord:
+  application_id: "my-app"
+  webhooks:
+    default: "https://example.com/hook"
+server:
+  listen_adr: ":8080"
+  drain_timeout: soon
+interactions:
+  handlers:
+    commands:
+      deploy:
+        description: Deploy a service
+      ask:
+        agent: claude
+        require_roles: ["moderators"]
+jobs:
+  standup:
+    schedule: "0 9 * * 1-5"
+    webhook: alerts
+    content: Standup time
+events:
+  types: [MESSAGE_CREATE, TYPING_START]
+  guilds: ["general"]
+`
+	problems := validateConfigData([]byte(data))
+	want := map[int]string{
+		2:  "not a Discord ID",
+		4:  "webhook URL must look like",
+		6:  "unknown field listen_adr",
+		7:  "time.Duration",
+		11: "needs an agent, an initial_response, a script, wasm, or respond",
+		15: "not a Discord ID",
+		19: `webhook "alerts" is not defined`,
+		22: `unsupported event "TYPING_START"`,
+		23: "not a Discord ID",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %+v", len(want), problems)
+	}
+	for _, p := range problems {
+		substr, ok := want[p.Line]
+		if !ok || !strings.Contains(p.Problem, substr) {
+			t.Fatalf("unexpected problem %+v", p)
+		}
+	}
+}
+
+func TestConfigValidateSyntaxError(t *testing.T) {
+	problems := validateConfigData([]byte("discord:\n  application_id: [1\n"))
+	if len(problems) != 1 || problems[0].Line == 0 {
+		t.Fatalf("expected one syntax problem with a line, got %+v", problems)
+	}
+}
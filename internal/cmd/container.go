@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const (
+	envConfigEnvOnly          = "ARC_DISCORD_ENV_ONLY"
+	envListenAddr             = "VIBE_DISCORD_LISTEN_ADDR"
+	envHandlerCommands        = "VIBE_DISCORD_COMMANDS"
+	envHandlerComponents      = "VIBE_DISCORD_COMPONENTS"
+	envHandlerModals          = "VIBE_DISCORD_MODALS"
+	healthzPath               = "/healthz"
+	healthzTimeout            = 2 * time.Second
+	brokerLossInterval        = 5 * time.Second
+	brokerLossThreshold       = 3
+	defaultHealthcheckTimeout = 5 * time.Second
+)
+
+// envOnlyMode reports whether configuration files are skipped in favour of
+// environment variables, via --env-only or ARC_DISCORD_ENV_ONLY.
+func (o *globalOptions) envOnlyMode() bool {
+	if o.envOnly {
+		return true
+	}
+	enabled, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv(envConfigEnvOnly)))
+	return enabled
+}
+
+// parseEnvHandlers parses "key=agent,key=agent" handler routes from an
+// environment variable.
+func parseEnvHandlers(name, raw string) (map[string]handlerRoute, error) {
+	routes := map[string]handlerRoute{}
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, agent, ok := strings.Cut(entry, "=")
+		key, agent = strings.TrimSpace(key), strings.TrimSpace(agent)
+		if !ok || key == "" || agent == "" {
+			return nil, fmt.Errorf("%s: invalid handler %q (want key=agent)", name, entry)
+		}
+		routes[key] = handlerRoute{Agent: agent}
+	}
+	return routes, nil
+}
+
+// applyEnvHandlers adds handler routes from VIBE_DISCORD_COMMANDS,
+// VIBE_DISCORD_COMPONENTS, and VIBE_DISCORD_MODALS on top of the file's.
+func applyEnvHandlers(cfg *interactionsConfig) error {
+	ensureHandlerMaps(cfg)
+	for name, dst := range map[string]map[string]handlerRoute{
+		envHandlerCommands:   cfg.Handlers.Commands,
+		envHandlerComponents: cfg.Handlers.Components,
+		envHandlerModals:     cfg.Handlers.Modals,
+	} {
+		raw := strings.TrimSpace(os.Getenv(name))
+		if raw == "" {
+			continue
+		}
+		routes, err := parseEnvHandlers(name, raw)
+		if err != nil {
+			return err
+		}
+		for key, route := range routes {
+			dst[key] = route
+		}
+	}
+	return nil
+}
+
+type healthStatus struct {
+	Status string `json:"status"`
+	Broker string `json:"broker"`
+}
+
+// newHealthHandler serves /healthz for container health checks. It is public,
+// so it reports only whether the broker answers, never addresses or errors.
+func newHealthHandler(b broker.Broker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := healthStatus{Status: "ok", Broker: "ok"}
+		code := http.StatusOK
+		if pinger, ok := b.(broker.Pinger); ok {
+			ctx, cancel := context.WithTimeout(r.Context(), healthzTimeout)
+			defer cancel()
+			if err := pinger.Ping(ctx); err != nil {
+				status = healthStatus{Status: "unavailable", Broker: "unreachable"}
+				code = http.StatusServiceUnavailable
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
+
+// watchBrokerLoss pings the broker every interval and sends on lost once
+// threshold consecutive pings have failed.
+func watchBrokerLoss(ctx context.Context, pinger broker.Pinger, interval time.Duration, threshold int, lost chan<- error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := pinger.Ping(ctx)
+		if err == nil {
+			failures = 0
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		failures++
+		if failures >= threshold {
+			lost <- fmt.Errorf("%d consecutive pings failed: %w", failures, err)
+			return
+		}
+	}
+}
+
+func serverHealthcheckCmd(opts *globalOptions) *cobra.Command {
+	var (
+		addr    string
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "healthcheck",
+		Short: "Probe the local server's /healthz and exit non-zero when unhealthy",
+		Long: `Probe the running server's /healthz endpoint. It exits 0 when the server answers and
+the broker is reachable, so it works as a Docker HEALTHCHECK in images without curl or wget.
+
+The address defaults to server.listen_addr (or $VIBE_DISCORD_LISTEN_ADDR), with a wildcard
+host probed on 127.0.0.1.`,
+		Example: `Example:
+  arc-discord server healthcheck
+
+Example (Dockerfile):
+  HEALTHCHECK --interval=30s --timeout=5s CMD ["arc-discord", "server", "healthcheck"]`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, extra, _, err := opts.loadConfigWithInteractions()
+			if err != nil {
+				return err
+			}
+			if addr == "" {
+				addr = extra.Server.ListenAddr
+			}
+			target, err := healthcheckURL(addr, extra.Server.tlsEnabled())
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "pass --addr host:port"}
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+			if err != nil {
+				return err
+			}
+			// The certificate names the public host, not the loopback address
+			// probed here, and only reachability is being checked.
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+			resp, err := client.Do(req)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("server not reachable at %s", target)}).WithCause(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			if resp.StatusCode != http.StatusOK {
+				return &arcer.CLIError{Msg: fmt.Sprintf("server unhealthy: %s %s", resp.Status, strings.TrimSpace(string(body)))}
+			}
+			cmd.Println(strings.TrimSpace(string(body)))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", "", "Server address to probe (default server.listen_addr)")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultHealthcheckTimeout, "Time limit for the probe")
+	return cmd
+}
+
+// healthcheckURL turns a listen address into the /healthz URL to probe,
+// mapping wildcard hosts to loopback.
+func healthcheckURL(addr string, useTLS bool) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid listen address %q: %w", addr, err)
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	return scheme + "://" + net.JoinHostPort(host, port) + healthzPath, nil
+}
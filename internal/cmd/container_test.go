@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+)
+
+type pingBroker struct {
+	broker.Broker
+
+	mu  sync.Mutex
+	err error
+}
+
+func (p *pingBroker) Ping(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *pingBroker) setErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+func TestHealthHandlerReportsBroker(t *testing.T) {
+	b := &pingBroker{Broker: broker.NewMemory()}
+	handler := newHealthHandler(b)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, healthzPath, nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"status":"ok"`) {
+		t.Fatalf("healthy: %d %s", rec.Code, rec.Body.String())
+	}
+
+	b.setErr(errors.New("dial tcp 10.0.0.5:6379: connection refused"))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, healthzPath, nil))
+	if rec.Code != http.StatusServiceUnavailable || strings.Contains(rec.Body.String(), "10.0.0.5") {
+		t.Fatalf("unhealthy: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAgentsHandlerListsRegistry(t *testing.T) {
+	mem := broker.NewMemory()
+	defer mem.Close()
+	ctx := context.Background()
+	for _, info := range []broker.AgentInfo{
+		{Agent: "ops", Capabilities: []string{"deploy:staging"}},
+		{Agent: "triage", Capabilities: []string{"summarize"}},
+	} {
+		if err := mem.Registry().Register(ctx, info); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := newAgentsHandler(mem.Registry())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, agentsPath+"?capability=deploy", nil))
+	var entries []agentListEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("decode %d %s: %v", rec.Code, rec.Body.String(), err)
+	}
+	if rec.Code != http.StatusOK || len(entries) != 1 || entries[0].Agent != "ops" || entries[0].Capabilities[0] != "deploy:staging" {
+		t.Fatalf("unexpected entries %d %+v", rec.Code, entries)
+	}
+	if !strings.Contains(rec.Body.String(), `"heartbeat_age_seconds":0`) {
+		t.Fatalf("missing heartbeat age: %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, agentsPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("POST: %d", rec.Code)
+	}
+}
+
+func TestWatchBrokerLossNeedsConsecutiveFailures(t *testing.T) {
+	b := &pingBroker{err: errors.New("down")}
+	lost := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchBrokerLoss(ctx, b, time.Millisecond, 3, lost)
+
+	select {
+	case err := <-lost:
+		if !strings.Contains(err.Error(), "3 consecutive pings failed") {
+			t.Fatalf("unexpected loss error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("broker loss not reported")
+	}
+
+	healthy := &pingBroker{}
+	lost = make(chan error, 1)
+	go watchBrokerLoss(ctx, healthy, time.Millisecond, 1, lost)
+	select {
+	case err := <-lost:
+		t.Fatalf("healthy broker reported lost: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestEnvOnlyIgnoresConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	file := "discord:\n  bot_token: from-file\nserver:\n  listen_addr: 127.0.0.1:9999\n"
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envConfigEnvOnly, "true")
+	t.Setenv("DISCORD_BOT_TOKEN", "from-env")
+	t.Setenv(envListenAddr, "0.0.0.0:8080")
+	t.Setenv(envHandlerCommands, "ask=claude, deploy=ops")
+
+	opts := &globalOptions{configPath: path}
+	cfg, extra, cfgPath, err := opts.loadConfigWithInteractions()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfgPath != "" || cfg.Discord.BotToken != "from-env" || extra.Server.ListenAddr != "0.0.0.0:8080" {
+		t.Fatalf("config file was read: path=%q token=%q addr=%q", cfgPath, cfg.Discord.BotToken, extra.Server.ListenAddr)
+	}
+	if extra.Interactions.Handlers.Commands["deploy"].Agent != "ops" || len(extra.Interactions.Handlers.Commands) != 2 {
+		t.Fatalf("unexpected commands: %+v", extra.Interactions.Handlers.Commands)
+	}
+
+	t.Setenv(envHandlerCommands, "ask")
+	if _, _, _, err := opts.loadConfigWithInteractions(); err == nil || !strings.Contains(err.Error(), envHandlerCommands) {
+		t.Fatalf("expected invalid handler error, got %v", err)
+	}
+}
+
+func TestServerHealthcheckCmd(t *testing.T) {
+	t.Setenv(envConfigEnvOnly, "1")
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != healthzPath {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"status":"ok","broker":"ok"}`))
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	var buf bytes.Buffer
+	cmd := serverHealthcheckCmd(&globalOptions{})
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--addr", addr})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("healthy: %v", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	cmd = serverHealthcheckCmd(&globalOptions{})
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--addr", addr})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected unhealthy error")
+	}
+
+	if got, _ := healthcheckURL("0.0.0.0:8443", true); got != "https://127.0.0.1:8443/healthz" {
+		t.Fatalf("healthcheckURL = %s", got)
+	}
+}
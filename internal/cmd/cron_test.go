@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC) // a Friday
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2025, 3, 15, 10, 30, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,15 feb *", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 1st of the month or any Monday.
+		{"0 0 1 * mon", time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		sched, err := parseCron(tc.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.expr, err)
+		}
+		if got := sched.Next(base); !got.Equal(tc.want) {
+			t.Errorf("%q: next = %s, want %s", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestCronNextUsesLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	sched, err := parseCron("0 9 * * *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := sched.Next(time.Date(2025, 3, 14, 6, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 3, 14, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("next = %s, want %s", got.UTC(), want)
+	}
+}
+
+func TestCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		if _, err := parseCron(expr, time.UTC); err == nil {
+			t.Errorf("expected %q to be rejected", expr)
+		}
+	}
+}
+
+func TestCronNextImpossibleDate(t *testing.T) {
+	sched, err := parseCron("0 0 30 2 *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sched.Next(time.Now()); !got.IsZero() {
+		t.Fatalf("expected no match, got %s", got)
+	}
+}
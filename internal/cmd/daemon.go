@@ -9,8 +9,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"syscall"
+	"time"
 )
 
 const daemonEnvFlag = "VIBE_DISCORD_DAEMON_CHILD"
@@ -20,6 +22,12 @@ type daemonOptions struct {
 	LogFile string
 	Workdir string
 	EnvFile string
+
+	// Restart, MaxRestarts, and Backoff run the server under a supervisor
+	// process that relaunches it; see daemon_supervise.go.
+	Restart     string
+	MaxRestarts int
+	Backoff     time.Duration
 }
 
 type daemonManager struct {
@@ -74,6 +82,10 @@ func (m *daemonManager) Start(ctx context.Context, argv []string) error {
 	}
 
 	env := append(envFromFile(m.opts.EnvFile), fmt.Sprintf("%s=1", daemonEnvFlag))
+	_ = os.Remove(supervisorStatePath(m.opts.PIDFile))
+	if m.opts.supervised() && len(argv) > 0 {
+		argv = m.opts.supervisorArgv(argv)
+	}
 	pid, err := m.startProc(ctx, argv, m.opts.LogFile, m.opts.Workdir, env)
 	if err != nil {
 		return err
@@ -96,6 +108,7 @@ func (m *daemonManager) Stop(ctx context.Context) error {
 		return err
 	}
 	_ = os.Remove(m.opts.PIDFile)
+	_ = os.Remove(supervisorStatePath(m.opts.PIDFile))
 	return nil
 }
 
@@ -107,19 +120,93 @@ func (m *daemonManager) Status() (string, error) {
 	if pid == 0 {
 		return "stopped", nil
 	}
+	status := fmt.Sprintf("stale pid file (%d)", pid)
 	if m.checkProc(pid) {
-		return fmt.Sprintf("running (pid %d)", pid), nil
+		status = fmt.Sprintf("running (pid %d)", pid)
+	}
+	if state, err := readSupervisorState(supervisorStatePath(m.opts.PIDFile)); err == nil {
+		status += "; " + state.summary()
 	}
-	return fmt.Sprintf("stale pid file (%d)", pid), nil
+	return status, nil
 }
 
 func (m *daemonManager) PIDPath() string {
 	return m.opts.PIDFile
 }
 
+const daemonFilePrefix = "discord-server"
+
 func defaultPIDPath() string {
+	return instancePIDPath("")
+}
+
+func daemonStateDir() string {
 	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".cache", "vibe", "discord-server.pid")
+	return filepath.Join(home, ".cache", "vibe")
+}
+
+// instancePIDPath is the default PID file for a named instance; the unnamed
+// instance keeps discord-server.pid.
+func instancePIDPath(instance string) string {
+	return filepath.Join(daemonStateDir(), instanceFileName(instance, ".pid"))
+}
+
+func instanceLogPath(instance string) string {
+	return filepath.Join(daemonStateDir(), instanceFileName(instance, ".log"))
+}
+
+func instanceFileName(instance, ext string) string {
+	if instance == "" {
+		return daemonFilePrefix + ext
+	}
+	return daemonFilePrefix + "-" + instance + ext
+}
+
+// instanceChannelPrefix namespaces the default channel prefix so instances
+// on one Redis don't deliver to each other's agents. Explicit prefixes are
+// kept as configured.
+func instanceChannelPrefix(prefix, instance string) string {
+	if instance == "" || normalizeChannelPrefix(prefix) != defaultRedisPrefix {
+		return prefix
+	}
+	return defaultRedisPrefix + ":" + instance
+}
+
+func validateInstanceName(name string) error {
+	if name == "" {
+		return nil
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid instance name %q", name)
+		}
+	}
+	return nil
+}
+
+type daemonInstance struct {
+	Name    string
+	PIDFile string
+}
+
+// listDaemonInstances finds the PID files of every instance in dir, sorted
+// by name with the unnamed instance first.
+func listDaemonInstances(dir string) ([]daemonInstance, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, daemonFilePrefix+"*.pid"))
+	if err != nil {
+		return nil, err
+	}
+	var instances []daemonInstance
+	for _, path := range matches {
+		rest := strings.TrimPrefix(strings.TrimSuffix(filepath.Base(path), ".pid"), daemonFilePrefix)
+		name := strings.TrimPrefix(rest, "-")
+		if rest != "" && (name == rest || name == "" || validateInstanceName(name) != nil) {
+			continue
+		}
+		instances = append(instances, daemonInstance{Name: name, PIDFile: path})
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Name < instances[j].Name })
+	return instances, nil
 }
 
 func envFromFile(path string) []string {
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	restartNever          = "no"
+	restartOnFailure      = "on-failure"
+	restartAlways         = "always"
+	defaultRestartBackoff = time.Second
+	maxRestartBackoff     = time.Minute
+)
+
+func validateRestartPolicy(policy string) error {
+	switch policy {
+	case "", restartNever, restartOnFailure, restartAlways:
+		return nil
+	default:
+		return fmt.Errorf("unknown restart policy %q", policy)
+	}
+}
+
+func (o daemonOptions) supervised() bool {
+	return o.Restart != "" && o.Restart != restartNever
+}
+
+// supervisorArgv wraps the server argv in a "server supervise" invocation of
+// the same binary, which becomes the process recorded in the PID file.
+func (o daemonOptions) supervisorArgv(argv []string) []string {
+	backoff := o.Backoff
+	if backoff <= 0 {
+		backoff = defaultRestartBackoff
+	}
+	wrapped := []string{
+		argv[0], "server", "supervise",
+		"--pid-file", o.PIDFile,
+		"--restart", o.Restart,
+		"--max-restarts", strconv.Itoa(o.MaxRestarts),
+		"--backoff", backoff.String(),
+		"--",
+	}
+	return append(wrapped, argv...)
+}
+
+// supervisorState is written next to the PID file so "server status" can
+// report restarts without talking to the supervisor.
+type supervisorState struct {
+	ChildPID  int       `json:"child_pid,omitempty"`
+	Restarts  int       `json:"restarts"`
+	LastExit  string    `json:"last_exit,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	GaveUp    bool      `json:"gave_up,omitempty"`
+}
+
+func supervisorStatePath(pidPath string) string {
+	return pidPath + ".supervisor.json"
+}
+
+func readSupervisorState(path string) (*supervisorState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state supervisorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *supervisorState) save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *supervisorState) summary() string {
+	var out string
+	if s.GaveUp {
+		out = fmt.Sprintf("gave up after %d restart(s)", s.Restarts)
+	} else {
+		out = fmt.Sprintf("supervised, %d restart(s)", s.Restarts)
+		if s.ChildPID > 0 {
+			out += fmt.Sprintf(", server pid %d", s.ChildPID)
+		}
+	}
+	if s.LastExit != "" {
+		out += ", last exit: " + s.LastExit
+	}
+	return out
+}
+
+type runChildFn func(ctx context.Context, argv []string, started func(pid int)) error
+
+var runChild runChildFn = realRunChild
+
+// daemonSupervisor runs the server as a child process and relaunches it
+// according to the restart policy, doubling the delay after each crash.
+type daemonSupervisor struct {
+	argv      []string
+	opts      daemonOptions
+	statePath string
+	runChild  runChildFn
+	sleep     func(ctx context.Context, d time.Duration) bool
+	now       func() time.Time
+	logf      func(format string, args ...interface{})
+}
+
+func newDaemonSupervisor(argv []string, opts daemonOptions, logf func(string, ...interface{})) *daemonSupervisor {
+	if opts.PIDFile == "" {
+		opts.PIDFile = defaultPIDPath()
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = defaultRestartBackoff
+	}
+	return &daemonSupervisor{
+		argv:      argv,
+		opts:      opts,
+		statePath: supervisorStatePath(opts.PIDFile),
+		runChild:  runChild,
+		sleep:     sleepContext,
+		now:       time.Now,
+		logf:      logf,
+	}
+}
+
+// run supervises until ctx is done, the policy says not to restart, or the
+// restart budget is spent. Cancelling ctx stops the running child.
+func (s *daemonSupervisor) run(ctx context.Context) error {
+	var state supervisorState
+	delay := s.opts.Backoff
+	for {
+		started := s.now()
+		err := s.runChild(ctx, s.argv, func(pid int) {
+			state.ChildPID = pid
+			state.StartedAt = started
+			s.saveState(&state)
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		state.ChildPID = 0
+		state.LastExit = "exit status 0"
+		if err != nil {
+			state.LastExit = err.Error()
+		}
+		if err == nil && s.opts.Restart == restartOnFailure {
+			s.saveState(&state)
+			return nil
+		}
+		if s.opts.MaxRestarts > 0 && state.Restarts >= s.opts.MaxRestarts {
+			state.GaveUp = true
+			s.saveState(&state)
+			return fmt.Errorf("server exited (%s); giving up after %d restart(s)", state.LastExit, state.Restarts)
+		}
+		s.saveState(&state)
+		// A run that outlived the longest backoff was healthy; start over.
+		if s.now().Sub(started) >= maxRestartBackoff {
+			delay = s.opts.Backoff
+		}
+		s.logf("server exited (%s); restarting in %s\n", state.LastExit, delay)
+		if !s.sleep(ctx, delay) {
+			return nil
+		}
+		state.Restarts++
+		delay = min(delay*2, maxRestartBackoff)
+	}
+}
+
+func (s *daemonSupervisor) saveState(state *supervisorState) {
+	if err := state.save(s.statePath); err != nil {
+		s.logf("Warning: failed to write %s: %v\n", s.statePath, err)
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// realRunChild runs argv with the supervisor's stdio and environment and
+// forwards cancellation as a termination signal so the server can drain.
+func realRunChild(ctx context.Context, argv []string, started func(pid int)) error {
+	if len(argv) == 0 {
+		return errors.New("missing argv for supervised server")
+	}
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	started(cmd.Process.Pid)
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		_ = killProcess(cmd.Process.Pid)
+		return <-done
+	}
+}
+
+func serverSuperviseCmd() *cobra.Command {
+	var opts daemonOptions
+	cmd := &cobra.Command{
+		Use:    "supervise -- <argv>...",
+		Short:  "Run a server process and restart it after crashes (used by start --daemon --restart)",
+		Hidden: true,
+		Args:   cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRestartPolicy(opts.Restart); err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return newDaemonSupervisor(args, opts, cmd.Printf).run(ctx)
+		},
+	}
+	cmd.Flags().StringVar(&opts.PIDFile, "pid-file", "", "PID file of the daemon; supervisor state is kept next to it")
+	cmd.Flags().StringVar(&opts.Restart, "restart", restartOnFailure, "Restart policy: on-failure|always")
+	cmd.Flags().IntVar(&opts.MaxRestarts, "max-restarts", 0, "Give up after this many restarts (0 = unlimited)")
+	cmd.Flags().DurationVar(&opts.Backoff, "backoff", defaultRestartBackoff, "Delay before the first restart; doubles after each crash")
+	return cmd
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDaemonManagerStartWritesPID(t *testing.T) {
@@ -89,3 +91,116 @@ func TestEnvFromFileParsesLines(t *testing.T) {
 		t.Fatalf("unexpected env %v", env)
 	}
 }
+
+func TestDaemonManagerStartSupervisedWrapsArgv(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "daemon.pid")
+	var got []string
+	startProcess = func(ctx context.Context, argv []string, logPath, workdir string, env []string) (int, error) {
+		got = argv
+		return 1234, nil
+	}
+	checkProcess = func(pid int) bool { return false }
+	defer func() {
+		startProcess = realStartProcess
+		checkProcess = realCheckProcess
+	}()
+	m := newDaemonManager(daemonOptions{PIDFile: pidPath, Restart: restartOnFailure, MaxRestarts: 3})
+	if err := m.Start(context.Background(), []string{"/bin/arc-discord", "server", "start"}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	want := "/bin/arc-discord server supervise --pid-file " + pidPath + " --restart on-failure --max-restarts 3 --backoff 1s -- /bin/arc-discord server start"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("unexpected argv:\n got %q\nwant %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestDaemonSupervisorRestartsOnFailure(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "daemon.pid")
+	exits := []error{errors.New("exit status 1"), errors.New("exit status 2"), nil}
+	runs := 0
+	var delays []time.Duration
+	s := newDaemonSupervisor([]string{"server"}, daemonOptions{PIDFile: pidPath, Restart: restartOnFailure}, t.Logf)
+	s.runChild = func(ctx context.Context, argv []string, started func(pid int)) error {
+		started(100 + runs)
+		err := exits[runs]
+		runs++
+		return err
+	}
+	s.sleep = func(ctx context.Context, d time.Duration) bool {
+		delays = append(delays, d)
+		return true
+	}
+	if err := s.run(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if runs != 3 || len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Fatalf("unexpected runs %d, delays %v", runs, delays)
+	}
+	state, err := readSupervisorState(supervisorStatePath(pidPath))
+	if err != nil {
+		t.Fatalf("read state: %v", err)
+	}
+	if state.Restarts != 2 || state.LastExit != "exit status 0" || state.GaveUp {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+}
+
+func TestDaemonSupervisorGivesUpAndReportsStatus(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "daemon.pid")
+	if err := writePID(pidPath, 77); err != nil {
+		t.Fatalf("writePID: %v", err)
+	}
+	s := newDaemonSupervisor([]string{"server"}, daemonOptions{PIDFile: pidPath, Restart: restartAlways, MaxRestarts: 2}, t.Logf)
+	s.runChild = func(ctx context.Context, argv []string, started func(pid int)) error {
+		started(200)
+		return errors.New("exit status 1")
+	}
+	s.sleep = func(context.Context, time.Duration) bool { return true }
+	if err := s.run(context.Background()); err == nil || !strings.Contains(err.Error(), "giving up after 2 restart(s)") {
+		t.Fatalf("expected give-up error, got %v", err)
+	}
+
+	checkProcess = func(pid int) bool { return false }
+	defer func() { checkProcess = realCheckProcess }()
+	status, err := newDaemonManager(daemonOptions{PIDFile: pidPath}).Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if status != "stale pid file (77); gave up after 2 restart(s), last exit: exit status 1" {
+		t.Fatalf("unexpected status: %q", status)
+	}
+}
+
+func TestListDaemonInstances(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"discord-server.pid", "discord-server-ops.pid", "discord-server.pid.supervisor.json", "discord-serverx.pid", "discord-server-.pid"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("1"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	instances, err := listDaemonInstances(dir)
+	if err != nil {
+		t.Fatalf("listDaemonInstances: %v", err)
+	}
+	if len(instances) != 2 || instances[0].Name != "" || instances[1].Name != "ops" {
+		t.Fatalf("unexpected instances: %+v", instances)
+	}
+}
+
+func TestInstanceNamespacing(t *testing.T) {
+	if got := instanceChannelPrefix("", "ops"); got != "arc:discord:ops" {
+		t.Fatalf("default prefix: %s", got)
+	}
+	if got := instanceChannelPrefix("team:bots", "ops"); got != "team:bots" {
+		t.Fatalf("explicit prefix changed: %s", got)
+	}
+	if got := instanceChannelPrefix(defaultRedisPrefix, ""); got != defaultRedisPrefix {
+		t.Fatalf("unnamed instance changed prefix: %s", got)
+	}
+	if filepath.Base(instancePIDPath("ops")) != "discord-server-ops.pid" || filepath.Base(instanceLogPath("ops")) != "discord-server-ops.log" {
+		t.Fatalf("unexpected instance paths: %s %s", instancePIDPath("ops"), instanceLogPath("ops"))
+	}
+	if err := validateInstanceName("Ops/1"); err == nil {
+		t.Fatal("expected invalid instance name")
+	}
+}
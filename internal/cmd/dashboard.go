@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const (
+	defaultDashboardRefresh = 2 * time.Second
+	defaultDashboardRecent  = 50
+)
+
+func dashboardCmd(opts *globalOptions) *cobra.Command {
+	var (
+		refresh     time.Duration
+		recent      int
+		redisAddr   string
+		redisPrefix string
+		pidFile     string
+		once        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Live terminal dashboard of the server, handlers, agents, and traffic",
+		Long: `Show a full-screen dashboard with the interaction server's daemon status, the handlers configured
+in discord.yaml, agents in the broker registry, and interactions as they are routed to agents.
+
+Recent interactions are observed by subscribing to every handler agent's stream; Redis pub/sub delivers
+a copy to each subscriber, so the dashboard does not take traffic away from agents. "Undeliverable"
+counts interactions routed to an agent with no live registry entry.
+
+With interactions.delivery configured, the dashboard also follows agent acknowledgements the way the
+server does: "Unacked" is the number of envelopes still waiting for an Ack, and "Dead letters" counts
+envelopes that ran out of retries without one, for which the server sent the fallback message. Both
+start at zero when the dashboard opens.
+
+Press q or Esc to quit. --once prints a single snapshot instead (respects --output).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			d, err := newDashboard(cmd.Context(), opts, redisAddr, redisPrefix, pidFile, recent)
+			if err != nil {
+				return err
+			}
+			defer d.broker.Close()
+			if once {
+				snap := d.snapshot(cmd.Context())
+				return renderOutput(cmd, opts.output, snap, dashboardSummaryTable(snap))
+			}
+			return d.run(cmd.Context(), refresh)
+		},
+		Example: `Example:
+  arc-discord dashboard
+
+Example:
+  # Watch a remote fleet's registry, refreshing every 5s
+  arc-discord dashboard --redis-addr redis.internal:6379 --refresh 5s
+
+Example:
+  # One-shot snapshot for scripts
+  arc-discord dashboard --once --output json`,
+	}
+	cmd.Flags().DurationVar(&refresh, "refresh", defaultDashboardRefresh, "How often to poll the registry and daemon status")
+	cmd.Flags().IntVar(&recent, "recent", defaultDashboardRecent, "Number of recent interactions to keep")
+	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis host:port or redis[s]:// URL for the registry and agent streams")
+	cmd.Flags().StringVar(&redisPrefix, "redis-prefix", "", "Redis channel prefix (default arc:discord)")
+	cmd.Flags().StringVar(&pidFile, "pid-file", "", "Server PID file (default ~/.cache/vibe/discord-server.pid)")
+	cmd.Flags().BoolVar(&once, "once", false, "Print one snapshot and exit instead of opening the dashboard")
+	return cmd
+}
+
+// dashboardSnapshot is everything the dashboard shows at one refresh.
+type dashboardSnapshot struct {
+	Server        dashboardServer    `json:"server" yaml:"server"`
+	Handlers      []dashboardHandler `json:"handlers" yaml:"handlers"`
+	Agents        []dashboardAgent   `json:"agents" yaml:"agents"`
+	Recent        []dashboardEvent   `json:"recent" yaml:"recent"`
+	Undeliverable int                `json:"undeliverable" yaml:"undeliverable"`
+	Errors        []string           `json:"errors,omitempty" yaml:"errors,omitempty"`
+	UpdatedAt     time.Time          `json:"updated_at" yaml:"updated_at"`
+
+	// Unacked and DeadLetters are only tracked with interactions.delivery.
+	AckTracking bool `json:"ack_tracking" yaml:"ack_tracking"`
+	Unacked     int  `json:"unacked" yaml:"unacked"`
+	DeadLetters int  `json:"dead_letters" yaml:"dead_letters"`
+}
+
+type dashboardServer struct {
+	Status     string `json:"status" yaml:"status"`
+	ListenAddr string `json:"listen_addr" yaml:"listen_addr"`
+	PublicURL  string `json:"public_url,omitempty" yaml:"public_url,omitempty"`
+	Broker     string `json:"broker" yaml:"broker"`
+	ConfigPath string `json:"config_path" yaml:"config_path"`
+}
+
+type dashboardHandler struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Key       string `json:"key" yaml:"key"`
+	Agent     string `json:"agent" yaml:"agent"`
+	AgentLive bool   `json:"agent_live" yaml:"agent_live"`
+}
+
+type dashboardAgent struct {
+	broker.AgentInfo `yaml:",inline"`
+	Status           string `json:"status" yaml:"status"`
+}
+
+type dashboardEvent struct {
+	At    time.Time `json:"at" yaml:"at"`
+	Agent string    `json:"agent" yaml:"agent"`
+	Kind  string    `json:"kind" yaml:"kind"`
+	Key   string    `json:"key" yaml:"key"`
+	Live  bool      `json:"live" yaml:"live"`
+}
+
+// dashboard polls the registry and daemon status and records interactions
+// seen on the handler agents' streams.
+type dashboard struct {
+	broker   broker.Broker
+	server   dashboardServer
+	bindings []handlerBinding
+	daemon   daemonController
+	delivery deliveryConfig
+	limit    int
+	now      func() time.Time
+
+	mu            sync.Mutex
+	live          map[string]bool
+	recent        []dashboardEvent
+	undeliverable int
+	// unacked mirrors the server's ackTracker; seen holds envelope IDs
+	// already recorded or acked, so redeliveries and acks that arrive
+	// before their envelope are not tracked again.
+	unacked     map[string]*pendingDelivery
+	seen        recentIDs
+	deadLetters int
+}
+
+func newDashboard(ctx context.Context, opts *globalOptions, redisAddr, redisPrefix, pidFile string, limit int) (*dashboard, error) {
+	_, extra, cfgPath, err := opts.loadConfigWithInteractions()
+	if err != nil {
+		return nil, err
+	}
+	b, err := openAgentBroker(ctx, opts, redisAddr, redisPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultDashboardRecent
+	}
+	// openAgentBroker already validated the same settings.
+	brokerCfg, _ := extra.brokerConfig()
+	if redisAddr != "" {
+		brokerCfg.Addr = redisAddr
+	}
+	brokerDesc := brokerCfg.Backend
+	if brokerDesc == "" {
+		brokerDesc = broker.DefaultBackend
+	}
+	if brokerCfg.Addr != "" {
+		brokerDesc += " " + redactRedisAddr(brokerCfg.Addr)
+	}
+	return &dashboard{
+		broker: b,
+		server: dashboardServer{
+			ListenAddr: extra.Server.ListenAddr,
+			PublicURL:  extra.PublicURL,
+			Broker:     brokerDesc,
+			ConfigPath: cfgPath,
+		},
+		bindings: collectHandlerBindings(extra.Interactions),
+		daemon:   newDaemonManagerFn(daemonOptions{PIDFile: pidFile}),
+		delivery: extra.Interactions.Delivery,
+		limit:    limit,
+		now:      time.Now,
+		live:     map[string]bool{},
+		unacked:  map[string]*pendingDelivery{},
+	}, nil
+}
+
+// agents returns the distinct agents handlers route to, sorted.
+func (d *dashboard) agents() []string {
+	seen := map[string]bool{}
+	var agents []string
+	for _, b := range d.bindings {
+		name := strings.ToLower(b.Route.Agent)
+		if !seen[name] {
+			seen[name] = true
+			agents = append(agents, name)
+		}
+	}
+	sort.Strings(agents)
+	return agents
+}
+
+// watch subscribes to every handler agent's stream, and with
+// interactions.delivery to acknowledgements, until ctx is done.
+func (d *dashboard) watch(ctx context.Context) {
+	if bus, ok := d.broker.(broker.AckBus); ok && d.delivery.enabled() {
+		go func() {
+			_ = bus.SubscribeAcks(ctx, func(_ context.Context, ack broker.Ack) error {
+				d.acked(ack.ID)
+				return nil
+			})
+		}()
+	}
+	for _, agent := range d.agents() {
+		go func(agent string) {
+			_ = d.broker.Subscribe(ctx, agent, func(ctx context.Context, msg *broker.Message) error {
+				if env, err := msg.Envelope(); err == nil {
+					d.record(env)
+				}
+				return nil
+			})
+		}(agent)
+	}
+}
+
+func (d *dashboard) record(env *broker.Envelope) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	at := env.ReceivedAt
+	if at.IsZero() {
+		at = d.now()
+	}
+	live := d.live[strings.ToLower(env.Agent)]
+	if !live {
+		d.undeliverable++
+	}
+	d.recent = append([]dashboardEvent{{At: at, Agent: env.Agent, Kind: env.Kind, Key: env.Key, Live: live}}, d.recent...)
+	if len(d.recent) > d.limit {
+		d.recent = d.recent[:d.limit]
+	}
+	if d.delivery.enabled() && env.ID != "" && d.seen.add(env.ID) {
+		d.unacked[env.ID] = &pendingDelivery{env: *env, deadline: d.now().Add(d.delivery.AckTimeout)}
+	}
+}
+
+func (d *dashboard) acked(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen.add(id)
+	delete(d.unacked, id)
+}
+
+// snapshot refreshes the registry and daemon status. Failures are reported
+// in Errors so one unreachable source doesn't blank the whole screen.
+func (d *dashboard) snapshot(ctx context.Context) dashboardSnapshot {
+	now := d.now()
+	snap := dashboardSnapshot{Server: d.server, UpdatedAt: now}
+
+	status, err := d.daemon.Status()
+	if err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("daemon status: %v", err))
+	}
+	snap.Server.Status = status
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	live := map[string]bool{}
+	infos, err := d.broker.Registry().List(ctx)
+	if err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("registry: %v", err))
+	}
+	for _, info := range infos {
+		freshness := agentFreshness(info, defaultStaleAfter, now)
+		if freshness == agentStatusLive {
+			live[strings.ToLower(info.Agent)] = true
+		}
+		snap.Agents = append(snap.Agents, dashboardAgent{AgentInfo: info, Status: freshness})
+	}
+	sort.Slice(snap.Agents, func(i, j int) bool { return snap.Agents[i].Agent < snap.Agents[j].Agent })
+
+	for _, b := range d.bindings {
+		snap.Handlers = append(snap.Handlers, dashboardHandler{
+			Kind:      b.Kind,
+			Key:       b.Key,
+			Agent:     b.Route.Agent,
+			AgentLive: live[strings.ToLower(b.Route.Agent)],
+		})
+	}
+	sort.Slice(snap.Handlers, func(i, j int) bool {
+		if snap.Handlers[i].Kind != snap.Handlers[j].Kind {
+			return snap.Handlers[i].Kind < snap.Handlers[j].Kind
+		}
+		return snap.Handlers[i].Key < snap.Handlers[j].Key
+	})
+
+	d.mu.Lock()
+	if err == nil {
+		d.live = live
+	}
+	snap.Recent = append([]dashboardEvent(nil), d.recent...)
+	snap.Undeliverable = d.undeliverable
+	if d.delivery.enabled() {
+		_, failed := expirePending(d.unacked, d.delivery, now)
+		d.deadLetters += len(failed)
+		snap.AckTracking = true
+		snap.Unacked = len(d.unacked)
+		snap.DeadLetters = d.deadLetters
+	}
+	d.mu.Unlock()
+	return snap
+}
+
+// run opens the full-screen dashboard until the user quits or ctx is done.
+func (d *dashboard) run(ctx context.Context, refresh time.Duration) error {
+	if refresh <= 0 {
+		refresh = defaultDashboardRefresh
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	d.watch(ctx)
+
+	app := tview.NewApplication()
+	header := tview.NewTextView().SetDynamicColors(true)
+	handlers := newDashboardTable("Handlers")
+	agents := newDashboardTable("Agents")
+	recent := newDashboardTable("Recent interactions")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 4, 0, false).
+		AddItem(tview.NewFlex().
+			AddItem(handlers, 0, 1, false).
+			AddItem(agents, 0, 1, false), 0, 1, false).
+		AddItem(recent, 0, 1, true)
+
+	draw := func(snap dashboardSnapshot) {
+		header.SetText(dashboardHeader(snap))
+		fillDashboardTable(handlers, dashboardHandlersTable(snap))
+		fillDashboardTable(agents, dashboardAgentsTable(snap))
+		fillDashboardTable(recent, dashboardRecentTable(snap))
+	}
+	draw(d.snapshot(ctx))
+
+	app.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEscape || ev.Rune() == 'q' {
+			app.Stop()
+			return nil
+		}
+		return ev
+	})
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				app.Stop()
+				return
+			case <-ticker.C:
+				snap := d.snapshot(ctx)
+				app.QueueUpdateDraw(func() { draw(snap) })
+			}
+		}
+	}()
+	if err := app.SetRoot(layout, true).Run(); err != nil {
+		return (&arcer.CLIError{Msg: "failed to start dashboard", Hint: "run in an interactive terminal, or use --once"}).WithCause(err)
+	}
+	return nil
+}
+
+func newDashboardTable(title string) *tview.Table {
+	t := tview.NewTable().SetFixed(1, 0)
+	t.SetBorder(true).SetTitle(" " + title + " ")
+	return t
+}
+
+func fillDashboardTable(t *tview.Table, data *tableData) {
+	t.Clear()
+	for col, h := range data.headers {
+		t.SetCell(0, col, tview.NewTableCell(h).SetTextColor(tcell.ColorYellow).SetSelectable(false))
+	}
+	for row, cells := range data.rows {
+		for col, cell := range cells {
+			t.SetCell(row+1, col, tview.NewTableCell(tview.Escape(cell)).SetExpansion(1))
+		}
+	}
+}
+
+func dashboardHeader(snap dashboardSnapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]Server[::-] %s  listen %s  broker %s\n", valueOrDash(snap.Server.Status), valueOrDash(snap.Server.ListenAddr), snap.Server.Broker)
+	fmt.Fprintf(&b, "Public URL %s  config %s\n", valueOrDash(snap.Server.PublicURL), valueOrDash(snap.Server.ConfigPath))
+	fmt.Fprintf(&b, "Undeliverable %d  %s  updated %s  (q to quit)", snap.Undeliverable, dashboardAcks(snap), snap.UpdatedAt.Format("15:04:05"))
+	if len(snap.Errors) > 0 {
+		fmt.Fprintf(&b, "  [red]%s[-]", tview.Escape(strings.Join(snap.Errors, "; ")))
+	}
+	return b.String()
+}
+
+// dashboardAcks summarises acknowledgement tracking for the header.
+func dashboardAcks(snap dashboardSnapshot) string {
+	if !snap.AckTracking {
+		return "Unacked - (interactions.delivery off)"
+	}
+	return fmt.Sprintf("Unacked %d  dead letters %d", snap.Unacked, snap.DeadLetters)
+}
+
+func dashboardHandlersTable(snap dashboardSnapshot) *tableData {
+	rows := make([][]string, 0, len(snap.Handlers))
+	for _, h := range snap.Handlers {
+		state := "no live agent"
+		if h.AgentLive {
+			state = "live"
+		}
+		rows = append(rows, []string{h.Kind, h.Key, h.Agent, state})
+	}
+	return &tableData{headers: []string{"Kind", "Key", "Agent", "Agent Status"}, rows: rows}
+}
+
+func dashboardAgentsTable(snap dashboardSnapshot) *tableData {
+	rows := make([][]string, 0, len(snap.Agents))
+	for _, a := range snap.Agents {
+		rows = append(rows, []string{a.Agent, a.Status, valueOrDash(a.Version), a.Hostname, formatHeartbeatAge(a.UpdatedAt, snap.UpdatedAt)})
+	}
+	return &tableData{headers: []string{"Agent", "Status", "Version", "Host", "Last Heartbeat"}, rows: rows}
+}
+
+func dashboardRecentTable(snap dashboardSnapshot) *tableData {
+	rows := make([][]string, 0, len(snap.Recent))
+	for _, ev := range snap.Recent {
+		delivered := "yes"
+		if !ev.Live {
+			delivered = "no live agent"
+		}
+		rows = append(rows, []string{ev.At.Format("15:04:05"), ev.Kind, ev.Key, ev.Agent, delivered})
+	}
+	return &tableData{headers: []string{"Time", "Kind", "Key", "Agent", "Delivered"}, rows: rows}
+}
+
+// dashboardSummaryTable is the --once table view.
+func dashboardSummaryTable(snap dashboardSnapshot) *tableData {
+	live := 0
+	for _, a := range snap.Agents {
+		if a.Status == agentStatusLive {
+			live++
+		}
+	}
+	data := map[string]string{
+		"server":        valueOrDash(snap.Server.Status),
+		"listen_addr":   snap.Server.ListenAddr,
+		"broker":        snap.Server.Broker,
+		"handlers":      fmt.Sprintf("%d", len(snap.Handlers)),
+		"agents":        fmt.Sprintf("%d (%d live)", len(snap.Agents), live),
+		"undeliverable": fmt.Sprintf("%d", snap.Undeliverable),
+		"acks":          dashboardAcks(snap),
+	}
+	if len(snap.Errors) > 0 {
+		data["errors"] = strings.Join(snap.Errors, "; ")
+	}
+	return keyValueTable(data)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+
+	"github.com/yourorg/arc-sdk/output"
+)
+
+const dashboardTestConfig = `discord:
+  public_key: "abc"
+interactions:
+  enabled: true
+  handlers:
+    commands:
+      ask:
+        agent: claude
+      deploy:
+        agent: ghost
+  delivery:
+    ack_timeout: 5s
+    retries: 1
+`
+
+func hookDashboard(t *testing.T) *broker.Memory {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte(dashboardTestConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig()
+	loadDiscordConfigFn = func(string) (*discordconfig.Config, string, error) { return cfg, path, nil }
+	mem := broker.NewMemory()
+	newBrokerFn = func(context.Context, broker.Config) (broker.Broker, error) { return mem, nil }
+	newDaemonManagerFn = func(opts daemonOptions) daemonController { return &fakeDaemon{status: "running (pid 42)"} }
+	t.Cleanup(func() {
+		loadDiscordConfigFn = loadDiscordConfig
+		newBrokerFn = func(ctx context.Context, cfg broker.Config) (broker.Broker, error) {
+			return broker.Open(ctx, cfg)
+		}
+		newDaemonManagerFn = func(opts daemonOptions) daemonController { return newDaemonManager(opts) }
+	})
+	return mem
+}
+
+func TestDashboardTracksAgentsAndTraffic(t *testing.T) {
+	mem := hookDashboard(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Now()
+	if err := mem.Registry().Register(ctx, broker.AgentInfo{Agent: "claude", StartedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	d, err := newDashboard(ctx, &globalOptions{}, "", "", "", 10)
+	if err != nil {
+		t.Fatalf("newDashboard: %v", err)
+	}
+	snap := d.snapshot(ctx)
+	if snap.Server.Status != "running (pid 42)" || len(snap.Agents) != 1 || len(snap.Handlers) != 2 {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+	if !snap.Handlers[0].AgentLive || snap.Handlers[1].AgentLive {
+		t.Fatalf("expected ask live and deploy without agent: %+v", snap.Handlers)
+	}
+
+	d.watch(ctx)
+	waitFor(t, func() bool { return mem.Subscribers("claude") == 1 && mem.Subscribers("ghost") == 1 })
+	_ = mem.Publish(ctx, &broker.Envelope{Agent: "claude", Kind: "command", Key: "ask"})
+	_ = mem.Publish(ctx, &broker.Envelope{Agent: "ghost", Kind: "command", Key: "deploy"})
+	waitFor(t, func() bool { return len(d.snapshot(ctx).Recent) == 2 })
+
+	snap = d.snapshot(ctx)
+	if snap.Undeliverable != 1 {
+		t.Fatalf("expected one undeliverable interaction, got %d", snap.Undeliverable)
+	}
+	if rows := dashboardRecentTable(snap).rows; len(rows) != 2 {
+		t.Fatalf("expected two recent rows, got %v", rows)
+	}
+}
+
+func TestDashboardTracksUnackedAndDeadLetters(t *testing.T) {
+	mem := hookDashboard(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d, err := newDashboard(ctx, &globalOptions{}, "", "", "", 10)
+	if err != nil {
+		t.Fatalf("newDashboard: %v", err)
+	}
+	now := time.Now()
+	d.now = func() time.Time { return now }
+
+	d.watch(ctx)
+	waitFor(t, func() bool { return mem.Subscribers("claude") == 1 })
+	for _, id := range []string{"e1", "e2"} {
+		_ = mem.Publish(ctx, &broker.Envelope{Agent: "claude", Kind: "command", Key: "ask", ID: id, Attempt: 1})
+	}
+	waitFor(t, func() bool { return d.snapshot(ctx).Unacked == 2 })
+
+	payload, _ := json.Marshal(&broker.Envelope{Agent: "claude", ID: "e1"})
+	waitFor(t, func() bool {
+		// Ack again until the dashboard's ack subscription is attached.
+		_ = mem.Ack(ctx, &broker.Message{Agent: "claude", Payload: payload})
+		return d.snapshot(ctx).Unacked == 1
+	})
+
+	// e2 gets its retry after one ack timeout and is a dead letter after the next.
+	now = now.Add(5 * time.Second)
+	if snap := d.snapshot(ctx); snap.Unacked != 1 || snap.DeadLetters != 0 {
+		t.Fatalf("expected e2 to be awaiting its retry, got %d unacked, %d dead", snap.Unacked, snap.DeadLetters)
+	}
+	now = now.Add(5 * time.Second)
+	snap := d.snapshot(ctx)
+	if !snap.AckTracking || snap.Unacked != 0 || snap.DeadLetters != 1 {
+		t.Fatalf("expected e2 to be dead-lettered, got %+v", snap)
+	}
+	if got := dashboardAcks(snap); got != "Unacked 0  dead letters 1" {
+		t.Fatalf("unexpected header %q", got)
+	}
+}
+
+func TestDashboardOnceJSON(t *testing.T) {
+	hookDashboard(t)
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := dashboardCmd(opts)
+	cmd.SetArgs([]string{"--once"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var snap dashboardSnapshot
+	if err := json.Unmarshal(buf.Bytes(), &snap); err != nil {
+		t.Fatalf("decode: %v\n%s", err, buf.String())
+	}
+	if len(snap.Handlers) != 2 || snap.Server.Broker != "redis 127.0.0.1:6379" {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	debugVarsOnce sync.Once
+	processStart  = time.Now()
+	// interactionRejections counts requests refused before routing, by
+	// reason (signature, stale_timestamp, remote_addr).
+	interactionRejections = new(expvar.Map)
+)
+
+// validateDebugAddr requires server.debug_addr to bind a loopback address:
+// pprof and expvar expose heap contents and command lines, and they sit
+// outside server.auth.
+func validateDebugAddr(addr string) error {
+	if addr == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("server.debug_addr %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("server.debug_addr %q must bind a loopback address such as 127.0.0.1:6060", addr)
+}
+
+// newDebugMux serves net/http/pprof under /debug/pprof/ and expvar under
+// /debug/vars on a dedicated mux, so nothing leaks onto http.DefaultServeMux.
+func newDebugMux() *http.ServeMux {
+	debugVarsOnce.Do(func() {
+		expvar.Publish("goroutines", expvar.Func(func() any { return runtime.NumGoroutine() }))
+		expvar.Publish("uptime_seconds", expvar.Func(func() any { return int64(time.Since(processStart).Seconds()) }))
+		expvar.Publish("ratelimit", expvar.Func(func() any { return rateLimitRecorder.Snapshot() }))
+		expvar.Publish("interactions_rejected", interactionRejections)
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// startDebugServer binds addr before returning so a taken port fails server
+// start instead of being logged later, then serves until ctx is done.
+func startDebugServer(ctx context.Context, addr string, logf func(string, ...any)) (net.Addr, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// No WriteTimeout: /debug/pprof/profile streams for ?seconds= (30 by default).
+	srv := &http.Server{Handler: newDebugMux(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logf("debug listener stopped: %v\n", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	return ln.Addr(), nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateDebugAddr(t *testing.T) {
+	for _, addr := range []string{"", "127.0.0.1:6060", "localhost:6060", "[::1]:6060"} {
+		if err := validateDebugAddr(addr); err != nil {
+			t.Errorf("%q: unexpected error %v", addr, err)
+		}
+	}
+	for _, addr := range []string{":6060", "0.0.0.0:6060", "10.0.0.5:6060", "example.com:6060", "6060"} {
+		if err := validateDebugAddr(addr); err == nil {
+			t.Errorf("%q: expected rejection", addr)
+		}
+	}
+}
+
+func TestDebugServerServesPprofAndVars(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	addr, err := startDebugServer(ctx, "127.0.0.1:0", t.Logf)
+	if err != nil {
+		t.Fatalf("startDebugServer: %v", err)
+	}
+	base := "http://" + addr.String()
+
+	for path, want := range map[string]string{
+		"/debug/vars":         `"goroutines"`,
+		"/debug/pprof/":       "goroutine",
+		"/debug/pprof/symbol": "num_symbols",
+	} {
+		resp, err := http.Get(base + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), want) {
+			t.Fatalf("GET %s: status %d, body missing %q", path, resp.StatusCode, want)
+		}
+	}
+
+	resp, err := http.Get(base + "/interactions")
+	if err != nil {
+		t.Fatalf("GET /interactions: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("debug listener should only serve /debug, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+const (
+	defaultFallbackMessage = "The agent that handles this is unavailable right now. Please try again later."
+	minAckCheckInterval    = 100 * time.Millisecond
+)
+
+// deliveryConfig is interactions.delivery. With an ack_timeout the server
+// waits for agents to acknowledge each envelope, publishes unacknowledged
+// ones again up to retries times, and then replaces the interaction's
+// response with fallback_message.
+type deliveryConfig struct {
+	AckTimeout      time.Duration `yaml:"ack_timeout"`
+	Retries         int           `yaml:"retries"`
+	FallbackMessage string        `yaml:"fallback_message"`
+}
+
+func (c deliveryConfig) enabled() bool {
+	return c.AckTimeout > 0
+}
+
+func (c deliveryConfig) validate() error {
+	if c.AckTimeout < 0 {
+		return errors.New("ack_timeout must not be negative")
+	}
+	if c.Retries < 0 {
+		return errors.New("retries must not be negative")
+	}
+	if c.Retries > 0 && !c.enabled() {
+		return errors.New("retries need an ack_timeout")
+	}
+	return nil
+}
+
+// ackTracker is the publisher for interactions.delivery: it gives each
+// envelope an ID and keeps it until the agent's Ack arrives. Tracking is in
+// memory, so envelopes pending when the server stops are not retried.
+type ackTracker struct {
+	broker.Publisher
+	cfg    deliveryConfig
+	appID  string
+	client interactionResponder
+	logger *logger.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*pendingDelivery
+}
+
+type pendingDelivery struct {
+	env      broker.Envelope
+	deadline time.Time
+}
+
+func newAckTracker(publisher broker.Publisher, cfg deliveryConfig, appID string, client interactionResponder, log *logger.Logger) *ackTracker {
+	if log == nil {
+		log = logger.Default()
+	}
+	return &ackTracker{
+		Publisher: publisher,
+		cfg:       cfg,
+		appID:     appID,
+		client:    client,
+		logger:    log,
+		now:       time.Now,
+		pending:   map[string]*pendingDelivery{},
+	}
+}
+
+func (t *ackTracker) Publish(ctx context.Context, env *broker.Envelope) error {
+	if env == nil || env.Agent == "" {
+		return t.Publisher.Publish(ctx, env)
+	}
+	if env.ID == "" {
+		env.ID = newRequestID()
+	}
+	env.Attempt = 1
+	// Track first so an agent that acks before Publish returns is seen.
+	t.mu.Lock()
+	t.pending[env.ID] = &pendingDelivery{env: *env, deadline: t.now().Add(t.cfg.AckTimeout)}
+	t.mu.Unlock()
+	if err := t.Publisher.Publish(ctx, env); err != nil {
+		t.acked(env.ID)
+		return err
+	}
+	return nil
+}
+
+func (t *ackTracker) acked(id string) {
+	t.mu.Lock()
+	delete(t.pending, id)
+	t.mu.Unlock()
+}
+
+// run consumes acknowledgements from bus and checks deadlines until ctx is
+// done.
+func (t *ackTracker) run(ctx context.Context, bus broker.AckBus) {
+	go func() {
+		err := bus.SubscribeAcks(ctx, func(_ context.Context, ack broker.Ack) error {
+			t.acked(ack.ID)
+			return nil
+		})
+		if err != nil && ctx.Err() == nil {
+			t.logger.Error("ack subscription failed; every interaction will now be redispatched", "error", err)
+		}
+	}()
+	interval := t.cfg.AckTimeout / 4
+	if interval < minAckCheckInterval {
+		interval = minAckCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.expire(ctx)
+		}
+	}
+}
+
+// expire publishes overdue envelopes again, or sends the fallback for those
+// out of retries.
+func (t *ackTracker) expire(ctx context.Context) {
+	t.mu.Lock()
+	retry, failed := expirePending(t.pending, t.cfg, t.now())
+	t.mu.Unlock()
+
+	for i := range retry {
+		env := &retry[i]
+		t.logger.Warn("redispatching unacknowledged interaction", "agent", env.Agent, "key", env.Key, "id", env.ID, "attempt", env.Attempt)
+		if err := t.Publisher.Publish(ctx, env); err != nil {
+			t.logger.Error("redispatch failed", "agent", env.Agent, "id", env.ID, "error", err)
+		}
+	}
+	for i := range failed {
+		env := &failed[i]
+		t.logger.Warn("no agent acknowledged interaction; sending fallback", "agent", env.Agent, "key", env.Key, "id", env.ID, "attempts", env.Attempt)
+		if err := t.fallback(ctx, env); err != nil {
+			t.logger.Error("fallback response failed", "agent", env.Agent, "id", env.ID, "error", err)
+		}
+	}
+}
+
+// expirePending moves overdue deliveries on: those with retries left get
+// their next attempt and deadline and are returned in retry, the rest are
+// removed from pending and returned in failed.
+func expirePending(pending map[string]*pendingDelivery, cfg deliveryConfig, now time.Time) (retry, failed []broker.Envelope) {
+	for id, p := range pending {
+		if now.Before(p.deadline) {
+			continue
+		}
+		if p.env.Attempt <= cfg.Retries {
+			p.env.Attempt++
+			p.deadline = now.Add(cfg.AckTimeout)
+			retry = append(retry, p.env)
+			continue
+		}
+		delete(pending, id)
+		failed = append(failed, p.env)
+	}
+	return retry, failed
+}
+
+// fallback replaces the deferred "thinking" response with the fallback
+// message.
+func (t *ackTracker) fallback(ctx context.Context, env *broker.Envelope) error {
+	var interaction types.Interaction
+	if err := json.Unmarshal(env.Interaction, &interaction); err != nil {
+		return fmt.Errorf("decode interaction: %w", err)
+	}
+	if interaction.Token == "" {
+		return errors.New("interaction missing token")
+	}
+	message := t.cfg.FallbackMessage
+	if message == "" {
+		message = defaultFallbackMessage
+	}
+	opCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := t.client.EditOriginalInteractionResponse(opCtx, t.appID, interaction.Token, &types.MessageEditParams{Content: message})
+	return err
+}
+
+// recentIDs remembers the last envelope IDs an agent handled, so it can skip
+// redeliveries of envelopes whose Ack reached the server too late.
+type recentIDs struct {
+	mu    sync.Mutex
+	size  int
+	order []string
+	ids   map[string]bool
+}
+
+const recentIDsSize = 1024
+
+// add records id and reports whether it is new.
+func (r *recentIDs) add(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ids == nil {
+		r.ids = map[string]bool{}
+		if r.size == 0 {
+			r.size = recentIDsSize
+		}
+	}
+	if r.ids[id] {
+		return false
+	}
+	r.ids[id] = true
+	r.order = append(r.order, id)
+	if len(r.order) > r.size {
+		delete(r.ids, r.order[0])
+		r.order = r.order[1:]
+	}
+	return true
+}
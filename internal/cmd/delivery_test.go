@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestAckTrackerRedispatchesThenFallsBack(t *testing.T) {
+	pub := &stubPublisher{}
+	responder := &stubInteractionResponder{}
+	tracker := newAckTracker(pub, deliveryConfig{AckTimeout: 10 * time.Second, Retries: 1}, "app123", responder, nil)
+	now := time.Unix(1700000000, 0)
+	tracker.now = func() time.Time { return now }
+
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+	if err := tracker.Publish(context.Background(), &broker.Envelope{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw}); err != nil {
+		t.Fatal(err)
+	}
+	first := pub.envelopes[0]
+	if first.ID == "" || first.Attempt != 1 {
+		t.Fatalf("expected an ID and attempt 1, got %+v", first)
+	}
+
+	tracker.expire(context.Background())
+	if len(pub.envelopes) != 1 {
+		t.Fatalf("redispatched before the deadline")
+	}
+	now = now.Add(10 * time.Second)
+	tracker.expire(context.Background())
+	if len(pub.envelopes) != 2 || pub.envelopes[1].ID != first.ID || pub.envelopes[1].Attempt != 2 {
+		t.Fatalf("expected a second attempt, got %+v", pub.envelopes)
+	}
+	if responder.called {
+		t.Fatal("fallback sent while retries remain")
+	}
+
+	now = now.Add(10 * time.Second)
+	tracker.expire(context.Background())
+	if len(pub.envelopes) != 2 || !responder.called || responder.token != "tok" || responder.params.Content != defaultFallbackMessage {
+		t.Fatalf("expected fallback edit, got %d envelopes and %+v", len(pub.envelopes), responder.params)
+	}
+	if len(tracker.pending) != 0 {
+		t.Fatalf("expected nothing pending, got %d", len(tracker.pending))
+	}
+}
+
+func TestAckTrackerStopsTrackingAcknowledgedEnvelopes(t *testing.T) {
+	mem := broker.NewMemory()
+	defer mem.Close()
+	responder := &stubInteractionResponder{}
+	tracker := newAckTracker(mem, deliveryConfig{AckTimeout: time.Minute}, "app123", responder, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tracker.run(ctx, mem)
+	go mem.Subscribe(ctx, "ops", func(ctx context.Context, msg *broker.Message) error {
+		return mem.Ack(ctx, msg)
+	})
+	deadline := time.Now().Add(time.Second)
+	for mem.Subscribers("ops") == 0 || mem.Subscribers("\x00acks") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscribers never attached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+	if err := tracker.Publish(ctx, &broker.Envelope{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw}); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		tracker.mu.Lock()
+		pending := len(tracker.pending)
+		tracker.mu.Unlock()
+		if pending == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("ack never cleared the pending envelope")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLoadInteractionSettingsReadsDelivery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	config := "interactions:\n  delivery:\n    ack_timeout: 5s\n    retries: 2\n    fallback_message: Try again soon.\n"
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	want := deliveryConfig{AckTimeout: 5 * time.Second, Retries: 2, FallbackMessage: "Try again soon."}
+	if settings.Interactions.Delivery != want {
+		t.Fatalf("expected %+v, got %+v", want, settings.Interactions.Delivery)
+	}
+}
+
+func TestAgentListenerSkipsRedeliveredEnvelopes(t *testing.T) {
+	responder := &stubInteractionResponder{}
+	agent := &stubAgentResponder{params: &types.MessageEditParams{Content: "done"}}
+	listener := newAgentListener("ops", "app123", responder, testPrinter{t})
+	listener.responder = agent
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+	env := &broker.Envelope{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw, ID: "d1", Attempt: 1}
+	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
+		t.Fatal(err)
+	}
+	agent.payload = nil
+	env.Attempt = 2
+	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
+		t.Fatal(err)
+	}
+	if agent.payload != nil {
+		t.Fatal("redelivered envelope was handled twice")
+	}
+}
+
+func TestRecentIDsForgetsOldest(t *testing.T) {
+	ids := recentIDs{size: 2}
+	for _, id := range []string{"a", "b", "c"} {
+		if !ids.add(id) {
+			t.Fatalf("%s should be new", id)
+		}
+	}
+	if ids.add("c") || !ids.add("a") {
+		t.Fatal("expected c remembered and a forgotten")
+	}
+}
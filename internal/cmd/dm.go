@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func dmCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dm",
+		Short: "Send direct messages to users with the authenticated bot",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(dmSendCmd(opts))
+	return cmd
+}
+
+func dmSendCmd(opts *globalOptions) *cobra.Command {
+	var (
+		userID      string
+		payloadPath string
+		content     string
+		tmpl        templateFlags
+	)
+
+	c := &cobra.Command{
+		Use:   "send",
+		Short: "Send a direct message to a user via the bot token",
+		Long: `Open (or reuse) a DM channel with a user via POST /users/@me/channels and send a message to it.
+Accepts the same --content, --payload (types.MessageCreateParams JSON), and --template inputs as "message send".
+The user must share a guild with the bot and allow direct messages from server members.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if userID == "" {
+				return &arcer.CLIError{Msg: "--user is required", Hint: "pass the Discord user ID to message"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			return runDMSend(cmd, opts, userID, messageSendInput{
+				payloadPath: payloadPath,
+				stdin:       cmd.InOrStdin(),
+				content:     content,
+				template:    tmpl,
+				output:      opts.output,
+			})
+		},
+		Example: `Example:
+  # Send a plain text DM
+  arc-discord dm send --user 123456789012345678 --content "Your deploy finished"
+
+Example:
+  # Send an embed-driven payload from disk
+  arc-discord dm send --user 123456789012345678 --payload advanced_message.json`,
+	}
+
+	c.Flags().StringVar(&userID, "user", "", "Recipient user ID")
+	c.Flags().StringVar(&payloadPath, "payload", "", "Path to JSON payload for types.MessageCreateParams (- reads stdin)")
+	c.Flags().StringVar(&content, "content", "", "Message content when not using --payload")
+	tmpl.register(c)
+	return c
+}
+
+func runDMSend(cmd *cobra.Command, opts *globalOptions, userID string, in messageSendInput) error {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	fmtr, err := opts.formatter(cfg)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := in.template.compile(cmd, fmtr)
+	if err != nil {
+		return err
+	}
+	params, err := buildMessageParams(in, tmpl)
+	if err != nil {
+		return err
+	}
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	channel, err := bot.Users().CreateDM(ctx, userID)
+	if err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to open DM channel with user %s", userID)}).WithCause(err)
+	}
+
+	msg, err := bot.Messages().CreateMessage(ctx, channel.ID, params)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to send direct message"}).WithCause(err)
+	}
+
+	data := map[string]string{
+		"message_id": msg.ID,
+		"channel_id": channel.ID,
+		"user_id":    userID,
+		"timestamp":  fmtr.DateTime(msg.Timestamp),
+		"status":     "sent",
+	}
+
+	return renderOutput(cmd, in.output, msg, keyValueTable(data))
+}
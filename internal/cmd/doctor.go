@@ -0,0 +1,395 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	"github.com/yourorg/arc-sdk/output"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+type doctorReport struct {
+	ConfigPath string        `json:"config_path,omitempty" yaml:"config_path,omitempty"`
+	Passed     bool          `json:"passed" yaml:"passed"`
+	Checks     []doctorCheck `json:"checks" yaml:"checks"`
+}
+
+type doctorCheck struct {
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	Required bool   `json:"required" yaml:"required"`
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`
+	HowToFix string `json:"how_to_fix,omitempty" yaml:"how_to_fix,omitempty"`
+}
+
+func doctorCmd(opts *globalOptions) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check configuration, Discord credentials, and local tooling in one pass",
+		Long: `Run the same prerequisite checks as "server start --check-prereqs", then verify the setup
+against Discord itself: the bot token is accepted, it belongs to the configured application, the
+registered slash commands match the configured handlers, which privileged gateway intents are
+enabled, every configured webhook still exists, and a tunnel binary is installed.
+
+Exits non-zero when any required check fails, so it can gate CI or deploy scripts.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			report, err := runDoctor(ctx, opts, dryRun)
+			if err != nil {
+				return err
+			}
+			if opts.output.Is(output.OutputTable) {
+				cmd.Print(report.FormatReport())
+			} else if err := renderOutput(cmd, opts.output, newDoctorReport(report), nil); err != nil {
+				return err
+			}
+			if !report.AllPassed {
+				return &arcer.CLIError{
+					Msg:  fmt.Sprintf("doctor found %d failing check(s)", countFailedChecks(report)),
+					Hint: "fix the issues above and run arc-discord doctor again",
+				}
+			}
+			return nil
+		},
+		Example: `Example:
+  arc-discord doctor
+
+Example:
+  # Fail a CI job when the bot is misconfigured
+  arc-discord doctor --output json > doctor.json`,
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Skip the public key requirement, as server start --dry-run does")
+	return cmd
+}
+
+// runDoctor extends the server prerequisite report with checks that talk to
+// Discord and the local machine. Live checks are skipped when the config
+// itself could not be loaded.
+func runDoctor(ctx context.Context, opts *globalOptions, dryRun bool) (*PrereqReport, error) {
+	prereqs := NewServerPrereqChecker(opts, serverStartOptions{DryRun: dryRun})
+	prereqs.skipDiscord = true
+	report, err := prereqs.Check(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, extra, _, err := opts.loadConfigWithInteractions()
+	if err != nil {
+		return report, nil
+	}
+
+	checker := &doctorChecker{cfg: cfg, extra: extra, http: http.DefaultClient, lookPath: lookPath}
+	if strings.TrimSpace(cfg.Discord.BotToken) != "" {
+		bot, err := newBotClientFn(cfg, opts.tokenOverride)
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+		}
+		checker.bot = bot
+	}
+	report.Checks = append(report.Checks, checker.run(ctx)...)
+	report.AllPassed = countFailedChecks(report) == 0
+	return report, nil
+}
+
+// doctorChecker runs the live checks. bot is nil when no token is configured.
+type doctorChecker struct {
+	cfg      *discordconfig.Config
+	extra    *interactionSettings
+	bot      botClient
+	http     *http.Client
+	lookPath func(string) (string, error)
+}
+
+func (d *doctorChecker) run(ctx context.Context) []PrereqCheck {
+	tokenCheck := d.checkBotToken(ctx)
+	checks := []PrereqCheck{tokenCheck}
+	if tokenCheck.Status == PrereqOK {
+		app, appCheck := d.checkApplication(ctx)
+		checks = append(checks, appCheck)
+		if app != nil {
+			checks = append(checks, d.checkIntents(app))
+			checks = append(checks, d.checkRegisteredCommands(ctx, app.ID))
+		}
+	}
+	checks = append(checks, d.checkWebhooks(ctx)...)
+	checks = append(checks, d.checkTunnelBinaries())
+	return checks
+}
+
+func (d *doctorChecker) checkBotToken(ctx context.Context) PrereqCheck {
+	if d.bot == nil {
+		return PrereqCheck{
+			Name:        "Bot Token",
+			Status:      PrereqMissing,
+			Required:    true,
+			Description: "Authenticates REST calls such as registering commands and editing replies",
+			ConfigKey:   "discord.bot_token",
+			EnvVar:      "DISCORD_BOT_TOKEN",
+			HowToFix:    "Add the bot token from the developer portal (Bot -> Reset Token)",
+		}
+	}
+	return checkBotToken(ctx, d.bot)
+}
+
+func (d *doctorChecker) checkApplication(ctx context.Context) (*types.Application, PrereqCheck) {
+	check := PrereqCheck{
+		Name:        "Application Ownership",
+		Required:    true,
+		Description: "The bot token must belong to the configured application",
+		ConfigKey:   "discord.application_id",
+	}
+	app, err := d.bot.Applications().GetCurrentApplication(ctx)
+	if err != nil {
+		check.Status = discordErrorStatus(err)
+		check.Value = err.Error()
+		check.HowToFix = "Could not read the application for this token; retry or check Discord's status page"
+		return nil, check
+	}
+	configured := strings.TrimSpace(d.cfg.Discord.ApplicationID)
+	switch {
+	case configured == "":
+		check.Status = PrereqMissing
+		check.Value = fmt.Sprintf("token belongs to %s (%s)", app.Name, app.ID)
+		check.HowToFix = fmt.Sprintf("Set discord.application_id to %s", app.ID)
+	case configured != app.ID:
+		check.Status = PrereqInvalid
+		check.Value = fmt.Sprintf("configured %s, token belongs to %s (%s)", configured, app.Name, app.ID)
+		check.HowToFix = "Use the bot token and application ID from the same application"
+	default:
+		check.Status = PrereqOK
+		check.Value = fmt.Sprintf("%s (%s)", app.Name, app.ID)
+	}
+	return app, check
+}
+
+func (d *doctorChecker) checkIntents(app *types.Application) PrereqCheck {
+	return checkPrivilegedIntents(app, requiredIntents(d.cfg, d.extra))
+}
+
+func (d *doctorChecker) checkRegisteredCommands(ctx context.Context, appID string) PrereqCheck {
+	check := PrereqCheck{
+		Name:        "Registered Commands",
+		Required:    true,
+		Description: "Slash commands registered with Discord should match interactions.handlers.commands",
+		ConfigKey:   "interactions.handlers.commands",
+	}
+	svc := d.bot.ApplicationCommands(appID)
+	registered, err := svc.GetGlobalApplicationCommands(ctx)
+	if err != nil {
+		check.Status = discordErrorStatus(err)
+		check.Value = err.Error()
+		return check
+	}
+	if guildID := d.cfg.Discord.DefaultGuildID; guildID != "" {
+		guildCmds, err := svc.GetGuildApplicationCommands(ctx, guildID)
+		if err != nil {
+			check.Status = discordErrorStatus(err)
+			check.Value = err.Error()
+			return check
+		}
+		registered = append(registered, guildCmds...)
+	}
+
+	names := map[string]bool{}
+	for _, c := range registered {
+		if c.Type == 0 || c.Type == types.ApplicationCommandTypeChatInput {
+			names[strings.ToLower(c.Name)] = true
+		}
+	}
+	handled := map[string]bool{}
+	var unregistered, unhandled []string
+	for name := range d.extra.Interactions.Handlers.Commands {
+		handled[strings.ToLower(name)] = true
+		if !names[strings.ToLower(name)] {
+			unregistered = append(unregistered, name)
+		}
+	}
+	for name := range names {
+		if !handled[name] {
+			unhandled = append(unhandled, name)
+		}
+	}
+	sort.Strings(unregistered)
+	sort.Strings(unhandled)
+
+	if len(unregistered) == 0 && len(unhandled) == 0 {
+		check.Status = PrereqOK
+		check.Value = fmt.Sprintf("%d command(s) registered and handled", len(names))
+		return check
+	}
+	var parts, fixes []string
+	if len(unregistered) > 0 {
+		parts = append(parts, "not registered: "+strings.Join(unregistered, ", "))
+		fixes = append(fixes, "Register the missing commands with: arc-discord interaction register --file <command.json>")
+	}
+	if len(unhandled) > 0 {
+		parts = append(parts, "no handler: "+strings.Join(unhandled, ", "))
+		fixes = append(fixes, "Add handlers for them under interactions.handlers.commands, or remove them with: arc-discord interaction delete")
+	}
+	check.Status = PrereqInvalid
+	check.Value = strings.Join(parts, "; ")
+	check.HowToFix = strings.Join(fixes, "\n")
+	return check
+}
+
+// checkWebhooks GETs every configured webhook; Discord answers 200 with the
+// webhook object while it exists and 401/404 once it is deleted.
+func (d *doctorChecker) checkWebhooks(ctx context.Context) []PrereqCheck {
+	names := make([]string, 0, len(d.cfg.Discord.Webhooks))
+	for name, url := range d.cfg.Discord.Webhooks {
+		if strings.TrimSpace(url) != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	checks := make([]PrereqCheck, 0, len(names))
+	for _, name := range names {
+		url := d.cfg.Discord.Webhooks[name]
+		check := PrereqCheck{
+			Name:        fmt.Sprintf("Webhook %q", name),
+			Required:    true,
+			Description: "Webhook used by the webhook and message commands",
+			ConfigKey:   "discord.webhooks." + name,
+			Value:       maskWebhookURL(url),
+		}
+		status, err := d.probeWebhook(ctx, url)
+		switch {
+		case err != nil:
+			check.Status = PrereqUnreachable
+			check.Value = fmt.Sprintf("%s (%v)", check.Value, err)
+			check.HowToFix = "Check network access to discord.com"
+		case status == http.StatusOK:
+			check.Status = PrereqOK
+		case status == http.StatusUnauthorized || status == http.StatusNotFound:
+			check.Status = PrereqInvalid
+			check.Value = fmt.Sprintf("%s (HTTP %d)", check.Value, status)
+			check.HowToFix = "The webhook was deleted or its token changed; create a new one under Channel Settings -> Integrations"
+		default:
+			check.Status = PrereqUnreachable
+			check.Value = fmt.Sprintf("%s (HTTP %d)", check.Value, status)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func (d *doctorChecker) probeWebhook(ctx context.Context, url string) (int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// checkTunnelBinaries only fails when a tunnel provider is configured and its
+// binary is missing; with a public URL no tunnel is needed.
+func (d *doctorChecker) checkTunnelBinaries() PrereqCheck {
+	check := PrereqCheck{
+		Name:        "Tunnel Binary",
+		Description: "ngrok or localtunnel (lt) on PATH for --tunnel",
+		ConfigKey:   "tunnel.provider",
+		EnvVar:      envTunnelProvider,
+	}
+	found := map[string]string{}
+	var parts []string
+	for _, bin := range []string{"ngrok", "lt"} {
+		if path, err := d.lookPath(bin); err == nil {
+			found[bin] = path
+			parts = append(parts, fmt.Sprintf("%s (%s)", bin, path))
+		} else {
+			parts = append(parts, bin+" not found")
+		}
+	}
+	check.Value = strings.Join(parts, ", ")
+
+	provider := strings.ToLower(strings.TrimSpace(d.extra.Tunnel.Provider))
+	switch provider {
+	case "ngrok":
+		check.Required = true
+		check.Status = binaryStatus(found["ngrok"] != "")
+		check.HowToFix = "Install ngrok: https://ngrok.com/download"
+	case "localtunnel":
+		check.Required = true
+		check.Status = binaryStatus(found["lt"] != "")
+		check.HowToFix = "Install localtunnel: npm install -g localtunnel"
+	case "auto":
+		check.Required = true
+		check.Status = binaryStatus(len(found) > 0)
+		check.HowToFix = "Install ngrok or localtunnel (npm install -g localtunnel)"
+	default:
+		if d.extra.PublicURL != "" {
+			check.Status = PrereqOK
+			check.Value = "not needed (public URL set)"
+			return check
+		}
+		check.Status = binaryStatus(len(found) > 0)
+		check.HowToFix = "Install ngrok or localtunnel to expose a local server with --tunnel"
+	}
+	if check.Status == PrereqOK {
+		check.HowToFix = ""
+	}
+	return check
+}
+
+func binaryStatus(found bool) PrereqStatus {
+	if found {
+		return PrereqOK
+	}
+	return PrereqMissing
+}
+
+// discordErrorStatus separates rejected credentials from transport failures.
+func discordErrorStatus(err error) PrereqStatus {
+	var apiErr *types.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 {
+		return PrereqInvalid
+	}
+	return PrereqUnreachable
+}
+
+func countFailedChecks(report *PrereqReport) int {
+	failed := 0
+	for _, check := range report.Checks {
+		if check.Required && check.Status != PrereqOK {
+			failed++
+		}
+	}
+	return failed
+}
+
+func newDoctorReport(report *PrereqReport) doctorReport {
+	out := doctorReport{ConfigPath: report.ConfigPath, Passed: report.AllPassed, Checks: make([]doctorCheck, 0, len(report.Checks))}
+	for _, check := range report.Checks {
+		out.Checks = append(out.Checks, doctorCheck{
+			Name:     check.Name,
+			Status:   check.Status.String(),
+			Required: check.Required,
+			Value:    check.Value,
+			HowToFix: check.HowToFix,
+		})
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func newTestDoctor(bot botClient) *doctorChecker {
+	cfg := discordconfig.Default()
+	cfg.Discord.ApplicationID = "app-1"
+	extra := defaultInteractionSettings()
+	extra.Interactions.Handlers.Commands = map[string]handlerRoute{"ping": {Agent: "default"}}
+	return &doctorChecker{
+		cfg:      cfg,
+		extra:    extra,
+		bot:      bot,
+		http:     http.DefaultClient,
+		lookPath: func(string) (string, error) { return "", errors.New("not found") },
+	}
+}
+
+func findCheck(t *testing.T, checks []PrereqCheck, name string) PrereqCheck {
+	t.Helper()
+	for _, check := range checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("check %q not found in %+v", name, checks)
+	return PrereqCheck{}
+}
+
+func TestDoctorPassesMatchingSetup(t *testing.T) {
+	bot := &fakeBotClient{
+		appSvc:     &fakeApplicationService{app: &types.Application{ID: "app-1", Name: "arc", Flags: types.ApplicationFlagGatewayGuildMembers}},
+		commandSvc: &fakeApplicationCommands{global: []*types.ApplicationCommand{{Name: "ping", Type: types.ApplicationCommandTypeChatInput}}},
+	}
+	checks := newTestDoctor(bot).run(context.Background())
+	for _, name := range []string{"Bot Token", "Application Ownership", "Registered Commands"} {
+		if check := findCheck(t, checks, name); check.Status != PrereqOK {
+			t.Fatalf("%s: expected OK, got %+v", name, check)
+		}
+	}
+	if intents := findCheck(t, checks, "Privileged Intents"); intents.Value != "server members" {
+		t.Fatalf("unexpected intents value %q", intents.Value)
+	}
+	if tunnel := findCheck(t, checks, "Tunnel Binary"); tunnel.Required {
+		t.Fatalf("tunnel binary should be optional without a provider: %+v", tunnel)
+	}
+}
+
+func TestDoctorFlagsApplicationMismatchAndCommandDrift(t *testing.T) {
+	bot := &fakeBotClient{
+		appSvc: &fakeApplicationService{app: &types.Application{ID: "app-2", Name: "other"}},
+		commandSvc: &fakeApplicationCommands{global: []*types.ApplicationCommand{
+			{Name: "stale", Type: types.ApplicationCommandTypeChatInput},
+			{Name: "Report Message", Type: types.ApplicationCommandTypeMessage},
+		}},
+	}
+	checks := newTestDoctor(bot).run(context.Background())
+
+	app := findCheck(t, checks, "Application Ownership")
+	if app.Status != PrereqInvalid || !strings.Contains(app.Value, "app-2") {
+		t.Fatalf("expected ownership mismatch, got %+v", app)
+	}
+	commands := findCheck(t, checks, "Registered Commands")
+	if commands.Status != PrereqInvalid {
+		t.Fatalf("expected command drift, got %+v", commands)
+	}
+	if !strings.Contains(commands.Value, "not registered: ping") || !strings.Contains(commands.Value, "no handler: stale") {
+		t.Fatalf("unexpected drift summary %q", commands.Value)
+	}
+}
+
+func TestDoctorSkipsDependentChecksForRejectedToken(t *testing.T) {
+	bot := &fakeBotClient{userSvc: &fakeUserService{err: &types.APIError{StatusCode: http.StatusUnauthorized, Message: "401: Unauthorized"}}}
+	checks := newTestDoctor(bot).run(context.Background())
+
+	if token := findCheck(t, checks, "Bot Token"); token.Status != PrereqInvalid {
+		t.Fatalf("expected invalid token, got %+v", token)
+	}
+	for _, check := range checks {
+		if check.Name == "Application Ownership" || check.Name == "Registered Commands" {
+			t.Fatalf("dependent check %q should be skipped", check.Name)
+		}
+	}
+}
+
+func TestDoctorProbesWebhooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/gone") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	d := newTestDoctor(nil)
+	d.cfg.Discord.Webhooks = map[string]string{"alerts": server.URL + "/ok", "old": server.URL + "/gone"}
+	checks := d.checkWebhooks(context.Background())
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 webhook checks, got %+v", checks)
+	}
+	if checks[0].Name != `Webhook "alerts"` || checks[0].Status != PrereqOK {
+		t.Fatalf("unexpected alerts check %+v", checks[0])
+	}
+	if checks[1].Status != PrereqInvalid {
+		t.Fatalf("expected deleted webhook to be invalid, got %+v", checks[1])
+	}
+}
+
+func TestDoctorTunnelBinaryRequiredForProvider(t *testing.T) {
+	d := newTestDoctor(nil)
+	d.extra.Tunnel.Provider = "ngrok"
+	check := d.checkTunnelBinaries()
+	if !check.Required || check.Status != PrereqMissing {
+		t.Fatalf("expected missing required ngrok, got %+v", check)
+	}
+
+	d.lookPath = func(name string) (string, error) { return "/usr/local/bin/" + name, nil }
+	if check := d.checkTunnelBinaries(); check.Status != PrereqOK {
+		t.Fatalf("expected ngrok to be found, got %+v", check)
+	}
+}
+
+func TestPrivilegedIntentsNamesPortalToggleForMissingIntent(t *testing.T) {
+	app := &types.Application{ID: "app-1", Flags: types.ApplicationFlagGatewayMessageContentLimited}
+	check := checkPrivilegedIntents(app, []string{"guild_members", "message_content"})
+	if !check.Required || check.Status != PrereqInvalid {
+		t.Fatalf("expected required invalid check, got %+v", check)
+	}
+	if check.Value != "enabled: message content; missing: server members" {
+		t.Fatalf("unexpected value %q", check.Value)
+	}
+	if !strings.Contains(check.HowToFix, `Enable "Server Members Intent"`) || !strings.Contains(check.HowToFix, "/applications/app-1/bot") {
+		t.Fatalf("expected portal toggle in fix, got %q", check.HowToFix)
+	}
+
+	if check := checkPrivilegedIntents(app, []string{"typing"}); check.Status != PrereqInvalid || !strings.Contains(check.Value, "typing") {
+		t.Fatalf("expected unknown intent to be rejected, got %+v", check)
+	}
+}
+
+func TestRequiredIntentsFromConfig(t *testing.T) {
+	cfg := discordconfig.Default()
+	cfg.Client.Features = map[string]bool{"message_content_intent": true}
+	extra := defaultInteractionSettings()
+	extra.Intents = []string{"members", "Guild_Members"}
+	got := requiredIntents(cfg, extra)
+	if strings.Join(got, ",") != "guild_members,message_content" {
+		t.Fatalf("unexpected required intents %v", got)
+	}
+}
+
+func TestServerPrereqsVerifyBotTokenAndIntents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	content := `discord:
+  bot_token: "token"
+  application_id: "app-1"
+  intents: ["guild_members"]
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	prev := newBotClientFn
+	t.Cleanup(func() { newBotClientFn = prev })
+	newBotClientFn = func(*discordconfig.Config, string) (botClient, error) {
+		return &fakeBotClient{appSvc: &fakeApplicationService{app: &types.Application{ID: "app-1"}}}, nil
+	}
+
+	opts := &globalOptions{configPath: path}
+	report, err := NewServerPrereqChecker(opts, serverStartOptions{DryRun: true}).Check(context.Background())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if token := findCheck(t, report.Checks, "Bot Token"); token.Status != PrereqOK {
+		t.Fatalf("expected token to pass, got %+v", token)
+	}
+	if intents := findCheck(t, report.Checks, "Privileged Intents"); intents.Status != PrereqInvalid {
+		t.Fatalf("expected missing members intent, got %+v", intents)
+	}
+	if report.AllPassed {
+		t.Fatal("missing required intent should fail the report")
+	}
+}
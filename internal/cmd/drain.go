@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+)
+
+// drainingPublisher counts in-flight Publish calls so shutdown can wait for
+// them before the broker connection is closed.
+type drainingPublisher struct {
+	broker.Publisher
+
+	mu      sync.Mutex
+	pending int
+	idle    chan struct{}
+}
+
+func newDrainingPublisher(publisher broker.Publisher) *drainingPublisher {
+	return &drainingPublisher{Publisher: publisher}
+}
+
+func (p *drainingPublisher) Publish(ctx context.Context, env *broker.Envelope) error {
+	p.mu.Lock()
+	if p.pending == 0 {
+		p.idle = make(chan struct{})
+	}
+	p.pending++
+	p.mu.Unlock()
+	defer p.release()
+	return p.Publisher.Publish(ctx, env)
+}
+
+func (p *drainingPublisher) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending--
+	if p.pending == 0 {
+		close(p.idle)
+	}
+}
+
+// wait blocks until no Publish call is in flight or ctx is done.
+func (p *drainingPublisher) wait(ctx context.Context) error {
+	p.mu.Lock()
+	if p.pending == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	idle := p.idle
+	p.mu.Unlock()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return fmt.Errorf("%d publish(es) still pending: %w", p.pending, ctx.Err())
+	}
+}
+
+// drainServer stops srv accepting requests, then waits for in-flight
+// requests, pending publishes, and tunnel teardown, all within timeout. It
+// reports what did not finish in time rather than failing shutdown.
+func drainServer(srv *http.Server, publisher *drainingPublisher, tunnel *TunnelSession, timeout time.Duration, out outputPrinter) {
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	out.Printf("Draining in-flight interactions (up to %s)\n", timeout)
+	if err := srv.Shutdown(ctx); err != nil {
+		out.Printf("Warning: in-flight requests not finished: %v\n", err)
+	}
+	if publisher != nil {
+		if err := publisher.wait(ctx); err != nil {
+			out.Printf("Warning: %v\n", err)
+		}
+	}
+	if err := tunnel.Close(ctx); err != nil {
+		out.Printf("Warning: tunnel teardown failed: %v\n", err)
+	}
+}
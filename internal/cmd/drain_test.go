@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+)
+
+type gatedPublisher struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *gatedPublisher) Publish(ctx context.Context, _ *broker.Envelope) error {
+	close(p.started)
+	<-p.release
+	return nil
+}
+
+func (p *gatedPublisher) Close() error { return nil }
+
+func TestDrainServerWaitsForInFlightPublish(t *testing.T) {
+	gate := &gatedPublisher{started: make(chan struct{}), release: make(chan struct{})}
+	pending := newDrainingPublisher(gate)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := pending.Publish(r.Context(), &broker.Envelope{Agent: "claude"}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.Serve(ln) }()
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Post("http://"+ln.Addr().String(), "application/json", nil)
+		if err != nil {
+			status <- 0
+			return
+		}
+		resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-gate.started
+
+	drained := make(chan struct{})
+	go func() {
+		drainServer(srv, pending, nil, 5*time.Second, &bufferPrinter{})
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		t.Fatal("drain returned while a publish was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(gate.release)
+	<-drained
+	if code := <-status; code != http.StatusOK {
+		t.Fatalf("in-flight request status = %d, want 200", code)
+	}
+}
+
+func TestDrainingPublisherWaitTimesOut(t *testing.T) {
+	gate := &gatedPublisher{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(gate.release)
+	pending := newDrainingPublisher(gate)
+	go func() { _ = pending.Publish(context.Background(), &broker.Envelope{Agent: "claude"}) }()
+	<-gate.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := pending.wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "1 publish(es) still pending") {
+		t.Fatalf("unexpected wait error: %v", err)
+	}
+	if err := newDrainingPublisher(noopPublisher{}).wait(context.Background()); err != nil {
+		t.Fatalf("idle publisher: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/embeds"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// embedFlags holds the --embed-* flags shared by message send and webhook
+// send, so a simple embed doesn't need a JSON file.
+type embedFlags struct {
+	title       string
+	description string
+	color       string
+	footer      string
+	image       string
+	fields      []string
+}
+
+func (f *embedFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.title, "embed-title", "", "Embed title")
+	cmd.Flags().StringVar(&f.description, "embed-description", "", "Embed description")
+	cmd.Flags().StringVar(&f.color, "embed-color", "", "Embed color as #rrggbb, 0xrrggbb, or decimal")
+	cmd.Flags().StringArrayVar(&f.fields, "embed-field", nil, "Embed field as name=value[:inline] (repeatable)")
+	cmd.Flags().StringVar(&f.footer, "embed-footer", "", "Embed footer text")
+	cmd.Flags().StringVar(&f.image, "embed-image", "", "Embed image URL")
+}
+
+func (f embedFlags) set() bool {
+	return f.title != "" || f.description != "" || f.color != "" || f.footer != "" || f.image != "" || len(f.fields) > 0
+}
+
+// build returns the embed described by the flags, or nil when none are set.
+func (f embedFlags) build() (*types.Embed, error) {
+	if !f.set() {
+		return nil, nil
+	}
+	b := embeds.New().SetTitle(f.title).SetDescription(f.description)
+	if f.color != "" {
+		color, err := parseColor(f.color, "embed")
+		if err != nil {
+			return nil, err
+		}
+		b.SetColor(color)
+	}
+	for _, spec := range f.fields {
+		name, value, inline, err := parseEmbedField(spec)
+		if err != nil {
+			return nil, err
+		}
+		b.AddField(name, value, inline)
+	}
+	if f.footer != "" {
+		b.SetFooter(f.footer, "")
+	}
+	if f.image != "" {
+		b.SetImage(f.image)
+	}
+	embed, err := b.Build()
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "invalid embed flags"}).WithCause(err)
+	}
+	return embed, nil
+}
+
+// parseEmbedField splits name=value[:inline]. Only a trailing ":inline" is
+// treated as the flag, so values may contain colons (URLs, times).
+func parseEmbedField(spec string) (string, string, bool, error) {
+	name, value, ok := strings.Cut(spec, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", false, &arcer.CLIError{
+			Msg:  fmt.Sprintf("invalid --embed-field %q", spec),
+			Hint: "use name=value or name=value:inline",
+		}
+	}
+	inline := false
+	if strings.HasSuffix(value, ":inline") {
+		value = strings.TrimSuffix(value, ":inline")
+		inline = true
+	}
+	if strings.TrimSpace(value) == "" {
+		return "", "", false, &arcer.CLIError{Msg: fmt.Sprintf("--embed-field %q has an empty value", name)}
+	}
+	return name, value, inline, nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestEmbedFlagsBuild(t *testing.T) {
+	flags := embedFlags{
+		title:  "Deploy finished",
+		color:  "#2ecc71",
+		fields: []string{"env=prod:inline", "link=https://ci.example.com/runs/1"},
+		footer: "ci",
+		image:  "https://example.com/chart.png",
+	}
+	embed, err := flags.build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if embed.Title != "Deploy finished" || embed.Color != 0x2ecc71 {
+		t.Fatalf("unexpected embed %+v", embed)
+	}
+	if len(embed.Fields) != 2 || !embed.Fields[0].Inline || embed.Fields[0].Value != "prod" {
+		t.Fatalf("unexpected first field %+v", embed.Fields)
+	}
+	if embed.Fields[1].Inline || embed.Fields[1].Value != "https://ci.example.com/runs/1" {
+		t.Fatalf("colons in values should be preserved: %+v", embed.Fields[1])
+	}
+	if embed.Footer == nil || embed.Footer.Text != "ci" || embed.Image == nil || embed.Image.URL != "https://example.com/chart.png" {
+		t.Fatalf("missing footer or image: %+v", embed)
+	}
+}
+
+func TestEmbedFlagsUnsetBuildsNothing(t *testing.T) {
+	embed, err := embedFlags{}.build()
+	if err != nil || embed != nil {
+		t.Fatalf("expected no embed, got %+v, %v", embed, err)
+	}
+}
+
+func TestEmbedFlagsRejectInvalidField(t *testing.T) {
+	if _, err := (embedFlags{fields: []string{"novalue"}}).build(); err == nil {
+		t.Fatal("expected error for field without '='")
+	}
+	if _, err := (embedFlags{title: "x", color: "green"}).build(); err == nil {
+		t.Fatal("expected error for invalid color")
+	}
+}
+
+func TestMessageParamsFromEmbedFlagsOnly(t *testing.T) {
+	params, err := buildMessageParams(messageSendInput{embed: embedFlags{title: "Standup"}}, nil)
+	if err != nil {
+		t.Fatalf("build params: %v", err)
+	}
+	if params.Content != "" || len(params.Embeds) != 1 || params.Embeds[0].Title != "Standup" {
+		t.Fatalf("unexpected params %+v", params)
+	}
+
+	msg, err := buildWebhookMessage(webhookSendInput{content: "hi", embed: embedFlags{description: "details"}}, nil)
+	if err != nil {
+		t.Fatalf("build webhook message: %v", err)
+	}
+	if len(msg.Embeds) != 1 || msg.Embeds[0].Description != "details" {
+		t.Fatalf("unexpected webhook embeds %+v", msg.Embeds)
+	}
+}
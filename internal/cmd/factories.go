@@ -12,14 +12,22 @@ import (
 type webhookDispatcher interface {
 	Send(ctx context.Context, msg *types.WebhookMessage) error
 	SendWithFiles(ctx context.Context, msg *types.WebhookMessage, files []webhook.FileAttachment) error
+	SendAndWait(ctx context.Context, msg *types.WebhookMessage) (*types.Message, error)
+	SendWithFilesAndWait(ctx context.Context, msg *types.WebhookMessage, files []webhook.FileAttachment) (*types.Message, error)
 	CreateThread(ctx context.Context, threadName string, msg *types.WebhookMessage) error
+	GetInThread(ctx context.Context, messageID, threadID string) (*types.Message, error)
+	Edit(ctx context.Context, messageID string, params *webhook.MessageEditParams) (*types.Message, error)
+	DeleteInThread(ctx context.Context, messageID, threadID string) error
 }
 
 type botClient interface {
 	Messages() messageService
 	Channels() channelService
 	Guilds() guildService
+	Users() userService
 	ApplicationCommands(applicationID string) applicationCommandService
+	Applications() applicationService
+	StageInstances() stageInstanceService
 }
 
 type messageService interface {
@@ -28,12 +36,18 @@ type messageService interface {
 	DeleteMessage(ctx context.Context, channelID, messageID string) error
 	CreateReaction(ctx context.Context, channelID, messageID, emoji string) error
 	DeleteOwnReaction(ctx context.Context, channelID, messageID, emoji string) error
+	GetMessage(ctx context.Context, channelID, messageID string) (*types.Message, error)
+	GetReactions(ctx context.Context, channelID, messageID, emoji string, params *client.GetReactionsParams) ([]*types.User, error)
 }
 
 type channelService interface {
 	GetChannel(ctx context.Context, channelID string) (*types.Channel, error)
 	GetChannelMessages(ctx context.Context, channelID string, params *client.GetChannelMessagesParams) ([]*types.Message, error)
 	ModifyChannel(ctx context.Context, channelID string, params *types.ModifyChannelParams) (*types.Channel, error)
+	DeleteChannel(ctx context.Context, channelID string) error
+	StartThreadInForum(ctx context.Context, channelID string, params *types.ForumThreadParams) (*types.ForumThread, error)
+	FollowAnnouncementChannel(ctx context.Context, channelID, targetChannelID, reason string) (*types.FollowedChannel, error)
+	TriggerTypingIndicator(ctx context.Context, channelID string) error
 }
 
 type guildService interface {
@@ -41,6 +55,30 @@ type guildService interface {
 	ListGuildMembers(ctx context.Context, guildID string, params *types.ListMembersParams) ([]*types.Member, error)
 	GetGuildRoles(ctx context.Context, guildID string) ([]*types.Role, error)
 	GetGuildChannels(ctx context.Context, guildID string) ([]*types.Channel, error)
+	CreateGuildChannel(ctx context.Context, guildID string, params *types.ChannelCreateParams) (*types.Channel, error)
+	ModifyGuild(ctx context.Context, guildID string, params *types.GuildModifyParams) (*types.Guild, error)
+	CreateGuildRole(ctx context.Context, guildID string, params *types.RoleCreateParams) (*types.Role, error)
+	ModifyGuildRole(ctx context.Context, guildID, roleID string, params *types.RoleModifyParams) (*types.Role, error)
+	DeleteGuildRole(ctx context.Context, guildID, roleID string) error
+	GetGuildOnboarding(ctx context.Context, guildID string) (*types.GuildOnboarding, error)
+	ModifyGuildOnboarding(ctx context.Context, guildID string, params *types.GuildOnboardingParams) (*types.GuildOnboarding, error)
+	GetGuildWelcomeScreen(ctx context.Context, guildID string) (*types.WelcomeScreen, error)
+	ModifyGuildWelcomeScreen(ctx context.Context, guildID string, params *types.WelcomeScreenModifyParams) (*types.WelcomeScreen, error)
+	GetGuildTemplates(ctx context.Context, guildID string) ([]*types.GuildTemplate, error)
+	CreateGuildTemplate(ctx context.Context, guildID string, params *types.GuildTemplateParams) (*types.GuildTemplate, error)
+	SyncGuildTemplate(ctx context.Context, guildID, code string) (*types.GuildTemplate, error)
+	CreateGuildFromTemplate(ctx context.Context, code string, params *types.GuildFromTemplateParams) (*types.Guild, error)
+	GetGuildBans(ctx context.Context, guildID string, params *types.ListBansParams) ([]*types.Ban, error)
+	CreateGuildBan(ctx context.Context, guildID, userID string, params *types.CreateBanParams) error
+	BulkGuildBan(ctx context.Context, guildID string, params *types.BulkBanParams) (*types.BulkBanResult, error)
+	GetGuildPruneCount(ctx context.Context, guildID string, params *types.PruneParams) (int, error)
+	BeginGuildPrune(ctx context.Context, guildID string, params *types.PruneParams) (*types.PruneResult, error)
+}
+
+type userService interface {
+	GetCurrentUser(ctx context.Context) (*types.User, error)
+	GetCurrentUserGuilds(ctx context.Context) ([]*types.Guild, error)
+	CreateDM(ctx context.Context, recipientID string) (*types.Channel, error)
 }
 
 type applicationCommandService interface {
@@ -48,8 +86,25 @@ type applicationCommandService interface {
 	GetGuildApplicationCommands(ctx context.Context, guildID string) ([]*types.ApplicationCommand, error)
 	CreateGlobalApplicationCommand(ctx context.Context, cmd *types.ApplicationCommand) (*types.ApplicationCommand, error)
 	CreateGuildApplicationCommand(ctx context.Context, guildID string, cmd *types.ApplicationCommand) (*types.ApplicationCommand, error)
+	EditGlobalApplicationCommand(ctx context.Context, commandID string, cmd *types.ApplicationCommand) (*types.ApplicationCommand, error)
+	EditGuildApplicationCommand(ctx context.Context, guildID, commandID string, cmd *types.ApplicationCommand) (*types.ApplicationCommand, error)
 	DeleteGlobalApplicationCommand(ctx context.Context, commandID string) error
 	DeleteGuildApplicationCommand(ctx context.Context, guildID, commandID string) error
+	BulkOverwriteGlobalApplicationCommands(ctx context.Context, cmds []*types.ApplicationCommand) ([]*types.ApplicationCommand, error)
+	BulkOverwriteGuildApplicationCommands(ctx context.Context, guildID string, cmds []*types.ApplicationCommand) ([]*types.ApplicationCommand, error)
+}
+
+type applicationService interface {
+	GetCurrentApplication(ctx context.Context) (*types.Application, error)
+	EditCurrentApplication(ctx context.Context, params *types.ModifyApplicationParams) (*types.Application, error)
+	ListEntitlements(ctx context.Context, applicationID string, params *types.ListEntitlementsParams) ([]*types.Entitlement, error)
+}
+
+type stageInstanceService interface {
+	CreateStageInstance(ctx context.Context, params *types.StageInstanceCreateParams) (*types.StageInstance, error)
+	GetStageInstance(ctx context.Context, channelID string) (*types.StageInstance, error)
+	ModifyStageInstance(ctx context.Context, channelID string, params *types.StageInstanceModifyParams) (*types.StageInstance, error)
+	DeleteStageInstance(ctx context.Context, channelID, reason string) error
 }
 
 type realBotClient struct {
@@ -68,10 +123,22 @@ func (r *realBotClient) Guilds() guildService {
 	return r.inner.Guilds()
 }
 
+func (r *realBotClient) Users() userService {
+	return r.inner.Users()
+}
+
 func (r *realBotClient) ApplicationCommands(applicationID string) applicationCommandService {
 	return r.inner.ApplicationCommands(applicationID)
 }
 
+func (r *realBotClient) Applications() applicationService {
+	return r.inner.Applications()
+}
+
+func (r *realBotClient) StageInstances() stageInstanceService {
+	return r.inner.StageInstances()
+}
+
 func createWebhookClient(cfg *discordconfig.Config, webhookURL string) (webhookDispatcher, error) {
 	if cfg == nil {
 		cfg = discordconfig.Default()
@@ -80,6 +147,7 @@ func createWebhookClient(cfg *discordconfig.Config, webhookURL string) (webhookD
 		webhook.WithTimeout(cfg.Client.Timeout),
 		webhook.WithMaxRetries(cfg.Client.Retries),
 		webhook.WithStrategyName(cfg.Client.RateLimit.Strategy),
+		webhook.WithRateLimitObserver(rateLimitRecorder),
 	}
 	return webhook.NewClient(webhookURL, opts...)
 }
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/gateway"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// gatewaySession is the part of the shard manager gateway listen uses.
+type gatewaySession interface {
+	On(eventType string, handler gateway.EventHandler)
+	Connect(ctx context.Context) error
+	Disconnect() error
+	ShardCount() int
+}
+
+var newGatewaySessionFn = func(token string, shards int, intents gateway.Intent) gatewaySession {
+	return gateway.NewShardManager(token, shards, int(intents))
+}
+
+// gatewayEvents are the dispatch events the gateway client decodes.
+var gatewayEvents = []string{
+	gateway.EventReady,
+	gateway.EventMessageCreate,
+	gateway.EventMessageUpdate,
+	gateway.EventMessageDelete,
+	gateway.EventGuildCreate,
+	gateway.EventGuildUpdate,
+	gateway.EventGuildDelete,
+	gateway.EventInteractionCreate,
+	gateway.EventGuildMemberAdd,
+	gateway.EventGuildMemberUpdate,
+	gateway.EventGuildMemberRemove,
+}
+
+var gatewayIntentNames = map[string]gateway.Intent{
+	"guilds":                        gateway.IntentGuilds,
+	"guild_members":                 gateway.IntentGuildMembers,
+	"guild_bans":                    gateway.IntentGuildBans,
+	"guild_emojis":                  gateway.IntentGuildEmojis,
+	"guild_integrations":            gateway.IntentGuildIntegrations,
+	"guild_webhooks":                gateway.IntentGuildWebhooks,
+	"guild_invites":                 gateway.IntentGuildInvites,
+	"guild_voice_states":            gateway.IntentGuildVoiceStates,
+	"guild_presences":               gateway.IntentGuildPresences,
+	"guild_messages":                gateway.IntentGuildMessages,
+	"guild_message_reactions":       gateway.IntentGuildMessageReactions,
+	"guild_message_typing":          gateway.IntentGuildMessageTyping,
+	"direct_messages":               gateway.IntentDirectMessages,
+	"direct_message_reactions":      gateway.IntentDirectMessageReactions,
+	"direct_message_typing":         gateway.IntentDirectMessageTyping,
+	"message_content":               gateway.IntentMessageContent,
+	"guild_scheduled_events":        gateway.IntentGuildScheduledEvents,
+	"auto_moderation_configuration": gateway.IntentAutoModerationConfiguration,
+	"auto_moderation_execution":     gateway.IntentAutoModerationExecution,
+	"default":                       gateway.DefaultIntents(),
+	"all":                           gateway.AllIntents(),
+}
+
+func gatewayCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gateway",
+		Short: "Connect to the Discord gateway",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(gatewayListenCmd(opts))
+	cmd.AddCommand(gatewaySubscribeCmd(opts))
+	return cmd
+}
+
+func gatewayListenCmd(opts *globalOptions) *cobra.Command {
+	var (
+		shards  string
+		intents []string
+		events  []string
+		publish bool
+	)
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Short: "Stream gateway events as JSON lines",
+		Long: `Connect to the Discord gateway and print each dispatch event as a JSON line with
+the shard that received it.
+
+--shards auto uses the shard count Discord recommends for the bot (required once it is in
+more than 2500 guilds). Shards start in parallel up to the session's max_concurrency, and
+shards sharing a bucket identify five seconds apart, so large bots take a while to come up.
+
+With --publish, events are published to the broker instead of printed: each event type goes to
+{prefix}:event:{type} (e.g. arc:discord:event:message_create) in the envelope format used for
+interactions, with kind "event" and the event data under "event". The events: section of
+discord.yaml selects what is bridged:
+
+  events:
+    types: [MESSAGE_CREATE, GUILD_MEMBER_ADD]
+    guilds: ["123456789012345678"]   # empty: every guild
+    channels: ["234567890123456789"] # empty: every channel
+
+--events overrides events.types. Member events need --intents guild_members, which must also
+be enabled in the developer portal. Agents read the channels with "gateway subscribe".`,
+		Example: `Example:
+  arc-discord gateway listen --shards auto
+
+Example:
+  arc-discord gateway listen --intents guild_messages,message_content --events MESSAGE_CREATE
+
+Example:
+  arc-discord gateway listen --publish --intents default,guild_members`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, err := parseShardCount(shards)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "use --shards auto or a positive number"}
+			}
+			mask, err := parseGatewayIntents(intents)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "intent names match Discord's, e.g. guild_messages"}
+			}
+			var (
+				cfg      *discordconfig.Config
+				settings *interactionSettings
+			)
+			if publish {
+				cfg, settings, _, err = opts.loadConfigWithInteractions()
+				if err == nil && len(events) == 0 {
+					if events = settings.Events.Types; len(events) == 0 {
+						return &arcer.CLIError{Msg: "no events to publish", Hint: "list them in events.types in discord.yaml or pass --events"}
+					}
+				}
+			} else {
+				cfg, _, err = opts.loadConfig()
+			}
+			if err != nil {
+				return err
+			}
+			selected, err := selectGatewayEvents(events)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "supported events: " + strings.Join(gatewayEvents, ", ")}
+			}
+			if strings.TrimSpace(cfg.Discord.BotToken) == "" {
+				return &arcer.CLIError{Msg: "no bot token configured", Hint: "set discord.bot_token or DISCORD_BOT_TOKEN"}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			session := newGatewaySessionFn(cfg.Discord.BotToken, count, mask)
+			handler := newGatewayEventEncoder(cmd.OutOrStdout()).handle
+			if publish {
+				brokerCfg, err := settings.brokerConfig()
+				if err != nil {
+					return &arcer.CLIError{Msg: err.Error(), Hint: "fix the redis section in discord.yaml"}
+				}
+				b, err := newBrokerFn(ctx, brokerCfg)
+				if err != nil {
+					return (&arcer.CLIError{Msg: "failed to connect to broker"}).WithCause(err)
+				}
+				defer b.Close()
+				bus, ok := b.(broker.EventBus)
+				if !ok {
+					return &arcer.CLIError{Msg: fmt.Sprintf("broker backend %q cannot publish events", brokerCfg.Backend)}
+				}
+				publisher := &gatewayEventPublisher{bus: bus, filter: newGatewayEventFilter(settings.Events)}
+				handler = publisher.handle
+				fmt.Fprintf(cmd.ErrOrStderr(), "Publishing %s to %s\n", strings.Join(selected, ", "), brokerCfg.EventChannel("*"))
+			}
+			for _, event := range selected {
+				session.On(event, handler)
+			}
+
+			if err := session.Connect(ctx); err != nil {
+				return (&arcer.CLIError{Msg: "failed to connect to the gateway"}).WithCause(err)
+			}
+			defer session.Disconnect()
+			fmt.Fprintf(cmd.ErrOrStderr(), "Connected %d shard(s); press Ctrl+C to stop\n", session.ShardCount())
+			<-ctx.Done()
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&shards, "shards", "auto", `Shard count, or "auto" for Discord's recommendation`)
+	cmd.Flags().StringSliceVar(&intents, "intents", []string{"default"}, "Gateway intents to request (names, default, or all)")
+	cmd.Flags().StringSliceVar(&events, "events", nil, "Only print these event types (default all; with --publish, events.types)")
+	cmd.Flags().BoolVar(&publish, "publish", false, "Publish events to the broker instead of printing them")
+	return cmd
+}
+
+func gatewaySubscribeCmd(opts *globalOptions) *cobra.Command {
+	var events []string
+	cmd := &cobra.Command{
+		Use:   "subscribe",
+		Short: "Print gateway events published to the broker as JSON lines",
+		Long: `Subscribe to the event channels "gateway listen --publish" writes to and print each
+envelope as a JSON line. Any number of subscribers receive every event. --events defaults to
+events.types in discord.yaml.`,
+		Example: `Example:
+  arc-discord gateway subscribe --events MESSAGE_CREATE,GUILD_MEMBER_ADD`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, settings, _, err := opts.loadConfigWithInteractions()
+			if err != nil {
+				return err
+			}
+			if len(events) == 0 {
+				if events = settings.Events.Types; len(events) == 0 {
+					return &arcer.CLIError{Msg: "no events to subscribe to", Hint: "pass --events or list them in events.types in discord.yaml"}
+				}
+			}
+			selected, err := selectGatewayEvents(events)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "supported events: " + strings.Join(gatewayEvents, ", ")}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			brokerCfg, err := settings.brokerConfig()
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "fix the redis section in discord.yaml"}
+			}
+			b, err := newBrokerFn(ctx, brokerCfg)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to connect to broker"}).WithCause(err)
+			}
+			defer b.Close()
+			bus, ok := b.(broker.EventBus)
+			if !ok {
+				return &arcer.CLIError{Msg: fmt.Sprintf("broker backend %q cannot carry events", brokerCfg.Backend)}
+			}
+
+			var mu sync.Mutex
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(cmd.ErrOrStderr(), "Subscribed to %s on %s; press Ctrl+C to stop\n", strings.Join(selected, ", "), brokerCfg.EventChannel("*"))
+			err = bus.SubscribeEvents(ctx, selected, func(_ context.Context, msg *broker.Message) error {
+				mu.Lock()
+				defer mu.Unlock()
+				_, err := fmt.Fprintln(out, strings.TrimSpace(string(msg.Payload)))
+				return err
+			})
+			if err != nil && ctx.Err() == nil {
+				return (&arcer.CLIError{Msg: "event subscription failed"}).WithCause(err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&events, "events", nil, "Event types to receive (default: events.types)")
+	return cmd
+}
+
+// parseShardCount accepts "auto" or a positive shard count.
+func parseShardCount(raw string) (int, error) {
+	raw = strings.TrimSpace(strings.ToLower(raw))
+	if raw == "" || raw == "auto" {
+		return gateway.ShardCountAuto, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid shard count %q", raw)
+	}
+	return n, nil
+}
+
+func parseGatewayIntents(names []string) (gateway.Intent, error) {
+	var mask gateway.Intent
+	for _, name := range names {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		intent, ok := gatewayIntentNames[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown intent %q", name)
+		}
+		mask |= intent
+	}
+	if mask == 0 {
+		mask = gateway.DefaultIntents()
+	}
+	return mask, nil
+}
+
+func selectGatewayEvents(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return gatewayEvents, nil
+	}
+	known := map[string]bool{}
+	for _, event := range gatewayEvents {
+		known[event] = true
+	}
+	seen := map[string]bool{}
+	var selected []string
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !known[name] {
+			return nil, fmt.Errorf("unsupported event %q", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			selected = append(selected, name)
+		}
+	}
+	sort.Strings(selected)
+	return selected, nil
+}
+
+type gatewayEventLine struct {
+	Shard int           `json:"shard"`
+	Type  string        `json:"type"`
+	Data  gateway.Event `json:"data"`
+}
+
+// gatewayEventEncoder writes events from every shard to one stream.
+type gatewayEventEncoder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newGatewayEventEncoder(w io.Writer) *gatewayEventEncoder {
+	return &gatewayEventEncoder{enc: json.NewEncoder(w)}
+}
+
+func (e *gatewayEventEncoder) handle(ctx context.Context, event gateway.Event) error {
+	shard, _ := gateway.ShardFromContext(ctx)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.enc.Encode(gatewayEventLine{Shard: shard, Type: event.Type(), Data: event})
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/gateway"
+)
+
+// gatewayEventSource is the Envelope.Source of events bridged from the
+// gateway, next to vibe.discord.server for interactions.
+const gatewayEventSource = "vibe.discord.gateway"
+
+// gatewayEventFilter is the guild and channel allowlist of the events:
+// section. An empty list matches everything.
+type gatewayEventFilter struct {
+	guilds   map[string]bool
+	channels map[string]bool
+}
+
+func newGatewayEventFilter(cfg eventsConfig) gatewayEventFilter {
+	return gatewayEventFilter{guilds: idSet(cfg.Guilds), channels: idSet(cfg.Channels)}
+}
+
+func idSet(ids []string) map[string]bool {
+	if len(ids) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// allows reports whether event passes the allowlist. Events without a guild
+// (DMs, READY) only pass an empty guild list; events without a channel are
+// not restricted by the channel list.
+func (f gatewayEventFilter) allows(event gateway.Event) bool {
+	guildID, channelID := gatewayEventScope(event)
+	if f.guilds != nil && !f.guilds[guildID] {
+		return false
+	}
+	if f.channels != nil && channelID != "" && !f.channels[channelID] {
+		return false
+	}
+	return true
+}
+
+// gatewayEventScope returns the guild and channel an event belongs to.
+func gatewayEventScope(event gateway.Event) (guildID, channelID string) {
+	switch e := event.(type) {
+	case *gateway.MessageCreateEvent:
+		if e.Message != nil {
+			return e.GuildID, e.ChannelID
+		}
+	case *gateway.MessageUpdateEvent:
+		if e.Message != nil {
+			return e.GuildID, e.ChannelID
+		}
+	case *gateway.MessageDeleteEvent:
+		return e.GuildID, e.ChannelID
+	case *gateway.InteractionCreateEvent:
+		if e.Interaction != nil {
+			return e.GuildID, e.ChannelID
+		}
+	case *gateway.GuildCreateEvent:
+		if e.Guild != nil {
+			return e.ID, ""
+		}
+	case *gateway.GuildUpdateEvent:
+		if e.Guild != nil {
+			return e.ID, ""
+		}
+	case *gateway.GuildDeleteEvent:
+		return e.GuildID, ""
+	case *gateway.GuildMemberAddEvent:
+		return e.GuildID, ""
+	case *gateway.GuildMemberUpdateEvent:
+		return e.GuildID, ""
+	case *gateway.GuildMemberRemoveEvent:
+		return e.GuildID, ""
+	}
+	return "", ""
+}
+
+// gatewayEventPublisher publishes allowed gateway events to their event
+// channel on the broker.
+type gatewayEventPublisher struct {
+	bus    broker.EventBus
+	filter gatewayEventFilter
+}
+
+func (p *gatewayEventPublisher) handle(ctx context.Context, event gateway.Event) error {
+	if !p.filter.allows(event) {
+		return nil
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.bus.PublishEvent(ctx, &broker.Envelope{
+		Version:    broker.EnvelopeVersion,
+		Kind:       broker.EventKind,
+		Key:        event.Type(),
+		Event:      data,
+		ReceivedAt: time.Now().UTC(),
+		Source:     gatewayEventSource,
+	})
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/gateway"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+type fakeGatewaySession struct {
+	shards   int
+	handlers map[string]gateway.EventHandler
+}
+
+func (f *fakeGatewaySession) On(eventType string, handler gateway.EventHandler) {
+	f.handlers[eventType] = handler
+}
+
+func (f *fakeGatewaySession) Connect(ctx context.Context) error {
+	if f.shards == gateway.ShardCountAuto {
+		f.shards = 4
+	}
+	handler := f.handlers[gateway.EventMessageCreate]
+	return handler(ctx, &gateway.MessageCreateEvent{Message: &types.Message{ID: "m1", Content: "hi"}})
+}
+
+func (f *fakeGatewaySession) Disconnect() error { return nil }
+
+func (f *fakeGatewaySession) ShardCount() int { return f.shards }
+
+func TestParseShardCount(t *testing.T) {
+	for raw, want := range map[string]int{"auto": gateway.ShardCountAuto, "": gateway.ShardCountAuto, "4": 4} {
+		if got, err := parseShardCount(raw); err != nil || got != want {
+			t.Fatalf("parseShardCount(%q) = %d, %v", raw, got, err)
+		}
+	}
+	for _, raw := range []string{"0", "-1", "many"} {
+		if _, err := parseShardCount(raw); err == nil {
+			t.Fatalf("parseShardCount(%q) should fail", raw)
+		}
+	}
+}
+
+func TestParseGatewayIntents(t *testing.T) {
+	mask, err := parseGatewayIntents([]string{"guild_messages", "MESSAGE_CONTENT"})
+	if err != nil || mask != gateway.IntentGuildMessages|gateway.IntentMessageContent {
+		t.Fatalf("unexpected mask %d, %v", mask, err)
+	}
+	if _, err := parseGatewayIntents([]string{"guild_mesages"}); err == nil {
+		t.Fatalf("expected unknown intent error")
+	}
+}
+
+func TestGatewayListenStreamsEvents(t *testing.T) {
+	t.Setenv("DISCORD_BOT_TOKEN", "token")
+	t.Setenv(envConfigEnvOnly, "1")
+	var session *fakeGatewaySession
+	newGatewaySessionFn = func(token string, shards int, intents gateway.Intent) gatewaySession {
+		session = &fakeGatewaySession{shards: shards, handlers: map[string]gateway.EventHandler{}}
+		return session
+	}
+	t.Cleanup(func() {
+		newGatewaySessionFn = func(token string, shards int, intents gateway.Intent) gatewaySession {
+			return gateway.NewShardManager(token, shards, int(intents))
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	cmd := gatewayListenCmd(&globalOptions{})
+	cmd.SetContext(ctx)
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--shards", "auto", "--events", "message_create"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if len(session.handlers) != 1 || session.shards != 4 {
+		t.Fatalf("unexpected session: %+v", session)
+	}
+	var line struct {
+		Shard int
+		Type  string
+		Data  map[string]interface{}
+	}
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	if line.Type != gateway.EventMessageCreate || line.Data["id"] != "m1" {
+		t.Fatalf("unexpected line %+v", line)
+	}
+}
+
+func TestGatewayEventFilter(t *testing.T) {
+	filter := newGatewayEventFilter(eventsConfig{Guilds: []string{"1"}, Channels: []string{"10"}})
+	cases := []struct {
+		event gateway.Event
+		want  bool
+	}{
+		{&gateway.MessageCreateEvent{Message: &types.Message{GuildID: "1", ChannelID: "10"}}, true},
+		{&gateway.MessageCreateEvent{Message: &types.Message{GuildID: "1", ChannelID: "11"}}, false},
+		{&gateway.MessageDeleteEvent{GuildID: "2", ChannelID: "10"}, false},
+		{&gateway.GuildMemberAddEvent{Member: &types.Member{}, GuildID: "1"}, true},
+		{&gateway.GuildMemberRemoveEvent{GuildID: "2"}, false},
+		{&gateway.MessageCreateEvent{Message: &types.Message{ChannelID: "10"}}, false},
+	}
+	for i, tc := range cases {
+		if got := filter.allows(tc.event); got != tc.want {
+			t.Fatalf("case %d: allows(%s) = %v", i, tc.event.Type(), got)
+		}
+	}
+	if !newGatewayEventFilter(eventsConfig{}).allows(&gateway.ReadyEvent{}) {
+		t.Fatalf("empty filter should allow everything")
+	}
+}
+
+func TestGatewayListenPublishesEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	config := `discord:
+  bot_token: dummy
+events:
+  types: [message_create]
+`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mem := broker.NewMemory()
+	newBrokerFn = func(context.Context, broker.Config) (broker.Broker, error) { return mem, nil }
+	var session *fakeGatewaySession
+	newGatewaySessionFn = func(token string, shards int, intents gateway.Intent) gatewaySession {
+		session = &fakeGatewaySession{shards: shards, handlers: map[string]gateway.EventHandler{}}
+		return session
+	}
+	t.Cleanup(func() {
+		newBrokerFn = func(ctx context.Context, cfg broker.Config) (broker.Broker, error) {
+			return broker.Open(ctx, cfg)
+		}
+		newGatewaySessionFn = func(token string, shards int, intents gateway.Intent) gatewaySession {
+			return gateway.NewShardManager(token, shards, int(intents))
+		}
+	})
+
+	subCtx, stopSub := context.WithCancel(context.Background())
+	defer stopSub()
+	received := make(chan *broker.Message, 1)
+	go mem.SubscribeEvents(subCtx, []string{gateway.EventMessageCreate}, func(_ context.Context, msg *broker.Message) error {
+		received <- msg
+		return nil
+	})
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out, errOut bytes.Buffer
+	cmd := gatewayListenCmd(&globalOptions{configPath: path})
+	cmd.SetContext(ctx)
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"--shards", "1", "--publish"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("listen --publish: %v", err)
+	}
+	if out.Len() != 0 || !strings.Contains(errOut.String(), "arc:discord:event:*") {
+		t.Fatalf("unexpected output %q / %q", out.String(), errOut.String())
+	}
+	select {
+	case msg := <-received:
+		env, err := msg.Envelope()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if env.Kind != broker.EventKind || env.Key != gateway.EventMessageCreate || env.Source != gatewayEventSource || !strings.Contains(string(env.Event), `"m1"`) {
+			t.Fatalf("unexpected envelope %+v", env)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("event was not published")
+	}
+}
+
+func TestGatewayListenPublishRequiresEvents(t *testing.T) {
+	t.Setenv("DISCORD_BOT_TOKEN", "token")
+	t.Setenv(envConfigEnvOnly, "1")
+	cmd := gatewayListenCmd(&globalOptions{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--publish"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "no events to publish") {
+		t.Fatalf("expected missing events error, got %v", err)
+	}
+}
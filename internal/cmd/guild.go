@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -22,9 +24,18 @@ func guildCmd(opts *globalOptions) *cobra.Command {
 	}
 
 	cmd.AddCommand(guildGetCmd(opts))
+	cmd.AddCommand(guildModifyCmd(opts))
 	cmd.AddCommand(guildMembersCmd(opts))
 	cmd.AddCommand(guildRolesCmd(opts))
 	cmd.AddCommand(guildChannelsCmd(opts))
+	cmd.AddCommand(guildOnboardingCmd(opts))
+	cmd.AddCommand(guildWelcomeScreenCmd(opts))
+	cmd.AddCommand(guildTemplateCmd(opts))
+	cmd.AddCommand(guildFromTemplateCmd(opts))
+	cmd.AddCommand(guildBansCmd(opts))
+	cmd.AddCommand(guildPruneCmd(opts))
+	cmd.AddCommand(guildSnapshotCmd(opts))
+	cmd.AddCommand(guildApplyCmd(opts))
 	return cmd
 }
 
@@ -38,6 +49,10 @@ func guildGetCmd(opts *globalOptions) *cobra.Command {
 		Long: `Retrieve detailed information about a Discord guild (server), including owner, name, region, and member counts.
 Use --with-counts to include approximate member and presence statistics.
 
+The summary also covers boost tier/count, verification level, system and rules channels, locale, and the
+guild feature list. Features that gate bot capabilities (for example COMMUNITY, required for stage and
+announcement channels) are reported under capability_features with an enabled flag.
+
 Guild ID can be found by:
   1. Enable Developer Mode in Discord settings
   2. Right-click server icon/name in sidebar
@@ -63,7 +78,7 @@ If --guild is not provided, uses default_guild_id from discord.yaml (if configur
   arc-discord guild get --with-counts --output yaml`,
 	}
 
-	c.Flags().StringVar(&guildID, "guild", "", "Guild ID to fetch (optional if default_guild_id set in config)")
+	c.Flags().StringVar(&guildID, "guild", "", "Guild ID or name to fetch (optional if default_guild_id set in config)")
 	c.Flags().BoolVar(&withCounts, "with-counts", false, "Include approximate member presence counts")
 
 	return c
@@ -90,22 +105,94 @@ func runGuildGet(cmd *cobra.Command, opts *globalOptions, guildID string, withCo
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
 
 	guild, err := bot.Guilds().GetGuild(ctx, guildID, withCounts)
 	if err != nil {
 		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch guild %s", guildID)}).WithCause(err)
 	}
 
+	details := newGuildDetails(guild)
 	table := keyValueTable(map[string]string{
-		"id":              guild.ID,
-		"name":            guild.Name,
-		"owner_id":        guild.OwnerID,
-		"region":          guild.Region,
-		"approx_members":  fmt.Sprintf("%d", guild.ApproximateMemberCount),
-		"approx_presence": fmt.Sprintf("%d", guild.ApproximatePresenceCount),
+		"id":                 guild.ID,
+		"name":               guild.Name,
+		"owner_id":           guild.OwnerID,
+		"region":             guild.Region,
+		"approx_members":     fmt.Sprintf("%d", guild.ApproximateMemberCount),
+		"approx_presence":    fmt.Sprintf("%d", guild.ApproximatePresenceCount),
+		"premium_tier":       fmt.Sprintf("%d", guild.PremiumTier),
+		"boost_count":        fmt.Sprintf("%d", guild.PremiumSubscriptionCount),
+		"verification_level": verificationLevelName(guild.VerificationLevel),
+		"system_channel_id":  guild.SystemChannelID,
+		"rules_channel_id":   guild.RulesChannelID,
+		"locale":             guild.PreferredLocale,
+		"features":           strings.Join(guild.Features, ","),
+		"bot_features":       formatCapabilityFeatures(details.CapabilityFeatures),
 	})
 
-	return renderOutput(cmd, output, guild, table)
+	return renderOutput(cmd, output, details, table)
+}
+
+// guildDetails decorates a guild with the features that affect bot capabilities.
+type guildDetails struct {
+	*types.Guild       `yaml:",inline"`
+	CapabilityFeatures []guildCapabilityFeature `json:"capability_features" yaml:"capability_features"`
+}
+
+type guildCapabilityFeature struct {
+	Feature string `json:"feature" yaml:"feature"`
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Affects string `json:"affects" yaml:"affects"`
+}
+
+func newGuildDetails(guild *types.Guild) guildDetails {
+	keys := make([]string, 0, len(types.BotCapabilityFeatures))
+	for feature := range types.BotCapabilityFeatures {
+		keys = append(keys, feature)
+	}
+	sort.Strings(keys)
+
+	details := guildDetails{Guild: guild, CapabilityFeatures: make([]guildCapabilityFeature, 0, len(keys))}
+	for _, feature := range keys {
+		details.CapabilityFeatures = append(details.CapabilityFeatures, guildCapabilityFeature{
+			Feature: feature,
+			Enabled: guild.HasFeature(feature),
+			Affects: types.BotCapabilityFeatures[feature],
+		})
+	}
+	return details
+}
+
+func formatCapabilityFeatures(features []guildCapabilityFeature) string {
+	parts := make([]string, 0, len(features))
+	for _, f := range features {
+		mark := "-"
+		if f.Enabled {
+			mark = "+"
+		}
+		parts = append(parts, mark+f.Feature)
+	}
+	return strings.Join(parts, " ")
+}
+
+func verificationLevelName(level int) string {
+	switch level {
+	case 0:
+		return "none"
+	case 1:
+		return "low"
+	case 2:
+		return "medium"
+	case 3:
+		return "high"
+	case 4:
+		return "very_high"
+	default:
+		return fmt.Sprintf("unknown(%d)", level)
+	}
 }
 
 func guildMembersCmd(opts *globalOptions) *cobra.Command {
@@ -150,7 +237,7 @@ If --guild is not provided, uses default_guild_id from discord.yaml (if configur
   # View in table format
   arc-discord guild members --output table`,
 	}
-	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
 	cmd.Flags().IntVar(&limit, "limit", 50, "Number of members to return (1-1000)")
 	cmd.Flags().StringVar(&after, "after", "", "Only return members after this user ID")
 	return cmd
@@ -182,6 +269,10 @@ func runGuildMembers(cmd *cobra.Command, opts *globalOptions, guildID string, li
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
 
 	members, err := bot.Guilds().ListGuildMembers(ctx, guildID, params)
 	if err != nil {
@@ -238,7 +329,8 @@ If --guild is not provided, uses default_guild_id from discord.yaml (if configur
   # Find a specific role using grep
   arc-discord guild roles | grep -i moderator`,
 	}
-	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.AddCommand(guildRoleEditCmd(opts))
 	return cmd
 }
 
@@ -262,6 +354,10 @@ func runGuildRoles(cmd *cobra.Command, opts *globalOptions, guildID string, outp
 	}
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
 
 	roles, err := bot.Guilds().GetGuildRoles(ctx, guildID)
 	if err != nil {
@@ -320,7 +416,7 @@ If --guild is not provided, uses default_guild_id from discord.yaml (if configur
   # Use with jq to filter text channels only
   arc-discord guild channels | jq '.[] | select(.type == "guild_text")'`,
 	}
-	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
 	return cmd
 }
 
@@ -344,6 +440,10 @@ func runGuildChannels(cmd *cobra.Command, opts *globalOptions, guildID string, o
 	}
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
 
 	channels, err := bot.Guilds().GetGuildChannels(ctx, guildID)
 	if err != nil {
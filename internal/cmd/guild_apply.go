@@ -0,0 +1,687 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/permissions"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"gopkg.in/yaml.v3"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// guildPlanStep is one change "guild apply" makes to converge a guild on a
+// snapshot.
+type guildPlanStep struct {
+	Action  string   `json:"action"`
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	ID      string   `json:"id,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+
+	run func(ctx context.Context, bot botClient, st *guildApplyState) error
+}
+
+// guildApplyState maps the names used in a snapshot to live IDs. While
+// planning, entries still to be created hold a pending placeholder; the
+// create steps replace it with the real ID before later steps read it.
+type guildApplyState struct {
+	guildID    string
+	reason     string
+	roles      map[string]string
+	categories map[string]string
+	channels   map[string]string
+	liveRoles  map[string]bool
+}
+
+func pendingID(name string) string { return "pending:" + name }
+
+func guildApplyCmd(opts *globalOptions) *cobra.Command {
+	var guildRef, reason string
+	var dryRun, yes bool
+	cmd := &cobra.Command{
+		Use:   "apply <snapshot.yaml>",
+		Short: "Converge a guild on a snapshot file",
+		Args:  cobra.ExactArgs(1),
+		Long: `Compare a "guild snapshot" file with the guild's live roles, channels, and settings, print the
+plan, and create, update, and delete roles and channels until the guild matches the file.
+
+Entries are matched by ID, then by name (channels by type, category, and name), so a snapshot can
+be applied to the guild it came from or used to stamp out another. Roles and channels missing
+from the file are deleted; managed roles, @everyone, and threads are never touched. Role and
+channel order and emojis are not applied.
+
+The plan is confirmed before anything changes; pass --yes to skip the prompt, or --dry-run to
+only print the plan. Requires MANAGE_ROLES, MANAGE_CHANNELS, and MANAGE_GUILD.`,
+		Example: `Example:
+  arc-discord guild apply guild.yaml --guild "Arc Labs" --dry-run
+
+Example:
+  arc-discord guild snapshot --guild "Template Server" --out base.yaml
+  arc-discord guild apply base.yaml --guild "New Project" --yes --reason "Standard layout"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			snap, err := loadGuildSnapshot(args[0])
+			if err != nil {
+				return err
+			}
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			guild, err := bot.Guilds().GetGuild(ctx, guildID, false)
+			if err != nil {
+				cancel()
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch guild %s", guildID)}).WithCause(err)
+			}
+			roles, err := bot.Guilds().GetGuildRoles(ctx, guildID)
+			if err != nil {
+				cancel()
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list roles for guild %s", guildID)}).WithCause(err)
+			}
+			channels, err := bot.Guilds().GetGuildChannels(ctx, guildID)
+			cancel()
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list channels for guild %s", guildID)}).WithCause(err)
+			}
+
+			plan, st, err := planGuildApply(snap, guild, roles, channels)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "fix the snapshot file; see guild snapshot for the format"}
+			}
+			st.reason = reason
+			if len(plan) == 0 {
+				cmd.Printf("Guild %s matches %s\n", guild.Name, args[0])
+				return nil
+			}
+			table := &tableData{headers: []string{"ACTION", "KIND", "NAME", "CHANGES"}}
+			for _, step := range plan {
+				table.rows = append(table.rows, []string{step.Action, step.Kind, step.Name, strings.Join(step.Changes, "; ")})
+			}
+			if err := renderOutput(cmd, opts.output, plan, table); err != nil {
+				return err
+			}
+			if dryRun {
+				return nil
+			}
+			if !yes {
+				ok, err := confirmAction(cmd, fmt.Sprintf("Apply %s to guild %s?", planSummary(plan), guild.Name))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return &arcer.CLIError{Msg: "apply cancelled"}
+				}
+			}
+			for i, step := range plan {
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				err := step.run(ctx, bot, st)
+				cancel()
+				if err != nil {
+					return (&arcer.CLIError{
+						Msg:  fmt.Sprintf("failed to %s %s %s (%d of %d changes applied)", step.Action, step.Kind, step.Name, i, len(plan)),
+						Hint: "re-run guild apply to continue from the current state",
+					}).WithCause(err)
+				}
+			}
+			cmd.Printf("Applied %s to guild %s\n", planSummary(plan), guild.Name)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the plan without changing anything")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Apply without asking for confirmation")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+func loadGuildSnapshot(path string) (*guildSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+	}
+	var snap guildSnapshot
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("invalid snapshot %s", path)}).WithCause(err)
+	}
+	if snap.Version != guildSnapshotVersion {
+		return nil, &arcer.CLIError{Msg: fmt.Sprintf("snapshot %s has version %d; this build reads version %d", path, snap.Version, guildSnapshotVersion)}
+	}
+	return &snap, nil
+}
+
+func planSummary(plan []*guildPlanStep) string {
+	counts := map[string]int{}
+	for _, step := range plan {
+		counts[step.Action]++
+	}
+	return fmt.Sprintf("%d create(s), %d update(s), %d delete(s)", counts["create"], counts["update"], counts["delete"])
+}
+
+// planGuildApply diffs the snapshot against the live guild. Steps are
+// ordered so every reference exists when it is used: roles, categories,
+// channels, guild settings, then deletions.
+func planGuildApply(snap *guildSnapshot, guild *types.Guild, roles []*types.Role, channels []*types.Channel) ([]*guildPlanStep, *guildApplyState, error) {
+	st := &guildApplyState{
+		guildID:    guild.ID,
+		roles:      map[string]string{},
+		categories: map[string]string{},
+		channels:   map[string]string{},
+		liveRoles:  map[string]bool{},
+	}
+	var plan []*guildPlanStep
+
+	roleSteps, roleDeletes, err := planRoles(st, snap.Roles, roles)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan = append(plan, roleSteps...)
+
+	channelSteps, channelDeletes, err := planChannels(st, snap.Channels, channels)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan = append(plan, channelSteps...)
+
+	settings, err := planGuildSettings(st, snap.Guild, guild)
+	if err != nil {
+		return nil, nil, err
+	}
+	if settings != nil {
+		plan = append(plan, settings)
+	}
+	plan = append(plan, channelDeletes...)
+	plan = append(plan, roleDeletes...)
+	return plan, st, nil
+}
+
+func planRoles(st *guildApplyState, want []roleSnapshot, live []*types.Role) ([]*guildPlanStep, []*guildPlanStep, error) {
+	byID := map[string]*types.Role{}
+	byName := map[string]*types.Role{}
+	for _, r := range live {
+		st.liveRoles[r.ID] = true
+		byID[r.ID] = r
+		if _, dup := byName[strings.ToLower(r.Name)]; !dup {
+			byName[strings.ToLower(r.Name)] = r
+		}
+	}
+
+	var steps []*guildPlanStep
+	matched := map[string]bool{}
+	for _, w := range want {
+		w := w
+		key := strings.ToLower(w.Name)
+		if key == "" {
+			return nil, nil, fmt.Errorf("role without a name")
+		}
+		if _, dup := st.roles[key]; dup {
+			return nil, nil, fmt.Errorf("role %q is listed twice", w.Name)
+		}
+		mask, err := parsePermissionList(w.Permissions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("role %s: %w", w.Name, err)
+		}
+		color, err := parseRoleColor(w.Color)
+		if err != nil {
+			return nil, nil, fmt.Errorf("role %s: %w", w.Name, err)
+		}
+
+		have := byID[w.ID]
+		if have == nil {
+			have = byName[key]
+		}
+		if have != nil && matched[have.ID] {
+			have = nil
+		}
+		if have == nil {
+			if w.Managed || key == "@everyone" {
+				// Integrations create their own roles.
+				continue
+			}
+			st.roles[key] = pendingID(w.Name)
+			steps = append(steps, &guildPlanStep{Action: "create", Kind: "role", Name: w.Name, run: func(ctx context.Context, bot botClient, st *guildApplyState) error {
+				role, err := bot.Guilds().CreateGuildRole(ctx, st.guildID, &types.RoleCreateParams{
+					Name: w.Name, Permissions: strconv.FormatInt(int64(mask), 10), Color: color,
+					Hoist: w.Hoist, Mentionable: w.Mentionable, AuditLogReason: st.reason,
+				})
+				if err != nil {
+					return err
+				}
+				st.roles[key] = role.ID
+				return nil
+			}})
+			continue
+		}
+		matched[have.ID] = true
+		st.roles[key] = have.ID
+		if have.Managed {
+			continue
+		}
+
+		var changes []string
+		patch := map[string]any{}
+		if have.Name != w.Name && have.ID != st.guildID {
+			changes = append(changes, fmt.Sprintf("name %s → %s", have.Name, w.Name))
+			patch["name"] = w.Name
+		}
+		if have.Color != color {
+			changes = append(changes, fmt.Sprintf("color #%06x → #%06x", have.Color, color))
+			patch["color"] = color
+		}
+		if have.Hoist != w.Hoist {
+			changes = append(changes, fmt.Sprintf("hoist %t → %t", have.Hoist, w.Hoist))
+			patch["hoist"] = w.Hoist
+		}
+		if have.Mentionable != w.Mentionable {
+			changes = append(changes, fmt.Sprintf("mentionable %t → %t", have.Mentionable, w.Mentionable))
+			patch["mentionable"] = w.Mentionable
+		}
+		if current := permissions.PermissionFromString(have.Permissions); current != mask {
+			changes = append(changes, "permissions "+permissionChange(current, mask))
+			patch["permissions"] = strconv.FormatInt(int64(mask), 10)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		id := have.ID
+		steps = append(steps, &guildPlanStep{Action: "update", Kind: "role", Name: w.Name, ID: id, Changes: changes, run: func(ctx context.Context, bot botClient, st *guildApplyState) error {
+			body, err := json.Marshal(patch)
+			if err != nil {
+				return err
+			}
+			_, err = bot.Guilds().ModifyGuildRole(ctx, st.guildID, id, &types.RoleModifyParams{MergePatch: body, AuditLogReason: st.reason})
+			return err
+		}})
+	}
+
+	var deletes []*guildPlanStep
+	for _, r := range live {
+		if matched[r.ID] || r.Managed || r.ID == st.guildID {
+			continue
+		}
+		id := r.ID
+		deletes = append(deletes, &guildPlanStep{Action: "delete", Kind: "role", Name: r.Name, ID: id, run: func(ctx context.Context, bot botClient, st *guildApplyState) error {
+			return bot.Guilds().DeleteGuildRole(ctx, st.guildID, id)
+		}})
+	}
+	return steps, deletes, nil
+}
+
+func planChannels(st *guildApplyState, want []channelSnapshot, live []*types.Channel) ([]*guildPlanStep, []*guildPlanStep, error) {
+	liveNames := map[string]string{}
+	for _, c := range live {
+		liveNames[c.ID] = c.Name
+	}
+	channelKey := func(t types.ChannelType, category, name string) string {
+		return fmt.Sprintf("%d/%s/%s", t, strings.ToLower(category), strings.ToLower(name))
+	}
+	byID := map[string]*types.Channel{}
+	byKey := map[string]*types.Channel{}
+	for _, c := range orderSnapshotChannels(live) {
+		byID[c.ID] = c
+		key := channelKey(c.Type, liveNames[c.ParentID], c.Name)
+		if _, dup := byKey[key]; !dup {
+			byKey[key] = c
+		}
+	}
+
+	// Categories first so channels can refer to them by name.
+	ordered := make([]channelSnapshot, 0, len(want))
+	for _, w := range want {
+		if w.Type == "category" {
+			ordered = append(ordered, w)
+		}
+	}
+	for _, w := range want {
+		if w.Type != "category" {
+			ordered = append(ordered, w)
+		}
+	}
+
+	var steps []*guildPlanStep
+	seen := map[string]bool{}
+	matched := map[string]bool{}
+	for _, w := range ordered {
+		w := w
+		ctype, ok := snapshotChannelTypes[w.Type]
+		if !ok {
+			return nil, nil, fmt.Errorf("channel %s: unknown type %q", w.Name, w.Type)
+		}
+		if strings.TrimSpace(w.Name) == "" {
+			return nil, nil, fmt.Errorf("channel without a name")
+		}
+		if ctype == types.ChannelTypeGuildCategory && w.Category != "" {
+			return nil, nil, fmt.Errorf("category %s cannot itself have a category", w.Name)
+		}
+		if w.Category != "" {
+			if _, ok := st.categories[strings.ToLower(w.Category)]; !ok {
+				return nil, nil, fmt.Errorf("channel %s: category %q is not in the snapshot", w.Name, w.Category)
+			}
+		}
+		key := channelKey(ctype, w.Category, w.Name)
+		if seen[key] {
+			return nil, nil, fmt.Errorf("channel %q is listed twice", w.Name)
+		}
+		seen[key] = true
+		overwrites, err := st.overwrites(w.Permissions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("channel %s: %w", w.Name, err)
+		}
+
+		have := byID[w.ID]
+		if have == nil || have.Type != ctype {
+			have = byKey[key]
+		}
+		if have != nil && matched[have.ID] {
+			have = nil
+		}
+		if have == nil {
+			st.remember(ctype, w.Name, pendingID(w.Name))
+			steps = append(steps, &guildPlanStep{Action: "create", Kind: "channel", Name: w.Name, run: func(ctx context.Context, bot botClient, st *guildApplyState) error {
+				overwrites, err := st.overwrites(w.Permissions)
+				if err != nil {
+					return err
+				}
+				created, err := bot.Guilds().CreateGuildChannel(ctx, st.guildID, &types.ChannelCreateParams{
+					Name: w.Name, Type: ctype, Topic: w.Topic, NSFW: w.NSFW, RateLimitPerUser: w.Slowmode,
+					Bitrate: w.Bitrate, UserLimit: w.UserLimit, ParentID: st.categoryID(w.Category),
+					PermissionOverwrites: overwrites, AuditLogReason: st.reason,
+				})
+				if err != nil {
+					return err
+				}
+				st.remember(ctype, w.Name, created.ID)
+				return nil
+			}})
+			continue
+		}
+		matched[have.ID] = true
+		st.remember(ctype, w.Name, have.ID)
+
+		var changes, clear []string
+		params := &types.ModifyChannelParams{}
+		if have.Name != w.Name {
+			changes = append(changes, fmt.Sprintf("name %s → %s", have.Name, w.Name))
+			params.Name = w.Name
+		}
+		if have.Topic != w.Topic {
+			changes = append(changes, "topic")
+			params.Topic = w.Topic
+			if w.Topic == "" {
+				clear = append(clear, "topic")
+			}
+		}
+		if have.NSFW != w.NSFW {
+			changes = append(changes, fmt.Sprintf("nsfw %t → %t", have.NSFW, w.NSFW))
+			params.NSFW = w.NSFW
+			if !w.NSFW {
+				clear = append(clear, "nsfw")
+			}
+		}
+		if have.RateLimitPerUser != w.Slowmode {
+			changes = append(changes, fmt.Sprintf("slowmode %ds → %ds", have.RateLimitPerUser, w.Slowmode))
+			params.RateLimitPerUser = w.Slowmode
+			if w.Slowmode == 0 {
+				clear = append(clear, "rate_limit_per_user")
+			}
+		}
+		if w.Bitrate != 0 && have.Bitrate != w.Bitrate {
+			changes = append(changes, fmt.Sprintf("bitrate %d → %d", have.Bitrate, w.Bitrate))
+			params.Bitrate = w.Bitrate
+		}
+		if have.UserLimit != w.UserLimit && ctype != types.ChannelTypeGuildText {
+			changes = append(changes, fmt.Sprintf("user_limit %d → %d", have.UserLimit, w.UserLimit))
+			limit := w.UserLimit
+			params.UserLimit = &limit
+		}
+		parentChanged := have.ParentID != st.categoryID(w.Category)
+		if parentChanged {
+			changes = append(changes, fmt.Sprintf("category %q → %q", liveNames[have.ParentID], w.Category))
+			if w.Category == "" {
+				clear = append(clear, "parent_id")
+			}
+		}
+		overwritesChanged := !sameOverwrites(have.PermissionOverwrites, overwrites)
+		if overwritesChanged {
+			changes = append(changes, "permissions")
+			if len(overwrites) == 0 {
+				clear = append(clear, "permission_overwrites")
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		params.ClearFields = clear
+		id := have.ID
+		steps = append(steps, &guildPlanStep{Action: "update", Kind: "channel", Name: w.Name, ID: id, Changes: changes, run: func(ctx context.Context, bot botClient, st *guildApplyState) error {
+			params.AuditLogReason = st.reason
+			if parentChanged && w.Category != "" {
+				params.ParentID = st.categoryID(w.Category)
+			}
+			if overwritesChanged {
+				overwrites, err := st.overwrites(w.Permissions)
+				if err != nil {
+					return err
+				}
+				params.PermissionOverwrites = overwrites
+			}
+			_, err := bot.Channels().ModifyChannel(ctx, id, params)
+			return err
+		}})
+	}
+
+	// Delete channels before the categories that hold them.
+	var deletes, categoryDeletes []*guildPlanStep
+	for _, c := range orderSnapshotChannels(live) {
+		if matched[c.ID] {
+			continue
+		}
+		id := c.ID
+		step := &guildPlanStep{Action: "delete", Kind: "channel", Name: c.Name, ID: id, run: func(ctx context.Context, bot botClient, st *guildApplyState) error {
+			return bot.Channels().DeleteChannel(ctx, id)
+		}}
+		if c.Type == types.ChannelTypeGuildCategory {
+			categoryDeletes = append(categoryDeletes, step)
+		} else {
+			deletes = append(deletes, step)
+		}
+	}
+	return steps, append(deletes, categoryDeletes...), nil
+}
+
+// planGuildSettings updates the guild's settings. Channel references and
+// text fields left empty in the snapshot are not changed.
+func planGuildSettings(st *guildApplyState, want guildSnapshotSettings, have *types.Guild) (*guildPlanStep, error) {
+	var changes []string
+	ints := map[string]int{}
+	strs := map[string]string{}
+	channelRefs := map[string]string{}
+	setInt := func(field string, from, to int) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s %d → %d", field, from, to))
+			ints[field] = to
+		}
+	}
+	setString := func(field, from, to string) {
+		if to != "" && from != to {
+			changes = append(changes, fmt.Sprintf("%s %q → %q", field, from, to))
+			strs[field] = to
+		}
+	}
+	setChannel := func(field, fromID, to string) error {
+		if to == "" {
+			return nil
+		}
+		id, ok := st.channels[strings.ToLower(to)]
+		if !ok {
+			return fmt.Errorf("guild %s: channel %q is not in the snapshot", field, to)
+		}
+		if id != fromID {
+			changes = append(changes, fmt.Sprintf("%s → %s", field, to))
+			channelRefs[field] = to
+		}
+		return nil
+	}
+
+	setString("name", have.Name, want.Name)
+	setString("description", have.Description, want.Description)
+	setString("preferred_locale", have.PreferredLocale, want.PreferredLocale)
+	setInt("verification_level", have.VerificationLevel, want.VerificationLevel)
+	setInt("default_message_notifications", have.DefaultMessageNotifications, want.DefaultMessageNotifications)
+	setInt("explicit_content_filter", have.ExplicitContentFilter, want.ExplicitContentFilter)
+	if want.AFKTimeout != 0 {
+		setInt("afk_timeout", have.AFKTimeout, want.AFKTimeout)
+	}
+	for _, ref := range []struct{ field, fromID, to string }{
+		{"afk_channel_id", have.AFKChannelID, want.AFKChannel},
+		{"system_channel_id", have.SystemChannelID, want.SystemChannel},
+		{"rules_channel_id", have.RulesChannelID, want.RulesChannel},
+		{"public_updates_channel_id", have.PublicUpdatesChannelID, want.PublicUpdatesChannel},
+	} {
+		if err := setChannel(ref.field, ref.fromID, ref.to); err != nil {
+			return nil, err
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return &guildPlanStep{Action: "update", Kind: "guild", Name: have.Name, ID: have.ID, Changes: changes, run: func(ctx context.Context, bot botClient, st *guildApplyState) error {
+		patch := map[string]any{}
+		for field, v := range ints {
+			patch[field] = v
+		}
+		for field, v := range strs {
+			patch[field] = v
+		}
+		for field, name := range channelRefs {
+			patch[field] = st.channels[strings.ToLower(name)]
+		}
+		body, err := json.Marshal(patch)
+		if err != nil {
+			return err
+		}
+		_, err = bot.Guilds().ModifyGuild(ctx, st.guildID, &types.GuildModifyParams{MergePatch: body, AuditLogReason: st.reason})
+		return err
+	}}, nil
+}
+
+func (st *guildApplyState) remember(t types.ChannelType, name, id string) {
+	if t == types.ChannelTypeGuildCategory {
+		st.categories[strings.ToLower(name)] = id
+		return
+	}
+	if _, ok := st.channels[strings.ToLower(name)]; !ok {
+		st.channels[strings.ToLower(name)] = id
+	}
+}
+
+func (st *guildApplyState) categoryID(name string) string {
+	if name == "" {
+		return ""
+	}
+	return st.categories[strings.ToLower(name)]
+}
+
+// overwrites resolves a snapshot's overwrites to API form. Roles are named,
+// or given by ID when the snapshot could not name them.
+func (st *guildApplyState) overwrites(in []overwriteSnapshot) ([]types.PermissionOverwrite, error) {
+	out := make([]types.PermissionOverwrite, 0, len(in))
+	for _, o := range in {
+		allow, err := parsePermissionList(o.Allow)
+		if err != nil {
+			return nil, err
+		}
+		deny, err := parsePermissionList(o.Deny)
+		if err != nil {
+			return nil, err
+		}
+		ow := types.PermissionOverwrite{Allow: strconv.FormatInt(int64(allow), 10), Deny: strconv.FormatInt(int64(deny), 10)}
+		switch {
+		case o.Member != "" && o.Role == "":
+			ow.ID, ow.Type = o.Member, types.PermissionOverwriteMember
+		case o.Role != "" && o.Member == "":
+			id, ok := st.roles[strings.ToLower(o.Role)]
+			if !ok && st.liveRoles[o.Role] {
+				id, ok = o.Role, true
+			}
+			if !ok {
+				return nil, fmt.Errorf("role %q is not in the snapshot", o.Role)
+			}
+			ow.ID, ow.Type = id, types.PermissionOverwriteRole
+		default:
+			return nil, fmt.Errorf("each permission overwrite needs exactly one of role or member")
+		}
+		out = append(out, ow)
+	}
+	return out, nil
+}
+
+func sameOverwrites(have, want []types.PermissionOverwrite) bool {
+	if len(have) != len(want) {
+		return false
+	}
+	index := make(map[string]types.PermissionOverwrite, len(have))
+	for _, o := range have {
+		index[o.ID] = o
+	}
+	for _, w := range want {
+		h, ok := index[w.ID]
+		if !ok || h.Type != w.Type ||
+			permissions.PermissionFromString(h.Allow) != permissions.PermissionFromString(w.Allow) ||
+			permissions.PermissionFromString(h.Deny) != permissions.PermissionFromString(w.Deny) {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePermissionList is the inverse of permissionList: permission names,
+// or decimal bitfields for bits without one.
+func parsePermissionList(names []string) (permissions.Permission, error) {
+	var mask permissions.Permission
+	for _, name := range names {
+		if n, err := strconv.ParseInt(strings.TrimSpace(name), 10, 64); err == nil {
+			mask = mask.Add(permissions.Permission(n))
+			continue
+		}
+		perm, err := permissions.ParsePermission(name)
+		if err != nil {
+			return 0, err
+		}
+		mask = mask.Add(perm)
+	}
+	return mask, nil
+}
+
+func parseRoleColor(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || n < 0 || n > 0xffffff {
+		return 0, fmt.Errorf("color %q must be #rrggbb", s)
+	}
+	return int(n), nil
+}
+
+// permissionChange describes the difference between two masks, e.g.
+// "+ManageMessages -KickMembers".
+func permissionChange(from, to permissions.Permission) string {
+	var parts []string
+	for _, name := range permissionList(strconv.FormatInt(int64(to.Remove(from)), 10)) {
+		parts = append(parts, "+"+name)
+	}
+	for _, name := range permissionList(strconv.FormatInt(int64(from.Remove(to)), 10)) {
+		parts = append(parts, "-"+name)
+	}
+	return strings.Join(parts, " ")
+}
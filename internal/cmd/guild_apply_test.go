@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+const applySnapshot = `version: 1
+guild:
+  name: Arc Labs
+  verification_level: 1
+roles:
+  - name: Moderators
+    color: "#e74c3c"
+    hoist: true
+    permissions: [KickMembers, ManageMessages]
+  - name: Ops
+    permissions: [ViewChannel]
+  - name: "@everyone"
+    permissions: [ViewChannel]
+channels:
+  - name: Engineering
+    type: category
+  - name: dev
+    type: text
+    category: Engineering
+    topic: builds and deploys
+  - name: ops
+    type: text
+    category: Engineering
+    permissions:
+      - role: Ops
+        allow: [ViewChannel]
+      - role: "@everyone"
+        deny: [ViewChannel]
+`
+
+func newApplyFixture(guildID string) (*fakeGuildService, *fakeChannelService) {
+	guilds := &fakeGuildService{
+		guild: &types.Guild{ID: guildID, Name: "Arc Labs", VerificationLevel: 1},
+		roles: []*types.Role{
+			{ID: guildID, Name: "@everyone", Permissions: "1024"},
+			{ID: "r-mod", Name: "Moderators", Color: 0x2ecc71, Hoist: true, Permissions: "8194"},
+			{ID: "r-old", Name: "Old", Permissions: "0"},
+			{ID: "r-bot", Name: "Arc", Managed: true, Permissions: "0"},
+		},
+		channels: map[string][]*types.Channel{guildID: {
+			{ID: "cat-eng", Name: "Engineering", Type: types.ChannelTypeGuildCategory},
+			{ID: "c-dev", Name: "dev", Type: types.ChannelTypeGuildText, ParentID: "cat-eng", Topic: "builds"},
+			{ID: "c-stale", Name: "stale", Type: types.ChannelTypeGuildText},
+			{ID: "c-thread", Name: "a thread", Type: types.ChannelTypeGuildPublicThread},
+		}},
+	}
+	return guilds, &fakeChannelService{}
+}
+
+func writeApplySnapshot(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "guild.yaml")
+	if err := os.WriteFile(path, []byte(applySnapshot), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGuildApplyDryRunOnlyPlans(t *testing.T) {
+	const guildID = "123456789012345678"
+	guilds, channels := newApplyFixture(guildID)
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds, channelSvc: channels})
+
+	var out bytes.Buffer
+	cmd := guildApplyCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{writeApplySnapshot(t), "--guild", guildID, "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("guild apply: %v", err)
+	}
+
+	var plan []guildPlanStep
+	if err := json.Unmarshal([]byte(out.String()), &plan); err != nil {
+		t.Fatalf("decode plan: %v\n%s", err, out.String())
+	}
+	want := []string{"update role Moderators", "create role Ops", "update channel dev", "create channel ops", "delete channel stale", "delete role Old"}
+	if len(plan) != len(want) {
+		t.Fatalf("expected %d steps, got %+v", len(want), plan)
+	}
+	for i, step := range plan {
+		if got := step.Action + " " + step.Kind + " " + step.Name; got != want[i] {
+			t.Fatalf("step %d = %q, want %q", i, got, want[i])
+		}
+	}
+	if len(guilds.newRoles)+len(guilds.newChannels)+len(guilds.deleted)+len(channels.deleted) != 0 || guilds.roleEdits != nil || channels.modifyParams != nil {
+		t.Fatal("dry run changed the guild")
+	}
+}
+
+func TestGuildApplyConverges(t *testing.T) {
+	const guildID = "123456789012345678"
+	guilds, channels := newApplyFixture(guildID)
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds, channelSvc: channels})
+
+	cmd := guildApplyCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{writeApplySnapshot(t), "--guild", guildID, "--yes", "--reason", "sync"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("guild apply: %v", err)
+	}
+
+	if len(guilds.newRoles) != 1 || guilds.newRoles[0].Name != "Ops" || guilds.newRoles[0].Permissions != "1024" || guilds.newRoles[0].AuditLogReason != "sync" {
+		t.Fatalf("unexpected role creates %+v", guilds.newRoles)
+	}
+	edit := guilds.roleEdits["r-mod"]
+	if edit == nil || string(edit.MergePatch) != `{"color":15158332}` {
+		t.Fatalf("unexpected moderator edit %+v", edit)
+	}
+	if channels.modifyParams == nil || channels.modifyParams.Topic != "builds and deploys" {
+		t.Fatalf("unexpected channel edit %+v", channels.modifyParams)
+	}
+	if len(guilds.newChannels) != 1 {
+		t.Fatalf("unexpected channel creates %+v", guilds.newChannels)
+	}
+	ops := guilds.newChannels[0]
+	if ops.ParentID != "cat-eng" || len(ops.PermissionOverwrites) != 2 ||
+		ops.PermissionOverwrites[0].ID != "new-role-1" || ops.PermissionOverwrites[1].ID != guildID || ops.PermissionOverwrites[1].Deny != "1024" {
+		t.Fatalf("ops channel not wired to new role and category: %+v", ops)
+	}
+	if len(channels.deleted) != 1 || channels.deleted[0] != "c-stale" {
+		t.Fatalf("unexpected channel deletes %v", channels.deleted)
+	}
+	if len(guilds.deleted) != 1 || guilds.deleted[0] != "r-old" {
+		t.Fatalf("unexpected role deletes %v", guilds.deleted)
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const (
+	banPageSize  = 1000
+	bulkBanBatch = 200
+)
+
+func guildBansCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bans",
+		Short: "Export and import guild ban lists",
+		Long: `List a guild's bans (every page) and import a ban list into another guild, e.g. to carry
+bans over when moving a community to a new server. Requires BAN_MEMBERS.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(guildBansListCmd(opts))
+	cmd.AddCommand(guildBansImportCmd(opts))
+	return cmd
+}
+
+func guildBansListCmd(opts *globalOptions) *cobra.Command {
+	var guildRef string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every ban in a guild",
+		Example: `Example:
+  arc-discord guild bans list --guild "Old Server" --output csv > bans.csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, _, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			cancel()
+			bans, err := listAllBans(cmd.Context(), bot, guildID)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list bans for guild %s", guildID), Hint: "the bot needs BAN_MEMBERS"}).WithCause(err)
+			}
+			table := &tableData{headers: []string{"USER_ID", "USERNAME", "REASON"}}
+			for _, ban := range bans {
+				if ban.User == nil {
+					continue
+				}
+				table.rows = append(table.rows, []string{ban.User.ID, ban.User.Username, ban.Reason})
+			}
+			return renderOutput(cmd, opts.output, bans, table)
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	return cmd
+}
+
+// listAllBans pages through the guild's bans in user ID order.
+func listAllBans(parent context.Context, bot botClient, guildID string) ([]*types.Ban, error) {
+	var all []*types.Ban
+	after := ""
+	for {
+		ctx, cancel := context.WithTimeout(parent, 30*time.Second)
+		page, err := bot.Guilds().GetGuildBans(ctx, guildID, &types.ListBansParams{Limit: banPageSize, After: after})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < banPageSize || page[len(page)-1].User == nil {
+			return all, nil
+		}
+		after = page[len(page)-1].User.ID
+	}
+}
+
+// banImportSummary is the result of guild bans import.
+type banImportSummary struct {
+	GuildID     string   `json:"guild_id"`
+	Requested   int      `json:"requested"`
+	BannedUsers []string `json:"banned_users"`
+	FailedUsers []string `json:"failed_users"`
+	Bulk        bool     `json:"bulk"`
+	DryRun      bool     `json:"dry_run,omitempty"`
+}
+
+func guildBansImportCmd(opts *globalOptions) *cobra.Command {
+	var (
+		guildRef       string
+		reason         string
+		deleteMessages time.Duration
+		dryRun         bool
+	)
+	cmd := &cobra.Command{
+		Use:   "import <file.csv>",
+		Short: "Ban every user listed in a CSV file",
+		Long: `Ban the users in a CSV file, such as one written by "guild bans list --output csv". The
+user_id column is used when the file has a header row; otherwise the first column. Users are
+banned 200 at a time with Discord's bulk ban, which also needs MANAGE_GUILD; without it the import
+falls back to banning users one by one. Users already banned are reported as failed by bulk ban.`,
+		Example: `Example:
+  arc-discord guild bans import bans.csv --guild "New Server" --reason "Migrated from Old Server"
+
+Example:
+  arc-discord guild bans import bans.csv --guild "New Server" --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			userIDs, err := readBanCSV(args[0])
+			if err != nil {
+				return err
+			}
+			seconds := int(deleteMessages / time.Second)
+			if err := (&types.CreateBanParams{DeleteMessageSeconds: seconds}).Validate(); err != nil {
+				return &arcer.CLIError{Msg: "--delete-messages must be at most 7 days"}
+			}
+			bot, _, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			cancel()
+
+			summary := &banImportSummary{GuildID: guildID, Requested: len(userIDs), DryRun: dryRun}
+			if !dryRun {
+				if err := importBans(cmd.Context(), bot, guildID, summary, userIDs, seconds, reason); err != nil {
+					return (&arcer.CLIError{Msg: fmt.Sprintf("ban import stopped after %d of %d users", len(summary.BannedUsers)+len(summary.FailedUsers), len(userIDs))}).WithCause(err)
+				}
+			}
+			table := keyValueTable(map[string]string{
+				"guild_id":  guildID,
+				"requested": strconv.Itoa(summary.Requested),
+				"banned":    strconv.Itoa(len(summary.BannedUsers)),
+				"failed":    strings.Join(summary.FailedUsers, ","),
+				"bulk":      strconv.FormatBool(summary.Bulk),
+				"dry_run":   strconv.FormatBool(dryRun),
+			})
+			return renderOutput(cmd, opts.output, summary, table)
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Target guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason recorded for the bans")
+	cmd.Flags().DurationVar(&deleteMessages, "delete-messages", 0, "Also delete the users' recent messages (up to 168h)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Parse the file and report how many users would be banned")
+	return cmd
+}
+
+// importBans bans userIDs in bulk batches, switching to single bans when the
+// bulk endpoint is unavailable to the bot.
+func importBans(parent context.Context, bot botClient, guildID string, summary *banImportSummary, userIDs []string, seconds int, reason string) error {
+	bulk := true
+	for start := 0; start < len(userIDs); start += bulkBanBatch {
+		batch := userIDs[start:min(start+bulkBanBatch, len(userIDs))]
+		if bulk {
+			ctx, cancel := context.WithTimeout(parent, 30*time.Second)
+			result, err := bot.Guilds().BulkGuildBan(ctx, guildID, &types.BulkBanParams{UserIDs: batch, DeleteMessageSeconds: seconds, AuditLogReason: reason})
+			cancel()
+			if err == nil {
+				summary.Bulk = true
+				summary.BannedUsers = append(summary.BannedUsers, result.BannedUsers...)
+				summary.FailedUsers = append(summary.FailedUsers, result.FailedUsers...)
+				continue
+			}
+			if !bulkBanUnavailable(err) {
+				return err
+			}
+			bulk = false
+		}
+		for _, userID := range batch {
+			ctx, cancel := context.WithTimeout(parent, 30*time.Second)
+			err := bot.Guilds().CreateGuildBan(ctx, guildID, userID, &types.CreateBanParams{DeleteMessageSeconds: seconds, AuditLogReason: reason})
+			cancel()
+			if err != nil {
+				var apiErr *types.APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode == 401 || apiErr.StatusCode == 429 {
+					return err
+				}
+				summary.FailedUsers = append(summary.FailedUsers, userID)
+				continue
+			}
+			summary.BannedUsers = append(summary.BannedUsers, userID)
+		}
+	}
+	return nil
+}
+
+// bulkBanUnavailable reports errors that mean the bot cannot use bulk ban
+// (missing MANAGE_GUILD, or an API version without the route).
+func bulkBanUnavailable(err error) bool {
+	var apiErr *types.APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == 403 || apiErr.UnknownRoute())
+}
+
+// readBanCSV returns the unique user IDs in a ban list CSV.
+func readBanCSV(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	column := 0
+	seen := make(map[string]bool)
+	var ids []string
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to parse %s", path)}).WithCause(err)
+		}
+		if line == 1 {
+			if header := banCSVColumn(record); header >= 0 {
+				column = header
+				continue
+			}
+		}
+		if column >= len(record) || strings.TrimSpace(record[column]) == "" {
+			continue
+		}
+		id := strings.TrimSpace(record[column])
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			return nil, &arcer.CLIError{Msg: fmt.Sprintf("%s:%d: %q is not a user ID", path, line, id)}
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, &arcer.CLIError{Msg: fmt.Sprintf("no user IDs found in %s", path)}
+	}
+	return ids, nil
+}
+
+// banCSVColumn returns the index of the user ID column when record is a
+// header row, or -1 when it is data.
+func banCSVColumn(record []string) int {
+	for i, field := range record {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "user_id", "userid", "id":
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestGuildBansListPaginates(t *testing.T) {
+	guilds := &fakeGuildService{}
+	for i := 0; i < banPageSize+5; i++ {
+		guilds.bans = append(guilds.bans, &types.Ban{User: &types.User{ID: fmt.Sprintf("1%05d", i), Username: "spammer"}, Reason: "spam"})
+	}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	var out bytes.Buffer
+	cmd := guildBansListCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--guild", "123456789012345678"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("bans list: %v", err)
+	}
+	var bans []types.Ban
+	if err := json.Unmarshal(out.Bytes(), &bans); err != nil || len(bans) != banPageSize+5 {
+		t.Fatalf("expected %d bans, got %d: %v", banPageSize+5, len(bans), err)
+	}
+	if len(guilds.banPages) != 2 || guilds.banPages[1] != "100999" {
+		t.Fatalf("unexpected pages %v", guilds.banPages)
+	}
+}
+
+func writeBanCSV(t *testing.T, n int) string {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString("user_id,username,reason\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "2%05d,user%d,\"spam, links\"\n", i, i)
+	}
+	path := filepath.Join(t.TempDir(), "bans.csv")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGuildBansImportBatchesBulkBans(t *testing.T) {
+	guilds := &fakeGuildService{}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	cmd := guildBansImportCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{writeBanCSV(t, 450), "--guild", "123456789012345678", "--reason", "migrate"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("bans import: %v", err)
+	}
+	if len(guilds.bulkBans) != 3 || len(guilds.bulkBans[0]) != bulkBanBatch || len(guilds.bulkBans[2]) != 50 {
+		t.Fatalf("unexpected batches %d", len(guilds.bulkBans))
+	}
+	if len(guilds.banned) != 0 {
+		t.Fatalf("did not expect single bans, got %d", len(guilds.banned))
+	}
+}
+
+func TestGuildBansImportFallsBackWithoutManageGuild(t *testing.T) {
+	guilds := &fakeGuildService{bulkErr: &types.APIError{StatusCode: 403, Code: 50013, Message: "Missing Permissions"}}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	var out bytes.Buffer
+	cmd := guildBansImportCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{writeBanCSV(t, 3), "--guild", "123456789012345678"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("bans import: %v", err)
+	}
+	if len(guilds.banned) != 3 {
+		t.Fatalf("expected 3 single bans, got %v", guilds.banned)
+	}
+	var summary banImportSummary
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil || summary.Bulk || len(summary.BannedUsers) != 3 {
+		t.Fatalf("unexpected summary %+v: %v", summary, err)
+	}
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"gopkg.in/yaml.v3"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const welcomeScreenEnabledFeature = "WELCOME_SCREEN_ENABLED"
+
+func guildOnboardingCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "onboarding",
+		Short: "Export and apply guild onboarding (prompts, default channels)",
+		Long: `Round-trip a community guild's onboarding through a file: "get --output yaml" exports it and
+"set --file" applies it, so the same setup can be reviewed in git and reapplied.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(guildOnboardingGetCmd(opts))
+	cmd.AddCommand(guildOnboardingSetCmd(opts))
+	return cmd
+}
+
+func guildOnboardingGetCmd(opts *globalOptions) *cobra.Command {
+	var guildRef string
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the guild's onboarding configuration",
+		Example: `Example:
+  arc-discord guild onboarding get --guild "Arc Labs" --output yaml > onboarding.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			onboarding, err := bot.Guilds().GetGuildOnboarding(ctx, guildID)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch onboarding for guild %s", guildID)}).WithCause(err)
+			}
+			table := &tableData{headers: []string{"PROMPT", "TYPE", "OPTIONS", "REQUIRED"}}
+			for _, prompt := range onboarding.Prompts {
+				kind := "multiple_choice"
+				if prompt.Type == types.OnboardingPromptDropdown {
+					kind = "dropdown"
+				}
+				table.rows = append(table.rows, []string{prompt.Title, kind, strconv.Itoa(len(prompt.Options)), strconv.FormatBool(prompt.Required)})
+			}
+			return renderFileShape(cmd, opts, onboarding, table)
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	return cmd
+}
+
+func guildOnboardingSetCmd(opts *globalOptions) *cobra.Command {
+	var guildRef, file, reason string
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Replace the guild's onboarding configuration from a YAML/JSON file",
+		Long: `Replace the guild's onboarding prompts, default channels, mode, and enabled flag with the
+contents of --file (YAML or JSON, in the shape "get" prints). Everything is replaced, so
+prompts missing from the file are removed. Requires MANAGE_GUILD and MANAGE_ROLES.`,
+		Example: `Example:
+  arc-discord guild onboarding set --guild "Arc Labs" --file onboarding.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return &arcer.CLIError{Msg: "--file is required"}
+			}
+			var params types.GuildOnboardingParams
+			if err := loadStructuredFile(file, &params); err != nil {
+				return err
+			}
+			params.AuditLogReason = reason
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			onboarding, err := bot.Guilds().ModifyGuildOnboarding(ctx, guildID, &params)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to update onboarding for guild %s", guildID)}).WithCause(err)
+			}
+			cmd.Printf("Onboarding for guild %s updated (%d prompt(s), enabled=%t)\n", guildID, len(onboarding.Prompts), onboarding.Enabled)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML or JSON file with the onboarding configuration")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+// welcomeScreenDoc is the file shape for welcome screens. Discord reports
+// whether the screen is enabled as a guild feature rather than on the
+// welcome screen itself, so get merges the two.
+type welcomeScreenDoc struct {
+	Enabled         bool                         `json:"enabled"`
+	Description     string                       `json:"description"`
+	WelcomeChannels []types.WelcomeScreenChannel `json:"welcome_channels"`
+}
+
+func guildWelcomeScreenCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "welcome-screen",
+		Short: "Export and apply the guild welcome screen",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(guildWelcomeScreenGetCmd(opts))
+	cmd.AddCommand(guildWelcomeScreenSetCmd(opts))
+	return cmd
+}
+
+func guildWelcomeScreenGetCmd(opts *globalOptions) *cobra.Command {
+	var guildRef string
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the guild's welcome screen",
+		Example: `Example:
+  arc-discord guild welcome-screen get --output yaml > welcome.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			screen, err := bot.Guilds().GetGuildWelcomeScreen(ctx, guildID)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch welcome screen for guild %s", guildID)}).WithCause(err)
+			}
+			guild, err := bot.Guilds().GetGuild(ctx, guildID, false)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch guild %s", guildID)}).WithCause(err)
+			}
+			doc := welcomeScreenDoc{Description: screen.Description, WelcomeChannels: screen.WelcomeChannels}
+			for _, feature := range guild.Features {
+				if feature == welcomeScreenEnabledFeature {
+					doc.Enabled = true
+				}
+			}
+			table := &tableData{headers: []string{"CHANNEL", "EMOJI", "DESCRIPTION"}}
+			for _, ch := range doc.WelcomeChannels {
+				table.rows = append(table.rows, []string{ch.ChannelID, ch.EmojiName, ch.Description})
+			}
+			return renderFileShape(cmd, opts, doc, table)
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	return cmd
+}
+
+func guildWelcomeScreenSetCmd(opts *globalOptions) *cobra.Command {
+	var guildRef, file, reason string
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Update the guild's welcome screen from a YAML/JSON file",
+		Long: `Update the welcome screen from --file (YAML or JSON, in the shape "get" prints). Only the keys
+present in the file are changed; welcome_channels, when given, replaces the list (at most 5).
+Requires MANAGE_GUILD.`,
+		Example: `Example:
+  arc-discord guild welcome-screen set --file welcome.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return &arcer.CLIError{Msg: "--file is required"}
+			}
+			var params types.WelcomeScreenModifyParams
+			if err := loadStructuredFile(file, &params); err != nil {
+				return err
+			}
+			params.AuditLogReason = reason
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			screen, err := bot.Guilds().ModifyGuildWelcomeScreen(ctx, guildID, &params)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to update welcome screen for guild %s", guildID), Hint: "welcome screens require a community guild"}).WithCause(err)
+			}
+			cmd.Printf("Welcome screen for guild %s updated (%d channel(s))\n", guildID, len(screen.WelcomeChannels))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML or JSON file with the welcome screen")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+// guildTarget loads config, builds the bot client, and resolves guildRef
+// (falling back to default_guild_id). The context is bounded to 30s.
+func guildTarget(cmd *cobra.Command, opts *globalOptions, guildRef string) (botClient, context.Context, context.CancelFunc, string, error) {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	if guildRef == "" {
+		guildRef = cfg.Discord.DefaultGuildID
+	}
+	if guildRef == "" {
+		return nil, nil, nil, "", &arcer.CLIError{Msg: "--guild is required", Hint: "pass a Discord guild ID or set default_guild_id in discord.yaml"}
+	}
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return nil, nil, nil, "", (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	guildID, err := newNameResolver(bot, cfg).GuildID(ctx, guildRef)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, "", err
+	}
+	return bot, ctx, cancel, guildID, nil
+}
+
+// renderFileShape renders data with its JSON field names in both JSON and
+// YAML, so the YAML output can be fed back to a set command.
+func renderFileShape(cmd *cobra.Command, opts *globalOptions, data any, table *tableData) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var shaped any
+	if err := json.Unmarshal(raw, &shaped); err != nil {
+		return err
+	}
+	return renderOutput(cmd, opts.output, shaped, table)
+}
+
+// loadStructuredFile decodes a YAML or JSON file (JSON is valid YAML) into
+// out using out's JSON field names.
+func loadStructuredFile(path string, out any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to parse %s", path)}).WithCause(err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to parse %s", path)}).WithCause(err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		hint := ""
+		if strings.Contains(err.Error(), "number") {
+			hint = "quote IDs in YAML so they stay strings, e.g. channel_id: \"1427555325136867393\""
+		}
+		return (&arcer.CLIError{Msg: fmt.Sprintf("invalid content in %s", path), Hint: hint}).WithCause(err)
+	}
+	return nil
+}
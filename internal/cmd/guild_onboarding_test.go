@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestGuildOnboardingRoundTripsThroughYAML(t *testing.T) {
+	guilds := &fakeGuildService{onboarding: &types.GuildOnboarding{
+		GuildID:           "123456789012345678",
+		DefaultChannelIDs: []string{"1427555325136867393"},
+		Enabled:           true,
+		Mode:              types.OnboardingModeAdvanced,
+		Prompts: []types.OnboardingPrompt{{
+			ID:    "900000000000000001",
+			Title: "What brings you here?",
+			Type:  types.OnboardingPromptDropdown,
+			Options: []types.OnboardingPromptOption{{
+				ID:      "900000000000000002",
+				Title:   "Contributing",
+				RoleIDs: []string{"700000000000000001"},
+				Emoji:   &types.OnboardingEmoji{Name: "🛠️"},
+			}},
+		}},
+	}}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	var out bytes.Buffer
+	get := guildOnboardingGetCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputYAML)}})
+	get.SetOut(&out)
+	get.SetArgs([]string{"--guild", "123456789012345678"})
+	if err := get.Execute(); err != nil {
+		t.Fatalf("onboarding get: %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "onboarding.yaml")
+	if err := os.WriteFile(file, out.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	set := guildOnboardingSetCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	set.SetOut(&bytes.Buffer{})
+	set.SetArgs([]string{"--guild", "123456789012345678", "--file", file, "--reason", "sync"})
+	if err := set.Execute(); err != nil {
+		t.Fatalf("onboarding set: %v", err)
+	}
+	params := guilds.onboardingParams
+	if params == nil || !params.Enabled || params.Mode != types.OnboardingModeAdvanced || params.AuditLogReason != "sync" {
+		t.Fatalf("unexpected params %+v", params)
+	}
+	if len(params.DefaultChannelIDs) != 1 || params.DefaultChannelIDs[0] != "1427555325136867393" {
+		t.Fatalf("default channels not preserved: %v", params.DefaultChannelIDs)
+	}
+	opt := params.Prompts[0].Options[0]
+	if params.Prompts[0].Type != types.OnboardingPromptDropdown || opt.Title != "Contributing" || opt.RoleIDs[0] != "700000000000000001" || opt.Emoji.Name != "🛠️" {
+		t.Fatalf("prompt not preserved: %+v", params.Prompts[0])
+	}
+}
+
+func TestGuildWelcomeScreenSetOnlySendsFileKeys(t *testing.T) {
+	guilds := &fakeGuildService{}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	file := filepath.Join(t.TempDir(), "welcome.json")
+	doc := `{"welcome_channels": [{"channel_id": "1427555325136867393", "description": "Start here", "emoji_name": "👋"}]}`
+	if err := os.WriteFile(file, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := guildWelcomeScreenSetCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--guild", "123456789012345678", "--file", file})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("welcome-screen set: %v", err)
+	}
+	params := guilds.welcomeParams
+	if params.Enabled != nil || params.Description != nil {
+		t.Fatalf("expected only welcome_channels, got %+v", params)
+	}
+	if params.WelcomeChannels == nil || (*params.WelcomeChannels)[0].EmojiName != "👋" {
+		t.Fatalf("unexpected channels %+v", params.WelcomeChannels)
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// pruneSummary is the result of guild prune.
+type pruneSummary struct {
+	GuildID      string   `json:"guild_id"`
+	Days         int      `json:"days"`
+	IncludeRoles []string `json:"include_roles,omitempty"`
+	Estimated    int      `json:"estimated"`
+	Pruned       *int     `json:"pruned,omitempty"`
+	ComputeOnly  bool     `json:"compute_only,omitempty"`
+}
+
+func guildPruneCmd(opts *globalOptions) *cobra.Command {
+	var (
+		guildRef    string
+		roleRefs    []string
+		computeOnly bool
+		yes         bool
+		params      types.PruneParams
+	)
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove members who have been inactive for a number of days",
+		Long: `Kick members who have not been seen for --days (1-30). By default only members without roles
+are pruned; --include-role also prunes members whose roles are all among the given roles.
+
+The command first shows how many members would be pruned and asks for confirmation; pass --yes to
+skip the prompt in scripts, or --compute-only to report the count without pruning. Requires
+MANAGE_GUILD and KICK_MEMBERS.`,
+		Example: `Example:
+  arc-discord guild prune --days 30 --compute-only
+
+Example:
+  arc-discord guild prune --days 30 --include-role Guest --include-role "Trial" --reason "Quarterly cleanup" --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			if err := params.Validate(); err != nil {
+				return &arcer.CLIError{Msg: "--days must be between 1 and 30"}
+			}
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			if len(roleRefs) > 0 {
+				roles, err := resolveRoleIDs(ctx, bot, guildID, roleRefs)
+				if err != nil {
+					cancel()
+					return err
+				}
+				params.IncludeRoles = roles
+			}
+			count, err := bot.Guilds().GetGuildPruneCount(ctx, guildID, &params)
+			cancel()
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to compute prune count for guild %s", guildID), Hint: "the bot needs MANAGE_GUILD and KICK_MEMBERS"}).WithCause(err)
+			}
+			summary := &pruneSummary{GuildID: guildID, Days: params.Days, IncludeRoles: params.IncludeRoles, Estimated: count, ComputeOnly: computeOnly}
+			if !computeOnly && count > 0 {
+				if !yes {
+					ok, err := confirmAction(cmd, fmt.Sprintf("Prune %d member(s) inactive for %d days from guild %s?", count, params.Days, guildID))
+					if err != nil {
+						return err
+					}
+					if !ok {
+						return &arcer.CLIError{Msg: "prune cancelled"}
+					}
+				}
+				// The prompt can outlast the lookup timeout, so prune on a fresh one.
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				defer cancel()
+				result, err := bot.Guilds().BeginGuildPrune(ctx, guildID, &params)
+				if err != nil {
+					return (&arcer.CLIError{Msg: fmt.Sprintf("failed to prune guild %s", guildID)}).WithCause(err)
+				}
+				summary.Pruned = result.Pruned
+				if summary.Pruned == nil {
+					summary.Pruned = &count
+				}
+			}
+
+			pruned := "-"
+			if summary.Pruned != nil {
+				pruned = strconv.Itoa(*summary.Pruned)
+			}
+			table := keyValueTable(map[string]string{
+				"guild_id":      guildID,
+				"days":          strconv.Itoa(params.Days),
+				"include_roles": strings.Join(params.IncludeRoles, ","),
+				"estimated":     strconv.Itoa(count),
+				"pruned":        pruned,
+			})
+			return renderOutput(cmd, opts.output, summary, table)
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().IntVar(&params.Days, "days", 30, "Days of inactivity (1-30)")
+	cmd.Flags().StringArrayVar(&roleRefs, "include-role", nil, "Also prune members whose roles are all in this set (role ID or name, repeatable)")
+	cmd.Flags().BoolVar(&computeOnly, "compute-only", false, "Only report how many members would be pruned")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Prune without asking for confirmation")
+	cmd.Flags().BoolVar(&params.ComputePruneCount, "count", false, "Ask Discord to count pruned members (slow on large guilds)")
+	cmd.Flags().StringVar(&params.AuditLogReason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+// resolveRoleIDs maps role IDs or names (case-insensitive, optional @) to IDs.
+func resolveRoleIDs(ctx context.Context, bot botClient, guildID string, refs []string) ([]string, error) {
+	roles, err := bot.Guilds().GetGuildRoles(ctx, guildID)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to list roles for guild %s", guildID)}).WithCause(err)
+	}
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		name := strings.TrimPrefix(strings.TrimSpace(ref), "@")
+		var match string
+		for _, role := range roles {
+			if role.ID == name || strings.EqualFold(role.Name, name) {
+				match = role.ID
+				break
+			}
+		}
+		if match == "" {
+			return nil, &arcer.CLIError{Msg: fmt.Sprintf("role %q not found in guild %s", ref, guildID), Hint: "list roles with: arc-discord guild roles"}
+		}
+		ids = append(ids, match)
+	}
+	return ids, nil
+}
+
+// confirmAction asks a yes/no question on the command's input. Anything but
+// y/yes is a no; end of input is an error, so scripts must pass --yes.
+func confirmAction(cmd *cobra.Command, prompt string) (bool, error) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(cmd.ErrOrStderr())
+		return false, &arcer.CLIError{Msg: "no confirmation received", Hint: "pass --yes to run non-interactively"}
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func runGuildPrune(t *testing.T, guilds *fakeGuildService, stdin string, args ...string) (string, error) {
+	t.Helper()
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+	var out bytes.Buffer
+	cmd := guildPruneCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"--guild", "123456789012345678"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestGuildPruneComputeOnlyResolvesRoles(t *testing.T) {
+	guilds := &fakeGuildService{pruneCount: 7, roles: []*types.Role{{ID: "r1", Name: "Guest"}}}
+	out, err := runGuildPrune(t, guilds, "", "--days", "14", "--include-role", "@guest", "--compute-only")
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if guilds.pruned {
+		t.Fatal("compute-only must not prune")
+	}
+	if guilds.pruneParams.Days != 14 || len(guilds.pruneParams.IncludeRoles) != 1 || guilds.pruneParams.IncludeRoles[0] != "r1" {
+		t.Fatalf("unexpected params %+v", guilds.pruneParams)
+	}
+	if !strings.Contains(out, "estimated") || !strings.Contains(out, "7") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestGuildPruneRequiresConfirmation(t *testing.T) {
+	guilds := &fakeGuildService{pruneCount: 3}
+	if _, err := runGuildPrune(t, guilds, "n\n"); err == nil || guilds.pruned {
+		t.Fatalf("expected prune to be cancelled, err=%v pruned=%t", err, guilds.pruned)
+	}
+	if _, err := runGuildPrune(t, guilds, ""); err == nil || guilds.pruned {
+		t.Fatalf("expected missing confirmation error, err=%v", err)
+	}
+	if _, err := runGuildPrune(t, guilds, "yes\n"); err != nil || !guilds.pruned {
+		t.Fatalf("expected prune after confirmation, err=%v", err)
+	}
+}
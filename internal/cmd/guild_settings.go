@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func guildModifyCmd(opts *globalOptions) *cobra.Command {
+	var (
+		guildID           string
+		params            types.GuildModifyParams
+		verificationLevel int
+		patchPath         string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "modify",
+		Aliases: []string{"settings"},
+		Short:   "Update guild settings (name, locale, system channels)",
+		Long: `Update guild settings with the bot token (requires MANAGE_GUILD).
+Only the flags you pass are sent. --patch FILE merges an RFC 7386 patch over the flag values, which is
+how to set fields without a dedicated flag or clear them explicitly with null (e.g. {"system_channel_id": null}).
+
+If --guild is not provided, uses default_guild_id from discord.yaml (if configured).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("verification-level") {
+				params.VerificationLevel = verificationLevel
+			}
+			patch, err := loadMergePatch(patchPath)
+			if err != nil {
+				return err
+			}
+			params.MergePatch = patch
+			if params.Name == "" && params.Description == "" && params.PreferredLocale == "" &&
+				params.SystemChannelID == "" && params.RulesChannelID == "" && params.AFKChannelID == "" &&
+				!cmd.Flags().Changed("verification-level") && len(params.MergePatch) == 0 {
+				return &arcer.CLIError{Msg: "specify at least one setting to update", Hint: "see --help for flags or pass --patch"}
+			}
+			return runGuildModify(cmd, opts, guildID, &params)
+		},
+		Example: `  # Rename the guild and set its locale
+  arc-discord guild modify --name "Arc Labs" --locale en-US
+
+  # Point system messages at a different channel
+  arc-discord guild settings --system-channel 1427555325136867393
+
+  # Clear the rules channel with a merge patch
+  echo '{"rules_channel_id": null}' > guild.json
+  arc-discord guild modify --patch guild.json`,
+	}
+
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&params.Name, "name", "", "New guild name")
+	cmd.Flags().StringVar(&params.Description, "description", "", "Guild description (community guilds)")
+	cmd.Flags().StringVar(&params.PreferredLocale, "locale", "", "Preferred locale, e.g. en-US")
+	cmd.Flags().StringVar(&params.SystemChannelID, "system-channel", "", "Channel ID for system messages")
+	cmd.Flags().StringVar(&params.RulesChannelID, "rules-channel", "", "Channel ID for rules (community guilds)")
+	cmd.Flags().StringVar(&params.AFKChannelID, "afk-channel", "", "Voice channel ID for AFK members")
+	cmd.Flags().IntVar(&verificationLevel, "verification-level", 0, "Verification level 0-4 (none..very_high)")
+	cmd.Flags().StringVar(&params.AuditLogReason, "reason", "", "Audit log reason")
+	cmd.Flags().StringVar(&patchPath, "patch", "", patchFlagUsage)
+	return cmd
+}
+
+func runGuildModify(cmd *cobra.Command, opts *globalOptions, guildID string, params *types.GuildModifyParams) error {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	if guildID == "" {
+		guildID = cfg.Discord.DefaultGuildID
+	}
+	if guildID == "" {
+		return &arcer.CLIError{Msg: "--guild is required", Hint: "pass a Discord guild ID or set default_guild_id in discord.yaml"}
+	}
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := bot.Guilds().ModifyGuild(ctx, guildID, params); err != nil {
+		return (&arcer.CLIError{Msg: "failed to modify guild"}).WithCause(err)
+	}
+	cmd.Printf("Guild %s updated\n", guildID)
+	return nil
+}
+
+func guildRoleEditCmd(opts *globalOptions) *cobra.Command {
+	var (
+		guildID   string
+		roleID    string
+		color     string
+		params    types.RoleModifyParams
+		patchPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit a guild role",
+		Long: `Edit a role's name, color, permissions, or display flags (requires MANAGE_ROLES).
+Only the flags you pass are sent. --patch FILE merges an RFC 7386 patch over the flag values; use it to
+set false/0 values (e.g. {"hoist": false, "color": 0}) or fields without a dedicated flag.
+
+If --guild is not provided, uses default_guild_id from discord.yaml (if configured).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if roleID == "" {
+				return &arcer.CLIError{Msg: "--role is required"}
+			}
+			if color != "" {
+				value, err := parseColor(color, "role")
+				if err != nil {
+					return err
+				}
+				params.Color = value
+			}
+			patch, err := loadMergePatch(patchPath)
+			if err != nil {
+				return err
+			}
+			params.MergePatch = patch
+			if params.Name == "" && color == "" && params.Permissions == "" && !params.Hoist && !params.Mentionable && len(params.MergePatch) == 0 {
+				return &arcer.CLIError{Msg: "specify at least one field to update", Hint: "see --help for flags or pass --patch"}
+			}
+			return runGuildRoleEdit(cmd, opts, guildID, roleID, &params)
+		},
+		Example: `  # Rename a role and change its color
+  arc-discord guild roles edit --role $ROLE --name Operators --color "#3498db"
+
+  # Hide a role from the member list via merge patch
+  echo '{"hoist": false}' > role.json
+  arc-discord guild roles edit --role $ROLE --patch role.json`,
+	}
+
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&roleID, "role", "", "Role ID to edit")
+	cmd.Flags().StringVar(&params.Name, "name", "", "New role name")
+	cmd.Flags().StringVar(&color, "color", "", "Role color as #rrggbb or decimal")
+	cmd.Flags().StringVar(&params.Permissions, "permissions", "", "Permission bitset as a decimal string")
+	cmd.Flags().BoolVar(&params.Hoist, "hoist", false, "Display role members separately")
+	cmd.Flags().BoolVar(&params.Mentionable, "mentionable", false, "Allow anyone to mention the role")
+	cmd.Flags().StringVar(&params.AuditLogReason, "reason", "", "Audit log reason")
+	cmd.Flags().StringVar(&patchPath, "patch", "", patchFlagUsage)
+	return cmd
+}
+
+func runGuildRoleEdit(cmd *cobra.Command, opts *globalOptions, guildID, roleID string, params *types.RoleModifyParams) error {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	if guildID == "" {
+		guildID = cfg.Discord.DefaultGuildID
+	}
+	if guildID == "" {
+		return &arcer.CLIError{Msg: "--guild is required", Hint: "pass a Discord guild ID or set default_guild_id in discord.yaml"}
+	}
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := bot.Guilds().ModifyGuildRole(ctx, guildID, roleID, params); err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to edit role %s", roleID)}).WithCause(err)
+	}
+	cmd.Printf("Role %s updated in guild %s\n", roleID, guildID)
+	return nil
+}
+
+// parseColor accepts #rrggbb, 0xrrggbb, or a decimal RGB value; what names
+// the flag in error messages.
+func parseColor(raw, what string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	base := 10
+	if strings.HasPrefix(raw, "#") {
+		raw = strings.TrimPrefix(raw, "#")
+		base = 16
+	} else if strings.HasPrefix(strings.ToLower(raw), "0x") {
+		raw = raw[2:]
+		base = 16
+	}
+	value, err := strconv.ParseInt(raw, base, 32)
+	if err != nil || value < 0 || value > 0xFFFFFF {
+		return 0, &arcer.CLIError{Msg: fmt.Sprintf("invalid %s color %q", what, raw), Hint: "use #rrggbb or a decimal value"}
+	}
+	return int(value), nil
+}
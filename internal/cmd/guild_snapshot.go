@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/permissions"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"gopkg.in/yaml.v3"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const guildSnapshotVersion = 1
+
+// guildSnapshot is the declarative form of a guild written by "guild
+// snapshot". Roles, channels, and emojis refer to each other by name so a
+// snapshot stays readable and can be applied to another guild; IDs are kept
+// so re-applying to the source guild matches renamed entries.
+type guildSnapshot struct {
+	Version  int                   `yaml:"version"`
+	Guild    guildSnapshotSettings `yaml:"guild"`
+	Roles    []roleSnapshot        `yaml:"roles"`
+	Channels []channelSnapshot     `yaml:"channels"`
+	Emojis   []emojiSnapshot       `yaml:"emojis,omitempty"`
+}
+
+type guildSnapshotSettings struct {
+	ID                          string `yaml:"id,omitempty"`
+	Name                        string `yaml:"name"`
+	Description                 string `yaml:"description,omitempty"`
+	VerificationLevel           int    `yaml:"verification_level"`
+	DefaultMessageNotifications int    `yaml:"default_message_notifications"`
+	ExplicitContentFilter       int    `yaml:"explicit_content_filter"`
+	AFKChannel                  string `yaml:"afk_channel,omitempty"`
+	AFKTimeout                  int    `yaml:"afk_timeout,omitempty"`
+	SystemChannel               string `yaml:"system_channel,omitempty"`
+	RulesChannel                string `yaml:"rules_channel,omitempty"`
+	PublicUpdatesChannel        string `yaml:"public_updates_channel,omitempty"`
+	PreferredLocale             string `yaml:"preferred_locale,omitempty"`
+	// Features is informational; most are granted by Discord, not set.
+	Features []string `yaml:"features,omitempty"`
+}
+
+// roleSnapshot is one role. Roles are listed highest first, so the list
+// order is the hierarchy.
+type roleSnapshot struct {
+	ID          string   `yaml:"id,omitempty"`
+	Name        string   `yaml:"name"`
+	Color       string   `yaml:"color,omitempty"`
+	Hoist       bool     `yaml:"hoist,omitempty"`
+	Mentionable bool     `yaml:"mentionable,omitempty"`
+	Managed     bool     `yaml:"managed,omitempty"`
+	Permissions []string `yaml:"permissions,omitempty"`
+}
+
+// channelSnapshot is one channel. Categories come before their channels and
+// channels are listed in display order.
+type channelSnapshot struct {
+	ID          string              `yaml:"id,omitempty"`
+	Name        string              `yaml:"name"`
+	Type        string              `yaml:"type"`
+	Category    string              `yaml:"category,omitempty"`
+	Topic       string              `yaml:"topic,omitempty"`
+	NSFW        bool                `yaml:"nsfw,omitempty"`
+	Slowmode    int                 `yaml:"slowmode,omitempty"`
+	Bitrate     int                 `yaml:"bitrate,omitempty"`
+	UserLimit   int                 `yaml:"user_limit,omitempty"`
+	Permissions []overwriteSnapshot `yaml:"permissions,omitempty"`
+}
+
+// overwriteSnapshot is a channel permission overwrite for a role (by name)
+// or a member (by ID).
+type overwriteSnapshot struct {
+	Role   string   `yaml:"role,omitempty"`
+	Member string   `yaml:"member,omitempty"`
+	Allow  []string `yaml:"allow,omitempty"`
+	Deny   []string `yaml:"deny,omitempty"`
+}
+
+type emojiSnapshot struct {
+	ID       string   `yaml:"id,omitempty"`
+	Name     string   `yaml:"name"`
+	Animated bool     `yaml:"animated,omitempty"`
+	Roles    []string `yaml:"roles,omitempty"`
+	URL      string   `yaml:"url,omitempty"`
+}
+
+// snapshotChannelTypes names the channel types a snapshot can hold. Threads
+// and DMs are not part of a guild's layout.
+var snapshotChannelTypes = map[string]types.ChannelType{
+	"text":         types.ChannelTypeGuildText,
+	"voice":        types.ChannelTypeGuildVoice,
+	"category":     types.ChannelTypeGuildCategory,
+	"announcement": types.ChannelTypeGuildNews,
+	"stage":        types.ChannelTypeGuildStageVoice,
+	"forum":        types.ChannelTypeGuildForum,
+	"media":        types.ChannelTypeGuildMedia,
+}
+
+func snapshotChannelTypeName(t types.ChannelType) (string, bool) {
+	for name, ct := range snapshotChannelTypes {
+		if ct == t {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func guildSnapshotCmd(opts *globalOptions) *cobra.Command {
+	var guildRef, outPath string
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export a guild's roles, channels, emojis, and settings to YAML",
+		Long: `Export a guild's settings, roles (with permissions, highest first), channels (with categories,
+topics, and permission overwrites), and emojis into one declarative YAML file. Roles and channels
+refer to each other by name; permissions are listed by name, so the file reads well in review.
+
+Keep the file in git to track changes to the server, or feed it to "guild apply".`,
+		Example: `Example:
+  arc-discord guild snapshot --guild "Arc Labs" --out guild.yaml
+
+Example:
+  arc-discord guild snapshot --guild $GUILD | yq '.roles[].name'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			guild, err := bot.Guilds().GetGuild(ctx, guildID, false)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch guild %s", guildID)}).WithCause(err)
+			}
+			roles, err := bot.Guilds().GetGuildRoles(ctx, guildID)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list roles for guild %s", guildID)}).WithCause(err)
+			}
+			channels, err := bot.Guilds().GetGuildChannels(ctx, guildID)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list channels for guild %s", guildID)}).WithCause(err)
+			}
+			data, err := yaml.Marshal(buildGuildSnapshot(guild, roles, channels))
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to encode snapshot"}).WithCause(err)
+			}
+			if outPath == "" || outPath == "-" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+			if err := os.WriteFile(outPath, data, 0o644); err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to write %s", outPath)}).WithCause(err)
+			}
+			cmd.Printf("Snapshot of %s written to %s (%d roles, %d channels)\n", guild.Name, outPath, len(roles), len(channels))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&outPath, "out", "", "File to write the snapshot to (default stdout)")
+	return cmd
+}
+
+// buildGuildSnapshot converts the API objects into a snapshot, replacing
+// IDs with names wherever the file refers to another entry.
+func buildGuildSnapshot(guild *types.Guild, roles []*types.Role, channels []*types.Channel) *guildSnapshot {
+	roleNames := make(map[string]string, len(roles))
+	for _, r := range roles {
+		roleNames[r.ID] = r.Name
+	}
+	channelNames := make(map[string]string, len(channels))
+	for _, c := range channels {
+		channelNames[c.ID] = c.Name
+	}
+
+	snap := &guildSnapshot{
+		Version: guildSnapshotVersion,
+		Guild: guildSnapshotSettings{
+			ID:                          guild.ID,
+			Name:                        guild.Name,
+			Description:                 guild.Description,
+			VerificationLevel:           guild.VerificationLevel,
+			DefaultMessageNotifications: guild.DefaultMessageNotifications,
+			ExplicitContentFilter:       guild.ExplicitContentFilter,
+			AFKChannel:                  channelNames[guild.AFKChannelID],
+			AFKTimeout:                  guild.AFKTimeout,
+			SystemChannel:               channelNames[guild.SystemChannelID],
+			RulesChannel:                channelNames[guild.RulesChannelID],
+			PublicUpdatesChannel:        channelNames[guild.PublicUpdatesChannelID],
+			PreferredLocale:             guild.PreferredLocale,
+			Features:                    sortedStrings(guild.Features),
+		},
+	}
+
+	sorted := append([]*types.Role(nil), roles...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Position != sorted[j].Position {
+			return sorted[i].Position > sorted[j].Position
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	for _, r := range sorted {
+		role := roleSnapshot{
+			ID:          r.ID,
+			Name:        r.Name,
+			Hoist:       r.Hoist,
+			Mentionable: r.Mentionable,
+			Managed:     r.Managed,
+			Permissions: permissionList(r.Permissions),
+		}
+		if r.Color != 0 {
+			role.Color = fmt.Sprintf("#%06x", r.Color)
+		}
+		snap.Roles = append(snap.Roles, role)
+	}
+
+	for _, c := range orderSnapshotChannels(channels) {
+		typeName, _ := snapshotChannelTypeName(c.Type)
+		ch := channelSnapshot{
+			ID:        c.ID,
+			Name:      c.Name,
+			Type:      typeName,
+			Category:  channelNames[c.ParentID],
+			Topic:     c.Topic,
+			NSFW:      c.NSFW,
+			Slowmode:  c.RateLimitPerUser,
+			Bitrate:   c.Bitrate,
+			UserLimit: c.UserLimit,
+		}
+		for _, o := range c.PermissionOverwrites {
+			ow := overwriteSnapshot{Allow: permissionList(o.Allow), Deny: permissionList(o.Deny)}
+			if o.Type == types.PermissionOverwriteMember {
+				ow.Member = o.ID
+			} else if ow.Role = roleNames[o.ID]; ow.Role == "" {
+				ow.Role = o.ID
+			}
+			ch.Permissions = append(ch.Permissions, ow)
+		}
+		snap.Channels = append(snap.Channels, ch)
+	}
+
+	for _, e := range guild.Emojis {
+		emoji := emojiSnapshot{ID: e.ID, Name: e.Name, Animated: e.Animated, URL: emojiURL(e)}
+		for _, id := range e.Roles {
+			if name := roleNames[id]; name != "" {
+				emoji.Roles = append(emoji.Roles, name)
+			} else {
+				emoji.Roles = append(emoji.Roles, id)
+			}
+		}
+		snap.Emojis = append(snap.Emojis, emoji)
+	}
+	sort.SliceStable(snap.Emojis, func(i, j int) bool { return snap.Emojis[i].Name < snap.Emojis[j].Name })
+	return snap
+}
+
+// orderSnapshotChannels returns the layout channels in display order:
+// uncategorized channels first, then each category followed by its
+// channels, each group sorted by position.
+func orderSnapshotChannels(channels []*types.Channel) []*types.Channel {
+	categoryPos := map[string]int{}
+	var layout []*types.Channel
+	for _, c := range channels {
+		if _, ok := snapshotChannelTypeName(c.Type); !ok {
+			continue
+		}
+		if c.Type == types.ChannelTypeGuildCategory {
+			categoryPos[c.ID] = c.Position
+		}
+		layout = append(layout, c)
+	}
+	group := func(c *types.Channel) (int, bool) {
+		if c.Type == types.ChannelTypeGuildCategory {
+			return c.Position, false
+		}
+		if pos, ok := categoryPos[c.ParentID]; ok {
+			return pos, true
+		}
+		return -1, true
+	}
+	sort.SliceStable(layout, func(i, j int) bool {
+		gi, ci := group(layout[i])
+		gj, cj := group(layout[j])
+		switch {
+		case gi != gj:
+			return gi < gj
+		case ci != cj:
+			return !ci
+		case layout[i].Position != layout[j].Position:
+			return layout[i].Position < layout[j].Position
+		default:
+			return layout[i].ID < layout[j].ID
+		}
+	})
+	return layout
+}
+
+// permissionList names the permissions in a bitfield string. Bits the SDK
+// has no name for are kept as one decimal entry so nothing is lost.
+func permissionList(mask string) []string {
+	p := permissions.PermissionFromString(mask)
+	names := p.Names()
+	if unknown := p.Remove(permissions.AllPermissions()); unknown != 0 {
+		names = append(names, strconv.FormatInt(int64(unknown), 10))
+	}
+	return names
+}
+
+func emojiURL(e types.Emoji) string {
+	if e.ID == "" {
+		return ""
+	}
+	ext := "png"
+	if e.Animated {
+		ext = "gif"
+	}
+	return fmt.Sprintf("https://cdn.discordapp.com/emojis/%s.%s", e.ID, ext)
+}
+
+func sortedStrings(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestGuildSnapshotExportsLayoutByName(t *testing.T) {
+	const guildID = "123456789012345678"
+	guilds := &fakeGuildService{
+		guild: &types.Guild{ID: guildID, Name: "Arc Labs", SystemChannelID: "c-general", VerificationLevel: 2,
+			Emojis: []types.Emoji{{ID: "e1", Name: "shipit", Animated: true, Roles: []string{"r-mod"}}}},
+		roles: []*types.Role{
+			{ID: guildID, Name: "@everyone", Position: 0, Permissions: "1024"},
+			{ID: "r-mod", Name: "Moderators", Position: 2, Color: 0x2ecc71, Hoist: true, Permissions: "8194"},
+			{ID: "r-bot", Name: "Arc", Position: 1, Managed: true, Permissions: "0"},
+		},
+		channels: map[string][]*types.Channel{guildID: {
+			{ID: "c-dev", Name: "dev", Type: types.ChannelTypeGuildText, ParentID: "cat-eng", Position: 1, Topic: "builds", RateLimitPerUser: 10,
+				PermissionOverwrites: []types.PermissionOverwrite{
+					{ID: guildID, Type: types.PermissionOverwriteRole, Allow: "0", Deny: "1024"},
+					{ID: "u1", Type: types.PermissionOverwriteMember, Allow: "1024", Deny: "0"},
+				}},
+			{ID: "cat-eng", Name: "Engineering", Type: types.ChannelTypeGuildCategory, Position: 0},
+			{ID: "c-general", Name: "general", Type: types.ChannelTypeGuildText, Position: 0},
+			{ID: "c-thread", Name: "a thread", Type: types.ChannelTypeGuildPublicThread},
+		}},
+	}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	out := filepath.Join(t.TempDir(), "guild.yaml")
+	cmd := guildSnapshotCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"--guild", guildID, "--out", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("guild snapshot: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snap guildSnapshot
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		t.Fatalf("decode snapshot: %v\n%s", err, data)
+	}
+
+	if snap.Guild.Name != "Arc Labs" || snap.Guild.SystemChannel != "general" || snap.Guild.VerificationLevel != 2 {
+		t.Fatalf("unexpected settings %+v", snap.Guild)
+	}
+	if len(snap.Roles) != 3 || snap.Roles[0].Name != "Moderators" || snap.Roles[2].Name != "@everyone" {
+		t.Fatalf("roles not ordered highest first: %+v", snap.Roles)
+	}
+	mod := snap.Roles[0]
+	if mod.Color != "#2ecc71" || len(mod.Permissions) != 2 || mod.Permissions[0] != "KickMembers" || mod.Permissions[1] != "ManageMessages" {
+		t.Fatalf("unexpected role %+v", mod)
+	}
+
+	var names []string
+	for _, c := range snap.Channels {
+		names = append(names, c.Name)
+	}
+	if len(names) != 3 || names[0] != "general" || names[1] != "Engineering" || names[2] != "dev" {
+		t.Fatalf("unexpected channel order %v", names)
+	}
+	dev := snap.Channels[2]
+	if dev.Category != "Engineering" || dev.Slowmode != 10 || len(dev.Permissions) != 2 {
+		t.Fatalf("unexpected channel %+v", dev)
+	}
+	if dev.Permissions[0].Role != "@everyone" || dev.Permissions[0].Deny[0] != "ViewChannel" || dev.Permissions[1].Member != "u1" {
+		t.Fatalf("unexpected overwrites %+v", dev.Permissions)
+	}
+	if len(snap.Emojis) != 1 || snap.Emojis[0].Roles[0] != "Moderators" || snap.Emojis[0].URL != "https://cdn.discordapp.com/emojis/e1.gif" {
+		t.Fatalf("unexpected emojis %+v", snap.Emojis)
+	}
+}
+
+func TestPermissionListKeepsUnknownBits(t *testing.T) {
+	got := permissionList("1125899906842632") // Administrator plus bit 50
+	if len(got) != 2 || got[0] != "Administrator" || got[1] != "1125899906842624" {
+		t.Fatalf("unexpected list %v", got)
+	}
+	if got := permissionList("0"); len(got) != 0 {
+		t.Fatalf("expected no permissions, got %v", got)
+	}
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func guildTemplateCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Create, sync, and list guild templates",
+		Long: `Guild templates capture a guild's channels, roles, permissions, and settings (not messages
+or members) so the layout can be stamped out again with "guild from-template". Requires
+MANAGE_GUILD in the source guild.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(guildTemplateCreateCmd(opts))
+	cmd.AddCommand(guildTemplateSyncCmd(opts))
+	cmd.AddCommand(guildTemplateListCmd(opts))
+	return cmd
+}
+
+func guildTemplateCreateCmd(opts *globalOptions) *cobra.Command {
+	var guildRef string
+	var params types.GuildTemplateParams
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Snapshot a guild into a new template",
+		Long:  `Snapshot a guild into a new template. Discord allows one template per guild.`,
+		Example: `Example:
+  arc-discord guild template create --guild "Project Base" --name "Project server" --description "Channels and roles for a new project"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if params.Name == "" {
+				return &arcer.CLIError{Msg: "--name is required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			template, err := bot.Guilds().CreateGuildTemplate(ctx, guildID, &params)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to create template for guild %s", guildID), Hint: "a guild can only have one template; use 'guild template sync' to update it"}).WithCause(err)
+			}
+			return renderGuildTemplates(cmd, opts, template, []*types.GuildTemplate{template})
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Source guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&params.Name, "name", "", "Template name (1-100 characters)")
+	cmd.Flags().StringVar(&params.Description, "description", "", "Template description (up to 120 characters)")
+	return cmd
+}
+
+func guildTemplateSyncCmd(opts *globalOptions) *cobra.Command {
+	var guildRef, code string
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Update templates to the guild's current layout",
+		Long: `Update a template to match the source guild's current channels, roles, and settings. Without
+--code every template of the guild with unsynced changes is synced.`,
+		Example: `Example:
+  arc-discord guild template sync --guild "Project Base"
+
+Example:
+  arc-discord guild template sync --guild "Project Base" --code hgM48av5Q69A`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			codes := []string{types.TemplateCode(code)}
+			if code == "" {
+				templates, err := bot.Guilds().GetGuildTemplates(ctx, guildID)
+				if err != nil {
+					return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list templates for guild %s", guildID)}).WithCause(err)
+				}
+				codes = codes[:0]
+				for _, template := range templates {
+					if template.IsDirty {
+						codes = append(codes, template.Code)
+					}
+				}
+				if len(codes) == 0 {
+					cmd.Printf("Templates for guild %s are up to date\n", guildID)
+					return nil
+				}
+			}
+			for _, c := range codes {
+				if _, err := bot.Guilds().SyncGuildTemplate(ctx, guildID, c); err != nil {
+					return (&arcer.CLIError{Msg: fmt.Sprintf("failed to sync template %s", c)}).WithCause(err)
+				}
+				cmd.Printf("Template %s synced from guild %s\n", c, guildID)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Source guild ID or name (optional if default_guild_id set in config)")
+	cmd.Flags().StringVar(&code, "code", "", "Template code or discord.new link (default: all templates with changes)")
+	return cmd
+}
+
+func guildTemplateListCmd(opts *globalOptions) *cobra.Command {
+	var guildRef string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List a guild's templates",
+		Example: `Example:
+  arc-discord guild template list --guild "Project Base"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			bot, ctx, cancel, guildID, err := guildTarget(cmd, opts, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			templates, err := bot.Guilds().GetGuildTemplates(ctx, guildID)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list templates for guild %s", guildID)}).WithCause(err)
+			}
+			return renderGuildTemplates(cmd, opts, templates, templates)
+		},
+	}
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Source guild ID or name (optional if default_guild_id set in config)")
+	return cmd
+}
+
+func renderGuildTemplates(cmd *cobra.Command, opts *globalOptions, data any, templates []*types.GuildTemplate) error {
+	table := &tableData{headers: []string{"CODE", "NAME", "USES", "CHANNELS", "ROLES", "SYNCED", "URL"}}
+	for _, t := range templates {
+		channels, roles := "", ""
+		if src := t.SerializedSourceGuild; src != nil {
+			channels, roles = strconv.Itoa(len(src.Channels)), strconv.Itoa(len(src.Roles))
+		}
+		table.rows = append(table.rows, []string{t.Code, t.Name, strconv.Itoa(t.UsageCount), channels, roles, strconv.FormatBool(!t.IsDirty), t.URL()})
+	}
+	return renderOutput(cmd, opts.output, data, table)
+}
+
+func guildFromTemplateCmd(opts *globalOptions) *cobra.Command {
+	var (
+		params   types.GuildFromTemplateParams
+		iconPath string
+	)
+	cmd := &cobra.Command{
+		Use:   "from-template <code|link>",
+		Short: "Create a new guild from a template",
+		Long: `Create a new guild from a template code or discord.new link. The bot owns the new guild, so
+invite yourself and transfer ownership afterwards. Discord only lets bots in fewer than 10 guilds
+create guilds.`,
+		Example: `Example:
+  arc-discord guild from-template hgM48av5Q69A --name "Project Atlas"
+
+Example:
+  arc-discord guild from-template https://discord.new/hgM48av5Q69A --name "Project Atlas" --icon atlas.png`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if params.Name == "" {
+				return &arcer.CLIError{Msg: "--name is required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			if iconPath != "" {
+				icon, err := imageDataURI(iconPath)
+				if err != nil {
+					return err
+				}
+				params.Icon = icon
+			}
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			code := types.TemplateCode(args[0])
+			guild, err := bot.Guilds().CreateGuildFromTemplate(ctx, code, &params)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to create guild from template %s", code), Hint: "bots in 10 or more guilds cannot create guilds"}).WithCause(err)
+			}
+			table := keyValueTable(map[string]string{
+				"id":       guild.ID,
+				"name":     guild.Name,
+				"owner_id": guild.OwnerID,
+				"template": code,
+			})
+			return renderOutput(cmd, opts.output, guild, table)
+		},
+	}
+	cmd.Flags().StringVar(&params.Name, "name", "", "Name of the new guild (2-100 characters)")
+	cmd.Flags().StringVar(&iconPath, "icon", "", "PNG, JPEG, or GIF file for the guild icon")
+	return cmd
+}
+
+// imageDataURI reads an image file into the data URI form Discord accepts
+// for icons.
+func imageDataURI(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+	}
+	mime := http.DetectContentType(data)
+	switch mime {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return "", &arcer.CLIError{Msg: fmt.Sprintf("%s is %s, not a PNG, JPEG, or GIF image", path, strings.Split(mime, ";")[0])}
+	}
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
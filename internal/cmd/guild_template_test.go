@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestGuildTemplateSyncDefaultsToDirtyTemplates(t *testing.T) {
+	guilds := &fakeGuildService{templates: []*types.GuildTemplate{
+		{Code: "clean"},
+		{Code: "dirty", IsDirty: true},
+	}}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	var out bytes.Buffer
+	cmd := guildTemplateSyncCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--guild", "123456789012345678"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("template sync: %v", err)
+	}
+	if len(guilds.synced) != 1 || guilds.synced[0] != "dirty" {
+		t.Fatalf("expected only the dirty template synced, got %v", guilds.synced)
+	}
+}
+
+func TestGuildFromTemplateAcceptsLinkAndIcon(t *testing.T) {
+	guilds := &fakeGuildService{}
+	hookBot(t, testConfig(), &fakeBotClient{guildSvc: guilds})
+
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	if err := os.WriteFile(icon, png, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	cmd := guildFromTemplateCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"https://discord.new/hgM48av5Q69A", "--name", "Project Atlas", "--icon", icon})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("from-template: %v", err)
+	}
+	if guilds.templateCode != "hgM48av5Q69A" || guilds.fromTemplate.Name != "Project Atlas" {
+		t.Fatalf("unexpected call code=%q params=%+v", guilds.templateCode, guilds.fromTemplate)
+	}
+	if !strings.HasPrefix(guilds.fromTemplate.Icon, "data:image/png;base64,") {
+		t.Fatalf("icon not encoded: %q", guilds.fromTemplate.Icon)
+	}
+	if !strings.Contains(out.String(), "new-guild") {
+		t.Fatalf("expected new guild in output, got %q", out.String())
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestLoadInteractionSettingsMiddleware(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	yaml := `
+interactions:
+  enabled: true
+  middleware:
+    - name: log
+  handlers:
+    commands:
+      deploy:
+        agent: ops
+        middleware:
+          - name: guilds
+            allow: ["1427555325136867393"]
+          - name: rate_limit
+            limit: 3
+            per: 1m
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	bindings := collectHandlerBindings(settings.Interactions)
+	if len(bindings) != 1 {
+		t.Fatalf("expected 1 binding, got %d", len(bindings))
+	}
+	mw := bindings[0].Middleware
+	if len(mw) != 3 || mw[0].Name != "log" || mw[1].Name != "guilds" || mw[2].Name != "rate_limit" {
+		t.Fatalf("unexpected middleware %+v", mw)
+	}
+	if mw[2].Options["per"] != "1m" || mw[2].Options["limit"] != 3 {
+		t.Fatalf("unexpected rate_limit options %+v", mw[2].Options)
+	}
+	if _, err := buildMiddleware(bindings[0]); err != nil {
+		t.Fatalf("build middleware: %v", err)
+	}
+}
+
+func TestServerMiddlewareRejectsBeforePublishing(t *testing.T) {
+	cfg := interactionsConfig{
+		Enabled: true,
+		Timeout: time.Second,
+		Handlers: handlerMappings{
+			Commands: map[string]handlerRoute{
+				"deploy": {Agent: "ops", Middleware: []middlewareConfig{{Name: "rate_limit", Options: map[string]any{"limit": 1, "per": "1h"}}}},
+			},
+		},
+	}
+	srv, priv, publisher := newServerWithConfig(t, cfg)
+
+	body, _ := json.Marshal(map[string]any{
+		"type":   types.InteractionTypeApplicationCommand,
+		"token":  "t",
+		"id":     "1",
+		"member": map[string]any{"user": map[string]any{"id": "u1"}},
+		"data":   map[string]any{"name": "deploy"},
+	})
+	var resp types.InteractionResponse
+	for n := 0; n < 2; n++ {
+		rec := httptest.NewRecorder()
+		srv.HandleInteraction(rec, signedRequest(t, priv, body))
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	if len(publisher.envelopes) != 1 {
+		t.Fatalf("expected the limited call not to publish, got %d envelopes", len(publisher.envelopes))
+	}
+	if resp.Type != types.InteractionResponseChannelMessageWithSource || !strings.Contains(resp.Data.Content, "too often") {
+		t.Fatalf("expected rate limit reply, got %+v", resp.Data)
+	}
+}
+
+func TestRegisterInteractionHandlersRejectsUnknownMiddleware(t *testing.T) {
+	cfg := interactionsConfig{
+		Enabled:    true,
+		Middleware: []middlewareConfig{{Name: "audit"}},
+		Handlers:   handlerMappings{Commands: map[string]handlerRoute{"deploy": {Agent: "ops"}}},
+	}
+	_, err := interactionServerBuilder{PublicKey: strings.Repeat("ab", 32)}.build(time.Second, collectHandlerBindings(cfg))
+	if err == nil || !strings.Contains(err.Error(), `unknown middleware "audit"`) {
+		t.Fatalf("expected unknown middleware error, got %v", err)
+	}
+}
+
+func TestServerRequireRolesRejectsWithoutPublishing(t *testing.T) {
+	cfg := interactionsConfig{
+		Enabled: true,
+		Timeout: time.Second,
+		Handlers: handlerMappings{
+			Commands: map[string]handlerRoute{
+				"deploy": {Agent: "ops", RequireRoles: []string{"role-ops"}, RequirePermissions: []string{"manage_guild"}, UnauthorizedMessage: "Ops only."},
+			},
+		},
+	}
+	srv, priv, publisher := newServerWithConfig(t, cfg)
+
+	send := func(roles []string, perms string) types.InteractionResponse {
+		body, _ := json.Marshal(map[string]any{
+			"type":   types.InteractionTypeApplicationCommand,
+			"token":  "t",
+			"id":     "1",
+			"member": map[string]any{"user": map[string]any{"id": "u1"}, "roles": roles, "permissions": perms},
+			"data":   map[string]any{"name": "deploy"},
+		})
+		rec := httptest.NewRecorder()
+		srv.HandleInteraction(rec, signedRequest(t, priv, body))
+		var resp types.InteractionResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return resp
+	}
+
+	if resp := send([]string{"role-dev"}, "32"); resp.Data == nil || resp.Data.Content != "Ops only." || resp.Data.Flags != types.MessageFlagEphemeral {
+		t.Fatalf("expected ephemeral rejection, got %+v", resp.Data)
+	}
+	if resp := send([]string{"role-ops"}, "0"); resp.Data == nil || resp.Data.Content != "Ops only." {
+		t.Fatalf("expected rejection without manage_guild, got %+v", resp.Data)
+	}
+	if len(publisher.envelopes) != 0 {
+		t.Fatalf("rejected interactions should not publish, got %d", len(publisher.envelopes))
+	}
+	if resp := send([]string{"role-ops"}, "32"); resp.Type != types.InteractionResponseDeferredChannelMessageWithSource || len(publisher.envelopes) != 1 {
+		t.Fatalf("expected authorized member to dispatch, got %+v", resp)
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// regexKeyPrefix marks a component or modal handler key as a regular
+// expression, e.g. "re:^approve:(PR-\d+)$".
+const regexKeyPrefix = "re:"
+
+// handlerKeyPattern compiles a component or modal handler key that matches
+// more than one custom ID. Keys with a "*" match any run of characters there
+// ("approve:*"); keys starting with "re:" are regular expressions. Other keys
+// are exact custom IDs and return nil.
+func handlerKeyPattern(key string) (*regexp.Regexp, error) {
+	if expr, ok := strings.CutPrefix(key, regexKeyPrefix); ok {
+		return regexp.Compile(expr)
+	}
+	if !strings.Contains(key, "*") {
+		return nil, nil
+	}
+	parts := strings.Split(key, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.Compile("^" + strings.Join(parts, "(.*)") + "$")
+}
+
+// captures returns the custom ID parts matched by the binding's wildcards or
+// regex groups.
+func (b handlerBinding) captures(i *types.Interaction) []string {
+	if b.Pattern == nil || i.Data == nil {
+		return nil
+	}
+	match := b.Pattern.FindStringSubmatch(i.Data.CustomID)
+	if len(match) < 2 {
+		return nil
+	}
+	return match[1:]
+}
+
+// orderBindings sorts bindings so that, among patterns, longer (usually more
+// specific) keys are tried first: approve:pr-* before approve:*.
+func orderBindings(bindings []handlerBinding) []handlerBinding {
+	ordered := append([]handlerBinding(nil), bindings...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if len(ordered[i].Key) != len(ordered[j].Key) {
+			return len(ordered[i].Key) > len(ordered[j].Key)
+		}
+		return ordered[i].Key < ordered[j].Key
+	})
+	return ordered
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestHandlerKeyPattern(t *testing.T) {
+	cases := []struct {
+		key, customID string
+		want          []string
+	}{
+		{"approve:*", "approve:PR-1234", []string{"PR-1234"}},
+		{"vote:*:*", "vote:42:up", []string{"42", "up"}},
+		{`re:^deploy:(staging|prod)$`, "deploy:prod", []string{"prod"}},
+	}
+	for _, tc := range cases {
+		pattern, err := handlerKeyPattern(tc.key)
+		if err != nil || pattern == nil {
+			t.Fatalf("%s: expected pattern, got %v", tc.key, err)
+		}
+		binding := handlerBinding{Kind: handlerKindComponent, Key: tc.key, Pattern: pattern}
+		got := binding.captures(&types.Interaction{Data: &types.InteractionData{CustomID: tc.customID}})
+		if len(got) != len(tc.want) || got[0] != tc.want[0] {
+			t.Fatalf("%s: captures %v, want %v", tc.key, got, tc.want)
+		}
+	}
+	if pattern, err := handlerKeyPattern("confirm.primary"); pattern != nil || err != nil {
+		t.Fatalf("exact key should not be a pattern: %v %v", pattern, err)
+	}
+	if _, err := handlerKeyPattern("re:approve:("); err == nil {
+		t.Fatal("expected error for invalid regex")
+	}
+}
+
+func TestServerComponentWildcardPublishesCaptures(t *testing.T) {
+	cfg := interactionsConfig{
+		Enabled: true,
+		Timeout: time.Second,
+		Handlers: handlerMappings{
+			Components: map[string]handlerRoute{
+				"approve:*":    {Agent: "reviewer"},
+				"approve:pr-*": {Agent: "codex"},
+				"approve:now":  {Agent: "ops"},
+			},
+		},
+	}
+	srv, priv, publisher := newServerWithConfig(t, cfg)
+
+	cases := []struct {
+		customID, agent, capture string
+	}{
+		{"approve:pr-1234", "codex", "1234"},
+		{"approve:RFC-9", "reviewer", "RFC-9"},
+		{"approve:now", "ops", ""},
+	}
+	for _, tc := range cases {
+		body, _ := json.Marshal(map[string]any{
+			"type":  types.InteractionTypeMessageComponent,
+			"token": "component-token",
+			"id":    "321",
+			"data":  map[string]any{"custom_id": tc.customID},
+		})
+		publisher.envelopes = nil
+		srv.HandleInteraction(httptest.NewRecorder(), signedRequest(t, priv, body))
+		if len(publisher.envelopes) != 1 || publisher.envelopes[0].Agent != tc.agent {
+			t.Fatalf("%s: expected dispatch to %s, got %+v", tc.customID, tc.agent, publisher.envelopes)
+		}
+		got := publisher.envelopes[0].Captures
+		if (tc.capture == "" && len(got) != 0) || (tc.capture != "" && (len(got) != 1 || got[0] != tc.capture)) {
+			t.Fatalf("%s: captures %v, want %q", tc.customID, got, tc.capture)
+		}
+	}
+}
+
+func TestRegisterInteractionHandlersRejectsInvalidPattern(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	srv, err := interactions.NewServer(hex.EncodeToString(pub))
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	bindings := []handlerBinding{{Kind: handlerKindModal, Key: "re:feedback:(", Route: handlerRoute{Agent: "codex"}}}
+	if err := registerInteractionHandlers(srv, time.Second, &stubPublisher{}, bindings); err == nil {
+		t.Fatal("expected error for invalid modal pattern")
+	}
+}
@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/yourorg/arc-discord/gosdk/broker"
 	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 )
@@ -18,7 +19,6 @@ const (
 	handlerKindComponent    = "component"
 	handlerKindModal        = "modal"
 	handlerKindAutocomplete = "autocomplete"
-	redisPublishTimeout     = 5 * time.Second
 )
 
 type handlerBinding struct {
@@ -26,11 +26,18 @@ type handlerBinding struct {
 	Key                 string
 	Route               handlerRoute
 	AutocompleteChoices []types.AutocompleteChoice
-}
-
-type interactionPublisher interface {
-	Publish(ctx context.Context, env *redisEnvelope) error
-	Close() error
+	// Suggestions backs redis_key autocomplete sources.
+	Suggestions suggestionStore
+	// Pattern is set for component and modal keys with wildcards or a regex.
+	Pattern *regexp.Regexp
+	// Middleware is the interactions-wide middleware followed by the route's.
+	Middleware []middlewareConfig
+	// Rules are the interactions.rules, run before dispatching to an agent.
+	Rules []routingRule
+	// Script is the parsed Route.Script.
+	Script *handlerScript
+	// Wasm is the compiled Route.Wasm module.
+	Wasm *wasmHandler
 }
 
 func collectHandlerBindings(cfg interactionsConfig) []handlerBinding {
@@ -40,38 +47,44 @@ func collectHandlerBindings(cfg interactionsConfig) []handlerBinding {
 	total := len(cfg.Handlers.Commands) + len(cfg.Handlers.Components) + len(cfg.Handlers.Modals) + len(cfg.Handlers.Autocomplete)
 	bindings := make([]handlerBinding, 0, total)
 	for key, route := range cfg.Handlers.Commands {
-		if route.Agent == "" {
+		if !route.answered() {
 			continue
 		}
 		bindings = append(bindings, handlerBinding{
-			Kind:  handlerKindCommand,
-			Key:   strings.ToLower(key),
-			Route: route,
+			Kind:       handlerKindCommand,
+			Key:        strings.ToLower(key),
+			Route:      route,
+			Middleware: routeMiddleware(cfg, route),
+			Rules:      cfg.Rules,
 		})
 	}
 	for key, route := range cfg.Handlers.Components {
-		if route.Agent == "" {
+		if !route.answered() {
 			continue
 		}
 		bindings = append(bindings, handlerBinding{
-			Kind:  handlerKindComponent,
-			Key:   key,
-			Route: route,
+			Kind:       handlerKindComponent,
+			Key:        key,
+			Route:      route,
+			Middleware: routeMiddleware(cfg, route),
+			Rules:      cfg.Rules,
 		})
 	}
 	for key, route := range cfg.Handlers.Modals {
-		if route.Agent == "" {
+		if !route.answered() {
 			continue
 		}
 		bindings = append(bindings, handlerBinding{
-			Kind:  handlerKindModal,
-			Key:   key,
-			Route: route,
+			Kind:       handlerKindModal,
+			Key:        key,
+			Route:      route,
+			Middleware: routeMiddleware(cfg, route),
+			Rules:      cfg.Rules,
 		})
 	}
 	for key, route := range cfg.Handlers.Autocomplete {
 		choices := buildAutocompleteChoices(route.Choices)
-		if len(choices) == 0 {
+		if len(choices) == 0 && route.Source == nil {
 			continue
 		}
 		bindings = append(bindings, handlerBinding{
@@ -79,27 +92,74 @@ func collectHandlerBindings(cfg interactionsConfig) []handlerBinding {
 			Key:                 strings.ToLower(key),
 			Route:               route,
 			AutocompleteChoices: choices,
+			Middleware:          routeMiddleware(cfg, route),
 		})
 	}
 	return bindings
 }
 
-func registerInteractionHandlers(srv *interactions.Server, timeout time.Duration, publisher interactionPublisher, bindings []handlerBinding) error {
+// routeMiddleware lists the interactions-wide middleware, the route's own,
+// then its access checks, which run last so the other middleware (logging,
+// rate limits) still sees rejected interactions.
+func routeMiddleware(cfg interactionsConfig, route handlerRoute) []middlewareConfig {
+	var chain []middlewareConfig
+	chain = append(chain, cfg.Middleware...)
+	chain = append(chain, route.Middleware...)
+	if len(route.RequireRoles) > 0 {
+		chain = append(chain, middlewareConfig{Name: "roles", Options: map[string]any{"any": route.RequireRoles, "message": route.UnauthorizedMessage}})
+	}
+	if len(route.RequirePermissions) > 0 {
+		chain = append(chain, middlewareConfig{Name: "permissions", Options: map[string]any{"require": route.RequirePermissions, "message": route.UnauthorizedMessage}})
+	}
+	return chain
+}
+
+// buildMiddleware constructs the binding's middleware from the interactions
+// registry.
+func buildMiddleware(binding handlerBinding) ([]interactions.Middleware, error) {
+	chain := make([]interactions.Middleware, 0, len(binding.Middleware))
+	for _, mc := range binding.Middleware {
+		m, err := interactions.NewMiddleware(mc.Name, mc.Options)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, m)
+	}
+	return chain, nil
+}
+
+func registerInteractionHandlers(srv *interactions.Server, timeout time.Duration, publisher broker.Publisher, bindings []handlerBinding) error {
 	if srv == nil {
 		return errors.New("interaction server is not initialized")
 	}
 	if len(bindings) == 0 {
 		return errors.New("no interaction handlers configured (set interactions.handlers in discord.yaml)")
 	}
-	for _, binding := range bindings {
-		handler := dispatchHandler(binding, timeout, publisher)
+	for _, binding := range orderBindings(bindings) {
+		binding, chain, err := prepareBinding(binding)
+		if err != nil {
+			return fmt.Errorf("%s handler %s: %w", binding.Kind, binding.Key, err)
+		}
+		handler := interactions.Chain(dispatchHandler(binding, timeout, publisher), chain...)
 		switch binding.Kind {
 		case handlerKindCommand:
 			srv.RegisterCommand(binding.Key, handler)
 		case handlerKindComponent:
-			srv.RegisterComponent(binding.Key, handler)
+			if binding.Pattern != nil {
+				if err := srv.RegisterComponentPattern(binding.Pattern.String(), handler); err != nil {
+					return fmt.Errorf("component handler %s: %w", binding.Key, err)
+				}
+			} else {
+				srv.RegisterComponent(binding.Key, handler)
+			}
 		case handlerKindModal:
-			srv.RegisterModal(binding.Key, handler)
+			if binding.Pattern != nil {
+				if err := srv.RegisterModalPattern(binding.Pattern.String(), handler); err != nil {
+					return fmt.Errorf("modal handler %s: %w", binding.Key, err)
+				}
+			} else {
+				srv.RegisterModal(binding.Key, handler)
+			}
 		case handlerKindAutocomplete:
 			srv.RegisterAutocomplete(binding.Key, handler)
 		default:
@@ -109,31 +169,150 @@ func registerInteractionHandlers(srv *interactions.Server, timeout time.Duration
 	return nil
 }
 
-func dispatchHandler(binding handlerBinding, timeout time.Duration, publisher interactionPublisher) interactions.Handler {
+// prepareBinding compiles the binding's key pattern, script, and wasm module
+// and checks its initial response or autocomplete source, returning the built
+// middleware chain.
+func prepareBinding(binding handlerBinding) (handlerBinding, []interactions.Middleware, error) {
+	if binding.Kind == handlerKindComponent || binding.Kind == handlerKindModal {
+		pattern, err := handlerKeyPattern(binding.Key)
+		if err != nil {
+			return binding, nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		binding.Pattern = pattern
+	}
+	if binding.Route.Script != "" && binding.Route.Wasm != nil {
+		return binding, nil, errors.New("routes take a script or a wasm module, not both")
+	}
+	if binding.Route.Script != "" {
+		if binding.Kind == handlerKindAutocomplete {
+			return binding, nil, errors.New("autocomplete routes cannot have a script")
+		}
+		if binding.Route.Agent != "" || binding.Route.InitialResponse != nil || binding.Route.Respond != nil {
+			return binding, nil, errors.New("script routes answer in the server and take no agent, initial_response, or respond")
+		}
+		script, err := parseHandlerScript(binding.Route.Script)
+		if err != nil {
+			return binding, nil, err
+		}
+		binding.Script = script
+		if err := validateRoutingRules(binding.Rules); err != nil {
+			return binding, nil, err
+		}
+	} else if binding.Route.Wasm != nil {
+		if binding.Kind == handlerKindAutocomplete {
+			return binding, nil, errors.New("autocomplete routes cannot have a wasm module")
+		}
+		if binding.Route.Agent != "" || binding.Route.InitialResponse != nil || binding.Route.Respond != nil {
+			return binding, nil, errors.New("wasm routes answer in the server and take no agent, initial_response, or respond")
+		}
+		wasm, err := compileWasmHandler(binding.Route.Wasm)
+		if err != nil {
+			return binding, nil, err
+		}
+		binding.Wasm = wasm
+		if err := validateRoutingRules(binding.Rules); err != nil {
+			return binding, nil, err
+		}
+	} else if binding.Kind != handlerKindAutocomplete {
+		if _, err := binding.initialResponse(); err != nil {
+			return binding, nil, err
+		}
+		if _, err := binding.renderTemplates(nil); err != nil {
+			return binding, nil, fmt.Errorf("template: %w", err)
+		}
+		if err := validateRoutingRules(binding.Rules); err != nil {
+			return binding, nil, err
+		}
+	} else if binding.Route.Source != nil {
+		if err := binding.Route.Source.validate(); err != nil {
+			return binding, nil, err
+		}
+	}
+	chain, err := buildMiddleware(binding)
+	if err != nil {
+		return binding, nil, err
+	}
+	return binding, chain, nil
+}
+
+func dispatchHandler(binding handlerBinding, timeout time.Duration, publisher broker.Publisher) interactions.Handler {
 	if binding.Kind == handlerKindAutocomplete {
 		return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
-			if len(binding.AutocompleteChoices) == 0 {
+			query := newAutocompleteQuery(i)
+			choices := staticAutocompleteChoices(binding.AutocompleteChoices, query.Value)
+			if src := binding.Route.Source; src != nil {
+				// Static choices, when configured, stand in for a source
+				// that fails or has nothing to suggest.
+				live, err := src.fetch(ctx, binding.Suggestions, query)
+				if err != nil && len(choices) == 0 {
+					return nil, fmt.Errorf("autocomplete handler %s: %w", binding.Key, err)
+				}
+				if len(live) > 0 {
+					choices = live
+				}
+			}
+			if len(choices) == 0 {
 				return nil, fmt.Errorf("autocomplete handler %s missing choices", binding.Key)
 			}
-			return buildAutocompleteResponse(binding.AutocompleteChoices)
+			return buildAutocompleteResponse(choices)
 		}
 	}
 	return func(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
-		if binding.Route.Agent == "" {
+		binding := binding
+		outcome := applyRoutingRules(binding, i)
+		if outcome.Drop {
+			return buildDropResponse(outcome.Message)
+		}
+		if outcome.Agent != "" {
+			// A rule sending a static route to an agent makes it a
+			// regular agent route.
+			binding.Route.Agent = outcome.Agent
+			binding.Route.Respond = nil
+		}
+		if !binding.Route.answered() {
 			return nil, fmt.Errorf("interaction handler %s missing agent routing", binding.Key)
 		}
-		payload, err := newRedisEnvelope(binding, timeout, i)
+		if sku := binding.Route.PremiumSKU; sku != "" && !hasEntitlement(i, sku, time.Now()) {
+			return buildPremiumRequiredResponse(sku, binding.Route.PremiumMessage)
+		}
+		if binding.Script != nil && binding.Route.Agent == "" {
+			resp, err := binding.Script.respond(i, binding.Route.Ephemeral)
+			if err != nil {
+				return nil, fmt.Errorf("interaction handler %s: %w", binding.Key, err)
+			}
+			return resp, nil
+		}
+		if binding.Wasm != nil && binding.Route.Agent == "" {
+			resp, err := binding.Wasm.respond(ctx, i)
+			if err != nil {
+				return nil, fmt.Errorf("interaction handler %s: %w", binding.Key, err)
+			}
+			return resp, nil
+		}
+		binding, err := binding.renderTemplates(i)
+		if err != nil {
+			return nil, fmt.Errorf("interaction handler %s: %w", binding.Key, err)
+		}
+		resp, err := binding.initialResponse()
+		if err != nil {
+			return nil, err
+		}
+		if binding.Route.Agent == "" {
+			return resp, nil
+		}
+		payload, err := newEnvelope(binding, timeout, i)
 		if err != nil {
 			return nil, err
 		}
+		payload.RequestID = requestIDFrom(ctx)
 		if err := publisher.Publish(ctx, payload); err != nil {
 			return nil, err
 		}
-		return buildDeferredResponse()
+		return resp, nil
 	}
 }
 
-func newRedisEnvelope(binding handlerBinding, timeout time.Duration, interaction *types.Interaction) (*redisEnvelope, error) {
+func newEnvelope(binding handlerBinding, timeout time.Duration, interaction *types.Interaction) (*broker.Envelope, error) {
 	if interaction == nil {
 		return nil, errors.New("interaction payload is nil")
 	}
@@ -141,7 +320,8 @@ func newRedisEnvelope(binding handlerBinding, timeout time.Duration, interaction
 	if err != nil {
 		return nil, fmt.Errorf("encode interaction: %w", err)
 	}
-	env := &redisEnvelope{
+	env := &broker.Envelope{
+		Version:        broker.EnvelopeVersion,
 		Agent:          binding.Route.Agent,
 		Kind:           binding.Kind,
 		Key:            binding.Key,
@@ -149,18 +329,49 @@ func newRedisEnvelope(binding handlerBinding, timeout time.Duration, interaction
 		ReceivedAt:     time.Now().UTC(),
 		TimeoutSeconds: int(timeout.Seconds()),
 		Source:         "vibe.discord.server",
+		Ephemeral:      binding.Route.Ephemeral,
+		Captures:       binding.captures(interaction),
 	}
 	return env, nil
 }
 
-func buildDeferredResponse() (*types.InteractionResponse, error) {
-	resp, err := interactions.NewDeferredResponse().Build()
+func buildDeferredResponse(ephemeral bool) (*types.InteractionResponse, error) {
+	resp, err := interactions.NewDeferredResponse().SetEphemeral(ephemeral).Build()
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
+const defaultPremiumMessage = "This requires a premium subscription."
+
+// buildPremiumRequiredResponse replies privately with a button that opens the
+// purchase flow for skuID.
+func buildPremiumRequiredResponse(skuID, message string) (*types.InteractionResponse, error) {
+	if message == "" {
+		message = defaultPremiumMessage
+	}
+	button, err := interactions.NewPremiumButton(skuID).Build()
+	if err != nil {
+		return nil, err
+	}
+	return interactions.NewMessageResponse(message).
+		AddComponentRow(&types.ActionRow{Components: []types.Component{button}}).
+		SetEphemeral(true).
+		Build()
+}
+
+// hasEntitlement reports whether the interaction carries an active
+// entitlement to skuID for the invoking user or guild.
+func hasEntitlement(i *types.Interaction, skuID string, now time.Time) bool {
+	for _, e := range i.Entitlements {
+		if e.SKUID == skuID && e.Active(now) {
+			return true
+		}
+	}
+	return false
+}
+
 func buildAutocompleteResponse(choices []types.AutocompleteChoice) (*types.InteractionResponse, error) {
 	resp := &types.InteractionResponse{
 		Type: types.InteractionResponseAutocompleteResult,
@@ -190,43 +401,3 @@ func buildAutocompleteChoices(raw []autocompleteChoice) []types.AutocompleteChoi
 	}
 	return choices
 }
-
-type redisPublisher struct {
-	client *redis.Client
-	prefix string
-}
-
-func newRedisPublisher(cfg redisConfig) (*redisPublisher, error) {
-	client := redis.NewClient(newRedisOptions(cfg))
-	ctx, cancel := context.WithTimeout(context.Background(), redisPublishTimeout)
-	defer cancel()
-	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("connect redis: %w", err)
-	}
-	prefix := normalizeChannelPrefix(cfg.ChannelPrefix)
-	return &redisPublisher{client: client, prefix: prefix}, nil
-}
-
-func (p *redisPublisher) Publish(ctx context.Context, env *redisEnvelope) error {
-	if env == nil {
-		return errors.New("missing envelope")
-	}
-	if strings.TrimSpace(env.Agent) == "" {
-		return errors.New("envelope missing agent")
-	}
-	payload, err := json.Marshal(env)
-	if err != nil {
-		return fmt.Errorf("encode envelope: %w", err)
-	}
-	channel := fmt.Sprintf("%s:agent:%s", p.prefix, strings.ToLower(env.Agent))
-	pubCtx, cancel := context.WithTimeout(ctx, redisPublishTimeout)
-	defer cancel()
-	if err := p.client.Publish(pubCtx, channel, payload).Err(); err != nil {
-		return fmt.Errorf("publish redis channel %s: %w", channel, err)
-	}
-	return nil
-}
-
-func (p *redisPublisher) Close() error {
-	return p.client.Close()
-}
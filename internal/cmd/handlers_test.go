@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/yourorg/arc-discord/gosdk/broker"
 	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 )
@@ -82,6 +83,55 @@ func TestDispatchHandlerAutocomplete(t *testing.T) {
 	}
 }
 
+func TestDispatchHandlerPremiumSKU(t *testing.T) {
+	pub := &stubPublisher{}
+	binding := handlerBinding{
+		Kind:  handlerKindCommand,
+		Key:   "render",
+		Route: handlerRoute{Agent: "renderer", PremiumSKU: "sku-1"},
+	}
+	handler := dispatchHandler(binding, 0, pub)
+	interaction := &types.Interaction{Type: types.InteractionTypeApplicationCommand, Data: &types.InteractionData{Name: "render"}}
+
+	resp, err := handler(context.Background(), interaction)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if len(pub.envelopes) != 0 || resp.Type != types.InteractionResponseChannelMessageWithSource {
+		t.Fatalf("expected premium prompt without dispatch, got %d envelopes and %+v", len(pub.envelopes), resp)
+	}
+	button := resp.Data.Components[0].Components[0]
+	if button.Style != int(types.ButtonStylePremium) || button.SKUID != "sku-1" || resp.Data.Flags == 0 {
+		t.Fatalf("unexpected premium response %+v", resp.Data)
+	}
+
+	ended := time.Now().Add(-time.Hour)
+	interaction.Entitlements = []types.Entitlement{{SKUID: "sku-1", EndsAt: &ended}}
+	if resp, _ := handler(context.Background(), interaction); resp.Type == types.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatal("expired entitlement should not unlock the handler")
+	}
+	interaction.Entitlements = []types.Entitlement{{SKUID: "sku-1"}}
+	resp, err = handler(context.Background(), interaction)
+	if err != nil || resp.Type != types.InteractionResponseDeferredChannelMessageWithSource || len(pub.envelopes) != 1 {
+		t.Fatalf("expected dispatch with entitlement, got %+v, %v", resp, err)
+	}
+}
+
+func TestDispatchHandlerEphemeral(t *testing.T) {
+	pub := &stubPublisher{}
+	binding := handlerBinding{Kind: handlerKindCommand, Key: "status", Route: handlerRoute{Agent: "ops", Ephemeral: true}}
+	resp, err := dispatchHandler(binding, 0, pub)(context.Background(), &types.Interaction{Type: types.InteractionTypeApplicationCommand})
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if resp.Data == nil || resp.Data.Flags&types.MessageFlagEphemeral == 0 {
+		t.Fatalf("expected ephemeral deferred response, got %+v", resp.Data)
+	}
+	if len(pub.envelopes) != 1 || !pub.envelopes[0].Ephemeral {
+		t.Fatalf("expected envelope marked ephemeral, got %+v", pub.envelopes)
+	}
+}
+
 func TestRegisterInteractionHandlersRequiresBindings(t *testing.T) {
 	pub, _, err := ed25519.GenerateKey(nil)
 	if err != nil {
@@ -99,5 +149,5 @@ func TestRegisterInteractionHandlersRequiresBindings(t *testing.T) {
 
 type noopPublisher struct{}
 
-func (noopPublisher) Publish(context.Context, *redisEnvelope) error { return nil }
+func (noopPublisher) Publish(context.Context, *broker.Envelope) error { return nil }
 func (noopPublisher) Close() error                                  { return nil }
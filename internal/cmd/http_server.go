@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// validateServerLimits rejects negative timeouts and body limits, which
+// net/http would otherwise read as "no limit".
+func validateServerLimits(cfg serverConfig) error {
+	for name, d := range map[string]int64{
+		"server.read_timeout":  int64(cfg.ReadTimeout),
+		"server.write_timeout": int64(cfg.WriteTimeout),
+		"server.idle_timeout":  int64(cfg.IdleTimeout),
+	} {
+		if d < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	if cfg.MaxBodyBytes < 0 {
+		return fmt.Errorf("server.max_body_bytes must not be negative")
+	}
+	return nil
+}
+
+// newHTTPServer applies the server section's timeouts and wraps handler so
+// no request body can exceed MaxBodyBytes.
+func newHTTPServer(cfg serverConfig, handler http.Handler) *http.Server {
+	if cfg.MaxBodyBytes > 0 {
+		handler = limitBody(handler, cfg.MaxBodyBytes)
+	}
+	return &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
+// limitBody answers 413 up front when Content-Length is over the limit and
+// caps the body for requests that don't declare one.
+func limitBody(next http.Handler, limit int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
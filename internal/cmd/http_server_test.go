@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPServerAppliesLimits(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	srv, err := interactionServerBuilder{PublicKey: hex.EncodeToString(pub), Publisher: &stubPublisher{}}.build(time.Second,
+		collectHandlerBindings(interactionsConfig{Enabled: true, Handlers: handlerMappings{Commands: map[string]handlerRoute{"help": {Agent: "claude"}}}}))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	cfg := defaultInteractionSettings().Server
+	cfg.MaxBodyBytes = 64
+	httpServer := newHTTPServer(cfg, http.HandlerFunc(srv.HandleInteraction))
+	if httpServer.ReadTimeout != defaultReadTimeout || httpServer.WriteTimeout != defaultWriteTimeout || httpServer.IdleTimeout != defaultIdleTimeout {
+		t.Fatalf("unexpected timeouts %+v", httpServer)
+	}
+
+	big := strings.Repeat("x", 65)
+	rec := httptest.NewRecorder()
+	httpServer.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/interactions", strings.NewReader(big)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for declared length, got %d", rec.Code)
+	}
+
+	// Without a Content-Length the cap applies while reading.
+	req := httptest.NewRequest(http.MethodPost, "/interactions", io.MultiReader(strings.NewReader(big)))
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	httpServer.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for streamed body, got %d", rec.Code)
+	}
+}
+
+func TestLoadInteractionSettingsServerLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  read_timeout: 5s\n  max_body_bytes: 65536\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	s := settings.Server
+	if s.ReadTimeout != 5*time.Second || s.WriteTimeout != defaultWriteTimeout || s.MaxBodyBytes != 65536 {
+		t.Fatalf("unexpected server limits %+v", s)
+	}
+	s.IdleTimeout = -time.Second
+	if err := validateServerLimits(s); err == nil || !strings.Contains(err.Error(), "idle_timeout") {
+		t.Fatalf("expected idle_timeout error, got %v", err)
+	}
+}
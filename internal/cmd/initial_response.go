@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+const (
+	initialResponseDeferred       = "deferred"
+	initialResponseDeferredUpdate = "deferred_update"
+	initialResponseMessage        = "message"
+	initialResponseUpdate         = "update"
+)
+
+// initialResponse builds the first response for the binding: the configured
+// initial_response or respond message, or a deferred acknowledgement the
+// agent edits later.
+func (b handlerBinding) initialResponse() (*types.InteractionResponse, error) {
+	cfg, field := b.Route.InitialResponse, "initial_response"
+	if static := b.Route.Respond; static != nil {
+		if b.Route.Agent != "" || cfg != nil {
+			return nil, errors.New("respond answers in the server and takes no agent or initial_response")
+		}
+		if static.Content == "" && len(static.Embeds) == 0 && len(static.Components) == 0 {
+			return nil, errors.New("respond needs content, embeds, or components")
+		}
+		cfg, field = &initialResponseConfig{Type: initialResponseMessage, Content: static.Content, Embeds: static.Embeds, Components: static.Components}, "respond"
+	}
+	if cfg == nil {
+		if b.Route.Agent == "" {
+			return nil, fmt.Errorf("route needs an agent or an initial_response")
+		}
+		return buildDeferredResponse(b.Route.Ephemeral)
+	}
+
+	kind := cfg.Type
+	if kind == "" {
+		kind = initialResponseDeferred
+	}
+	var resp *types.InteractionResponse
+	switch kind {
+	case initialResponseDeferred:
+		resp = &types.InteractionResponse{Type: types.InteractionResponseDeferredChannelMessageWithSource, Data: &types.InteractionApplicationCommandCallbackData{}}
+	case initialResponseDeferredUpdate:
+		resp = &types.InteractionResponse{Type: types.InteractionResponseDeferredUpdateMessage}
+	case initialResponseMessage:
+		resp = &types.InteractionResponse{Type: types.InteractionResponseChannelMessageWithSource}
+	case initialResponseUpdate:
+		resp = &types.InteractionResponse{Type: types.InteractionResponseUpdateMessage}
+	default:
+		return nil, fmt.Errorf("initial_response.type %q must be deferred, message, update, or deferred_update", cfg.Type)
+	}
+	switch {
+	case (kind == initialResponseUpdate || kind == initialResponseDeferredUpdate) && b.Kind != handlerKindComponent:
+		return nil, fmt.Errorf("initial_response.type %s only applies to component handlers", kind)
+	case b.Route.Agent == "" && kind != initialResponseMessage && kind != initialResponseUpdate:
+		return nil, fmt.Errorf("routes without an agent need an initial_response of type message or update")
+	}
+
+	if kind == initialResponseMessage || kind == initialResponseUpdate {
+		data := &types.InteractionApplicationCommandCallbackData{Content: cfg.Content}
+		if err := convertConfigValue(cfg.Embeds, &data.Embeds); err != nil {
+			return nil, fmt.Errorf("%s.embeds: %w", field, err)
+		}
+		if err := convertConfigValue(cfg.Components, &data.Components); err != nil {
+			return nil, fmt.Errorf("%s.components: %w", field, err)
+		}
+		if data.Content == "" && len(data.Embeds) == 0 && len(data.Components) == 0 {
+			return nil, fmt.Errorf("initial_response of type %s needs content, embeds, or components", kind)
+		}
+		resp.Data = data
+	} else if cfg.Content != "" || len(cfg.Embeds) > 0 || len(cfg.Components) > 0 {
+		return nil, fmt.Errorf("initial_response of type %s cannot have content, embeds, or components", kind)
+	}
+	// Message updates keep the original message's visibility.
+	if b.Route.Ephemeral && resp.Data != nil && kind != initialResponseUpdate {
+		resp.Data.Flags |= types.MessageFlagEphemeral
+	}
+	if err := resp.Validate(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// convertConfigValue re-decodes YAML-sourced values into their Discord JSON
+// types.
+func convertConfigValue(in any, out any) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
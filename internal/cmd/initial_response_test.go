@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestDispatchHandlerStaticReply(t *testing.T) {
+	pub := &stubPublisher{}
+	binding := handlerBinding{Kind: handlerKindCommand, Key: "rules", Route: handlerRoute{
+		Ephemeral: true,
+		InitialResponse: &initialResponseConfig{
+			Type:    "message",
+			Content: "Be kind.",
+			Components: []map[string]any{{
+				"type":       1,
+				"components": []any{map[string]any{"type": 2, "style": 5, "label": "Full rules", "url": "https://example.com/rules"}},
+			}},
+		},
+	}}
+	resp, err := dispatchHandler(binding, 0, pub)(context.Background(), &types.Interaction{Type: types.InteractionTypeApplicationCommand})
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if len(pub.envelopes) != 0 {
+		t.Fatalf("static reply should not dispatch, got %d envelopes", len(pub.envelopes))
+	}
+	if resp.Type != types.InteractionResponseChannelMessageWithSource || resp.Data.Content != "Be kind." || resp.Data.Flags != types.MessageFlagEphemeral {
+		t.Fatalf("unexpected response %+v", resp.Data)
+	}
+	if got := resp.Data.Components[0].Components[0]; got.URL != "https://example.com/rules" {
+		t.Fatalf("unexpected components %+v", resp.Data.Components)
+	}
+}
+
+func TestDispatchHandlerInitialMessageWithAgent(t *testing.T) {
+	pub := &stubPublisher{}
+	binding := handlerBinding{Kind: handlerKindCommand, Key: "build", Route: handlerRoute{
+		Agent:           "builder",
+		InitialResponse: &initialResponseConfig{Type: "message", Content: "Working on it…"},
+	}}
+	resp, err := dispatchHandler(binding, 0, pub)(context.Background(), &types.Interaction{Type: types.InteractionTypeApplicationCommand})
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if len(pub.envelopes) != 1 || resp.Data.Content != "Working on it…" {
+		t.Fatalf("expected dispatch with initial message, got %d envelopes and %+v", len(pub.envelopes), resp.Data)
+	}
+}
+
+func TestInitialResponseValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		binding handlerBinding
+		want    string
+	}{
+		{"no agent or response", handlerBinding{Kind: handlerKindCommand}, "needs an agent"},
+		{"static deferred", handlerBinding{Kind: handlerKindCommand, Route: handlerRoute{InitialResponse: &initialResponseConfig{}}}, "type message or update"},
+		{"update on command", handlerBinding{Kind: handlerKindCommand, Route: handlerRoute{Agent: "a", InitialResponse: &initialResponseConfig{Type: "update", Content: "x"}}}, "component handlers"},
+		{"empty message", handlerBinding{Kind: handlerKindCommand, Route: handlerRoute{Agent: "a", InitialResponse: &initialResponseConfig{Type: "message"}}}, "needs content"},
+		{"unknown type", handlerBinding{Kind: handlerKindCommand, Route: handlerRoute{Agent: "a", InitialResponse: &initialResponseConfig{Type: "modal"}}}, "must be deferred"},
+	}
+	for _, tc := range cases {
+		if _, err := tc.binding.initialResponse(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	update := handlerBinding{Kind: handlerKindComponent, Route: handlerRoute{InitialResponse: &initialResponseConfig{Type: "update", Content: "Done"}}}
+	resp, err := update.initialResponse()
+	if err != nil || resp.Type != types.InteractionResponseUpdateMessage {
+		t.Fatalf("expected static component update, got %+v, %v", resp, err)
+	}
+}
+
+func TestDispatchHandlerRespondBlock(t *testing.T) {
+	binding, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "links", Route: handlerRoute{
+		Respond: &staticResponseConfig{Content: "Docs and dashboards:", Embeds: []map[string]any{{"title": "Runbook", "url": "https://example.com/runbook"}}},
+	}, Rules: []routingRule{{Match: ruleMatch{Guilds: []string{"100"}}, Action: "route", Agent: "ops"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &stubPublisher{}
+	handler := dispatchHandler(binding, 0, pub)
+	resp, err := handler(context.Background(), &types.Interaction{Type: types.InteractionTypeApplicationCommand})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pub.envelopes) != 0 || resp.Type != types.InteractionResponseChannelMessageWithSource || resp.Data.Content != "Docs and dashboards:" || resp.Data.Embeds[0].Title != "Runbook" {
+		t.Fatalf("unexpected static response %+v with %d envelopes", resp.Data, len(pub.envelopes))
+	}
+
+	// A rule routing to an agent turns the route into a deferred dispatch.
+	resp, err = handler(context.Background(), &types.Interaction{Type: types.InteractionTypeApplicationCommand, GuildID: "100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pub.envelopes) != 1 || pub.envelopes[0].Agent != "ops" || resp.Type != types.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("expected a deferred dispatch to ops, got %+v", resp)
+	}
+
+	for _, route := range []handlerRoute{
+		{Respond: &staticResponseConfig{}},
+		{Agent: "ops", Respond: &staticResponseConfig{Content: "x"}},
+	} {
+		if _, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "links", Route: route}); err == nil || !strings.Contains(err.Error(), "respond") {
+			t.Fatalf("expected a respond error for %+v, got %v", route, err)
+		}
+	}
+}
@@ -10,15 +10,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/yourorg/arc-discord/gosdk/broker"
 	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 )
 
 type channelPublisher struct {
-	ch chan *redisEnvelope
+	ch chan *broker.Envelope
 }
 
-func (p *channelPublisher) Publish(ctx context.Context, env *redisEnvelope) error {
+func (p *channelPublisher) Publish(ctx context.Context, env *broker.Envelope) error {
 	p.ch <- env
 	return nil
 }
@@ -50,7 +51,7 @@ func TestIntegrationServerToAgentFlow(t *testing.T) {
 			},
 		},
 	}
-	publisher := &channelPublisher{ch: make(chan *redisEnvelope, 1)}
+	publisher := &channelPublisher{ch: make(chan *broker.Envelope, 1)}
 	srv, err := interactions.NewServer(strings.Repeat("0", 64), interactions.WithDryRun(true))
 	if err != nil {
 		t.Fatalf("new server: %v", err)
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -25,7 +24,13 @@ func interactionCmd(opts *globalOptions) *cobra.Command {
 	}
 	cmd.AddCommand(interactionListCmd(opts))
 	cmd.AddCommand(interactionRegisterCmd(opts))
+	cmd.AddCommand(interactionEditCmd(opts))
+	cmd.AddCommand(interactionBulkRegisterCmd(opts))
 	cmd.AddCommand(interactionDeleteCmd(opts))
+	cmd.AddCommand(interactionDiffCmd(opts))
+	cmd.AddCommand(interactionEntitlementsCmd(opts))
+	cmd.AddCommand(interactionReplayCmd(opts))
+	cmd.AddCommand(interactionSimulateCmd(opts))
 	return cmd
 }
 
@@ -47,7 +52,7 @@ func interactionListCmd(opts *globalOptions) *cobra.Command {
 	# List guild-scoped commands
 	arc-discord interaction list --guild $GUILD`,
 	}
-	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID (omit for global commands)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name (omit for global commands)")
 	cmd.Flags().StringVar(&applicationID, "application-id", "", "Override application ID (default from config)")
 	return cmd
 }
@@ -69,6 +74,10 @@ func runInteractionList(cmd *cobra.Command, opts *globalOptions, appID, guildID
 	}
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
 
 	commandsSvc := bot.ApplicationCommands(appID)
 	var cmds []*types.ApplicationCommand
@@ -115,11 +124,12 @@ func interactionRegisterCmd(opts *globalOptions) *cobra.Command {
 			return runInteractionRegister(cmd, opts, defPath, applicationID, guildID)
 		},
 		Example: `  arc-discord interaction register --file slash.json
-  arc-discord interaction register --file slash.json --guild $GUILD`,
+  arc-discord interaction register --file slash.json --guild $GUILD
+  generate-command | arc-discord interaction register --file -`,
 	}
 
-	cmd.Flags().StringVar(&defPath, "file", "", "Path to JSON definition (types.ApplicationCommand)")
-	cmd.Flags().StringVar(&guildID, "guild", "", "Optional guild ID for guild-scoped command")
+	cmd.Flags().StringVar(&defPath, "file", "", "Path to JSON definition (types.ApplicationCommand, - reads stdin)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Optional guild ID or name for guild-scoped command")
 	cmd.Flags().StringVar(&applicationID, "application-id", "", "Override application ID")
 	return cmd
 }
@@ -140,9 +150,9 @@ func runInteractionRegister(cmd *cobra.Command, opts *globalOptions, path, appID
 		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
 	}
 
-	data, err := os.ReadFile(path)
+	data, err := readPayload(cmd.InOrStdin(), path)
 	if err != nil {
-		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+		return err
 	}
 	var command types.ApplicationCommand
 	if err := json.Unmarshal(data, &command); err != nil {
@@ -151,6 +161,10 @@ func runInteractionRegister(cmd *cobra.Command, opts *globalOptions, path, appID
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
 
 	commandsSvc := bot.ApplicationCommands(appID)
 	var created *types.ApplicationCommand
@@ -167,6 +181,188 @@ func runInteractionRegister(cmd *cobra.Command, opts *globalOptions, path, appID
 	return nil
 }
 
+func interactionEditCmd(opts *globalOptions) *cobra.Command {
+	var (
+		defPath       string
+		guildID       string
+		applicationID string
+		commandID     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Update an application command in place",
+		Long: `Update an existing command's name, description, options, or permissions with PATCH. Unlike
+delete and register, the command keeps its ID and stays usable while it changes. The definition
+needs a name and description; optional fields it leaves out keep their current values.
+--command-id defaults to the "id" in the file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if defPath == "" {
+				return &arcer.CLIError{Msg: "--file is required", Hint: "provide a JSON definition for the application command"}
+			}
+			return runInteractionEdit(cmd, opts, defPath, applicationID, guildID, commandID)
+		},
+		Example: `  arc-discord interaction edit --command-id $CMD --file slash.json
+  arc-discord interaction edit --command-id $CMD --file slash.json --guild $GUILD`,
+	}
+
+	cmd.Flags().StringVar(&defPath, "file", "", "Path to JSON definition (types.ApplicationCommand, - reads stdin)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name when editing guild-scoped commands")
+	cmd.Flags().StringVar(&applicationID, "application-id", "", "Override application ID (default from config)")
+	cmd.Flags().StringVar(&commandID, "command-id", "", "Application command ID to edit (default: id in the file)")
+	return cmd
+}
+
+func runInteractionEdit(cmd *cobra.Command, opts *globalOptions, path, appID, guildID, commandID string) error {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	if appID == "" {
+		appID = cfg.Discord.ApplicationID
+	}
+	if strings.TrimSpace(appID) == "" {
+		return &arcer.CLIError{Msg: "application ID not configured", Hint: "set discord.application_id or pass --application-id"}
+	}
+
+	data, err := readPayload(cmd.InOrStdin(), path)
+	if err != nil {
+		return err
+	}
+	var command types.ApplicationCommand
+	if err := json.Unmarshal(data, &command); err != nil {
+		return (&arcer.CLIError{Msg: "invalid application command JSON"}).WithCause(err)
+	}
+	if commandID == "" {
+		commandID = command.ID
+	}
+	if commandID == "" {
+		return &arcer.CLIError{Msg: "--command-id is required", Hint: "find command IDs with: arc-discord interaction list"}
+	}
+	if command.ID != "" && command.ID != commandID {
+		return &arcer.CLIError{Msg: fmt.Sprintf("%s defines command %s but --command-id is %s", path, command.ID, commandID)}
+	}
+	// The ID and owning application/guild are part of the URL, not the update.
+	command.ID, command.ApplicationID, command.GuildID, command.Version = "", "", "", ""
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	commandsSvc := bot.ApplicationCommands(appID)
+	var updated *types.ApplicationCommand
+	if guildID == "" {
+		updated, err = commandsSvc.EditGlobalApplicationCommand(ctx, commandID, &command)
+	} else {
+		updated, err = commandsSvc.EditGuildApplicationCommand(ctx, guildID, commandID, &command)
+	}
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to edit application command", Hint: "guild-scoped commands need --guild"}).WithCause(err)
+	}
+
+	cmd.Printf("Command %s (%s) updated\n", updated.Name, updated.ID)
+	return nil
+}
+
+func interactionBulkRegisterCmd(opts *globalOptions) *cobra.Command {
+	var (
+		defPath       string
+		guildID       string
+		applicationID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bulk-register",
+		Short: "Replace the whole command set in one request",
+		Long: `Replace every global (or --guild) command with the JSON array in --file using Discord's bulk
+overwrite. Commands in the file are created or updated, keeping their IDs when the name and type
+match, and registered commands missing from the file are deleted. The change is atomic: users
+never see a partial command set, and it costs one request instead of one per command.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if defPath == "" {
+				return &arcer.CLIError{Msg: "--file is required", Hint: "provide a JSON array of application commands"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			return runInteractionBulkRegister(cmd, opts, defPath, applicationID, guildID)
+		},
+		Example: `  arc-discord interaction bulk-register --file commands.json
+  arc-discord interaction bulk-register --file commands.json --guild $GUILD`,
+	}
+
+	cmd.Flags().StringVar(&defPath, "file", "", "Path to JSON array of definitions ([]types.ApplicationCommand, - reads stdin)")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name to overwrite guild-scoped commands")
+	cmd.Flags().StringVar(&applicationID, "application-id", "", "Override application ID (default from config)")
+	return cmd
+}
+
+func runInteractionBulkRegister(cmd *cobra.Command, opts *globalOptions, path, appID, guildID string) error {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	if appID == "" {
+		appID = cfg.Discord.ApplicationID
+	}
+	if strings.TrimSpace(appID) == "" {
+		return &arcer.CLIError{Msg: "application ID not configured", Hint: "set discord.application_id or pass --application-id"}
+	}
+
+	data, err := readPayload(cmd.InOrStdin(), path)
+	if err != nil {
+		return err
+	}
+	var commands []*types.ApplicationCommand
+	if err := json.Unmarshal(data, &commands); err != nil {
+		return (&arcer.CLIError{Msg: "invalid application command JSON", Hint: "the file must contain a JSON array of commands"}).WithCause(err)
+	}
+	for i, c := range commands {
+		if c == nil {
+			return &arcer.CLIError{Msg: fmt.Sprintf("%s: command %d is null", path, i)}
+		}
+		if err := c.Validate(); err != nil {
+			return (&arcer.CLIError{Msg: fmt.Sprintf("%s: invalid command %d (%s)", path, i, c.Name)}).WithCause(err)
+		}
+	}
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	commandsSvc := bot.ApplicationCommands(appID)
+	var registered []*types.ApplicationCommand
+	if guildID == "" {
+		registered, err = commandsSvc.BulkOverwriteGlobalApplicationCommands(ctx, commands)
+	} else {
+		registered, err = commandsSvc.BulkOverwriteGuildApplicationCommands(ctx, guildID, commands)
+	}
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to overwrite application commands"}).WithCause(err)
+	}
+
+	rows := make([][]string, 0, len(registered))
+	for _, c := range registered {
+		rows = append(rows, []string{c.ID, c.Name, fmt.Sprintf("%d", c.Type), c.Description})
+	}
+	table := &tableData{headers: []string{"ID", "Name", "Type", "Description"}, rows: rows}
+	return renderOutput(cmd, opts.output, registered, table)
+}
+
 func interactionDeleteCmd(opts *globalOptions) *cobra.Command {
 	var (
 		applicationID string
@@ -188,7 +384,7 @@ func interactionDeleteCmd(opts *globalOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&applicationID, "application-id", "", "Override application ID (default from config)")
-	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID when deleting guild-scoped commands")
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name when deleting guild-scoped commands")
 	cmd.Flags().StringVar(&commandID, "command-id", "", "Application command ID to delete")
 	return cmd
 }
@@ -211,6 +407,10 @@ func runInteractionDelete(cmd *cobra.Command, opts *globalOptions, appID, guildI
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return err
+	}
 
 	commandsSvc := bot.ApplicationCommands(appID)
 	if guildID == "" {
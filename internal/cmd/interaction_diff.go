@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// commandDrift reports how the registered commands differ from the expected
+// definitions. Added commands are defined but not registered; removed ones are
+// registered but not defined.
+type commandDrift struct {
+	Source  string           `json:"source"`
+	GuildID string           `json:"guild_id,omitempty"`
+	Added   []string         `json:"added"`
+	Removed []string         `json:"removed"`
+	Changed []commandChanges `json:"changed"`
+}
+
+type commandChanges struct {
+	Name   string   `json:"name"`
+	ID     string   `json:"id"`
+	Fields []string `json:"fields"`
+}
+
+func (d *commandDrift) drifted() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Changed) > 0
+}
+
+// expectedCommand is a definition to compare against. Handler-derived
+// definitions only know the name and, when configured, the description.
+type expectedCommand struct {
+	command *types.ApplicationCommand
+	partial bool
+}
+
+func interactionDiffCmd(opts *globalOptions) *cobra.Command {
+	var (
+		guildID       string
+		applicationID string
+		dir           string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare registered commands with the expected definitions",
+		Long: `Compare the application commands registered with Discord (global, or --guild) against the
+expected set and report added, removed, and changed commands.
+
+By default the expected set comes from interactions.handlers.commands: every handled command
+should be registered, with the handler's description when one is configured. With --dir, every
+*.json file in the directory (one command or an array of commands, as accepted by register and
+bulk-register) is compared field by field, including options and permissions.
+
+Exits non-zero when drift exists, so it can gate CI.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			drift, err := runInteractionDiff(cmd, opts, applicationID, guildID, dir)
+			if err != nil {
+				return err
+			}
+			table := &tableData{headers: []string{"Change", "Name", "Details"}}
+			for _, name := range drift.Added {
+				table.rows = append(table.rows, []string{"added", name, "not registered"})
+			}
+			for _, name := range drift.Removed {
+				table.rows = append(table.rows, []string{"removed", name, "registered but not defined"})
+			}
+			for _, c := range drift.Changed {
+				table.rows = append(table.rows, []string{"changed", c.Name, strings.Join(c.Fields, ", ")})
+			}
+			if err := renderOutput(cmd, opts.output, drift, table); err != nil {
+				return err
+			}
+			if drift.drifted() {
+				return &arcer.CLIError{
+					Msg:  fmt.Sprintf("command drift: %d added, %d removed, %d changed", len(drift.Added), len(drift.Removed), len(drift.Changed)),
+					Hint: "sync the commands with: arc-discord interaction bulk-register --file <commands.json>",
+				}
+			}
+			return nil
+		},
+		Example: `  arc-discord interaction diff
+  arc-discord interaction diff --guild $GUILD --dir commands/ --output json`,
+	}
+
+	cmd.Flags().StringVar(&guildID, "guild", "", "Guild ID or name (omit for global commands)")
+	cmd.Flags().StringVar(&applicationID, "application-id", "", "Override application ID (default from config)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory of JSON command definitions (default: interactions.handlers.commands)")
+	return cmd
+}
+
+func runInteractionDiff(cmd *cobra.Command, opts *globalOptions, appID, guildID, dir string) (*commandDrift, error) {
+	cfg, extra, _, err := opts.loadConfigWithInteractions()
+	if err != nil {
+		return nil, err
+	}
+	if appID == "" {
+		appID = cfg.Discord.ApplicationID
+	}
+	if strings.TrimSpace(appID) == "" {
+		return nil, &arcer.CLIError{Msg: "application ID not configured", Hint: "set discord.application_id or pass --application-id"}
+	}
+
+	drift := &commandDrift{Source: "interactions.handlers.commands", Added: []string{}, Removed: []string{}, Changed: []commandChanges{}}
+	var expected []expectedCommand
+	if dir != "" {
+		drift.Source = dir
+		expected, err = loadCommandDir(dir)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for name, route := range extra.Interactions.Handlers.Commands {
+			command := &types.ApplicationCommand{Name: strings.ToLower(name), Description: route.Description}
+			expected = append(expected, expectedCommand{command: command, partial: true})
+		}
+	}
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	guildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	drift.GuildID = guildID
+
+	commandsSvc := bot.ApplicationCommands(appID)
+	var registered []*types.ApplicationCommand
+	if guildID == "" {
+		registered, err = commandsSvc.GetGlobalApplicationCommands(ctx)
+	} else {
+		registered, err = commandsSvc.GetGuildApplicationCommands(ctx, guildID)
+	}
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "failed to list application commands"}).WithCause(err)
+	}
+
+	diffCommands(drift, expected, registered)
+	return drift, nil
+}
+
+// diffCommands matches commands by case-insensitive name, as the interaction
+// router does.
+func diffCommands(drift *commandDrift, expected []expectedCommand, registered []*types.ApplicationCommand) {
+	byName := make(map[string]*types.ApplicationCommand, len(registered))
+	for _, c := range registered {
+		byName[strings.ToLower(c.Name)] = c
+	}
+	defined := make(map[string]bool, len(expected))
+	for _, want := range expected {
+		key := strings.ToLower(want.command.Name)
+		defined[key] = true
+		have, ok := byName[key]
+		if !ok {
+			drift.Added = append(drift.Added, want.command.Name)
+			continue
+		}
+		if fields := commandFieldChanges(want, have); len(fields) > 0 {
+			drift.Changed = append(drift.Changed, commandChanges{Name: have.Name, ID: have.ID, Fields: fields})
+		}
+	}
+	for _, c := range registered {
+		if !defined[strings.ToLower(c.Name)] {
+			drift.Removed = append(drift.Removed, c.Name)
+		}
+	}
+	sort.Strings(drift.Added)
+	sort.Strings(drift.Removed)
+	sort.Slice(drift.Changed, func(i, j int) bool { return drift.Changed[i].Name < drift.Changed[j].Name })
+}
+
+func commandFieldChanges(want expectedCommand, have *types.ApplicationCommand) []string {
+	w := want.command
+	var fields []string
+	if want.partial {
+		if w.Description != "" && w.Description != have.Description {
+			fields = append(fields, "description")
+		}
+		return fields
+	}
+	if commandType(w) != commandType(have) {
+		fields = append(fields, "type")
+	}
+	if w.Name != have.Name {
+		fields = append(fields, "name")
+	}
+	if w.Description != have.Description {
+		fields = append(fields, "description")
+	}
+	if !jsonEqual(w.Options, have.Options) {
+		fields = append(fields, "options")
+	}
+	if derefString(w.DefaultMemberPermissions) != derefString(have.DefaultMemberPermissions) {
+		fields = append(fields, "default_member_permissions")
+	}
+	// Discord treats an unset dm_permission as true.
+	if w.DMPermission != nil && *w.DMPermission != (have.DMPermission == nil || *have.DMPermission) {
+		fields = append(fields, "dm_permission")
+	}
+	if w.NSFW != have.NSFW {
+		fields = append(fields, "nsfw")
+	}
+	if !jsonEqual(w.NameLocalizations, have.NameLocalizations) || !jsonEqual(w.DescriptionLocalizations, have.DescriptionLocalizations) {
+		fields = append(fields, "localizations")
+	}
+	return fields
+}
+
+func commandType(c *types.ApplicationCommand) types.ApplicationCommandType {
+	if c.Type == 0 {
+		return types.ApplicationCommandTypeChatInput
+	}
+	return c.Type
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// jsonEqual compares values by their JSON encoding so nil and empty
+// collections are equal.
+func jsonEqual(a, b any) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	normalize := func(j []byte) string {
+		switch s := string(j); s {
+		case "null", "[]", "{}":
+			return ""
+		default:
+			return s
+		}
+	}
+	return normalize(ja) == normalize(jb)
+}
+
+// loadCommandDir reads every *.json file in dir as a command or an array of
+// commands.
+func loadCommandDir(dir string) ([]expectedCommand, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to list %s", dir)}).WithCause(err)
+	}
+	if len(paths) == 0 {
+		return nil, &arcer.CLIError{Msg: fmt.Sprintf("no *.json command definitions in %s", dir)}
+	}
+	sort.Strings(paths)
+	var expected []expectedCommand
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", path)}).WithCause(err)
+		}
+		var commands []*types.ApplicationCommand
+		if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+			err = json.Unmarshal(data, &commands)
+		} else {
+			var command types.ApplicationCommand
+			err = json.Unmarshal(data, &command)
+			commands = append(commands, &command)
+		}
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("invalid application command JSON in %s", path)}).WithCause(err)
+		}
+		for _, c := range commands {
+			if err := c.Validate(); err != nil {
+				return nil, (&arcer.CLIError{Msg: fmt.Sprintf("invalid command in %s", path)}).WithCause(err)
+			}
+			expected = append(expected, expectedCommand{command: c})
+		}
+	}
+	return expected, nil
+}
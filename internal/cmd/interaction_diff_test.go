@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestDiffCommandsFromHandlers(t *testing.T) {
+	expected := []expectedCommand{
+		{command: &types.ApplicationCommand{Name: "ping", Description: "Check latency"}, partial: true},
+		{command: &types.ApplicationCommand{Name: "help"}, partial: true},
+		{command: &types.ApplicationCommand{Name: "deploy"}, partial: true},
+	}
+	registered := []*types.ApplicationCommand{
+		{ID: "1", Name: "ping", Description: "Pong"},
+		{ID: "2", Name: "Help", Description: "anything", Options: []types.ApplicationCommandOption{{Name: "topic", Description: "Topic"}}},
+		{ID: "3", Name: "stale"},
+	}
+	drift := &commandDrift{}
+	diffCommands(drift, expected, registered)
+
+	if !reflect.DeepEqual(drift.Added, []string{"deploy"}) || !reflect.DeepEqual(drift.Removed, []string{"stale"}) {
+		t.Fatalf("unexpected added/removed %+v", drift)
+	}
+	if len(drift.Changed) != 1 || drift.Changed[0].ID != "1" || !reflect.DeepEqual(drift.Changed[0].Fields, []string{"description"}) {
+		t.Fatalf("unexpected changes %+v", drift.Changed)
+	}
+}
+
+func TestInteractionDiffDir(t *testing.T) {
+	yes := true
+	commands := &fakeApplicationCommands{global: []*types.ApplicationCommand{
+		{ID: "1", Name: "ping", Description: "Check latency", Type: types.ApplicationCommandTypeChatInput, DMPermission: &yes},
+		{ID: "2", Name: "echo", Description: "Echo text", Type: types.ApplicationCommandTypeChatInput},
+	}}
+	cfg := testConfig()
+	hookBot(t, cfg, &fakeBotClient{commandSvc: commands})
+	dir := t.TempDir()
+	cfgPath := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(cfgPath, []byte("interactions:\n  handlers:\n    commands:\n      ping:\n        agent: default\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loadDiscordConfigFn = func(string) (*discordconfig.Config, string, error) {
+		return cfg, cfgPath, nil
+	}
+
+	write := func(name, body string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("ping.json", `{"name":"ping","description":"Check latency","dm_permission":true}`)
+	write("echo.json", `[{"name":"echo","description":"Echo text","type":1}]`)
+	run := func(args ...string) error {
+		cmd := interactionDiffCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+		cmd.SetOut(&strings.Builder{})
+		cmd.SetArgs(append([]string{"--application-id", "app-1"}, args...))
+		return cmd.Execute()
+	}
+	if err := run("--dir", dir); err != nil {
+		t.Fatalf("expected no drift, got %v", err)
+	}
+	// The handlers only cover ping, so echo is unhandled.
+	if err := run(); err == nil || !strings.Contains(err.Error(), "0 added, 1 removed") {
+		t.Fatalf("expected echo to be reported as removed, got %v", err)
+	}
+
+	write("echo.json", `[{"name":"echo","description":"Echo text","dm_permission":false,"options":[{"type":3,"name":"text","description":"Text"}]}]`)
+	err := run("--dir", dir)
+	if err == nil || !strings.Contains(err.Error(), "0 added, 0 removed, 1 changed") {
+		t.Fatalf("expected one changed command, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const entitlementPageSize = 100
+
+func interactionEntitlementsCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "entitlements",
+		Short: "Inspect premium entitlements for the application's SKUs",
+		Long: `Entitlements record which users and guilds own the application's premium SKUs. Handlers can
+require one with interactions.handlers.<kind>.<name>.premium_sku; users without it get a
+premium button that opens the purchase flow instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(interactionEntitlementsListCmd(opts))
+	return cmd
+}
+
+func interactionEntitlementsListCmd(opts *globalOptions) *cobra.Command {
+	var (
+		applicationID string
+		guildRef      string
+		params        types.ListEntitlementsParams
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every entitlement, optionally filtered by user, guild, or SKU",
+		Example: `  arc-discord interaction entitlements list
+  arc-discord interaction entitlements list --user $USER --sku $SKU --exclude-ended`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			if applicationID == "" {
+				applicationID = cfg.Discord.ApplicationID
+			}
+			if strings.TrimSpace(applicationID) == "" {
+				return &arcer.CLIError{Msg: "application ID not configured", Hint: "set discord.application_id or pass --application-id"}
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+			}
+			if guildRef != "" {
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				params.GuildID, err = newNameResolver(bot, cfg).GuildID(ctx, guildRef)
+				cancel()
+				if err != nil {
+					return err
+				}
+			}
+
+			entitlements, err := listAllEntitlements(cmd.Context(), bot, applicationID, params)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to list entitlements"}).WithCause(err)
+			}
+			now := time.Now()
+			table := &tableData{headers: []string{"ID", "SKU", "Type", "User", "Guild", "Ends", "Active"}}
+			for _, e := range entitlements {
+				ends := ""
+				if e.EndsAt != nil {
+					ends = e.EndsAt.Format(time.RFC3339)
+				}
+				active := "no"
+				if e.Active(now) {
+					active = "yes"
+				}
+				table.rows = append(table.rows, []string{e.ID, e.SKUID, entitlementTypeName(e.Type), e.UserID, e.GuildID, ends, active})
+			}
+			return renderOutput(cmd, opts.output, entitlements, table)
+		},
+	}
+	cmd.Flags().StringVar(&applicationID, "application-id", "", "Override application ID (default from config)")
+	cmd.Flags().StringVar(&params.UserID, "user", "", "Only entitlements owned by this user ID")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Only entitlements owned by this guild (ID or name)")
+	cmd.Flags().StringSliceVar(&params.SKUIDs, "sku", nil, "Only entitlements for these SKU IDs (repeatable or comma-separated)")
+	cmd.Flags().BoolVar(&params.ExcludeEnded, "exclude-ended", false, "Skip entitlements that have ended")
+	return cmd
+}
+
+// listAllEntitlements pages through the application's entitlements in ID
+// order.
+func listAllEntitlements(parent context.Context, bot botClient, applicationID string, params types.ListEntitlementsParams) ([]*types.Entitlement, error) {
+	params.Limit = entitlementPageSize
+	var all []*types.Entitlement
+	for {
+		ctx, cancel := context.WithTimeout(parent, 30*time.Second)
+		page, err := bot.Applications().ListEntitlements(ctx, applicationID, &params)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < entitlementPageSize {
+			return all, nil
+		}
+		params.After = page[len(page)-1].ID
+	}
+}
+
+func entitlementTypeName(t types.EntitlementType) string {
+	switch t {
+	case types.EntitlementTypePurchase:
+		return "purchase"
+	case types.EntitlementTypePremiumSubscription:
+		return "premium_subscription"
+	case types.EntitlementTypeDeveloperGift:
+		return "developer_gift"
+	case types.EntitlementTypeTestModePurchase:
+		return "test_mode_purchase"
+	case types.EntitlementTypeFreePurchase:
+		return "free_purchase"
+	case types.EntitlementTypeUserGift:
+		return "user_gift"
+	case types.EntitlementTypePremiumPurchase:
+		return "premium_purchase"
+	case types.EntitlementTypeApplicationSubscription:
+		return "application_subscription"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	"github.com/yourorg/arc-sdk/utils"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const defaultReplayTarget = "http://localhost:8080/interactions"
+
+type replayResult struct {
+	File         string `json:"file" yaml:"file"`
+	Interaction  string `json:"interaction" yaml:"interaction"`
+	Status       int    `json:"status" yaml:"status"`
+	ResponseType int    `json:"response_type,omitempty" yaml:"response_type,omitempty"`
+	Error        string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func interactionReplayCmd(opts *globalOptions) *cobra.Command {
+	var (
+		target     string
+		privateKey string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay <file.json>...",
+		Short: "Re-send recorded interactions to an interactions endpoint",
+		Long: `Re-send interactions recorded with "server start --record" (or captured with --capture-dir) to
+an interactions endpoint, usually a local server started with --dry-run.
+
+Each request is re-signed with a fresh timestamp. Without --private-key a throwaway test key is
+generated, which a --dry-run server accepts; pass the hex private key (or seed) matching the target's
+discord.public_key to exercise signature verification too.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			key, err := replaySigningKey(privateKey)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "pass a 32-byte seed or 64-byte ed25519 private key in hex"}
+			}
+			results := make([]replayResult, 0, len(args))
+			rows := make([][]string, 0, len(args))
+			failed := 0
+			for _, path := range args {
+				result := replayInteraction(cmd.Context(), target, key, utils.ExpandPath(path))
+				result.File = path
+				if result.Error != "" {
+					failed++
+				}
+				results = append(results, result)
+				rows = append(rows, []string{path, result.Interaction, strconv.Itoa(result.Status), valueOrDash(responseTypeLabel(result.ResponseType)), valueOrDash(result.Error)})
+			}
+			table := &tableData{headers: []string{"File", "Interaction", "Status", "Response", "Error"}, rows: rows}
+			if err := renderOutput(cmd, opts.output, results, table); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return &arcer.CLIError{Msg: fmt.Sprintf("%d of %d replayed interactions failed", failed, len(args))}
+			}
+			return nil
+		},
+		Example: `Example:
+  arc-discord server start --dry-run --listen :8080 &
+  arc-discord interaction replay recordings/20250301T120000.000Z-0001.json
+
+Example:
+  arc-discord interaction replay recordings/*.json --target http://localhost:9090/interactions`,
+	}
+	cmd.Flags().StringVar(&target, "target", defaultReplayTarget, "Interactions endpoint to send to")
+	cmd.Flags().StringVar(&privateKey, "private-key", "", "Hex ed25519 private key or seed for signing (default a throwaway test key)")
+	return cmd
+}
+
+func replaySigningKey(raw string) (ed25519.PrivateKey, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		_, priv, err := ed25519.GenerateKey(nil)
+		return priv, err
+	}
+	decoded, err := hex.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --private-key: %w", err)
+	}
+	switch len(decoded) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(decoded), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(decoded), nil
+	default:
+		return nil, fmt.Errorf("invalid --private-key: got %d bytes", len(decoded))
+	}
+}
+
+func replayInteraction(ctx context.Context, target string, key ed25519.PrivateKey, path string) replayResult {
+	var result replayResult
+	body, err := loadRecordedBody(path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Interaction = describeRecordedInteraction(body)
+
+	status, respBody, err := postSignedInteraction(ctx, target, key, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = status
+	if status < 200 || status > 299 {
+		result.Error = strings.TrimSpace(string(respBody))
+		if result.Error == "" {
+			result.Error = http.StatusText(status)
+		}
+		return result
+	}
+	var decoded types.InteractionResponse
+	if json.Unmarshal(respBody, &decoded) == nil {
+		result.ResponseType = int(decoded.Type)
+	}
+	return result
+}
+
+// postSignedInteraction signs body with key and a fresh timestamp, as Discord
+// would, and POSTs it to target.
+func postSignedInteraction(ctx context.Context, target string, key ed25519.PrivateKey, body []byte) (int, []byte, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := ed25519.Sign(key, append([]byte(timestamp), body...))
+
+	reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// loadRecordedBody reads the interaction body from a --record file or from a
+// --capture-dir metadata file and its sibling .body file.
+func loadRecordedBody(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Body     string `json:"body"`
+		BodyFile string `json:"body_file"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", filepath.Base(path), err)
+	}
+	switch {
+	case file.Body != "":
+		return []byte(file.Body), nil
+	case file.BodyFile != "":
+		return os.ReadFile(filepath.Join(filepath.Dir(path), file.BodyFile))
+	default:
+		return nil, fmt.Errorf("%s is not a recorded interaction", filepath.Base(path))
+	}
+}
+
+func describeRecordedInteraction(body []byte) string {
+	var interaction types.Interaction
+	if err := json.Unmarshal(body, &interaction); err != nil {
+		return "-"
+	}
+	kind := interactionTypeLabel(interaction.Type)
+	if interaction.Data == nil {
+		return kind
+	}
+	if interaction.Data.Name != "" {
+		return kind + " " + interaction.Data.Name
+	}
+	if interaction.Data.CustomID != "" {
+		return kind + " " + interaction.Data.CustomID
+	}
+	return kind
+}
+
+func interactionTypeLabel(t types.InteractionType) string {
+	switch t {
+	case types.InteractionTypePing:
+		return "ping"
+	case types.InteractionTypeApplicationCommand:
+		return handlerKindCommand
+	case types.InteractionTypeMessageComponent:
+		return handlerKindComponent
+	case types.InteractionTypeApplicationCommandAutocomplete:
+		return handlerKindAutocomplete
+	case types.InteractionTypeModalSubmit:
+		return handlerKindModal
+	default:
+		return fmt.Sprintf("type %d", t)
+	}
+}
+
+func responseTypeLabel(t int) string {
+	switch types.InteractionResponseType(t) {
+	case 0:
+		return ""
+	case types.InteractionResponsePong:
+		return "pong"
+	case types.InteractionResponseChannelMessageWithSource:
+		return "message"
+	case types.InteractionResponseDeferredChannelMessageWithSource:
+		return "deferred"
+	case types.InteractionResponseDeferredUpdateMessage:
+		return "deferred update"
+	case types.InteractionResponseUpdateMessage:
+		return "update"
+	case types.InteractionResponseAutocompleteResult:
+		return "autocomplete"
+	case types.InteractionResponseModal:
+		return "modal"
+	default:
+		return strconv.Itoa(t)
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	"github.com/yourorg/arc-sdk/output"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const simulatedApplicationID = "000000000000000000"
+
+type simulateInput struct {
+	command       string
+	component     string
+	modal         string
+	options       []string
+	userID        string
+	guildID       string
+	channelID     string
+	applicationID string
+	locale        string
+}
+
+type simulateResult struct {
+	Status       int             `json:"status" yaml:"status"`
+	Interaction  json.RawMessage `json:"interaction" yaml:"-"`
+	ResponseType string          `json:"response_type,omitempty" yaml:"response_type,omitempty"`
+	Response     json.RawMessage `json:"response,omitempty" yaml:"-"`
+	Error        string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func interactionSimulateCmd(opts *globalOptions) *cobra.Command {
+	var (
+		in         simulateInput
+		target     string
+		privateKey string
+		showBody   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Send a fabricated interaction to an interactions endpoint",
+		// --guild and --channel are placed verbatim in the payload.
+		Annotations: map[string]string{annotationRawIDs: "true"},
+		Long: `Build a realistic interaction payload for a slash command, component click, or modal submit,
+sign it the way Discord does, POST it to --target, and print the server's response. Point it at a
+server started with --dry-run, or pass --private-key matching the server's discord.public_key.
+
+--options name=value sets command options (typed as integer, number, boolean, or string from the
+value) or, with --modal, the submitted text inputs.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			payload, err := buildSimulatedInteraction(in, time.Now())
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "see --help for examples"}
+			}
+			key, err := replaySigningKey(privateKey)
+			if err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "pass a 32-byte seed or 64-byte ed25519 private key in hex"}
+			}
+			if showBody && opts.output.Is(output.OutputTable) {
+				cmd.Printf("%s\n", payload)
+			}
+
+			result := simulateResult{Interaction: payload}
+			status, body, err := postSignedInteraction(cmd.Context(), target, key, payload)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to reach interactions endpoint", Hint: "start one with: arc-discord server start --dry-run"}).WithCause(err)
+			}
+			result.Status = status
+			var decoded types.InteractionResponse
+			if json.Valid(body) {
+				result.Response = body
+				if json.Unmarshal(body, &decoded) == nil {
+					result.ResponseType = responseTypeLabel(int(decoded.Type))
+				}
+			} else {
+				result.Error = strings.TrimSpace(string(body))
+			}
+
+			data := map[string]string{
+				"status":        strconv.Itoa(status),
+				"response_type": valueOrDash(result.ResponseType),
+			}
+			if result.Response != nil {
+				data["response"] = string(result.Response)
+			}
+			if result.Error != "" {
+				data["error"] = result.Error
+			}
+			if err := renderOutput(cmd, opts.output, result, keyValueTable(data)); err != nil {
+				return err
+			}
+			if status < 200 || status > 299 {
+				return &arcer.CLIError{Msg: fmt.Sprintf("server returned HTTP %d", status), Hint: valueOrDash(result.Error)}
+			}
+			return nil
+		},
+		Example: `Example:
+  arc-discord server start --dry-run &
+  arc-discord interaction simulate --command help --options topic=billing --user 123
+
+Example:
+  arc-discord interaction simulate --component confirm:42 --guild 456 --channel 789
+
+Example:
+  arc-discord interaction simulate --modal feedback --options summary="Great bot" --show-body`,
+	}
+	cmd.Flags().StringVar(&in.command, "command", "", "Slash command name to invoke")
+	cmd.Flags().StringVar(&in.component, "component", "", "Component custom_id to click")
+	cmd.Flags().StringVar(&in.modal, "modal", "", "Modal custom_id to submit")
+	cmd.Flags().StringArrayVar(&in.options, "options", nil, "Option or modal field as name=value (repeatable)")
+	cmd.Flags().StringVar(&in.userID, "user", "100000000000000001", "Invoking user ID")
+	cmd.Flags().StringVar(&in.guildID, "guild", "", "Guild ID (omit to simulate a DM)")
+	cmd.Flags().StringVar(&in.channelID, "channel", "100000000000000002", "Channel ID")
+	cmd.Flags().StringVar(&in.applicationID, "application-id", simulatedApplicationID, "Application ID placed in the payload")
+	cmd.Flags().StringVar(&in.locale, "locale", "en-US", "Invoking user's locale")
+	cmd.Flags().StringVar(&target, "target", defaultReplayTarget, "Interactions endpoint to send to")
+	cmd.Flags().StringVar(&privateKey, "private-key", "", "Hex ed25519 private key or seed for signing (default a throwaway test key)")
+	cmd.Flags().BoolVar(&showBody, "show-body", false, "Print the generated interaction before sending it")
+	return cmd
+}
+
+// buildSimulatedInteraction fabricates the JSON Discord would send. It is
+// built as a map because option and modal values have no field in
+// types.Interaction.
+func buildSimulatedInteraction(in simulateInput, now time.Time) ([]byte, error) {
+	chosen := 0
+	for _, v := range []string{in.command, in.component, in.modal} {
+		if strings.TrimSpace(v) != "" {
+			chosen++
+		}
+	}
+	if chosen != 1 {
+		return nil, errors.New("exactly one of --command, --component, or --modal is required")
+	}
+	fields, err := parseSimulateOptions(in.options)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strconv.FormatInt(now.UnixNano(), 10)
+	payload := map[string]any{
+		"id":             id,
+		"application_id": in.applicationID,
+		"token":          "simulated-" + id,
+		"version":        1,
+		"channel_id":     in.channelID,
+		"locale":         in.locale,
+	}
+	user := map[string]any{"id": in.userID, "username": "simulated-user", "discriminator": "0"}
+	if in.guildID != "" {
+		payload["guild_id"] = in.guildID
+		payload["guild_locale"] = in.locale
+		payload["member"] = map[string]any{"user": user, "roles": []string{}, "joined_at": now.UTC().Format(time.RFC3339)}
+	} else {
+		payload["user"] = user
+	}
+
+	switch {
+	case in.command != "":
+		options := make([]map[string]any, 0, len(fields))
+		for _, f := range fields {
+			optType, value := inferOptionValue(f.value)
+			options = append(options, map[string]any{"name": f.name, "type": optType, "value": value})
+		}
+		payload["type"] = types.InteractionTypeApplicationCommand
+		data := map[string]any{"id": id, "name": strings.TrimPrefix(in.command, "/"), "type": types.ApplicationCommandTypeChatInput}
+		if len(options) > 0 {
+			data["options"] = options
+		}
+		payload["data"] = data
+	case in.component != "":
+		if len(fields) > 0 {
+			return nil, errors.New("--options is not used with --component")
+		}
+		payload["type"] = types.InteractionTypeMessageComponent
+		payload["data"] = map[string]any{"custom_id": in.component, "component_type": types.ComponentTypeButton}
+		payload["message"] = map[string]any{"id": id, "channel_id": in.channelID, "content": ""}
+	case in.modal != "":
+		rows := make([]map[string]any, 0, len(fields))
+		for _, f := range fields {
+			rows = append(rows, map[string]any{
+				"type":       types.ComponentTypeActionRow,
+				"components": []map[string]any{{"type": types.ComponentTypeTextInput, "custom_id": f.name, "value": f.value}},
+			})
+		}
+		payload["type"] = types.InteractionTypeModalSubmit
+		payload["data"] = map[string]any{"custom_id": in.modal, "components": rows}
+	}
+	return json.Marshal(payload)
+}
+
+type simulateField struct {
+	name  string
+	value string
+}
+
+func parseSimulateOptions(raw []string) ([]simulateField, error) {
+	fields := make([]simulateField, 0, len(raw))
+	for _, entry := range raw {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --options %q: expected name=value", entry)
+		}
+		fields = append(fields, simulateField{name: name, value: value})
+	}
+	return fields, nil
+}
+
+// inferOptionValue picks the option type a value most likely came from.
+func inferOptionValue(raw string) (types.ApplicationCommandOptionType, any) {
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return types.CommandOptionInteger, n
+	}
+	if raw == "true" || raw == "false" {
+		return types.CommandOptionBoolean, raw == "true"
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return types.CommandOptionNumber, f
+	}
+	return types.CommandOptionString, raw
+}
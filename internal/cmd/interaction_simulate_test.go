@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestBuildSimulatedInteraction(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	body, err := buildSimulatedInteraction(simulateInput{
+		command: "/help", options: []string{"topic=billing", "count=3", "verbose=true", "ratio=0.5"},
+		userID: "123", guildID: "456", channelID: "789", applicationID: "app", locale: "de",
+	}, now)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	var interaction types.Interaction
+	if err := json.Unmarshal(body, &interaction); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if interaction.Type != types.InteractionTypeApplicationCommand || interaction.Data.Name != "help" {
+		t.Fatalf("unexpected interaction %+v", interaction)
+	}
+	if interaction.Member == nil || interaction.Member.User.ID != "123" || interaction.GuildID != "456" || interaction.Locale != "de" {
+		t.Fatalf("unexpected invoker %+v", interaction)
+	}
+	for _, want := range []string{`"name":"topic","type":3,"value":"billing"`, `"name":"count","type":4,"value":3`, `"name":"verbose","type":5,"value":true`, `"name":"ratio","type":10,"value":0.5`} {
+		if !strings.Contains(string(body), want) {
+			t.Fatalf("expected %s in %s", want, body)
+		}
+	}
+
+	body, err = buildSimulatedInteraction(simulateInput{modal: "feedback", options: []string{"summary=Great bot"}, userID: "123"}, now)
+	if err != nil {
+		t.Fatalf("build modal: %v", err)
+	}
+	if !strings.Contains(string(body), `"custom_id":"summary","type":4,"value":"Great bot"`) || !strings.Contains(string(body), `"user":{`) {
+		t.Fatalf("unexpected modal payload %s", body)
+	}
+
+	if _, err := buildSimulatedInteraction(simulateInput{command: "a", component: "b"}, now); err == nil {
+		t.Fatal("expected error for two interaction kinds")
+	}
+	if _, err := buildSimulatedInteraction(simulateInput{command: "a", options: []string{"novalue"}}, now); err == nil {
+		t.Fatal("expected error for malformed option")
+	}
+}
+
+func TestInteractionSimulatePrintsResponse(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	target, publisher := replayTestServer(t, hex.EncodeToString(pub), true)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := interactionSimulateCmd(opts)
+	cmd.SetArgs([]string{"--command", "help", "--options", "topic=billing", "--target", target.URL})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("simulate: %v (%s)", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), `"response_type": "deferred"`) {
+		t.Fatalf("unexpected output %s", buf.String())
+	}
+	if len(publisher.envelopes) != 1 || publisher.envelopes[0].Key != "help" {
+		t.Fatalf("expected the simulated command to be published, got %#v", publisher.envelopes)
+	}
+
+	cmd = interactionSimulateCmd(opts)
+	cmd.SetArgs([]string{"--command", "unknown", "--target", target.URL})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 for an unrouted command, got %v", err)
+	}
+}
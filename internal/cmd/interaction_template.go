@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/locale"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	discordutils "github.com/yourorg/arc-discord/gosdk/discord/utils"
+)
+
+// interactionTemplateFuncs are the helpers for response templates, rendered
+// with the interaction as dot by the server (respond and initial_response
+// texts) and by agent listen --templates (handler replies):
+//
+//	{{option "environment"}}  {{invoker | mention}}  {{mention .Member.User.ID}}
+//	{{discordTime now "f"}}  {{discordRelative now}}  {{option "log" | code "text"}}
+//
+// They extend templateFuncs, minus env: these templates are posted to chat.
+func interactionTemplateFuncs(i *types.Interaction) template.FuncMap {
+	funcs := templateFuncs()
+	delete(funcs, "env")
+	funcs["option"] = func(name string) string {
+		if i == nil {
+			return ""
+		}
+		opt := findInteractionOption(i, name)
+		if opt == nil {
+			return ""
+		}
+		return scriptText(opt.Value)
+	}
+	// invoker is the user who triggered the interaction, in guilds and DMs.
+	funcs["invoker"] = func() *types.User {
+		if i == nil {
+			return nil
+		}
+		if i.Member != nil && i.Member.User != nil {
+			return i.Member.User
+		}
+		return i.User
+	}
+	funcs["mention"] = templateMention
+	// discordTime and discordRelative are the locale FuncMap helpers of
+	// message --template, also taking unix seconds or RFC3339 text.
+	funcs["discordTime"] = func(v any, style string) (string, error) {
+		return templateMarkup(v, discordutils.TimestampStyle(style))
+	}
+	funcs["discordRelative"] = func(v any) (string, error) {
+		return templateMarkup(v, discordutils.TimestampRelative)
+	}
+	funcs["code"] = templateCode
+	funcs["now"] = time.Now
+	return funcs
+}
+
+// templateMention renders a user mention from an ID, *types.User, or
+// *types.Member.
+func templateMention(v any) (string, error) {
+	var id string
+	switch v := v.(type) {
+	case string:
+		id = v
+	case *types.User:
+		if v != nil {
+			id = v.ID
+		}
+	case *types.Member:
+		if v != nil && v.User != nil {
+			id = v.User.ID
+		}
+	case nil:
+	default:
+		return "", fmt.Errorf("mention: cannot mention a %T", v)
+	}
+	if id = strings.TrimSpace(id); id == "" {
+		return "", nil
+	}
+	return "<@" + id + ">", nil
+}
+
+// templateMarkup renders locale.Markup for a time, unix seconds, or RFC3339
+// text; style is one of t, T, d, D, f, F, R.
+func templateMarkup(v any, style discordutils.TimestampStyle) (string, error) {
+	var at time.Time
+	switch v := v.(type) {
+	case time.Time:
+		at = v
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		at = *v
+	case int:
+		at = time.Unix(int64(v), 0)
+	case int64:
+		at = time.Unix(v, 0)
+	case float64:
+		at = time.Unix(int64(v), 0)
+	case string:
+		parsed, err := parseTimestampAt(v, time.Now())
+		if err != nil {
+			return "", fmt.Errorf("discordTime: cannot read %q as a time", v)
+		}
+		at = parsed
+	default:
+		return "", fmt.Errorf("discordTime: cannot read a %T as a time", v)
+	}
+	return locale.Markup(at, style), nil
+}
+
+// templateCode wraps the last argument in a code block, with the first as its
+// language when two are given. Backtick fences inside are broken up so the
+// text cannot close the block early.
+func templateCode(args ...string) (string, error) {
+	var lang, text string
+	switch len(args) {
+	case 1:
+		text = args[0]
+	case 2:
+		lang, text = args[0], args[1]
+	default:
+		return "", fmt.Errorf("code: want text or a language and text, got %d arguments", len(args))
+	}
+	text = strings.ReplaceAll(text, "```", "`\u200b``")
+	return "```" + lang + "\n" + strings.TrimRight(text, "\n") + "\n```", nil
+}
+
+// renderInteractionTemplate executes text with i as dot. Text without "{{"
+// is returned as is.
+func renderInteractionTemplate(name, text string, i *types.Interaction) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Funcs(interactionTemplateFuncs(i)).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, i); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// checkInteractionTemplate reports syntax errors and unknown functions in
+// text without executing it.
+func checkInteractionTemplate(name, text string) error {
+	if !strings.Contains(text, "{{") {
+		return nil
+	}
+	_, err := template.New(name).Funcs(interactionTemplateFuncs(nil)).Parse(text)
+	return err
+}
+
+// renderConfigTemplates renders every string in a YAML-sourced value, such
+// as respond embeds, returning a copy. With i nil it only checks them.
+func renderConfigTemplates(name string, v any, i *types.Interaction) (any, error) {
+	switch v := v.(type) {
+	case string:
+		if i == nil {
+			return v, checkInteractionTemplate(name, v)
+		}
+		return renderInteractionTemplate(name, v, i)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			rendered, err := renderConfigTemplates(name+"."+key, value, i)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = rendered
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for idx, value := range v {
+			rendered, err := renderConfigTemplates(fmt.Sprintf("%s.%d", name, idx), value, i)
+			if err != nil {
+				return nil, err
+			}
+			out[idx] = rendered
+		}
+		return out, nil
+	case []map[string]any:
+		if v == nil {
+			return v, nil
+		}
+		out := make([]map[string]any, len(v))
+		for idx, value := range v {
+			rendered, err := renderConfigTemplates(fmt.Sprintf("%s.%d", name, idx), value, i)
+			if err != nil {
+				return nil, err
+			}
+			out[idx] = rendered.(map[string]any)
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// render returns a copy of c with templates in its content, embeds, and
+// components rendered against i, or only checked when i is nil.
+func (c initialResponseConfig) render(name string, i *types.Interaction) (initialResponseConfig, error) {
+	content, err := renderConfigTemplates(name+".content", c.Content, i)
+	if err != nil {
+		return c, err
+	}
+	embeds, err := renderConfigTemplates(name+".embeds", c.Embeds, i)
+	if err != nil {
+		return c, err
+	}
+	components, err := renderConfigTemplates(name+".components", c.Components, i)
+	if err != nil {
+		return c, err
+	}
+	c.Content = content.(string)
+	c.Embeds = embeds.([]map[string]any)
+	c.Components = components.([]map[string]any)
+	return c, nil
+}
+
+// renderTemplates returns the binding with the templates in its
+// initial_response and respond texts rendered against i, or only checked
+// when i is nil.
+func (b handlerBinding) renderTemplates(i *types.Interaction) (handlerBinding, error) {
+	if cfg := b.Route.InitialResponse; cfg != nil {
+		rendered, err := cfg.render("initial_response", i)
+		if err != nil {
+			return b, err
+		}
+		b.Route.InitialResponse = &rendered
+	}
+	if static := b.Route.Respond; static != nil {
+		rendered, err := initialResponseConfig{Content: static.Content, Embeds: static.Embeds, Components: static.Components}.render("respond", i)
+		if err != nil {
+			return b, err
+		}
+		b.Route.Respond = &staticResponseConfig{Content: rendered.Content, Embeds: rendered.Embeds, Components: rendered.Components}
+	}
+	return b, nil
+}
+
+// renderMessageTemplates renders the texts of an agent reply against i.
+func renderMessageTemplates(params *types.MessageEditParams, i *types.Interaction) error {
+	render := func(name string, text *string) error {
+		out, err := renderInteractionTemplate(name, *text, i)
+		if err != nil {
+			return err
+		}
+		*text = out
+		return nil
+	}
+	if err := render("content", &params.Content); err != nil {
+		return err
+	}
+	for idx := range params.Embeds {
+		embed := &params.Embeds[idx]
+		texts := []*string{&embed.Title, &embed.Description}
+		if embed.Footer != nil {
+			texts = append(texts, &embed.Footer.Text)
+		}
+		if embed.Author != nil {
+			texts = append(texts, &embed.Author.Name)
+		}
+		for f := range embed.Fields {
+			texts = append(texts, &embed.Fields[f].Name, &embed.Fields[f].Value)
+		}
+		for _, text := range texts {
+			if err := render(fmt.Sprintf("embeds.%d", idx), text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/locale"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func templateInteraction() *types.Interaction {
+	return &types.Interaction{
+		Type:   types.InteractionTypeApplicationCommand,
+		Token:  "tok",
+		Member: &types.Member{User: &types.User{ID: "7", Username: "ada"}},
+		Data: &types.InteractionData{Name: "deploy", Options: []types.ApplicationCommandOption{
+			{Type: types.CommandOptionString, Name: "environment", Value: "staging"},
+		}},
+	}
+}
+
+func TestDispatchHandlerRendersRespondTemplates(t *testing.T) {
+	binding, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "deploy", Route: handlerRoute{
+		Respond: &staticResponseConfig{
+			Content: `{{.Member.User.Username}} asked for {{option "environment"}} ({{invoker | mention}})`,
+			Embeds:  []map[string]any{{"title": `Deploy {{option "environment"}}`, "fields": []any{map[string]any{"name": "at", "value": `{{discordTime 1700000000 "R"}} {{discordRelative "2023-11-14T22:13:20Z"}}`}}}},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := dispatchHandler(binding, 0, &stubPublisher{})(context.Background(), templateInteraction())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Content != "ada asked for staging (<@7>)" {
+		t.Fatalf("unexpected content %q", resp.Data.Content)
+	}
+	embed := resp.Data.Embeds[0]
+	if embed.Title != "Deploy staging" || embed.Fields[0].Value != "<t:1700000000:R> <t:1700000000:R>" {
+		t.Fatalf("unexpected embed %+v", embed)
+	}
+	if binding.Route.Respond.Content != `{{.Member.User.Username}} asked for {{option "environment"}} ({{invoker | mention}})` {
+		t.Fatal("rendering changed the configured template")
+	}
+
+	for _, route := range []handlerRoute{
+		{Respond: &staticResponseConfig{Content: "{{.Member"}},
+		{Respond: &staticResponseConfig{Content: `{{env "TOKEN"}}`}},
+		{Agent: "ops", InitialResponse: &initialResponseConfig{Type: "message", Content: "{{nope}}"}},
+	} {
+		if _, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "deploy", Route: route}); err == nil || !strings.Contains(err.Error(), "template") {
+			t.Fatalf("expected a template error for %+v, got %v", route, err)
+		}
+	}
+}
+
+func TestAgentListenerRendersTemplates(t *testing.T) {
+	responder := &stubInteractionResponder{}
+	listener := newAgentListener("ops", "app123", responder, testPrinter{t})
+	listener.templates = true
+	listener.responder = &stubAgentResponder{params: &types.MessageEditParams{
+		Content: `{{mention .Member.User.ID}} {{option "environment" | code "text"}}`,
+		Embeds:  []types.Embed{{Title: "{{.Data.Name}}"}},
+	}}
+	raw, _ := json.Marshal(templateInteraction())
+	env := &broker.Envelope{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw}
+	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
+		t.Fatal(err)
+	}
+	if responder.params.Content != "<@7> ```text\nstaging\n```" || responder.params.Embeds[0].Title != "deploy" {
+		t.Fatalf("unexpected edit %+v", responder.params)
+	}
+
+	listener.responder = &stubAgentResponder{params: &types.MessageEditParams{Content: "{{.Nope}}"}}
+	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(responder.params.Content, "could not handle") {
+		t.Fatalf("expected the failure message, got %q", responder.params.Content)
+	}
+}
+
+func TestTemplateCodeBreaksFences(t *testing.T) {
+	got, err := templateCode("a ``` b")
+	if err != nil || strings.Count(got, "```") != 2 {
+		t.Fatalf("code block can be closed early: %q, %v", got, err)
+	}
+}
+
+func TestInteractionTemplateTimesMatchLocaleFuncs(t *testing.T) {
+	fmtr, err := locale.New("en", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Unix(1700000000, 0)
+	ours, theirs := interactionTemplateFuncs(nil), fmtr.FuncMap()
+	for _, style := range []string{"t", "F", "R"} {
+		want := theirs["discordTime"].(func(time.Time, string) string)(at, style)
+		got, err := ours["discordTime"].(func(any, string) (string, error))(at, style)
+		if err != nil || got != want {
+			t.Fatalf("discordTime %s: got %q (%v), want %q", style, got, err, want)
+		}
+	}
+	want := theirs["discordRelative"].(func(time.Time) string)(at)
+	if got, err := ours["discordRelative"].(func(any) (string, error))(int64(1700000000)); err != nil || got != want {
+		t.Fatalf("discordRelative: got %q (%v), want %q", got, err, want)
+	}
+}
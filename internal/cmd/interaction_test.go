@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestInteractionEdit(t *testing.T) {
+	commands := &fakeApplicationCommands{}
+	hookBot(t, testConfig(), &fakeBotClient{commandSvc: commands})
+
+	path := filepath.Join(t.TempDir(), "ping.json")
+	if err := os.WriteFile(path, []byte(`{"id":"cmd-1","name":"ping","description":"Check latency"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := interactionEditCmd(&globalOptions{})
+	cmd.SetArgs([]string{"--application-id", "app-1", "--file", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if len(commands.edited) != 1 || commands.edited[0] != "cmd-1" {
+		t.Fatalf("expected global edit of cmd-1, got %v", commands.edited)
+	}
+
+	cmd = interactionEditCmd(&globalOptions{})
+	cmd.SetArgs([]string{"--application-id", "app-1", "--file", path, "--command-id", "cmd-2"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "cmd-1") {
+		t.Fatalf("expected id mismatch error, got %v", err)
+	}
+}
+
+func TestInteractionBulkRegister(t *testing.T) {
+	commands := &fakeApplicationCommands{}
+	hookBot(t, testConfig(), &fakeBotClient{commandSvc: commands})
+
+	path := filepath.Join(t.TempDir(), "commands.json")
+	defs := `[{"name":"ping","description":"Check latency","type":1},{"name":"help","description":"Show help","type":1}]`
+	if err := os.WriteFile(path, []byte(defs), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	cmd := interactionBulkRegisterCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--application-id", "app-1", "--file", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("bulk-register: %v", err)
+	}
+	if got := commands.bulk[""]; len(got) != 2 || got[1].Name != "help" {
+		t.Fatalf("unexpected global overwrite %v", got)
+	}
+	if !strings.Contains(out.String(), "cmd-2") {
+		t.Fatalf("expected registered IDs in output, got %q", out.String())
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name":"ping"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd = interactionBulkRegisterCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetArgs([]string{"--application-id", "app-1", "--file", path})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error for a non-array file")
+	}
+}
+
+func TestInteractionEntitlementsListPages(t *testing.T) {
+	apps := &fakeApplicationService{}
+	for i := 0; i < 150; i++ {
+		apps.entitlements = append(apps.entitlements, &types.Entitlement{ID: fmt.Sprintf("ent-%03d", i), SKUID: "sku-1", UserID: "user-1"})
+	}
+	hookBot(t, testConfig(), &fakeBotClient{appSvc: apps})
+
+	var out bytes.Buffer
+	cmd := interactionEntitlementsListCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--application-id", "app-1", "--sku", "sku-1", "--exclude-ended"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("entitlements list: %v", err)
+	}
+	var got []types.Entitlement
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(got) != 150 || len(apps.entParams) != 2 || apps.entParams[1].After != "ent-099" || !apps.entParams[0].ExcludeEnded {
+		t.Fatalf("expected two pages and 150 entitlements, got %d over %+v", len(got), apps.entParams)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// rejectRemoteAddr is the interactions_rejected reason for requests from
+// outside server.allowed_cidrs.
+const rejectRemoteAddr = "remote_addr"
+
+// parseAllowedCIDRs reads server.allowed_cidrs. Bare addresses are accepted
+// as single-host ranges.
+func parseAllowedCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, raw := range cidrs {
+		s := strings.TrimSpace(raw)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("server.allowed_cidrs: %q is not an IP or CIDR", raw)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("server.allowed_cidrs: %q is not an IP or CIDR", raw)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// ipAllowlist answers 403 to requests whose peer address is outside the
+// allowed ranges. It checks the TCP peer, not X-Forwarded-For, so behind a
+// proxy the proxy's address is what must be listed.
+type ipAllowlist struct {
+	next     http.Handler
+	prefixes []netip.Prefix
+}
+
+func newIPAllowlist(next http.Handler, prefixes []netip.Prefix) http.Handler {
+	if len(prefixes) == 0 {
+		return next
+	}
+	return &ipAllowlist{next: next, prefixes: prefixes}
+}
+
+func (m *ipAllowlist) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !m.allowed(r.RemoteAddr) {
+		interactionRejections.Add(rejectRemoteAddr, 1)
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	m.next.ServeHTTP(w, r)
+}
+
+func (m *ipAllowlist) allowed(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range m.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIPAllowlist(t *testing.T) {
+	prefixes, err := parseAllowedCIDRs([]string{"10.0.0.0/8", " 203.0.113.7 ", "2001:db8::/32"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	handler := newIPAllowlist(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), prefixes)
+
+	cases := map[string]int{
+		"10.1.2.3:5000":        http.StatusOK,
+		"203.0.113.7:443":      http.StatusOK,
+		"[::ffff:10.9.9.9]:80": http.StatusOK,
+		"[2001:db8::1]:8080":   http.StatusOK,
+		"203.0.113.8:443":      http.StatusForbidden,
+		"192.168.1.1:1234":     http.StatusForbidden,
+		"not-an-address":       http.StatusForbidden,
+	}
+	for addr, want := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/interactions", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("%s: status %d, want %d", addr, rec.Code, want)
+		}
+	}
+}
+
+func TestParseAllowedCIDRsRejectsInvalid(t *testing.T) {
+	for _, bad := range []string{"10.0.0.0/33", "example.com"} {
+		if _, err := parseAllowedCIDRs([]string{bad}); err == nil {
+			t.Errorf("%s: expected error", bad)
+		}
+	}
+	prefixes, err := parseAllowedCIDRs(nil)
+	if err != nil || len(prefixes) != 0 {
+		t.Fatalf("expected no prefixes, got %v %v", prefixes, err)
+	}
+	next := http.NotFoundHandler()
+	if newIPAllowlist(next, nil) == nil {
+		t.Fatal("expected passthrough handler")
+	}
+}
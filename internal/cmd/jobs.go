@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-sdk/utils"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func jobsCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Inspect and trigger scheduled jobs",
+		Long: `Scheduled jobs are declared under jobs: in discord.yaml and run by "arc-discord server start"
+(including --daemon). Each job posts content or a payload file to a named webhook or a channel on a
+cron schedule. See "arc-discord server start --example" for the format.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(jobsListCmd(opts))
+	cmd.AddCommand(jobsRunNowCmd(opts))
+	return cmd
+}
+
+func jobsListCmd(opts *globalOptions) *cobra.Command {
+	var statePath string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured jobs with their next and last runs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			return runJobsList(cmd, opts, opts.output, statePath)
+		},
+		Example: `Example:
+  arc-discord jobs list
+
+Example:
+  arc-discord jobs list --output json`,
+	}
+	cmd.Flags().StringVar(&statePath, "state-file", "", "Job state file (default ~/.cache/vibe/discord-jobs.json)")
+	return cmd
+}
+
+func jobsRunNowCmd(opts *globalOptions) *cobra.Command {
+	var statePath string
+	cmd := &cobra.Command{
+		Use:   "run-now <name>",
+		Short: "Run a job immediately, outside its schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			return runJobsRunNow(cmd, opts, opts.output, args[0], statePath)
+		},
+		Example: `Example:
+  # Post today's standup reminder now to check the payload
+  arc-discord jobs run-now standup`,
+	}
+	cmd.Flags().StringVar(&statePath, "state-file", "", "Job state file (default ~/.cache/vibe/discord-jobs.json)")
+	return cmd
+}
+
+// jobListEntry is one row of jobs list.
+type jobListEntry struct {
+	Name      string    `json:"name" yaml:"name"`
+	Schedule  string    `json:"schedule" yaml:"schedule"`
+	Timezone  string    `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	Target    string    `json:"target" yaml:"target"`
+	Misfire   string    `json:"misfire" yaml:"misfire"`
+	NextRun   time.Time `json:"next_run,omitempty" yaml:"next_run,omitempty"`
+	LastRun   time.Time `json:"last_run,omitempty" yaml:"last_run,omitempty"`
+	LastError string    `json:"last_error,omitempty" yaml:"last_error,omitempty"`
+}
+
+func runJobsList(cmd *cobra.Command, opts *globalOptions, out output.OutputOptions, statePath string) error {
+	_, extra, _, err := opts.loadConfigWithInteractions()
+	if err != nil {
+		return err
+	}
+	names, err := validateJobs(extra.Jobs)
+	if err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "fix the jobs section in discord.yaml"}
+	}
+	state, err := openJobState(statePath)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entries := make([]jobListEntry, 0, len(names))
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		job := extra.Jobs[name]
+		sched, _ := job.schedule()
+		rec := state.get(name)
+		entry := jobListEntry{
+			Name:      name,
+			Schedule:  job.Schedule,
+			Timezone:  job.Timezone,
+			Target:    job.target(),
+			Misfire:   job.misfirePolicy(),
+			NextRun:   sched.Next(now),
+			LastRun:   rec.LastRun,
+			LastError: rec.LastError,
+		}
+		entries = append(entries, entry)
+		status := "ok"
+		switch {
+		case rec.LastRun.IsZero():
+			status = "never run"
+		case rec.LastError != "":
+			status = "error: " + rec.LastError
+		}
+		rows = append(rows, []string{name, job.Schedule, entry.Target, formatJobTime(entry.NextRun), formatJobTime(rec.LastRun), status})
+	}
+	table := &tableData{headers: []string{"Name", "Schedule", "Target", "Next Run", "Last Run", "Status"}, rows: rows}
+	return renderOutput(cmd, out, entries, table)
+}
+
+func runJobsRunNow(cmd *cobra.Command, opts *globalOptions, out output.OutputOptions, name, statePath string) error {
+	cfg, extra, _, err := opts.loadConfigWithInteractions()
+	if err != nil {
+		return err
+	}
+	job, ok := extra.Jobs[name]
+	if !ok {
+		known := make([]string, 0, len(extra.Jobs))
+		for n := range extra.Jobs {
+			known = append(known, n)
+		}
+		sort.Strings(known)
+		return &arcer.CLIError{Msg: fmt.Sprintf("unknown job %q", name), Hint: fmt.Sprintf("configured jobs: %v", known)}
+	}
+	if err := job.validate(); err != nil {
+		return &arcer.CLIError{Msg: fmt.Sprintf("jobs.%s: %v", name, err), Hint: "fix the jobs section in discord.yaml"}
+	}
+	state, err := openJobState(statePath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	runErr := newJobRunner(cfg, opts)(ctx, name, job)
+	now := time.Now()
+	if err := state.update(name, func(rec *jobRecord) {
+		rec.LastRun = now
+		rec.LastError = ""
+		if runErr != nil {
+			rec.LastError = runErr.Error()
+		}
+	}); err != nil {
+		cmd.PrintErrf("warning: failed to save job state: %v\n", err)
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	result := map[string]string{
+		"job":    name,
+		"target": job.target(),
+		"status": "sent",
+	}
+	return renderOutput(cmd, out, result, keyValueTable(result))
+}
+
+func openJobState(path string) (*jobStateStore, error) {
+	if path == "" {
+		path = defaultJobStatePath()
+	}
+	state, err := loadJobState(utils.ExpandPath(path))
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "failed to load job state"}).WithCause(err)
+	}
+	return state, nil
+}
+
+// newJobRunner returns the function that delivers a job: through a named
+// webhook, or as the bot to a channel ID or #name.
+func newJobRunner(cfg *discordconfig.Config, opts *globalOptions) jobRunFunc {
+	return func(ctx context.Context, name string, job jobConfig) error {
+		payloadPath := ""
+		if job.Payload != "" {
+			payloadPath = utils.ExpandPath(job.Payload)
+		}
+		if job.Channel != "" {
+			params, err := buildMessageParams(messageSendInput{content: job.Content, payloadPath: payloadPath}, nil)
+			if err != nil {
+				return err
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+			}
+			channelID, err := newNameResolver(bot, cfg).ChannelID(ctx, job.Channel, "")
+			if err != nil {
+				return err
+			}
+			if _, err := bot.Messages().CreateMessage(ctx, channelID, params); err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("job %s: failed to send Discord message", name)}).WithCause(err)
+			}
+			return nil
+		}
+
+		webhookURL, err := resolveWebhookURL(cfg, nil, job.webhookName())
+		if err != nil {
+			return &arcer.CLIError{Msg: fmt.Sprintf("job %s: %v", name, err), Hint: "add the webhook under discord.webhooks"}
+		}
+		msg, err := buildWebhookMessage(webhookSendInput{content: job.Content, payloadPath: payloadPath}, nil)
+		if err != nil {
+			return err
+		}
+		dispatcher, err := newWebhookClientFn(cfg, webhookURL)
+		if err != nil {
+			return (&arcer.CLIError{Msg: fmt.Sprintf("failed to create webhook client for %s", maskWebhookURL(webhookURL))}).WithCause(err)
+		}
+		if err := dispatcher.Send(ctx, msg); err != nil {
+			return (&arcer.CLIError{Msg: fmt.Sprintf("job %s: webhook send failed", name)}).WithCause(err)
+		}
+		return nil
+	}
+}
+
+func formatJobTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	jobMisfireSkip    = "skip"
+	jobMisfireRunOnce = "run_once"
+
+	// jobLateTolerance is how late a run may start before it counts as a
+	// misfire (timer jitter, a slow previous job).
+	jobLateTolerance = time.Minute
+	// defaultJobMisfireGrace bounds how old a missed run may be and still be
+	// caught up under misfire: run_once.
+	defaultJobMisfireGrace = time.Hour
+	jobRunTimeout          = 30 * time.Second
+)
+
+// jobConfig is one entry under the jobs: section of discord.yaml.
+type jobConfig struct {
+	Schedule     string        `yaml:"schedule"`
+	Timezone     string        `yaml:"timezone"`
+	Webhook      string        `yaml:"webhook"`
+	Channel      string        `yaml:"channel"`
+	Content      string        `yaml:"content"`
+	Payload      string        `yaml:"payload"`
+	Misfire      string        `yaml:"misfire"`
+	MisfireGrace time.Duration `yaml:"misfire_grace"`
+}
+
+func (j jobConfig) target() string {
+	if j.Channel != "" {
+		return "channel " + j.Channel
+	}
+	return "webhook " + j.webhookName()
+}
+
+func (j jobConfig) webhookName() string {
+	if j.Webhook == "" {
+		return "default"
+	}
+	return j.Webhook
+}
+
+func (j jobConfig) misfirePolicy() string {
+	if j.Misfire == "" {
+		return jobMisfireSkip
+	}
+	return j.Misfire
+}
+
+func (j jobConfig) misfireGrace() time.Duration {
+	if j.MisfireGrace <= 0 {
+		return defaultJobMisfireGrace
+	}
+	return j.MisfireGrace
+}
+
+// schedule parses the cron expression in the job's time zone.
+func (j jobConfig) schedule() (*cronSchedule, error) {
+	loc := time.Local
+	if j.Timezone != "" {
+		l, err := time.LoadLocation(j.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("timezone: %w", err)
+		}
+		loc = l
+	}
+	return parseCron(j.Schedule, loc)
+}
+
+func (j jobConfig) validate() error {
+	if _, err := j.schedule(); err != nil {
+		return err
+	}
+	if j.Webhook != "" && j.Channel != "" {
+		return errors.New("set either webhook or channel, not both")
+	}
+	if j.Content == "" && j.Payload == "" {
+		return errors.New("content or payload is required")
+	}
+	switch j.misfirePolicy() {
+	case jobMisfireSkip, jobMisfireRunOnce:
+	default:
+		return fmt.Errorf("misfire must be %s or %s", jobMisfireSkip, jobMisfireRunOnce)
+	}
+	return nil
+}
+
+// validateJobs checks every job and returns the names in sorted order.
+func validateJobs(jobs map[string]jobConfig) ([]string, error) {
+	names := make([]string, 0, len(jobs))
+	for name, job := range jobs {
+		if err := job.validate(); err != nil {
+			return nil, fmt.Errorf("jobs.%s: %w", name, err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// jobRecord is the persisted state of a job. LastScheduled is the last cron
+// slot the scheduler handled (run or skipped); misfires after a restart are
+// detected against it. run-now only updates LastRun.
+type jobRecord struct {
+	LastScheduled time.Time `json:"last_scheduled,omitempty"`
+	LastRun       time.Time `json:"last_run,omitempty"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// jobStateStore persists jobRecords as JSON so misfires survive restarts.
+type jobStateStore struct {
+	path    string
+	mu      sync.Mutex
+	records map[string]jobRecord
+}
+
+func defaultJobStatePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "vibe", "discord-jobs.json")
+}
+
+func loadJobState(path string) (*jobStateStore, error) {
+	store := &jobStateStore{path: path, records: map[string]jobRecord{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.records); err != nil {
+		return nil, fmt.Errorf("parse job state %s: %w", path, err)
+	}
+	return store, nil
+}
+
+func (s *jobStateStore) get(name string) jobRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records[name]
+}
+
+func (s *jobStateStore) update(name string, fn func(*jobRecord)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := s.records[name]
+	fn(&rec)
+	s.records[name] = rec
+	data, err := json.MarshalIndent(s.records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+type jobRunFunc func(ctx context.Context, name string, job jobConfig) error
+
+type scheduledJob struct {
+	name     string
+	cfg      jobConfig
+	schedule *cronSchedule
+	next     time.Time
+}
+
+// jobScheduler fires jobs on their cron schedules inside the server process.
+// A slot that comes due more than jobLateTolerance late (the server was down
+// or the host slept) is a misfire: "skip" drops it, "run_once" runs it once
+// if it is within misfire_grace, however many slots were missed.
+type jobScheduler struct {
+	jobs  []*scheduledJob
+	state *jobStateStore
+	run   jobRunFunc
+	now   func() time.Time
+	logf  func(format string, args ...any)
+}
+
+func newJobScheduler(jobs map[string]jobConfig, state *jobStateStore, run jobRunFunc, logf func(string, ...any)) (*jobScheduler, error) {
+	names, err := validateJobs(jobs)
+	if err != nil {
+		return nil, err
+	}
+	s := &jobScheduler{state: state, run: run, now: time.Now, logf: logf}
+	for _, name := range names {
+		sched, _ := jobs[name].schedule()
+		s.jobs = append(s.jobs, &scheduledJob{name: name, cfg: jobs[name], schedule: sched})
+	}
+	return s, nil
+}
+
+// start seeds each job's next slot: from its last handled slot when state
+// exists, so slots missed while the server was down surface as misfires.
+func (s *jobScheduler) start(now time.Time) {
+	for _, job := range s.jobs {
+		if last := s.state.get(job.name).LastScheduled; !last.IsZero() {
+			job.next = job.schedule.Next(last)
+		} else {
+			job.next = job.schedule.Next(now)
+		}
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (s *jobScheduler) Run(ctx context.Context) {
+	s.start(s.now())
+	for {
+		s.tick(ctx, s.now())
+		wake := s.nextWake()
+		if wake.IsZero() {
+			<-ctx.Done()
+			return
+		}
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *jobScheduler) nextWake() time.Time {
+	var wake time.Time
+	for _, job := range s.jobs {
+		if !job.next.IsZero() && (wake.IsZero() || job.next.Before(wake)) {
+			wake = job.next
+		}
+	}
+	return wake
+}
+
+// tick handles every job whose slot is due at now.
+func (s *jobScheduler) tick(ctx context.Context, now time.Time) {
+	for _, job := range s.jobs {
+		if job.next.IsZero() || job.next.After(now) {
+			continue
+		}
+		due := job.next
+		late := now.Sub(due)
+		// Coalesce any further slots missed in the same gap.
+		job.next = job.schedule.Next(now)
+
+		if late > jobLateTolerance {
+			switch {
+			case job.cfg.misfirePolicy() == jobMisfireRunOnce && late <= job.cfg.misfireGrace():
+				s.logf("job %s misfired (due %s, %s late); running once\n", job.name, due.Format(time.RFC3339), late.Round(time.Second))
+			default:
+				s.logf("job %s misfired (due %s, %s late); skipping\n", job.name, due.Format(time.RFC3339), late.Round(time.Second))
+				s.record(job.name, now, nil, false)
+				continue
+			}
+		}
+		runCtx, cancel := context.WithTimeout(ctx, jobRunTimeout)
+		err := s.run(runCtx, job.name, job.cfg)
+		cancel()
+		if err != nil {
+			s.logf("job %s failed: %v\n", job.name, err)
+		} else {
+			s.logf("job %s ran (%s)\n", job.name, job.cfg.target())
+		}
+		s.record(job.name, now, err, true)
+	}
+}
+
+func (s *jobScheduler) record(name string, slot time.Time, runErr error, ran bool) {
+	err := s.state.update(name, func(rec *jobRecord) {
+		rec.LastScheduled = slot
+		if ran {
+			rec.LastRun = slot
+			rec.LastError = ""
+			if runErr != nil {
+				rec.LastError = strings.TrimSpace(runErr.Error())
+			}
+		}
+	})
+	if err != nil {
+		s.logf("job %s: failed to save state: %v\n", name, err)
+	}
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+
+	"github.com/yourorg/arc-sdk/output"
+)
+
+const jobsTestConfig = `discord:
+  public_key: "abc"
+jobs:
+  standup:
+    schedule: "0 9 * * mon-fri"
+    timezone: "UTC"
+    content: "Standup in 15 minutes"
+    misfire: run_once
+    misfire_grace: 2h
+  digest:
+    schedule: "@daily"
+    channel: "42"
+    content: "Daily digest"
+`
+
+type jobRunRecorder struct {
+	runs []string
+	err  error
+}
+
+func (r *jobRunRecorder) run(_ context.Context, name string, _ jobConfig) error {
+	r.runs = append(r.runs, name)
+	return r.err
+}
+
+func newTestScheduler(t *testing.T, jobs map[string]jobConfig) (*jobScheduler, *jobRunRecorder, *jobStateStore) {
+	t.Helper()
+	state, err := loadJobState(filepath.Join(t.TempDir(), "jobs.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &jobRunRecorder{}
+	s, err := newJobScheduler(jobs, state, rec.run, func(string, ...any) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, rec, state
+}
+
+func TestJobSchedulerRunsDueJobs(t *testing.T) {
+	s, rec, state := newTestScheduler(t, map[string]jobConfig{
+		"hourly": {Schedule: "0 * * * *", Timezone: "UTC", Content: "hi"},
+	})
+	start := time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)
+	s.start(start)
+	if want := time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC); !s.nextWake().Equal(want) {
+		t.Fatalf("next wake = %s, want %s", s.nextWake(), want)
+	}
+
+	s.tick(context.Background(), start.Add(10*time.Minute))
+	if len(rec.runs) != 0 {
+		t.Fatalf("job ran early: %v", rec.runs)
+	}
+	due := time.Date(2025, 3, 14, 11, 0, 5, 0, time.UTC)
+	s.tick(context.Background(), due)
+	if len(rec.runs) != 1 {
+		t.Fatalf("expected one run, got %v", rec.runs)
+	}
+	if got := state.get("hourly").LastRun; !got.Equal(due) {
+		t.Fatalf("last run = %s, want %s", got, due)
+	}
+}
+
+func TestJobSchedulerMisfirePolicies(t *testing.T) {
+	s, rec, state := newTestScheduler(t, map[string]jobConfig{
+		"skipper": {Schedule: "0 * * * *", Timezone: "UTC", Content: "hi"},
+		"catchup": {Schedule: "0 * * * *", Timezone: "UTC", Content: "hi", Misfire: jobMisfireRunOnce, MisfireGrace: 3 * time.Hour},
+		"tooLate": {Schedule: "0 * * * *", Timezone: "UTC", Content: "hi", Misfire: jobMisfireRunOnce, MisfireGrace: 30 * time.Minute},
+	})
+	s.start(time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC))
+
+	// The host slept through the 11:00 and 12:00 slots.
+	wake := time.Date(2025, 3, 14, 12, 40, 0, 0, time.UTC)
+	s.tick(context.Background(), wake)
+	if strings.Join(rec.runs, ",") != "catchup" {
+		t.Fatalf("expected only catchup to run once, got %v", rec.runs)
+	}
+	if got := state.get("skipper"); !got.LastRun.IsZero() || !got.LastScheduled.Equal(wake) {
+		t.Fatalf("skipped job should record the slot without a run: %+v", got)
+	}
+	for _, job := range s.jobs {
+		if want := time.Date(2025, 3, 14, 13, 0, 0, 0, time.UTC); !job.next.Equal(want) {
+			t.Fatalf("%s: next = %s, want %s", job.name, job.next, want)
+		}
+	}
+}
+
+func TestJobSchedulerDetectsMisfiresAcrossRestarts(t *testing.T) {
+	jobs := map[string]jobConfig{
+		"daily": {Schedule: "0 9 * * *", Timezone: "UTC", Content: "hi", Misfire: jobMisfireRunOnce},
+	}
+	s, rec, state := newTestScheduler(t, jobs)
+	if err := state.update("daily", func(r *jobRecord) {
+		r.LastScheduled = time.Date(2025, 3, 13, 9, 0, 0, 0, time.UTC)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restarted 20 minutes after today's slot: within the default 1h grace.
+	restart := time.Date(2025, 3, 14, 9, 20, 0, 0, time.UTC)
+	s.start(restart)
+	s.tick(context.Background(), restart)
+	if len(rec.runs) != 1 {
+		t.Fatalf("expected the missed slot to run once, got %v", rec.runs)
+	}
+
+	reloaded, err := loadJobState(state.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.get("daily").LastRun; !got.Equal(restart) {
+		t.Fatalf("persisted last run = %s, want %s", got, restart)
+	}
+}
+
+func TestJobSchedulerRecordsErrors(t *testing.T) {
+	s, rec, state := newTestScheduler(t, map[string]jobConfig{
+		"hourly": {Schedule: "0 * * * *", Timezone: "UTC", Content: "hi"},
+	})
+	rec.err = errors.New("webhook send failed")
+	s.start(time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC))
+	s.tick(context.Background(), time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC))
+	if got := state.get("hourly").LastError; got != "webhook send failed" {
+		t.Fatalf("last error = %q", got)
+	}
+}
+
+func TestValidateJobs(t *testing.T) {
+	cases := map[string]jobConfig{
+		"bad cron":     {Schedule: "every day", Content: "x"},
+		"bad timezone": {Schedule: "@daily", Timezone: "Mars/Olympus", Content: "x"},
+		"two targets":  {Schedule: "@daily", Webhook: "default", Channel: "42", Content: "x"},
+		"no content":   {Schedule: "@daily"},
+		"bad misfire":  {Schedule: "@daily", Content: "x", Misfire: "always"},
+	}
+	for name, job := range cases {
+		if _, err := validateJobs(map[string]jobConfig{"j": job}); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestLoadInteractionSettingsParsesJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte(jobsTestConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	standup, ok := settings.Jobs["standup"]
+	if !ok {
+		t.Fatalf("standup job missing: %+v", settings.Jobs)
+	}
+	if standup.misfirePolicy() != jobMisfireRunOnce || standup.misfireGrace() != 2*time.Hour {
+		t.Fatalf("misfire settings not parsed: %+v", standup)
+	}
+	if settings.Jobs["digest"].target() != "channel 42" {
+		t.Fatalf("digest target = %q", settings.Jobs["digest"].target())
+	}
+}
+
+func hookJobsConfig(t *testing.T, cfg *discordconfig.Config, webhookClient webhookDispatcher, bot botClient) string {
+	t.Helper()
+	hookStubs(t, cfg, webhookClient, bot)
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte(jobsTestConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loadDiscordConfigFn = func(string) (*discordconfig.Config, string, error) {
+		return cfg, path, nil
+	}
+	return filepath.Join(t.TempDir(), "jobs.json")
+}
+
+func TestJobsRunNowSendsWebhookAndRecordsRun(t *testing.T) {
+	webhookClient := &fakeWebhookClient{}
+	statePath := hookJobsConfig(t, testConfig(), webhookClient, nil)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := jobsCmd(opts)
+	cmd.SetArgs([]string{"run-now", "standup", "--state-file", statePath})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if len(webhookClient.messages) != 1 || webhookClient.messages[0].Content != "Standup in 15 minutes" {
+		t.Fatalf("unexpected webhook messages: %+v", webhookClient.messages)
+	}
+	state, err := loadJobState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := state.get("standup"); rec.LastRun.IsZero() || !rec.LastScheduled.IsZero() {
+		t.Fatalf("run-now should record only the last run: %+v", rec)
+	}
+}
+
+func TestJobsRunNowSendsToChannel(t *testing.T) {
+	messageSvc := &fakeMessageService{}
+	statePath := hookJobsConfig(t, testConfig(), nil, &fakeBotClient{messageSvc: messageSvc})
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := jobsCmd(opts)
+	cmd.SetArgs([]string{"run-now", "digest", "--state-file", statePath})
+	cmd.SetOut(&bytes.Buffer{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if messageSvc.channelID != "42" || messageSvc.params.Content != "Daily digest" {
+		t.Fatalf("unexpected message: %s %+v", messageSvc.channelID, messageSvc.params)
+	}
+}
+
+func TestJobsRunNowUnknownJob(t *testing.T) {
+	statePath := hookJobsConfig(t, testConfig(), nil, nil)
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := jobsCmd(opts)
+	cmd.SetArgs([]string{"run-now", "nope", "--state-file", statePath})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `unknown job "nope"`) {
+		t.Fatalf("expected unknown job error, got %v", err)
+	}
+}
+
+func TestJobsListShowsSchedule(t *testing.T) {
+	statePath := hookJobsConfig(t, testConfig(), nil, nil)
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}}
+	cmd := jobsCmd(opts)
+	cmd.SetArgs([]string{"list", "--state-file", statePath})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"standup", "0 9 * * mon-fri", "webhook default", "digest", "channel 42", "never run"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+// kafkaMessageWriter is the subset of *kafka.Writer used by kafkaPublisher.
+type kafkaMessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// kafkaPublisher mirrors interaction envelopes onto a Kafka topic. Writes are
+// asynchronous and batched; delivery results are reported through the logger.
+type kafkaPublisher struct {
+	writer kafkaMessageWriter
+	topic  string
+	logger *logger.Logger
+}
+
+func newKafkaPublisher(cfg kafkaConfig, log *logger.Logger) (*kafkaPublisher, error) {
+	if !cfg.enabled() {
+		return nil, errors.New("kafka brokers and topic are required")
+	}
+	if log == nil {
+		log = logger.Default()
+	}
+	p := &kafkaPublisher{topic: cfg.Topic, logger: log}
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        cfg.Topic,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    cfg.BatchSize,
+		BatchTimeout: cfg.BatchTimeout,
+		RequiredAcks: kafka.RequireOne,
+		Async:        true,
+		Completion:   p.reportDelivery,
+	}
+	if cfg.ClientID != "" {
+		writer.Transport = &kafka.Transport{ClientID: cfg.ClientID}
+	}
+	p.writer = writer
+	return p, nil
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, env *broker.Envelope) error {
+	if env == nil {
+		return errors.New("missing envelope")
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("encode envelope: %w", err)
+	}
+	msg := kafka.Message{
+		Key:   []byte(strings.ToLower(env.Agent)),
+		Value: payload,
+		Time:  env.ReceivedAt,
+		Headers: []kafka.Header{
+			{Key: "kind", Value: []byte(env.Kind)},
+			{Key: "source", Value: []byte(env.Source)},
+		},
+	}
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("publish kafka topic %s: %w", p.topic, err)
+	}
+	return nil
+}
+
+// reportDelivery is the kafka.Writer completion callback for each batch.
+func (p *kafkaPublisher) reportDelivery(messages []kafka.Message, err error) {
+	if err != nil {
+		p.logger.Error("kafka delivery failed", "topic", p.topic, "messages", len(messages), "error", err)
+		return
+	}
+	if len(messages) == 0 {
+		return
+	}
+	last := messages[len(messages)-1]
+	p.logger.Debug("kafka batch delivered", "topic", p.topic, "messages", len(messages), "partition", last.Partition, "offset", last.Offset)
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+// mirroredPublisher publishes to a primary publisher and copies each envelope
+// to best-effort mirrors. Only primary failures are returned to the caller so
+// an analytics sink outage never fails an interaction.
+type mirroredPublisher struct {
+	primary broker.Publisher
+	mirrors []broker.Publisher
+	logger  *logger.Logger
+}
+
+func newMirroredPublisher(primary broker.Publisher, log *logger.Logger, mirrors ...broker.Publisher) *mirroredPublisher {
+	if log == nil {
+		log = logger.Default()
+	}
+	return &mirroredPublisher{primary: primary, mirrors: mirrors, logger: log}
+}
+
+func (p *mirroredPublisher) Publish(ctx context.Context, env *broker.Envelope) error {
+	if err := p.primary.Publish(ctx, env); err != nil {
+		return err
+	}
+	for _, mirror := range p.mirrors {
+		if err := mirror.Publish(ctx, env); err != nil {
+			p.logger.Warn("mirror publish failed", "agent", env.Agent, "key", env.Key, "error", err)
+		}
+	}
+	return nil
+}
+
+func (p *mirroredPublisher) Close() error {
+	errs := []error{p.primary.Close()}
+	for _, mirror := range p.mirrors {
+		errs = append(errs, mirror.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func splitKafkaBrokers(raw string) []string {
+	var brokers []string
+	for _, broker := range strings.Split(raw, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	return brokers
+}
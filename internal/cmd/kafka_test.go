@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+type fakeKafkaWriter struct {
+	messages []kafka.Message
+	closed   bool
+}
+
+func (w *fakeKafkaWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeKafkaWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestKafkaPublisherWritesEnvelope(t *testing.T) {
+	writer := &fakeKafkaWriter{}
+	var logs bytes.Buffer
+	p := &kafkaPublisher{writer: writer, topic: "discord.interactions", logger: logger.New(logger.DebugLevel, "json", &logs)}
+
+	env := &broker.Envelope{Agent: "Claude", Kind: handlerKindCommand, Key: "help", Interaction: json.RawMessage(`{}`), ReceivedAt: time.Unix(1700000000, 0).UTC(), Source: "vibe.discord.server"}
+	if err := p.Publish(context.Background(), env); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if len(writer.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(writer.messages))
+	}
+	msg := writer.messages[0]
+	if string(msg.Key) != "claude" {
+		t.Fatalf("expected lowercased agent key, got %q", msg.Key)
+	}
+	var decoded broker.Envelope
+	if err := json.Unmarshal(msg.Value, &decoded); err != nil {
+		t.Fatalf("decode message value: %v", err)
+	}
+	if decoded.Key != "help" || decoded.Kind != handlerKindCommand {
+		t.Fatalf("unexpected envelope %+v", decoded)
+	}
+
+	p.reportDelivery(writer.messages, errors.New("broker down"))
+	if !strings.Contains(logs.String(), "kafka delivery failed") {
+		t.Fatalf("expected delivery failure log, got %s", logs.String())
+	}
+}
+
+func TestMirroredPublisherIgnoresMirrorFailures(t *testing.T) {
+	primary := &stubPublisher{}
+	mirror := &stubPublisher{err: errors.New("kafka unavailable")}
+	var logs bytes.Buffer
+	p := newMirroredPublisher(primary, logger.New(logger.InfoLevel, "json", &logs), mirror)
+
+	if err := p.Publish(context.Background(), &broker.Envelope{Agent: "claude", Key: "help"}); err != nil {
+		t.Fatalf("mirror failure should not fail publish: %v", err)
+	}
+	if len(primary.envelopes) != 1 {
+		t.Fatalf("expected primary publish, got %d", len(primary.envelopes))
+	}
+	if !strings.Contains(logs.String(), "mirror publish failed") {
+		t.Fatalf("expected mirror warning, got %s", logs.String())
+	}
+
+	primary.err = errors.New("redis down")
+	if err := p.Publish(context.Background(), &broker.Envelope{Agent: "claude"}); err == nil {
+		t.Fatal("expected primary failure to be returned")
+	}
+}
+
+func TestLoadInteractionSettingsKafka(t *testing.T) {
+	t.Setenv(envKafkaBrokers, "k1:9092, k2:9092")
+	t.Setenv(envKafkaTopic, "")
+	settings, err := loadInteractionSettings("")
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	if len(settings.Kafka.Brokers) != 2 || settings.Kafka.Brokers[1] != "k2:9092" {
+		t.Fatalf("unexpected brokers %v", settings.Kafka.Brokers)
+	}
+	if settings.Kafka.enabled() {
+		t.Fatal("kafka should stay disabled without a topic")
+	}
+	if settings.Kafka.BatchSize != defaultKafkaBatchSize || settings.Kafka.BatchTimeout != defaultKafkaBatchTimeout {
+		t.Fatalf("unexpected batch defaults %+v", settings.Kafka)
+	}
+}
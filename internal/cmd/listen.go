@@ -8,84 +8,22 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
 	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
 	"github.com/yourorg/arc-discord/gosdk/discord/client"
 	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/logger"
 	arcer "github.com/yourorg/arc-sdk/errors"
 )
 
-type interactionSubscriber interface {
-	Subscribe(ctx context.Context, handler func(context.Context, []byte) error) error
-	Close() error
-}
-
-type agentRegistryClient interface {
-	Register(context.Context, AgentInfo) error
-	Heartbeat(context.Context, AgentInfo, time.Duration) error
-	Unregister(context.Context, string) error
-	Close() error
-}
-
-type redisSubscriber struct {
-	client    *redis.Client
-	channel   string
-	subscribe func(ctx context.Context, channel string) pubSub
-}
-
-type pubSub interface {
-	Close() error
-	ReceiveMessage(ctx context.Context) (*redis.Message, error)
-}
-
-var newRedisSubscriberFn = newRedisSubscriber
-
-var newAgentRegistryFn = func(cfg redisConfig, ttl time.Duration) (agentRegistryClient, error) {
-	return newAgentRegistry(cfg, ttl)
-}
-
-func newRedisSubscriber(cfg redisConfig, agent string) (interactionSubscriber, error) {
-	channel := fmt.Sprintf("%s:agent:%s", normalizeChannelPrefix(cfg.ChannelPrefix), strings.ToLower(agent))
-	client := redis.NewClient(newRedisOptions(cfg))
-	ctx, cancel := context.WithTimeout(context.Background(), redisPublishTimeout)
-	defer cancel()
-	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("connect redis: %w", err)
-	}
-	return &redisSubscriber{
-		client:  client,
-		channel: channel,
-		subscribe: func(ctx context.Context, channel string) pubSub {
-			return client.Subscribe(ctx, channel)
-		},
-	}, nil
-}
-
-func (s *redisSubscriber) Subscribe(ctx context.Context, handler func(context.Context, []byte) error) error {
-	sub := s.subscribe(ctx, s.channel)
-	defer sub.Close()
-	for {
-		msg, err := sub.ReceiveMessage(ctx)
-		if err != nil {
-			if errors.Is(err, context.Canceled) || errors.Is(err, redis.ErrClosed) {
-				return nil
-			}
-			return err
-		}
-		if handler != nil {
-			if err := handler(ctx, []byte(msg.Payload)); err != nil {
-				return err
-			}
-		}
-	}
-}
-
-func (s *redisSubscriber) Close() error {
-	return s.client.Close()
+var newBrokerFn = func(ctx context.Context, cfg broker.Config) (broker.Broker, error) {
+	return broker.Open(ctx, cfg)
 }
 
 type outputPrinter interface {
@@ -97,11 +35,33 @@ type interactionResponder interface {
 	CreateFollowupMessage(ctx context.Context, applicationID, token string, params *types.MessageCreateParams) (*types.Message, error)
 }
 
+// agentResponder produces the reply for an envelope instead of the built-in
+// acknowledgement. payload is the raw envelope JSON as received.
+type agentResponder interface {
+	Respond(ctx context.Context, env *broker.Envelope, payload []byte) (*types.MessageEditParams, error)
+}
+
 type agentListener struct {
 	agentID       string
 	applicationID string
 	client        interactionResponder
 	output        outputPrinter
+	responder     agentResponder
+	// newerEnvelope warns once about envelopes from a newer server.
+	newerEnvelope sync.Once
+	handled       recentIDs
+	// replies, when set, receives responses for the server's reply queue
+	// instead of client; unqueued warns once about servers without one.
+	replies  broker.ResponseBus
+	unqueued sync.Once
+	// templates renders interaction templates in handler replies.
+	templates bool
+	// progressInterval, when set, edits the original response with progress
+	// while the responder runs.
+	progressInterval time.Duration
+	// log, when set, receives one record per envelope in place of the
+	// per-envelope output lines.
+	log *logger.Logger
 }
 
 func newAgentListener(agentID, appID string, cli interactionResponder, out outputPrinter) *agentListener {
@@ -114,12 +74,45 @@ func newAgentListener(agentID, appID string, cli interactionResponder, out outpu
 }
 
 func (l *agentListener) handlePayload(ctx context.Context, payload []byte) error {
-	var env redisEnvelope
-	if err := json.Unmarshal(payload, &env); err != nil {
-		l.output.Printf("invalid payload: %v\n", err)
+	rec := &envelopeRecord{outcome: envelopeProcessed}
+	start := time.Now()
+	err := l.processPayload(ctx, payload, rec)
+	if err != nil {
+		rec.outcome, rec.err = envelopeFailed, err
+	}
+	l.logEnvelope(rec, time.Since(start))
+	return err
+}
+
+func (l *agentListener) processPayload(ctx context.Context, payload []byte, rec *envelopeRecord) error {
+	// Responders get the envelope JSON even if the server compressed it.
+	payload, err := broker.EnvelopeJSON(payload)
+	if err != nil {
+		rec.outcome, rec.err = envelopeInvalid, err
+		l.textf("invalid payload: %v\n", err)
 		return nil
 	}
+	env, err := broker.DecodeEnvelope(payload)
+	if err != nil {
+		rec.outcome, rec.err = envelopeInvalid, err
+		l.textf("invalid payload: %v\n", err)
+		return nil
+	}
+	if env.Version > broker.EnvelopeVersion {
+		// Handle it with the fields this agent knows rather than drop it,
+		// so agents keep serving while a server upgrade rolls out.
+		l.newerEnvelope.Do(func() {
+			l.output.Printf("Warning: the server sends envelope version %d; this agent understands %d, so newer fields are ignored (upgrade arc-discord)\n", env.Version, broker.EnvelopeVersion)
+		})
+	}
 	if strings.ToLower(env.Agent) != strings.ToLower(l.agentID) {
+		rec.outcome = envelopeIgnored
+		return nil
+	}
+	rec.env = env
+	if env.ID != "" && !l.handled.add(env.ID) {
+		rec.outcome = envelopeSkipped
+		l.textf("Skipping redelivered %s interaction %s (attempt %d)\n", env.Kind, env.Key, env.Attempt)
 		return nil
 	}
 	var interaction types.Interaction
@@ -129,18 +122,67 @@ func (l *agentListener) handlePayload(ctx context.Context, payload []byte) error
 	if interaction.Token == "" {
 		return fmt.Errorf("interaction missing token")
 	}
-	opCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	target := l.clientFor(env, &interaction)
+	if l.responder != nil {
+		return l.respond(ctx, target, env, &interaction, payload, rec)
+	}
 	content := fmt.Sprintf("Agent %s received %s `%s` at %s", l.agentID, env.Kind, env.Key, time.Now().Format(time.RFC3339))
 	params := &types.MessageEditParams{Content: content}
-	if _, err := l.client.EditOriginalInteractionResponse(opCtx, l.applicationID, interaction.Token, params); err != nil {
+	if _, err := target.EditOriginalInteractionResponse(ctx, l.applicationID, interaction.Token, params); err != nil {
 		return fmt.Errorf("edit original response: %w", err)
 	}
 	followup := &types.MessageCreateParams{Content: fmt.Sprintf("Follow-up: %s completed %s `%s`", l.agentID, env.Kind, env.Key)}
-	if _, err := l.client.CreateFollowupMessage(opCtx, l.applicationID, interaction.Token, followup); err != nil {
+	if env.Ephemeral {
+		followup.Flags = types.MessageFlagEphemeral
+	}
+	if _, err := target.CreateFollowupMessage(ctx, l.applicationID, interaction.Token, followup); err != nil {
 		return fmt.Errorf("create followup response: %w", err)
 	}
-	l.output.Printf("Processed %s interaction %s\n", env.Kind, env.Key)
+	l.textf("Processed %s interaction %s%s\n", env.Kind, env.Key, requestRef(env))
+	return nil
+}
+
+// clientFor returns what answers the interaction: a queuedResponder in
+// reply-queue mode, else the Discord client, retrying transient failures
+// while the interaction token is valid.
+func (l *agentListener) clientFor(env *broker.Envelope, interaction *types.Interaction) interactionResponder {
+	next := l.client
+	if l.replies != nil {
+		if !env.ReplyQueue {
+			l.unqueued.Do(func() {
+				l.output.Printf("Warning: the server does not run a reply queue (interactions.reply_queue), so responses from this agent will not reach Discord\n")
+			})
+		}
+		next = &queuedResponder{bus: l.replies, agent: l.agentID, interactionID: interaction.ID}
+	}
+	retrying := newRetryingResponder(next, interaction)
+	retrying.retried = func(op string, attempt int, wait time.Duration, err error) {
+		l.output.Printf("Retrying %s for %s interaction %s%s in %s (attempt %d): %v\n", op, env.Kind, env.Key, requestRef(env), wait, attempt, err)
+	}
+	return retrying
+}
+
+// respond posts the responder's reply as the original response. Handler
+// failures are reported in Discord and logged rather than stopping the agent.
+func (l *agentListener) respond(ctx context.Context, target interactionResponder, env *broker.Envelope, interaction *types.Interaction, payload []byte, rec *envelopeRecord) error {
+	progress := l.startProgress(ctx, target, env, interaction)
+	params, err := l.responder.Respond(progress.context(ctx), env, payload)
+	progress.finish()
+	if err != nil {
+		rec.outcome, rec.err = envelopeHandlerFailed, err
+		l.textf("Handler failed for %s interaction %s%s: %v\n", env.Kind, env.Key, requestRef(env), err)
+		params = &types.MessageEditParams{Content: fmt.Sprintf("Agent %s could not handle %s `%s`.", l.agentID, env.Kind, env.Key)}
+	} else if l.templates {
+		if err := renderMessageTemplates(params, interaction); err != nil {
+			rec.outcome, rec.err = envelopeHandlerFailed, err
+			l.textf("Template failed for %s interaction %s%s: %v\n", env.Kind, env.Key, requestRef(env), err)
+			params = &types.MessageEditParams{Content: fmt.Sprintf("Agent %s could not handle %s `%s`.", l.agentID, env.Kind, env.Key)}
+		}
+	}
+	if _, err := target.EditOriginalInteractionResponse(ctx, l.applicationID, interaction.Token, params); err != nil {
+		return fmt.Errorf("edit original response: %w", err)
+	}
+	l.textf("Processed %s interaction %s%s\n", env.Kind, env.Key, requestRef(env))
 	return nil
 }
 
@@ -153,28 +195,123 @@ func agentCmd(opts *globalOptions) *cobra.Command {
 		},
 	}
 	cmd.AddCommand(agentListenCmd(opts))
+	cmd.AddCommand(agentListCmd(opts))
+	cmd.AddCommand(agentStatusCmd(opts))
+	cmd.AddCommand(agentPruneCmd(opts))
 	return cmd
 }
 
 func agentListenCmd(opts *globalOptions) *cobra.Command {
 	var (
-		agentID     string
-		redisAddr   string
-		redisDB     int
-		redisPass   string
-		redisPrefix string
+		agentID         string
+		redisAddr       string
+		redisDB         int
+		redisPass       string
+		redisPrefix     string
+		caps            []string
+		version         string
+		commit          string
+		execCommand     string
+		execTimeout     time.Duration
+		forwardURL      string
+		forwardAttempts int
+		instance        string
+		replyQueue      bool
+		templates       bool
+		progressEvery   time.Duration
+		concurrency     int
+		orderBy         string
+		logFormat       string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "listen",
 		Short: "Subscribe to interaction events and respond via the Discord API",
+		Long: `Subscribe to interaction events routed to this agent and respond via the Discord API.
+
+The agent registers itself in the Redis registry with the capabilities derived from the
+interaction handlers in discord.yaml. Use --capability to declare extra capabilities the running
+binary supports; they are merged into the registry entry and shown by "agent list".
+
+--version and --commit (default $VIBE_AGENT_VERSION and $VIBE_AGENT_COMMIT) record which build is
+running, alongside the start time, so operators can confirm a rollout before routing traffic.
+
+--exec runs a command for every interaction instead of the built-in acknowledgement. The envelope
+JSON is written to its stdin and stdout is posted as the response: either a JSON object with
+content, embeds, and components, or plain text used as the message content. VIBE_AGENT_ID,
+VIBE_INTERACTION_KIND, and VIBE_INTERACTION_KEY are set in its environment.
+
+--forward-url POSTs the envelope JSON to a local HTTP endpoint instead and reads the same reply
+format from the response body. Connection errors, 429s, and 5xx responses are retried with backoff
+within the envelope's timeout budget.
+
+--reply-queue publishes responses to the broker for the server to deliver (interactions.reply_queue
+in the server's discord.yaml), so the agent needs no bot token or application ID.
+
+--templates renders replies as Go templates with the interaction as dot, using the same helpers as
+respond blocks in discord.yaml: {{.Member.User.Username}}, {{option "environment"}},
+{{invoker | mention}}, {{discordRelative now}}, and {{code "go" .Data.Name}}.
+
+--progress-interval edits the response while the handler runs with a spinner, the elapsed time,
+and the latest "progress: 40 building image" line an --exec handler wrote to stderr (the percent
+is optional), then replaces it with the reply.
+
+--concurrency handles up to N interactions at once. Envelopes for the same interaction token (or,
+with --order-by channel, the same channel) run on the same worker in arrival order, so an edit
+and the followup after it never race.
+
+--log-format json writes one JSON record per envelope (agent, kind, key, outcome, latency_ms, and
+error) and the listener's other messages as JSON log lines to stdout, for log pipelines. Text
+output goes to stderr.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateCapabilities(caps); err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "pass names like --capability summarize --capability deploy:staging"}
+			}
+			if execCommand != "" && forwardURL != "" {
+				return &arcer.CLIError{Msg: "--exec and --forward-url are mutually exclusive"}
+			}
+			if forwardURL != "" {
+				if err := validateForwardURL(forwardURL); err != nil {
+					return &arcer.CLIError{Msg: err.Error(), Hint: "for example --forward-url http://localhost:9000/hook"}
+				}
+			}
+			if cmd.Flags().Changed("exec") {
+				if err := validateExecCommand(execCommand); err != nil {
+					return &arcer.CLIError{Msg: err.Error(), Hint: "for example --exec \"./my-handler\""}
+				}
+			}
+			if err := validateProgressInterval(progressEvery); err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "for example --progress-interval 5s"}
+			}
+			if err := validateConcurrency(concurrency, orderBy); err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "for example --concurrency 4 --order-by channel"}
+			}
+			if err := validateAgentLogFormat(logFormat); err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "use --log-format text or --log-format json"}
+			}
+			if err := validateInstanceName(instance); err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "use lowercase letters, digits, - and _"}
+			}
 			return runAgentListen(cmd, opts, agentListenOptions{
-				AgentID:     agentID,
-				RedisAddr:   redisAddr,
-				RedisDB:     redisDB,
-				RedisPass:   redisPass,
-				RedisPrefix: redisPrefix,
+				AgentID:         agentID,
+				Instance:        instance,
+				RedisAddr:       redisAddr,
+				RedisDB:         redisDB,
+				RedisPass:       redisPass,
+				RedisPrefix:     redisPrefix,
+				Capabilities:    caps,
+				Version:         version,
+				Commit:          commit,
+				Exec:            execCommand,
+				ExecTimeout:     execTimeout,
+				ForwardURL:      forwardURL,
+				ForwardAttempts: forwardAttempts,
+				ReplyQueue:      replyQueue,
+				Templates:       templates,
+				Progress:        progressEvery,
+				Concurrency:     concurrency,
+				OrderBy:         orderBy,
+				LogFormat:       logFormat,
 			})
 		},
 		Example: `Example:
@@ -184,23 +321,78 @@ Example:
   VIBE_AGENT_ID=triage arc-discord agent listen --redis-addr redis://localhost:6379
 
 Example:
-  VIBE_AGENT_ID=reviewer arc-discord agent listen --redis-prefix arc:discord --redis-db 1`,
+  VIBE_AGENT_ID=reviewer arc-discord agent listen --redis-prefix arc:discord --redis-db 1
+
+Example:
+  VIBE_AGENT_ID=ops arc-discord agent listen --capability summarize --capability deploy:staging
+
+Example:
+  VIBE_AGENT_ID=ops arc-discord agent listen --version 1.4.0-rc1 --commit $(git rev-parse HEAD)
+
+Example:
+  VIBE_AGENT_ID=py arc-discord agent listen --exec "python3 handler.py" --exec-timeout 20s
+
+Example:
+  VIBE_AGENT_ID=node arc-discord agent listen --forward-url http://localhost:9000/hook
+
+Example:
+  VIBE_AGENT_ID=ops arc-discord agent listen --reply-queue --exec ./handler
+
+Example:
+  VIBE_AGENT_ID=ops arc-discord agent listen --templates --exec ./handler
+
+Example:
+  VIBE_AGENT_ID=build arc-discord agent listen --progress-interval 5s --exec ./build.sh
+
+Example:
+  VIBE_AGENT_ID=ops arc-discord agent listen --concurrency 8 --order-by channel --exec ./handler
+
+Example:
+  VIBE_AGENT_ID=ops arc-discord agent listen --log-format json --exec ./handler | vector --config agents.toml`,
 	}
 
 	cmd.Flags().StringVar(&agentID, "agent", "", "Agent identifier (default $VIBE_AGENT_ID)")
-	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis address for subscriptions")
+	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis host:port or redis[s]://user:pass@host:port/db URL for subscriptions")
 	cmd.Flags().IntVar(&redisDB, "redis-db", 0, "Redis database index")
 	cmd.Flags().StringVar(&redisPass, "redis-password", "", "Redis password")
 	cmd.Flags().StringVar(&redisPrefix, "redis-prefix", "", "Redis channel prefix (default arc:discord)")
+	cmd.Flags().StringVar(&instance, "instance", "", "Server instance to listen to (uses its default channel prefix arc:discord:<instance>)")
+	cmd.Flags().StringArrayVar(&caps, "capability", nil, "Declare an extra capability for the registry entry (repeatable)")
+	cmd.Flags().StringVar(&version, "version", "", "Agent build version for the registry entry (default $VIBE_AGENT_VERSION)")
+	cmd.Flags().StringVar(&commit, "commit", "", "Agent git commit for the registry entry (default $VIBE_AGENT_COMMIT)")
+	cmd.Flags().StringVar(&execCommand, "exec", "", "Run this command per interaction (envelope JSON on stdin, reply JSON on stdout)")
+	cmd.Flags().DurationVar(&execTimeout, "exec-timeout", 0, "Time limit for each --exec run or --forward-url delivery (default the envelope timeout, else 30s)")
+	cmd.Flags().StringVar(&forwardURL, "forward-url", "", "POST each interaction to this URL and use the JSON response as the reply")
+	cmd.Flags().IntVar(&forwardAttempts, "forward-attempts", defaultForwardAttempts, "Delivery attempts per interaction for --forward-url")
+	cmd.Flags().BoolVar(&replyQueue, "reply-queue", false, "Publish responses for the server to deliver instead of calling Discord")
+	cmd.Flags().BoolVar(&templates, "templates", false, "Render handler replies as templates over the interaction")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 1, "Interactions to handle at once, ordered per --order-by key")
+	cmd.Flags().StringVar(&orderBy, "order-by", orderByToken, "Key that keeps envelopes in order with --concurrency: token or channel")
+	cmd.Flags().StringVar(&logFormat, "log-format", agentLogText, "Output format: text, or json for one record per envelope")
+	cmd.Flags().DurationVar(&progressEvery, "progress-interval", 0, "Edit the response with progress at this interval while the handler runs (minimum 2s)")
 	return cmd
 }
 
 type agentListenOptions struct {
-	AgentID     string
-	RedisAddr   string
-	RedisDB     int
-	RedisPass   string
-	RedisPrefix string
+	AgentID         string
+	Instance        string
+	RedisAddr       string
+	RedisDB         int
+	RedisPass       string
+	RedisPrefix     string
+	Capabilities    []string
+	Version         string
+	Commit          string
+	Exec            string
+	ExecTimeout     time.Duration
+	ForwardURL      string
+	ForwardAttempts int
+	ReplyQueue      bool
+	Templates       bool
+	Progress        time.Duration
+	Concurrency     int
+	OrderBy         string
+	LogFormat       string
 }
 
 func runAgentListen(cmd *cobra.Command, opts *globalOptions, overrides agentListenOptions) error {
@@ -228,30 +420,48 @@ func runAgentListen(cmd *cobra.Command, opts *globalOptions, overrides agentList
 	if overrides.RedisPrefix != "" {
 		extra.Redis.ChannelPrefix = overrides.RedisPrefix
 	}
-	extra.Redis.ChannelPrefix = normalizeChannelPrefix(extra.Redis.ChannelPrefix)
-	if cfg.Discord.ApplicationID == "" {
-		return &arcer.CLIError{Msg: "discord.application_id is required to edit responses"}
+	extra.Redis.ChannelPrefix = normalizeChannelPrefix(instanceChannelPrefix(extra.Redis.ChannelPrefix, overrides.Instance))
+	if cfg.Discord.ApplicationID == "" && !overrides.ReplyQueue {
+		return &arcer.CLIError{Msg: "discord.application_id is required to edit responses", Hint: "or pass --reply-queue to have the server respond"}
 	}
 
-	redisSub, err := newRedisSubscriberFn(extra.Redis, agentID)
+	brokerCfg, err := extra.brokerConfig()
 	if err != nil {
-		return (&arcer.CLIError{Msg: "failed to connect to redis"}).WithCause(err)
+		return &arcer.CLIError{Msg: err.Error(), Hint: "fix the redis section in discord.yaml"}
 	}
-	defer redisSub.Close()
-
-	interactionClient, err := newInteractionClientFn(cfg, opts.tokenOverride)
+	b, err := newBrokerFn(cmd.Context(), brokerCfg)
 	if err != nil {
-		return (&arcer.CLIError{Msg: "failed to initialize interaction client"}).WithCause(err)
+		return (&arcer.CLIError{Msg: "failed to connect to broker"}).WithCause(err)
 	}
+	defer b.Close()
 
-	registry, err := newAgentRegistryFn(extra.Redis, defaultRegistryTTL)
-	if err != nil {
-		return (&arcer.CLIError{Msg: "failed to initialize agent registry"}).WithCause(err)
+	var (
+		interactionClient interactionResponder
+		replies           broker.ResponseBus
+	)
+	if overrides.ReplyQueue {
+		bus, ok := b.(broker.ResponseBus)
+		if !ok {
+			return &arcer.CLIError{Msg: fmt.Sprintf("--reply-queue is not supported by the %q broker", brokerCfg.Backend)}
+		}
+		replies = bus
+	} else {
+		interactionClient, err = newAgentInteractionClientFn(cfg, opts.tokenOverride)
+		if err != nil {
+			return (&arcer.CLIError{Msg: "failed to initialize interaction client"}).WithCause(err)
+		}
 	}
-	defer registry.Close()
 
-	channelName := fmt.Sprintf("%s:agent:%s", normalizeChannelPrefix(extra.Redis.ChannelPrefix), strings.ToLower(agentID))
-	info := agentInfo(agentID, extra.Interactions.Handlers, channelName)
+	registry := b.Registry()
+	channelName := brokerCfg.AgentChannel(agentID)
+	build := agentBuild{Version: overrides.Version, Commit: overrides.Commit}
+	if build.Version == "" {
+		build.Version = strings.TrimSpace(os.Getenv(envAgentVersion))
+	}
+	if build.Commit == "" {
+		build.Commit = strings.TrimSpace(os.Getenv(envAgentCommit))
+	}
+	info := agentInfo(agentID, extra.Interactions.Handlers, channelName, overrides.Capabilities, build)
 	baseCtx := cmd.Context()
 	if err := registry.Register(baseCtx, info); err != nil {
 		return (&arcer.CLIError{Msg: "failed to register agent"}).WithCause(err)
@@ -262,15 +472,48 @@ func runAgentListen(cmd *cobra.Command, opts *globalOptions, overrides agentList
 	go registry.Heartbeat(hbCtx, info, defaultHeartbeatInterval)
 	defer registry.Unregister(context.Background(), agentID)
 
-	listener := newAgentListener(agentID, cfg.Discord.ApplicationID, interactionClient, cmd)
+	out, log := agentListenOutput(cmd, overrides.LogFormat)
+	listener := newAgentListener(agentID, cfg.Discord.ApplicationID, interactionClient, out)
+	listener.log = log
+	listener.replies = replies
+	listener.templates = overrides.Templates
+	listener.progressInterval = overrides.Progress
+	if replies != nil {
+		out.Printf("Publishing responses to the server's reply queue\n")
+	}
+	if overrides.Exec != "" {
+		listener.responder = &execResponder{command: overrides.Exec, timeout: overrides.ExecTimeout, stderr: cmd.ErrOrStderr()}
+		out.Printf("Running %q for each interaction\n", overrides.Exec)
+	}
+	if overrides.ForwardURL != "" {
+		listener.responder = &forwardResponder{url: overrides.ForwardURL, attempts: overrides.ForwardAttempts, timeout: overrides.ExecTimeout}
+		out.Printf("Forwarding interactions to %s\n", overrides.ForwardURL)
+	}
 
-	cmd.Printf("Listening for interactions as agent %s (channel prefix %s)\n", agentID, extra.Redis.ChannelPrefix)
-	ctx, stop := signal.NotifyContext(baseCtx, os.Interrupt)
+	out.Printf("Listening for interactions as agent %s (channel prefix %s)\n", agentID, extra.Redis.ChannelPrefix)
+	ctx, stop := signal.NotifyContext(baseCtx, os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	err = redisSub.Subscribe(ctx, func(ctx context.Context, payload []byte) error {
-		return listener.handlePayload(ctx, payload)
-	})
+	// A signal stops the subscription, but the envelope being handled runs
+	// on baseCtx so its response is still delivered before exiting.
+	handle := func(_ context.Context, msg *broker.Message) error {
+		if err := listener.handlePayload(baseCtx, msg.Payload); err != nil {
+			return err
+		}
+		return b.Ack(baseCtx, msg)
+	}
+	if overrides.Concurrency > 1 {
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		shards := newShardedHandler(baseCtx, overrides.Concurrency, overrides.OrderBy, handle, cancel)
+		out.Printf("Handling up to %d interactions at once, ordered by %s\n", overrides.Concurrency, overrides.OrderBy)
+		err = b.Subscribe(subCtx, agentID, shards.submit)
+		if closeErr := shards.close(); err == nil {
+			err = closeErr
+		}
+	} else {
+		err = b.Subscribe(ctx, agentID, handle)
+	}
 	if err != nil {
 		return (&arcer.CLIError{Msg: "listener exited with error"}).WithCause(err)
 	}
@@ -287,20 +530,48 @@ func createInteractionClient(cfg *discordconfig.Config, token string) (interacti
 	return interactions.NewInteractionClient(rawClient), nil
 }
 
-func createRawDiscordClient(cfg *discordconfig.Config, token string) (*client.Client, error) {
+var newAgentInteractionClientFn = createAgentInteractionClient
+
+// createAgentInteractionClient builds the listener's client with the client's
+// own retries off: retryingResponder retries instead, so a 429 is sent again
+// once, after its retry_after, and every wait counts against the token.
+func createAgentInteractionClient(cfg *discordconfig.Config, token string) (interactionResponder, error) {
+	rawClient, err := createRawDiscordClient(cfg, token, client.WithMaxRetries(0))
+	if err != nil {
+		return nil, err
+	}
+	return interactions.NewInteractionClient(rawClient), nil
+}
+
+func createRawDiscordClient(cfg *discordconfig.Config, token string, extra ...client.Option) (*client.Client, error) {
 	if cfg == nil {
 		cfg = discordconfig.Default()
 	}
+	var fallbacks []string
 	if token == "" {
-		token = cfg.Discord.BotToken
-	}
-	if token == "" {
-		return nil, errors.New("no bot token configured")
+		tokens := cfg.Discord.Tokens()
+		if len(tokens) == 0 {
+			return nil, errors.New("no bot token configured")
+		}
+		token, fallbacks = tokens[0], tokens[1:]
 	}
 	opts := []client.Option{
+		client.WithFallbackTokens(fallbacks...),
 		client.WithTimeout(cfg.Client.Timeout),
 		client.WithMaxRetries(cfg.Client.Retries),
 		client.WithStrategyName(cfg.Client.RateLimit.Strategy),
+		client.WithAPIVersion(cfg.Client.APIVersion),
+		client.WithFeatures(cfg.Client.Features),
+		client.WithRateLimitObserver(rateLimitRecorder),
+	}
+	return client.New(token, append(opts, extra...)...)
+}
+
+// requestRef names the server request that published env, so agent output
+// can be matched to the server's access log.
+func requestRef(env *broker.Envelope) string {
+	if env.RequestID == "" {
+		return ""
 	}
-	return client.New(token, opts...)
+	return " (request " + env.RequestID + ")"
 }
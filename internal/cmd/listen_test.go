@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,8 +13,11 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
 	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	"github.com/yourorg/arc-sdk/output"
 )
 
 type stubInteractionResponder struct {
@@ -42,7 +47,7 @@ func (s *stubInteractionResponder) CreateFollowupMessage(ctx context.Context, ap
 	return &types.Message{ID: "456"}, s.followupErr
 }
 
-func mustEnvelope(t *testing.T, env *redisEnvelope) []byte {
+func mustEnvelope(t *testing.T, env *broker.Envelope) []byte {
 	t.Helper()
 	data, err := json.Marshal(env)
 	if err != nil {
@@ -56,7 +61,7 @@ func TestAgentListenerHandlePayloadEditsResponse(t *testing.T) {
 	listener := newAgentListener("claude", "app123", responder, testPrinter{t})
 	interaction := types.Interaction{Token: "tok", Type: types.InteractionTypeApplicationCommand}
 	raw, _ := json.Marshal(interaction)
-	env := &redisEnvelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
+	env := &broker.Envelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
 
 	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
 		t.Fatalf("handlePayload: %v", err)
@@ -78,12 +83,26 @@ func TestAgentListenerHandlePayloadEditsResponse(t *testing.T) {
 	}
 }
 
+func TestAgentListenerEphemeralFollowup(t *testing.T) {
+	responder := &stubInteractionResponder{}
+	listener := newAgentListener("claude", "app123", responder, testPrinter{t})
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+	env := &broker.Envelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw, Ephemeral: true}
+
+	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
+		t.Fatalf("handlePayload: %v", err)
+	}
+	if responder.followupParams == nil || responder.followupParams.Flags != types.MessageFlagEphemeral {
+		t.Fatalf("expected ephemeral followup, got %+v", responder.followupParams)
+	}
+}
+
 func TestAgentListenerHandlePayloadSkipsOtherAgent(t *testing.T) {
 	responder := &stubInteractionResponder{}
 	listener := newAgentListener("codex", "app123", responder, testPrinter{t})
 	interaction := types.Interaction{Token: "tok"}
 	raw, _ := json.Marshal(interaction)
-	env := &redisEnvelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
+	env := &broker.Envelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
 
 	if err := listener.handlePayload(context.Background(), mustEnvelope(t, env)); err != nil {
 		t.Fatalf("handlePayload: %v", err)
@@ -98,7 +117,7 @@ func TestAgentListenerHandlePayloadErrorOnMissingToken(t *testing.T) {
 	listener := newAgentListener("claude", "app123", responder, testPrinter{t})
 	interaction := types.Interaction{}
 	raw, _ := json.Marshal(interaction)
-	env := &redisEnvelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
+	env := &broker.Envelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
 
 	err := listener.handlePayload(context.Background(), mustEnvelope(t, env))
 	if err == nil || !strings.Contains(err.Error(), "token") {
@@ -111,7 +130,7 @@ func TestAgentListenerFollowupError(t *testing.T) {
 	listener := newAgentListener("claude", "app123", responder, testPrinter{t})
 	interaction := types.Interaction{Token: "tok"}
 	raw, _ := json.Marshal(interaction)
-	env := &redisEnvelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
+	env := &broker.Envelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
 
 	err := listener.handlePayload(context.Background(), mustEnvelope(t, env))
 	if err == nil || !strings.Contains(err.Error(), "followup") {
@@ -125,7 +144,7 @@ func TestAgentListenerHandlePayloadResponderError(t *testing.T) {
 	listener := newAgentListener("claude", "app123", responder, testPrinter{t})
 	interaction := types.Interaction{Token: "tok"}
 	raw, _ := json.Marshal(interaction)
-	env := &redisEnvelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
+	env := &broker.Envelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw}
 
 	err := listener.handlePayload(context.Background(), mustEnvelope(t, env))
 	if !errors.Is(err, stubErr) {
@@ -146,42 +165,95 @@ func TestAgentListenerHandlePayloadInvalidJSON(t *testing.T) {
 }
 
 // testPrinter satisfies outputPrinter for tests.
+func TestAgentListenerHandlesNewerCompressedEnvelopes(t *testing.T) {
+	responder := &stubInteractionResponder{}
+	agent := &stubAgentResponder{params: &types.MessageEditParams{Content: "done"}}
+	out := &bufferPrinter{}
+	listener := newAgentListener("claude", "app123", responder, out)
+	listener.responder = agent
+	raw, _ := json.Marshal(types.Interaction{Token: "tok"})
+	data, _ := json.Marshal(map[string]any{"version": broker.EnvelopeVersion + 1, "agent": "claude", "kind": "command", "key": "help", "interaction": json.RawMessage(raw), "trace": "abc"})
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	zw.Write(data)
+	zw.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := listener.handlePayload(context.Background(), compressed.Bytes()); err != nil {
+			t.Fatalf("handlePayload: %v", err)
+		}
+	}
+	if !responder.called || !bytes.Equal(agent.payload, data) {
+		t.Fatalf("expected the responder to get the envelope JSON, got %q", agent.payload)
+	}
+	if n := strings.Count(out.String(), "envelope version 2"); n != 1 {
+		t.Fatalf("expected one version warning, got %d in %q", n, out.String())
+	}
+}
+
 type testPrinter struct{ t *testing.T }
 
 func (tp testPrinter) Printf(format string, args ...interface{}) { tp.t.Logf(format, args...) }
 
-type stubInteractionSubscriber struct {
+// stubBroker delivers a single payload and records registry calls.
+type stubBroker struct {
 	payload []byte
-	closed  bool
 	err     error
+	closed  bool
+	acked   int
+	reg     *stubRegistry
 }
 
-func (s *stubInteractionSubscriber) Subscribe(ctx context.Context, handler func(context.Context, []byte) error) error {
+func (s *stubBroker) Publish(ctx context.Context, env *broker.Envelope) error { return nil }
+
+func (s *stubBroker) Subscribe(ctx context.Context, agent string, handler broker.Handler) error {
 	if handler != nil && len(s.payload) > 0 {
-		if err := handler(ctx, s.payload); err != nil {
+		if err := handler(ctx, &broker.Message{Agent: agent, Payload: s.payload}); err != nil {
 			return err
 		}
 	}
 	return s.err
 }
 
-func (s *stubInteractionSubscriber) Close() error {
+func (s *stubBroker) Ack(ctx context.Context, msg *broker.Message) error {
+	s.acked++
+	return nil
+}
+
+func (s *stubBroker) Registry() broker.Registry { return s.reg }
+
+func (s *stubBroker) Close() error {
 	s.closed = true
 	return nil
 }
 
+func hookBroker(t *testing.T, b *stubBroker) {
+	newBrokerFn = func(context.Context, broker.Config) (broker.Broker, error) { return b, nil }
+	t.Cleanup(func() {
+		newBrokerFn = func(ctx context.Context, cfg broker.Config) (broker.Broker, error) {
+			return broker.Open(ctx, cfg)
+		}
+	})
+}
+
 type stubRegistry struct {
 	registered   int
 	unregistered int
-	closed       bool
+	lastInfo     broker.AgentInfo
+	agents       []broker.AgentInfo
 }
 
-func (s *stubRegistry) Register(ctx context.Context, info AgentInfo) error {
+func (s *stubRegistry) Register(ctx context.Context, info broker.AgentInfo) error {
 	s.registered++
+	s.lastInfo = info
 	return nil
 }
 
-func (s *stubRegistry) Heartbeat(ctx context.Context, info AgentInfo, interval time.Duration) error {
+func (s *stubRegistry) List(ctx context.Context) ([]broker.AgentInfo, error) {
+	return s.agents, nil
+}
+
+func (s *stubRegistry) Heartbeat(ctx context.Context, info broker.AgentInfo, interval time.Duration) error {
 	<-ctx.Done()
 	return ctx.Err()
 }
@@ -191,11 +263,6 @@ func (s *stubRegistry) Unregister(ctx context.Context, agent string) error {
 	return nil
 }
 
-func (s *stubRegistry) Close() error {
-	s.closed = true
-	return nil
-}
-
 func TestRunAgentListenRegistersAndExits(t *testing.T) {
 	dir := t.TempDir()
 	config := `discord:
@@ -214,27 +281,21 @@ interactions:
 	}
 	interaction := types.Interaction{Token: "tok"}
 	raw, _ := json.Marshal(interaction)
-	payload, _ := json.Marshal(&redisEnvelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw})
-	stubSub := &stubInteractionSubscriber{payload: payload}
-	newRedisSubscriberFn = func(cfg redisConfig, agent string) (interactionSubscriber, error) { return stubSub, nil }
-	t.Cleanup(func() { newRedisSubscriberFn = newRedisSubscriber })
-	responder := &stubInteractionResponder{}
-	newInteractionClientFn = func(cfg *discordconfig.Config, token string) (interactionResponder, error) { return responder, nil }
-	t.Cleanup(func() { newInteractionClientFn = createInteractionClient })
+	payload, _ := json.Marshal(&broker.Envelope{Agent: "claude", Kind: handlerKindCommand, Key: "help", Interaction: raw})
 	reg := &stubRegistry{}
-	newAgentRegistryFn = func(cfg redisConfig, ttl time.Duration) (agentRegistryClient, error) { return reg, nil }
-	t.Cleanup(func() {
-		newAgentRegistryFn = func(cfg redisConfig, ttl time.Duration) (agentRegistryClient, error) {
-			return newAgentRegistry(cfg, ttl)
-		}
-	})
+	stub := &stubBroker{payload: payload, reg: reg}
+	hookBroker(t, stub)
+	responder := &stubInteractionResponder{}
+	newAgentInteractionClientFn = func(cfg *discordconfig.Config, token string) (interactionResponder, error) { return responder, nil }
+	t.Cleanup(func() { newAgentInteractionClientFn = createAgentInteractionClient })
+	t.Setenv(envAgentCommit, "abc1234def")
 	cmd := &cobra.Command{}
 	ctx, cancel := context.WithCancel(context.Background())
 	cmd.SetContext(ctx)
 	opts := &globalOptions{configPath: path}
 	done := make(chan error, 1)
 	go func() {
-		done <- runAgentListen(cmd, opts, agentListenOptions{AgentID: "claude"})
+		done <- runAgentListen(cmd, opts, agentListenOptions{AgentID: "claude", Capabilities: []string{"Deploy:staging", "command:help"}, Version: "1.4.0"})
 	}()
 	time.Sleep(10 * time.Millisecond)
 	cancel()
@@ -246,13 +307,71 @@ interactions:
 	case <-time.After(time.Second):
 		t.Fatalf("runAgentListen did not return")
 	}
-	if !stubSub.closed {
-		t.Fatalf("expected subscriber close")
+	if !stub.closed {
+		t.Fatalf("expected broker close")
+	}
+	if stub.acked != 1 {
+		t.Fatalf("expected message ack, got %d", stub.acked)
 	}
-	if reg.registered == 0 || reg.unregistered == 0 || !reg.closed {
+	if reg.registered == 0 || reg.unregistered == 0 {
 		t.Fatalf("registry not cleaned up: %+v", reg)
 	}
+	if got := strings.Join(reg.lastInfo.Capabilities, ","); got != "command:help,deploy:staging" {
+		t.Fatalf("unexpected registered capabilities %q", got)
+	}
+	if reg.lastInfo.Version != "1.4.0" || reg.lastInfo.Commit != "abc1234def" || reg.lastInfo.StartedAt.IsZero() {
+		t.Fatalf("unexpected build metadata %+v", reg.lastInfo)
+	}
 	if !responder.called {
 		t.Fatalf("expected interaction responder call")
 	}
 }
+
+func TestFormatHeartbeatAge(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	if got := formatHeartbeatAge(now.Add(-75*time.Second), now); got != "1m15s ago" {
+		t.Fatalf("unexpected age %q", got)
+	}
+	if got := formatHeartbeatAge(time.Time{}, now); got != "-" {
+		t.Fatalf("expected dash for missing heartbeat, got %q", got)
+	}
+	if got := heartbeatAge(now.Add(time.Second), now); got != 0 {
+		t.Fatalf("expected clock skew to clamp to zero, got %v", got)
+	}
+}
+
+func TestAgentListFiltersByCapability(t *testing.T) {
+	reg := &stubRegistry{agents: []broker.AgentInfo{
+		{Agent: "claude", Capabilities: []string{"command:help", "deploy:staging"}, Version: "1.4.0", Commit: "abc1234def", UpdatedAt: time.Now().Add(-90 * time.Second)},
+		{Agent: "triage", Capabilities: []string{"summarize"}},
+	}}
+	stub := &stubBroker{reg: reg}
+	hookBroker(t, stub)
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte("discord:\n  bot_token: dummy\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	opts := &globalOptions{configPath: path, output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := agentListCmd(opts)
+	cmd.SetArgs([]string{"--capability", "deploy"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v (%s)", err, buf.String())
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"agent": "claude"`) || strings.Contains(out, "triage") {
+		t.Fatalf("unexpected agent list output %s", out)
+	}
+	if !strings.Contains(out, `"version": "1.4.0"`) {
+		t.Fatalf("expected version in agent list output %s", out)
+	}
+	if !strings.Contains(out, `"heartbeat_age_seconds": 90`) {
+		t.Fatalf("expected heartbeat age in agent list output %s", out)
+	}
+	if !stub.closed {
+		t.Fatal("expected broker close")
+	}
+}
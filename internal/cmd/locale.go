@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/locale"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// formatter resolves the locale and time zone for human-readable output from
+// --locale/--timezone, then output.locale/output.timezone in discord.yaml.
+func (o *globalOptions) formatter(cfg *discordconfig.Config) (*locale.Formatter, error) {
+	lang, tz := o.locale, o.timezone
+	if cfg != nil {
+		if lang == "" {
+			lang = cfg.Output.Locale
+		}
+		if tz == "" {
+			tz = cfg.Output.Timezone
+		}
+	}
+	f, err := locale.New(lang, tz)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "invalid locale settings", Hint: "use a Discord locale such as en-US, en-GB, de, fr, pt-BR and an IANA zone such as Europe/Berlin"}).WithCause(err)
+	}
+	return f, nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestFormatterPrecedence(t *testing.T) {
+	cfg := &discordconfig.Config{Output: discordconfig.OutputConfig{Locale: "de", Timezone: "Europe/Berlin"}}
+
+	opts := &globalOptions{}
+	f, err := opts.formatter(cfg)
+	if err != nil {
+		t.Fatalf("formatter error: %v", err)
+	}
+	if f.Locale() != "de" || f.Location().String() != "Europe/Berlin" {
+		t.Fatalf("expected config locale, got %s %s", f.Locale(), f.Location())
+	}
+
+	opts = &globalOptions{locale: "en-GB", timezone: "UTC"}
+	f, err = opts.formatter(cfg)
+	if err != nil {
+		t.Fatalf("formatter error: %v", err)
+	}
+	if f.Locale() != "en-GB" || f.Location().String() != "UTC" {
+		t.Fatalf("expected flag locale, got %s %s", f.Locale(), f.Location())
+	}
+
+	opts = &globalOptions{locale: "xx-YY"}
+	if _, err := opts.formatter(cfg); err == nil {
+		t.Fatal("expected error for unsupported locale")
+	}
+}
+
+func TestUtilTimestamp(t *testing.T) {
+	hookStubs(t, &discordconfig.Config{}, nil, nil)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}, locale: "de", timezone: "Europe/Berlin"}
+	cmd := utilTimestampCmd(opts)
+	cmd.SetArgs([]string{"--at", "2025-03-01T17:00:00Z", "--style", "R"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute error: %v (%s)", err, buf.String())
+	}
+	out := buf.String()
+	if !strings.Contains(out, `t:1740848400:R`) {
+		t.Fatalf("expected markup, got %s", out)
+	}
+	if !strings.Contains(out, "01.03.2025 18:00 CET") {
+		t.Fatalf("expected German preview, got %s", out)
+	}
+}
+
+func TestParseTimestampAt(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"":                     now,
+		"2h":                   now.Add(2 * time.Hour),
+		"-30m":                 now.Add(-30 * time.Minute),
+		"1700000000":           time.Unix(1700000000, 0),
+		"2025-03-01T17:00:00Z": time.Date(2025, 3, 1, 17, 0, 0, 0, time.UTC),
+	}
+	for raw, want := range cases {
+		got, err := parseTimestampAt(raw, now)
+		if err != nil {
+			t.Fatalf("parseTimestampAt(%q) error: %v", raw, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("parseTimestampAt(%q) = %v, want %v", raw, got, want)
+		}
+	}
+	if _, err := parseTimestampAt("tomorrow", now); err == nil {
+		t.Fatal("expected error for invalid value")
+	}
+}
@@ -3,8 +3,7 @@ package cmd
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"os"
+	"io"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -27,7 +26,10 @@ func messageCmd(opts *globalOptions) *cobra.Command {
 	cmd.AddCommand(messageEditCmd(opts))
 	cmd.AddCommand(messageDeleteCmd(opts))
 	cmd.AddCommand(messageReactCmd(opts))
+	cmd.AddCommand(messageReactionsCmd(opts))
 	cmd.AddCommand(messageListCmd(opts))
+	cmd.AddCommand(messageSearchCmd(opts))
+	cmd.AddCommand(messageAttachmentsCmd(opts))
 	return cmd
 }
 
@@ -36,6 +38,8 @@ func messageSendCmd(opts *globalOptions) *cobra.Command {
 		channelID   string
 		payloadPath string
 		content     string
+		embed       embedFlags
+		tmpl        templateFlags
 	)
 
 	c := &cobra.Command{
@@ -68,7 +72,10 @@ Payload Structure (types.MessageCreateParams):
 			return runMessageSend(cmd, opts, messageSendInput{
 				channelID:   channelID,
 				payloadPath: payloadPath,
+				stdin:       cmd.InOrStdin(),
 				content:     content,
+				embed:       embed,
+				template:    tmpl,
 				output:      opts.output,
 			})
 		},
@@ -84,6 +91,20 @@ Example:
   # Load an embed-driven payload from disk
   arc-discord message send --payload advanced_message.json
 
+Example:
+  # Pipe a payload generated by another tool
+  jq -n '{content: "Nightly report", embeds: [{title: "Coverage 91%"}]}' | arc-discord message send --payload -
+
+Example:
+  # Build a simple embed from flags instead of a JSON file
+  arc-discord message send --embed-title "Deploy finished" --embed-color "#2ecc71" \
+    --embed-field "env=prod:inline" --embed-field "version=1.4.2:inline"
+
+Example:
+  # Render content as a Go template with CI context
+  arc-discord message send --template --data build.json \
+    --content 'Build {{ .Data.number }} on {{ env "GITHUB_REF_NAME" }}: {{ .Data.status | upper }}'
+
 Example:
   # Combine inline content with YAML output for logging
   arc-discord message send --content "Test" --output yaml
@@ -93,9 +114,11 @@ Example:
   arc-discord message send --content \"Smoke test\" --profile staging`,
 	}
 
-	c.Flags().StringVar(&channelID, "channel", "", "Target channel ID (optional if default_channel_id set in config)")
-	c.Flags().StringVar(&payloadPath, "payload", "", "Path to JSON payload for types.MessageCreateParams")
+	c.Flags().StringVar(&channelID, "channel", "", "Target channel ID or #name (optional if default_channel_id set in config)")
+	c.Flags().StringVar(&payloadPath, "payload", "", "Path to JSON payload for types.MessageCreateParams (- reads stdin)")
 	c.Flags().StringVar(&content, "content", "", "Message content when not using --payload")
+	embed.register(c)
+	tmpl.register(c)
 
 	return c
 }
@@ -103,7 +126,10 @@ Example:
 type messageSendInput struct {
 	channelID   string
 	payloadPath string
+	stdin       io.Reader // payload source when payloadPath is "-"
 	content     string
+	embed       embedFlags
+	template    templateFlags
 	output      output.OutputOptions
 }
 
@@ -112,6 +138,10 @@ func runMessageSend(cmd *cobra.Command, opts *globalOptions, in messageSendInput
 	if err != nil {
 		return err
 	}
+	fmtr, err := opts.formatter(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Use provided channel ID or fall back to config default
 	if in.channelID == "" {
@@ -121,7 +151,11 @@ func runMessageSend(cmd *cobra.Command, opts *globalOptions, in messageSendInput
 		return &arcer.CLIError{Msg: "--channel is required", Hint: "pass a Discord channel ID or set default_channel_id in discord.yaml"}
 	}
 
-	params, err := buildMessageParams(in)
+	tmpl, err := in.template.compile(cmd, fmtr)
+	if err != nil {
+		return err
+	}
+	params, err := buildMessageParams(in, tmpl)
 	if err != nil {
 		return err
 	}
@@ -133,6 +167,10 @@ func runMessageSend(cmd *cobra.Command, opts *globalOptions, in messageSendInput
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	in.channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, in.channelID, "")
+	if err != nil {
+		return err
+	}
 
 	msg, err := bot.Messages().CreateMessage(ctx, in.channelID, params)
 	if err != nil {
@@ -143,27 +181,44 @@ func runMessageSend(cmd *cobra.Command, opts *globalOptions, in messageSendInput
 		"message_id": msg.ID,
 		"channel_id": msg.ChannelID,
 		"guild_id":   msg.GuildID,
-		"timestamp":  msg.Timestamp.Format(time.RFC3339),
+		"timestamp":  fmtr.DateTime(msg.Timestamp),
 		"status":     "sent",
 	}
 
 	return renderOutput(cmd, in.output, msg, keyValueTable(data))
 }
 
-func buildMessageParams(in messageSendInput) (*types.MessageCreateParams, error) {
+func buildMessageParams(in messageSendInput, tmpl *messageTemplate) (*types.MessageCreateParams, error) {
+	embed, err := in.embed.build()
+	if err != nil {
+		return nil, err
+	}
 	if in.payloadPath != "" {
-		data, err := os.ReadFile(in.payloadPath)
+		data, err := readPayload(in.stdin, in.payloadPath)
 		if err != nil {
-			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read payload %s", in.payloadPath)}).WithCause(err)
+			return nil, err
+		}
+		if data, err = tmpl.RenderFile(in.payloadPath, data); err != nil {
+			return nil, err
 		}
 		var params types.MessageCreateParams
 		if err := json.Unmarshal(data, &params); err != nil {
 			return nil, (&arcer.CLIError{Msg: "payload must be valid JSON for types.MessageCreateParams"}).WithCause(err)
 		}
+		if embed != nil {
+			params.Embeds = append(params.Embeds, *embed)
+		}
 		return &params, nil
 	}
-	if in.content == "" {
-		return nil, &arcer.CLIError{Msg: "provide --content or --payload"}
+	if in.content, err = tmpl.Render("content", in.content); err != nil {
+		return nil, err
+	}
+	if in.content == "" && embed == nil {
+		return nil, &arcer.CLIError{Msg: "provide --content, --payload, or --embed-* flags"}
+	}
+	params := &types.MessageCreateParams{Content: in.content}
+	if embed != nil {
+		params.Embeds = []types.Embed{*embed}
 	}
-	return &types.MessageCreateParams{Content: in.content}, nil
+	return params, nil
 }
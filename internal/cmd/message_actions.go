@@ -12,46 +12,60 @@ import (
 
 func messageEditCmd(opts *globalOptions) *cobra.Command {
 	var (
-		channelID  string
-		messageID  string
-		content    string
-		embedFiles []string
+		channelID    string
+		messageID    string
+		content      string
+		embedFiles   []string
+		clearContent bool
+		clearEmbeds  bool
+		patchPath    string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "edit",
 		Short: "Edit an existing bot-authored message",
+		Long: `Edit the content or embeds of a message the bot sent. Only the fields you pass are changed.
+Use --clear-content or --clear-embeds to remove them (a message must keep content, an embed, or an attachment).
+--patch FILE merges an RFC 7386 patch over the edit payload for fields without a dedicated flag.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if channelID == "" || messageID == "" {
 				return &arcer.CLIError{Msg: "--channel and --message are required"}
 			}
-			if strings.TrimSpace(content) == "" && len(embedFiles) == 0 {
-				return &arcer.CLIError{Msg: "supply --content or --embed-file when editing a message"}
+			if strings.TrimSpace(content) == "" && len(embedFiles) == 0 && !clearContent && !clearEmbeds && patchPath == "" {
+				return &arcer.CLIError{Msg: "supply --content, --embed-file, --clear-content, --clear-embeds, or --patch when editing a message"}
 			}
-			return runMessageEdit(cmd, opts, channelID, messageID, content, embedFiles)
+			if clearContent && strings.TrimSpace(content) != "" {
+				return &arcer.CLIError{Msg: "--content and --clear-content are mutually exclusive"}
+			}
+			if clearEmbeds && len(embedFiles) > 0 {
+				return &arcer.CLIError{Msg: "--embed-file and --clear-embeds are mutually exclusive"}
+			}
+			params, err := buildMessageEditParams(content, embedFiles, clearContent, clearEmbeds)
+			if err != nil {
+				return err
+			}
+			if params.MergePatch, err = loadMergePatch(patchPath); err != nil {
+				return err
+			}
+			return runMessageEdit(cmd, opts, channelID, messageID, params)
 		},
 		Example: `  arc-discord message edit --channel $CHANNEL --message $MSG --content "Updated text"
-  arc-discord message edit --channel $CHANNEL --message $MSG --embed-file embed.json`,
+  arc-discord message edit --channel $CHANNEL --message $MSG --embed-file embed.json
+  arc-discord message edit --channel $CHANNEL --message $MSG --embed-file embed.json --clear-content
+  arc-discord message edit --channel $CHANNEL --message $MSG --patch edit.json`,
 	}
 
-	cmd.Flags().StringVar(&channelID, "channel", "", "Target channel ID")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Target channel ID or #name")
 	cmd.Flags().StringVar(&messageID, "message", "", "Message ID to edit")
 	cmd.Flags().StringVar(&content, "content", "", "Replacement content for the message")
 	cmd.Flags().StringArrayVar(&embedFiles, "embed-file", nil, "Embed JSON file to include (repeatable)")
+	cmd.Flags().BoolVar(&clearContent, "clear-content", false, "Remove the message content")
+	cmd.Flags().BoolVar(&clearEmbeds, "clear-embeds", false, "Remove all embeds from the message")
+	cmd.Flags().StringVar(&patchPath, "patch", "", patchFlagUsage)
 	return cmd
 }
 
-func runMessageEdit(cmd *cobra.Command, opts *globalOptions, channelID, messageID, content string, embedFiles []string) error {
-	cfg, _, err := opts.loadConfig()
-	if err != nil {
-		return err
-	}
-
-	bot, err := newBotClientFn(cfg, opts.tokenOverride)
-	if err != nil {
-		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
-	}
-
+func buildMessageEditParams(content string, embedFiles []string, clearContent, clearEmbeds bool) (*types.MessageEditParams, error) {
 	params := &types.MessageEditParams{}
 	if strings.TrimSpace(content) != "" {
 		params.Content = content
@@ -59,13 +73,36 @@ func runMessageEdit(cmd *cobra.Command, opts *globalOptions, channelID, messageI
 	if len(embedFiles) > 0 {
 		embeds, err := loadEmbeds(embedFiles)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		params.Embeds = embeds
 	}
+	if clearContent {
+		params.ClearFields = append(params.ClearFields, "content")
+	}
+	if clearEmbeds {
+		params.ClearFields = append(params.ClearFields, "embeds")
+	}
+	return params, nil
+}
+
+func runMessageEdit(cmd *cobra.Command, opts *globalOptions, channelID, messageID string, params *types.MessageEditParams) error {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	if _, err := bot.Messages().EditMessage(ctx, channelID, messageID, params); err != nil {
 		return (&arcer.CLIError{Msg: "failed to edit message"}).WithCause(err)
@@ -88,7 +125,7 @@ func messageDeleteCmd(opts *globalOptions) *cobra.Command {
 		},
 		Example: `  arc-discord message delete --channel $CHANNEL --message $MSG`,
 	}
-	cmd.Flags().StringVar(&channelID, "channel", "", "Target channel ID")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Target channel ID or #name")
 	cmd.Flags().StringVar(&messageID, "message", "", "Message ID to delete")
 	return cmd
 }
@@ -104,6 +141,10 @@ func runMessageDelete(cmd *cobra.Command, opts *globalOptions, channelID, messag
 	}
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	if err := bot.Messages().DeleteMessage(ctx, channelID, messageID); err != nil {
 		return (&arcer.CLIError{Msg: "failed to delete message"}).WithCause(err)
@@ -138,7 +179,7 @@ func messageReactAddCmd(opts *globalOptions) *cobra.Command {
 		},
 		Example: `  arc-discord message react add --channel $CHANNEL --message $MSG --emoji 🔥`,
 	}
-	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID or #name")
 	cmd.Flags().StringVar(&messageID, "message", "", "Message ID")
 	cmd.Flags().StringVar(&emoji, "emoji", "", "Emoji to use (unicode or name:id)")
 	return cmd
@@ -157,7 +198,7 @@ func messageReactRemoveCmd(opts *globalOptions) *cobra.Command {
 		},
 		Example: `  arc-discord message react remove --channel $CHANNEL --message $MSG --emoji 🔥`,
 	}
-	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID or #name")
 	cmd.Flags().StringVar(&messageID, "message", "", "Message ID")
 	cmd.Flags().StringVar(&emoji, "emoji", "", "Emoji to remove (unicode or name:id)")
 	return cmd
@@ -174,6 +215,10 @@ func runMessageReact(cmd *cobra.Command, opts *globalOptions, channelID, message
 	}
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	messageSvc := bot.Messages()
 	if add {
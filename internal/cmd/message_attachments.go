@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// attachmentHTTPClient fetches attachments from Discord's CDN. Attachments
+// can be hundreds of MB, so the limit is per file rather than per command.
+var attachmentHTTPClient = &http.Client{Timeout: 10 * time.Minute}
+
+// downloadedAttachment reports where one attachment was written.
+type downloadedAttachment struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+}
+
+func messageAttachmentsCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attachments",
+		Short: "Work with the files attached to a message",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(messageAttachmentsDownloadCmd(opts))
+	return cmd
+}
+
+func messageAttachmentsDownloadCmd(opts *globalOptions) *cobra.Command {
+	var channelRef, guildRef, messageID, outDir string
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "download",
+		Short: "Download a message's attachments with their original filenames",
+		Long: `Fetch a message and download each of its attachments from Discord's CDN into --out, keeping the
+uploaded filenames. Attachments that share a name get their attachment ID appended. Existing files
+are left alone unless --force is set, so re-running an archive job only fetches what is missing.`,
+		Example: `Example:
+  arc-discord message attachments download --channel "#builds" --message $MSG --out artifacts/
+
+Example:
+  arc-discord message attachments download --channel $CHANNEL --message $MSG --output json | jq -r '.[].path'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || messageID == "" {
+				return &arcer.CLIError{Msg: "--channel and --message are required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			channelID, err := newNameResolver(bot, cfg).ChannelID(ctx, channelRef, guildRef)
+			if err != nil {
+				cancel()
+				return err
+			}
+			msg, err := bot.Messages().GetMessage(ctx, channelID, messageID)
+			cancel()
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch message %s", messageID)}).WithCause(err)
+			}
+			if len(msg.Attachments) == 0 {
+				return &arcer.CLIError{Msg: fmt.Sprintf("message %s has no attachments", messageID)}
+			}
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to create %s", outDir)}).WithCause(err)
+			}
+
+			names := attachmentFilenames(msg.Attachments)
+			downloaded := make([]downloadedAttachment, 0, len(msg.Attachments))
+			table := &tableData{headers: []string{"ID", "FILENAME", "PATH", "SIZE"}}
+			for i, att := range msg.Attachments {
+				path := filepath.Join(outDir, names[i])
+				size, err := downloadAttachment(cmd.Context(), att, path, force)
+				if err != nil {
+					return (&arcer.CLIError{Msg: fmt.Sprintf("failed to download %s", att.Filename)}).WithCause(err)
+				}
+				downloaded = append(downloaded, downloadedAttachment{ID: att.ID, Filename: att.Filename, Path: path, Size: size})
+				table.rows = append(table.rows, []string{att.ID, att.Filename, path, strconv.FormatInt(size, 10)})
+			}
+			return renderOutput(cmd, opts.output, downloaded, table)
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&messageID, "message", "", "Message ID")
+	cmd.Flags().StringVar(&outDir, "out", ".", "Directory to write the attachments to")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite files that already exist")
+	return cmd
+}
+
+// attachmentFilenames returns a safe local name for each attachment. Names
+// are reduced to their base so a crafted filename cannot escape --out, and
+// duplicates get the attachment ID before the extension.
+func attachmentFilenames(attachments []types.Attachment) []string {
+	names := make([]string, len(attachments))
+	seen := make(map[string]bool, len(attachments))
+	for i, att := range attachments {
+		name := filepath.Base(strings.ReplaceAll(att.Filename, `\`, "/"))
+		if name == "." || name == "/" || name == ".." || strings.TrimSpace(name) == "" {
+			name = att.ID
+		}
+		if seen[name] {
+			ext := filepath.Ext(name)
+			name = strings.TrimSuffix(name, ext) + "-" + att.ID + ext
+		}
+		seen[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// downloadAttachment writes att to path through a temporary file, so an
+// interrupted download never leaves a truncated file under the final name.
+// An existing file is kept, and its size reported, unless force is set.
+func downloadAttachment(ctx context.Context, att types.Attachment, path string, force bool) (int64, error) {
+	if info, err := os.Stat(path); err == nil && !force {
+		return info.Size(), nil
+	}
+	url := att.URL
+	if url == "" {
+		url = att.ProxyURL
+	}
+	if url == "" {
+		return 0, fmt.Errorf("attachment %s has no URL", att.ID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := attachmentHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("CDN returned %s", resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".attachment-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	size, err := io.Copy(tmp, resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if att.Size > 0 && size != int64(att.Size) {
+		return 0, fmt.Errorf("got %d bytes, expected %d", size, att.Size)
+	}
+	// CreateTemp makes the file private; archived attachments are not.
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestMessageAttachmentsDownload(t *testing.T) {
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("contents of " + r.URL.Path))
+	}))
+	defer cdn.Close()
+
+	messages := &fakeMessageService{message: &types.Message{ID: "m1", Attachments: []types.Attachment{
+		{ID: "a1", Filename: "report.txt", URL: cdn.URL + "/a1"},
+		{ID: "a2", Filename: "report.txt", URL: cdn.URL + "/a2"},
+		{ID: "a3", Filename: "../../escape.sh", ProxyURL: cdn.URL + "/a3"},
+	}}}
+	hookBot(t, testConfig(), &fakeBotClient{messageSvc: messages})
+
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	var out bytes.Buffer
+	cmd := messageAttachmentsDownloadCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--channel", "1427555325136867393", "--message", "m1", "--out", dir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attachments download: %v", err)
+	}
+	var downloaded []downloadedAttachment
+	if err := json.Unmarshal(out.Bytes(), &downloaded); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	want := map[string]string{
+		"report.txt":    "contents of /a1",
+		"report-a2.txt": "contents of /a2",
+		"escape.sh":     "contents of /a3",
+	}
+	if len(downloaded) != len(want) {
+		t.Fatalf("unexpected result %+v", downloaded)
+	}
+	for name, body := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != body {
+			t.Fatalf("%s: got %q, %v", name, data, err)
+		}
+	}
+}
+
+func TestDownloadAttachmentKeepsExistingAndChecksSize(t *testing.T) {
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("new"))
+	}))
+	defer cdn.Close()
+
+	path := filepath.Join(t.TempDir(), "build.log")
+	if err := os.WriteFile(path, []byte("old!"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	att := types.Attachment{ID: "a1", Filename: "build.log", URL: cdn.URL}
+	if size, err := downloadAttachment(context.Background(), att, path, false); err != nil || size != 4 {
+		t.Fatalf("expected existing file kept, got %d, %v", size, err)
+	}
+	if size, err := downloadAttachment(context.Background(), att, path, true); err != nil || size != 3 {
+		t.Fatalf("expected overwrite, got %d, %v", size, err)
+	}
+	att.Size = 10
+	if _, err := downloadAttachment(context.Background(), att, path, true); err == nil {
+		t.Fatal("expected size mismatch error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Fatalf("short download replaced the file: %q", data)
+	}
+}
@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,6 +26,8 @@ func messageListCmd(opts *globalOptions) *cobra.Command {
 		around    string
 		contains  string
 		fromUser  string
+		follow    bool
+		interval  time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -29,11 +35,23 @@ func messageListCmd(opts *globalOptions) *cobra.Command {
 		Short: "List recent messages from a channel",
 		Long: `List recent messages from a Discord channel.
 
-If --channel is not provided, uses default_channel_id from discord.yaml (if configured).`,
+If --channel is not provided, uses default_channel_id from discord.yaml (if configured).
+--contains and --from only filter the single page that was fetched; use "message search" to scan
+further back through history.
+
+--follow prints the latest page and then polls for new messages every --interval, streaming each one
+to stdout as a JSON object per line (NDJSON) until interrupted. --contains and --from apply to the
+stream as well, which makes it easy to pipe into jq or other tooling.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := opts.output.Resolve(); err != nil {
 				return err
 			}
+			if follow {
+				if interval < time.Second {
+					return &arcer.CLIError{Msg: "--interval must be at least 1s"}
+				}
+				return runMessageFollow(cmd, opts, channelID, limit, after, interval, contains, fromUser)
+			}
 			return runMessageList(cmd, opts, channelID, opts.output, limit, before, after, around, contains, fromUser)
 		},
 		Example: `Example:
@@ -54,16 +72,22 @@ Example:
 
 Example:
   # Show only messages from a specific bot user
-  arc-discord message list --from 123456789012345678 --output json`,
+  arc-discord message list --from 123456789012345678 --output json
+
+Example:
+  # Stream new messages as NDJSON into jq
+  arc-discord message list --channel $CHANNEL --follow | jq -r '.content'`,
 	}
 
-	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID to inspect (optional if default_channel_id set in config)")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID or #name to inspect (optional if default_channel_id set in config)")
 	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum messages (1-100)")
 	cmd.Flags().StringVar(&before, "before", "", "Message ID to page before")
 	cmd.Flags().StringVar(&after, "after", "", "Message ID to page after")
 	cmd.Flags().StringVar(&around, "around", "", "Message ID to center results around")
 	cmd.Flags().StringVar(&contains, "contains", "", "Only include messages containing this substring")
 	cmd.Flags().StringVar(&fromUser, "from", "", "Only include messages from a specific author ID")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep polling and stream new messages as NDJSON")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Polling interval for --follow")
 	return cmd
 }
 
@@ -72,6 +96,10 @@ func runMessageList(cmd *cobra.Command, opts *globalOptions, channelID string, o
 	if err != nil {
 		return err
 	}
+	fmtr, err := opts.formatter(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Use provided channel ID or fall back to config default
 	if channelID == "" {
@@ -99,6 +127,10 @@ func runMessageList(cmd *cobra.Command, opts *globalOptions, channelID string, o
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	messages, err := bot.Channels().GetChannelMessages(ctx, channelID, params)
 	if err != nil {
@@ -116,7 +148,7 @@ func runMessageList(cmd *cobra.Command, opts *globalOptions, channelID string, o
 			"content":   truncate(m.Content, 80),
 		}
 		payload = append(payload, entry)
-		rows = append(rows, []string{entry["id"], entry["author"], entry["timestamp"], entry["content"]})
+		rows = append(rows, []string{entry["id"], entry["author"], fmtr.DateTime(m.Timestamp), entry["content"]})
 	}
 
 	table := &tableData{headers: []string{"ID", "Author", "Timestamp", "Content"}, rows: rows}
@@ -142,3 +174,104 @@ func filterMessages(messages []*types.Message, contains, fromUser string) []*typ
 
 	return filtered
 }
+
+type followedMessage struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	AuthorID  string `json:"author_id,omitempty"`
+	Author    string `json:"author"`
+	Timestamp string `json:"timestamp"`
+	Content   string `json:"content"`
+}
+
+func runMessageFollow(cmd *cobra.Command, opts *globalOptions, channelID string, limit int, after string, interval time.Duration, contains, fromUser string) error {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	if channelID == "" {
+		channelID = cfg.Discord.DefaultChannelID
+	}
+	if channelID == "" {
+		return &arcer.CLIError{Msg: "--channel is required", Hint: "pass a Discord channel ID or set default_channel_id in discord.yaml"}
+	}
+
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	emit := func(m *types.Message) error {
+		if len(filterMessages([]*types.Message{m}, contains, fromUser)) == 0 {
+			return nil
+		}
+		entry := followedMessage{
+			ID:        m.ID,
+			ChannelID: channelID,
+			Author:    safeUser(m.Author),
+			Timestamp: m.Timestamp.Format(time.RFC3339),
+			Content:   m.Content,
+		}
+		if m.Author != nil {
+			entry.AuthorID = m.Author.ID
+		}
+		return enc.Encode(entry)
+	}
+
+	err = followChannelMessages(ctx, bot.Channels(), channelID, limit, after, interval, emit)
+	if err != nil && ctx.Err() == nil {
+		return (&arcer.CLIError{Msg: "failed to follow channel messages"}).WithCause(err)
+	}
+	return nil
+}
+
+// followChannelMessages emits the latest page (or everything after "after") in
+// chronological order and then polls for newer messages until ctx is done.
+func followChannelMessages(ctx context.Context, svc channelService, channelID string, limit int, after string, interval time.Duration, emit func(*types.Message) error) error {
+	params := &client.GetChannelMessagesParams{Limit: limit, After: after}
+	for {
+		reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		messages, err := svc.GetChannelMessages(reqCtx, channelID, params)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		sortMessagesChronologically(messages)
+		for _, m := range messages {
+			if err := emit(m); err != nil {
+				return err
+			}
+			params.After = m.ID
+		}
+
+		if params.After != "" {
+			params.Limit = 100
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// sortMessagesChronologically orders messages by snowflake ID, oldest first.
+func sortMessagesChronologically(messages []*types.Message) {
+	sort.Slice(messages, func(i, j int) bool {
+		a, b := messages[i].ID, messages[j].ID
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+}
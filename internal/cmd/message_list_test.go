@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/yourorg/arc-discord/gosdk/discord/client"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 )
 
@@ -28,3 +33,51 @@ func TestFilterMessages(t *testing.T) {
 		t.Fatalf("expected author filter to return message 1")
 	}
 }
+
+type followChannelService struct {
+	fakeChannelService
+	pages  [][]*types.Message
+	afters []string
+	cancel func()
+}
+
+func (f *followChannelService) GetChannelMessages(_ context.Context, _ string, params *client.GetChannelMessagesParams) ([]*types.Message, error) {
+	f.afters = append(f.afters, params.After)
+	if len(f.pages) == 0 {
+		f.cancel()
+		return nil, nil
+	}
+	page := f.pages[0]
+	f.pages = f.pages[1:]
+	return page, nil
+}
+
+func TestFollowChannelMessagesStreamsInOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc := &followChannelService{
+		pages: [][]*types.Message{
+			{{ID: "12"}, {ID: "11"}, {ID: "9"}},
+			{{ID: "14"}, {ID: "13"}},
+		},
+		cancel: cancel,
+	}
+
+	var seen []string
+	err := followChannelMessages(ctx, svc, "c1", 20, "", time.Millisecond, func(m *types.Message) error {
+		seen = append(seen, m.ID)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+
+	want := []string{"9", "11", "12", "13", "14"}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, seen)
+	}
+	if len(svc.afters) < 3 || svc.afters[0] != "" || svc.afters[1] != "12" || svc.afters[2] != "14" {
+		t.Fatalf("unexpected after cursors %v", svc.afters)
+	}
+}
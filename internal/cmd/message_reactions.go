@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/client"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const reactionPageSize = 100
+
+// reactionTally lists the users who reacted with one emoji.
+type reactionTally struct {
+	Emoji string        `json:"emoji"`
+	Count int           `json:"count"`
+	Users []*types.User `json:"users"`
+}
+
+func messageReactionsCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reactions",
+		Short: "Inspect who reacted to a message",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(messageReactionsListCmd(opts))
+	return cmd
+}
+
+func messageReactionsListCmd(opts *globalOptions) *cobra.Command {
+	var channelRef, guildRef, messageID string
+	var emojis []string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the users who reacted to a message, per emoji",
+		Long: `List every user who reacted to a message, grouped by emoji, paging through all reactions.
+Without --emoji every emoji on the message is listed. Use it to tally polls or approvals run
+over reactions; the table has one row per user and emoji.`,
+		Example: `Example:
+  arc-discord message reactions list --channel "#releases" --message $MSG
+
+Example:
+  arc-discord message reactions list --channel $CHANNEL --message $MSG --emoji ✅ --emoji ❌ --output csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || messageID == "" {
+				return &arcer.CLIError{Msg: "--channel and --message are required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			channelID, err := newNameResolver(bot, cfg).ChannelID(ctx, channelRef, guildRef)
+			if err != nil {
+				cancel()
+				return err
+			}
+			tallies := make([]*reactionTally, 0, len(emojis))
+			for _, emoji := range emojis {
+				tallies = append(tallies, &reactionTally{Emoji: emoji})
+			}
+			if len(tallies) == 0 {
+				msg, err := bot.Messages().GetMessage(ctx, channelID, messageID)
+				if err != nil {
+					cancel()
+					return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch message %s", messageID)}).WithCause(err)
+				}
+				for _, reaction := range msg.Reactions {
+					tallies = append(tallies, &reactionTally{Emoji: reaction.EmojiRef()})
+				}
+			}
+			cancel()
+
+			table := &tableData{headers: []string{"EMOJI", "USER_ID", "USERNAME"}}
+			for _, tally := range tallies {
+				users, err := listReactionUsers(cmd.Context(), bot, channelID, messageID, tally.Emoji)
+				if err != nil {
+					return (&arcer.CLIError{Msg: fmt.Sprintf("failed to list %s reactions on message %s", tally.Emoji, messageID), Hint: "custom emoji are given as name:id"}).WithCause(err)
+				}
+				tally.Users, tally.Count = users, len(users)
+				for _, user := range users {
+					table.rows = append(table.rows, []string{tally.Emoji, user.ID, user.Username})
+				}
+			}
+			return renderOutput(cmd, opts.output, tallies, table)
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&messageID, "message", "", "Message ID")
+	cmd.Flags().StringArrayVar(&emojis, "emoji", nil, "Only list this emoji (unicode or name:id, repeatable)")
+	return cmd
+}
+
+// listReactionUsers pages through every user who reacted with emoji.
+func listReactionUsers(parent context.Context, bot botClient, channelID, messageID, emoji string) ([]*types.User, error) {
+	var users []*types.User
+	after := ""
+	for {
+		ctx, cancel := context.WithTimeout(parent, 30*time.Second)
+		page, err := bot.Messages().GetReactions(ctx, channelID, messageID, emoji, &client.GetReactionsParams{Limit: reactionPageSize, After: after})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) < reactionPageSize {
+			return users, nil
+		}
+		after = page[len(page)-1].ID
+	}
+}
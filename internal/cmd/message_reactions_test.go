@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestMessageReactionsListPagesEveryEmoji(t *testing.T) {
+	var yes []*types.User
+	for i := 0; i < reactionPageSize+1; i++ {
+		yes = append(yes, &types.User{ID: fmt.Sprintf("1%04d", i), Username: "voter"})
+	}
+	messages := &fakeMessageService{
+		message: &types.Message{ID: "m1", Reactions: []types.Reaction{
+			{Count: len(yes), Emoji: types.Emoji{Name: "✅"}},
+			{Count: 1, Emoji: types.Emoji{Name: "shipit", ID: "555"}},
+		}},
+		reactions: map[string][]*types.User{
+			"✅":          yes,
+			"shipit:555": {{ID: "42", Username: "lead"}},
+		},
+	}
+	hookBot(t, testConfig(), &fakeBotClient{messageSvc: messages})
+
+	var out bytes.Buffer
+	cmd := messageReactionsListCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--channel", "1427555325136867393", "--message", "m1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("reactions list: %v", err)
+	}
+	var tallies []reactionTally
+	if err := json.Unmarshal(out.Bytes(), &tallies); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	if len(tallies) != 2 || tallies[0].Count != reactionPageSize+1 || tallies[1].Emoji != "shipit:555" || tallies[1].Users[0].ID != "42" {
+		t.Fatalf("unexpected tallies %+v", tallies)
+	}
+	if len(messages.pages) != 3 {
+		t.Fatalf("expected two pages for ✅ and one for shipit, got %v", messages.pages)
+	}
+}
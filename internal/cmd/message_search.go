@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/client"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	"github.com/yourorg/arc-sdk/output"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const messageSearchPageSize = 100
+
+func messageSearchCmd(opts *globalOptions) *cobra.Command {
+	var in messageSearchInput
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search channel history with client-side filters",
+		Long: `Search message history by paging backwards through one channel (--channel) or every text
+channel in a guild (--guild), applying filters to each page until --since is reached, --max-pages
+pages have been read per channel, or --limit matches were found.
+
+Filters are combined with AND:
+  --contains  case-insensitive substring of the message content
+  --from      author user ID
+  --has       attachment|embed (repeatable)
+  --since     duration (e.g. 24h) or RFC3339 timestamp; older messages stop the scan
+
+Each match includes a jump URL that opens the message in the Discord client.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if in.channelID == "" && in.guildID == "" {
+				return &arcer.CLIError{Msg: "--channel or --guild is required"}
+			}
+			if in.channelID != "" && in.guildID != "" {
+				return &arcer.CLIError{Msg: "--channel and --guild are mutually exclusive"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			return runMessageSearch(cmd, opts, in, opts.output)
+		},
+		Example: `Example:
+  # Find incident mentions in a channel during the last day
+  arc-discord message search --channel $CHANNEL --contains incident --since 24h
+
+Example:
+  # Find attachments a user posted anywhere in a guild
+  arc-discord message search --guild $GUILD --from 123456789012345678 --has attachment
+
+Example:
+  # Scan deeper history and print a table
+  arc-discord message search --channel $CHANNEL --contains deploy --max-pages 50 --output table`,
+	}
+
+	cmd.Flags().StringVar(&in.channelID, "channel", "", "Channel ID or #name to search")
+	cmd.Flags().StringVar(&in.guildID, "guild", "", "Guild ID or name whose text channels should be searched")
+	cmd.Flags().StringVar(&in.contains, "contains", "", "Only include messages containing this substring")
+	cmd.Flags().StringVar(&in.fromUser, "from", "", "Only include messages from a specific author ID")
+	cmd.Flags().StringArrayVar(&in.has, "has", nil, "Require message content type: attachment|embed (repeatable)")
+	cmd.Flags().StringVar(&in.since, "since", "", "Stop at messages older than this duration or RFC3339 timestamp")
+	cmd.Flags().IntVar(&in.maxPages, "max-pages", 10, "Maximum pages of 100 messages to scan per channel")
+	cmd.Flags().IntVar(&in.limit, "limit", 50, "Maximum matches to return")
+	return cmd
+}
+
+type messageSearchInput struct {
+	channelID string
+	guildID   string
+	contains  string
+	fromUser  string
+	has       []string
+	since     string
+	maxPages  int
+	limit     int
+}
+
+type messageSearchFilter struct {
+	contains       string
+	fromUser       string
+	needAttachment bool
+	needEmbed      bool
+	since          time.Time
+}
+
+type messageSearchMatch struct {
+	ID        string `json:"id" yaml:"id"`
+	ChannelID string `json:"channel_id" yaml:"channel_id"`
+	Author    string `json:"author" yaml:"author"`
+	Timestamp string `json:"timestamp" yaml:"timestamp"`
+	Content   string `json:"content" yaml:"content"`
+	JumpURL   string `json:"jump_url" yaml:"jump_url"`
+}
+
+func runMessageSearch(cmd *cobra.Command, opts *globalOptions, in messageSearchInput, output output.OutputOptions) error {
+	filter, err := newMessageSearchFilter(in, time.Now())
+	if err != nil {
+		return err
+	}
+	if in.maxPages <= 0 {
+		return &arcer.CLIError{Msg: "--max-pages must be positive"}
+	}
+	if in.limit <= 0 {
+		return &arcer.CLIError{Msg: "--limit must be positive"}
+	}
+
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	fmtr, err := opts.formatter(cfg)
+	if err != nil {
+		return err
+	}
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	resolver := newNameResolver(bot, cfg)
+	if in.guildID, err = resolver.GuildID(ctx, in.guildID); err != nil {
+		return err
+	}
+	if in.channelID, err = resolver.ChannelID(ctx, in.channelID, ""); err != nil {
+		return err
+	}
+
+	var channels []*types.Channel
+	if in.guildID != "" {
+		all, err := resolver.Channels(ctx, in.guildID)
+		if err != nil {
+			return err
+		}
+		for _, ch := range all {
+			if ch.Type == types.ChannelTypeGuildText || ch.Type == types.ChannelTypeGuildNews {
+				if ch.GuildID == "" {
+					ch.GuildID = in.guildID
+				}
+				channels = append(channels, ch)
+			}
+		}
+	} else {
+		ch, err := bot.Channels().GetChannel(ctx, in.channelID)
+		if err != nil {
+			return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch channel %s", in.channelID)}).WithCause(err)
+		}
+		channels = append(channels, ch)
+	}
+
+	matches := make([]messageSearchMatch, 0)
+	for _, ch := range channels {
+		remaining := in.limit - len(matches)
+		if remaining <= 0 {
+			break
+		}
+		found, err := searchChannelMessages(ctx, bot.Channels(), ch, filter, in.maxPages, remaining)
+		if err != nil {
+			return (&arcer.CLIError{Msg: fmt.Sprintf("failed to search channel %s", ch.ID)}).WithCause(err)
+		}
+		matches = append(matches, found...)
+	}
+
+	rows := make([][]string, 0, len(matches))
+	for _, m := range matches {
+		ts := m.Timestamp
+		if parsed, err := time.Parse(time.RFC3339, m.Timestamp); err == nil {
+			ts = fmtr.DateTime(parsed)
+		}
+		rows = append(rows, []string{m.ID, m.Author, ts, truncate(m.Content, 60), m.JumpURL})
+	}
+	table := &tableData{headers: []string{"ID", "Author", "Timestamp", "Content", "URL"}, rows: rows}
+	return renderOutput(cmd, output, matches, table)
+}
+
+func newMessageSearchFilter(in messageSearchInput, now time.Time) (messageSearchFilter, error) {
+	filter := messageSearchFilter{
+		contains: strings.ToLower(strings.TrimSpace(in.contains)),
+		fromUser: strings.TrimSpace(in.fromUser),
+	}
+	for _, has := range in.has {
+		switch strings.ToLower(strings.TrimSpace(has)) {
+		case "attachment", "file":
+			filter.needAttachment = true
+		case "embed":
+			filter.needEmbed = true
+		default:
+			return filter, &arcer.CLIError{Msg: fmt.Sprintf("unsupported --has value %q", has), Hint: "use attachment or embed"}
+		}
+	}
+	if since := strings.TrimSpace(in.since); since != "" {
+		if d, err := time.ParseDuration(since); err == nil {
+			filter.since = now.Add(-d)
+		} else if ts, err := time.Parse(time.RFC3339, since); err == nil {
+			filter.since = ts
+		} else {
+			return filter, &arcer.CLIError{Msg: fmt.Sprintf("invalid --since value %q", since), Hint: "use a duration like 24h or an RFC3339 timestamp"}
+		}
+	}
+	return filter, nil
+}
+
+func (f messageSearchFilter) match(m *types.Message) bool {
+	if f.fromUser != "" && (m.Author == nil || m.Author.ID != f.fromUser) {
+		return false
+	}
+	if f.contains != "" && !strings.Contains(strings.ToLower(m.Content), f.contains) {
+		return false
+	}
+	if f.needAttachment && len(m.Attachments) == 0 {
+		return false
+	}
+	if f.needEmbed && len(m.Embeds) == 0 {
+		return false
+	}
+	return true
+}
+
+// searchChannelMessages pages backwards through a channel's history until the
+// page budget, match limit, or --since cutoff is reached.
+func searchChannelMessages(ctx context.Context, svc channelService, ch *types.Channel, filter messageSearchFilter, maxPages, limit int) ([]messageSearchMatch, error) {
+	var (
+		matches []messageSearchMatch
+		before  string
+	)
+	for page := 0; page < maxPages; page++ {
+		messages, err := svc.GetChannelMessages(ctx, ch.ID, &client.GetChannelMessagesParams{Limit: messageSearchPageSize, Before: before})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range messages {
+			if !filter.since.IsZero() && m.Timestamp.Before(filter.since) {
+				return matches, nil
+			}
+			if !filter.match(m) {
+				continue
+			}
+			matches = append(matches, messageSearchMatch{
+				ID:        m.ID,
+				ChannelID: ch.ID,
+				Author:    safeUser(m.Author),
+				Timestamp: m.Timestamp.Format(time.RFC3339),
+				Content:   m.Content,
+				JumpURL:   messageJumpURL(ch.GuildID, ch.ID, m.ID),
+			})
+			if len(matches) >= limit {
+				return matches, nil
+			}
+		}
+		if len(messages) < messageSearchPageSize {
+			break
+		}
+		before = messages[len(messages)-1].ID
+	}
+	return matches, nil
+}
+
+func messageJumpURL(guildID, channelID, messageID string) string {
+	if guildID == "" {
+		guildID = "@me"
+	}
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guildID, channelID, messageID)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/client"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+type pagedChannelService struct {
+	fakeChannelService
+	history []*types.Message
+	calls   int
+}
+
+func (p *pagedChannelService) GetChannelMessages(_ context.Context, channelID string, params *client.GetChannelMessagesParams) ([]*types.Message, error) {
+	p.calls++
+	start := 0
+	if params.Before != "" {
+		for i, m := range p.history {
+			if m.ID == params.Before {
+				start = i + 1
+			}
+		}
+	}
+	end := start + params.Limit
+	if end > len(p.history) {
+		end = len(p.history)
+	}
+	return p.history[start:end], nil
+}
+
+func buildHistory(n int, now time.Time) []*types.Message {
+	history := make([]*types.Message, 0, n)
+	for i := 0; i < n; i++ {
+		m := &types.Message{
+			ID:        fmt.Sprintf("m%d", i),
+			Content:   "routine update",
+			Timestamp: now.Add(-time.Duration(i) * time.Minute),
+			Author:    &types.User{ID: "u1", Username: "ops"},
+		}
+		if i%50 == 0 {
+			m.Content = "Incident opened"
+		}
+		if i == 150 {
+			m.Attachments = []types.Attachment{{ID: "a1"}}
+		}
+		history = append(history, m)
+	}
+	return history
+}
+
+func TestSearchChannelMessagesPaginates(t *testing.T) {
+	now := time.Now()
+	svc := &pagedChannelService{history: buildHistory(250, now)}
+	ch := &types.Channel{ID: "c1", GuildID: "g1"}
+
+	filter, err := newMessageSearchFilter(messageSearchInput{contains: "incident"}, now)
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	matches, err := searchChannelMessages(context.Background(), svc, ch, filter, 10, 50)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(matches) != 5 {
+		t.Fatalf("expected 5 matches across pages, got %d", len(matches))
+	}
+	if svc.calls != 3 {
+		t.Fatalf("expected 3 page requests, got %d", svc.calls)
+	}
+	if matches[0].JumpURL != "https://discord.com/channels/g1/c1/m0" {
+		t.Fatalf("unexpected jump URL %s", matches[0].JumpURL)
+	}
+}
+
+func TestSearchChannelMessagesStopsAtSince(t *testing.T) {
+	now := time.Now()
+	svc := &pagedChannelService{history: buildHistory(250, now)}
+	ch := &types.Channel{ID: "c1"}
+
+	filter, err := newMessageSearchFilter(messageSearchInput{contains: "incident", since: "90m"}, now)
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	matches, err := searchChannelMessages(context.Background(), svc, ch, filter, 10, 50)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches within 90m, got %d", len(matches))
+	}
+	if svc.calls != 1 {
+		t.Fatalf("expected scan to stop after first page, got %d calls", svc.calls)
+	}
+}
+
+func TestMessageSearchFilterHas(t *testing.T) {
+	now := time.Now()
+	svc := &pagedChannelService{history: buildHistory(250, now)}
+	filter, err := newMessageSearchFilter(messageSearchInput{has: []string{"attachment"}}, now)
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	matches, err := searchChannelMessages(context.Background(), svc, &types.Channel{ID: "c1"}, filter, 10, 50)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(matches) != 1 || matches[0].ID != "m150" {
+		t.Fatalf("expected attachment match m150, got %+v", matches)
+	}
+
+	if _, err := newMessageSearchFilter(messageSearchInput{has: []string{"sticker"}}, now); err == nil {
+		t.Fatal("expected error for unsupported --has value")
+	}
+}
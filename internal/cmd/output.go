@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -19,7 +24,27 @@ type tableData struct {
 	rows    [][]string
 }
 
+const (
+	outputGoTemplate     = "go-template="
+	outputGoTemplateFile = "go-template-file="
+	outputCSV            = "csv"
+)
+
+type outputTemplateKey struct{}
+
+// outputCSVKey marks --output csv, which renders a command's table as CSV.
+type outputCSVKey struct{}
+
 func renderOutput(cmd *cobra.Command, opts output.OutputOptions, data any, table *tableData) error {
+	if tmpl, ok := cmd.Context().Value(outputTemplateKey{}).(*template.Template); ok && tmpl != nil {
+		return renderGoTemplate(cmd, tmpl, data)
+	}
+	if csvOut, _ := cmd.Context().Value(outputCSVKey{}).(bool); csvOut {
+		if table == nil {
+			return &arcer.CLIError{Msg: "csv output not supported for this command", Hint: "use --output json or --output yaml"}
+		}
+		return renderCSV(cmd, table)
+	}
 	switch {
 	case opts.Is(output.OutputQuiet):
 		return nil
@@ -37,8 +62,70 @@ func renderOutput(cmd *cobra.Command, opts output.OutputOptions, data any, table
 		}
 		return renderTable(cmd, table)
 	default:
-		return &arcer.CLIError{Msg: fmt.Sprintf("unsupported output format %q", opts.Format), Hint: "valid options: table|json|yaml|quiet"}
+		return &arcer.CLIError{Msg: fmt.Sprintf("unsupported output format %q", opts.Format), Hint: "valid options: table|json|yaml|csv|quiet|go-template=...|go-template-file=..."}
+	}
+}
+
+// applyOutputTemplate handles --output go-template=TEMPLATE and
+// go-template-file=PATH. The parsed template is attached to the command
+// context for renderOutput, and the format is switched to json so commands
+// behave as they do for any other machine-readable output. --output csv is
+// marked on the context the same way and switched to table, since CSV is
+// rendered from a command's table.
+func applyOutputTemplate(cmd *cobra.Command, opts *output.OutputOptions) error {
+	var name, text string
+	switch format := opts.Format; {
+	case strings.EqualFold(format, outputCSV):
+		opts.Format = string(output.OutputTable)
+		cmd.SetContext(context.WithValue(commandContext(cmd), outputCSVKey{}, true))
+		return nil
+	case strings.HasPrefix(format, outputGoTemplate):
+		name, text = "go-template", strings.TrimPrefix(format, outputGoTemplate)
+	case strings.HasPrefix(format, outputGoTemplateFile):
+		name = strings.TrimPrefix(format, outputGoTemplateFile)
+		raw, err := os.ReadFile(name)
+		if err != nil {
+			return (&arcer.CLIError{Msg: fmt.Sprintf("failed to read output template %s", name)}).WithCause(err)
+		}
+		text = string(raw)
+	case format == "go-template" || format == "go-template-file":
+		return &arcer.CLIError{Msg: fmt.Sprintf("--output %s needs a value", format), Hint: "e.g. --output go-template='{{.id}} {{.name}}'"}
+	default:
+		return nil
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "invalid output template"}).WithCause(err)
 	}
+	opts.Format = string(output.OutputJSON)
+	cmd.SetContext(context.WithValue(commandContext(cmd), outputTemplateKey{}, tmpl))
+	return nil
+}
+
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// renderGoTemplate executes tmpl against data's JSON form, so templates use
+// the same field names as --output json (e.g. {{.id}}), as kubectl does.
+func renderGoTemplate(cmd *cobra.Command, tmpl *template.Template, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	if err := tmpl.Execute(cmd.OutOrStdout(), value); err != nil {
+		return (&arcer.CLIError{Msg: "failed to render output template", Hint: "field names match --output json"}).WithCause(err)
+	}
+	return nil
 }
 
 func renderTable(cmd *cobra.Command, tbl *tableData) error {
@@ -55,6 +142,25 @@ func renderTable(cmd *cobra.Command, tbl *tableData) error {
 	return w.Flush()
 }
 
+// renderCSV writes the table as CSV with lower-case column names, so the
+// header row reads like the json field names (USER_ID becomes user_id).
+func renderCSV(cmd *cobra.Command, tbl *tableData) error {
+	w := csv.NewWriter(cmd.OutOrStdout())
+	if len(tbl.headers) > 0 {
+		headers := make([]string, len(tbl.headers))
+		for i, h := range tbl.headers {
+			headers[i] = strings.ToLower(h)
+		}
+		if err := w.Write(headers); err != nil {
+			return err
+		}
+	}
+	if err := w.WriteAll(tbl.rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
 func keyValueTable(m map[string]string) *tableData {
 	rows := make([][]string, 0, len(m))
 	keys := make([]string, 0, len(m))
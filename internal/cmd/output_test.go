@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func runRootWithChannel(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	channelSvc := &fakeChannelService{channel: &types.Channel{ID: "42", Name: "alerts"}}
+	hookBot(t, testConfig(), &fakeBotClient{messageSvc: &fakeMessageService{}, channelSvc: channelSvc, guildSvc: &fakeGuildService{}})
+
+	cmd := NewRootCmd()
+	cmd.SetArgs(append([]string{"channel", "get", "--channel", "42"}, args...))
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestOutputGoTemplate(t *testing.T) {
+	out, err := runRootWithChannel(t, "--output", "go-template={{.id}} {{.name | upper}}")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if out != "42 ALERTS" {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestOutputGoTemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "channel.tmpl")
+	if err := os.WriteFile(path, []byte("#{{.name}}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runRootWithChannel(t, "-o", "go-template-file="+path)
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if out != "#alerts\n" {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestOutputGoTemplateErrors(t *testing.T) {
+	cases := map[string]string{
+		"go-template":              "needs a value",
+		"go-template={{.id":        "invalid output template",
+		"go-template={{.missing}}": "failed to render output template",
+		"go-template-file=/nope":   "failed to read output template",
+	}
+	for format, want := range cases {
+		_, err := runRootWithChannel(t, "--output", format)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected %q error, got %v", format, want, err)
+		}
+	}
+}
+
+func TestOutputCSV(t *testing.T) {
+	out, err := runRootWithChannel(t, "--output", "csv")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.HasPrefix(out, "field,value\n") || !strings.Contains(out, "name,alerts\n") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
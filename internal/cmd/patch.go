@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const patchFlagUsage = "RFC 7386 merge patch JSON file applied over the flag values (null clears a field)"
+
+// loadMergePatch reads a merge patch document for --patch flags.
+func loadMergePatch(path string) (json.RawMessage, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read patch %s", path)}).WithCause(err)
+	}
+	if err := types.ValidateMergePatch(data); err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("invalid merge patch %s", path), Hint: "the patch must be a JSON object, e.g. {\"topic\": null}"}).WithCause(err)
+	}
+	return json.RawMessage(data), nil
+}
@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/ratelimit"
+	arcer "github.com/yourorg/arc-sdk/errors"
+	"github.com/yourorg/arc-sdk/output"
+)
+
+// rateLimitStateRetention bounds how long flushed buckets and 429s are kept
+// in the state file; status --window can't look back further than this.
+const rateLimitStateRetention = 24 * time.Hour
+
+var (
+	// rateLimitRecorder observes every bot and webhook client this process
+	// creates. Its state is merged into the state file when the command
+	// finishes (and periodically by server start) so status can report on
+	// earlier invocations.
+	rateLimitRecorder      = ratelimit.NewRecorder(rateLimitStateRetention)
+	rateLimitStatePathFn   = defaultRateLimitStatePath
+	rateLimitFinalizerOnce sync.Once
+)
+
+func defaultRateLimitStatePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "vibe", "discord-ratelimit.json")
+}
+
+// rateLimitState is the persisted view of the rate limit recorder.
+type rateLimitState struct {
+	UpdatedAt  time.Time           `json:"updated_at"`
+	Buckets    []rateLimitBucket   `json:"buckets"`
+	Events     []rateLimitEvent    `json:"events"`
+	Strategies []rateLimitStrategy `json:"strategies,omitempty"`
+}
+
+// rateLimitStrategy is the client-wide state of a rate limit strategy as of
+// the last flush that used it.
+type rateLimitStrategy struct {
+	Name          string    `json:"name" yaml:"name"`
+	Threshold     float64   `json:"threshold" yaml:"threshold"`
+	Requests      int       `json:"requests" yaml:"requests"`
+	RateLimitHits int       `json:"rate_limit_hits" yaml:"rate_limit_hits"`
+	HitRate       float64   `json:"hit_rate" yaml:"hit_rate"`
+	UpdatedAt     time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+type rateLimitBucket struct {
+	Key       string    `json:"key" yaml:"key"`
+	Route     string    `json:"route" yaml:"route"`
+	Limit     int       `json:"limit" yaml:"limit"`
+	Remaining int       `json:"remaining" yaml:"remaining"`
+	Reset     time.Time `json:"reset" yaml:"reset"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+
+	// Threshold is the adaptive strategy's learned threshold for this
+	// bucket: the fraction of Limit at which requests start waiting.
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+}
+
+type rateLimitEvent struct {
+	Route      string    `json:"route"`
+	Bucket     string    `json:"bucket,omitempty"`
+	Global     bool      `json:"global,omitempty"`
+	RetryAfter float64   `json:"retry_after_seconds,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+func loadRateLimitState(path string) (*rateLimitState, error) {
+	state := &rateLimitState{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse rate limit state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// merge folds a recorder snapshot into the state. The newest report of a
+// bucket wins; events are keyed by time and route so flushing the same
+// snapshot twice doesn't double count; strategies are replaced by name.
+func (s *rateLimitState) merge(snap ratelimit.RecorderSnapshot, now time.Time) {
+	thresholds := map[string]float64{}
+	for _, st := range snap.Strategies {
+		for _, route := range st.Routes {
+			thresholds[route.Key] = route.Threshold
+		}
+		s.mergeStrategy(rateLimitStrategy{
+			Name:          st.Name,
+			Threshold:     st.Threshold,
+			Requests:      st.Requests,
+			RateLimitHits: st.RateLimitHits,
+			HitRate:       st.HitRate,
+			UpdatedAt:     now,
+		})
+	}
+
+	buckets := make(map[string]rateLimitBucket, len(s.Buckets))
+	for _, b := range s.Buckets {
+		buckets[b.Key] = b
+	}
+	for _, b := range snap.Buckets {
+		key := b.Key
+		if key == "" {
+			key = b.Route
+		}
+		if prev, ok := buckets[key]; ok && prev.UpdatedAt.After(b.UpdatedAt) {
+			continue
+		}
+		buckets[key] = rateLimitBucket{Key: key, Route: b.Route, Limit: b.Limit, Remaining: b.Remaining, Reset: b.Reset, UpdatedAt: b.UpdatedAt, Threshold: thresholds[key]}
+	}
+
+	seen := make(map[string]bool, len(s.Events))
+	eventKey := func(e rateLimitEvent) string { return strconv.FormatInt(e.At.UnixNano(), 10) + " " + e.Route }
+	for _, e := range s.Events {
+		seen[eventKey(e)] = true
+	}
+	for _, e := range snap.Events {
+		ev := rateLimitEvent{Route: e.Route, Bucket: e.Bucket, Global: e.Global, RetryAfter: e.RetryAfter.Seconds(), At: e.At}
+		if !seen[eventKey(ev)] {
+			seen[eventKey(ev)] = true
+			s.Events = append(s.Events, ev)
+		}
+	}
+
+	cutoff := now.Add(-rateLimitStateRetention)
+	s.Buckets = s.Buckets[:0]
+	for _, b := range buckets {
+		if b.UpdatedAt.After(cutoff) {
+			s.Buckets = append(s.Buckets, b)
+		}
+	}
+	sort.Slice(s.Buckets, func(i, j int) bool { return s.Buckets[i].Key < s.Buckets[j].Key })
+	events := s.Events[:0]
+	for _, e := range s.Events {
+		if e.At.After(cutoff) {
+			events = append(events, e)
+		}
+	}
+	s.Events = events
+	sort.Slice(s.Events, func(i, j int) bool { return s.Events[i].At.Before(s.Events[j].At) })
+	s.UpdatedAt = now
+}
+
+func (s *rateLimitState) mergeStrategy(st rateLimitStrategy) {
+	for i := range s.Strategies {
+		if s.Strategies[i].Name == st.Name {
+			s.Strategies[i] = st
+			return
+		}
+	}
+	s.Strategies = append(s.Strategies, st)
+	sort.Slice(s.Strategies, func(i, j int) bool { return s.Strategies[i].Name < s.Strategies[j].Name })
+}
+
+func (s *rateLimitState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// flushRateLimitState merges what this process has recorded into the state
+// file. It is best-effort: a command's result never depends on it.
+func flushRateLimitState() {
+	snap := rateLimitRecorder.Snapshot()
+	if len(snap.Buckets) == 0 && len(snap.Events) == 0 {
+		return
+	}
+	path := rateLimitStatePathFn()
+	state, err := loadRateLimitState(path)
+	if err != nil {
+		state = &rateLimitState{}
+	}
+	state.merge(snap, time.Now())
+	_ = state.save(path)
+}
+
+// registerRateLimitFlush flushes on every command exit, including failed
+// ones (PersistentPostRun is skipped when RunE errors, which is exactly when
+// exhausted 429 retries matter).
+func registerRateLimitFlush() {
+	rateLimitFinalizerOnce.Do(func() { cobra.OnFinalize(flushRateLimitState) })
+}
+
+// flushRateLimitStateEvery keeps the state file current for long-running
+// processes such as server start.
+func flushRateLimitStateEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushRateLimitState()
+			return
+		case <-ticker.C:
+			flushRateLimitState()
+		}
+	}
+}
+
+func ratelimitCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ratelimit",
+		Short: "Inspect Discord API rate limit state",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(ratelimitStatusCmd(opts))
+	return cmd
+}
+
+func ratelimitStatusCmd(opts *globalOptions) *cobra.Command {
+	var (
+		statePath string
+		window    time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show rate limit buckets and recent 429s seen by the client",
+		Long: `Reports the rate limit buckets the bot and webhook clients last saw (remaining requests and
+reset times) and the 429 responses received within --window, including global limit hits.
+Buckets are per route and major parameter (channel, guild, webhook); with the adaptive strategy the
+Threshold column is the fraction of a bucket's limit at which requests start waiting, learned per bucket.
+Every arc-discord invocation records its buckets to ~/.cache/vibe/discord-ratelimit.json on exit;
+server start refreshes it every minute.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			return runRatelimitStatus(cmd, opts.output, statePath, window)
+		},
+		Example: `Example:
+  arc-discord ratelimit status
+
+Example:
+  # Only count 429s from the last 10 minutes
+  arc-discord ratelimit status --window 10m --output json`,
+	}
+	cmd.Flags().StringVar(&statePath, "state-file", "", "Rate limit state file (default ~/.cache/vibe/discord-ratelimit.json)")
+	cmd.Flags().DurationVar(&window, "window", time.Hour, "How far back to count 429 responses")
+	return cmd
+}
+
+// rateLimitStatus is the output of ratelimit status.
+type rateLimitStatus struct {
+	Window      string                  `json:"window" yaml:"window"`
+	Recent429s  int                     `json:"recent_429s" yaml:"recent_429s"`
+	GlobalHits  int                     `json:"global_hits" yaml:"global_hits"`
+	LastUpdated time.Time               `json:"last_updated,omitempty" yaml:"last_updated,omitempty"`
+	Strategies  []rateLimitStrategy     `json:"strategies,omitempty" yaml:"strategies,omitempty"`
+	Buckets     []rateLimitBucketStatus `json:"buckets" yaml:"buckets"`
+}
+
+type rateLimitBucketStatus struct {
+	rateLimitBucket `yaml:",inline"`
+	Recent429s      int `json:"recent_429s" yaml:"recent_429s"`
+}
+
+func runRatelimitStatus(cmd *cobra.Command, out output.OutputOptions, statePath string, window time.Duration) error {
+	if window <= 0 {
+		return &arcer.CLIError{Msg: "--window must be positive", Hint: "e.g. --window 15m"}
+	}
+	if statePath == "" {
+		statePath = rateLimitStatePathFn()
+	}
+	state, err := loadRateLimitState(statePath)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to read rate limit state", Hint: "delete " + statePath + " to start fresh"}).WithCause(err)
+	}
+	now := time.Now()
+	state.merge(rateLimitRecorder.Snapshot(), now)
+
+	status := buildRateLimitStatus(state, window, now)
+	rows := make([][]string, 0, len(status.Buckets)+1)
+	for _, b := range status.Buckets {
+		rows = append(rows, []string{
+			b.Key,
+			valueOrDash(b.Route),
+			fmt.Sprintf("%d/%d", b.Remaining, b.Limit),
+			formatBucketReset(b.Reset, now),
+			formatThreshold(b.Threshold),
+			strconv.Itoa(b.Recent429s),
+		})
+	}
+	rows = append(rows, []string{"(global)", "-", "-", "-", "-", strconv.Itoa(status.GlobalHits)})
+	for _, st := range status.Strategies {
+		rows = append(rows, []string{"(strategy " + st.Name + ")", "-", "-", "-", formatThreshold(st.Threshold), strconv.Itoa(st.RateLimitHits)})
+	}
+	table := &tableData{headers: []string{"Bucket", "Route", "Remaining", "Resets", "Threshold", "429s (" + status.Window + ")"}, rows: rows}
+	return renderOutput(cmd, out, status, table)
+}
+
+func buildRateLimitStatus(state *rateLimitState, window time.Duration, now time.Time) rateLimitStatus {
+	status := rateLimitStatus{Window: window.String(), Strategies: state.Strategies, Buckets: make([]rateLimitBucketStatus, 0, len(state.Buckets))}
+	if !state.UpdatedAt.IsZero() && (len(state.Buckets) > 0 || len(state.Events) > 0) {
+		status.LastUpdated = state.UpdatedAt
+	}
+	hits := map[string]int{}
+	cutoff := now.Add(-window)
+	for _, e := range state.Events {
+		if e.At.Before(cutoff) {
+			continue
+		}
+		status.Recent429s++
+		if e.Global {
+			status.GlobalHits++
+			continue
+		}
+		key := e.Bucket
+		if key == "" {
+			key = e.Route
+		}
+		hits[key]++
+	}
+	for _, b := range state.Buckets {
+		status.Buckets = append(status.Buckets, rateLimitBucketStatus{rateLimitBucket: b, Recent429s: hits[b.Key]})
+	}
+	return status
+}
+
+func formatThreshold(threshold float64) string {
+	if threshold == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", threshold*100)
+}
+
+func formatBucketReset(reset, now time.Time) string {
+	if reset.IsZero() {
+		return "-"
+	}
+	if !reset.After(now) {
+		return "reset"
+	}
+	return "in " + reset.Sub(now).Round(100*time.Millisecond).String()
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/ratelimit"
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func hookRateLimitState(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	rateLimitStatePathFn = func() string { return path }
+	rateLimitRecorder.Reset()
+	t.Cleanup(func() {
+		rateLimitStatePathFn = defaultRateLimitStatePath
+		rateLimitRecorder.Reset()
+	})
+	return path
+}
+
+func TestRatelimitStatusReportsFlushedState(t *testing.T) {
+	path := hookRateLimitState(t)
+	route := "POST:https://discord.com/api/v10/channels/1/messages"
+	bucket := ratelimit.Bucket{Key: "abc", Limit: 5, Remaining: 0, Reset: time.Now().Add(time.Minute)}
+	strategy := ratelimit.NewDefaultAdaptiveStrategy()
+	strategy.RecordRequest(&bucket, true)
+	rateLimitRecorder.ObserveStrategy(strategy)
+	rateLimitRecorder.BucketUpdated(route, bucket)
+	rateLimitRecorder.RateLimited(route, false, 2*time.Second)
+	rateLimitRecorder.RateLimited(route, true, time.Second)
+
+	// Flushing twice (command exit after a periodic flush) must not double count.
+	flushRateLimitState()
+	flushRateLimitState()
+	rateLimitRecorder.Reset()
+
+	state, err := loadRateLimitState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Buckets) != 1 || len(state.Events) != 2 {
+		t.Fatalf("unexpected persisted state: %+v", state)
+	}
+
+	var buf bytes.Buffer
+	cmd := ratelimitCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetArgs([]string{"status"})
+	cmd.SetOut(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var status rateLimitStatus
+	if err := json.Unmarshal(buf.Bytes(), &status); err != nil {
+		t.Fatalf("decode: %v\n%s", err, buf.String())
+	}
+	if status.Recent429s != 2 || status.GlobalHits != 1 || len(status.Buckets) != 1 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if len(status.Strategies) != 1 || status.Strategies[0].Name != "adaptive" || status.Strategies[0].RateLimitHits != 1 {
+		t.Fatalf("unexpected strategies: %+v", status.Strategies)
+	}
+	if b := status.Buckets[0]; b.Key != "abc" || b.Route != route || b.Recent429s != 1 || b.Threshold != strategy.MaxThreshold {
+		t.Fatalf("unexpected bucket: %+v", b)
+	}
+}
+
+func TestRatelimitStatusTableWindow(t *testing.T) {
+	path := hookRateLimitState(t)
+	old := &rateLimitState{Events: []rateLimitEvent{{Route: "GET:/x", Bucket: "x", At: time.Now().Add(-2 * time.Hour)}}}
+	old.Buckets = []rateLimitBucket{{Key: "x", Route: "GET:/x", Limit: 1, UpdatedAt: time.Now()}}
+	if err := old.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	cmd := ratelimitCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetArgs([]string{"status", "--window", "30m"})
+	cmd.SetOut(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(buf.String(), "(global)") || strings.Contains(buf.String(), "reset") {
+		t.Fatalf("unexpected table:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+// interactionRecord is one recorded interaction, self-contained so that
+// "interaction replay" can re-send it. Body keeps the exact bytes received.
+type interactionRecord struct {
+	RecordedAt time.Time           `json:"recorded_at"`
+	Method     string              `json:"method"`
+	Path       string              `json:"path"`
+	Headers    map[string][]string `json:"headers"`
+	Body       string              `json:"body"`
+	Status     int                 `json:"status"`
+}
+
+// recordMiddleware persists every interaction the server accepted. Requests
+// rejected for a bad signature (401) are not recorded, so the directory only
+// holds traffic that really came from Discord.
+type recordMiddleware struct {
+	next   http.Handler
+	dir    string
+	now    func() time.Time
+	logger *logger.Logger
+
+	mu  sync.Mutex
+	seq int
+}
+
+func newRecordMiddleware(next http.Handler, dir string, log *logger.Logger) (*recordMiddleware, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create record dir: %w", err)
+	}
+	if log == nil {
+		log = logger.Default()
+	}
+	return &recordMiddleware{next: next, dir: dir, now: time.Now, logger: log}, nil
+}
+
+func (m *recordMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		m.logger.Warn("record read body failed", "error", err)
+		m.next.ServeHTTP(w, r)
+		return
+	}
+
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	m.next.ServeHTTP(rec, r)
+	if rec.status == http.StatusUnauthorized || !json.Valid(body) {
+		return
+	}
+	if err := m.write(r, body, rec.status); err != nil {
+		m.logger.Warn("record write failed", "error", err)
+	}
+}
+
+func (m *recordMiddleware) write(r *http.Request, body []byte, status int) error {
+	m.mu.Lock()
+	m.seq++
+	seq := m.seq
+	m.mu.Unlock()
+
+	recorded := m.now().UTC()
+	data, err := json.MarshalIndent(interactionRecord{
+		RecordedAt: recorded,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Headers:    r.Header.Clone(),
+		Body:       string(body),
+		Status:     status,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%04d.json", recorded.Format("20060102T150405.000Z"), seq)
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o600)
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func replayTestServer(t *testing.T, publicKey string, dryRun bool) (*httptest.Server, *stubPublisher) {
+	t.Helper()
+	publisher := &stubPublisher{}
+	builder := interactionServerBuilder{PublicKey: publicKey, DryRun: dryRun, Publisher: publisher}
+	bindings := collectHandlerBindings(interactionsConfig{Enabled: true, Handlers: handlerMappings{
+		Commands: map[string]handlerRoute{"help": {Agent: "claude"}},
+	}})
+	srv, err := builder.build(time.Second, bindings)
+	if err != nil {
+		t.Fatalf("build server: %v", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(srv.HandleInteraction))
+	t.Cleanup(ts.Close)
+	return ts, publisher
+}
+
+func TestRecordMiddlewareKeepsVerifiedInteractions(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	publisher := &stubPublisher{}
+	srv, err := interactionServerBuilder{PublicKey: hex.EncodeToString(pub), Publisher: publisher}.build(time.Second,
+		collectHandlerBindings(interactionsConfig{Enabled: true, Handlers: handlerMappings{Commands: map[string]handlerRoute{"help": {Agent: "claude"}}}}))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	dir := t.TempDir()
+	rec, err := newRecordMiddleware(http.HandlerFunc(srv.HandleInteraction), dir, nil)
+	if err != nil {
+		t.Fatalf("record middleware: %v", err)
+	}
+
+	body := []byte(`{"type":2,"id":"1","token":"tok","data":{"name":"help"}}`)
+	w := httptest.NewRecorder()
+	rec.ServeHTTP(w, signedRequest(t, priv, body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected signed request to succeed, got %d", w.Code)
+	}
+	forged := httptest.NewRequest(http.MethodPost, "/interactions", bytes.NewReader(body))
+	rec.ServeHTTP(httptest.NewRecorder(), forged)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected only the verified interaction to be recorded, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	var record interactionRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("decode record: %v", err)
+	}
+	if record.Body != string(body) || record.Status != http.StatusOK || record.Path != "/interactions" {
+		t.Fatalf("unexpected record %+v", record)
+	}
+}
+
+func TestInteractionReplaySendsRecordings(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	target, publisher := replayTestServer(t, hex.EncodeToString(pub), true)
+
+	dir := t.TempDir()
+	recorded := filepath.Join(dir, "one.json")
+	data, _ := json.Marshal(interactionRecord{Body: `{"type":2,"id":"1","token":"tok","data":{"name":"help"}}`})
+	if err := os.WriteFile(recorded, data, 0o600); err != nil {
+		t.Fatalf("write record: %v", err)
+	}
+	// A --capture-dir pair replays too.
+	captured := filepath.Join(dir, "two.json")
+	_ = os.WriteFile(filepath.Join(dir, "two.body"), []byte(`{"type":2,"id":"2","token":"tok2","data":{"name":"help"}}`), 0o600)
+	data, _ = json.Marshal(requestCapture{BodyFile: "two.body"})
+	_ = os.WriteFile(captured, data, 0o600)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := interactionReplayCmd(opts)
+	cmd.SetArgs([]string{recorded, captured, "--target", target.URL})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("replay: %v (%s)", err, buf.String())
+	}
+	if len(publisher.envelopes) != 2 {
+		t.Fatalf("expected both interactions to reach the handler, got %d", len(publisher.envelopes))
+	}
+	if !strings.Contains(buf.String(), `"interaction": "command help"`) || !strings.Contains(buf.String(), `"response_type": 5`) {
+		t.Fatalf("unexpected replay output %s", buf.String())
+	}
+}
+
+func TestInteractionReplaySignsWithProvidedKey(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, ed25519.SeedSize)
+	priv := ed25519.NewKeyFromSeed(seed)
+	target, publisher := replayTestServer(t, hex.EncodeToString(priv.Public().(ed25519.PublicKey)), false)
+
+	path := filepath.Join(t.TempDir(), "one.json")
+	data, _ := json.Marshal(interactionRecord{Body: `{"type":2,"id":"1","token":"tok","data":{"name":"help"}}`})
+	_ = os.WriteFile(path, data, 0o600)
+
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := interactionReplayCmd(opts)
+	cmd.SetArgs([]string{path, "--target", target.URL, "--private-key", hex.EncodeToString(seed)})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("replay with matching key: %v", err)
+	}
+	if len(publisher.envelopes) != 1 {
+		t.Fatalf("expected verified replay to publish, got %d", len(publisher.envelopes))
+	}
+
+	cmd = interactionReplayCmd(opts)
+	cmd.SetArgs([]string{path, "--target", target.URL})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected a throwaway key to be rejected by a verifying server")
+	}
+	if !strings.Contains(buf.String(), `"status": 401`) {
+		t.Fatalf("expected 401 in output, got %s", buf.String())
+	}
+}
@@ -1,125 +1,47 @@
 package cmd
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/yourorg/arc-discord/gosdk/broker"
 )
 
 const (
-	defaultRegistryTTL       = 2 * time.Minute
-	defaultHeartbeatInterval = 30 * time.Second
-	registryKeySuffix        = "registry"
+	defaultRegistryTTL       = broker.DefaultRegistryTTL
+	defaultHeartbeatInterval = broker.DefaultHeartbeatInterval
 )
 
-type redisCommander interface {
-	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
-	Del(ctx context.Context, keys ...string) *redis.IntCmd
-	Close() error
+// agentBuild identifies the running agent build for rollout checks.
+type agentBuild struct {
+	Version string
+	Commit  string
 }
 
-type agentRegistry struct {
-	client redisCommander
-	ttl    time.Duration
-	prefix string
-}
-
-type AgentInfo struct {
-	Agent        string    `json:"agent"`
-	Capabilities []string  `json:"capabilities,omitempty"`
-	Channels     []string  `json:"channels,omitempty"`
-	Hostname     string    `json:"hostname,omitempty"`
-	ProcessID    int       `json:"process_id,omitempty"`
-	UpdatedAt    time.Time `json:"updated_at"`
-}
-
-func newAgentRegistry(cfg redisConfig, ttl time.Duration) (*agentRegistry, error) {
-	if ttl <= 0 {
-		ttl = defaultRegistryTTL
-	}
-	client := redis.NewClient(newRedisOptions(cfg))
-	ctx, cancel := context.WithTimeout(context.Background(), redisPublishTimeout)
-	defer cancel()
-	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("connect redis registry: %w", err)
-	}
-	prefix := fmt.Sprintf("%s:%s", normalizeChannelPrefix(cfg.ChannelPrefix), registryKeySuffix)
-	return &agentRegistry{
-		client: client,
-		ttl:    ttl,
-		prefix: prefix,
-	}, nil
-}
-
-func (r *agentRegistry) Register(ctx context.Context, info AgentInfo) error {
-	if strings.TrimSpace(info.Agent) == "" {
-		return fmt.Errorf("agent is required for registry entry")
-	}
-	info.UpdatedAt = time.Now().UTC()
-	payload, err := json.Marshal(info)
-	if err != nil {
-		return fmt.Errorf("marshal agent info: %w", err)
-	}
-	if err := r.client.Set(ctx, r.key(info.Agent), payload, r.ttl).Err(); err != nil {
-		return fmt.Errorf("store registry info: %w", err)
-	}
-	return nil
-}
-
-func (r *agentRegistry) Heartbeat(ctx context.Context, info AgentInfo, interval time.Duration) error {
-	if interval <= 0 {
-		interval = defaultHeartbeatInterval
-	}
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
-			_ = r.Register(ctx, info)
-		}
-	}
-}
-
-func (r *agentRegistry) Unregister(ctx context.Context, agent string) error {
-	if strings.TrimSpace(agent) == "" {
-		return nil
-	}
-	if err := r.client.Del(ctx, r.key(agent)).Err(); err != nil {
-		return fmt.Errorf("remove registry entry: %w", err)
-	}
-	return nil
-}
-
-func (r *agentRegistry) Close() error {
-	if r == nil || r.client == nil {
-		return nil
-	}
-	return r.client.Close()
-}
-
-func (r *agentRegistry) key(agent string) string {
-	return fmt.Sprintf("%s:%s", r.prefix, strings.ToLower(agent))
-}
-
-func agentInfo(agent string, handlers handlerMappings, channel string) AgentInfo {
-	return AgentInfo{
+func agentInfo(agent string, handlers handlerMappings, channel string, declared []string, build agentBuild) broker.AgentInfo {
+	return broker.AgentInfo{
 		Agent:        agent,
-		Capabilities: resolveAgentCapabilities(agent, handlers),
+		Capabilities: mergeCapabilities(resolveAgentCapabilities(agent, handlers), declared),
 		Channels:     []string{channel},
 		Hostname:     hostnameOrUnknown(),
 		ProcessID:    os.Getpid(),
+		Version:      build.Version,
+		Commit:       build.Commit,
+		StartedAt:    time.Now().UTC(),
 	}
 }
 
+// shortCommit trims a git SHA to the conventional 7 characters for display.
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
 func resolveAgentCapabilities(agent string, mappings handlerMappings) []string {
 	var caps []string
 	lowerAgent := strings.ToLower(agent)
@@ -148,24 +70,54 @@ func resolveAgentCapabilities(agent string, mappings handlerMappings) []string {
 	return caps
 }
 
-func hostnameOrUnknown() string {
-	host, err := os.Hostname()
-	if err != nil || strings.TrimSpace(host) == "" {
-		return "unknown"
+// mergeCapabilities combines handler-derived capabilities with those declared
+// by the running agent, dropping duplicates.
+func mergeCapabilities(derived, declared []string) []string {
+	seen := make(map[string]bool, len(derived)+len(declared))
+	var caps []string
+	for _, c := range append(append([]string{}, derived...), declared...) {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		caps = append(caps, c)
 	}
-	return host
+	sort.Strings(caps)
+	return caps
 }
 
-func newAgentRegistryWithClient(client redisCommander, ttl time.Duration, prefix string) *agentRegistry {
-	if ttl <= 0 {
-		ttl = defaultRegistryTTL
+// validateCapabilities checks declared capability names such as "summarize"
+// or "deploy:staging".
+func validateCapabilities(caps []string) error {
+	for _, c := range caps {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			return fmt.Errorf("capability names must not be empty")
+		}
+		if strings.ContainsAny(c, " \t*") {
+			return fmt.Errorf("invalid capability %q: use letters, digits, and separators like deploy:staging", c)
+		}
 	}
-	if strings.TrimSpace(prefix) == "" {
-		prefix = fmt.Sprintf("%s:%s", defaultRedisPrefix, registryKeySuffix)
+	return nil
+}
+
+// hasCapability reports whether caps satisfies want. A bare name matches any
+// scoped variant, so "deploy" matches "deploy:staging".
+func hasCapability(caps []string, want string) bool {
+	want = strings.ToLower(strings.TrimSpace(want))
+	for _, c := range caps {
+		if c == want || strings.HasPrefix(c, want+":") {
+			return true
+		}
 	}
-	return &agentRegistry{
-		client: client,
-		ttl:    ttl,
-		prefix: prefix,
+	return false
+}
+
+func hostnameOrUnknown() string {
+	host, err := os.Hostname()
+	if err != nil || strings.TrimSpace(host) == "" {
+		return "unknown"
 	}
+	return host
 }
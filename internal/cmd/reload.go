@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
+)
+
+const defaultConfigPollInterval = 2 * time.Second
+
+// interactionSwitch serves interactions from the current server and lets a
+// reload swap in a rebuilt one without touching the HTTP listener.
+type interactionSwitch struct {
+	current atomic.Pointer[interactions.Server]
+}
+
+func newInteractionSwitch(srv *interactions.Server) *interactionSwitch {
+	sw := &interactionSwitch{}
+	sw.current.Store(srv)
+	return sw
+}
+
+func (s *interactionSwitch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.current.Load().HandleInteraction(w, r)
+}
+
+func (s *interactionSwitch) swap(srv *interactions.Server) {
+	s.current.Store(srv)
+}
+
+// interactionServerBuilder creates an interaction server with the given
+// handler bindings registered. Suggestions, when set, serves redis_key
+// autocomplete sources.
+type interactionServerBuilder struct {
+	PublicKey       string
+	DryRun          bool
+	Publisher       broker.Publisher
+	Suggestions     suggestionStore
+	TimestampWindow time.Duration
+	ClockSkew       time.Duration
+}
+
+func (b interactionServerBuilder) build(timeout time.Duration, bindings []handlerBinding) (*interactions.Server, error) {
+	serverOptions := []interactions.ServerOption{
+		interactions.WithTimestampWindow(b.TimestampWindow, b.ClockSkew),
+		interactions.WithRejectionObserver(func(reason string) { interactionRejections.Add(reason, 1) }),
+	}
+	if b.DryRun {
+		serverOptions = append(serverOptions, interactions.WithDryRun(true))
+	}
+	srv, err := interactions.NewServer(b.PublicKey, serverOptions...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bindings {
+		if bindings[i].Kind == handlerKindAutocomplete {
+			bindings[i].Suggestions = b.Suggestions
+		}
+	}
+	if err := registerInteractionHandlers(srv, timeout, b.Publisher, bindings); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// handlerReloader re-reads the interactions section of discord.yaml and swaps
+// the rebuilt bindings into the running server. Other settings (listen
+// address, TLS, broker, public key) still require a restart.
+type handlerReloader struct {
+	path     string
+	builder  interactionServerBuilder
+	sw       *interactionSwitch
+	logf     func(format string, args ...interface{})
+	mu       sync.Mutex
+	bindings []handlerBinding
+}
+
+func (r *handlerReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings, err := loadInteractionSettings(r.path)
+	if err != nil {
+		return err
+	}
+	bindings := collectHandlerBindings(settings.Interactions)
+	srv, err := r.builder.build(settings.Interactions.Timeout, bindings)
+	if err != nil {
+		return err
+	}
+	r.sw.swap(srv)
+
+	diff := diffHandlerBindings(r.bindings, bindings)
+	r.bindings = bindings
+	if diff.empty() {
+		r.logf("Reloaded handlers from %s (no route changes)\n", r.path)
+		return nil
+	}
+	r.logf("Reloaded handlers from %s: %d added, %d removed, %d changed\n", r.path, len(diff.Added), len(diff.Removed), len(diff.Changed))
+	for _, route := range diff.Added {
+		r.logf("  + %s\n", route)
+	}
+	for _, route := range diff.Removed {
+		r.logf("  - %s\n", route)
+	}
+	for _, route := range diff.Changed {
+		r.logf("  ~ %s\n", route)
+	}
+	return nil
+}
+
+// run reloads on SIGHUP and, when poll is positive, whenever the config
+// file's modification time changes. Failed reloads keep the previous
+// bindings.
+func (r *handlerReloader) run(ctx context.Context, poll time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	lastMod := configModTime(r.path)
+	if poll > 0 && r.path != "" {
+		ticker := time.NewTicker(poll)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reloadAndLog("SIGHUP")
+		case <-tick:
+			mod := configModTime(r.path)
+			if mod.IsZero() || mod.Equal(lastMod) {
+				continue
+			}
+			lastMod = mod
+			r.reloadAndLog("config change")
+		}
+	}
+}
+
+func (r *handlerReloader) reloadAndLog(reason string) {
+	if err := r.reload(); err != nil {
+		r.logf("Handler reload (%s) failed, keeping previous handlers: %v\n", reason, err)
+	}
+}
+
+func configModTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+type handlerBindingDiff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+func (d handlerBindingDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func diffHandlerBindings(before, after []handlerBinding) handlerBindingDiff {
+	index := func(bindings []handlerBinding) map[string]handlerBinding {
+		out := make(map[string]handlerBinding, len(bindings))
+		for _, binding := range bindings {
+			out[binding.Kind+":"+binding.Key] = binding
+		}
+		return out
+	}
+	old, next := index(before), index(after)
+
+	var diff handlerBindingDiff
+	for route, binding := range next {
+		prev, ok := old[route]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, describeBinding(route, binding))
+		case prev.Route.Agent != binding.Route.Agent:
+			diff.Changed = append(diff.Changed, fmt.Sprintf("%s (%s -> %s)", route, prev.Route.Agent, binding.Route.Agent))
+		case fmt.Sprint(prev.AutocompleteChoices) != fmt.Sprint(binding.AutocompleteChoices):
+			diff.Changed = append(diff.Changed, route+" (choices)")
+		case prev.Route.PremiumSKU != binding.Route.PremiumSKU || prev.Route.PremiumMessage != binding.Route.PremiumMessage:
+			diff.Changed = append(diff.Changed, route+" (premium_sku)")
+		case prev.Route.Ephemeral != binding.Route.Ephemeral:
+			diff.Changed = append(diff.Changed, route+" (ephemeral)")
+		case !reflect.DeepEqual(prev.Route.InitialResponse, binding.Route.InitialResponse):
+			diff.Changed = append(diff.Changed, route+" (initial_response)")
+		case prev.Route.Script != binding.Route.Script:
+			diff.Changed = append(diff.Changed, route+" (script)")
+		case !reflect.DeepEqual(prev.Route.Wasm, binding.Route.Wasm):
+			diff.Changed = append(diff.Changed, route+" (wasm)")
+		case !reflect.DeepEqual(prev.Route.Respond, binding.Route.Respond):
+			diff.Changed = append(diff.Changed, route+" (respond)")
+		case !reflect.DeepEqual(prev.Route.Source, binding.Route.Source):
+			diff.Changed = append(diff.Changed, route+" (source)")
+		case !reflect.DeepEqual(prev.Middleware, binding.Middleware):
+			diff.Changed = append(diff.Changed, route+" (middleware)")
+		}
+	}
+	for route, binding := range old {
+		if _, ok := next[route]; !ok {
+			diff.Removed = append(diff.Removed, describeBinding(route, binding))
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
+
+func describeBinding(route string, binding handlerBinding) string {
+	if binding.Route.Agent == "" {
+		return route
+	}
+	return fmt.Sprintf("%s -> %s", route, binding.Route.Agent)
+}
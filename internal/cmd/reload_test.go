@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffHandlerBindings(t *testing.T) {
+	before := collectHandlerBindings(interactionsConfig{Enabled: true, Handlers: handlerMappings{
+		Commands:   map[string]handlerRoute{"help": {Agent: "claude"}, "deploy": {Agent: "ops"}},
+		Components: map[string]handlerRoute{"confirm": {Agent: "claude"}},
+	}})
+	after := collectHandlerBindings(interactionsConfig{Enabled: true, Handlers: handlerMappings{
+		Commands: map[string]handlerRoute{"help": {Agent: "claude"}, "deploy": {Agent: "release"}},
+		Modals:   map[string]handlerRoute{"feedback": {Agent: "claude"}},
+	}})
+	diff := diffHandlerBindings(before, after)
+	if strings.Join(diff.Added, ",") != "modal:feedback -> claude" {
+		t.Fatalf("unexpected added %#v", diff.Added)
+	}
+	if strings.Join(diff.Removed, ",") != "component:confirm -> claude" {
+		t.Fatalf("unexpected removed %#v", diff.Removed)
+	}
+	if strings.Join(diff.Changed, ",") != "command:deploy (ops -> release)" {
+		t.Fatalf("unexpected changed %#v", diff.Changed)
+	}
+	if !diffHandlerBindings(after, after).empty() {
+		t.Fatal("expected no diff for identical bindings")
+	}
+}
+
+func TestHandlerReloaderSwapsBindings(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	writeHandlers := func(command, agent string) {
+		t.Helper()
+		body := fmt.Sprintf("interactions:\n  enabled: true\n  handlers:\n    commands:\n      %s:\n        agent: %s\n", command, agent)
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	writeHandlers("help", "claude")
+
+	publisher := &stubPublisher{}
+	builder := interactionServerBuilder{PublicKey: hex.EncodeToString(pub), Publisher: publisher}
+	var logs strings.Builder
+	reloader := &handlerReloader{
+		path:    path,
+		builder: builder,
+		logf:    func(format string, args ...interface{}) { fmt.Fprintf(&logs, format, args...) },
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("load settings: %v", err)
+	}
+	reloader.bindings = collectHandlerBindings(settings.Interactions)
+	srv, err := builder.build(settings.Interactions.Timeout, reloader.bindings)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	reloader.sw = newInteractionSwitch(srv)
+
+	invoke := func(command string) int {
+		body, _ := json.Marshal(map[string]any{"type": 2, "id": "1", "token": "tok", "data": map[string]any{"name": command}})
+		rec := httptest.NewRecorder()
+		reloader.sw.ServeHTTP(rec, signedRequest(t, priv, body))
+		return rec.Code
+	}
+	if code := invoke("help"); code != http.StatusOK {
+		t.Fatalf("expected help to be routed, got %d", code)
+	}
+
+	writeHandlers("status", "ops")
+	if err := reloader.reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if code := invoke("status"); code != http.StatusOK {
+		t.Fatalf("expected status to be routed after reload, got %d", code)
+	}
+	if code := invoke("help"); code == http.StatusOK {
+		t.Fatal("expected help to be removed after reload")
+	}
+	if len(publisher.envelopes) != 2 || publisher.envelopes[1].Agent != "ops" {
+		t.Fatalf("unexpected envelopes %#v", publisher.envelopes)
+	}
+	out := logs.String()
+	if !strings.Contains(out, "+ command:status -> ops") || !strings.Contains(out, "- command:help -> claude") {
+		t.Fatalf("unexpected reload log %q", out)
+	}
+
+	if err := os.WriteFile(path, []byte("interactions:\n  enabled: true\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := reloader.reload(); err == nil {
+		t.Fatal("expected reload without handlers to fail")
+	}
+	if code := invoke("status"); code != http.StatusOK {
+		t.Fatalf("expected previous handlers to survive failed reload, got %d", code)
+	}
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/client"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+)
+
+// interactionTokenTTL is how long Discord accepts an interaction token for
+// responses and followups.
+const interactionTokenTTL = 15 * time.Minute
+
+// fileResponder is implemented by interaction clients that can upload files
+// with a response.
+type fileResponder interface {
+	EditOriginalInteractionResponseWithFiles(ctx context.Context, applicationID, token string, params *types.MessageEditParams, files []client.File) (*types.Message, error)
+	CreateFollowupMessageWithFiles(ctx context.Context, applicationID, token string, params *types.MessageCreateParams, files []client.File) (*types.Message, error)
+}
+
+// replyRouter is the publisher for interactions.reply_queue: it marks
+// envelopes so agents publish their responses to the broker, and delivers
+// those responses to Discord with the server's token. Interaction tokens are
+// kept in memory only, so responses to interactions published before a
+// restart are dropped.
+type replyRouter struct {
+	broker.Publisher
+	appID  string
+	client interactionResponder
+	logger *logger.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*pendingReply
+}
+
+type pendingReply struct {
+	token     string
+	agent     string
+	ephemeral bool
+	expires   time.Time
+}
+
+func newReplyRouter(publisher broker.Publisher, appID string, responder interactionResponder, log *logger.Logger) *replyRouter {
+	if log == nil {
+		log = logger.Default()
+	}
+	return &replyRouter{
+		Publisher: publisher,
+		appID:     appID,
+		client:    responder,
+		logger:    log,
+		now:       time.Now,
+		pending:   map[string]*pendingReply{},
+	}
+}
+
+func (r *replyRouter) Publish(ctx context.Context, env *broker.Envelope) error {
+	if env == nil || env.Agent == "" {
+		return r.Publisher.Publish(ctx, env)
+	}
+	var interaction types.Interaction
+	if err := json.Unmarshal(env.Interaction, &interaction); err != nil || interaction.ID == "" || interaction.Token == "" {
+		// Without an ID and token the agent's response could not be
+		// delivered; let it answer Discord itself.
+		return r.Publisher.Publish(ctx, env)
+	}
+	env.ReplyQueue = true
+	r.mu.Lock()
+	r.pending[interaction.ID] = &pendingReply{
+		token:     interaction.Token,
+		agent:     env.Agent,
+		ephemeral: env.Ephemeral,
+		expires:   r.now().Add(interactionTokenTTL),
+	}
+	r.mu.Unlock()
+	return r.Publisher.Publish(ctx, env)
+}
+
+// run delivers responses from bus and forgets expired tokens until ctx is
+// done.
+func (r *replyRouter) run(ctx context.Context, bus broker.ResponseBus) {
+	go func() {
+		err := bus.SubscribeResponses(ctx, func(ctx context.Context, resp *broker.Response) error {
+			if err := r.deliver(ctx, resp); err != nil {
+				r.logger.Error("reply queue delivery failed", "interaction", resp.InteractionID, "agent", resp.Agent, "error", err)
+			}
+			return nil
+		})
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error("response subscription failed; agent responses will not reach Discord", "error", err)
+		}
+	}()
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.expire()
+		}
+	}
+}
+
+func (r *replyRouter) expire() {
+	now := r.now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, p := range r.pending {
+		if !now.Before(p.expires) {
+			delete(r.pending, id)
+		}
+	}
+}
+
+// deliver sends resp to Discord as an edit of the original response or a
+// followup. Responses for interactions this server did not publish, whose
+// token expired, or from an agent other than the one dispatched to are
+// dropped.
+func (r *replyRouter) deliver(ctx context.Context, resp *broker.Response) error {
+	r.mu.Lock()
+	p, ok := r.pending[resp.InteractionID]
+	var reply pendingReply
+	if ok {
+		reply = *p
+	}
+	r.mu.Unlock()
+	if !ok || !r.now().Before(reply.expires) {
+		return errors.New("unknown or expired interaction")
+	}
+	if !strings.EqualFold(resp.Agent, reply.agent) {
+		return fmt.Errorf("interaction was dispatched to %s", reply.agent)
+	}
+	files := make([]client.File, 0, len(resp.Files))
+	for _, f := range resp.Files {
+		files = append(files, client.File{Name: f.Name, ContentType: f.ContentType, Data: f.Data})
+	}
+	uploader, canUpload := r.client.(fileResponder)
+	if len(files) > 0 && !canUpload {
+		return errors.New("the interaction client cannot upload files")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if resp.Followup {
+		var params types.MessageCreateParams
+		if len(resp.Message) > 0 {
+			if err := json.Unmarshal(resp.Message, &params); err != nil {
+				return fmt.Errorf("decode followup message: %w", err)
+			}
+		}
+		if reply.ephemeral {
+			params.Flags |= types.MessageFlagEphemeral
+		}
+		var err error
+		if len(files) > 0 {
+			_, err = uploader.CreateFollowupMessageWithFiles(opCtx, r.appID, reply.token, &params, files)
+		} else {
+			_, err = r.client.CreateFollowupMessage(opCtx, r.appID, reply.token, &params)
+		}
+		return err
+	}
+	// The message is merged as-is, so fields the params don't model
+	// (allowed_mentions, attachments) reach Discord too.
+	params := &types.MessageEditParams{}
+	if len(resp.Message) > 0 {
+		params.MergePatch = resp.Message
+	}
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+	var err error
+	if len(files) > 0 {
+		_, err = uploader.EditOriginalInteractionResponseWithFiles(opCtx, r.appID, reply.token, params, files)
+	} else {
+		_, err = r.client.EditOriginalInteractionResponse(opCtx, r.appID, reply.token, params)
+	}
+	return err
+}
+
+// queuedResponder answers one interaction by publishing Responses for the
+// server's reply queue instead of calling Discord. The application ID and
+// token arguments are ignored; the server fills them in.
+type queuedResponder struct {
+	bus           broker.ResponseBus
+	agent         string
+	interactionID string
+}
+
+func (q *queuedResponder) EditOriginalInteractionResponse(ctx context.Context, _, _ string, params *types.MessageEditParams) (*types.Message, error) {
+	return nil, q.publish(ctx, false, params)
+}
+
+func (q *queuedResponder) CreateFollowupMessage(ctx context.Context, _, _ string, params *types.MessageCreateParams) (*types.Message, error) {
+	return nil, q.publish(ctx, true, params)
+}
+
+func (q *queuedResponder) publish(ctx context.Context, followup bool, params interface{}) error {
+	message, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("encode response: %w", err)
+	}
+	return q.bus.PublishResponse(ctx, &broker.Response{
+		InteractionID: q.interactionID,
+		Agent:         q.agent,
+		Followup:      followup,
+		Message:       message,
+	})
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/client"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+type stubFileResponder struct {
+	stubInteractionResponder
+	files []client.File
+}
+
+func (s *stubFileResponder) EditOriginalInteractionResponseWithFiles(ctx context.Context, applicationID, token string, params *types.MessageEditParams, files []client.File) (*types.Message, error) {
+	s.files = files
+	return s.EditOriginalInteractionResponse(ctx, applicationID, token, params)
+}
+
+func (s *stubFileResponder) CreateFollowupMessageWithFiles(ctx context.Context, applicationID, token string, params *types.MessageCreateParams, files []client.File) (*types.Message, error) {
+	s.files = files
+	return s.CreateFollowupMessage(ctx, applicationID, token, params)
+}
+
+// notifyingResponder signals each edit of the original response.
+type notifyingResponder struct {
+	stubInteractionResponder
+	edited chan *types.MessageEditParams
+}
+
+func (n *notifyingResponder) EditOriginalInteractionResponse(ctx context.Context, applicationID, token string, params *types.MessageEditParams) (*types.Message, error) {
+	msg, err := n.stubInteractionResponder.EditOriginalInteractionResponse(ctx, applicationID, token, params)
+	n.edited <- params
+	return msg, err
+}
+
+func TestReplyRouterDeliversResponses(t *testing.T) {
+	pub := &stubPublisher{}
+	responder := &stubFileResponder{}
+	router := newReplyRouter(pub, "app123", responder, nil)
+	now := time.Unix(1700000000, 0)
+	router.now = func() time.Time { return now }
+
+	raw, _ := json.Marshal(types.Interaction{ID: "42", Token: "tok"})
+	if err := router.Publish(context.Background(), &broker.Envelope{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw, Ephemeral: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(pub.envelopes) != 1 || !pub.envelopes[0].ReplyQueue {
+		t.Fatalf("expected a reply-queue envelope, got %+v", pub.envelopes)
+	}
+
+	ctx := context.Background()
+	if err := router.deliver(ctx, &broker.Response{InteractionID: "43", Agent: "ops", Message: json.RawMessage(`{"content":"x"}`)}); err == nil {
+		t.Fatal("delivered a response for an unknown interaction")
+	}
+	if err := router.deliver(ctx, &broker.Response{InteractionID: "42", Agent: "other", Message: json.RawMessage(`{"content":"x"}`)}); err == nil {
+		t.Fatal("delivered a response from the wrong agent")
+	}
+	if responder.called {
+		t.Fatal("rejected responses reached Discord")
+	}
+
+	message := json.RawMessage(`{"content":"done","allowed_mentions":{"parse":[]}}`)
+	if err := router.deliver(ctx, &broker.Response{InteractionID: "42", Agent: "OPS", Message: message, Files: []broker.ResponseFile{{Name: "log.txt", Data: []byte("ok")}}}); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(responder.params)
+	if responder.token != "tok" || responder.application != "app123" || string(body) != `{"allowed_mentions":{"parse":[]},"content":"done"}` {
+		t.Fatalf("unexpected edit %s with token %q", body, responder.token)
+	}
+	if len(responder.files) != 1 || responder.files[0].Name != "log.txt" {
+		t.Fatalf("unexpected files %+v", responder.files)
+	}
+
+	if err := router.deliver(ctx, &broker.Response{InteractionID: "42", Agent: "ops", Followup: true, Message: json.RawMessage(`{"content":"more"}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if !responder.followupCalled || responder.followupParams.Content != "more" || responder.followupParams.Flags&types.MessageFlagEphemeral == 0 {
+		t.Fatalf("expected an ephemeral followup, got %+v", responder.followupParams)
+	}
+
+	now = now.Add(interactionTokenTTL)
+	router.expire()
+	if err := router.deliver(ctx, &broker.Response{InteractionID: "42", Agent: "ops", Message: message}); err == nil || len(router.pending) != 0 {
+		t.Fatal("delivered a response after the token expired")
+	}
+}
+
+func TestLoadInteractionSettingsReadsReplyQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte("interactions:\n  reply_queue: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	if !settings.Interactions.ReplyQueue {
+		t.Fatal("expected reply_queue from the config file")
+	}
+}
+
+func TestAgentListenerPublishesToReplyQueue(t *testing.T) {
+	mem := broker.NewMemory()
+	defer mem.Close()
+	responder := &notifyingResponder{edited: make(chan *types.MessageEditParams, 1)}
+	router := newReplyRouter(mem, "app123", responder, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go router.run(ctx, mem)
+	deadline := time.Now().Add(time.Second)
+	for mem.Subscribers("\x00responses") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("response subscriber never attached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	listener := newAgentListener("ops", "", nil, testPrinter{t})
+	listener.replies = mem
+	listener.responder = &stubAgentResponder{params: &types.MessageEditParams{Content: "from the queue"}}
+	raw, _ := json.Marshal(types.Interaction{ID: "42", Token: "tok"})
+	env := &broker.Envelope{Agent: "ops", Kind: handlerKindCommand, Key: "deploy", Interaction: raw}
+	if err := router.Publish(ctx, env); err != nil {
+		t.Fatal(err)
+	}
+	if err := listener.handlePayload(ctx, mustEnvelope(t, env)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case params := <-responder.edited:
+		body, _ := json.Marshal(params)
+		if string(body) != `{"content":"from the queue"}` {
+			t.Fatalf("unexpected edit %s", body)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("response never reached Discord")
+	}
+}
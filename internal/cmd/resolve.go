@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourorg/arc-discord/gosdk/cache"
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// nameResolver turns "#channel" and guild-name flag values into snowflake
+// IDs so users don't have to copy IDs out of developer mode. Listings are
+// cached for the life of the resolver; a command that resolves a guild and
+// then one of its channels hits Discord once per listing.
+type nameResolver struct {
+	bot            botClient
+	defaultGuildID string
+	guilds         []*types.Guild
+	channels       *cache.LRUCache[string, []*types.Channel]
+}
+
+func newNameResolver(bot botClient, cfg *discordconfig.Config) *nameResolver {
+	r := &nameResolver{bot: bot, channels: cache.NewLRUCache[string, []*types.Channel](16)}
+	if cfg != nil {
+		r.defaultGuildID = strings.TrimSpace(cfg.Discord.DefaultGuildID)
+	}
+	return r
+}
+
+// isSnowflake reports whether ref is a numeric ID rather than a name. A
+// channel literally named with digits can still be selected as "#123".
+func isSnowflake(ref string) bool {
+	if ref == "" {
+		return false
+	}
+	for _, r := range ref {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// GuildID resolves a guild ID or name (case-insensitive) among the guilds
+// the bot belongs to. Empty refs pass through unchanged.
+func (r *nameResolver) GuildID(ctx context.Context, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || isSnowflake(ref) {
+		return ref, nil
+	}
+	guilds, err := r.listGuilds(ctx)
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, g := range guilds {
+		if strings.EqualFold(g.Name, ref) {
+			matches = append(matches, g.ID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", &arcer.CLIError{
+			Msg:  fmt.Sprintf("no guild named %q", ref),
+			Hint: "check the name with arc-discord guild get, or pass the guild ID",
+		}
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", &arcer.CLIError{
+			Msg:  fmt.Sprintf("guild name %q is ambiguous (%s)", ref, strings.Join(matches, ", ")),
+			Hint: "pass one of the guild IDs instead",
+		}
+	}
+}
+
+// ChannelID resolves a channel ID, "#name", or bare name. Names are looked
+// up in guildRef when given, else in default_guild_id, else across every
+// guild the bot belongs to. Empty refs pass through unchanged.
+func (r *nameResolver) ChannelID(ctx context.Context, ref, guildRef string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || isSnowflake(ref) {
+		return ref, nil
+	}
+	name := strings.TrimPrefix(ref, "#")
+
+	var guildIDs []string
+	guildID, err := r.GuildID(ctx, guildRef)
+	if err != nil {
+		return "", err
+	}
+	if guildID == "" {
+		guildID = r.defaultGuildID
+	}
+	if guildID != "" {
+		guildIDs = []string{guildID}
+	} else {
+		guilds, err := r.listGuilds(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, g := range guilds {
+			guildIDs = append(guildIDs, g.ID)
+		}
+	}
+
+	var matches []string
+	for _, id := range guildIDs {
+		channels, err := r.Channels(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		for _, ch := range channels {
+			if strings.EqualFold(ch.Name, name) {
+				matches = append(matches, ch.ID)
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", &arcer.CLIError{
+			Msg:  fmt.Sprintf("no channel named #%s", name),
+			Hint: "pass --guild or set default_guild_id to search the right server, or use the channel ID",
+		}
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", &arcer.CLIError{
+			Msg:  fmt.Sprintf("channel name #%s is ambiguous (%s)", name, strings.Join(matches, ", ")),
+			Hint: "pass --guild to narrow the search, or use one of the channel IDs",
+		}
+	}
+}
+
+// Channels returns the guild's channels, fetching them once per resolver.
+func (r *nameResolver) Channels(ctx context.Context, guildID string) ([]*types.Channel, error) {
+	if channels, ok := r.channels.Get(guildID); ok {
+		return channels, nil
+	}
+	channels, err := r.bot.Guilds().GetGuildChannels(ctx, guildID)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to list channels for guild %s", guildID)}).WithCause(err)
+	}
+	r.channels.Set(guildID, channels)
+	return channels, nil
+}
+
+func (r *nameResolver) listGuilds(ctx context.Context) ([]*types.Guild, error) {
+	if r.guilds != nil {
+		return r.guilds, nil
+	}
+	guilds, err := r.bot.Users().GetCurrentUserGuilds(ctx)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "failed to list the bot's guilds"}).WithCause(err)
+	}
+	if guilds == nil {
+		guilds = []*types.Guild{}
+	}
+	r.guilds = guilds
+	return guilds, nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func newResolverFixture(defaultGuild string) (*nameResolver, *fakeGuildService) {
+	guildSvc := &fakeGuildService{channels: map[string][]*types.Channel{
+		"111111111111111111": {{ID: "500000000000000001", Name: "deploys"}, {ID: "500000000000000002", Name: "general"}},
+		"222222222222222222": {{ID: "600000000000000001", Name: "general"}},
+	}}
+	bot := &fakeBotClient{guildSvc: guildSvc, userSvc: &fakeUserService{guilds: []*types.Guild{
+		{ID: "111111111111111111", Name: "Arc Labs"},
+		{ID: "222222222222222222", Name: "Sandbox"},
+	}}}
+	cfg := discordconfig.Default()
+	cfg.Discord.DefaultGuildID = defaultGuild
+	return newNameResolver(bot, cfg), guildSvc
+}
+
+func TestNameResolverPassesThroughIDs(t *testing.T) {
+	r, guildSvc := newResolverFixture("")
+	id, err := r.ChannelID(context.Background(), "500000000000000001", "")
+	if err != nil || id != "500000000000000001" {
+		t.Fatalf("expected ID passthrough, got %q, %v", id, err)
+	}
+	if guildSvc.channelCalls != 0 {
+		t.Fatalf("IDs should not trigger lookups, got %d", guildSvc.channelCalls)
+	}
+}
+
+func TestNameResolverResolvesGuildAndChannelNames(t *testing.T) {
+	r, guildSvc := newResolverFixture("")
+	guildID, err := r.GuildID(context.Background(), "arc labs")
+	if err != nil || guildID != "111111111111111111" {
+		t.Fatalf("expected Arc Labs guild, got %q, %v", guildID, err)
+	}
+	id, err := r.ChannelID(context.Background(), "#deploys", "")
+	if err != nil || id != "500000000000000001" {
+		t.Fatalf("expected #deploys, got %q, %v", id, err)
+	}
+	if _, err := r.ChannelID(context.Background(), "#deploys", "Arc Labs"); err != nil {
+		t.Fatalf("resolve within guild: %v", err)
+	}
+	if guildSvc.channelCalls != 2 {
+		t.Fatalf("expected each guild's channels to be listed once, got %d calls", guildSvc.channelCalls)
+	}
+}
+
+func TestNameResolverRejectsAmbiguousChannel(t *testing.T) {
+	r, _ := newResolverFixture("")
+	_, err := r.ChannelID(context.Background(), "#general", "")
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+
+	r, _ = newResolverFixture("222222222222222222")
+	id, err := r.ChannelID(context.Background(), "general", "")
+	if err != nil || id != "600000000000000001" {
+		t.Fatalf("default guild should narrow the search, got %q, %v", id, err)
+	}
+}
+
+func TestNameResolverUnknownGuild(t *testing.T) {
+	r, _ := newResolverFixture("")
+	if _, err := r.GuildID(context.Background(), "Nowhere"); err == nil || !strings.Contains(err.Error(), "no guild named") {
+		t.Fatalf("expected unknown guild error, got %v", err)
+	}
+}
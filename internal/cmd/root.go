@@ -18,7 +18,10 @@ func NewRootCmd() *cobra.Command {
 		Long: `Interact with Discord webhooks and bot endpoints using the Discord SDK.
 Configuration is discovered automatically from ~/.config/arc/discord.yaml, config/discord.yaml,
 or the file specified with --config. The command family follows the standard --output json|yaml|table pattern
-and surfaces webhook as well as authenticated bot workflows.`,
+(plus go-template=TEMPLATE and go-template-file=PATH for extracting fields) and surfaces webhook as well as authenticated bot workflows.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyOutputTemplate(cmd, &opts.output)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -34,6 +37,10 @@ Example:
   # Inspect channel metadata in YAML
   arc-discord channel get --channel $CHANNEL_ID --output yaml
 
+Example:
+  # Print just the fields a script needs
+  arc-discord message list --channel $CHANNEL_ID --output go-template='{{range .}}{{.id}} {{.author}}{{"\n"}}{{end}}'
+
 Example:
   # Override the config path when testing new profiles
   arc-discord webhook send "Smoketest passed" --config ~/.config/arc/discord_staging.yaml`,
@@ -45,16 +52,32 @@ Example:
 	cmd.PersistentFlags().StringVar(&opts.webhookOverride, "webhook-url", "", "Override webhook URL for webhook commands")
 	cmd.PersistentFlags().StringVar(&opts.profile, "profile", "", "Use named profile from discord.yaml (switches bot token/webhooks)")
 	cmd.PersistentFlags().StringVar(&opts.environment, "env", "", "Use named environment webhooks from discord.yaml")
+	cmd.PersistentFlags().StringVar(&opts.locale, "locale", "", "Locale for table dates and numbers, e.g. en-GB, de (overrides output.locale)")
+	cmd.PersistentFlags().StringVar(&opts.timezone, "timezone", "", "IANA time zone for table timestamps (overrides output.timezone)")
 	cmd.PersistentFlags().StringVar(&opts.rateStrategy, "rate-limit-strategy", "", "Override rate limit strategy: adaptive|reactive|proactive")
 
 	cmd.AddCommand(webhookCmd(opts))
 	cmd.AddCommand(messageCmd(opts))
+	cmd.AddCommand(dmCmd(opts))
 	cmd.AddCommand(channelCmd(opts))
 	cmd.AddCommand(guildCmd(opts))
 	cmd.AddCommand(configCmd(opts))
+	cmd.AddCommand(appCmd(opts))
 	cmd.AddCommand(interactionCmd(opts))
 	cmd.AddCommand(serverCmd(opts))
+	cmd.AddCommand(jobsCmd(opts))
 	cmd.AddCommand(agentCmd(opts))
+	cmd.AddCommand(stateCmd(opts))
+	cmd.AddCommand(gatewayCmd(opts))
+	cmd.AddCommand(voiceCmd(opts))
+	cmd.AddCommand(stageCmd(opts))
+	cmd.AddCommand(dashboardCmd(opts))
+	cmd.AddCommand(ratelimitCmd(opts))
+	cmd.AddCommand(utilCmd(opts))
+	cmd.AddCommand(selftestCmd(opts))
+	cmd.AddCommand(doctorCmd(opts))
 
+	registerFlagCompletions(cmd, opts)
+	registerRateLimitFlush()
 	return cmd
 }
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// Routing rule actions.
+const (
+	ruleActionRoute     = "route"
+	ruleActionDrop      = "drop"
+	ruleActionTransform = "transform"
+)
+
+const defaultDropMessage = "This isn't available here."
+
+// routingRule is an entry of interactions.rules. Rules run in order before
+// an interaction is dispatched: the first matching route or drop rule
+// decides where it goes, and matching transform rules edit its options for
+// the rules and the agent after them. Interactions no rule decides go to
+// the handler's agent.
+type routingRule struct {
+	Name   string    `yaml:"name"`
+	Match  ruleMatch `yaml:"match"`
+	Action string    `yaml:"action"`
+	// Agent receives interactions a route rule matches.
+	Agent string `yaml:"agent"`
+	// Message answers interactions a drop rule matches, ephemerally.
+	Message string `yaml:"message"`
+	// SetOptions are the option values a transform rule sets, added to the
+	// (sub)command's options when missing.
+	SetOptions map[string]string `yaml:"set_options"`
+}
+
+// ruleMatch holds the conditions of a rule; all that are set must hold.
+type ruleMatch struct {
+	Kind string `yaml:"kind"`
+	// Key is the handler key the interaction matched, e.g. the command name.
+	Key      string   `yaml:"key"`
+	Guilds   []string `yaml:"guilds"`
+	Channels []string `yaml:"channels"`
+	// Roles matches members with any of these role IDs.
+	Roles []string `yaml:"roles"`
+	// Options matches option values, compared as text, at any subcommand
+	// depth.
+	Options map[string]string `yaml:"options"`
+}
+
+func (r routingRule) label(index int) string {
+	if r.Name != "" {
+		return fmt.Sprintf("rule %q", r.Name)
+	}
+	return fmt.Sprintf("rule %d", index)
+}
+
+func (r routingRule) validate() error {
+	switch r.action() {
+	case ruleActionRoute:
+		if strings.TrimSpace(r.Agent) == "" {
+			return errors.New("route needs an agent")
+		}
+	case ruleActionDrop:
+	case ruleActionTransform:
+		if len(r.SetOptions) == 0 {
+			return errors.New("transform needs set_options")
+		}
+	default:
+		return fmt.Errorf("unknown action %q (use route, drop, or transform)", r.Action)
+	}
+	switch r.Match.Kind {
+	case "", handlerKindCommand, handlerKindComponent, handlerKindModal:
+	default:
+		return fmt.Errorf("unknown match kind %q (use command, component, or modal)", r.Match.Kind)
+	}
+	return nil
+}
+
+func (r routingRule) action() string {
+	return strings.ToLower(strings.TrimSpace(r.Action))
+}
+
+func validateRoutingRules(rules []routingRule) error {
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("interactions.rules: %s: %w", rule.label(i), err)
+		}
+	}
+	return nil
+}
+
+func (m ruleMatch) matches(binding handlerBinding, i *types.Interaction) bool {
+	if m.Kind != "" && m.Kind != binding.Kind {
+		return false
+	}
+	if m.Key != "" && !strings.EqualFold(m.Key, binding.Key) {
+		return false
+	}
+	if len(m.Guilds) > 0 && !containsString(m.Guilds, i.GuildID) {
+		return false
+	}
+	if len(m.Channels) > 0 && !containsString(m.Channels, i.ChannelID) {
+		return false
+	}
+	if len(m.Roles) > 0 {
+		if i.Member == nil || !containsAny(m.Roles, i.Member.Roles) {
+			return false
+		}
+	}
+	for name, want := range m.Options {
+		opt := findInteractionOption(i, name)
+		if opt == nil || fmt.Sprint(opt.Value) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleOutcome is what the routing rules decided for an interaction.
+type ruleOutcome struct {
+	// Agent overrides the handler's agent when set.
+	Agent string
+	// Drop answers the interaction with Message instead of dispatching it.
+	Drop    bool
+	Message string
+}
+
+// applyRoutingRules runs the binding's rules against i, applying transforms
+// to i in place.
+func applyRoutingRules(binding handlerBinding, i *types.Interaction) ruleOutcome {
+	for _, rule := range binding.Rules {
+		if !rule.Match.matches(binding, i) {
+			continue
+		}
+		switch rule.action() {
+		case ruleActionRoute:
+			return ruleOutcome{Agent: rule.Agent}
+		case ruleActionDrop:
+			message := rule.Message
+			if message == "" {
+				message = defaultDropMessage
+			}
+			return ruleOutcome{Drop: true, Message: message}
+		case ruleActionTransform:
+			for name, value := range rule.SetOptions {
+				setInteractionOption(i, name, value)
+			}
+		}
+	}
+	return ruleOutcome{}
+}
+
+func buildDropResponse(message string) (*types.InteractionResponse, error) {
+	return interactions.NewMessageResponse(message).SetEphemeral(true).Build()
+}
+
+// findInteractionOption returns the option named name, searching
+// subcommand options.
+func findInteractionOption(i *types.Interaction, name string) *types.ApplicationCommandOption {
+	if i.Data == nil {
+		return nil
+	}
+	return findOption(i.Data.Options, name)
+}
+
+func findOption(options []types.ApplicationCommandOption, name string) *types.ApplicationCommandOption {
+	for idx := range options {
+		opt := &options[idx]
+		if isSubcommandOption(opt.Type) {
+			if found := findOption(opt.Options, name); found != nil {
+				return found
+			}
+			continue
+		}
+		if strings.EqualFold(opt.Name, name) {
+			return opt
+		}
+	}
+	return nil
+}
+
+// setInteractionOption sets an option value, adding the option to the
+// invoked (sub)command when it was not given.
+func setInteractionOption(i *types.Interaction, name, raw string) {
+	if i.Data == nil {
+		i.Data = &types.InteractionData{}
+	}
+	optType, value := inferOptionValue(raw)
+	if opt := findInteractionOption(i, name); opt != nil {
+		opt.Value = value
+		return
+	}
+	options := &i.Data.Options
+	for {
+		var sub *types.ApplicationCommandOption
+		for idx := range *options {
+			if isSubcommandOption((*options)[idx].Type) {
+				sub = &(*options)[idx]
+				break
+			}
+		}
+		if sub == nil {
+			break
+		}
+		options = &sub.Options
+	}
+	*options = append(*options, types.ApplicationCommandOption{Type: optType, Name: name, Value: value})
+}
+
+func isSubcommandOption(t types.ApplicationCommandOptionType) bool {
+	return t == types.CommandOptionSubCommand || t == types.CommandOptionSubCommandGroup
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, c := range candidates {
+		if containsString(values, c) {
+			return true
+		}
+	}
+	return false
+}
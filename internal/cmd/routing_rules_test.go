@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+const routingRulesConfig = `enabled: true
+handlers:
+  commands:
+    deploy:
+      agent: staging
+rules:
+  - name: quiet-channel
+    match: {channels: ["900"]}
+    action: drop
+    message: Not in this channel.
+  - name: default-region
+    match: {key: deploy}
+    action: transform
+    set_options: {region: eu}
+  - name: infra-prod
+    match:
+      kind: command
+      key: deploy
+      guilds: ["100"]
+      roles: ["7"]
+      options: {env: prod}
+    action: route
+    agent: prod
+`
+
+func TestRoutingRulesRouteDropAndTransform(t *testing.T) {
+	var cfg interactionsConfig
+	if err := yaml.Unmarshal([]byte(routingRulesConfig), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	bindings := collectHandlerBindings(cfg)
+	if len(bindings) != 1 {
+		t.Fatalf("expected one binding, got %d", len(bindings))
+	}
+	binding, _, err := prepareBinding(bindings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploy := func(guild, channel, env string, roles ...string) *types.Interaction {
+		return &types.Interaction{
+			Type:      types.InteractionTypeApplicationCommand,
+			GuildID:   guild,
+			ChannelID: channel,
+			Member:    &types.Member{Roles: roles},
+			Data: &types.InteractionData{Name: "deploy", Options: []types.ApplicationCommandOption{
+				{Type: types.CommandOptionString, Name: "env", Value: env},
+			}},
+		}
+	}
+	cases := []struct {
+		name        string
+		interaction *types.Interaction
+		agent       string
+	}{
+		{"infra guild prod", deploy("100", "1", "prod", "7"), "prod"},
+		{"other guild", deploy("200", "1", "prod", "7"), "staging"},
+		{"missing role", deploy("100", "1", "prod"), "staging"},
+		{"staging env", deploy("100", "1", "staging", "7"), "staging"},
+	}
+	for _, tc := range cases {
+		pub := &stubPublisher{}
+		if _, err := dispatchHandler(binding, 0, pub)(context.Background(), tc.interaction); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if len(pub.envelopes) != 1 || pub.envelopes[0].Agent != tc.agent {
+			t.Fatalf("%s: expected agent %s, got %+v", tc.name, tc.agent, pub.envelopes)
+		}
+		if !strings.Contains(string(pub.envelopes[0].Interaction), `"name":"region","description":"","value":"eu"`) {
+			t.Fatalf("%s: transform did not add region: %s", tc.name, pub.envelopes[0].Interaction)
+		}
+	}
+
+	pub := &stubPublisher{}
+	resp, err := dispatchHandler(binding, 0, pub)(context.Background(), deploy("100", "900", "prod", "7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pub.envelopes) != 0 || resp.Data.Content != "Not in this channel." || resp.Data.Flags&types.MessageFlagEphemeral == 0 {
+		t.Fatalf("expected ephemeral drop, got %d envelopes and %+v", len(pub.envelopes), resp.Data)
+	}
+}
+
+func TestLoadInteractionSettingsReadsRoutingRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	indented := "  " + strings.ReplaceAll(strings.TrimSpace(routingRulesConfig), "\n", "\n  ")
+	if err := os.WriteFile(path, []byte("interactions:\n"+indented+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	bindings := collectHandlerBindings(settings.Interactions)
+	if len(bindings) != 1 || len(bindings[0].Rules) != 3 || bindings[0].Rules[2].Agent != "prod" {
+		t.Fatalf("expected the file's rules on the binding, got %+v", bindings)
+	}
+}
+
+func TestSetInteractionOptionTargetsSubcommand(t *testing.T) {
+	i := &types.Interaction{Data: &types.InteractionData{Options: []types.ApplicationCommandOption{
+		{Type: types.CommandOptionSubCommand, Name: "start", Options: []types.ApplicationCommandOption{
+			{Type: types.CommandOptionInteger, Name: "count", Value: float64(1)},
+		}},
+	}}}
+	setInteractionOption(i, "count", "3")
+	setInteractionOption(i, "dry_run", "true")
+	sub := i.Data.Options[0].Options
+	if len(sub) != 2 || sub[0].Value != int64(3) || sub[1].Name != "dry_run" || sub[1].Value != true {
+		t.Fatalf("unexpected subcommand options %+v", sub)
+	}
+}
+
+func TestValidateRoutingRules(t *testing.T) {
+	for _, rule := range []routingRule{
+		{Action: "route"},
+		{Action: "transform"},
+		{Action: "forward", Agent: "ops"},
+		{Action: "drop", Match: ruleMatch{Kind: "autocomplete"}},
+	} {
+		if err := validateRoutingRules([]routingRule{rule}); err == nil {
+			t.Fatalf("expected error for %+v", rule)
+		}
+	}
+	if err := validateRoutingRules([]routingRule{{Action: "Route", Agent: "ops"}, {Action: "drop"}}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// scriptCostLimit bounds the work one script run may do, in CEL cost units
+// (roughly one per operation or comprehension step).
+const scriptCostLimit = 10000
+
+// handlerScript is a compiled route script: a CEL expression
+// (https://cel.dev) evaluating to the reply text, or to a map with content
+// and ephemeral:
+//
+//	{'content': 'pong ' + interaction.?member.user.username.orValue(interaction.user.username),
+//	 'ephemeral': true}
+//
+// interaction is the interaction with Discord's JSON field names and options
+// maps option names, including subcommand options, to their values. Optional
+// selection (a.?b.orValue(c)) and the CEL string extensions are available.
+// Runs stop once they pass scriptCostLimit.
+type handlerScript struct {
+	program cel.Program
+}
+
+func scriptEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("interaction", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("options", cel.MapType(cel.StringType, cel.DynType)),
+		cel.OptionalTypes(),
+		ext.Strings(),
+	)
+}
+
+func parseHandlerScript(src string) (*handlerScript, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.New("script is empty")
+	}
+	env, err := scriptEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(src)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("script: %w", issues.Err())
+	}
+	switch out := ast.OutputType(); out.Kind() {
+	case celtypes.StringKind, celtypes.DynKind, celtypes.MapKind:
+	default:
+		return nil, fmt.Errorf("script must evaluate to a string or a map of content and ephemeral, not %s", out)
+	}
+	program, err := env.Program(ast, cel.CostLimit(scriptCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("script: %w", err)
+	}
+	return &handlerScript{program: program}, nil
+}
+
+// respond runs the script against i and builds the message response.
+func (s *handlerScript) respond(i *types.Interaction, ephemeral bool) (*types.InteractionResponse, error) {
+	vars, err := scriptVars(i)
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := s.program.Eval(vars)
+	if err != nil {
+		return nil, fmt.Errorf("script: %w", err)
+	}
+	data := &types.InteractionApplicationCommandCallbackData{}
+	if data.Content, ephemeral, err = scriptResult(out, ephemeral); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(data.Content) == "" {
+		return nil, errors.New("script left the content empty")
+	}
+	if ephemeral {
+		data.Flags |= types.MessageFlagEphemeral
+	}
+	resp := &types.InteractionResponse{Type: types.InteractionResponseChannelMessageWithSource, Data: data}
+	if err := resp.Validate(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// scriptResult reads the reply text and ephemeral flag from a script's value.
+func scriptResult(out ref.Val, ephemeral bool) (string, bool, error) {
+	if text, ok := out.Value().(string); ok {
+		return text, ephemeral, nil
+	}
+	native, err := out.ConvertToNative(reflect.TypeOf(map[string]any{}))
+	if err != nil {
+		return "", false, fmt.Errorf("script returned %s, not a string or a map", out.Type().TypeName())
+	}
+	content := ""
+	for key, value := range native.(map[string]any) {
+		switch key {
+		case "content":
+			content = scriptText(value)
+		case "ephemeral":
+			flag, ok := value.(bool)
+			if !ok {
+				return "", false, errors.New("script ephemeral must be true or false")
+			}
+			ephemeral = flag
+		default:
+			return "", false, fmt.Errorf("script cannot set %s (use content or ephemeral)", key)
+		}
+	}
+	return content, ephemeral, nil
+}
+
+// scriptVars builds the script's interaction and options variables.
+func scriptVars(i *types.Interaction) (map[string]any, error) {
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return nil, fmt.Errorf("encode interaction: %w", err)
+	}
+	var interaction map[string]any
+	if err := json.Unmarshal(raw, &interaction); err != nil {
+		return nil, fmt.Errorf("decode interaction: %w", err)
+	}
+	options := map[string]any{}
+	if i.Data != nil {
+		collectScriptOptions(i.Data.Options, options)
+	}
+	return map[string]any{"interaction": interaction, "options": options}, nil
+}
+
+func collectScriptOptions(opts []types.ApplicationCommandOption, into map[string]any) {
+	for _, opt := range opts {
+		if isSubcommandOption(opt.Type) {
+			collectScriptOptions(opt.Options, into)
+			continue
+		}
+		into[opt.Name] = opt.Value
+	}
+}
+
+func scriptText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(raw)
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+func TestHandlerScriptAnswersInServer(t *testing.T) {
+	binding, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "ping", Route: handlerRoute{
+		Script: "// reply with the caller's name\n" +
+			"{'content': 'pong ' + interaction.?member.user.username.orValue(interaction.user.username) + ' in ' + options.?region.orValue('us'),\n" +
+			" 'ephemeral': true}",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &stubPublisher{}
+	handler := dispatchHandler(binding, 0, pub)
+
+	guild := &types.Interaction{
+		Type:   types.InteractionTypeApplicationCommand,
+		Member: &types.Member{User: &types.User{Username: "ada"}},
+		Data: &types.InteractionData{Name: "ping", Options: []types.ApplicationCommandOption{
+			{Type: types.CommandOptionString, Name: "region", Value: "eu"},
+		}},
+	}
+	resp, err := handler(context.Background(), guild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Type != types.InteractionResponseChannelMessageWithSource || resp.Data.Content != "pong ada in eu" || resp.Data.Flags&types.MessageFlagEphemeral == 0 {
+		t.Fatalf("unexpected response %+v", resp.Data)
+	}
+
+	dm := &types.Interaction{Type: types.InteractionTypeApplicationCommand, User: &types.User{Username: "grace"}, Data: &types.InteractionData{Name: "ping"}}
+	resp, err = handler(context.Background(), dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Content != "pong grace in us" {
+		t.Fatalf("unexpected DM response %q", resp.Data.Content)
+	}
+	if len(pub.envelopes) != 0 {
+		t.Fatalf("script route published %d envelopes", len(pub.envelopes))
+	}
+}
+
+func TestParseHandlerScriptErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                      "empty",
+		"'pong' +":              "Syntax error",
+		"secrets.token":         "undeclared reference to 'secrets'",
+		"1 + 2":                 "must evaluate to a string or a map",
+		"interaction.user.name": "",
+	}
+	for src, want := range cases {
+		_, err := parseHandlerScript(src)
+		if want == "" {
+			if err != nil {
+				t.Fatalf("%q: %v", src, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%q: expected error containing %q, got %v", src, want, err)
+		}
+	}
+	if _, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "ping", Route: handlerRoute{Agent: "ops", Script: "'x'"}}); err == nil {
+		t.Fatal("expected an error for a script route with an agent")
+	}
+}
+
+func TestHandlerScriptRunErrors(t *testing.T) {
+	i := &types.Interaction{Type: types.InteractionTypeApplicationCommand, User: &types.User{Username: "grace"}, Data: &types.InteractionData{Name: "ping"}}
+	cases := map[string]string{
+		"{'title': 'x'}":      "cannot set title",
+		"{'ephemeral': true}": "left the content empty",
+		"options.region":      "no such key",
+		// 20^3 steps is past the cost limit.
+		"[1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20].map(a, [1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20].map(b, [1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20].map(c, a + b + c))).size() > 0 ? 'x' : 'y'": "cost limit",
+	}
+	for src, want := range cases {
+		script, err := parseHandlerScript(src)
+		if err != nil {
+			t.Fatalf("%q: %v", src, err)
+		}
+		if _, err := script.respond(i, false); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%q: expected error containing %q, got %v", src, want, err)
+		}
+	}
+}
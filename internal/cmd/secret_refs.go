@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	discordconfig "github.com/yourorg/arc-discord/gosdk/config"
+	"github.com/yourorg/arc-sdk/utils"
+)
+
+// secretRefPattern matches a config value that is entirely a secret
+// reference: ${env:NAME}, ${file:/path}, ${vault:path#field}, or
+// ${op://vault/item/field}.
+var secretRefPattern = regexp.MustCompile(`^\$\{(env|file|vault|op):([^}]+)\}$`)
+
+const secretCommandTimeout = 10 * time.Second
+
+// secretCommandFn runs a secret manager CLI (vault, op, age, the OS
+// keychain tools) with stdin as input and returns stdout.
+var secretCommandFn = func(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
+
+// resolveSecretRef returns the secret a reference or encrypted value stands
+// for. Other values are returned unchanged.
+func resolveSecretRef(value string) (string, error) {
+	if strings.HasPrefix(value, encryptedValuePrefix) {
+		return decryptConfigValue(value)
+	}
+	m := secretRefPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return value, nil
+	}
+	scheme, ref := m[1], m[2]
+	switch scheme {
+	case "env":
+		secret, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", ref)
+		}
+		return secret, nil
+	case "file":
+		data, err := os.ReadFile(utils.ExpandPath(ref))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case "vault":
+		// vault kv get handles both KV engine versions and reads VAULT_ADDR
+		// and VAULT_TOKEN from the environment.
+		path, field, ok := strings.Cut(ref, "#")
+		if !ok || path == "" || field == "" {
+			return "", fmt.Errorf("vault reference %q must be path#field", ref)
+		}
+		return runSecretCommand("vault", "kv", "get", "-field="+field, path)
+	case "op":
+		// The 1Password CLI reads op:// secret references directly.
+		return runSecretCommand("op", "read", "--no-newline", "op:"+ref)
+	}
+	return "", fmt.Errorf("unknown secret scheme %q", scheme)
+}
+
+func runSecretCommand(name string, args ...string) (string, error) {
+	out, err := runSecretCommandInput(nil, name, args...)
+	return strings.TrimRight(string(out), "\r\n"), err
+}
+
+func runSecretCommandInput(stdin []byte, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretCommandTimeout)
+	defer cancel()
+	out, err := secretCommandFn(ctx, stdin, name, args...)
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%s CLI not found in PATH", name)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// resolveConfigSecrets replaces secret references in the Discord section,
+// profiles, and environment webhooks with the secrets they name.
+func resolveConfigSecrets(cfg *discordconfig.Config) error {
+	if err := resolveDiscordSecrets(&cfg.Discord, "discord"); err != nil {
+		return err
+	}
+	for name, profile := range cfg.Profiles {
+		if profile.Discord == nil {
+			continue
+		}
+		if err := resolveDiscordSecrets(profile.Discord, "profiles."+name+".discord"); err != nil {
+			return err
+		}
+	}
+	for name, env := range cfg.Environments {
+		if err := resolveSecretMap(env.Webhooks, "environments."+name+".webhooks"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resolveDiscordSecrets(d *discordconfig.DiscordConfig, prefix string) error {
+	fields := []secretField{
+		{prefix + ".bot_token", &d.BotToken},
+		{prefix + ".application_id", &d.ApplicationID},
+		{prefix + ".default_guild_id", &d.DefaultGuildID},
+		{prefix + ".default_channel_id", &d.DefaultChannelID},
+	}
+	for i := range d.BotTokens {
+		fields = append(fields, secretField{fmt.Sprintf("%s.bot_tokens.%d", prefix, i), &d.BotTokens[i]})
+	}
+	if err := resolveSecretFields(fields); err != nil {
+		return err
+	}
+	return resolveSecretMap(d.Webhooks, prefix+".webhooks")
+}
+
+// resolveSettingsSecrets resolves secret references in the credentials
+// loadInteractionSettings reads.
+func resolveSettingsSecrets(s *interactionSettings) error {
+	fields := []secretField{
+		{"discord.public_key", &s.PublicKey},
+		{"redis.username", &s.Redis.Username},
+		{"redis.password", &s.Redis.Password},
+		{"tunnel.ngrok_auth_token", &s.Tunnel.NgrokAuthToken},
+	}
+	for i := range s.Server.Auth.BearerTokens {
+		fields = append(fields, secretField{fmt.Sprintf("server.auth.bearer_tokens.%d", i), &s.Server.Auth.BearerTokens[i]})
+	}
+	return resolveSecretFields(fields)
+}
+
+type secretField struct {
+	name  string
+	value *string
+}
+
+func resolveSecretFields(fields []secretField) error {
+	for _, f := range fields {
+		if err := resolveSecretField(f.value, f.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resolveSecretMap(values map[string]string, prefix string) error {
+	for key, value := range values {
+		if err := resolveSecretField(&value, prefix+"."+key); err != nil {
+			return err
+		}
+		values[key] = value
+	}
+	return nil
+}
+
+func resolveSecretField(value *string, field string) error {
+	secret, err := resolveSecretRef(*value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	*value = secret
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecretRefs(t *testing.T) {
+	t.Setenv("ARC_TEST_TOKEN", "from-env")
+	secretFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var calls [][]string
+	orig := secretCommandFn
+	secretCommandFn = func(_ context.Context, _ []byte, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{name}, args...))
+		return []byte("from-" + name + "\n"), nil
+	}
+	t.Cleanup(func() { secretCommandFn = orig })
+
+	for ref, want := range map[string]string{
+		"${env:ARC_TEST_TOKEN}":         "from-env",
+		"${file:" + secretFile + "}":    "from-file",
+		"${vault:secret/discord#token}": "from-vault",
+		"${op://Private/Discord/token}": "from-op",
+		"plain-token":                   "plain-token",
+		"prefix-${env:ARC_TEST_TOKEN}":  "prefix-${env:ARC_TEST_TOKEN}",
+	} {
+		got, err := resolveSecretRef(ref)
+		if err != nil || got != want {
+			t.Fatalf("resolveSecretRef(%q) = %q, %v; want %q", ref, got, err, want)
+		}
+	}
+	wantCalls := [][]string{
+		{"vault", "kv", "get", "-field=token", "secret/discord"},
+		{"op", "read", "--no-newline", "op://Private/Discord/token"},
+	}
+	for _, want := range wantCalls {
+		found := false
+		for _, call := range calls {
+			found = found || reflect.DeepEqual(call, want)
+		}
+		if !found {
+			t.Fatalf("expected call %v, got %v", want, calls)
+		}
+	}
+
+	if _, err := resolveSecretRef("${env:ARC_TEST_MISSING}"); err == nil {
+		t.Fatal("expected missing env var to fail")
+	}
+	if _, err := resolveSecretRef("${vault:secret/discord}"); err == nil {
+		t.Fatal("expected vault reference without a field to fail")
+	}
+}
+
+func TestLoadDiscordConfigResolvesSecrets(t *testing.T) {
+	t.Setenv("ARC_TEST_TOKEN", "bot-secret")
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	data := `discord:
+  bot_token: ${env:ARC_TEST_TOKEN}
+  public_key: ${env:ARC_TEST_TOKEN}
+redis:
+  password: ${env:ARC_TEST_MISSING}
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _, err := loadDiscordConfig(path)
+	if err != nil {
+		t.Fatalf("loadDiscordConfig: %v", err)
+	}
+	if cfg.Discord.BotToken != "bot-secret" {
+		t.Fatalf("bot token not resolved: %q", cfg.Discord.BotToken)
+	}
+	_, err = loadInteractionSettings(path)
+	if err == nil || !strings.Contains(err.Error(), "redis.password") {
+		t.Fatalf("expected redis.password error, got %v", err)
+	}
+}
@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const (
+	selftestAgent          = "selftest"
+	selftestApplicationID  = "selftest-app"
+	defaultSelftestTimeout = 5 * time.Second
+	selftestPass           = "pass"
+	selftestFail           = "fail"
+	selftestSkip           = "skip"
+)
+
+type selftestStage struct {
+	Stage    string `json:"stage" yaml:"stage"`
+	Status   string `json:"status" yaml:"status"`
+	Detail   string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	Duration string `json:"duration" yaml:"duration"`
+}
+
+func selftestCmd(opts *globalOptions) *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Run an end-to-end smoke test of the interaction pipeline",
+		Long: `Run the whole interaction pipeline in process: an in-memory broker, a dry-run interactions
+server on a random local port, and a built-in echo agent. Synthetic command, component, and modal
+interactions are posted to the server and each stage reports pass or fail. No Discord credentials
+or Redis are needed, so it is safe to run in CI and after deployments.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			stages := runSelftest(cmd.Context(), timeout)
+			rows := make([][]string, 0, len(stages))
+			failed := 0
+			for _, stage := range stages {
+				if stage.Status != selftestPass {
+					failed++
+				}
+				rows = append(rows, []string{stage.Stage, stage.Status, stage.Duration, valueOrDash(stage.Detail)})
+			}
+			table := &tableData{headers: []string{"Stage", "Result", "Duration", "Detail"}, rows: rows}
+			if err := renderOutput(cmd, opts.output, stages, table); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return &arcer.CLIError{
+					Msg:  fmt.Sprintf("selftest failed: %d of %d stages did not pass", failed, len(stages)),
+					Hint: "see the Detail column for the failing stage",
+				}
+			}
+			return nil
+		},
+		Example: `Example:
+  arc-discord selftest
+
+Example:
+  # Machine-readable result for CI
+  arc-discord selftest --output json --timeout 10s`,
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultSelftestTimeout, "How long each stage may take")
+	return cmd
+}
+
+// selftestRecorder stands in for the Discord API and reports the token of
+// every original-response edit the agent makes.
+type selftestRecorder struct {
+	edits chan string
+}
+
+func (r *selftestRecorder) EditOriginalInteractionResponse(ctx context.Context, applicationID, token string, params *types.MessageEditParams) (*types.Message, error) {
+	select {
+	case r.edits <- token:
+	default:
+	}
+	return &types.Message{ID: token}, nil
+}
+
+func (r *selftestRecorder) CreateFollowupMessage(ctx context.Context, applicationID, token string, params *types.MessageCreateParams) (*types.Message, error) {
+	return &types.Message{ID: token + "-followup"}, nil
+}
+
+type discardPrinter struct{}
+
+func (discardPrinter) Printf(string, ...interface{}) {}
+
+type selftestInteraction struct {
+	stage       string
+	interaction types.Interaction
+}
+
+func selftestInteractions() []selftestInteraction {
+	return []selftestInteraction{
+		{stage: "command /selftest", interaction: types.Interaction{
+			Type: types.InteractionTypeApplicationCommand,
+			Data: &types.InteractionData{Name: "selftest"},
+		}},
+		{stage: "component selftest:confirm", interaction: types.Interaction{
+			Type: types.InteractionTypeMessageComponent,
+			Data: &types.InteractionData{CustomID: "selftest:confirm", ComponentType: types.ComponentTypeButton},
+		}},
+		{stage: "modal selftest:feedback", interaction: types.Interaction{
+			Type: types.InteractionTypeModalSubmit,
+			Data: &types.InteractionData{CustomID: "selftest:feedback"},
+		}},
+	}
+}
+
+func runSelftest(parent context.Context, timeout time.Duration) []selftestStage {
+	if timeout <= 0 {
+		timeout = defaultSelftestTimeout
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var stages []selftestStage
+	record := func(name string, started time.Time, err error, detail string) bool {
+		stage := selftestStage{Stage: name, Status: selftestPass, Detail: detail, Duration: time.Since(started).Round(time.Millisecond).String()}
+		if err != nil {
+			stage.Status = selftestFail
+			stage.Detail = err.Error()
+		}
+		stages = append(stages, stage)
+		return err == nil
+	}
+	skipRest := func(from int) []selftestStage {
+		for _, pending := range selftestInteractions()[from:] {
+			stages = append(stages, selftestStage{Stage: pending.stage, Status: selftestSkip, Detail: "earlier stage failed", Duration: "0s"})
+		}
+		return stages
+	}
+
+	// Broker and echo agent.
+	started := time.Now()
+	mem := broker.NewMemory()
+	defer mem.Close()
+	recorder := &selftestRecorder{edits: make(chan string, 8)}
+	listener := newAgentListener(selftestAgent, selftestApplicationID, recorder, discardPrinter{})
+	agentErr := make(chan error, 1)
+	go func() {
+		agentErr <- mem.Subscribe(ctx, selftestAgent, func(ctx context.Context, msg *broker.Message) error {
+			return listener.handlePayload(ctx, msg.Payload)
+		})
+	}()
+	err := waitForSelftest(ctx, timeout, func() bool { return mem.Subscribers(selftestAgent) > 0 })
+	if !record("broker + echo agent", started, err, "in-memory broker, agent "+selftestAgent) {
+		stages = append(stages, selftestStage{Stage: "interaction server", Status: selftestSkip, Detail: "earlier stage failed", Duration: "0s"})
+		return skipRest(0)
+	}
+
+	// Dry-run interactions server on a random port.
+	started = time.Now()
+	baseURL, shutdown, err := startSelftestServer(mem, timeout)
+	if err == nil {
+		defer shutdown()
+		var resp *types.InteractionResponse
+		resp, err = postSelftestInteraction(ctx, baseURL, types.Interaction{Type: types.InteractionTypePing}, timeout)
+		if err == nil && resp.Type != types.InteractionResponsePong {
+			err = fmt.Errorf("ping returned response type %d, want %d", resp.Type, types.InteractionResponsePong)
+		}
+	}
+	if !record("interaction server", started, err, baseURL) {
+		return skipRest(0)
+	}
+
+	// Each synthetic interaction must be deferred by the server and then
+	// answered by the agent.
+	for i, tc := range selftestInteractions() {
+		started = time.Now()
+		interaction := tc.interaction
+		interaction.ID = fmt.Sprintf("selftest-%d", i+1)
+		interaction.Token = fmt.Sprintf("selftest-token-%d", i+1)
+		interaction.ApplicationID = selftestApplicationID
+
+		resp, err := postSelftestInteraction(ctx, baseURL, interaction, timeout)
+		if err == nil && resp.Type != types.InteractionResponseDeferredChannelMessageWithSource {
+			err = fmt.Errorf("server returned response type %d, want deferred (%d)", resp.Type, types.InteractionResponseDeferredChannelMessageWithSource)
+		}
+		if err == nil {
+			err = waitForSelftestEdit(ctx, recorder.edits, agentErr, interaction.Token, timeout)
+		}
+		record(tc.stage, started, err, "deferred by server, answered by agent")
+	}
+	return stages
+}
+
+func startSelftestServer(publisher broker.Publisher, timeout time.Duration) (string, func(), error) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return "", nil, err
+	}
+	cfg := interactionsConfig{
+		Enabled: true,
+		Timeout: timeout,
+		Handlers: handlerMappings{
+			Commands:   map[string]handlerRoute{"selftest": {Agent: selftestAgent}},
+			Components: map[string]handlerRoute{"selftest:confirm": {Agent: selftestAgent}},
+			Modals:     map[string]handlerRoute{"selftest:feedback": {Agent: selftestAgent}},
+		},
+	}
+	builder := interactionServerBuilder{PublicKey: hex.EncodeToString(pub), DryRun: true, Publisher: publisher}
+	srv, err := builder.build(cfg.Timeout, collectHandlerBindings(cfg))
+	if err != nil {
+		return "", nil, err
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("listen: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/interactions", srv.HandleInteraction)
+	httpServer := &http.Server{Handler: mux}
+	go func() { _ = httpServer.Serve(ln) }()
+	shutdown := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(ctx)
+	}
+	return "http://" + ln.Addr().String() + "/interactions", shutdown, nil
+}
+
+func postSelftestInteraction(ctx context.Context, url string, interaction types.Interaction, timeout time.Duration) (*types.InteractionResponse, error) {
+	body, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, err
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned HTTP %d", res.StatusCode)
+	}
+	var resp types.InteractionResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &resp, nil
+}
+
+func waitForSelftestEdit(ctx context.Context, edits <-chan string, agentErr <-chan error, token string, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case got := <-edits:
+			if got == token {
+				return nil
+			}
+		case err := <-agentErr:
+			if err == nil {
+				err = errors.New("agent stopped")
+			}
+			return fmt.Errorf("agent exited: %w", err)
+		case <-timer.C:
+			return fmt.Errorf("agent did not respond within %s", timeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func waitForSelftest(ctx context.Context, timeout time.Duration, ready func() bool) error {
+	deadline := time.Now().Add(timeout)
+	for !ready() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not ready within %s", timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	return nil
+}
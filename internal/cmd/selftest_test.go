@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestSelftestPassesAllStages(t *testing.T) {
+	opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+	cmd := selftestCmd(opts)
+	cmd.SetArgs([]string{"--timeout", "2s"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("selftest: %v\n%s", err, buf.String())
+	}
+	var stages []selftestStage
+	if err := json.Unmarshal(buf.Bytes(), &stages); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, buf.String())
+	}
+	if len(stages) != 5 {
+		t.Fatalf("expected 5 stages, got %#v", stages)
+	}
+	for _, stage := range stages {
+		if stage.Status != selftestPass {
+			t.Fatalf("stage %s did not pass: %+v", stage.Stage, stage)
+		}
+	}
+}
+
+func TestSelftestReportsCanceledRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stages := runSelftest(ctx, 50*time.Millisecond)
+	if len(stages) != 5 {
+		t.Fatalf("expected every stage to be reported, got %#v", stages)
+	}
+	failed := 0
+	for _, stage := range stages {
+		if stage.Status == selftestFail {
+			failed++
+		}
+	}
+	if failed == 0 || stages[len(stages)-1].Status != selftestSkip {
+		t.Fatalf("expected failure then skips, got %#v", stages)
+	}
+}
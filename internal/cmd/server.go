@@ -4,18 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-discord/gosdk/logger"
+	"github.com/yourorg/arc-sdk/utils"
 	arcer "github.com/yourorg/arc-sdk/errors"
 )
 
 var newDaemonManagerFn = func(opts daemonOptions) daemonController { return newDaemonManager(opts) }
-var newRedisPublisherFn = newRedisPublisher
+var newKafkaPublisherFn = func(cfg kafkaConfig) (broker.Publisher, error) { return newKafkaPublisher(cfg, logger.Default()) }
 
 func serverCmd(opts *globalOptions) *cobra.Command {
 	cmd := &cobra.Command{
@@ -27,7 +32,10 @@ func serverCmd(opts *globalOptions) *cobra.Command {
 	}
 	cmd.AddCommand(serverStartCmd(opts))
 	cmd.AddCommand(serverStopCmd())
-	cmd.AddCommand(serverStatusCmd())
+	cmd.AddCommand(serverStatusCmd(opts))
+	cmd.AddCommand(serverSuperviseCmd())
+	cmd.AddCommand(serverInstallSystemdCmd(opts))
+	cmd.AddCommand(serverHealthcheckCmd(opts))
 	return cmd
 }
 
@@ -35,42 +43,90 @@ func serverStartCmd(opts *globalOptions) *cobra.Command {
 	var (
 		listenAddr     string
 		publicURL      string
+		tlsCert        string
+		tlsKey         string
+		acmeDomain     string
 		redisAddr      string
 		redisDB        int
 		redisPass      string
 		redisPrefix    string
+		kafkaBrokers   []string
+		kafkaTopic     string
+		captureDir     string
+		captureFor     time.Duration
+		recordDir      string
+		jobsState      string
+		debugAddr      string
 		dryRun         bool
 		tunnelProvider string
 		ngrokToken     string
 		daemonEnabled  bool
+		systemd        bool
+		foreground     bool
+		exitOnLoss     bool
 		pidFile        string
 		logFile        string
 		workdir        string
 		envFile        string
+		instance       string
+		restart        string
+		maxRestarts    int
+		backoff        time.Duration
 		checkPrereqs   bool
 		showExample    bool
+		watchConfig    bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start the HTTP server that receives Discord interactions",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateInstanceName(instance); err != nil {
+				return &arcer.CLIError{Msg: err.Error(), Hint: "use lowercase letters, digits, - and _"}
+			}
+			if instance != "" {
+				if pidFile == "" {
+					pidFile = instancePIDPath(instance)
+				}
+				if logFile == "" {
+					logFile = instanceLogPath(instance)
+				}
+			}
 			startOpts := serverStartOptions{
 				ListenAddr:     listenAddr,
 				PublicURL:      publicURL,
+				TLSCert:        tlsCert,
+				TLSKey:         tlsKey,
+				ACMEDomain:     acmeDomain,
 				RedisAddr:      redisAddr,
 				RedisDB:        redisDB,
 				RedisPass:      redisPass,
 				RedisPrefix:    redisPrefix,
+				KafkaBrokers:   kafkaBrokers,
+				KafkaTopic:     kafkaTopic,
+				CaptureDir:     captureDir,
+				CaptureFor:     captureFor,
+				RecordDir:      recordDir,
+				JobsState:      jobsState,
+				DebugAddr:      debugAddr,
 				TunnelProvider: tunnelProvider,
 				NgrokToken:     ngrokToken,
 				DryRun:         dryRun,
+				WatchConfig:    watchConfig,
 				Daemon:         daemonEnabled,
+				Instance:       instance,
+				Systemd:        systemd,
+				Foreground:     foreground,
+				ExitOnLoss:     exitOnLoss,
 				DaemonOpts: daemonOptions{
 					PIDFile: pidFile,
 					LogFile: logFile,
 					Workdir: workdir,
 					EnvFile: envFile,
+
+					Restart:     restart,
+					MaxRestarts: maxRestarts,
+					Backoff:     backoff,
 				},
 			}
 
@@ -121,6 +177,9 @@ func serverStartCmd(opts *globalOptions) *cobra.Command {
   # Bind to a specific port and redis instance
   arc-discord server start --listen :9090 --redis-addr redis.internal:6379
 
+  # Mirror every published interaction onto a Kafka topic for archival
+  arc-discord server start --kafka-brokers kafka-1:9092,kafka-2:9092 --kafka-topic discord.interactions
+
   # Development mode with ngrok tunnel
   arc-discord server start --tunnel ngrok --ngrok-auth-token $NGROK_TOKEN
 
@@ -130,6 +189,33 @@ func serverStartCmd(opts *globalOptions) *cobra.Command {
   # Run as a background daemon with PID/log files
   arc-discord server start --daemon --pid-file /tmp/discord.pid --log-file /tmp/discord.log
 
+  # Restart the daemon after crashes, at most 5 times
+  arc-discord server start --daemon --restart on-failure --max-restarts 5 --backoff 2s
+
+  # Two apps on one host: separate PID/log files and channel prefixes
+  arc-discord server start --daemon --instance support --config support.yaml
+  arc-discord server start --daemon --instance ops --config ops.yaml
+  arc-discord server status --all
+
+  # Container: no config file, exit when Redis goes away, probe with server healthcheck
+  arc-discord server start --foreground --env-only --exit-on-redis-loss
+
+  # Record raw requests for 5 minutes to debug endpoint verification behind a proxy
+  arc-discord server start --capture-dir ./captures --capture-for 5m
+
+  # Keep every verified interaction for later "interaction replay"
+  arc-discord server start --record ./recordings
+
+  # Serve HTTPS directly with an existing certificate
+  arc-discord server start --listen :8443 --tls-cert /etc/ssl/bot.crt --tls-key /etc/ssl/bot.key
+
+  # Obtain a Let's Encrypt certificate automatically (listens on :443)
+  arc-discord server start --acme-domain bot.example.com
+
+  # Pick up handler changes in discord.yaml without restarting (SIGHUP also works)
+  arc-discord server start --watch-config
+  kill -HUP $(cat ~/.cache/arc/discord-server.pid)
+
   # Skip signature verification (development only)
   arc-discord server start --dry-run`,
 	}
@@ -141,26 +227,50 @@ func serverStartCmd(opts *globalOptions) *cobra.Command {
 	// Server configuration flags
 	cmd.Flags().StringVar(&listenAddr, "listen", "", "HTTP listen address (overrides server.listen_addr)")
 	cmd.Flags().StringVar(&publicURL, "public-url", "", "Public URL that Discord will hit (optional override)")
+	cmd.Flags().StringVar(&tlsCert, "tls-cert", "", "TLS certificate file for serving HTTPS (overrides server.tls_cert)")
+	cmd.Flags().StringVar(&tlsKey, "tls-key", "", "TLS private key file for serving HTTPS (overrides server.tls_key)")
+	cmd.Flags().StringVar(&acmeDomain, "acme-domain", "", "Obtain a Let's Encrypt certificate for this domain (overrides server.acme_domain)")
+
+	cmd.Flags().BoolVar(&watchConfig, "watch-config", false, "Reload interaction handlers when the config file changes (SIGHUP always reloads)")
 
 	// Redis flags
-	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis address for publishing events")
+	cmd.Flags().StringVar(&redisAddr, "redis-addr", "", "Redis host:port or redis[s]://user:pass@host:port/db URL for publishing events")
 	cmd.Flags().IntVar(&redisDB, "redis-db", 0, "Redis database index")
 	cmd.Flags().StringVar(&redisPass, "redis-password", "", "Redis password")
 	cmd.Flags().StringVar(&redisPrefix, "redis-prefix", "", "Redis channel prefix (default arc:discord)")
 
+	// Kafka mirror flags
+	cmd.Flags().StringSliceVar(&kafkaBrokers, "kafka-brokers", nil, "Kafka brokers for mirroring envelopes (overrides kafka.brokers)")
+	cmd.Flags().StringVar(&kafkaTopic, "kafka-topic", "", "Kafka topic for mirrored envelopes (overrides kafka.topic)")
+
 	// Tunnel flags
 	cmd.Flags().StringVar(&tunnelProvider, "tunnel", "", "Enable a development tunnel: ngrok|localtunnel|auto")
 	cmd.Flags().StringVar(&ngrokToken, "ngrok-auth-token", "", "Ngrok auth token (overrides tunnel.ngrok_auth_token)")
 
 	// Development flags
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Skip signature verification (development only)")
+	cmd.Flags().StringVar(&captureDir, "capture-dir", "", "Write inbound request headers and bodies to this directory for debugging")
+	cmd.Flags().DurationVar(&captureFor, "capture-for", defaultCaptureWindow, "How long to capture requests after startup when --capture-dir is set")
+	cmd.Flags().StringVar(&recordDir, "record", "", "Save every verified interaction to this directory for 'interaction replay'")
+	cmd.Flags().StringVar(&debugAddr, "debug-addr", "", "Loopback address for pprof and /debug/vars, e.g. 127.0.0.1:6060 (overrides server.debug_addr)")
+	cmd.Flags().StringVar(&jobsState, "jobs-state", "", "Job state file used for misfire detection (default ~/.cache/vibe/discord-jobs.json)")
+
+	// Container flags
+	cmd.Flags().BoolVar(&foreground, "foreground", false, "Run as a single foreground process (containers); rejects the daemon flags")
+	cmd.Flags().BoolVar(&exitOnLoss, "exit-on-redis-loss", false, "Drain and exit non-zero when the broker stops answering, so the orchestrator restarts the server")
+	cmd.Flags().BoolVar(&opts.envOnly, "env-only", false, "Ignore config files; read everything from the environment (also $ARC_DISCORD_ENV_ONLY)")
 
 	// Daemon flags
 	cmd.Flags().BoolVar(&daemonEnabled, "daemon", false, "Run the server in the background")
+	cmd.Flags().BoolVar(&systemd, "systemd", false, "Run in the foreground under systemd (Type=notify); see server install-systemd")
 	cmd.Flags().StringVar(&pidFile, "pid-file", "", "PID file path for daemon mode (default ~/.cache/arc/discord-server.pid)")
 	cmd.Flags().StringVar(&logFile, "log-file", "", "Log file for daemon stdout/stderr")
 	cmd.Flags().StringVar(&workdir, "workdir", "", "Working directory for daemonized server")
 	cmd.Flags().StringVar(&envFile, "env-file", "", "Optional env file (KEY=value per line) for daemon mode")
+	cmd.Flags().StringVar(&instance, "instance", "", "Instance name: namespaces the PID file, log file, and default channel prefix")
+	cmd.Flags().StringVar(&restart, "restart", restartNever, "Daemon restart policy: no|on-failure|always (runs a supervisor process)")
+	cmd.Flags().IntVar(&maxRestarts, "max-restarts", 0, "Stop restarting the daemon after this many restarts (0 = unlimited)")
+	cmd.Flags().DurationVar(&backoff, "backoff", defaultRestartBackoff, "Delay before the first daemon restart; doubles after each crash up to 1m")
 
 	return cmd
 }
@@ -168,18 +278,45 @@ func serverStartCmd(opts *globalOptions) *cobra.Command {
 type serverStartOptions struct {
 	ListenAddr     string
 	PublicURL      string
+	TLSCert        string
+	TLSKey         string
+	ACMEDomain     string
 	RedisAddr      string
 	RedisDB        int
 	RedisPass      string
 	RedisPrefix    string
+	KafkaBrokers   []string
+	KafkaTopic     string
+	CaptureDir     string
+	CaptureFor     time.Duration
+	RecordDir      string
+	JobsState      string
+	DebugAddr      string
 	DryRun         bool
 	TunnelProvider string
 	NgrokToken     string
+	WatchConfig    bool
 	Daemon         bool
+	Instance       string
+	Systemd        bool
+	Foreground     bool
+	ExitOnLoss     bool
 	DaemonOpts     daemonOptions
 }
 
 func runServerStart(cmd *cobra.Command, opts *globalOptions, overrides serverStartOptions) error {
+	if err := validateRestartPolicy(overrides.DaemonOpts.Restart); err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "use --restart no, on-failure, or always"}
+	}
+	if overrides.Foreground && (overrides.Daemon || overrides.DaemonOpts.supervised()) {
+		return &arcer.CLIError{Msg: "--foreground cannot be combined with --daemon or --restart", Hint: "let the container runtime restart the process"}
+	}
+	if overrides.Systemd && overrides.Daemon {
+		return &arcer.CLIError{Msg: "--systemd and --daemon are mutually exclusive", Hint: "systemd supervises the process itself; drop --daemon"}
+	}
+	if overrides.DaemonOpts.supervised() && !overrides.Daemon {
+		return &arcer.CLIError{Msg: "--restart requires --daemon", Hint: "run under systemd or another supervisor when not using --daemon"}
+	}
 	if overrides.Daemon && os.Getenv(daemonEnvFlag) == "" {
 		execPath, err := os.Executable()
 		if err != nil {
@@ -193,7 +330,7 @@ func runServerStart(cmd *cobra.Command, opts *globalOptions, overrides serverSta
 		cmd.Printf("daemon started (pid file %s)\n", mgr.PIDPath())
 		return nil
 	}
-	_, extra, cfgPath, err := opts.loadConfigWithInteractions()
+	cfg, extra, cfgPath, err := opts.loadConfigWithInteractions()
 	if err != nil {
 		return err
 	}
@@ -203,6 +340,24 @@ func runServerStart(cmd *cobra.Command, opts *globalOptions, overrides serverSta
 	if overrides.PublicURL != "" {
 		extra.PublicURL = overrides.PublicURL
 	}
+	if overrides.TLSCert != "" {
+		extra.Server.TLSCert = utils.ExpandPath(overrides.TLSCert)
+	}
+	if overrides.TLSKey != "" {
+		extra.Server.TLSKey = utils.ExpandPath(overrides.TLSKey)
+	}
+	if overrides.ACMEDomain != "" {
+		extra.Server.ACMEDomain = overrides.ACMEDomain
+	}
+	if overrides.DebugAddr != "" {
+		extra.Server.DebugAddr = overrides.DebugAddr
+	}
+	if extra.Server.ACMEDomain != "" && overrides.ListenAddr == "" && extra.Server.ListenAddr == defaultListenAddr {
+		extra.Server.ListenAddr = acmeListenAddr
+	}
+	if domains := splitACMEDomains(extra.Server.ACMEDomain); len(domains) > 0 && extra.PublicURL == "" {
+		extra.PublicURL = "https://" + domains[0] + "/interactions"
+	}
 	if overrides.RedisAddr != "" {
 		extra.Redis.Addr = overrides.RedisAddr
 	}
@@ -215,6 +370,13 @@ func runServerStart(cmd *cobra.Command, opts *globalOptions, overrides serverSta
 	if overrides.RedisPrefix != "" {
 		extra.Redis.ChannelPrefix = overrides.RedisPrefix
 	}
+	extra.Redis.ChannelPrefix = instanceChannelPrefix(extra.Redis.ChannelPrefix, overrides.Instance)
+	if len(overrides.KafkaBrokers) > 0 {
+		extra.Kafka.Brokers = overrides.KafkaBrokers
+	}
+	if overrides.KafkaTopic != "" {
+		extra.Kafka.Topic = overrides.KafkaTopic
+	}
 	if overrides.TunnelProvider != "" {
 		extra.Tunnel.Provider = overrides.TunnelProvider
 	}
@@ -224,29 +386,152 @@ func runServerStart(cmd *cobra.Command, opts *globalOptions, overrides serverSta
 	if extra.PublicKey == "" {
 		return &arcer.CLIError{Msg: "discord.public_key is required for signature verification"}
 	}
+	if err := validateServerTLS(extra.Server); err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "set both --tls-cert and --tls-key, or only --acme-domain"}
+	}
+	if err := validateServerAuth(extra.Server); err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "see server.auth in discord.yaml (server start --example)"}
+	}
+	if err := validateServerLimits(extra.Server); err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "timeouts and max_body_bytes in the server section must be positive"}
+	}
+	allowedCIDRs, err := parseAllowedCIDRs(extra.Server.AllowedCIDRs)
+	if err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "list addresses or ranges such as 10.0.0.0/8 or 203.0.113.7"}
+	}
+	if err := validateDebugAddr(extra.Server.DebugAddr); err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "profiling endpoints are unauthenticated; tunnel to them with ssh -L if you need remote access"}
+	}
+	if extra.Server.tlsEnabled() && extra.Tunnel.Provider != "" {
+		return &arcer.CLIError{Msg: "TLS cannot be combined with a tunnel", Hint: "tunnels terminate HTTPS themselves; drop --tunnel or the TLS settings"}
+	}
 
-	publisher, err := newRedisPublisherFn(extra.Redis)
+	brokerCfg, err := extra.brokerConfig()
+	if err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "fix the redis section in discord.yaml"}
+	}
+	b, err := newBrokerFn(cmd.Context(), brokerCfg)
 	if err != nil {
-		return (&arcer.CLIError{Msg: "failed to connect to redis"}).WithCause(err)
+		return (&arcer.CLIError{Msg: "failed to connect to broker"}).WithCause(err)
+	}
+	pinger, canPing := b.(broker.Pinger)
+	if overrides.ExitOnLoss && !canPing {
+		_ = b.Close()
+		return &arcer.CLIError{Msg: fmt.Sprintf("--exit-on-redis-loss is not supported by the %q broker", brokerCfg.Backend)}
+	}
+	var publisher broker.Publisher = b
+	if extra.Kafka.enabled() {
+		kafkaPub, err := newKafkaPublisherFn(extra.Kafka)
+		if err != nil {
+			_ = b.Close()
+			return (&arcer.CLIError{Msg: "failed to initialize kafka publisher"}).WithCause(err)
+		}
+		publisher = newMirroredPublisher(b, logger.Default(), kafkaPub)
+		cmd.Printf("Mirroring interactions to kafka topic %s (%s)\n", extra.Kafka.Topic, strings.Join(extra.Kafka.Brokers, ","))
 	}
+	publisher = newLivenessPublisher(publisher, b.Registry(), logger.Default())
+	if delivery := extra.Interactions.Delivery; delivery.enabled() {
+		bus, ok := b.(broker.AckBus)
+		if !ok {
+			_ = b.Close()
+			return &arcer.CLIError{Msg: fmt.Sprintf("interactions.delivery needs acknowledgements, which the %q broker does not carry", brokerCfg.Backend)}
+		}
+		if cfg.Discord.ApplicationID == "" {
+			_ = b.Close()
+			return &arcer.CLIError{Msg: "discord.application_id is required for interactions.delivery fallback responses"}
+		}
+		responder, err := newInteractionClientFn(cfg, opts.tokenOverride)
+		if err != nil {
+			_ = b.Close()
+			return (&arcer.CLIError{Msg: "failed to initialize interaction client"}).WithCause(err)
+		}
+		tracker := newAckTracker(publisher, delivery, cfg.Discord.ApplicationID, responder, logger.Default())
+		trackCtx, stopTracking := context.WithCancel(cmd.Context())
+		defer stopTracking()
+		go tracker.run(trackCtx, bus)
+		publisher = tracker
+		cmd.Printf("Waiting %s for agent acknowledgements (%d retries)\n", delivery.AckTimeout, delivery.Retries)
+	}
+	if extra.Interactions.ReplyQueue {
+		bus, ok := b.(broker.ResponseBus)
+		if !ok {
+			_ = b.Close()
+			return &arcer.CLIError{Msg: fmt.Sprintf("interactions.reply_queue is not supported by the %q broker", brokerCfg.Backend)}
+		}
+		if cfg.Discord.ApplicationID == "" {
+			_ = b.Close()
+			return &arcer.CLIError{Msg: "discord.application_id is required for interactions.reply_queue"}
+		}
+		responder, err := newInteractionClientFn(cfg, opts.tokenOverride)
+		if err != nil {
+			_ = b.Close()
+			return (&arcer.CLIError{Msg: "failed to initialize interaction client"}).WithCause(err)
+		}
+		router := newReplyRouter(publisher, cfg.Discord.ApplicationID, responder, logger.Default())
+		replyCtx, stopReplies := context.WithCancel(cmd.Context())
+		defer stopReplies()
+		go router.run(replyCtx, bus)
+		publisher = router
+		cmd.Printf("Delivering agent responses from %s\n", brokerCfg.ResponseChannel("*"))
+	}
+	pending := newDrainingPublisher(publisher)
+	publisher = pending
 	defer publisher.Close()
 
-	serverOptions := []interactions.ServerOption{}
-	if overrides.DryRun {
-		serverOptions = append(serverOptions, interactions.WithDryRun(true))
+	suggestions, err := newSuggestionStore(extra.Redis)
+	if err != nil {
+		return &arcer.CLIError{Msg: err.Error(), Hint: "fix the redis section in discord.yaml"}
 	}
-	srv, err := interactions.NewServer(extra.PublicKey, serverOptions...)
+	defer suggestions.Close()
+
+	builder := interactionServerBuilder{
+		PublicKey:       extra.PublicKey,
+		DryRun:          overrides.DryRun,
+		Publisher:       publisher,
+		Suggestions:     suggestions,
+		TimestampWindow: extra.Server.TimestampWindow,
+		ClockSkew:       extra.Server.ClockSkew,
+	}
+	bindings := collectHandlerBindings(extra.Interactions)
+	srv, err := builder.build(extra.Interactions.Timeout, bindings)
 	if err != nil {
 		return (&arcer.CLIError{Msg: "failed to initialize interaction server"}).WithCause(err)
 	}
-
-	bindings := collectHandlerBindings(extra.Interactions)
-	if err := registerInteractionHandlers(srv, extra.Interactions.Timeout, publisher, bindings); err != nil {
-		return err
+	handlerSwitch := newInteractionSwitch(srv)
+	reloader := &handlerReloader{
+		path:     cfgPath,
+		builder:  builder,
+		sw:       handlerSwitch,
+		logf:     cmd.Printf,
+		bindings: bindings,
 	}
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/interactions", srv.HandleInteraction)
+	var interactionHandler http.Handler = handlerSwitch
+	if overrides.RecordDir != "" {
+		recorder, err := newRecordMiddleware(interactionHandler, utils.ExpandPath(overrides.RecordDir), logger.Default())
+		if err != nil {
+			return (&arcer.CLIError{Msg: "failed to enable interaction recording"}).WithCause(err)
+		}
+		interactionHandler = recorder
+		cmd.Printf("Recording verified interactions to %s\n", overrides.RecordDir)
+	}
+	if overrides.CaptureDir != "" {
+		capture, err := newCaptureMiddleware(interactionHandler, utils.ExpandPath(overrides.CaptureDir), overrides.CaptureFor, logger.Default())
+		if err != nil {
+			return (&arcer.CLIError{Msg: "failed to enable request capture"}).WithCause(err)
+		}
+		interactionHandler = capture
+		cmd.Printf("Capturing inbound requests to %s for %s\n", overrides.CaptureDir, overrides.CaptureFor)
+	}
+	mux.Handle("/interactions", newAccessLogMiddleware(newIPAllowlist(interactionHandler, allowedCIDRs), logger.Default()))
+	mux.Handle(healthzPath, newHealthHandler(b))
+
+	// /healthz is public for container health checks. Every other endpoint is
+	// registered on adminMux and requires server.auth.
+	adminMux := http.NewServeMux()
+	adminMux.Handle(agentsPath, newAgentsHandler(b.Registry()))
+	mux.Handle("/", newAuthMiddleware(adminMux, extra.Server.Auth))
 
 	tunnelSession, err := maybeStartTunnel(cmd.Context(), cmd, extra, overrides)
 	if err != nil {
@@ -258,21 +543,79 @@ func runServerStart(cmd *cobra.Command, opts *globalOptions, overrides serverSta
 		}
 	}()
 
-	httpServer := &http.Server{
-		Addr:    extra.Server.ListenAddr,
-		Handler: mux,
+	httpServer := newHTTPServer(extra.Server, mux)
+	var certFile, keyFile string
+	if extra.Server.tlsEnabled() {
+		certFile, keyFile, err = applyServerTLS(httpServer, extra.Server)
+		if err != nil {
+			return (&arcer.CLIError{Msg: "failed to configure TLS"}).WithCause(err)
+		}
 	}
 
-	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	pollInterval := time.Duration(0)
+	if overrides.WatchConfig {
+		pollInterval = defaultConfigPollInterval
+	}
+	go reloader.run(ctx, pollInterval)
+	go flushRateLimitStateEvery(ctx, time.Minute)
+
+	var brokerLost chan error
+	if overrides.ExitOnLoss {
+		brokerLost = make(chan error, 1)
+		go watchBrokerLoss(ctx, pinger, brokerLossInterval, brokerLossThreshold, brokerLost)
+	}
+
+	if extra.Server.DebugAddr != "" {
+		addr, err := startDebugServer(ctx, extra.Server.DebugAddr, cmd.Printf)
+		if err != nil {
+			return (&arcer.CLIError{Msg: fmt.Sprintf("failed to start debug listener on %s", extra.Server.DebugAddr)}).WithCause(err)
+		}
+		cmd.Printf("Debug endpoints on http://%s/debug/pprof/ and /debug/vars\n", addr)
+	}
+
+	if len(extra.Jobs) > 0 {
+		state, err := openJobState(overrides.JobsState)
+		if err != nil {
+			return err
+		}
+		scheduler, err := newJobScheduler(extra.Jobs, state, newJobRunner(cfg, opts), cmd.Printf)
+		if err != nil {
+			return &arcer.CLIError{Msg: err.Error(), Hint: "fix the jobs section in discord.yaml"}
+		}
+		go scheduler.Run(ctx)
+		cmd.Printf("Scheduled %d job(s); see arc-discord jobs list\n", len(scheduler.jobs))
+	}
+
+	ln, err := net.Listen("tcp", httpServer.Addr)
+	if err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to listen on %s", httpServer.Addr)}).WithCause(err)
+	}
+
 	errCh := make(chan error, 1)
 	go func() {
-		cmd.Printf("Discord interaction server listening on %s (config: %s)\n", extra.Server.ListenAddr, cfgPath)
+		scheme := "http"
+		if extra.Server.tlsEnabled() {
+			scheme = "https"
+		}
+		cmd.Printf("Discord interaction server listening on %s (%s, config: %s)\n", extra.Server.ListenAddr, scheme, cfgPath)
+		if extra.Server.ACMEDomain != "" {
+			cmd.Printf("Certificates for %s are issued by Let's Encrypt on first request\n", extra.Server.ACMEDomain)
+		}
 		if extra.PublicURL != "" {
 			cmd.Printf("Public URL: %s\n", extra.PublicURL)
 		}
-		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		// No-op unless systemd started us with Type=notify.
+		_ = sdNotify("READY=1\nSTATUS=Listening on " + ln.Addr().String())
+		var err error
+		if extra.Server.tlsEnabled() {
+			err = httpServer.ServeTLS(ln, certFile, keyFile)
+		} else {
+			err = httpServer.Serve(ln)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			errCh <- err
 		}
 		close(errCh)
@@ -280,11 +623,17 @@ func runServerStart(cmd *cobra.Command, opts *globalOptions, overrides serverSta
 
 	select {
 	case <-ctx.Done():
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		_ = httpServer.Shutdown(shutdownCtx)
+		_ = sdNotify("STOPPING=1")
+		drainServer(httpServer, pending, tunnelSession, extra.Server.DrainTimeout, cmd)
+		tunnelSession = nil
 		cmd.Println("Discord interaction server stopped")
 		return nil
+	case err := <-brokerLost:
+		cmd.Printf("Lost connection to the broker: %v\n", err)
+		_ = sdNotify("STOPPING=1")
+		drainServer(httpServer, pending, tunnelSession, extra.Server.DrainTimeout, cmd)
+		tunnelSession = nil
+		return (&arcer.CLIError{Msg: "lost connection to the broker", Hint: "the server exits so a supervisor or container runtime can restart it"}).WithCause(err)
 	case err := <-errCh:
 		if err != nil {
 			return (&arcer.CLIError{Msg: "interaction server exited with error"}).WithCause(err)
@@ -327,11 +676,15 @@ func filterDaemonArgv(execPath string, args []string) []string {
 }
 
 func serverStopCmd() *cobra.Command {
-	var pidFile string
+	var pidFile, instance string
 	cmd := &cobra.Command{
 		Use:   "stop",
 		Short: "Stop the Discord interaction server daemon",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			pidFile, err := resolveInstancePIDFile(pidFile, instance)
+			if err != nil {
+				return err
+			}
 			mgr := newDaemonManagerFn(daemonOptions{PIDFile: pidFile})
 			if err := mgr.Stop(cmd.Context()); err != nil {
 				return err
@@ -340,16 +693,35 @@ func serverStopCmd() *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().StringVar(&pidFile, "pid-file", "", "PID file path (default ~/.cache/arc/discord-server.pid)")
+	cmd.Flags().StringVar(&pidFile, "pid-file", "", "PID file path (default ~/.cache/vibe/discord-server[-<instance>].pid)")
+	cmd.Flags().StringVar(&instance, "instance", "", "Named instance to stop")
 	return cmd
 }
 
-func serverStatusCmd() *cobra.Command {
-	var pidFile string
+// daemonInstanceStatus is one row of server status --all.
+type daemonInstanceStatus struct {
+	Instance string `json:"instance" yaml:"instance"`
+	Status   string `json:"status" yaml:"status"`
+	PIDFile  string `json:"pid_file" yaml:"pid_file"`
+}
+
+func serverStatusCmd(opts *globalOptions) *cobra.Command {
+	var (
+		pidFile  string
+		instance string
+		all      bool
+	)
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show daemon status",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return runServerStatusAll(cmd, opts)
+			}
+			pidFile, err := resolveInstancePIDFile(pidFile, instance)
+			if err != nil {
+				return err
+			}
 			mgr := newDaemonManagerFn(daemonOptions{PIDFile: pidFile})
 			status, err := mgr.Status()
 			if err != nil {
@@ -359,6 +731,42 @@ func serverStatusCmd() *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().StringVar(&pidFile, "pid-file", "", "PID file path (default ~/.cache/arc/discord-server.pid)")
+	cmd.Flags().StringVar(&pidFile, "pid-file", "", "PID file path (default ~/.cache/vibe/discord-server[-<instance>].pid)")
+	cmd.Flags().StringVar(&instance, "instance", "", "Named instance to report on")
+	cmd.Flags().BoolVar(&all, "all", false, "List every instance with a PID file in ~/.cache/vibe")
 	return cmd
 }
+
+func runServerStatusAll(cmd *cobra.Command, opts *globalOptions) error {
+	instances, err := listDaemonInstances(daemonStateDir())
+	if err != nil {
+		return (&arcer.CLIError{Msg: "failed to list daemon instances"}).WithCause(err)
+	}
+	entries := make([]daemonInstanceStatus, 0, len(instances))
+	rows := make([][]string, 0, len(instances))
+	for _, inst := range instances {
+		status, err := newDaemonManagerFn(daemonOptions{PIDFile: inst.PIDFile}).Status()
+		if err != nil {
+			status = "error: " + err.Error()
+		}
+		name := inst.Name
+		if name == "" {
+			name = "(default)"
+		}
+		entries = append(entries, daemonInstanceStatus{Instance: name, Status: status, PIDFile: inst.PIDFile})
+		rows = append(rows, []string{name, status, inst.PIDFile})
+	}
+	table := &tableData{headers: []string{"Instance", "Status", "PID File"}, rows: rows}
+	return renderOutput(cmd, opts.output, entries, table)
+}
+
+// resolveInstancePIDFile returns pidFile, or the instance's default PID file.
+func resolveInstancePIDFile(pidFile, instance string) (string, error) {
+	if err := validateInstanceName(instance); err != nil {
+		return "", &arcer.CLIError{Msg: err.Error(), Hint: "use lowercase letters, digits, - and _"}
+	}
+	if pidFile != "" {
+		return pidFile, nil
+	}
+	return instancePIDPath(instance), nil
+}
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"path/filepath"
 	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
 )
 
 type fakeDaemon struct {
@@ -59,7 +64,7 @@ func TestServerStatusCmdUsesDaemonManager(t *testing.T) {
 	t.Cleanup(func() {
 		newDaemonManagerFn = func(opts daemonOptions) daemonController { return newDaemonManager(opts) }
 	})
-	cmd := serverStatusCmd()
+	cmd := serverStatusCmd(&globalOptions{})
 	cmd.Flags().Set("pid-file", "pid")
 	if err := cmd.Execute(); err != nil {
 		t.Fatalf("status execute: %v", err)
@@ -75,9 +80,40 @@ func TestServerStatusCmdPropagatesError(t *testing.T) {
 	t.Cleanup(func() {
 		newDaemonManagerFn = func(opts daemonOptions) daemonController { return newDaemonManager(opts) }
 	})
-	cmd := serverStatusCmd()
+	cmd := serverStatusCmd(&globalOptions{})
 	cmd.Flags().Set("pid-file", "pid")
 	if err := cmd.Execute(); err == nil {
 		t.Fatalf("expected error from status")
 	}
 }
+
+func TestServerStatusAllListsInstances(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, instance := range []string{"", "ops"} {
+		if err := writePID(instancePIDPath(instance), 42); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newDaemonManagerFn = func(opts daemonOptions) daemonController {
+		return &fakeDaemon{status: "running (" + filepath.Base(opts.PIDFile) + ")"}
+	}
+	t.Cleanup(func() {
+		newDaemonManagerFn = func(opts daemonOptions) daemonController { return newDaemonManager(opts) }
+	})
+
+	var buf bytes.Buffer
+	cmd := serverStatusCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--all"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("status --all: %v", err)
+	}
+	var rows []daemonInstanceStatus
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("decode: %v\n%s", err, buf.String())
+	}
+	if len(rows) != 2 || rows[0].Instance != "(default)" || rows[1].Instance != "ops" || rows[1].Status != "running (discord-server-ops.pid)" {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+}
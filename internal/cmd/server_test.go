@@ -11,16 +11,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/yourorg/arc-discord/gosdk/broker"
 	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
 	"github.com/yourorg/arc-discord/gosdk/discord/types"
 )
 
 type stubPublisher struct {
-	envelopes []*redisEnvelope
+	envelopes []*broker.Envelope
 	err       error
 }
 
-func (s *stubPublisher) Publish(_ context.Context, env *redisEnvelope) error {
+func (s *stubPublisher) Publish(_ context.Context, env *broker.Envelope) error {
 	if s.err != nil {
 		return s.err
 	}
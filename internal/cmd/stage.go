@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func stageCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stage",
+		Short: "Start, edit, and end stage instances",
+		Long: `Manage live stages in stage channels (requires MANAGE_CHANNELS, MUTE_MEMBERS, and
+MOVE_MEMBERS in the channel). A stage channel holds at most one live stage.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(stageStartCmd(opts))
+	cmd.AddCommand(stageGetCmd(opts))
+	cmd.AddCommand(stageEditCmd(opts))
+	cmd.AddCommand(stageEndCmd(opts))
+	return cmd
+}
+
+func stageStartCmd(opts *globalOptions) *cobra.Command {
+	var (
+		channelRef string
+		guildRef   string
+		params     types.StageInstanceCreateParams
+	)
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start a stage in a stage channel",
+		Long: `Start a stage with a topic. --notify pings members who follow the guild's events
+(requires MENTION_EVERYONE), and --event links the stage to a scheduled event so Discord marks
+the event active.`,
+		Example: `Example:
+  arc-discord stage start --channel 1427555325136867393 --topic "Weekly town hall"
+
+Example:
+  arc-discord stage start --channel "#town-hall" --topic "Release Q&A" --event $EVENT_ID --notify`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || params.Topic == "" {
+				return &arcer.CLIError{Msg: "--channel and --topic are required"}
+			}
+			return runStage(cmd, opts, channelRef, guildRef, func(ctx context.Context, bot botClient, channelID string) (*types.StageInstance, error) {
+				params.ChannelID = channelID
+				stage, err := bot.StageInstances().CreateStageInstance(ctx, &params)
+				if err != nil {
+					return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to start stage in %s", channelID), Hint: "the channel must be a stage channel without a live stage"}).WithCause(err)
+				}
+				return stage, nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Stage channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&params.Topic, "topic", "", "Stage topic (1-120 characters)")
+	cmd.Flags().BoolVar(&params.SendStartNotification, "notify", false, "Notify @everyone that the stage started")
+	cmd.Flags().StringVar(&params.GuildScheduledEventID, "event", "", "Scheduled event ID the stage belongs to")
+	cmd.Flags().StringVar(&params.AuditLogReason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+func stageGetCmd(opts *globalOptions) *cobra.Command {
+	var channelRef, guildRef string
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the live stage in a stage channel",
+		Example: `Example:
+  arc-discord stage get --channel 1427555325136867393 --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" {
+				return &arcer.CLIError{Msg: "--channel is required"}
+			}
+			return runStage(cmd, opts, channelRef, guildRef, func(ctx context.Context, bot botClient, channelID string) (*types.StageInstance, error) {
+				stage, err := bot.StageInstances().GetStageInstance(ctx, channelID)
+				if err != nil {
+					return nil, (&arcer.CLIError{Msg: fmt.Sprintf("no live stage in %s", channelID)}).WithCause(err)
+				}
+				return stage, nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Stage channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	return cmd
+}
+
+func stageEditCmd(opts *globalOptions) *cobra.Command {
+	var (
+		channelRef string
+		guildRef   string
+		params     types.StageInstanceModifyParams
+	)
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Change the topic of a live stage",
+		Example: `Example:
+  arc-discord stage edit --channel 1427555325136867393 --topic "Open Q&A"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || params.Topic == "" {
+				return &arcer.CLIError{Msg: "--channel and --topic are required"}
+			}
+			return runStage(cmd, opts, channelRef, guildRef, func(ctx context.Context, bot botClient, channelID string) (*types.StageInstance, error) {
+				stage, err := bot.StageInstances().ModifyStageInstance(ctx, channelID, &params)
+				if err != nil {
+					return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to edit stage in %s", channelID)}).WithCause(err)
+				}
+				return stage, nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Stage channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&params.Topic, "topic", "", "New stage topic (1-120 characters)")
+	cmd.Flags().StringVar(&params.AuditLogReason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+func stageEndCmd(opts *globalOptions) *cobra.Command {
+	var channelRef, guildRef, reason string
+	cmd := &cobra.Command{
+		Use:   "end",
+		Short: "End the live stage in a stage channel",
+		Example: `Example:
+  arc-discord stage end --channel 1427555325136867393`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" {
+				return &arcer.CLIError{Msg: "--channel is required"}
+			}
+			bot, ctx, cancel, channelID, err := channelTarget(cmd, opts, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			if err := bot.StageInstances().DeleteStageInstance(ctx, channelID, reason); err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to end stage in %s", channelID)}).WithCause(err)
+			}
+			cmd.Printf("Stage in channel %s ended\n", channelID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Stage channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&reason, "reason", "", "Audit log reason")
+	return cmd
+}
+
+// runStage resolves the channel, runs call, and renders the stage it returns.
+func runStage(cmd *cobra.Command, opts *globalOptions, channelRef, guildRef string, call func(context.Context, botClient, string) (*types.StageInstance, error)) error {
+	bot, ctx, cancel, channelID, err := channelTarget(cmd, opts, channelRef, guildRef)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	stage, err := call(ctx, bot, channelID)
+	if err != nil {
+		return err
+	}
+	table := keyValueTable(map[string]string{
+		"id":              stage.ID,
+		"channel_id":      stage.ChannelID,
+		"guild_id":        stage.GuildID,
+		"topic":           stage.Topic,
+		"scheduled_event": stage.GuildScheduledEventID,
+	})
+	return renderOutput(cmd, opts.output, stage, table)
+}
+
+// channelTarget loads config, builds the bot client, and resolves channelRef
+// (an ID or #name within guildRef). The context is bounded to 30s.
+func channelTarget(cmd *cobra.Command, opts *globalOptions, channelRef, guildRef string) (botClient, context.Context, context.CancelFunc, string, error) {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	bot, err := newBotClientFn(cfg, opts.tokenOverride)
+	if err != nil {
+		return nil, nil, nil, "", (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	channelID, err := newNameResolver(bot, cfg).ChannelID(ctx, channelRef, guildRef)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, "", err
+	}
+	return bot, ctx, cancel, channelID, nil
+}
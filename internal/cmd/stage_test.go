@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+type fakeStageInstances struct {
+	created  *types.StageInstanceCreateParams
+	modified *types.StageInstanceModifyParams
+	deleted  string
+	reason   string
+}
+
+func (f *fakeStageInstances) CreateStageInstance(_ context.Context, params *types.StageInstanceCreateParams) (*types.StageInstance, error) {
+	f.created = params
+	return &types.StageInstance{ID: "si-1", ChannelID: params.ChannelID, Topic: params.Topic}, nil
+}
+
+func (f *fakeStageInstances) GetStageInstance(_ context.Context, channelID string) (*types.StageInstance, error) {
+	return &types.StageInstance{ID: "si-1", ChannelID: channelID, Topic: "Town hall"}, nil
+}
+
+func (f *fakeStageInstances) ModifyStageInstance(_ context.Context, channelID string, params *types.StageInstanceModifyParams) (*types.StageInstance, error) {
+	f.modified = params
+	return &types.StageInstance{ID: "si-1", ChannelID: channelID, Topic: params.Topic}, nil
+}
+
+func (f *fakeStageInstances) DeleteStageInstance(_ context.Context, channelID, reason string) error {
+	f.deleted, f.reason = channelID, reason
+	return nil
+}
+
+func TestStageStartLinksScheduledEvent(t *testing.T) {
+	stages := &fakeStageInstances{}
+	hookBot(t, testConfig(), &fakeBotClient{stageSvc: stages})
+
+	var out bytes.Buffer
+	cmd := stageStartCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--channel", "1427555325136867393", "--topic", "Town hall", "--event", "555", "--notify"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("stage start: %v", err)
+	}
+	if stages.created.ChannelID != "1427555325136867393" || stages.created.GuildScheduledEventID != "555" || !stages.created.SendStartNotification {
+		t.Fatalf("unexpected create params %+v", stages.created)
+	}
+	var stage types.StageInstance
+	if err := json.Unmarshal(out.Bytes(), &stage); err != nil || stage.ID != "si-1" {
+		t.Fatalf("unexpected output %q: %v", out.String(), err)
+	}
+}
+
+func TestStageEditAndEnd(t *testing.T) {
+	stages := &fakeStageInstances{}
+	hookBot(t, testConfig(), &fakeBotClient{stageSvc: stages})
+
+	cmd := stageEditCmd(&globalOptions{output: output.OutputOptions{Format: string(output.OutputTable)}})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--channel", "1427555325136867393", "--topic", "Open Q&A"})
+	if err := cmd.Execute(); err != nil || stages.modified.Topic != "Open Q&A" {
+		t.Fatalf("stage edit: %v %+v", err, stages.modified)
+	}
+
+	var out bytes.Buffer
+	cmd = stageEndCmd(&globalOptions{})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--channel", "1427555325136867393", "--reason", "wrap up"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("stage end: %v", err)
+	}
+	if stages.deleted != "1427555325136867393" || stages.reason != "wrap up" || !strings.Contains(out.String(), "ended") {
+		t.Fatalf("unexpected end: %+v %q", stages, out.String())
+	}
+
+	cmd = stageStartCmd(&globalOptions{})
+	cmd.SetArgs([]string{"--channel", "1427555325136867393"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected --topic to be required")
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func stateCmd(opts *globalOptions) *cobra.Command {
+	var (
+		redisAddr   string
+		redisPrefix string
+	)
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Share JSON state between the steps of an interaction flow",
+		Long: `Read and write JSON state kept in the broker per interaction, so the steps of a flow (a command,
+the button it shows, the modal the button opens) can share context. Keys are chosen by the agent,
+typically the ID or token of the flow's first interaction. State expires 15 minutes after it was
+last set, matching the interaction token lifetime, unless --ttl says otherwise.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.PersistentFlags().StringVar(&redisAddr, "redis-addr", "", "Redis host:port or redis[s]://user:pass@host:port/db URL for state")
+	cmd.PersistentFlags().StringVar(&redisPrefix, "redis-prefix", "", "Redis key prefix (default arc:discord)")
+	cmd.AddCommand(stateGetCmd(opts, &redisAddr, &redisPrefix))
+	cmd.AddCommand(stateSetCmd(opts, &redisAddr, &redisPrefix))
+	return cmd
+}
+
+func stateGetCmd(opts *globalOptions, redisAddr, redisPrefix *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key> [field]",
+		Short: "Print the state stored for an interaction",
+		Long: `Print every field stored for key, or with a field name only that field's JSON value, ready for
+jq or a handler script.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			store, closeStore, err := openStateStore(ctx, opts, *redisAddr, *redisPrefix)
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+			state, err := store.GetState(ctx, args[0])
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to read state"}).WithCause(err)
+			}
+			if len(args) == 2 {
+				value, ok := state[args[1]]
+				if !ok {
+					return &arcer.CLIError{Msg: fmt.Sprintf("no state field %s for %s", args[1], args[0]), Hint: "state expires 15 minutes after it was last set"}
+				}
+				cmd.Println(string(value))
+				return nil
+			}
+			fields := make([]string, 0, len(state))
+			for field := range state {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			rows := make([][]string, 0, len(fields))
+			for _, field := range fields {
+				rows = append(rows, []string{field, string(state[field])})
+			}
+			table := &tableData{headers: []string{"Field", "Value"}, rows: rows}
+			return renderOutput(cmd, opts.output, state, table)
+		},
+		Example: `Example:
+  arc-discord state get 1234567890 --output json
+
+Example:
+  ENV=$(arc-discord state get "$FLOW" environment | jq -r .)`,
+	}
+}
+
+func stateSetCmd(opts *globalOptions, redisAddr, redisPrefix *string) *cobra.Command {
+	var (
+		ttl      time.Duration
+		asString bool
+	)
+	cmd := &cobra.Command{
+		Use:   "set <key> <field> <json|->",
+		Short: "Store a JSON value for an interaction",
+		Long: `Store a JSON value under field for key, reading it from stdin when the value is -. --string stores
+the text as a JSON string instead. Each set renews the key's TTL.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ttl < 0 {
+				return &arcer.CLIError{Msg: "--ttl must not be negative"}
+			}
+			raw := args[2]
+			if raw == "-" {
+				data, err := io.ReadAll(io.LimitReader(cmd.InOrStdin(), maxAgentReplyBytes+1))
+				if err != nil {
+					return (&arcer.CLIError{Msg: "failed to read the value from stdin"}).WithCause(err)
+				}
+				if len(data) > maxAgentReplyBytes {
+					return &arcer.CLIError{Msg: fmt.Sprintf("value exceeds %d bytes", maxAgentReplyBytes)}
+				}
+				raw = strings.TrimRight(string(data), "\r\n")
+			}
+			value := json.RawMessage(raw)
+			if asString {
+				encoded, err := json.Marshal(raw)
+				if err != nil {
+					return err
+				}
+				value = encoded
+			} else if !json.Valid(value) {
+				return &arcer.CLIError{Msg: fmt.Sprintf("value for %s is not valid JSON", args[1]), Hint: `quote strings ('"staging"') or pass --string`}
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			store, closeStore, err := openStateStore(ctx, opts, *redisAddr, *redisPrefix)
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+			if err := store.SetState(ctx, args[0], args[1], value, ttl); err != nil {
+				return (&arcer.CLIError{Msg: "failed to store state"}).WithCause(err)
+			}
+			return nil
+		},
+		Example: `Example:
+  arc-discord state set 1234567890 environment '"staging"'
+
+Example:
+  arc-discord state set "$FLOW" step --string confirm --ttl 10m
+
+Example:
+  jq '{services: .data.values}' envelope.json | arc-discord state set "$FLOW" selection -`,
+	}
+	cmd.Flags().DurationVar(&ttl, "ttl", broker.DefaultStateTTL, "How long the key lives after this set")
+	cmd.Flags().BoolVar(&asString, "string", false, "Store the value as a JSON string")
+	return cmd
+}
+
+// openStateStore opens the configured broker as a StateStore.
+func openStateStore(ctx context.Context, opts *globalOptions, redisAddr, redisPrefix string) (broker.StateStore, func(), error) {
+	b, err := openAgentBroker(ctx, opts, redisAddr, redisPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, ok := b.(broker.StateStore)
+	if !ok {
+		b.Close()
+		return nil, nil, &arcer.CLIError{Msg: "the configured broker does not store interaction state", Hint: "use the redis broker"}
+	}
+	return store, func() { b.Close() }, nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestStateSetAndGet(t *testing.T) {
+	mem := broker.NewMemory()
+	newBrokerFn = func(context.Context, broker.Config) (broker.Broker, error) { return mem, nil }
+	t.Cleanup(func() {
+		newBrokerFn = func(ctx context.Context, cfg broker.Config) (broker.Broker, error) {
+			return broker.Open(ctx, cfg)
+		}
+	})
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte("discord:\n  bot_token: dummy\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	run := func(stdin string, args ...string) (string, error) {
+		opts := &globalOptions{configPath: path, output: output.OutputOptions{Format: string(output.OutputJSON)}}
+		cmd := stateCmd(opts)
+		cmd.SetArgs(args)
+		cmd.SetIn(strings.NewReader(stdin))
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("", "set", "flow1", "environment", `"staging"`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("", "set", "flow1", "step", "--string", "confirm"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(`{"services":["api","web"]}`+"\n", "set", "flow1", "selection", "-"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("", "set", "flow1", "step", "confirm"); err == nil || !strings.Contains(err.Error(), "not valid JSON") {
+		t.Fatalf("expected a JSON error, got %v", err)
+	}
+
+	out, err := run("", "get", "flow1", "selection")
+	if err != nil || strings.TrimSpace(out) != `{"services":["api","web"]}` {
+		t.Fatalf("unexpected field output %q, %v", out, err)
+	}
+	out, err = run("", "get", "flow1")
+	if err != nil || !strings.Contains(out, `"environment": "staging"`) || !strings.Contains(out, `"step": "confirm"`) {
+		t.Fatalf("unexpected state output %s, %v", out, err)
+	}
+	if _, err := run("", "get", "flow2", "step"); err == nil || !strings.Contains(err.Error(), "no state field") {
+		t.Fatalf("expected a missing field error, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	arcer "github.com/yourorg/arc-sdk/errors"
+	"github.com/yourorg/arc-sdk/utils"
+)
+
+const defaultSystemdUnitName = "arc-discord"
+
+// systemdUnit holds the resolved settings rendered into a service unit.
+type systemdUnit struct {
+	ExecStart   []string
+	WorkingDir  string
+	EnvFile     string
+	Env         []string
+	Restart     string
+	StopTimeout time.Duration
+	User        bool
+}
+
+func (u systemdUnit) render() string {
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=arc-discord interaction server\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n\n")
+	b.WriteString("[Service]\n")
+	b.WriteString("Type=notify\n")
+	b.WriteString("NotifyAccess=main\n")
+	fmt.Fprintf(&b, "ExecStart=%s\n", systemdQuoteArgs(u.ExecStart))
+	if u.WorkingDir != "" {
+		fmt.Fprintf(&b, "WorkingDirectory=%s\n", u.WorkingDir)
+	}
+	if u.EnvFile != "" {
+		fmt.Fprintf(&b, "EnvironmentFile=%s\n", u.EnvFile)
+	}
+	for _, kv := range u.Env {
+		fmt.Fprintf(&b, "Environment=%s\n", systemdQuote(kv))
+	}
+	fmt.Fprintf(&b, "Restart=%s\n", u.Restart)
+	b.WriteString("RestartSec=2s\n")
+	if u.StopTimeout > 0 {
+		fmt.Fprintf(&b, "TimeoutStopSec=%d\n", int(u.StopTimeout.Round(time.Second).Seconds()))
+	}
+	b.WriteString("\n[Install]\n")
+	if u.User {
+		b.WriteString("WantedBy=default.target\n")
+	} else {
+		b.WriteString("WantedBy=multi-user.target\n")
+	}
+	return b.String()
+}
+
+// systemdQuoteArgs joins argv for ExecStart, quoting words systemd would
+// otherwise split or expand.
+func systemdQuoteArgs(argv []string) string {
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		quoted[i] = systemdQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	s = strings.ReplaceAll(s, "$", "$$")
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\;") {
+		return s
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+func systemdUnitDir(user bool) (string, error) {
+	if !user {
+		return "/etc/systemd/system", nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "systemd", "user"), nil
+}
+
+func serverInstallSystemdCmd(opts *globalOptions) *cobra.Command {
+	var (
+		name    string
+		user    bool
+		install bool
+		outPath string
+		binary  string
+		workdir string
+		envFile string
+		env     []string
+		restart string
+	)
+	cmd := &cobra.Command{
+		Use:   "install-systemd [-- server start flags...]",
+		Short: "Generate a systemd unit that runs server start --systemd",
+		Long: `Generate a systemd service unit for the interaction server.
+
+The unit runs "server start --systemd" with the resolved --config and --profile, plus any
+server start flags given after --. It uses Type=notify: the server reports readiness once
+it is listening and stopping while it drains, and TimeoutStopSec covers server.drain_timeout.
+
+By default the unit is printed. --install writes it to /etc/systemd/system (or
+~/.config/systemd/user with --user); --output writes it to any path.`,
+		Example: `Example:
+  arc-discord server install-systemd > arc-discord.service
+
+Example:
+  sudo arc-discord server install-systemd --install --env-file /etc/arc-discord.env -- --listen-addr 0.0.0.0:8080
+
+Example:
+  arc-discord server install-systemd --user --install --name discord-bot`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.ContainsAny(name, "/ ") || name == "" {
+				return &arcer.CLIError{Msg: fmt.Sprintf("invalid unit name %q", name)}
+			}
+			if install && outPath != "" {
+				return &arcer.CLIError{Msg: "--install and --output are mutually exclusive"}
+			}
+			for _, kv := range env {
+				if !strings.Contains(kv, "=") {
+					return &arcer.CLIError{Msg: fmt.Sprintf("invalid --setenv %q", kv), Hint: "use KEY=value"}
+				}
+			}
+			if binary == "" {
+				exe, err := os.Executable()
+				if err != nil {
+					return (&arcer.CLIError{Msg: "unable to resolve the arc-discord binary", Hint: "pass --binary"}).WithCause(err)
+				}
+				binary = exe
+			}
+			_, extra, cfgPath, err := opts.loadConfigWithInteractions()
+			if err != nil {
+				return err
+			}
+
+			execStart := []string{utils.ExpandPath(binary)}
+			if cfgPath != "" {
+				abs, err := filepath.Abs(cfgPath)
+				if err == nil {
+					cfgPath = abs
+				}
+				execStart = append(execStart, "--config", cfgPath)
+			}
+			if opts.profile != "" {
+				execStart = append(execStart, "--profile", opts.profile)
+			}
+			execStart = append(execStart, "server", "start", "--systemd")
+			execStart = append(execStart, args...)
+
+			unit := systemdUnit{
+				ExecStart:   execStart,
+				Env:         env,
+				Restart:     restart,
+				StopTimeout: extra.Server.DrainTimeout + 5*time.Second,
+				User:        user,
+			}
+			if workdir != "" {
+				unit.WorkingDir = absPath(workdir)
+			}
+			if envFile != "" {
+				unit.EnvFile = absPath(envFile)
+			}
+			content := unit.render()
+
+			target := outPath
+			if install {
+				dir, err := systemdUnitDir(user)
+				if err != nil {
+					return (&arcer.CLIError{Msg: "unable to resolve the systemd unit directory"}).WithCause(err)
+				}
+				target = filepath.Join(dir, name+".service")
+			}
+			if target == "" {
+				cmd.Print(content)
+				return nil
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to create %s", filepath.Dir(target))}).WithCause(err)
+			}
+			if err := os.WriteFile(target, []byte(content), 0o644); err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to write %s", target), Hint: "system units need root; try sudo or --user"}).WithCause(err)
+			}
+			cmd.Printf("Wrote %s\n", target)
+			if install {
+				scope := ""
+				if user {
+					scope = "--user "
+				}
+				cmd.Printf("Enable it with: systemctl %sdaemon-reload && systemctl %senable --now %s\n", scope, scope, name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", defaultSystemdUnitName, "Unit name (without .service)")
+	cmd.Flags().BoolVar(&user, "user", false, "Generate a user unit (~/.config/systemd/user) instead of a system unit")
+	cmd.Flags().BoolVar(&install, "install", false, "Write the unit into the systemd unit directory")
+	cmd.Flags().StringVarP(&outPath, "output", "o", "", "Write the unit to this path instead of stdout")
+	cmd.Flags().StringVar(&binary, "binary", "", "arc-discord binary for ExecStart (default the running executable)")
+	cmd.Flags().StringVar(&workdir, "workdir", "", "WorkingDirectory for the service")
+	cmd.Flags().StringVar(&envFile, "env-file", "", "EnvironmentFile for the service (KEY=value per line; keep tokens here)")
+	cmd.Flags().StringArrayVar(&env, "setenv", nil, "Set an environment variable in the unit, KEY=value (repeatable)")
+	cmd.Flags().StringVar(&restart, "restart", "on-failure", "systemd Restart= policy")
+	return cmd
+}
+
+func absPath(path string) string {
+	path = utils.ExpandPath(path)
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// sdNotify sends a state update to the systemd notification socket. It is a
+// no-op unless systemd started the process with NOTIFY_SOCKET set.
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Write([]byte(state))
+	return err
+}
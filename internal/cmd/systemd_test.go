@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSystemdTestConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	config := "discord:\n  bot_token: dummy\nserver:\n  drain_timeout: 20s\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestServerInstallSystemdPrintsUnit(t *testing.T) {
+	path := writeSystemdTestConfig(t)
+	var buf bytes.Buffer
+	cmd := serverInstallSystemdCmd(&globalOptions{configPath: path})
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--binary", "/usr/bin/arc-discord", "--env-file", "/etc/arc-discord.env", "--setenv", "GREETING=hello world", "--", "--listen-addr", "0.0.0.0:8080"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	unit := buf.String()
+	for _, want := range []string{
+		"Type=notify\n",
+		"ExecStart=/usr/bin/arc-discord --config " + path + " server start --systemd --listen-addr 0.0.0.0:8080\n",
+		"EnvironmentFile=/etc/arc-discord.env\n",
+		"Environment=\"GREETING=hello world\"\n",
+		"TimeoutStopSec=25\n",
+		"WantedBy=multi-user.target\n",
+	} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("unit missing %q:\n%s", want, unit)
+		}
+	}
+}
+
+func TestServerInstallSystemdInstallsUserUnit(t *testing.T) {
+	path := writeSystemdTestConfig(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	var buf bytes.Buffer
+	cmd := serverInstallSystemdCmd(&globalOptions{configPath: path})
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--user", "--install", "--name", "discord-bot", "--binary", "/usr/bin/arc-discord"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".config", "systemd", "user", "discord-bot.service"))
+	if err != nil {
+		t.Fatalf("read unit: %v", err)
+	}
+	if !strings.Contains(string(data), "WantedBy=default.target") {
+		t.Fatalf("expected user unit:\n%s", data)
+	}
+	if !strings.Contains(buf.String(), "systemctl --user enable --now discord-bot") {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestSystemdQuote(t *testing.T) {
+	cases := map[string]string{
+		"plain":      "plain",
+		"100%":       "100%%",
+		"$HOME":      "$$HOME",
+		"a b":        `"a b"`,
+		`say "hi"`:   `"say \"hi\""`,
+		"":           `""`,
+		`C:\program`: `"C:\\program"`,
+	}
+	for in, want := range cases {
+		if got := systemdQuote(in); got != want {
+			t.Errorf("systemdQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestSDNotifySendsState(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if err := sdNotify("READY=1"); err != nil {
+		t.Fatalf("expected no-op without NOTIFY_SOCKET, got %v", err)
+	}
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram unavailable: %v", err)
+	}
+	defer conn.Close()
+	t.Setenv("NOTIFY_SOCKET", socket)
+	if err := sdNotify("READY=1"); err != nil {
+		t.Fatalf("sdNotify: %v", err)
+	}
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "READY=1" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/yourorg/arc-discord/gosdk/discord/locale"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+// templateFlags holds --template and --data for send commands. With
+// --template the content and payload file are rendered as Go templates
+// before they are parsed and sent.
+type templateFlags struct {
+	enabled  bool
+	dataPath string
+}
+
+func (f *templateFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.enabled, "template", false, "Render content and payload files as Go templates before sending")
+	cmd.Flags().StringVar(&f.dataPath, "data", "", "JSON file exposed to --template as .Data")
+}
+
+// templateContext is the dot value inside templates.
+type templateContext struct {
+	Data  any               // decoded --data file, nil when unset
+	Env   map[string]string // process environment
+	Flags map[string]string // every flag of the running command, by name
+	Now   time.Time
+}
+
+// messageTemplate renders user-supplied text. A nil *messageTemplate passes
+// text through unchanged, so callers don't branch on --template.
+type messageTemplate struct {
+	ctx   templateContext
+	funcs template.FuncMap
+}
+
+// compile loads the --data file and builds the template context. It returns
+// nil when --template is off.
+func (f templateFlags) compile(cmd *cobra.Command, fmtr *locale.Formatter) (*messageTemplate, error) {
+	if !f.enabled {
+		if f.dataPath != "" {
+			return nil, &arcer.CLIError{Msg: "--data requires --template"}
+		}
+		return nil, nil
+	}
+	ctx := templateContext{
+		Env:   environMap(),
+		Flags: map[string]string{},
+		Now:   time.Now(),
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		ctx.Flags[flag.Name] = flag.Value.String()
+	})
+	if f.dataPath != "" {
+		raw, err := os.ReadFile(f.dataPath)
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read template data %s", f.dataPath)}).WithCause(err)
+		}
+		if err := json.Unmarshal(raw, &ctx.Data); err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("template data %s must be valid JSON", f.dataPath)}).WithCause(err)
+		}
+	}
+
+	funcs := template.FuncMap{}
+	if fmtr != nil {
+		funcs = fmtr.FuncMap()
+	}
+	for name, fn := range templateFuncs() {
+		funcs[name] = fn
+	}
+	return &messageTemplate{ctx: ctx, funcs: funcs}, nil
+}
+
+// templateFuncs are the helpers available to every user-supplied template:
+// --template message content and --output go-template alike.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"env": os.Getenv,
+		"default": func(fallback, value any) any {
+			if value == nil || value == "" {
+				return fallback
+			}
+			return value
+		},
+		// json quotes a value for safe interpolation into JSON payload files.
+		"json": func(v any) (string, error) {
+			out, err := json.Marshal(v)
+			return string(out), err
+		},
+		"join": func(sep string, items []any) string {
+			parts := make([]string, len(items))
+			for i, item := range items {
+				parts[i] = fmt.Sprint(item)
+			}
+			return strings.Join(parts, sep)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+	}
+}
+
+// Render executes text as a template named after its source. Missing map keys
+// are errors so a typo in .Data doesn't silently post "<no value>".
+func (t *messageTemplate) Render(name, text string) (string, error) {
+	if t == nil || text == "" {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Funcs(t.funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", (&arcer.CLIError{Msg: fmt.Sprintf("failed to parse template %s", name)}).WithCause(err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, t.ctx); err != nil {
+		return "", (&arcer.CLIError{Msg: fmt.Sprintf("failed to render template %s", name)}).WithCause(err)
+	}
+	return buf.String(), nil
+}
+
+// RenderFile renders the contents of a payload file.
+func (t *messageTemplate) RenderFile(path string, data []byte) ([]byte, error) {
+	if t == nil {
+		return data, nil
+	}
+	out, err := t.Render(path, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+func environMap() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env
+}
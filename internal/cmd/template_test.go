@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newTemplateTestCmd(t *testing.T, flags templateFlags) *messageTemplate {
+	t.Helper()
+	cmd := &cobra.Command{Use: "send"}
+	cmd.Flags().String("channel", "", "")
+	if err := cmd.Flags().Set("channel", "#deploys"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+	tmpl, err := flags.compile(cmd, nil)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return tmpl
+}
+
+func TestTemplateRendersDataEnvAndFlags(t *testing.T) {
+	dataPath := filepath.Join(t.TempDir(), "build.json")
+	if err := os.WriteFile(dataPath, []byte(`{"number": 812, "status": "passed", "title": "fix \"quotes\""}`), 0o600); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+	t.Setenv("ARC_TEST_BRANCH", "main")
+	tmpl := newTemplateTestCmd(t, templateFlags{enabled: true, dataPath: dataPath})
+
+	got, err := tmpl.Render("content", `Build {{ .Data.number }} on {{ env "ARC_TEST_BRANCH" }} to {{ .Flags.channel }}: {{ .Data.status | upper }}`)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if got != "Build 812 on main to #deploys: PASSED" {
+		t.Fatalf("unexpected render %q", got)
+	}
+
+	payload, err := tmpl.RenderFile("payload.json", []byte(`{"content": {{ json .Data.title }}}`))
+	if err != nil {
+		t.Fatalf("render payload: %v", err)
+	}
+	if string(payload) != `{"content": "fix \"quotes\""}` {
+		t.Fatalf("unexpected payload %s", payload)
+	}
+}
+
+func TestTemplateMissingKeyFails(t *testing.T) {
+	dataPath := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(dataPath, []byte(`{"a": 1}`), 0o600); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+	tmpl := newTemplateTestCmd(t, templateFlags{enabled: true, dataPath: dataPath})
+	if _, err := tmpl.Render("content", "{{ .Data.missing }}"); err == nil || !strings.Contains(err.Error(), "render") {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+}
+
+func TestTemplateDisabledPassesThrough(t *testing.T) {
+	tmpl := newTemplateTestCmd(t, templateFlags{})
+	if tmpl != nil {
+		t.Fatal("expected nil template when --template is off")
+	}
+	if got, err := tmpl.Render("content", "{{ not rendered }}"); err != nil || got != "{{ not rendered }}" {
+		t.Fatalf("expected passthrough, got %q, %v", got, err)
+	}
+	if _, err := (templateFlags{dataPath: "x.json"}).compile(&cobra.Command{}, nil); err == nil {
+		t.Fatal("--data without --template should be rejected")
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestServerRejectsStaleTimestamps(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	builder := interactionServerBuilder{PublicKey: hex.EncodeToString(pub), Publisher: &stubPublisher{}, TimestampWindow: time.Minute, ClockSkew: 5 * time.Second}
+	srv, err := builder.build(time.Second,
+		collectHandlerBindings(interactionsConfig{Enabled: true, Handlers: handlerMappings{Commands: map[string]handlerRoute{"help": {Agent: "claude"}}}}))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	body := []byte(`{"type":2,"id":"1","token":"tok","data":{"name":"help"}}`)
+	send := func(signedAt time.Time) int {
+		timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+		req := signedRequest(t, priv, body)
+		req.Header.Set("X-Signature-Timestamp", timestamp)
+		req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(priv, append([]byte(timestamp), body...))))
+		rec := httptest.NewRecorder()
+		srv.HandleInteraction(rec, req)
+		return rec.Code
+	}
+
+	before := expvarInt(interactionRejections.Get("stale_timestamp"))
+	if code := send(time.Now()); code != http.StatusOK {
+		t.Fatalf("expected fresh interaction to pass, got %d", code)
+	}
+	if code := send(time.Now().Add(-10 * time.Minute)); code != http.StatusUnauthorized {
+		t.Fatalf("expected replayed interaction to be rejected, got %d", code)
+	}
+	if got := expvarInt(interactionRejections.Get("stale_timestamp")); got != before+1 {
+		t.Fatalf("expected stale_timestamp count %d, got %d", before+1, got)
+	}
+}
+
+func TestLoadInteractionSettingsTimestampWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "discord.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  timestamp_window: 5m\n  clock_skew: 10s\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	settings, err := loadInteractionSettings(path)
+	if err != nil {
+		t.Fatalf("loadInteractionSettings: %v", err)
+	}
+	if settings.Server.TimestampWindow != 5*time.Minute || settings.Server.ClockSkew != 10*time.Second {
+		t.Fatalf("unexpected window %v / skew %v", settings.Server.TimestampWindow, settings.Server.ClockSkew)
+	}
+}
+
+func expvarInt(v expvar.Var) int64 {
+	if n, ok := v.(*expvar.Int); ok {
+		return n.Value()
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const acmeListenAddr = ":443"
+
+func (c serverConfig) tlsEnabled() bool {
+	return c.TLSCert != "" || c.TLSKey != "" || c.ACMEDomain != ""
+}
+
+// validateServerTLS checks that static certificates and ACME are not mixed and
+// that certificate files exist before the listener starts.
+func validateServerTLS(cfg serverConfig) error {
+	if cfg.ACMEDomain != "" {
+		if cfg.TLSCert != "" || cfg.TLSKey != "" {
+			return errors.New("server.acme_domain cannot be combined with server.tls_cert/server.tls_key")
+		}
+		return nil
+	}
+	if (cfg.TLSCert == "") != (cfg.TLSKey == "") {
+		return errors.New("server.tls_cert and server.tls_key must be set together")
+	}
+	for _, path := range []string{cfg.TLSCert, cfg.TLSKey} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("tls file %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// applyServerTLS configures httpServer for HTTPS and returns the certificate
+// and key paths to pass to ListenAndServeTLS. With ACME the certificate is
+// obtained from Let's Encrypt via the TLS-ALPN-01 challenge, so the server must
+// be reachable on port 443 for the domain.
+func applyServerTLS(httpServer *http.Server, cfg serverConfig) (string, string, error) {
+	if err := validateServerTLS(cfg); err != nil {
+		return "", "", err
+	}
+	if cfg.ACMEDomain == "" {
+		httpServer.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if err := applyClientAuth(httpServer.TLSConfig, cfg.Auth); err != nil {
+			return "", "", err
+		}
+		return cfg.TLSCert, cfg.TLSKey, nil
+	}
+
+	cacheDir := cfg.ACMECacheDir
+	if cacheDir == "" {
+		cacheDir = defaultACMECacheDir()
+	}
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return "", "", fmt.Errorf("create acme cache dir: %w", err)
+	}
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(splitACMEDomains(cfg.ACMEDomain)...),
+		Cache:      autocert.DirCache(cacheDir),
+		Email:      cfg.ACMEEmail,
+	}
+	tlsConfig := manager.TLSConfig()
+	tlsConfig.MinVersion = tls.VersionTLS12
+	if err := applyClientAuth(tlsConfig, cfg.Auth); err != nil {
+		return "", "", err
+	}
+	httpServer.TLSConfig = tlsConfig
+	return "", "", nil
+}
+
+// applyClientAuth requests (but does not require) client certificates so
+// Discord can still reach /interactions while other paths accept mTLS.
+func applyClientAuth(tlsConfig *tls.Config, auth serverAuthConfig) error {
+	if auth.ClientCA == "" {
+		return nil
+	}
+	pool, err := auth.loadClientCAs()
+	if err != nil {
+		return err
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	return nil
+}
+
+func splitACMEDomains(raw string) []string {
+	var domains []string
+	for _, d := range strings.Split(raw, ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+func defaultACMECacheDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "vibe", "discord-acme")
+}
+
+func (c redisTLSConfig) enabled() bool {
+	return c.Enabled || c.CAFile != "" || c.CertFile != "" || c.KeyFile != ""
+}
+
+// tlsConfig builds the client TLS settings for redis.tls, or nil when TLS is
+// off. A CA file replaces the system roots; cert_file and key_file present a
+// client certificate for services that require mTLS.
+func (c redisConfig) tlsConfig() (*tls.Config, error) {
+	t := c.TLS
+	if !t.enabled() {
+		if t.InsecureSkipVerify || t.ServerName != "" {
+			return nil, errors.New("redis.tls.insecure_skip_verify and redis.tls.server_name require redis.tls.enabled")
+		}
+		return nil, nil
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, errors.New("redis.tls.cert_file and redis.tls.key_file must be set together")
+	}
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = parseRedisHost(c.Addr)
+	}
+	if t.CAFile != "" {
+		data, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read redis.tls.ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("redis.tls.ca_file %s contains no PEM certificates", t.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load redis.tls client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -1,46 +1,129 @@
 package cmd
 
 import (
-	"encoding/json"
 	"os"
 	"strings"
 	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/broker"
 )
 
 const (
 	defaultListenAddr          = "127.0.0.1:8080"
+	defaultDrainTimeout        = 10 * time.Second
+	defaultReadTimeout         = 10 * time.Second
+	defaultWriteTimeout        = 10 * time.Second
+	defaultIdleTimeout         = 2 * time.Minute
+	defaultMaxBodyBytes        = 1 << 20
 	defaultRedisAddr           = "127.0.0.1:6379"
-	defaultRedisPrefix         = "arc:discord"
+	defaultRedisPrefix         = broker.DefaultPrefix
 	defaultInteractionTimeout  = 15 * time.Minute
 	defaultHandlerEnabled      = true
 	envDiscordPublicKey        = "VIBE_DISCORD_PUBLIC_KEY"
 	envDiscordPublicURL        = "VIBE_DISCORD_PUBLIC_URL"
 	envDefaultAgentID          = "VIBE_AGENT_ID"
+	envAgentVersion            = "VIBE_AGENT_VERSION"
+	envAgentCommit             = "VIBE_AGENT_COMMIT"
 	envDefaultRedisAddr        = "VIBE_DISCORD_REDIS_ADDR"
+	envDefaultRedisUsername    = "VIBE_DISCORD_REDIS_USERNAME"
 	envDefaultRedisPassword    = "VIBE_DISCORD_REDIS_PASSWORD"
 	envDefaultRedisChannelPref = "VIBE_DISCORD_REDIS_PREFIX"
 	envTunnelProvider          = "VIBE_DISCORD_TUNNEL_PROVIDER"
 	envNgrokAuthToken          = "VIBE_DISCORD_NGROK_AUTH_TOKEN"
+	envKafkaBrokers            = "VIBE_DISCORD_KAFKA_BROKERS"
+	envKafkaTopic              = "VIBE_DISCORD_KAFKA_TOPIC"
+	defaultKafkaBatchSize      = 100
+	defaultKafkaBatchTimeout   = time.Second
 )
 
 type interactionSettings struct {
 	PublicKey    string
 	PublicURL    string
+	Intents      []string // privileged intents the configured features rely on
 	Server       serverConfig
 	Redis        redisConfig
+	Broker       brokerSettings
+	Kafka        kafkaConfig
 	Tunnel       tunnelConfig
 	Interactions interactionsConfig
+	Jobs         map[string]jobConfig // scheduled sends, keyed by job name
+	Events       eventsConfig
 }
 
 type serverConfig struct {
-	ListenAddr string `yaml:"listen_addr"`
+	ListenAddr   string           `yaml:"listen_addr"`
+	TLSCert      string           `yaml:"tls_cert"`
+	TLSKey       string           `yaml:"tls_key"`
+	ACMEDomain   string           `yaml:"acme_domain"`
+	ACMEEmail    string           `yaml:"acme_email"`
+	ACMECacheDir string           `yaml:"acme_cache_dir"`
+	DebugAddr    string           `yaml:"debug_addr"`
+	DrainTimeout time.Duration    `yaml:"drain_timeout"`
+	Auth         serverAuthConfig `yaml:"auth"`
+	// TimestampWindow rejects interactions signed longer ago than this
+	// (replay protection), allowing ClockSkew of drift. Zero disables it.
+	TimestampWindow time.Duration `yaml:"timestamp_window"`
+	ClockSkew       time.Duration `yaml:"clock_skew"`
+	// Limits for the public listener. Discord payloads are a few KB and
+	// must be answered within 3 seconds, so the defaults are tight.
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	MaxBodyBytes int64         `yaml:"max_body_bytes"`
+	// AllowedCIDRs limits /interactions to these peer addresses (for
+	// example Discord's ranges or a proxy). Empty allows any address.
+	AllowedCIDRs []string `yaml:"allowed_cidrs"`
 }
 
 type redisConfig struct {
-	Addr          string `yaml:"addr"`
-	DB            int    `yaml:"db"`
-	Password      string `yaml:"password"`
-	ChannelPrefix string `yaml:"channel_prefix"`
+	Addr          string            `yaml:"addr"`
+	DB            int               `yaml:"db"`
+	Username      string            `yaml:"username"`
+	Password      string            `yaml:"password"`
+	ChannelPrefix string            `yaml:"channel_prefix"`
+	TLS           redisTLSConfig    `yaml:"tls"`
+	PublishBatch  redisPublishBatch `yaml:"publish_batch"`
+}
+
+// redisPublishBatch pipelines envelope publishes under burst load. Size is the
+// most envelopes sent per round trip (0 or 1 publishes each one directly);
+// interval is how long to wait for more once one is queued.
+type redisPublishBatch struct {
+	Size     int           `yaml:"size"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+// redisTLSConfig enables TLS to Redis, as managed services (ElastiCache,
+// Upstash, Azure Cache) require. Setting any file enables it as well.
+type redisTLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// brokerSettings selects the gosdk/broker backend. The redis section supplies
+// the connection settings for the default backend; options are passed through
+// to third-party backends.
+type brokerSettings struct {
+	Backend string            `yaml:"backend"`
+	Options map[string]string `yaml:"options"`
+}
+
+// kafkaConfig configures the optional Kafka mirror of published interaction
+// envelopes. The mirror is enabled when both brokers and topic are set.
+type kafkaConfig struct {
+	Brokers      []string      `yaml:"brokers"`
+	Topic        string        `yaml:"topic"`
+	ClientID     string        `yaml:"client_id"`
+	BatchSize    int           `yaml:"batch_size"`
+	BatchTimeout time.Duration `yaml:"batch_timeout"`
+}
+
+func (c kafkaConfig) enabled() bool {
+	return len(c.Brokers) > 0 && strings.TrimSpace(c.Topic) != ""
 }
 
 type tunnelConfig struct {
@@ -48,10 +131,29 @@ type tunnelConfig struct {
 	NgrokAuthToken string `yaml:"ngrok_auth_token"`
 }
 
+// eventsConfig is the events: section of discord.yaml: the gateway events
+// gateway listen --publish bridges to the broker. Empty guilds or channels
+// match everything; channels only restricts events that carry a channel.
+type eventsConfig struct {
+	Types    []string `yaml:"types"`
+	Guilds   []string `yaml:"guilds"`
+	Channels []string `yaml:"channels"`
+}
+
 type interactionsConfig struct {
 	Enabled  bool            `yaml:"enabled"`
 	Timeout  time.Duration   `yaml:"timeout"`
 	Handlers handlerMappings `yaml:"handlers"`
+	// Middleware runs for every route, before the route's own middleware.
+	Middleware []middlewareConfig `yaml:"middleware"`
+	// Rules can reroute, drop, or transform interactions before they reach
+	// the handler's agent.
+	Rules []routingRule `yaml:"rules"`
+	// Delivery makes agent dispatch at-least-once; see deliveryConfig.
+	Delivery deliveryConfig `yaml:"delivery"`
+	// ReplyQueue has agents publish responses for the server to deliver;
+	// see replyRouter.
+	ReplyQueue bool `yaml:"reply_queue"`
 }
 
 type handlerMappings struct {
@@ -66,6 +168,80 @@ type handlerRoute struct {
 	Channel     string               `yaml:"channel"`
 	Description string               `yaml:"description"`
 	Choices     []autocompleteChoice `yaml:"choices"`
+	// PremiumSKU gates the handler on an entitlement to this SKU; users
+	// without one get PremiumMessage and a premium purchase button instead.
+	PremiumSKU     string `yaml:"premium_sku"`
+	PremiumMessage string `yaml:"premium_message"`
+	// Ephemeral defers the interaction ephemerally, so the agent's reply and
+	// followups are only shown to the invoking user.
+	Ephemeral bool `yaml:"ephemeral"`
+	// InitialResponse replaces the default deferred acknowledgement. Routes
+	// without an agent reply with it and dispatch nothing.
+	InitialResponse *initialResponseConfig `yaml:"initial_response"`
+	// Script answers the interaction in the server; see handlerScript.
+	// Script routes take no agent or initial_response.
+	Script string `yaml:"script"`
+	// Wasm answers the interaction with a sandboxed WebAssembly module; see
+	// wasmHandlerConfig. Wasm routes take no agent or initial_response.
+	Wasm *wasmHandlerConfig `yaml:"wasm"`
+	// Respond is a fixed message the server answers with, for routes that
+	// need no agent (/help, /links).
+	Respond *staticResponseConfig `yaml:"respond"`
+	// Source queries live autocomplete choices instead of, or ahead of, the
+	// static Choices.
+	Source *autocompleteSource `yaml:"source"`
+	// Middleware runs in order before the route dispatches; any of it can
+	// answer the interaction itself instead.
+	Middleware []middlewareConfig `yaml:"middleware"`
+	// RequireRoles admits members with any of these role IDs and
+	// RequirePermissions those with all of these permissions (ManageGuild or
+	// manage_guild). Everyone else gets UnauthorizedMessage ephemerally.
+	RequireRoles        []string `yaml:"require_roles"`
+	RequirePermissions  []string `yaml:"require_permissions"`
+	UnauthorizedMessage string   `yaml:"unauthorized_message"`
+}
+
+// middlewareConfig names a registered interactions middleware (log,
+// rate_limit, guilds, or one an embedder registered); the other keys are its
+// options.
+// answered reports whether the route has something to answer with: an
+// agent, an initial_response, a script, a wasm module, or a respond message.
+func (r handlerRoute) answered() bool {
+	return r.Agent != "" || r.InitialResponse != nil || r.Script != "" || r.Wasm != nil || r.Respond != nil
+}
+
+type middlewareConfig struct {
+	Name    string         `yaml:"name"`
+	Options map[string]any `yaml:",inline"`
+}
+
+// autocompleteSource is where an autocomplete route fetches choices. Exactly
+// one of Exec, URL, or RedisKey is set; Timeout defaults to
+// defaultAutocompleteTimeout.
+type autocompleteSource struct {
+	Exec     string        `yaml:"exec"`
+	URL      string        `yaml:"url"`
+	RedisKey string        `yaml:"redis_key"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// initialResponseConfig is the first response the server sends for a route.
+// Type is deferred (default), message, or, for components, update or
+// deferred_update. Embeds and components use Discord's JSON shape.
+type initialResponseConfig struct {
+	Type       string           `yaml:"type"`
+	Content    string           `yaml:"content"`
+	Embeds     []map[string]any `yaml:"embeds"`
+	Components []map[string]any `yaml:"components"`
+}
+
+// staticResponseConfig is a route's respond block: an initial_response of
+// type message without an agent. Embeds and components use Discord's JSON
+// shape.
+type staticResponseConfig struct {
+	Content    string           `yaml:"content"`
+	Embeds     []map[string]any `yaml:"embeds"`
+	Components []map[string]any `yaml:"components"`
 }
 
 type autocompleteChoice struct {
@@ -74,29 +250,29 @@ type autocompleteChoice struct {
 	Value       interface{} `yaml:"value"`
 }
 
-type redisEnvelope struct {
-	Agent          string          `json:"agent"`
-	Kind           string          `json:"kind"`
-	Key            string          `json:"key"`
-	Interaction    json.RawMessage `json:"interaction"`
-	ReceivedAt     time.Time       `json:"received_at"`
-	TimeoutSeconds int             `json:"timeout_seconds"`
-	Source         string          `json:"source"`
-}
-
 func defaultInteractionSettings() *interactionSettings {
 	cfg := &interactionSettings{
 		PublicKey: strings.TrimSpace(os.Getenv(envDiscordPublicKey)),
 		PublicURL: strings.TrimSpace(os.Getenv(envDiscordPublicURL)),
 		Server: serverConfig{
-			ListenAddr: defaultListenAddr,
+			ListenAddr:   defaultListenAddr,
+			DrainTimeout: defaultDrainTimeout,
+			ReadTimeout:  defaultReadTimeout,
+			WriteTimeout: defaultWriteTimeout,
+			IdleTimeout:  defaultIdleTimeout,
+			MaxBodyBytes: defaultMaxBodyBytes,
 		},
 		Redis: redisConfig{
 			Addr:          envOrDefault(envDefaultRedisAddr, defaultRedisAddr),
 			DB:            0,
+			Username:      os.Getenv(envDefaultRedisUsername),
 			Password:      os.Getenv(envDefaultRedisPassword),
 			ChannelPrefix: envOrDefault(envDefaultRedisChannelPref, defaultRedisPrefix),
 		},
+		Kafka: kafkaConfig{
+			BatchSize:    defaultKafkaBatchSize,
+			BatchTimeout: defaultKafkaBatchTimeout,
+		},
 		Tunnel: tunnelConfig{},
 		Interactions: interactionsConfig{
 			Enabled: defaultHandlerEnabled,
@@ -119,6 +295,28 @@ func defaultInteractionSettings() *interactionSettings {
 	return cfg
 }
 
+func (s *interactionSettings) brokerConfig() (broker.Config, error) {
+	tlsConfig, err := s.Redis.tlsConfig()
+	if err != nil {
+		return broker.Config{}, err
+	}
+	return broker.Config{
+		Backend:     s.Broker.Backend,
+		Addr:        s.Redis.Addr,
+		Username:    s.Redis.Username,
+		Password:    s.Redis.Password,
+		DB:          s.Redis.DB,
+		TLS:         tlsConfig,
+		Prefix:      normalizeChannelPrefix(s.Redis.ChannelPrefix),
+		RegistryTTL: defaultRegistryTTL,
+		Options:     s.Broker.Options,
+		PublishBatch: broker.BatchConfig{
+			Size:     s.Redis.PublishBatch.Size,
+			Interval: s.Redis.PublishBatch.Interval,
+		},
+	}, nil
+}
+
 func envOrDefault(key, fallback string) string {
 	if val := strings.TrimSpace(os.Getenv(key)); val != "" {
 		return val
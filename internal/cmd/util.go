@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/interactions"
+
+	"github.com/yourorg/arc-sdk/output"
+	"github.com/yourorg/arc-sdk/utils"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func utilCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "util",
+		Short: "Troubleshooting utilities",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(utilVerifySignatureCmd(opts))
+	cmd.AddCommand(utilTimestampCmd(opts))
+	return cmd
+}
+
+type verifySignatureInput struct {
+	publicKey string
+	timestamp string
+	signature string
+	bodyPath  string
+	output    output.OutputOptions
+}
+
+type signatureVerification struct {
+	Valid     bool     `json:"valid" yaml:"valid"`
+	Reason    string   `json:"reason,omitempty" yaml:"reason,omitempty"`
+	BodyBytes int      `json:"body_bytes" yaml:"body_bytes"`
+	Hints     []string `json:"hints,omitempty" yaml:"hints,omitempty"`
+}
+
+func utilVerifySignatureCmd(opts *globalOptions) *cobra.Command {
+	var in verifySignatureInput
+
+	cmd := &cobra.Command{
+		Use:   "verify-signature",
+		Short: "Verify a Discord interaction signature offline",
+		Long: `Reproduce the interaction server's Ed25519 check for a captured request: the signature
+must be valid for X-Signature-Timestamp followed by the raw request body, byte for byte.
+
+When verification fails, common reverse-proxy mutations are tried (trailing newline added or
+stripped, CRLF line endings, re-encoded JSON) and reported as hints so you can tell whether the
+proxy rewrote the body rather than the key or headers being wrong.
+
+If --public-key is not provided, uses discord.public_key from discord.yaml or VIBE_DISCORD_PUBLIC_KEY.
+Exits non-zero when the signature does not verify.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if in.timestamp == "" || in.signature == "" {
+				return &arcer.CLIError{Msg: "--timestamp and --signature are required", Hint: "copy the X-Signature-Timestamp and X-Signature-Ed25519 header values"}
+			}
+			if in.bodyPath == "" {
+				return &arcer.CLIError{Msg: "--body is required", Hint: "pass the raw request body file, or - for stdin"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			in.output = opts.output
+			return runVerifySignature(cmd, opts, in)
+		},
+		Example: `Example:
+  # Verify a captured request body against the configured public key
+  arc-discord util verify-signature --timestamp 1700000000 \
+    --signature 7f3c...e1 --body request.json
+
+Example:
+  # Pipe the body from stdin with an explicit key
+  cat request.json | arc-discord util verify-signature --public-key $KEY \
+    --timestamp 1700000000 --signature 7f3c...e1 --body -`,
+	}
+
+	cmd.Flags().StringVar(&in.publicKey, "public-key", "", "Application public key (hex); defaults to discord.public_key")
+	cmd.Flags().StringVar(&in.timestamp, "timestamp", "", "X-Signature-Timestamp header value")
+	cmd.Flags().StringVar(&in.signature, "signature", "", "X-Signature-Ed25519 header value (hex)")
+	cmd.Flags().StringVar(&in.bodyPath, "body", "", "Path to the raw request body (- for stdin)")
+	return cmd
+}
+
+func runVerifySignature(cmd *cobra.Command, opts *globalOptions, in verifySignatureInput) error {
+	publicKey := strings.TrimSpace(in.publicKey)
+	if publicKey == "" {
+		_, extra, _, err := opts.loadConfigWithInteractions()
+		if err != nil {
+			return err
+		}
+		publicKey = extra.PublicKey
+	}
+	if publicKey == "" {
+		return &arcer.CLIError{Msg: "--public-key is required", Hint: "pass the key or set discord.public_key in discord.yaml"}
+	}
+	pub, err := interactions.ParsePublicKey(publicKey)
+	if err != nil {
+		return (&arcer.CLIError{Msg: "invalid public key", Hint: "copy PUBLIC KEY from the Developer Portal's General Information page"}).WithCause(err)
+	}
+
+	body, err := readSignatureBody(cmd, in.bodyPath)
+	if err != nil {
+		return err
+	}
+
+	signature := strings.TrimSpace(in.signature)
+	timestamp := strings.TrimSpace(in.timestamp)
+	result := signatureVerification{BodyBytes: len(body)}
+	verifyErr := interactions.VerifySignature(pub, signature, timestamp, body)
+	if verifyErr == nil {
+		result.Valid = true
+	} else {
+		result.Reason = verifyErr.Error()
+		if errors.Is(verifyErr, interactions.ErrSignatureMismatch) {
+			result.Hints = signatureMismatchHints(pub, signature, timestamp, body)
+			if timestamp != in.timestamp {
+				result.Hints = append(result.Hints, "timestamp had surrounding whitespace; check the proxy forwards the header verbatim")
+			}
+		}
+	}
+
+	data := map[string]string{
+		"valid":      fmt.Sprintf("%t", result.Valid),
+		"body_bytes": fmt.Sprintf("%d", result.BodyBytes),
+	}
+	if result.Reason != "" {
+		data["reason"] = result.Reason
+	}
+	if len(result.Hints) > 0 {
+		data["hints"] = strings.Join(result.Hints, "; ")
+	}
+	if err := renderOutput(cmd, in.output, result, keyValueTable(data)); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return &arcer.CLIError{Msg: "signature verification failed", Hint: result.Reason}
+	}
+	return nil
+}
+
+// readPayload reads a --payload or --file argument, taking "-" to mean
+// stdin so payloads can be piped in from other tools.
+func readPayload(stdin io.Reader, path string) ([]byte, error) {
+	if path != "-" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read payload %s", path)}).WithCause(err)
+		}
+		return data, nil
+	}
+	if stdin == nil {
+		return nil, &arcer.CLIError{Msg: "payload - needs stdin, which is not available here"}
+	}
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "failed to read payload from stdin"}).WithCause(err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, &arcer.CLIError{Msg: "payload from stdin is empty", Hint: "pipe the JSON into the command, e.g. jq -n ... | arc-discord webhook send --payload -"}
+	}
+	return data, nil
+}
+
+func readSignatureBody(cmd *cobra.Command, path string) ([]byte, error) {
+	if path == "-" {
+		body, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: "failed to read body from stdin"}).WithCause(err)
+		}
+		return body, nil
+	}
+	body, err := os.ReadFile(utils.ExpandPath(path))
+	if err != nil {
+		return nil, (&arcer.CLIError{Msg: "failed to read body file", Hint: "check the --body path"}).WithCause(err)
+	}
+	return body, nil
+}
+
+// signatureMismatchHints re-verifies the signature against body variants that
+// reverse proxies commonly produce and describes any that match.
+func signatureMismatchHints(pub []byte, signature, timestamp string, body []byte) []string {
+	variants := []struct {
+		hint string
+		body []byte
+	}{
+		{"body verifies once the trailing newline is stripped; the proxy or capture appended one", bytes.TrimRight(body, "\r\n")},
+		{"body verifies with a trailing newline; the proxy or capture stripped it", append(bytes.Clone(body), '\n')},
+		{"body verifies with CRLF converted to LF; line endings were rewritten", bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n"))},
+		{"body verifies with LF converted to CRLF; line endings were rewritten", bytes.ReplaceAll(body, []byte("\n"), []byte("\r\n"))},
+	}
+	var compacted bytes.Buffer
+	if json.Compact(&compacted, body) == nil {
+		variants = append(variants, struct {
+			hint string
+			body []byte
+		}{"body verifies once JSON whitespace is removed; the proxy re-encoded the JSON", compacted.Bytes()})
+	}
+
+	var hints []string
+	for _, v := range variants {
+		if bytes.Equal(v.body, body) {
+			continue
+		}
+		if interactions.VerifySignature(pub, signature, timestamp, v.body) == nil {
+			hints = append(hints, v.hint)
+		}
+	}
+	if len(hints) == 0 {
+		hints = append(hints, "no common body rewrite matches; confirm the public key and that the timestamp and signature come from the same request")
+	}
+	return hints
+}
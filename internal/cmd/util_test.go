@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/yourorg/arc-sdk/output"
+)
+
+func TestUtilVerifySignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey error: %v", err)
+	}
+	timestamp := "1700000000"
+	signed := []byte(`{"type":1}`)
+	signature := hex.EncodeToString(ed25519.Sign(priv, append([]byte(timestamp), signed...)))
+
+	run := func(body string) (string, error) {
+		opts := &globalOptions{output: output.OutputOptions{Format: string(output.OutputJSON)}}
+		cmd := utilVerifySignatureCmd(opts)
+		cmd.SetArgs([]string{"--public-key", hex.EncodeToString(pub), "--timestamp", timestamp, "--signature", signature, "--body", "-"})
+		cmd.SetIn(strings.NewReader(body))
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run(string(signed))
+	if err != nil {
+		t.Fatalf("expected valid signature, got %v (%s)", err, out)
+	}
+	if !strings.Contains(out, `"valid": true`) {
+		t.Fatalf("expected valid result, got %s", out)
+	}
+
+	out, err = run(string(signed) + "\n")
+	if err == nil {
+		t.Fatal("expected failure for mangled body")
+	}
+	if !strings.Contains(out, "trailing newline is stripped") {
+		t.Fatalf("expected trailing newline hint, got %s", out)
+	}
+}
+
+func TestPayloadFromStdin(t *testing.T) {
+	msg, err := buildWebhookMessage(webhookSendInput{
+		payloadPath: "-",
+		stdin:       strings.NewReader(`{"content":"from a pipe"}`),
+		username:    "ci",
+	}, nil)
+	if err != nil {
+		t.Fatalf("buildWebhookMessage: %v", err)
+	}
+	if msg.Content != "from a pipe" || msg.Username != "ci" {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+
+	params, err := buildMessageParams(messageSendInput{payloadPath: "-", stdin: strings.NewReader(`{"content":"hi"}`)}, nil)
+	if err != nil || params.Content != "hi" {
+		t.Fatalf("buildMessageParams: %+v, %v", params, err)
+	}
+
+	if _, err := readPayload(strings.NewReader("  \n"), "-"); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Fatalf("expected empty stdin error, got %v", err)
+	}
+	if _, err := readPayload(nil, "-"); err == nil {
+		t.Fatal("expected error without stdin")
+	}
+}
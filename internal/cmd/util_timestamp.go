@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	discordutils "github.com/yourorg/arc-discord/gosdk/discord/utils"
+
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+type timestampMarkup struct {
+	Markup   string `json:"markup" yaml:"markup"`
+	Unix     int64  `json:"unix" yaml:"unix"`
+	Style    string `json:"style" yaml:"style"`
+	Preview  string `json:"preview" yaml:"preview"`
+	Relative string `json:"relative" yaml:"relative"`
+	Locale   string `json:"locale" yaml:"locale"`
+	Timezone string `json:"timezone" yaml:"timezone"`
+}
+
+var timestampStyles = map[string]discordutils.TimestampStyle{
+	"t": discordutils.TimestampShortTime,
+	"T": discordutils.TimestampLongTime,
+	"d": discordutils.TimestampShortDate,
+	"D": discordutils.TimestampLongDate,
+	"f": discordutils.TimestampShortDateTime,
+	"F": discordutils.TimestampLongDateTime,
+	"R": discordutils.TimestampRelative,
+}
+
+func utilTimestampCmd(opts *globalOptions) *cobra.Command {
+	var (
+		at    string
+		style string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "timestamp",
+		Short: "Build Discord <t:...> timestamp markup",
+		Long: `Print Discord timestamp markup for a point in time. Discord clients render the markup in
+each viewer's own locale and time zone; the preview column shows how it reads for --locale and
+--timezone (or output.locale/output.timezone in discord.yaml).
+
+--at accepts an RFC3339 timestamp, unix seconds, or an offset from now such as 2h or -30m.
+--style is one of t, T, d, D, f, F, R (default f).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			st, ok := timestampStyles[style]
+			if !ok {
+				return &arcer.CLIError{Msg: fmt.Sprintf("invalid --style %q", style), Hint: "use one of t, T, d, D, f, F, R"}
+			}
+			when, err := parseTimestampAt(at, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			fmtr, err := opts.formatter(cfg)
+			if err != nil {
+				return err
+			}
+			result := timestampMarkup{
+				Markup:   discordutils.FormatTimestamp(when, st),
+				Unix:     when.Unix(),
+				Style:    string(st),
+				Preview:  fmtr.DateTime(when),
+				Relative: fmtr.Relative(when),
+				Locale:   fmtr.Locale(),
+				Timezone: fmtr.Location().String(),
+			}
+			table := keyValueTable(map[string]string{
+				"markup":   result.Markup,
+				"unix":     strconv.FormatInt(result.Unix, 10),
+				"preview":  result.Preview,
+				"relative": result.Relative,
+				"locale":   result.Locale,
+				"timezone": result.Timezone,
+			})
+			return renderOutput(cmd, opts.output, result, table)
+		},
+		Example: `Example:
+  # Countdown to a maintenance window two hours from now
+  arc-discord util timestamp --at 2h --style R
+
+Example:
+  # Preview a release date for a German audience
+  arc-discord util timestamp --at 2025-03-01T18:00:00Z --style F --locale de --timezone Europe/Berlin`,
+	}
+	cmd.Flags().StringVar(&at, "at", "", "RFC3339 timestamp, unix seconds, or offset from now (e.g. 2h, -30m); default now")
+	cmd.Flags().StringVar(&style, "style", "f", "Discord timestamp style: t, T, d, D, f, F, R")
+	return cmd
+}
+
+func parseTimestampAt(raw string, now time.Time) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return now, nil
+	}
+	if ts, err := time.Parse(time.RFC3339, raw); err == nil {
+		return ts, nil
+	}
+	if secs, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		return now.Add(d), nil
+	}
+	return time.Time{}, &arcer.CLIError{Msg: fmt.Sprintf("invalid --at value %q", raw), Hint: "use an RFC3339 timestamp, unix seconds, or a duration like 2h"}
+}
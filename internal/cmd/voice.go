@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/gateway"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/discord/voice"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+const (
+	defaultVoiceJoinTimeout = 30 * time.Second
+	voiceLeaveTimeout       = 5 * time.Second
+	opusFrameDuration       = 20 * time.Millisecond
+)
+
+// voiceSession is a joined voice channel that can play audio.
+type voiceSession interface {
+	Play(ctx context.Context, src voice.OpusSource) (int, error)
+	Close() error
+}
+
+var joinVoiceFn = joinVoice
+
+func voiceCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "voice",
+		Short: "Play audio in voice channels",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(voicePlayCmd(opts))
+	return cmd
+}
+
+func voicePlayCmd(opts *globalOptions) *cobra.Command {
+	var (
+		channelRef string
+		guildRef   string
+		file       string
+		timeout    time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "play",
+		Short: "Join a voice channel, play an Ogg Opus file, and leave",
+		Long: `Join a voice channel through the gateway, stream an Ogg Opus file, and leave when it
+finishes (or on Ctrl+C).
+
+The file must be Opus at 48kHz in an Ogg container; convert other audio with
+  ffmpeg -i clip.mp3 -c:a libopus -ar 48000 -ac 2 -b:a 96k clip.ogg
+
+The bot needs the Connect and Speak permissions. In stage channels it joins as audience, so
+make it a speaker first. Channels that require end-to-end encrypted voice are not supported.`,
+		Example: `Example:
+  arc-discord voice play --channel 1427555325136867393 --file clip.ogg
+
+Example:
+  arc-discord voice play --channel "#announcements" --guild "Arc Labs" --file standup.ogg`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelRef == "" || file == "" {
+				return &arcer.CLIError{Msg: "--channel and --file are required"}
+			}
+			packets, err := loadOggPackets(file)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to read %s", file), Hint: "convert it with: ffmpeg -i in -c:a libopus -ar 48000 out.ogg"}).WithCause(err)
+			}
+
+			cfg, _, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			bot, err := newBotClientFn(cfg, opts.tokenOverride)
+			if err != nil {
+				return (&arcer.CLIError{Msg: "failed to initialize Discord bot client"}).WithCause(err)
+			}
+			lookupCtx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			channelID, err := newNameResolver(bot, cfg).ChannelID(lookupCtx, channelRef, guildRef)
+			if err != nil {
+				return err
+			}
+			ch, err := bot.Channels().GetChannel(lookupCtx, channelID)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch channel %s", channelID)}).WithCause(err)
+			}
+			if ch.Type != types.ChannelTypeGuildVoice && ch.Type != types.ChannelTypeGuildStageVoice {
+				return &arcer.CLIError{Msg: fmt.Sprintf("channel %s is a %s channel, not voice", channelID, channelTypeName(ch.Type))}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			session, err := joinVoiceFn(ctx, cfg.Discord.BotToken, ch.GuildID, channelID, timeout)
+			if err != nil {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("failed to join voice channel %s", channelID), Hint: "check the bot has Connect and Speak in that channel"}).WithCause(err)
+			}
+			defer session.Close()
+
+			src := packetSource(packets)
+			frames, err := session.Play(ctx, &src)
+			played := time.Duration(frames) * opusFrameDuration
+			if err != nil && !errors.Is(err, context.Canceled) {
+				return (&arcer.CLIError{Msg: fmt.Sprintf("playback failed after %s", played)}).WithCause(err)
+			}
+			cmd.Printf("Played %s of %s in channel %s\n", played, file, channelID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&channelRef, "channel", "", "Voice channel ID or #name")
+	cmd.Flags().StringVar(&guildRef, "guild", "", "Guild ID or name used to resolve a channel name")
+	cmd.Flags().StringVar(&file, "file", "", "Ogg Opus file to play")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultVoiceJoinTimeout, "Time limit for joining the channel")
+	return cmd
+}
+
+// loadOggPackets reads the whole file up front so a bad file fails before
+// the bot joins the channel.
+func loadOggPackets(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	reader := voice.NewOggReader(f)
+	var packets [][]byte
+	for {
+		packet, err := reader.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		packets = append(packets, packet)
+	}
+	if len(packets) == 0 {
+		return nil, errors.New("no audio packets")
+	}
+	return packets, nil
+}
+
+// packetSource replays preloaded Opus packets.
+type packetSource [][]byte
+
+func (s *packetSource) ReadPacket() ([]byte, error) {
+	if len(*s) == 0 {
+		return nil, io.EOF
+	}
+	packet := (*s)[0]
+	*s = (*s)[1:]
+	return packet, nil
+}
+
+// gatewayVoiceSession owns the gateway connection used to join voice.
+type gatewayVoiceSession struct {
+	gw      *gateway.Client
+	conn    *voice.Connection
+	guildID string
+}
+
+// joinVoice connects to the gateway, waits for READY to learn the bot's user
+// ID, and joins channelID. The gateway runs on ctx; timeout bounds only the
+// join.
+func joinVoice(ctx context.Context, token, guildID, channelID string, timeout time.Duration) (voiceSession, error) {
+	gw, err := gateway.NewClient(token, int(gateway.IntentGuilds|gateway.IntentGuildVoiceStates))
+	if err != nil {
+		return nil, err
+	}
+	ready := make(chan string, 1)
+	gw.On(gateway.EventReady, func(_ context.Context, event gateway.Event) error {
+		if evt, ok := event.(*gateway.ReadyEvent); ok && evt.User != nil {
+			select {
+			case ready <- evt.User.ID:
+			default:
+			}
+		}
+		return nil
+	})
+	if err := gw.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("connect gateway: %w", err)
+	}
+
+	joinCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var userID string
+	select {
+	case <-joinCtx.Done():
+		_ = gw.Disconnect()
+		return nil, fmt.Errorf("wait for gateway ready: %w", joinCtx.Err())
+	case userID = <-ready:
+	}
+	conn, err := voice.Join(joinCtx, gw, userID, guildID, channelID)
+	if err != nil {
+		_ = voice.Leave(ctx, gw, guildID)
+		_ = gw.Disconnect()
+		return nil, err
+	}
+	return &gatewayVoiceSession{gw: gw, conn: conn, guildID: guildID}, nil
+}
+
+func (s *gatewayVoiceSession) Play(ctx context.Context, src voice.OpusSource) (int, error) {
+	return s.conn.Play(ctx, src)
+}
+
+// Close hangs up the voice connection, leaves the channel, and disconnects
+// from the gateway.
+func (s *gatewayVoiceSession) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), voiceLeaveTimeout)
+	defer cancel()
+	return errors.Join(s.conn.Close(), voice.Leave(ctx, s.gw, s.guildID), s.gw.Disconnect())
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/discord/voice"
+)
+
+type fakeVoiceSession struct {
+	guildID, channelID string
+	frames             int
+	closed             bool
+}
+
+func (f *fakeVoiceSession) Play(ctx context.Context, src voice.OpusSource) (int, error) {
+	for {
+		if _, err := src.ReadPacket(); errors.Is(err, io.EOF) {
+			return f.frames, nil
+		}
+		f.frames++
+	}
+}
+
+func (f *fakeVoiceSession) Close() error {
+	f.closed = true
+	return nil
+}
+
+// writeOggOpus writes a single-page Ogg Opus file holding frames short packets.
+func writeOggOpus(t *testing.T, frames int) string {
+	t.Helper()
+	page := func(packets ...[]byte) []byte {
+		header := make([]byte, 27)
+		copy(header, "OggS")
+		binary.LittleEndian.PutUint32(header[14:18], 1)
+		header[26] = byte(len(packets))
+		var body []byte
+		for _, p := range packets {
+			header = append(header, byte(len(p)))
+			body = append(body, p...)
+		}
+		return append(header, body...)
+	}
+	var buf bytes.Buffer
+	buf.Write(page([]byte("OpusHead")))
+	buf.Write(page([]byte("OpusTags")))
+	audio := make([][]byte, frames)
+	for i := range audio {
+		audio[i] = []byte{0xFC, byte(i)}
+	}
+	buf.Write(page(audio...))
+	path := filepath.Join(t.TempDir(), "clip.ogg")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func hookVoice(t *testing.T, channel *types.Channel) *fakeVoiceSession {
+	t.Helper()
+	hookBot(t, testConfig(), &fakeBotClient{channelSvc: &fakeChannelService{channel: channel}})
+	session := &fakeVoiceSession{}
+	joinVoiceFn = func(ctx context.Context, token, guildID, channelID string, timeout time.Duration) (voiceSession, error) {
+		session.guildID, session.channelID = guildID, channelID
+		return session, nil
+	}
+	t.Cleanup(func() { joinVoiceFn = joinVoice })
+	return session
+}
+
+func TestVoicePlayStreamsFile(t *testing.T) {
+	session := hookVoice(t, &types.Channel{ID: "200000000000000001", GuildID: "100000000000000001", Type: types.ChannelTypeGuildVoice})
+	path := writeOggOpus(t, 50)
+
+	var out bytes.Buffer
+	cmd := voicePlayCmd(&globalOptions{})
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--channel", "200000000000000001", "--file", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("voice play: %v", err)
+	}
+	if session.guildID != "100000000000000001" || session.frames != 50 || !session.closed {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	if !strings.Contains(out.String(), "Played 1s") {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
+
+func TestVoicePlayRejectsTextChannelAndBadFile(t *testing.T) {
+	session := hookVoice(t, &types.Channel{ID: "200000000000000001", GuildID: "100000000000000001", Type: types.ChannelTypeGuildText})
+	cmd := voicePlayCmd(&globalOptions{})
+	cmd.SetArgs([]string{"--channel", "200000000000000001", "--file", writeOggOpus(t, 1)})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not voice") {
+		t.Fatalf("expected non-voice channel error, got %v", err)
+	}
+
+	bad := filepath.Join(t.TempDir(), "clip.mp3")
+	_ = os.WriteFile(bad, []byte("ID3 not an ogg file at all"), 0o644)
+	cmd = voicePlayCmd(&globalOptions{})
+	cmd.SetArgs([]string{"--channel", "200000000000000001", "--file", bad})
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected error for non-ogg file")
+	}
+	if session.channelID != "" {
+		t.Fatalf("should not join voice on invalid input")
+	}
+}
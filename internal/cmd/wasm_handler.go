@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-sdk/utils"
+)
+
+const (
+	defaultWasmTimeout  = time.Second
+	defaultWasmMemoryMB = 16
+	// wasmPageSize is the size of a WebAssembly memory page.
+	wasmPageSize = 64 << 10
+	// wasmOutputLimit caps what a module may write to stdout or stderr.
+	wasmOutputLimit = 1 << 20
+)
+
+// wasmHandlerConfig is a route's wasm: a WASI command module the server runs
+// in-process for each interaction. The module reads the interaction JSON on
+// stdin and writes an interaction response JSON to stdout; exiting non-zero
+// fails the interaction with whatever it wrote to stderr.
+type wasmHandlerConfig struct {
+	Module string `yaml:"module"`
+	// Timeout bounds a run's CPU time; the module is stopped when it passes.
+	Timeout time.Duration `yaml:"timeout"`
+	// MemoryMB caps the module's linear memory.
+	MemoryMB int `yaml:"memory_mb"`
+}
+
+func (c *wasmHandlerConfig) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return defaultWasmTimeout
+}
+
+func (c *wasmHandlerConfig) memoryMB() int {
+	if c.MemoryMB > 0 {
+		return c.MemoryMB
+	}
+	return defaultWasmMemoryMB
+}
+
+func (c *wasmHandlerConfig) validate() error {
+	if strings.TrimSpace(c.Module) == "" {
+		return errors.New("wasm needs a module")
+	}
+	if c.Timeout < 0 {
+		return errors.New("wasm timeout must not be negative")
+	}
+	if c.MemoryMB < 0 {
+		return errors.New("wasm memory_mb must not be negative")
+	}
+	if c.MemoryMB > 4096 {
+		return errors.New("wasm memory_mb must be at most 4096")
+	}
+	return nil
+}
+
+// wasmHandler is a compiled wasm route. Each interaction gets a fresh module
+// instance, so runs share no state. Handlers dropped by a reload are left to
+// the garbage collector, which releases wazero's compiled code.
+type wasmHandler struct {
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+	timeout time.Duration
+}
+
+func compileWasmHandler(cfg *wasmHandlerConfig) (*wasmHandler, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	path := utils.ExpandPath(cfg.Module)
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("wasm module: %w", err)
+	}
+	ctx := context.Background()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(cfg.memoryMB()*(1<<20)/wasmPageSize)).
+		WithCloseOnContextDone(true))
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("wasm module %s: %w", path, err)
+	}
+	module, err := runtime.CompileModule(ctx, code)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("wasm module %s: %w", path, err)
+	}
+	return &wasmHandler{runtime: runtime, module: module, timeout: cfg.timeout()}, nil
+}
+
+// respond runs the module on the interaction and decodes its response.
+func (h *wasmHandler) respond(ctx context.Context, i *types.Interaction) (*types.InteractionResponse, error) {
+	in, err := json.Marshal(i)
+	if err != nil {
+		return nil, fmt.Errorf("encode interaction: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	stdout := &limitedBuffer{limit: wasmOutputLimit}
+	stderr := &limitedBuffer{limit: wasmOutputLimit}
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithStdin(bytes.NewReader(in)).
+		WithStdout(stdout).
+		WithStderr(stderr)
+	mod, err := h.runtime.InstantiateModule(ctx, h.module, config)
+	if mod != nil {
+		defer mod.Close(context.Background())
+	}
+	if err != nil {
+		var exit *sys.ExitError
+		switch {
+		case ctx.Err() != nil:
+			return nil, fmt.Errorf("wasm module ran past its %s timeout", h.timeout)
+		case errors.As(err, &exit) && exit.ExitCode() == 0:
+		case errors.As(err, &exit):
+			return nil, fmt.Errorf("wasm module exited %d: %s", exit.ExitCode(), wasmStderr(stderr))
+		default:
+			return nil, fmt.Errorf("wasm module: %w", err)
+		}
+	}
+	if stdout.overflow {
+		return nil, fmt.Errorf("wasm module wrote more than %d bytes", wasmOutputLimit)
+	}
+	var resp types.InteractionResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("wasm module response: %w", err)
+	}
+	if err := resp.Validate(); err != nil {
+		return nil, fmt.Errorf("wasm module response: %w", err)
+	}
+	return &resp, nil
+}
+
+func wasmStderr(stderr *limitedBuffer) string {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return msg
+	}
+	return "no output"
+}
+
+// limitedBuffer keeps the first limit bytes written to it and drops the rest,
+// so a runaway module cannot exhaust the server's memory through its output.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.overflow = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+)
+
+// The modules below are assembled by hand so the tests need no wasm
+// toolchain. Each imports fd_read (0), fd_write (1), and proc_exit (2) from
+// WASI and exports _start (3) and its memory.
+const (
+	wasmCallFdRead   = 0
+	wasmCallFdWrite  = 1
+	wasmCallProcExit = 2
+)
+
+type wasmData struct {
+	offset int32
+	bytes  []byte
+}
+
+func buildWasmModule(memoryPages uint32, code []byte, data ...wasmData) []byte {
+	out := []byte{0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00}
+	section := func(id byte, items ...[]byte) {
+		body := wasmULEB(uint32(len(items)))
+		for _, item := range items {
+			body = append(body, item...)
+		}
+		out = append(out, id)
+		out = append(out, wasmULEB(uint32(len(body)))...)
+		out = append(out, body...)
+	}
+	i32 := byte(0x7f)
+	section(1,
+		[]byte{0x60, 4, i32, i32, i32, i32, 1, i32}, // fd_read, fd_write
+		[]byte{0x60, 0, 0},                          // _start
+		[]byte{0x60, 1, i32, 0},                     // proc_exit
+	)
+	imp := func(name string, typ byte) []byte {
+		b := wasmName("wasi_snapshot_preview1")
+		b = append(b, wasmName(name)...)
+		return append(b, 0x00, typ)
+	}
+	section(2, imp("fd_read", 0), imp("fd_write", 0), imp("proc_exit", 2))
+	section(3, []byte{1})
+	section(5, append([]byte{0x00}, wasmULEB(memoryPages)...))
+	section(7,
+		append(wasmName("memory"), 0x02, 0),
+		append(wasmName("_start"), 0x00, 3),
+	)
+	body := append([]byte{0}, code...)
+	body = append(body, 0x0b)
+	section(10, append(wasmULEB(uint32(len(body))), body...))
+	segments := make([][]byte, 0, len(data))
+	for _, d := range data {
+		seg := append([]byte{0x00}, wasmConst(d.offset)...)
+		seg = append(seg, 0x0b)
+		seg = append(seg, wasmULEB(uint32(len(d.bytes)))...)
+		segments = append(segments, append(seg, d.bytes...))
+	}
+	if len(segments) > 0 {
+		section(11, segments...)
+	}
+	return out
+}
+
+func wasmULEB(v uint32) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+// wasmConst encodes i32.const v.
+func wasmConst(v int32) []byte {
+	out := []byte{0x41}
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func wasmName(s string) []byte {
+	return append(wasmULEB(uint32(len(s))), s...)
+}
+
+func wasmCode(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func wasmCall(fn byte) []byte { return []byte{0x10, fn} }
+
+// wasmIOVec is a WASI iovec of buf and length, little-endian.
+func wasmIOVec(buf, length uint32) []byte {
+	return []byte{
+		byte(buf), byte(buf >> 8), byte(buf >> 16), byte(buf >> 24),
+		byte(length), byte(length >> 8), byte(length >> 16), byte(length >> 24),
+	}
+}
+
+// wasmReadStdin reads up to 1024 bytes of stdin to 1024 through the iovec at
+// 16, leaving the count at 8.
+var wasmReadStdin = wasmCode(wasmConst(0), wasmConst(16), wasmConst(1), wasmConst(8), wasmCall(wasmCallFdRead), []byte{0x1a})
+
+func writeWasmModule(t *testing.T, module []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "handler.wasm")
+	if err := os.WriteFile(path, module, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func wasmTestHandler(t *testing.T, wasm *wasmHandlerConfig) func(*types.Interaction) (*types.InteractionResponse, error) {
+	t.Helper()
+	binding, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "ping", Route: handlerRoute{Wasm: wasm}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &stubPublisher{}
+	handler := dispatchHandler(binding, 0, pub)
+	return func(i *types.Interaction) (*types.InteractionResponse, error) {
+		resp, err := handler(context.Background(), i)
+		if len(pub.envelopes) != 0 {
+			t.Fatalf("wasm route published %d envelopes", len(pub.envelopes))
+		}
+		return resp, err
+	}
+}
+
+func wasmTestInteraction() *types.Interaction {
+	return &types.Interaction{Type: types.InteractionTypeApplicationCommand, Token: "tok-42", Data: &types.InteractionData{Name: "ping"}}
+}
+
+func TestWasmHandlerAnswersInServer(t *testing.T) {
+	reply := []byte(`{"type":4,"data":{"content":"pong from wasm","flags":64}}`)
+	code := wasmCode(
+		wasmReadStdin,
+		// Trap unless the interaction arrived on stdin.
+		wasmConst(8), []byte{0x28, 0x02, 0x00, 0x45, 0x04, 0x40, 0x00, 0x0b},
+		wasmConst(1), wasmConst(24), wasmConst(1), wasmConst(12), wasmCall(wasmCallFdWrite), []byte{0x1a},
+	)
+	module := buildWasmModule(1, code,
+		wasmData{16, wasmIOVec(1024, 1024)},
+		wasmData{24, wasmIOVec(64, uint32(len(reply)))},
+		wasmData{64, reply},
+	)
+	handler := wasmTestHandler(t, &wasmHandlerConfig{Module: writeWasmModule(t, module)})
+
+	for range 2 {
+		resp, err := handler(wasmTestInteraction())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Type != types.InteractionResponseChannelMessageWithSource || resp.Data.Content != "pong from wasm" || resp.Data.Flags&types.MessageFlagEphemeral == 0 {
+			t.Fatalf("unexpected response %+v", resp.Data)
+		}
+	}
+}
+
+func TestWasmHandlerReportsStderrOnExit(t *testing.T) {
+	// Echo stdin to stderr, then exit 1.
+	code := wasmCode(
+		wasmReadStdin,
+		wasmConst(28), wasmConst(8), []byte{0x28, 0x02, 0x00}, []byte{0x36, 0x02, 0x00},
+		wasmConst(2), wasmConst(24), wasmConst(1), wasmConst(12), wasmCall(wasmCallFdWrite), []byte{0x1a},
+		wasmConst(1), wasmCall(wasmCallProcExit),
+	)
+	module := buildWasmModule(1, code, wasmData{16, wasmIOVec(1024, 1024)}, wasmData{24, wasmIOVec(1024, 0)})
+	handler := wasmTestHandler(t, &wasmHandlerConfig{Module: writeWasmModule(t, module)})
+
+	_, err := handler(wasmTestInteraction())
+	if err == nil || !strings.Contains(err.Error(), "exited 1") || !strings.Contains(err.Error(), `"token":"tok-42"`) {
+		t.Fatalf("expected the exit status and stderr, got %v", err)
+	}
+}
+
+func TestWasmHandlerLimits(t *testing.T) {
+	spin := buildWasmModule(1, []byte{0x03, 0x40, 0x0c, 0x00, 0x0b})
+	handler := wasmTestHandler(t, &wasmHandlerConfig{Module: writeWasmModule(t, spin), Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	if _, err := handler(wasmTestInteraction()); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("spinning module ran for %s", elapsed)
+	}
+
+	// Grow memory to 2MB and trap if that fails.
+	hog := buildWasmModule(1, wasmCode(wasmConst(31), []byte{0x40, 0x00}, wasmConst(-1), []byte{0x46, 0x04, 0x40, 0x00, 0x0b}))
+	path := writeWasmModule(t, hog)
+	if _, err := wasmTestHandler(t, &wasmHandlerConfig{Module: path, MemoryMB: 1})(wasmTestInteraction()); err == nil || !strings.Contains(err.Error(), "unreachable") {
+		t.Fatalf("expected growing past memory_mb to fail, got %v", err)
+	}
+	// With room to grow the module runs, but writes no response.
+	if _, err := wasmTestHandler(t, &wasmHandlerConfig{Module: path, MemoryMB: 4})(wasmTestInteraction()); err == nil || !strings.Contains(err.Error(), "wasm module response") {
+		t.Fatalf("expected a missing response error, got %v", err)
+	}
+
+	big := writeWasmModule(t, buildWasmModule(32, nil))
+	if _, _, err := prepareBinding(handlerBinding{Kind: handlerKindCommand, Key: "ping", Route: handlerRoute{Wasm: &wasmHandlerConfig{Module: big, MemoryMB: 1}}}); err == nil || !strings.Contains(err.Error(), "over limit") {
+		t.Fatalf("expected a module needing more than memory_mb to be rejected, got %v", err)
+	}
+}
+
+func TestPrepareBindingRejectsBadWasmRoutes(t *testing.T) {
+	module := writeWasmModule(t, buildWasmModule(1, nil))
+	cases := map[string]handlerBinding{
+		"not both":             {Kind: handlerKindCommand, Route: handlerRoute{Script: "'x'", Wasm: &wasmHandlerConfig{Module: module}}},
+		"take no agent":        {Kind: handlerKindCommand, Route: handlerRoute{Agent: "ops", Wasm: &wasmHandlerConfig{Module: module}}},
+		"cannot have a wasm":   {Kind: handlerKindAutocomplete, Route: handlerRoute{Wasm: &wasmHandlerConfig{Module: module}}},
+		"needs a module":       {Kind: handlerKindCommand, Route: handlerRoute{Wasm: &wasmHandlerConfig{}}},
+		"no such file":         {Kind: handlerKindCommand, Route: handlerRoute{Wasm: &wasmHandlerConfig{Module: filepath.Join(t.TempDir(), "missing.wasm")}}},
+		"must not be negative": {Kind: handlerKindCommand, Route: handlerRoute{Wasm: &wasmHandlerConfig{Module: module, Timeout: -time.Second}}},
+	}
+	for want, binding := range cases {
+		binding.Key = "ping"
+		if _, _, err := prepareBinding(binding); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected an error containing %q, got %v", want, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"mime"
 	"os"
 	"path/filepath"
@@ -30,6 +31,7 @@ func webhookCmd(opts *globalOptions) *cobra.Command {
 	cmd.AddCommand(webhookSendCmd(opts))
 	cmd.AddCommand(webhookListCmd(opts))
 	cmd.AddCommand(webhookThreadCmd(opts))
+	cmd.AddCommand(webhookMessageCmd(opts))
 
 	return cmd
 }
@@ -43,7 +45,10 @@ func webhookSendCmd(opts *globalOptions) *cobra.Command {
 		threadID         string
 		threadName       string
 		contentFlag      string
+		wait             bool
 		embedFiles       []string
+		embed            embedFlags
+		tmpl             templateFlags
 		componentFiles   []string
 		fileSpecs        []string
 		spoilerFileSpecs []string
@@ -71,7 +76,10 @@ Payload Structure (types.WebhookMessage):
     "components": [{...}],
     "thread_id": "123456...",
     "thread_name": "New Thread"
-  }`,
+  }
+
+With --wait the webhook is executed with ?wait=true and the created message ID and channel ID
+are included in the output, ready for "webhook message edit" or starting a thread.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			content := contentFlag
 			if len(args) > 0 {
@@ -83,15 +91,19 @@ Payload Structure (types.WebhookMessage):
 			return runWebhookSend(cmd, opts, webhookSendInput{
 				webhookName:      namedWebhook,
 				payloadPath:      payloadPath,
+				stdin:            cmd.InOrStdin(),
 				content:          content,
 				username:         username,
 				avatarURL:        avatarURL,
 				threadID:         threadID,
 				threadName:       threadName,
 				embedPaths:       embedFiles,
+				embed:            embed,
+				template:         tmpl,
 				componentPaths:   componentFiles,
 				fileSpecs:        fileSpecs,
 				spoilerFileSpecs: spoilerFileSpecs,
+				wait:             wait,
 				output:           opts.output,
 			})
 		},
@@ -107,6 +119,15 @@ Example:
   # Post a structured embed defined in JSON
   arc-discord webhook send --payload payload.json
 
+Example:
+  # Build a simple embed from flags
+  arc-discord webhook send --embed-title "Build failed" --embed-color 0xe74c3c \
+    --embed-field "branch=main:inline" --embed-footer "ci #812"
+
+Example:
+  # Render a JSON payload template from CI data (json quotes strings safely)
+  arc-discord webhook send --template --payload notify.json.tmpl --data release.json
+
 Example:
   # Override username/avatar for branded alerts
   arc-discord webhook send --content "Alert" --username "SecurityBot" --avatar "https://..."
@@ -117,20 +138,28 @@ Example:
 
 Example:
   # Attach artifacts or logs to the webhook message
-  arc-discord webhook send --payload msg.json --file "/path/to/file.log:build.log"`,
+  arc-discord webhook send --payload msg.json --file "/path/to/file.log:build.log"
+
+Example:
+  # Capture the message ID to edit the notice once the deploy finishes
+  MSG=$(arc-discord webhook send --wait --content "Deploying #42..." --output json | jq -r .message_id)
+  arc-discord webhook message edit --message-id $MSG --content "Deploy #42 finished"`,
 	}
 
 	cmd.Flags().StringVar(&namedWebhook, "webhook", "default", "Name of webhook entry from discord.yaml")
-	cmd.Flags().StringVar(&payloadPath, "payload", "", "Path to JSON file describing types.WebhookMessage")
+	cmd.Flags().StringVar(&payloadPath, "payload", "", "Path to JSON file describing types.WebhookMessage (- reads stdin)")
 	cmd.Flags().StringVar(&username, "username", "", "Override the webhook username")
 	cmd.Flags().StringVar(&avatarURL, "avatar", "", "Override the webhook avatar URL")
 	cmd.Flags().StringVar(&threadID, "thread-id", "", "Target a specific thread ID")
 	cmd.Flags().StringVar(&threadName, "thread-name", "", "Create a new thread with this name (forum channels only)")
 	cmd.Flags().StringVar(&contentFlag, "content", "", "Message content when not using positional arg")
 	cmd.Flags().StringArrayVar(&embedFiles, "embed-file", nil, "Load embed JSON definition from file (repeatable)")
+	embed.register(cmd)
+	tmpl.register(cmd)
 	cmd.Flags().StringArrayVar(&componentFiles, "component-file", nil, "Load message components JSON definition from file (repeatable)")
 	cmd.Flags().StringArrayVar(&fileSpecs, "file", nil, "Attach local file using path[:name]")
 	cmd.Flags().StringArrayVar(&spoilerFileSpecs, "spoiler-file", nil, "Attach local file marked as spoiler using path[:name]")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for Discord to confirm and report the created message ID and channel")
 
 	return cmd
 }
@@ -173,20 +202,24 @@ Example:
 type webhookSendInput struct {
 	webhookName      string
 	payloadPath      string
+	stdin            io.Reader // payload source when payloadPath is "-"
 	content          string
 	username         string
 	avatarURL        string
 	threadID         string
 	threadName       string
 	embedPaths       []string
+	embed            embedFlags
+	template         templateFlags
 	componentPaths   []string
 	fileSpecs        []string
 	spoilerFileSpecs []string
+	wait             bool
 	output           output.OutputOptions
 }
 
 func runWebhookSend(cmd *cobra.Command, opts *globalOptions, in webhookSendInput) error {
-	cfg, path, err := opts.loadConfig()
+	cfg, _, err := opts.loadConfig()
 	if err != nil {
 		return err
 	}
@@ -195,7 +228,15 @@ func runWebhookSend(cmd *cobra.Command, opts *globalOptions, in webhookSendInput
 		return &arcer.CLIError{Msg: err.Error(), Hint: "use --webhook-url or add entries under discord.webhooks"}
 	}
 
-	msg, err := buildWebhookMessage(in, path)
+	fmtr, err := opts.formatter(cfg)
+	if err != nil {
+		return err
+	}
+	tmpl, err := in.template.compile(cmd, fmtr)
+	if err != nil {
+		return err
+	}
+	msg, err := buildWebhookMessage(in, tmpl)
 	if err != nil {
 		return err
 	}
@@ -213,6 +254,7 @@ func runWebhookSend(cmd *cobra.Command, opts *globalOptions, in webhookSendInput
 		return err
 	}
 
+	var created *types.Message
 	if len(attachmentSpecs) > 0 {
 		files, cleanup, err := prepareAttachments(attachmentSpecs)
 		if err != nil {
@@ -220,11 +262,21 @@ func runWebhookSend(cmd *cobra.Command, opts *globalOptions, in webhookSendInput
 		}
 		defer cleanup()
 
-		if err := dispatcher.SendWithFiles(ctx, msg, files); err != nil {
+		if in.wait {
+			created, err = dispatcher.SendWithFilesAndWait(ctx, msg, files)
+		} else {
+			err = dispatcher.SendWithFiles(ctx, msg, files)
+		}
+		if err != nil {
 			return (&arcer.CLIError{Msg: "webhook send with files failed"}).WithCause(err)
 		}
 	} else {
-		if err := dispatcher.Send(ctx, msg); err != nil {
+		if in.wait {
+			created, err = dispatcher.SendAndWait(ctx, msg)
+		} else {
+			err = dispatcher.Send(ctx, msg)
+		}
+		if err != nil {
 			return (&arcer.CLIError{Msg: "webhook send failed"}).WithCause(err)
 		}
 	}
@@ -236,16 +288,27 @@ func runWebhookSend(cmd *cobra.Command, opts *globalOptions, in webhookSendInput
 		"thread_name": msg.ThreadName,
 		"status":      "sent",
 	}
+	if created != nil {
+		result["message_id"] = created.ID
+		result["channel_id"] = created.ChannelID
+	}
 
 	tbl := keyValueTable(result)
 	return renderOutput(cmd, in.output, result, tbl)
 }
 
-func buildWebhookMessage(in webhookSendInput, _ string) (*types.WebhookMessage, error) {
+func buildWebhookMessage(in webhookSendInput, tmpl *messageTemplate) (*types.WebhookMessage, error) {
+	embed, err := in.embed.build()
+	if err != nil {
+		return nil, err
+	}
 	if in.payloadPath != "" {
-		data, err := os.ReadFile(in.payloadPath)
+		data, err := readPayload(in.stdin, in.payloadPath)
 		if err != nil {
-			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read payload %s", in.payloadPath)}).WithCause(err)
+			return nil, err
+		}
+		if data, err = tmpl.RenderFile(in.payloadPath, data); err != nil {
+			return nil, err
 		}
 		var msg types.WebhookMessage
 		if err := json.Unmarshal(data, &msg); err != nil {
@@ -270,6 +333,9 @@ func buildWebhookMessage(in webhookSendInput, _ string) (*types.WebhookMessage,
 			}
 			msg.Embeds = append(msg.Embeds, embeds...)
 		}
+		if embed != nil {
+			msg.Embeds = append(msg.Embeds, *embed)
+		}
 		if len(in.componentPaths) > 0 {
 			comps, err := loadComponents(in.componentPaths)
 			if err != nil {
@@ -280,8 +346,11 @@ func buildWebhookMessage(in webhookSendInput, _ string) (*types.WebhookMessage,
 		return &msg, nil
 	}
 
-	if in.content == "" {
-		return nil, &arcer.CLIError{Msg: "provide message content via argument, --content, or --payload"}
+	if in.content, err = tmpl.Render("content", in.content); err != nil {
+		return nil, err
+	}
+	if in.content == "" && embed == nil {
+		return nil, &arcer.CLIError{Msg: "provide message content via argument, --content, --payload, or --embed-* flags"}
 	}
 
 	msg := &types.WebhookMessage{
@@ -298,6 +367,9 @@ func buildWebhookMessage(in webhookSendInput, _ string) (*types.WebhookMessage,
 		}
 		msg.Embeds = embeds
 	}
+	if embed != nil {
+		msg.Embeds = append(msg.Embeds, *embed)
+	}
 	if len(in.componentPaths) > 0 {
 		comps, err := loadComponents(in.componentPaths)
 		if err != nil {
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourorg/arc-discord/gosdk/discord/types"
+	"github.com/yourorg/arc-discord/gosdk/discord/webhook"
+
+	"github.com/yourorg/arc-sdk/output"
+	arcer "github.com/yourorg/arc-sdk/errors"
+)
+
+func webhookMessageCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "message",
+		Short: "Get, edit, or delete messages sent by a webhook",
+		Long: `Manage messages previously sent by a webhook using the webhook token message endpoints
+(/webhooks/{id}/{token}/messages/{message.id}). No bot token is required; only messages sent by
+the same webhook can be accessed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(webhookMessageGetCmd(opts))
+	cmd.AddCommand(webhookMessageEditCmd(opts))
+	cmd.AddCommand(webhookMessageDeleteCmd(opts))
+	return cmd
+}
+
+type webhookMessageInput struct {
+	webhookName string
+	messageID   string
+	threadID    string
+	output      output.OutputOptions
+}
+
+func (in *webhookMessageInput) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&in.webhookName, "webhook", "default", "Name of webhook entry from discord.yaml")
+	cmd.Flags().StringVar(&in.messageID, "message-id", "", "ID of the message sent by the webhook")
+	cmd.Flags().StringVar(&in.threadID, "thread-id", "", "Thread ID when the message lives in a thread")
+}
+
+func webhookMessageGetCmd(opts *globalOptions) *cobra.Command {
+	var in webhookMessageInput
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Fetch a message sent by the webhook",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if in.messageID == "" {
+				return &arcer.CLIError{Msg: "--message-id is required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			in.output = opts.output
+			return runWebhookMessageGet(cmd, opts, in)
+		},
+		Example: `Example:
+  # Inspect a previously sent deploy notice
+  arc-discord webhook message get --message-id 1427555325136867393
+
+Example:
+  # Fetch a message posted into a forum thread
+  arc-discord webhook message get --webhook releases --message-id $MSG --thread-id $THREAD --output json`,
+	}
+	in.bindFlags(cmd)
+	return cmd
+}
+
+func webhookMessageEditCmd(opts *globalOptions) *cobra.Command {
+	var (
+		in             webhookMessageInput
+		content        string
+		payloadPath    string
+		embedFiles     []string
+		componentFiles []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit a message sent by the webhook",
+		Long: `Edit a message previously sent by the webhook. --payload loads a JSON object with content,
+embeds, components, and allowed_mentions; --content, --embed-file, and --component-file override or
+extend it. Embeds and components replace the existing ones when provided.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if in.messageID == "" {
+				return &arcer.CLIError{Msg: "--message-id is required"}
+			}
+			params, err := buildWebhookEditParams(payloadPath, content, cmd.Flags().Changed("content"), embedFiles, componentFiles)
+			if err != nil {
+				return err
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			in.output = opts.output
+			return runWebhookMessageEdit(cmd, opts, in, params)
+		},
+		Example: `Example:
+  # Flip a deploy notice from "in progress" to "done"
+  arc-discord webhook message edit --message-id $MSG --content "✅ Deploy #42 finished"
+
+Example:
+  # Replace the embeds with a new status card
+  arc-discord webhook message edit --message-id $MSG --embed-file status.json
+
+Example:
+  # Apply a full edit payload to a message in a thread
+  arc-discord webhook message edit --message-id $MSG --thread-id $THREAD --payload edit.json`,
+	}
+	in.bindFlags(cmd)
+	cmd.Flags().StringVar(&content, "content", "", "New message content (empty string clears it)")
+	cmd.Flags().StringVar(&payloadPath, "payload", "", "Path to JSON file with content/embeds/components/allowed_mentions")
+	cmd.Flags().StringArrayVar(&embedFiles, "embed-file", nil, "Load embed JSON definition from file (repeatable)")
+	cmd.Flags().StringArrayVar(&componentFiles, "component-file", nil, "Load message components JSON definition from file (repeatable)")
+	return cmd
+}
+
+func webhookMessageDeleteCmd(opts *globalOptions) *cobra.Command {
+	var in webhookMessageInput
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a message sent by the webhook",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if in.messageID == "" {
+				return &arcer.CLIError{Msg: "--message-id is required"}
+			}
+			if err := opts.output.Resolve(); err != nil {
+				return err
+			}
+			in.output = opts.output
+			return runWebhookMessageDelete(cmd, opts, in)
+		},
+		Example: `Example:
+  # Remove a stale status message
+  arc-discord webhook message delete --message-id $MSG
+
+Example:
+  # Delete from a named webhook's thread
+  arc-discord webhook message delete --webhook alerts --message-id $MSG --thread-id $THREAD`,
+	}
+	in.bindFlags(cmd)
+	return cmd
+}
+
+func buildWebhookEditParams(payloadPath, content string, contentSet bool, embedPaths, componentPaths []string) (*webhook.MessageEditParams, error) {
+	params := &webhook.MessageEditParams{}
+	if payloadPath != "" {
+		data, err := os.ReadFile(payloadPath)
+		if err != nil {
+			return nil, (&arcer.CLIError{Msg: fmt.Sprintf("failed to read payload %s", payloadPath)}).WithCause(err)
+		}
+		if err := json.Unmarshal(data, params); err != nil {
+			return nil, (&arcer.CLIError{Msg: "payload must be valid JSON for a webhook message edit"}).WithCause(err)
+		}
+	}
+	if contentSet {
+		params.Content = &content
+	}
+	if len(embedPaths) > 0 {
+		embeds, err := loadEmbeds(embedPaths)
+		if err != nil {
+			return nil, err
+		}
+		params.Embeds = embeds
+	}
+	if len(componentPaths) > 0 {
+		comps, err := loadComponents(componentPaths)
+		if err != nil {
+			return nil, err
+		}
+		params.Components = comps
+	}
+	if params.Content == nil && params.Embeds == nil && params.Components == nil && params.AllowedMentions == nil {
+		return nil, &arcer.CLIError{Msg: "nothing to edit", Hint: "pass --content, --embed-file, --component-file, or --payload"}
+	}
+	return params, nil
+}
+
+func resolveWebhookMessageClient(opts *globalOptions, webhookName string) (webhookDispatcher, string, error) {
+	cfg, _, err := opts.loadConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	webhookURL, err := resolveWebhookURL(cfg, opts, webhookName)
+	if err != nil {
+		return nil, "", &arcer.CLIError{Msg: err.Error(), Hint: "use --webhook-url or add entries under discord.webhooks"}
+	}
+	dispatcher, err := newWebhookClientFn(cfg, webhookURL)
+	if err != nil {
+		return nil, "", (&arcer.CLIError{Msg: fmt.Sprintf("failed to create webhook client for %s", maskWebhookURL(webhookURL))}).WithCause(err)
+	}
+	return dispatcher, webhookURL, nil
+}
+
+func runWebhookMessageGet(cmd *cobra.Command, opts *globalOptions, in webhookMessageInput) error {
+	dispatcher, _, err := resolveWebhookMessageClient(opts, in.webhookName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	msg, err := dispatcher.GetInThread(ctx, in.messageID, in.threadID)
+	if err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to fetch webhook message %s", in.messageID)}).WithCause(err)
+	}
+	return renderOutput(cmd, in.output, msg, webhookMessageTable(msg, "fetched"))
+}
+
+func runWebhookMessageEdit(cmd *cobra.Command, opts *globalOptions, in webhookMessageInput, params *webhook.MessageEditParams) error {
+	dispatcher, _, err := resolveWebhookMessageClient(opts, in.webhookName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	params.ThreadID = in.threadID
+	msg, err := dispatcher.Edit(ctx, in.messageID, params)
+	if err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to edit webhook message %s", in.messageID)}).WithCause(err)
+	}
+	return renderOutput(cmd, in.output, msg, webhookMessageTable(msg, "edited"))
+}
+
+func runWebhookMessageDelete(cmd *cobra.Command, opts *globalOptions, in webhookMessageInput) error {
+	dispatcher, webhookURL, err := resolveWebhookMessageClient(opts, in.webhookName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := dispatcher.DeleteInThread(ctx, in.messageID, in.threadID); err != nil {
+		return (&arcer.CLIError{Msg: fmt.Sprintf("failed to delete webhook message %s", in.messageID)}).WithCause(err)
+	}
+	result := map[string]string{
+		"webhook":     in.webhookName,
+		"webhook_url": maskWebhookURL(webhookURL),
+		"message_id":  in.messageID,
+		"thread_id":   in.threadID,
+		"status":      "deleted",
+	}
+	return renderOutput(cmd, in.output, result, keyValueTable(result))
+}
+
+func webhookMessageTable(msg *types.Message, status string) *tableData {
+	data := map[string]string{
+		"message_id": msg.ID,
+		"channel_id": msg.ChannelID,
+		"content":    truncate(msg.Content, 80),
+		"embeds":     fmt.Sprintf("%d", len(msg.Embeds)),
+		"status":     status,
+	}
+	if msg.EditedTimestamp != nil {
+		data["edited"] = msg.EditedTimestamp.Format(time.RFC3339)
+	}
+	return keyValueTable(data)
+}
@@ -61,7 +61,7 @@ Example:
 
 	cmd.Flags().StringVar(&namedWebhook, "webhook", "default", "Webhook name from config")
 	cmd.Flags().StringVar(&threadName, "thread-name", "", "Name of the forum thread to create")
-	cmd.Flags().StringVar(&payloadPath, "payload", "", "Payload JSON for the first message")
+	cmd.Flags().StringVar(&payloadPath, "payload", "", "Payload JSON for the first message (- reads stdin)")
 	cmd.Flags().StringVar(&content, "content", "", "Message content if no payload is provided")
 	return cmd
 }
@@ -90,8 +90,9 @@ func runWebhookThreadCreate(cmd *cobra.Command, opts *globalOptions, input threa
 	msg, err := buildWebhookMessage(webhookSendInput{
 		content:     input.content,
 		payloadPath: input.payloadPath,
+		stdin:       cmd.InOrStdin(),
 		threadName:  input.threadName,
-	}, "")
+	}, nil)
 	if err != nil {
 		return err
 	}
@@ -138,7 +139,7 @@ Example:
   arc-discord webhook thread list --channel $CHANNEL --output json | jq '.[].name'`,
 	}
 
-	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID to inspect")
+	cmd.Flags().StringVar(&channelID, "channel", "", "Channel ID or #name to inspect")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Number of messages to inspect for thread metadata")
 	return cmd
 }
@@ -155,6 +156,10 @@ func runWebhookThreadList(cmd *cobra.Command, opts *globalOptions, channelID str
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
+	channelID, err = newNameResolver(bot, cfg).ChannelID(ctx, channelID, "")
+	if err != nil {
+		return err
+	}
 
 	params := &client.GetChannelMessagesParams{Limit: limit}
 	messages, err := bot.Channels().GetChannelMessages(ctx, channelID, params)